@@ -20,6 +20,7 @@ type Applier struct {
 	appRepo             shared.CFAppRepository
 	domainRepo          shared.CFDomainRepository
 	processRepo         shared.CFProcessRepository
+	sidecarRepo         shared.CFSidecarRepository
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
@@ -29,6 +30,7 @@ func NewApplier(
 	appRepo shared.CFAppRepository,
 	domainRepo shared.CFDomainRepository,
 	processRepo shared.CFProcessRepository,
+	sidecarRepo shared.CFSidecarRepository,
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
@@ -37,6 +39,7 @@ func NewApplier(
 		appRepo:             appRepo,
 		domainRepo:          domainRepo,
 		processRepo:         processRepo,
+		sidecarRepo:         sidecarRepo,
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
@@ -53,6 +56,10 @@ func (a *Applier) Apply(ctx context.Context, authInfo authorization.Info, spaceG
 		return err
 	}
 
+	if err := a.applySidecars(ctx, authInfo, appInfo, appState); err != nil {
+		return err
+	}
+
 	if err := a.applyRoutes(ctx, authInfo, appInfo, appState); err != nil {
 		return err
 	}
@@ -99,6 +106,28 @@ func (a *Applier) applyProcesses(
 	return nil
 }
 
+func (a *Applier) applySidecars(
+	ctx context.Context,
+	authInfo authorization.Info,
+	appInfo payloads.ManifestApplication,
+	appState AppState,
+) error {
+	for _, sidecarInfo := range appInfo.Sidecars {
+		if sidecar, ok := appState.Sidecars[sidecarInfo.Name]; ok {
+			if _, err := a.sidecarRepo.UpdateSidecar(ctx, authInfo, sidecarInfo.ToSidecarUpdateMessage(sidecar.GUID)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := a.sidecarRepo.CreateSidecar(ctx, authInfo, sidecarInfo.ToSidecarCreateMessage(appState.App.GUID, appState.App.SpaceGUID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	if appInfo.NoRoute {
 		return a.deleteAppDestinations(ctx, authInfo, appState.App.GUID, appState.Routes)
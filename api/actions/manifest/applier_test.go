@@ -23,6 +23,7 @@ var _ = Describe("Applier", func() {
 		appRepo             *fake.CFAppRepository
 		domainRepo          *fake.CFDomainRepository
 		processRepo         *fake.CFProcessRepository
+		sidecarRepo         *fake.CFSidecarRepository
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
@@ -38,10 +39,11 @@ var _ = Describe("Applier", func() {
 		appRepo = new(fake.CFAppRepository)
 		domainRepo = new(fake.CFDomainRepository)
 		processRepo = new(fake.CFProcessRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
-		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
+		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, sidecarRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
 		ctx = context.Background()
 		authInfo = authorization.Info{Token: "a-token"}
 		appInfo = payloads.ManifestApplication{
@@ -67,6 +69,7 @@ var _ = Describe("Applier", func() {
 		appState = manifest.AppState{
 			App:       repositories.AppRecord{},
 			Processes: map[string]repositories.ProcessRecord{},
+			Sidecars:  map[string]repositories.SidecarRecord{},
 			Routes:    map[string]repositories.RouteRecord{},
 		}
 	})
@@ -264,6 +267,87 @@ var _ = Describe("Applier", func() {
 		})
 	})
 
+	Describe("applying sidecars", func() {
+		BeforeEach(func() {
+			appState.App.GUID = "app-guid"
+			appState.App.SpaceGUID = "space-guid"
+			appInfo.Sidecars = []payloads.ManifestApplicationSidecar{
+				{
+					Name:         "agent",
+					Command:      "run-agent",
+					ProcessTypes: []string{"web", "worker"},
+					Memory:       tools.PtrTo("64M"),
+				},
+				{
+					Name:         "reloader",
+					Command:      "reload",
+					ProcessTypes: []string{"web"},
+				},
+			}
+		})
+
+		It("creates each sidecar", func() {
+			Expect(applierErr).NotTo(HaveOccurred())
+			Expect(sidecarRepo.UpdateSidecarCallCount()).To(Equal(0))
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(2))
+
+			_, _, createMsg := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     64,
+			}))
+
+			_, _, createMsg = sidecarRepo.CreateSidecarArgsForCall(1)
+			Expect(createMsg.Name).To(Equal("reloader"))
+			Expect(createMsg.MemoryMB).To(BeZero())
+		})
+
+		When("creating a sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("create-sidecar-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(applierErr).To(MatchError("create-sidecar-failed"))
+			})
+		})
+
+		When("a sidecar exists", func() {
+			BeforeEach(func() {
+				appState.Sidecars = map[string]repositories.SidecarRecord{
+					"reloader": {GUID: "sidecar-guid"},
+				}
+			})
+
+			It("updates that sidecar", func() {
+				Expect(applierErr).NotTo(HaveOccurred())
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+				Expect(sidecarRepo.UpdateSidecarCallCount()).To(Equal(1))
+
+				_, _, updateMsg := sidecarRepo.UpdateSidecarArgsForCall(0)
+				Expect(updateMsg).To(Equal(repositories.UpdateSidecarMessage{
+					GUID:         "sidecar-guid",
+					Command:      tools.PtrTo("reload"),
+					ProcessTypes: []string{"web"},
+				}))
+			})
+
+			When("updating the sidecar fails", func() {
+				BeforeEach(func() {
+					sidecarRepo.UpdateSidecarReturns(repositories.SidecarRecord{}, errors.New("sidecar-update-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(applierErr).To(MatchError("sidecar-update-error"))
+				})
+			})
+		})
+	})
+
 	Describe("applying routes", func() {
 		BeforeEach(func() {
 			appState.App.GUID = "app-guid"
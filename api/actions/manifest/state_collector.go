@@ -19,6 +19,7 @@ type StateCollector struct {
 	appRepo             shared.CFAppRepository
 	domainRepo          shared.CFDomainRepository
 	processRepo         shared.CFProcessRepository
+	sidecarRepo         shared.CFSidecarRepository
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
@@ -27,6 +28,7 @@ type StateCollector struct {
 type AppState struct {
 	App             repositories.AppRecord
 	Processes       map[string]repositories.ProcessRecord
+	Sidecars        map[string]repositories.SidecarRecord
 	Routes          map[string]repositories.RouteRecord
 	ServiceBindings map[string]repositories.ServiceBindingRecord
 }
@@ -35,6 +37,7 @@ func NewStateCollector(
 	appRepo shared.CFAppRepository,
 	domainRepo shared.CFDomainRepository,
 	processRepo shared.CFProcessRepository,
+	sidecarRepo shared.CFSidecarRepository,
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
@@ -43,6 +46,7 @@ func NewStateCollector(
 		appRepo:             appRepo,
 		domainRepo:          domainRepo,
 		processRepo:         processRepo,
+		sidecarRepo:         sidecarRepo,
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
@@ -71,6 +75,11 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		return AppState{}, err
 	}
 
+	existingSidecars, err := s.collectSidecars(ctx, authInfo, appRecord.GUID)
+	if err != nil {
+		return AppState{}, err
+	}
+
 	existingAppRoutes, err := s.collectRoutes(ctx, authInfo, appRecord.GUID, spaceGUID)
 	if err != nil {
 		return AppState{}, err
@@ -84,6 +93,7 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 	return AppState{
 		App:             appRecord,
 		Processes:       existingProcesses,
+		Sidecars:        existingSidecars,
 		Routes:          existingAppRoutes,
 		ServiceBindings: existingServiceBindings,
 	}, nil
@@ -106,6 +116,22 @@ func (s StateCollector) collectProcesses(ctx context.Context, authInfo authoriza
 	return existingProcesses, nil
 }
 
+func (s StateCollector) collectSidecars(ctx context.Context, authInfo authorization.Info, appGUID string) (map[string]repositories.SidecarRecord, error) {
+	existingSidecars := map[string]repositories.SidecarRecord{}
+	sidecars, err := s.sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+		AppGUIDs: []string{appGUID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sc := range sidecars {
+		existingSidecars[sc.Name] = sc
+	}
+
+	return existingSidecars, nil
+}
+
 func (s StateCollector) collectRoutes(ctx context.Context, authInfo authorization.Info, appGUID, spaceGUID string) (map[string]repositories.RouteRecord, error) {
 	existingAppRoutes := map[string]repositories.RouteRecord{}
 	routes, err := s.routeRepo.ListRoutesForApp(ctx, authInfo, appGUID, spaceGUID)
@@ -18,6 +18,7 @@ var _ = Describe("StateCollector", func() {
 		appRepo             *fake.CFAppRepository
 		domainRepo          *fake.CFDomainRepository
 		processRepo         *fake.CFProcessRepository
+		sidecarRepo         *fake.CFSidecarRepository
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
@@ -30,6 +31,7 @@ var _ = Describe("StateCollector", func() {
 		appRepo = new(fake.CFAppRepository)
 		domainRepo = new(fake.CFDomainRepository)
 		processRepo = new(fake.CFProcessRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
@@ -37,6 +39,7 @@ var _ = Describe("StateCollector", func() {
 			appRepo,
 			domainRepo,
 			processRepo,
+			sidecarRepo,
 			routeRepo,
 			serviceInstanceRepo,
 			serviceBindingRepo,
@@ -134,6 +137,45 @@ var _ = Describe("StateCollector", func() {
 		})
 	})
 
+	Describe("sidecars", func() {
+		BeforeEach(func() {
+			appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: "app-guid"}}, nil)
+		})
+
+		It("lists the sidecars of the app", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, listMsg := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(listMsg.AppGUIDs).To(ConsistOf("app-guid"))
+		})
+
+		When("there are existing sidecars", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+					{GUID: "agent-guid", Name: "agent"},
+					{GUID: "reloader-guid", Name: "reloader"},
+				}, nil)
+			})
+
+			It("constructs the sidecar map using sidecar name", func() {
+				Expect(collectStateErr).NotTo(HaveOccurred())
+				Expect(appState.Sidecars).To(Equal(map[string]repositories.SidecarRecord{
+					"agent":    {GUID: "agent-guid", Name: "agent"},
+					"reloader": {GUID: "reloader-guid", Name: "reloader"},
+				}))
+			})
+		})
+
+		When("list sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("list-sidecars-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("list-sidecars-error"))
+			})
+		})
+	})
+
 	Describe("routes", func() {
 		var routes []repositories.RouteRecord
 
@@ -17,6 +17,14 @@ type CFProcessRepository interface {
 	PatchProcess(context.Context, authorization.Info, repositories.PatchProcessMessage) (repositories.ProcessRecord, error)
 }
 
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+
+type CFSidecarRepository interface {
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	UpdateSidecar(context.Context, authorization.Info, repositories.UpdateSidecarMessage) (repositories.SidecarRecord, error)
+}
+
 //counterfeiter:generate -o fake -fake-name CFAppRepository . CFAppRepository
 
 type CFAppRepository interface {
@@ -14,12 +14,24 @@ type Info struct {
 
 type key int
 
-var infoKey key
+const (
+	infoKey key = iota
+	identityKey
+)
 
 func NewContext(ctx context.Context, info *Info) context.Context {
 	return context.WithValue(ctx, infoKey, info)
 }
 
+func NewIdentityContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey).(Identity)
+	return identity, ok
+}
+
 func InfoFromContext(ctx context.Context) (Info, bool) {
 	info, ok := ctx.Value(infoKey).(*Info)
 	if info == nil {
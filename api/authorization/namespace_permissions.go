@@ -3,6 +3,7 @@ package authorization
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -104,6 +105,32 @@ func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identi
 	return false, nil
 }
 
+func (o *NamespacePermissions) HasRoleIn(ctx context.Context, identity Identity, namespace string, clusterRoleNames ...string) (bool, error) {
+	var rolebindings rbacv1.RoleBindingList
+	err := o.privilegedClient.List(ctx, &rolebindings, client.InNamespace(namespace))
+	if err != nil {
+		return false, fmt.Errorf("failed to list rolebindings: %w", apierrors.FromK8sError(err, ""))
+	}
+
+	for _, roleBinding := range rolebindings.Items {
+		if roleBinding.RoleRef.Kind != "ClusterRole" || !slices.Contains(clusterRoleNames, roleBinding.RoleRef.Name) {
+			continue
+		}
+
+		for _, subject := range roleBinding.Subjects {
+			isMatch, err := SameSubject(subject, identity)
+			if err != nil {
+				return false, err
+			}
+			if isMatch {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func SameSubject(subject rbacv1.Subject, identity Identity) (bool, error) {
 	if identity.Kind != subject.Kind {
 		return false, nil
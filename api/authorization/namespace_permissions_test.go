@@ -406,6 +406,42 @@ var _ = Describe("Namespace Permissions", func() {
 			})
 		})
 	})
+
+	Describe("Has Role In", func() {
+		BeforeEach(func() {
+			org1NS = createNamespace("org1", map[string]string{korifiv1alpha1.OrgNameKey: "org1"})
+			createRoleBindingForUser(userName, roleName1, org1NS)
+			createRoleBindingForServiceAccount(serviceAccountName, serviceAccountNS, roleName2, org1NS)
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: org1NS}})).To(Succeed())
+		})
+
+		When("the user is bound to one of the roles in the namespace", func() {
+			It("returns true", func() {
+				hasRole, err := nsPerms.HasRoleIn(ctx, userIdentity, org1NS, "some-role", roleName1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasRole).To(BeTrue())
+			})
+		})
+
+		When("the user is bound to another role in the namespace", func() {
+			It("returns false", func() {
+				hasRole, err := nsPerms.HasRoleIn(ctx, userIdentity, org1NS, roleName2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasRole).To(BeFalse())
+			})
+		})
+
+		When("the service account is bound to one of the roles in the namespace", func() {
+			It("returns true", func() {
+				hasRole, err := nsPerms.HasRoleIn(ctx, serviceAccountIdentity, org1NS, roleName2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasRole).To(BeTrue())
+			})
+		})
+	})
 })
 
 func generateGUID(prefix string) string {
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 
 	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/rest"
 )
 
@@ -41,6 +42,10 @@ type (
 		DefaultDomainName                        string                 `yaml:"defaultDomainName"`
 		UserCertificateExpirationWarningDuration string                 `yaml:"userCertificateExpirationWarningDuration"`
 		DefaultLifecycleConfig                   DefaultLifecycleConfig `yaml:"defaultLifecycleConfig"`
+		RouterGroups                             []RouterGroup          `yaml:"routerGroups"`
+
+		// The registries, as host[:port], droplet images may be imported from
+		DropletImageRegistries []string `yaml:"dropletImageRegistries"`
 
 		RoleMappings map[string]Role `yaml:"roleMappings"`
 
@@ -52,11 +57,13 @@ type (
 	}
 
 	Experimental struct {
-		ManagedServices  ManagedServices `yaml:"managedServices"`
-		UAA              UAA             `yaml:"uaa"`
-		ExternalLogCache ExtenalLogCache `yaml:"externalLogCache"`
-		K8SClient        K8SClientConfig `yaml:"k8sClient"`
-		SecurityGroups   SecurityGroups  `yaml:"securityGroups"`
+		ManagedServices  ManagedServices  `yaml:"managedServices"`
+		UAA              UAA              `yaml:"uaa"`
+		ExternalLogCache ExtenalLogCache  `yaml:"externalLogCache"`
+		K8SClient        K8SClientConfig  `yaml:"k8sClient"`
+		SecurityGroups   SecurityGroups   `yaml:"securityGroups"`
+		SSH              SSH              `yaml:"ssh"`
+		ResourceMatching ResourceMatching `yaml:"resourceMatching"`
 	}
 
 	ManagedServices struct {
@@ -83,6 +90,24 @@ type (
 		Enabled bool `yaml:"enabled"`
 	}
 
+	SSH struct {
+		Enabled bool `yaml:"enabled"`
+		// The port the SSH proxy listens on
+		Port int `yaml:"port"`
+		// The host:port clients use to reach the SSH proxy, advertised as the app_ssh link
+		ExternalAddress string `yaml:"externalAddress"`
+		HostKeyPath     string `yaml:"hostKeyPath"`
+	}
+
+	ResourceMatching struct {
+		Enabled bool `yaml:"enabled"`
+		// The directory the cached application files are stored in
+		CacheDir string `yaml:"cacheDir"`
+		// The size the cached application files are kept under, as a
+		// Kubernetes quantity, e.g. 4Gi
+		MaxSize string `yaml:"maxSize"`
+	}
+
 	RoleLevel string
 
 	Role struct {
@@ -98,6 +123,12 @@ type (
 		StagingMemoryMB int    `yaml:"stagingMemoryMB"`
 	}
 
+	// RouterGroup describes a group of ports TCP routes can be reserved on
+	RouterGroup struct {
+		Name            string  `yaml:"name"`
+		ReservablePorts []int32 `yaml:"reservablePorts"`
+	}
+
 	InfoConfig struct {
 		Description           string                 `yaml:"description"`
 		Name                  string                 `yaml:"name"`
@@ -160,6 +191,26 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.Experimental.SSH.Enabled {
+		if c.Experimental.SSH.ExternalAddress == "" {
+			return errors.New("SSH requires a value for ExternalAddress")
+		}
+
+		if c.Experimental.SSH.HostKeyPath == "" {
+			return errors.New("SSH requires a value for HostKeyPath")
+		}
+	}
+
+	if c.Experimental.ResourceMatching.Enabled {
+		if c.Experimental.ResourceMatching.CacheDir == "" {
+			return errors.New("ResourceMatching requires a value for CacheDir")
+		}
+
+		if _, err := resource.ParseQuantity(c.Experimental.ResourceMatching.MaxSize); err != nil {
+			return fmt.Errorf("ResourceMatching has an invalid MaxSize: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -213,6 +213,86 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	When("ssh is enabled", func() {
+		BeforeEach(func() {
+			configMap["experimental"].(map[string]any)["ssh"] = map[string]any{
+				"enabled":         true,
+				"port":            2222,
+				"externalAddress": "ssh.foo:2222",
+				"hostKeyPath":     "/etc/korifi-ssh/host_key",
+			}
+		})
+
+		It("populates the ssh config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.SSH).To(Equal(config.SSH{
+				Enabled:         true,
+				Port:            2222,
+				ExternalAddress: "ssh.foo:2222",
+				HostKeyPath:     "/etc/korifi-ssh/host_key",
+			}))
+		})
+
+		When("the external address is not set", func() {
+			BeforeEach(func() {
+				delete(configMap["experimental"].(map[string]any)["ssh"].(map[string]any), "externalAddress")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSH requires a value for ExternalAddress"))
+			})
+		})
+
+		When("the host key path is not set", func() {
+			BeforeEach(func() {
+				delete(configMap["experimental"].(map[string]any)["ssh"].(map[string]any), "hostKeyPath")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSH requires a value for HostKeyPath"))
+			})
+		})
+	})
+
+	When("resource matching is enabled", func() {
+		BeforeEach(func() {
+			configMap["experimental"].(map[string]any)["resourceMatching"] = map[string]any{
+				"enabled":  true,
+				"cacheDir": "/var/korifi-resource-cache",
+				"maxSize":  "4Gi",
+			}
+		})
+
+		It("populates the resource matching config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.ResourceMatching).To(Equal(config.ResourceMatching{
+				Enabled:  true,
+				CacheDir: "/var/korifi-resource-cache",
+				MaxSize:  "4Gi",
+			}))
+		})
+
+		When("the cache dir is not set", func() {
+			BeforeEach(func() {
+				delete(configMap["experimental"].(map[string]any)["resourceMatching"].(map[string]any), "cacheDir")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("ResourceMatching requires a value for CacheDir"))
+			})
+		})
+
+		When("the max size is not a quantity", func() {
+			BeforeEach(func() {
+				configMap["experimental"].(map[string]any)["resourceMatching"].(map[string]any)["maxSize"] = "lots"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("ResourceMatching has an invalid MaxSize")))
+			})
+		})
+	})
+
 	When("external port is specified", func() {
 		BeforeEach(func() {
 			configMap["externalPort"] = 1234
@@ -354,6 +354,24 @@ func NewResourceNotReadyError(cause error) ResourceNotReadyError {
 	}
 }
 
+type ServiceUnavailableError struct {
+	apiError
+}
+
+// NewServiceUnavailableError is returned when a request cannot be served for
+// now but is expected to succeed when retried
+func NewServiceUnavailableError(cause error, detail string) ServiceUnavailableError {
+	return ServiceUnavailableError{
+		apiError: apiError{
+			cause:      cause,
+			title:      "CF-ServiceUnavailable",
+			detail:     detail,
+			code:       10015,
+			httpStatus: http.StatusServiceUnavailable,
+		},
+	}
+}
+
 type RollingDeployNotSupportedError struct {
 	apiError
 }
@@ -370,8 +388,41 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type QuotaExceededError struct {
+	apiError
+}
+
+// quotaExceededErrorCodes maps the quota validation errors returned by the
+// webhooks to the CF error codes with the same title
+var quotaExceededErrorCodes = map[string]int{
+	validation.OrgMemoryQuotaExceededErrorType:           100005,
+	validation.OrgInstanceMemoryQuotaExceededErrorType:   100007,
+	validation.OrgInstanceQuotaExceededErrorType:         100008,
+	validation.SpaceMemoryQuotaExceededErrorType:         310003,
+	validation.SpaceInstanceMemoryQuotaExceededErrorType: 310004,
+	validation.SpaceInstanceQuotaExceededErrorType:       310008,
+	validation.OrgServiceQuotaExceededErrorType:          60005,
+	validation.SpaceServiceQuotaExceededErrorType:        60012,
+}
+
+func NewQuotaExceededError(cause error, errorType string, detail string) QuotaExceededError {
+	return QuotaExceededError{
+		apiError: apiError{
+			cause:      cause,
+			title:      "CF-" + errorType,
+			detail:     detail,
+			code:       quotaExceededErrorCodes[errorType],
+			httpStatus: http.StatusBadRequest,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if _, isQuotaError := quotaExceededErrorCodes[webhookValidationError.Type]; isQuotaError {
+			return NewQuotaExceededError(err, webhookValidationError.Type, webhookValidationError.GetMessage())
+		}
+
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
 	}
 
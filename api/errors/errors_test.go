@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -62,6 +64,34 @@ var _ = Describe("FromK8sError", func() {
 		})
 	})
 
+	When("webhook validation error", func() {
+		BeforeEach(func() {
+			err = validation.ValidationError{Type: "SomeError", Message: "something is wrong"}.ExportJSONError()
+		})
+
+		It("translates it to an unprocessable entity api error", func() {
+			Expect(actualErr).To(Equal(apierrors.NewUnprocessableEntityError(err, "something is wrong")))
+		})
+	})
+
+	When("webhook quota validation error", func() {
+		BeforeEach(func() {
+			err = validation.ValidationError{
+				Type:    validation.SpaceMemoryQuotaExceededErrorType,
+				Message: validation.SpaceMemoryQuotaExceededErrorMessage,
+			}.ExportJSONError()
+		})
+
+		It("translates it to the matching CF quota error", func() {
+			var quotaErr apierrors.QuotaExceededError
+			Expect(errors.As(actualErr, &quotaErr)).To(BeTrue())
+			Expect(quotaErr.Title()).To(Equal("CF-SpaceQuotaMemoryLimitExceeded"))
+			Expect(quotaErr.Code()).To(Equal(310003))
+			Expect(quotaErr.Detail()).To(Equal(validation.SpaceMemoryQuotaExceededErrorMessage))
+			Expect(quotaErr.HttpStatus()).To(Equal(http.StatusBadRequest))
+		})
+	})
+
 	When("unknown error", func() {
 		BeforeEach(func() {
 			err = errors.New("bar")
@@ -78,6 +78,7 @@ type App struct {
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
+	sshEnabled              bool
 }
 
 func NewApp(
@@ -93,6 +94,7 @@ func NewApp(
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
+	sshEnabled bool,
 ) *App {
 	return &App{
 		serverURL:               serverURL,
@@ -107,6 +109,7 @@ func NewApp(
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
+		sshEnabled:              sshEnabled,
 	}
 }
 
@@ -660,32 +663,102 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 }
 
 func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-ssh-enabled")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	if !h.sshEnabled {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled globally",
+		}), nil
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, app.SpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space from Kubernetes", "SpaceGUID", app.SpaceGUID)
+	}
+
+	if !space.AllowSSH {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  fmt.Sprintf("Disabled for space %s", space.Name),
+		}), nil
+	}
+
+	if !app.EnableSSH {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled for app",
+		}), nil
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
-		Enabled: false,
-		Reason:  "Disabled globally",
+		Enabled: true,
 	}), nil
 }
 
 func (h *App) getAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-feature")
+	appGUID := routing.URLParam(r, "guid")
 	featureName := routing.URLParam(r, "name")
+
 	switch featureName {
 	case "ssh":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "ssh",
-			"description": "Enable SSHing into the app.",
-			"enabled":     false,
-		}), nil
+		app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+		}
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppSSHFeature(app)), nil
 	case "revisions":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "revisions",
-			"description": "Enable versioning of an application",
-			"enabled":     false,
-		}), nil
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppRevisionsFeature()), nil
 	default:
 		return nil, apierrors.NewNotFoundError(nil, "Feature")
 	}
 }
 
+func (h *App) updateAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.update-feature")
+	appGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	if featureName == "revisions" {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "The revisions feature cannot be changed"), "unsupported feature", "AppGUID", appGUID)
+	}
+
+	if featureName != "ssh" {
+		return nil, apierrors.NewNotFoundError(nil, "Feature")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	var payload payloads.FeaturePatch
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err = h.appRepo.PatchApp(r.Context(), authInfo, repositories.PatchAppMessage{
+		AppGUID:   appGUID,
+		SpaceGUID: app.SpaceGUID,
+		EnableSSH: payload.Enabled,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppSSHFeature(app)), nil
+}
+
 func (h *App) restartInstance(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.restart-instance")
@@ -758,6 +831,7 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppEnvPath, Handler: h.getEnvironment},
 		{Method: "GET", Pattern: AppPackagesPath, Handler: h.getPackages},
 		{Method: "GET", Pattern: AppFeaturePath, Handler: h.getAppFeature},
+		{Method: "PATCH", Pattern: AppFeaturePath, Handler: h.updateAppFeature},
 		{Method: "PATCH", Pattern: AppPath, Handler: h.update},
 		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
 		{Method: "DELETE", Pattern: AppInstanceRestartPath, Handler: h.restartInstance},
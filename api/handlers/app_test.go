@@ -42,6 +42,7 @@ var _ = Describe("App", func() {
 		requestValidator        *fake.RequestValidator
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
+		sshEnabled              bool
 		req                     *http.Request
 
 		appRecord repositories.AppRecord
@@ -59,21 +60,7 @@ var _ = Describe("App", func() {
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
-
-		apiHandler := NewApp(
-			*serverURL,
-			appRepo,
-			dropletRepo,
-			processRepo,
-			routeRepo,
-			domainRepo,
-			spaceRepo,
-			packageRepo,
-			requestValidator,
-			podRepo,
-			gaugesCollector,
-			instancesStateCollector,
-		)
+		sshEnabled = true
 
 		appRecord = repositories.AppRecord{
 			GUID:        appGUID,
@@ -97,10 +84,25 @@ var _ = Describe("App", func() {
 			},
 		}
 		appRepo.GetAppReturns(appRecord, nil)
-		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
+		apiHandler := NewApp(
+			*serverURL,
+			appRepo,
+			dropletRepo,
+			processRepo,
+			routeRepo,
+			domainRepo,
+			spaceRepo,
+			packageRepo,
+			requestValidator,
+			podRepo,
+			gaugesCollector,
+			instancesStateCollector,
+			sshEnabled,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -1788,35 +1790,132 @@ var _ = Describe("App", func() {
 
 	Describe("GET /v3/apps/GUID/ssh_enabled", func() {
 		BeforeEach(func() {
+			appRecord.EnableSSH = true
+			appRepo.GetAppReturns(appRecord, nil)
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				Name:     "the-space",
+				GUID:     spaceGUID,
+				AllowSSH: true,
+			}, nil)
 			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/ssh_enabled", nil)
 		})
 
-		It("returns false", func() {
+		It("returns true", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.enabled", BeFalse()),
-				MatchJSONPath("$.reason", Equal("Disabled globally")),
+				MatchJSONPath("$.enabled", BeTrue()),
+				MatchJSONPath("$.reason", BeEmpty()),
 			)))
 		})
+
+		When("ssh is disabled globally", func() {
+			BeforeEach(func() {
+				sshEnabled = false
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled globally")),
+				)))
+			})
+		})
+
+		When("ssh is not allowed in the space", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+					Name:     "the-space",
+					GUID:     spaceGUID,
+					AllowSSH: false,
+				}, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for space the-space")),
+				)))
+			})
+		})
+
+		When("ssh is disabled for the app", func() {
+			BeforeEach(func() {
+				appRecord.EnableSSH = false
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for app")),
+				)))
+			})
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("getting the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 
 	Describe("GET /v3/apps/GUID/features", func() {
 		When("feature ssh is called", func() {
 			BeforeEach(func() {
+				appRecord.EnableSSH = true
+				appRepo.GetAppReturns(appRecord, nil)
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/ssh", nil)
 			})
 
-			It("returns ssh enabled false", func() {
+			It("returns the app ssh setting", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("ssh")),
 					MatchJSONPath("$.description", Equal("Enable SSHing into the app.")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
 				)))
 			})
+
+			When("the app cannot be found", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+				})
+
+				It("returns a not found error", func() {
+					expectNotFoundError(repositories.AppResourceType)
+				})
+			})
 		})
+
 		When("feature revisions is called", func() {
 			BeforeEach(func() {
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/revisions", nil)
@@ -1850,6 +1949,92 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe("PATCH /v3/apps/GUID/features", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeaturePatch{
+				Enabled: tools.PtrTo(false),
+			})
+			appRepo.PatchAppReturns(repositories.AppRecord{GUID: appGUID, EnableSSH: false}, nil)
+			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/ssh", strings.NewReader("the-json-body"))
+		})
+
+		It("updates the app ssh setting", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, msg := appRepo.PatchAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(msg).To(Equal(repositories.PatchAppMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				EnableSSH: tools.PtrTo(false),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", Equal("ssh")),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the revisions feature is updated", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/revisions", strings.NewReader("the-json-body"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The revisions feature cannot be changed")
+				Expect(appRepo.PatchAppCallCount()).To(Equal(0))
+			})
+		})
+
+		When("an unknown feature is updated", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/anything-else", strings.NewReader("the-json-body"))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature")
+				Expect(appRepo.PatchAppCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+				Expect(appRepo.PatchAppCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(appRepo.PatchAppCallCount()).To(Equal(0))
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("DELETE /v3/apps/:guid/processes/:process/instances/:instance", func() {
 		BeforeEach(func() {
 			processRepo.ListProcessesReturns([]repositories.ProcessRecord{
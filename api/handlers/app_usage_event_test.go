@@ -0,0 +1,191 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEvent", func() {
+	var (
+		requestMethod     string
+		requestPath       string
+		appUsageEventRepo *fake.CFAppUsageEventRepository
+		requestValidator  *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		appUsageEventRepo = new(fake.CFAppUsageEventRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewAppUsageEvent(
+			*serverURL,
+			appUsageEventRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/app_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/app_usage_events/event-guid"
+
+			appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{
+				GUID:          "event-guid",
+				State:         "STARTED",
+				PreviousState: "STOPPED",
+				App:           repositories.UsageEventResource{GUID: "app-guid"},
+			}, nil)
+		})
+
+		It("returns the app usage event", func() {
+			Expect(appUsageEventRepo.GetAppUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := appUsageEventRepo.GetAppUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.state.current", "STARTED"),
+				MatchJSONPath("$.state.previous", "STOPPED"),
+				MatchJSONPath("$.app.guid", "app-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/app_usage_events/event-guid"),
+			)))
+		})
+
+		When("the app usage event is not accessible", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppUsageEventResourceType)
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/app_usage_events", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/app_usage_events"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppUsageEventList{
+				GUIDs:     "event-1,event-2",
+				AfterGUID: "event-0",
+				OrderBy:   "-created_at",
+			})
+
+			appUsageEventRepo.ListAppUsageEventsReturns([]repositories.AppUsageEventRecord{
+				{GUID: "event-1"},
+				{GUID: "event-2"},
+			}, nil)
+		})
+
+		It("lists the app usage events", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+
+			Expect(appUsageEventRepo.ListAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := appUsageEventRepo.ListAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage).To(Equal(repositories.ListAppUsageEventsMessage{
+				GUIDs:     []string{"event-1", "event-2"},
+				AfterGUID: "event-0",
+				OrderBy:   "-created_at",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/app_usage_events"),
+				MatchJSONPath("$.resources[0].guid", "event-1"),
+				MatchJSONPath("$.resources[1].guid", "event-2"),
+			)))
+		})
+
+		When("the query parameters are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.ListAppUsageEventsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/app_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/app_usage_events/actions/destructively_purge_all_and_reseed"
+		})
+
+		It("purges and reseeds the app usage events", func() {
+			Expect(appUsageEventRepo.PurgeAndReseedAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := appUsageEventRepo.PurgeAndReseedAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.PurgeAndReseedAppUsageEventsReturns(apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.PurgeAndReseedAppUsageEventsReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
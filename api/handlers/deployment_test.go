@@ -12,8 +12,10 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Deployment", func() {
@@ -127,9 +129,79 @@ var _ = Describe("Deployment", func() {
 			Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
 				AppGUID:     appGUID,
 				DropletGUID: dropletGUID,
+				Strategy:    "rolling",
 			}))
 		})
 
+		When("the payload specifies a revision", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DeploymentCreate{
+					Revision: &payloads.RevisionGUID{
+						Guid: "revision-guid",
+					},
+					Relationships: &payloads.DeploymentRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: appGUID,
+							},
+						},
+					},
+				})
+			})
+
+			It("rolls back to the revision", func() {
+				Expect(deploymentsRepo.CreateDeploymentCallCount()).To(Equal(1))
+				_, _, createMessage := deploymentsRepo.CreateDeploymentArgsForCall(0)
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:      appGUID,
+					RevisionGUID: "revision-guid",
+					Strategy:     "rolling",
+				}))
+			})
+		})
+
+		When("the payload specifies a max_in_flight greater than 1", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DeploymentCreate{
+					Relationships: &payloads.DeploymentRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: appGUID,
+							},
+						},
+					},
+					Options: &payloads.DeploymentOptions{
+						MaxInFlight: tools.PtrTo[int32](3),
+					},
+				})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(deploymentsRepo.CreateDeploymentCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Runner 'statefulset-runner' does not support max_in_flight greater than 1")
+			})
+
+			When("the runner supports max in flight", func() {
+				BeforeEach(func() {
+					runnerInfoRepo.GetRunnerInfoReturns(repositories.RunnerInfoRecord{
+						Name:       "statefulset-runner",
+						Namespace:  "korifi",
+						RunnerName: "statefulset-runner",
+						Capabilities: v1alpha1.RunnerInfoCapabilities{
+							RollingDeploy: true,
+							MaxInFlight:   true,
+						},
+					}, nil)
+				})
+
+				It("creates the deployment", func() {
+					Expect(deploymentsRepo.CreateDeploymentCallCount()).To(Equal(1))
+					_, _, createMessage := deploymentsRepo.CreateDeploymentArgsForCall(0)
+					Expect(createMessage.MaxInFlight).To(PointTo(BeEquivalentTo(3)))
+				})
+			})
+		})
+
 		When("the request payload is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -214,6 +286,134 @@ var _ = Describe("Deployment", func() {
 		})
 	})
 
+	Describe("POST /v3/deployments/{guid}/actions/continue", func() {
+		BeforeEach(func() {
+			deploymentsRepo.ContinueDeploymentReturns(repositories.DeploymentRecord{
+				GUID:        appGUID,
+				DropletGUID: dropletGUID,
+				Strategy:    "canary",
+				Status: repositories.DeploymentStatus{
+					Value:  "deployment-status-value",
+					Reason: "deployment-status-reason",
+				},
+			}, nil)
+			req = createHttpRequest("POST", "/v3/deployments/"+appGUID+"/actions/continue", nil)
+		})
+
+		It("returns a HTTP 200 OK response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", appGUID),
+				MatchJSONPath("$.strategy", "canary"),
+				MatchJSONPath("$.status.value", "deployment-status-value"),
+				MatchJSONPath("$.status.reason", "deployment-status-reason"),
+			)))
+		})
+
+		It("continues the deployment with the repository", func() {
+			Expect(deploymentsRepo.ContinueDeploymentCallCount()).To(Equal(1))
+			_, actualAuthInfo, deploymentGUID := deploymentsRepo.ContinueDeploymentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deploymentGUID).To(Equal(appGUID))
+		})
+
+		When("getting the deployment is forbidden", func() {
+			BeforeEach(func() {
+				deploymentsRepo.GetDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DeploymentResourceType)
+			})
+		})
+
+		When("the deployment cannot be continued", func() {
+			BeforeEach(func() {
+				deploymentsRepo.ContinueDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("continuing the deployment fails", func() {
+			BeforeEach(func() {
+				deploymentsRepo.ContinueDeploymentReturns(repositories.DeploymentRecord{}, errors.New("continue-deployment-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/deployments/{guid}/actions/cancel", func() {
+		BeforeEach(func() {
+			deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{
+				GUID:        appGUID,
+				DropletGUID: dropletGUID,
+				Strategy:    "canary",
+				Status: repositories.DeploymentStatus{
+					Value:  "deployment-status-value",
+					Reason: "deployment-status-reason",
+				},
+			}, nil)
+			req = createHttpRequest("POST", "/v3/deployments/"+appGUID+"/actions/cancel", nil)
+		})
+
+		It("returns a HTTP 200 OK response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", appGUID),
+				MatchJSONPath("$.strategy", "canary"),
+				MatchJSONPath("$.status.value", "deployment-status-value"),
+				MatchJSONPath("$.status.reason", "deployment-status-reason"),
+			)))
+		})
+
+		It("cancels the deployment with the repository", func() {
+			Expect(deploymentsRepo.CancelDeploymentCallCount()).To(Equal(1))
+			_, actualAuthInfo, deploymentGUID := deploymentsRepo.CancelDeploymentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deploymentGUID).To(Equal(appGUID))
+		})
+
+		When("getting the deployment is forbidden", func() {
+			BeforeEach(func() {
+				deploymentsRepo.GetDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DeploymentResourceType)
+			})
+		})
+
+		When("the deployment cannot be canceled", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("canceling the deployment fails", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, errors.New("cancel-deployment-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("GET /v3/deployments", func() {
 		var deploymentRecord repositories.DeploymentRecord
 
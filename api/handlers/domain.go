@@ -35,17 +35,20 @@ type Domain struct {
 	serverURL        url.URL
 	requestValidator RequestValidator
 	domainRepo       CFDomainRepository
+	routerGroupRepo  CFRouterGroupRepository
 }
 
 func NewDomain(
 	serverURL url.URL,
 	requestValidator RequestValidator,
 	domainRepo CFDomainRepository,
+	routerGroupRepo CFRouterGroupRepository,
 ) *Domain {
 	return &Domain{
 		serverURL:        serverURL,
 		requestValidator: requestValidator,
 		domainRepo:       domainRepo,
+		routerGroupRepo:  routerGroupRepo,
 	}
 }
 
@@ -64,6 +67,18 @@ func (h *Domain) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
 	}
 
+	if domainCreateMessage.RouterGroup != "" {
+		_, err = h.routerGroupRepo.GetRouterGroup(r.Context(), authInfo, domainCreateMessage.RouterGroup)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, "Router group not found. Ensure that the router group exists.", apierrors.NotFoundError{}),
+				"Failed to get router group",
+				"guid", domainCreateMessage.RouterGroup,
+			)
+		}
+	}
+
 	domain, err := h.domainRepo.CreateDomain(r.Context(), authInfo, domainCreateMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating domain in repository")
@@ -21,6 +21,7 @@ var _ = Describe("Domain", func() {
 	var (
 		apiHandler       *handlers.Domain
 		domainRepo       *fake.CFDomainRepository
+		routerGroupRepo  *fake.CFRouterGroupRepository
 		requestValidator *fake.RequestValidator
 		req              *http.Request
 	)
@@ -28,10 +29,12 @@ var _ = Describe("Domain", func() {
 	BeforeEach(func() {
 		requestValidator = new(fake.RequestValidator)
 		domainRepo = new(fake.CFDomainRepository)
+		routerGroupRepo = new(fake.CFRouterGroupRepository)
 		apiHandler = handlers.NewDomain(
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			routerGroupRepo,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -106,6 +109,41 @@ var _ = Describe("Domain", func() {
 			})
 		})
 
+		It("does not look up router groups", func() {
+			Expect(routerGroupRepo.GetRouterGroupCallCount()).To(BeZero())
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				payload.RouterGroup = &payloads.DomainRouterGroup{GUID: "default-tcp"}
+				routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{GUID: "default-tcp"}, nil)
+			})
+
+			It("creates a domain with the router group", func() {
+				Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+				_, _, actualGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+				Expect(actualGUID).To(Equal("default-tcp"))
+
+				Expect(domainRepo.CreateDomainCallCount()).To(Equal(1))
+				_, _, createMessage := domainRepo.CreateDomainArgsForCall(0)
+				Expect(createMessage.RouterGroup).To(Equal("default-tcp"))
+			})
+
+			When("the router group does not exist", func() {
+				BeforeEach(func() {
+					routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Router group not found. Ensure that the router group exists.")
+				})
+
+				It("does not create the domain", func() {
+					Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		When("the decoded payload is not valid", func() {
 			BeforeEach(func() {
 				payload.Internal = true
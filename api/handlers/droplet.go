@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -17,31 +19,49 @@ import (
 )
 
 const (
-	DropletPath = "/v3/droplets/{guid}"
+	DropletPath         = "/v3/droplets/{guid}"
+	DropletsPath        = "/v3/droplets"
+	DropletUploadPath   = "/v3/droplets/{guid}/upload"
+	DropletDownloadPath = "/v3/droplets/{guid}/download"
 )
 
+// uploadFormMaxMemory is how much of an upload form is kept in memory, the
+// rest of it is stored in temporary files
+const uploadFormMaxMemory = 32 << 20
+
 //counterfeiter:generate -o fake -fake-name CFDropletRepository . CFDropletRepository
 type CFDropletRepository interface {
 	GetDroplet(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	ListDroplets(context.Context, authorization.Info, repositories.ListDropletsMessage) ([]repositories.DropletRecord, error)
 	UpdateDroplet(context.Context, authorization.Info, repositories.UpdateDropletMessage) (repositories.DropletRecord, error)
+	CreateDroplet(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	UpdateDropletImage(context.Context, authorization.Info, repositories.UpdateDropletImageMessage) (repositories.DropletRecord, error)
 }
 
 type Droplet struct {
-	serverURL        url.URL
-	dropletRepo      CFDropletRepository
-	requestValidator RequestValidator
+	serverURL           url.URL
+	dropletRepo         CFDropletRepository
+	appRepo             CFAppRepository
+	imageRepo           ImageRepository
+	requestValidator    RequestValidator
+	registrySecretNames []string
 }
 
 func NewDroplet(
 	serverURL url.URL,
 	dropletRepo CFDropletRepository,
+	appRepo CFAppRepository,
+	imageRepo ImageRepository,
 	requestValidator RequestValidator,
+	registrySecretNames []string,
 ) *Droplet {
 	return &Droplet{
-		serverURL:        serverURL,
-		dropletRepo:      dropletRepo,
-		requestValidator: requestValidator,
+		serverURL:           serverURL,
+		dropletRepo:         dropletRepo,
+		appRepo:             appRepo,
+		imageRepo:           imageRepo,
+		requestValidator:    requestValidator,
+		registrySecretNames: registrySecretNames,
 	}
 }
 
@@ -93,6 +113,217 @@ func (h *Droplet) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
 }
 
+func (h *Droplet) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.create")
+
+	query := new(payloads.DropletCreateQuery)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, query); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if query.SourceGUID != "" {
+		return h.copy(r, query.SourceGUID)
+	}
+
+	var payload payloads.DropletCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appRecord, err := h.getApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error finding App", "App GUID", payload.Relationships.App.Data.GUID)
+	}
+
+	droplet, err := h.dropletRepo.CreateDroplet(r.Context(), authInfo, payload.ToMessage(appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating droplet with repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) copy(r *http.Request, sourceGUID string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.copy")
+
+	var payload payloads.DropletCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourceDroplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching source droplet", "sourceGUID", sourceGUID)
+	}
+
+	if sourceDroplet.State != repositories.DropletStateStaged || sourceDroplet.ImageRef == "" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source droplet has no bits to copy."),
+			"Source droplet is not staged", "sourceGUID", sourceGUID,
+		)
+	}
+
+	appRecord, err := h.getApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error finding App", "App GUID", payload.Relationships.App.Data.GUID)
+	}
+
+	droplet, err := h.dropletRepo.CreateDroplet(r.Context(), authInfo, payload.ToMessage(appRecord, sourceDroplet))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating droplet with repository")
+	}
+
+	copiedImageRef, err := h.imageRepo.CopyDropletImage(r.Context(), authInfo, sourceDroplet.ImageRef, repositories.RegistryCredentials{}, droplet.RepositoryRef, droplet.SpaceGUID, droplet.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling CopyDropletImage")
+	}
+
+	droplet, err = h.dropletRepo.UpdateDropletImage(r.Context(), authInfo, repositories.UpdateDropletImageMessage{
+		GUID:                droplet.GUID,
+		SpaceGUID:           droplet.SpaceGUID,
+		ImageRef:            copiedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletImage")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) getApp(ctx context.Context, authInfo authorization.Info, appGUID string) (repositories.AppRecord, error) {
+	appRecord, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return repositories.AppRecord{}, apierrors.AsUnprocessableEntity(
+			err,
+			"App is invalid. Ensure it exists and you have access to it.",
+			apierrors.NotFoundError{},
+			apierrors.ForbiddenError{},
+		)
+	}
+
+	return appRecord, nil
+}
+
+func (h *Droplet) upload(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.upload")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	err := r.ParseMultipartForm(uploadFormMaxMemory)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
+	}
+
+	// the query string is logged, so registry credentials are only accepted
+	// in the body
+	if query := r.URL.Query(); query.Has("username") || query.Has("password") {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewInvalidRequestError(nil, "Registry credentials must be sent in the request body"),
+			"Registry credentials sent in the query string",
+		)
+	}
+
+	var bitsFile io.Reader
+	formFile, _, err := r.FormFile("bits")
+	switch {
+	case err == nil:
+		defer formFile.Close()
+		bitsFile = formFile
+	case !errors.Is(err, http.ErrMissingFile):
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits or an image"), "Error reading form file \"bits\"")
+	}
+
+	payload := new(payloads.DropletUpload)
+	if err = h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request form values")
+	}
+
+	if (bitsFile == nil) == (payload.Image == "") {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Upload must include either bits or an image"),
+			"Upload must have exactly one of bits and image",
+		)
+	}
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.State != repositories.DropletStateAwaitingUpload {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplet may be uploaded only once. Create a new droplet to upload bits."),
+			"Error, cannot call droplet upload state was not AWAITING_UPLOAD", "dropletGUID", dropletGUID,
+		)
+	}
+
+	var uploadedImageRef string
+	if bitsFile != nil {
+		uploadedImageRef, err = h.imageRepo.UploadDropletImage(r.Context(), authInfo, droplet.RepositoryRef, bitsFile, droplet.SpaceGUID, dropletGUID)
+	} else {
+		uploadedImageRef, err = h.imageRepo.CopyDropletImage(r.Context(), authInfo, payload.Image, payload.ToRegistryCredentials(), droplet.RepositoryRef, droplet.SpaceGUID, dropletGUID)
+	}
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error uploading droplet image")
+	}
+
+	droplet, err = h.dropletRepo.UpdateDropletImage(r.Context(), authInfo, repositories.UpdateDropletImageMessage{
+		GUID:                dropletGUID,
+		SpaceGUID:           droplet.SpaceGUID,
+		ImageRef:            uploadedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletImage")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.download")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.Lifecycle.Type == "docker" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Cannot download droplets with 'docker' lifecycle."),
+			"downloading docker droplets is not supported",
+		)
+	}
+
+	if droplet.State != repositories.DropletStateStaged || droplet.ImageRef == "" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Only staged droplets can be downloaded."),
+			"Droplet is not staged", "dropletGUID", dropletGUID,
+		)
+	}
+
+	imageTarball, err := h.imageRepo.DownloadDropletImage(r.Context(), authInfo, droplet.ImageRef, droplet.SpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling DownloadDropletImage")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/x-tar").
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dropletGUID+".tar")).
+		WithStream(imageTarball), nil
+}
+
 func (h *Droplet) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -101,5 +332,8 @@ func (h *Droplet) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: DropletPath, Handler: h.get},
 		{Method: "PATCH", Pattern: DropletPath, Handler: h.update},
+		{Method: "POST", Pattern: DropletsPath, Handler: h.create},
+		{Method: "POST", Pattern: DropletUploadPath, Handler: h.upload},
+		{Method: "GET", Pattern: DropletDownloadPath, Handler: h.download},
 	}
 }
@@ -1,7 +1,11 @@
 package handlers_test
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
@@ -31,11 +35,15 @@ var _ = Describe("Droplet", func() {
 
 		requestValidator *fake.RequestValidator
 		dropletRepo      *fake.CFDropletRepository
+		appRepo          *fake.CFAppRepository
+		imageRepo        *fake.ImageRepository
 		req              *http.Request
 	)
 
 	BeforeEach(func() {
 		dropletRepo = new(fake.CFDropletRepository)
+		appRepo = new(fake.CFAppRepository)
+		imageRepo = new(fake.ImageRepository)
 		var err error
 		req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets/"+dropletGUID, nil)
 		Expect(err).NotTo(HaveOccurred())
@@ -45,7 +53,10 @@ var _ = Describe("Droplet", func() {
 		apiHandler := NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
+			[]string{"registry-secret"},
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -240,4 +251,536 @@ var _ = Describe("Droplet", func() {
 			})
 		})
 	})
+
+	Describe("the POST /v3/droplets endpoint", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: "test-space-guid",
+			}, nil)
+
+			dropletRepo.CreateDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				SpaceGUID: "test-space-guid",
+				AppGUID:   appGUID,
+				State:     repositories.DropletStateAwaitingUpload,
+				CreatedAt: createdAt,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DropletCreate{
+				Relationships: &payloads.DropletRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: appGUID},
+					},
+				},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/droplets", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates a droplet awaiting upload", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(dropletRepo.CreateDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualCreate := dropletRepo.CreateDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualCreate).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      appGUID,
+				SpaceGUID:    "test-space-guid",
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+				MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/droplets/"+dropletGUID+"/upload"),
+			)))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "test-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("test-error")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+
+			It("doesn't create the droplet", func() {
+				Expect(dropletRepo.CreateDropletCallCount()).To(Equal(0))
+			})
+		})
+
+		When("creating the droplet fails", func() {
+			BeforeEach(func() {
+				dropletRepo.CreateDropletReturns(repositories.DropletRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the POST /v3/droplets?source_guid= endpoint", func() {
+		const (
+			sourceDropletGUID = "source-droplet-guid"
+			destAppGUID       = "dest-app-guid"
+			destSpaceGUID     = "dest-space-guid"
+		)
+
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.DropletCreateQuery{
+				SourceGUID: sourceDropletGUID,
+			})
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DropletCopy{
+				Relationships: &payloads.DropletRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: destAppGUID},
+					},
+				},
+			})
+
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:         sourceDropletGUID,
+				State:        repositories.DropletStateStaged,
+				ImageRef:     "registry.repo/src-droplets@sha256:abc",
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+				Ports:        []int32{8080},
+			}, nil)
+
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      destAppGUID,
+				SpaceGUID: destSpaceGUID,
+			}, nil)
+
+			dropletRepo.CreateDropletReturns(repositories.DropletRecord{
+				GUID:          dropletGUID,
+				SpaceGUID:     destSpaceGUID,
+				State:         repositories.DropletStateAwaitingUpload,
+				RepositoryRef: "registry.repo/dest-droplets",
+			}, nil)
+
+			imageRepo.CopyDropletImageReturns("registry.repo/dest-droplets@sha256:abc", nil)
+
+			dropletRepo.UpdateDropletImageReturns(repositories.DropletRecord{
+				GUID:     dropletGUID,
+				State:    repositories.DropletStateStaged,
+				ImageRef: "registry.repo/dest-droplets@sha256:abc",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/droplets?source_guid="+sourceDropletGUID, strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("copies the source droplet image to a new droplet for the destination app", func() {
+			Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualDropletGUID := dropletRepo.GetDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualDropletGUID).To(Equal(sourceDropletGUID))
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(destAppGUID))
+
+			Expect(dropletRepo.CreateDropletCallCount()).To(Equal(1))
+			_, _, actualCreate := dropletRepo.CreateDropletArgsForCall(0)
+			Expect(actualCreate).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      destAppGUID,
+				SpaceGUID:    destSpaceGUID,
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+				Ports:        []int32{8080},
+			}))
+
+			Expect(imageRepo.CopyDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, srcRef, srcCreds, dstRef, actualSpaceGUID, actualTags := imageRepo.CopyDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(srcRef).To(Equal("registry.repo/src-droplets@sha256:abc"))
+			Expect(srcCreds).To(BeZero())
+			Expect(dstRef).To(Equal("registry.repo/dest-droplets"))
+			Expect(actualSpaceGUID).To(Equal(destSpaceGUID))
+			Expect(actualTags).To(ConsistOf(dropletGUID))
+
+			Expect(dropletRepo.UpdateDropletImageCallCount()).To(Equal(1))
+			_, _, message := dropletRepo.UpdateDropletImageArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdateDropletImageMessage{
+				GUID:                dropletGUID,
+				SpaceGUID:           destSpaceGUID,
+				ImageRef:            "registry.repo/dest-droplets@sha256:abc",
+				RegistrySecretNames: []string{"registry-secret"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "STAGED"),
+			)))
+		})
+
+		itDoesntCopyTheDroplet := func() {
+			It("doesn't copy the droplet", func() {
+				Expect(dropletRepo.CreateDropletCallCount()).To(Equal(0))
+				Expect(imageRepo.CopyDropletImageCallCount()).To(Equal(0))
+			})
+		}
+
+		When("the source droplet is not accessible", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DropletResourceType)
+			})
+			itDoesntCopyTheDroplet()
+		})
+
+		When("the source droplet has not been uploaded", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:  sourceDropletGUID,
+					State: repositories.DropletStateAwaitingUpload,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Source droplet has no bits to copy.")
+			})
+			itDoesntCopyTheDroplet()
+		})
+
+		When("the destination app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+			itDoesntCopyTheDroplet()
+		})
+
+		When("copying the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.CopyDropletImageReturns("", apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+			})
+
+			It("doesn't update the droplet image", func() {
+				Expect(dropletRepo.UpdateDropletImageCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("the POST /v3/droplets/:guid/upload endpoint", func() {
+		newUploadRequest := func(writeForm func(*multipart.Writer)) *http.Request {
+			var b bytes.Buffer
+			writer := multipart.NewWriter(&b)
+			writeForm(writer)
+			Expect(writer.Close()).To(Succeed())
+
+			uploadReq, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("/v3/droplets/%s/upload", dropletGUID), &b)
+			Expect(err).NotTo(HaveOccurred())
+			uploadReq.Header.Add("Content-Type", writer.FormDataContentType())
+
+			return uploadReq
+		}
+
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:          dropletGUID,
+				SpaceGUID:     "test-space-guid",
+				State:         repositories.DropletStateAwaitingUpload,
+				RepositoryRef: "registry.repo/droplets",
+			}, nil)
+
+			imageRepo.UploadDropletImageReturns("registry.repo/droplets@sha256:abc", nil)
+			imageRepo.CopyDropletImageReturns("registry.repo/droplets@sha256:def", nil)
+
+			dropletRepo.UpdateDropletImageReturns(repositories.DropletRecord{
+				GUID:     dropletGUID,
+				State:    repositories.DropletStateStaged,
+				ImageRef: "registry.repo/droplets@sha256:abc",
+			}, nil)
+
+			req = newUploadRequest(func(writer *multipart.Writer) {
+				part, err := writer.CreateFormFile("bits", "droplet.tar")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(part, strings.NewReader("the-image-tarball"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("uploads the image tarball to the droplet repository", func() {
+			Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualDropletGUID := dropletRepo.GetDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualDropletGUID).To(Equal(dropletGUID))
+
+			Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, repoRef, tarball, actualSpaceGUID, actualTags := imageRepo.UploadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(repoRef).To(Equal("registry.repo/droplets"))
+			actualContents, err := io.ReadAll(tarball)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(actualContents)).To(Equal("the-image-tarball"))
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+			Expect(actualTags).To(ConsistOf(dropletGUID))
+
+			Expect(imageRepo.CopyDropletImageCallCount()).To(Equal(0))
+
+			Expect(dropletRepo.UpdateDropletImageCallCount()).To(Equal(1))
+			_, _, message := dropletRepo.UpdateDropletImageArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdateDropletImageMessage{
+				GUID:                dropletGUID,
+				SpaceGUID:           "test-space-guid",
+				ImageRef:            "registry.repo/droplets@sha256:abc",
+				RegistrySecretNames: []string{"registry-secret"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "STAGED"),
+			)))
+		})
+
+		When("an image reference is uploaded instead of bits", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.DropletUpload{
+					Image:    "registry.example.com/ci/my-droplet:1.0",
+					Username: "user",
+					Password: "pass",
+				})
+
+				req = newUploadRequest(func(writer *multipart.Writer) {
+					Expect(writer.WriteField("image", "registry.example.com/ci/my-droplet:1.0")).To(Succeed())
+				})
+			})
+
+			It("copies the image into the droplet repository", func() {
+				Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(0))
+
+				Expect(imageRepo.CopyDropletImageCallCount()).To(Equal(1))
+				_, _, srcRef, srcCreds, dstRef, actualSpaceGUID, actualTags := imageRepo.CopyDropletImageArgsForCall(0)
+				Expect(srcRef).To(Equal("registry.example.com/ci/my-droplet:1.0"))
+				Expect(srcCreds).To(Equal(repositories.RegistryCredentials{Username: "user", Password: "pass"}))
+				Expect(dstRef).To(Equal("registry.repo/droplets"))
+				Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+				Expect(actualTags).To(ConsistOf(dropletGUID))
+
+				Expect(dropletRepo.UpdateDropletImageCallCount()).To(Equal(1))
+				_, _, message := dropletRepo.UpdateDropletImageArgsForCall(0)
+				Expect(message.ImageRef).To(Equal("registry.repo/droplets@sha256:def"))
+			})
+		})
+
+		When("the registry credentials are sent in the query string", func() {
+			BeforeEach(func() {
+				req.URL.RawQuery = "username=user&password=pass"
+			})
+
+			It("returns an error", func() {
+				expectErrorResponse(http.StatusBadRequest, "CF-InvalidRequest", "Registry credentials must be sent in the request body", 10004)
+			})
+
+			It("doesn't upload anything", func() {
+				Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(0))
+				Expect(imageRepo.CopyDropletImageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the body is not a multipart form", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("/v3/droplets/%s/upload", dropletGUID), strings.NewReader("image=my-image"))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			})
+
+			It("returns an error", func() {
+				expectErrorResponse(http.StatusBadRequest, "CF-InvalidRequest", "Unable to parse body as multipart form", 10004)
+			})
+		})
+
+		When("both bits and an image reference are uploaded", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.DropletUpload{
+					Image: "registry.example.com/ci/my-droplet:1.0",
+				})
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Upload must include either bits or an image")
+			})
+		})
+
+		When("neither bits nor an image reference are uploaded", func() {
+			BeforeEach(func() {
+				req = newUploadRequest(func(*multipart.Writer) {})
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Upload must include either bits or an image")
+			})
+		})
+
+		When("getting the droplet is forbidden", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DropletResourceType)
+			})
+		})
+
+		When("the droplet has already been uploaded", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:  dropletGUID,
+					State: repositories.DropletStateStaged,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Droplet may be uploaded only once. Create a new droplet to upload bits.")
+			})
+
+			It("doesn't upload anything", func() {
+				Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("uploading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadDropletImageReturns("", apierrors.NewUnprocessableEntityError(nil, "Droplet bits must be a container image tarball."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Droplet bits must be a container image tarball.")
+			})
+
+			It("doesn't update the droplet image", func() {
+				Expect(dropletRepo.UpdateDropletImageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("updating the droplet image fails", func() {
+			BeforeEach(func() {
+				dropletRepo.UpdateDropletImageReturns(repositories.DropletRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/droplets/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				SpaceGUID: "test-space-guid",
+				State:     repositories.DropletStateStaged,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				ImageRef:  "registry.repo/droplets@sha256:abc",
+			}, nil)
+
+			imageRepo.DownloadDropletImageReturns(io.NopCloser(strings.NewReader("the-image-tarball")), nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/v3/droplets/%s/download", dropletGUID), nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("streams the droplet image as a tarball", func() {
+			Expect(imageRepo.DownloadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualImageRef, actualSpaceGUID := imageRepo.DownloadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualImageRef).To(Equal("registry.repo/droplets@sha256:abc"))
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-tar"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dropletGUID+".tar")))
+			Expect(rr).To(HaveHTTPBody("the-image-tarball"))
+		})
+
+		When("getting the droplet is forbidden", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DropletResourceType)
+			})
+		})
+
+		When("the droplet is a docker droplet", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     repositories.DropletStateStaged,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+					ImageRef:  "some/image",
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Cannot download droplets with 'docker' lifecycle.")
+			})
+		})
+
+		When("the droplet has not been uploaded", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     repositories.DropletStateAwaitingUpload,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Only staged droplets can be downloaded.")
+			})
+
+			It("doesn't download anything", func() {
+				Expect(imageRepo.DownloadDropletImageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("downloading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadDropletImageReturns(nil, apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+			})
+		})
+	})
 })
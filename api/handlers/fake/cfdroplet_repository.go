@@ -11,6 +11,21 @@ import (
 )
 
 type CFDropletRepository struct {
+	CreateDropletStub        func(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	createDropletMutex       sync.RWMutex
+	createDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateDropletMessage
+	}
+	createDropletReturns struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	createDropletReturnsOnCall map[int]struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
 	GetDropletStub        func(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	getDropletMutex       sync.RWMutex
 	getDropletArgsForCall []struct {
@@ -56,10 +71,91 @@ type CFDropletRepository struct {
 		result1 repositories.DropletRecord
 		result2 error
 	}
+	UpdateDropletImageStub        func(context.Context, authorization.Info, repositories.UpdateDropletImageMessage) (repositories.DropletRecord, error)
+	updateDropletImageMutex       sync.RWMutex
+	updateDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateDropletImageMessage
+	}
+	updateDropletImageReturns struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	updateDropletImageReturnsOnCall map[int]struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFDropletRepository) CreateDroplet(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateDropletMessage) (repositories.DropletRecord, error) {
+	fake.createDropletMutex.Lock()
+	ret, specificReturn := fake.createDropletReturnsOnCall[len(fake.createDropletArgsForCall)]
+	fake.createDropletArgsForCall = append(fake.createDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateDropletMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateDropletStub
+	fakeReturns := fake.createDropletReturns
+	fake.recordInvocation("CreateDroplet", []interface{}{arg1, arg2, arg3})
+	fake.createDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDropletRepository) CreateDropletCallCount() int {
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	return len(fake.createDropletArgsForCall)
+}
+
+func (fake *CFDropletRepository) CreateDropletCalls(stub func(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = stub
+}
+
+func (fake *CFDropletRepository) CreateDropletArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateDropletMessage) {
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	argsForCall := fake.createDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) CreateDropletReturns(result1 repositories.DropletRecord, result2 error) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = nil
+	fake.createDropletReturns = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) CreateDropletReturnsOnCall(i int, result1 repositories.DropletRecord, result2 error) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = nil
+	if fake.createDropletReturnsOnCall == nil {
+		fake.createDropletReturnsOnCall = make(map[int]struct {
+			result1 repositories.DropletRecord
+			result2 error
+		})
+	}
+	fake.createDropletReturnsOnCall[i] = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFDropletRepository) GetDroplet(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.DropletRecord, error) {
 	fake.getDropletMutex.Lock()
 	ret, specificReturn := fake.getDropletReturnsOnCall[len(fake.getDropletArgsForCall)]
@@ -258,15 +354,85 @@ func (fake *CFDropletRepository) UpdateDropletReturnsOnCall(i int, result1 repos
 	}{result1, result2}
 }
 
+func (fake *CFDropletRepository) UpdateDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateDropletImageMessage) (repositories.DropletRecord, error) {
+	fake.updateDropletImageMutex.Lock()
+	ret, specificReturn := fake.updateDropletImageReturnsOnCall[len(fake.updateDropletImageArgsForCall)]
+	fake.updateDropletImageArgsForCall = append(fake.updateDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateDropletImageMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateDropletImageStub
+	fakeReturns := fake.updateDropletImageReturns
+	fake.recordInvocation("UpdateDropletImage", []interface{}{arg1, arg2, arg3})
+	fake.updateDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDropletRepository) UpdateDropletImageCallCount() int {
+	fake.updateDropletImageMutex.RLock()
+	defer fake.updateDropletImageMutex.RUnlock()
+	return len(fake.updateDropletImageArgsForCall)
+}
+
+func (fake *CFDropletRepository) UpdateDropletImageCalls(stub func(context.Context, authorization.Info, repositories.UpdateDropletImageMessage) (repositories.DropletRecord, error)) {
+	fake.updateDropletImageMutex.Lock()
+	defer fake.updateDropletImageMutex.Unlock()
+	fake.UpdateDropletImageStub = stub
+}
+
+func (fake *CFDropletRepository) UpdateDropletImageArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateDropletImageMessage) {
+	fake.updateDropletImageMutex.RLock()
+	defer fake.updateDropletImageMutex.RUnlock()
+	argsForCall := fake.updateDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) UpdateDropletImageReturns(result1 repositories.DropletRecord, result2 error) {
+	fake.updateDropletImageMutex.Lock()
+	defer fake.updateDropletImageMutex.Unlock()
+	fake.UpdateDropletImageStub = nil
+	fake.updateDropletImageReturns = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) UpdateDropletImageReturnsOnCall(i int, result1 repositories.DropletRecord, result2 error) {
+	fake.updateDropletImageMutex.Lock()
+	defer fake.updateDropletImageMutex.Unlock()
+	fake.UpdateDropletImageStub = nil
+	if fake.updateDropletImageReturnsOnCall == nil {
+		fake.updateDropletImageReturnsOnCall = make(map[int]struct {
+			result1 repositories.DropletRecord
+			result2 error
+		})
+	}
+	fake.updateDropletImageReturnsOnCall[i] = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFDropletRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
 	fake.getDropletMutex.RLock()
 	defer fake.getDropletMutex.RUnlock()
 	fake.listDropletsMutex.RLock()
 	defer fake.listDropletsMutex.RUnlock()
 	fake.updateDropletMutex.RLock()
 	defer fake.updateDropletMutex.RUnlock()
+	fake.updateDropletImageMutex.RLock()
+	defer fake.updateDropletImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
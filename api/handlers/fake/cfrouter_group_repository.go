@@ -0,0 +1,206 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFRouterGroupRepository struct {
+	GetRouterGroupStub        func(context.Context, authorization.Info, string) (repositories.RouterGroupRecord, error)
+	getRouterGroupMutex       sync.RWMutex
+	getRouterGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getRouterGroupReturns struct {
+		result1 repositories.RouterGroupRecord
+		result2 error
+	}
+	getRouterGroupReturnsOnCall map[int]struct {
+		result1 repositories.RouterGroupRecord
+		result2 error
+	}
+	ListRouterGroupsStub        func(context.Context, authorization.Info, repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error)
+	listRouterGroupsMutex       sync.RWMutex
+	listRouterGroupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListRouterGroupsMessage
+	}
+	listRouterGroupsReturns struct {
+		result1 []repositories.RouterGroupRecord
+		result2 error
+	}
+	listRouterGroupsReturnsOnCall map[int]struct {
+		result1 []repositories.RouterGroupRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFRouterGroupRepository) GetRouterGroup(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.RouterGroupRecord, error) {
+	fake.getRouterGroupMutex.Lock()
+	ret, specificReturn := fake.getRouterGroupReturnsOnCall[len(fake.getRouterGroupArgsForCall)]
+	fake.getRouterGroupArgsForCall = append(fake.getRouterGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetRouterGroupStub
+	fakeReturns := fake.getRouterGroupReturns
+	fake.recordInvocation("GetRouterGroup", []interface{}{arg1, arg2, arg3})
+	fake.getRouterGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouterGroupRepository) GetRouterGroupCallCount() int {
+	fake.getRouterGroupMutex.RLock()
+	defer fake.getRouterGroupMutex.RUnlock()
+	return len(fake.getRouterGroupArgsForCall)
+}
+
+func (fake *CFRouterGroupRepository) GetRouterGroupCalls(stub func(context.Context, authorization.Info, string) (repositories.RouterGroupRecord, error)) {
+	fake.getRouterGroupMutex.Lock()
+	defer fake.getRouterGroupMutex.Unlock()
+	fake.GetRouterGroupStub = stub
+}
+
+func (fake *CFRouterGroupRepository) GetRouterGroupArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getRouterGroupMutex.RLock()
+	defer fake.getRouterGroupMutex.RUnlock()
+	argsForCall := fake.getRouterGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouterGroupRepository) GetRouterGroupReturns(result1 repositories.RouterGroupRecord, result2 error) {
+	fake.getRouterGroupMutex.Lock()
+	defer fake.getRouterGroupMutex.Unlock()
+	fake.GetRouterGroupStub = nil
+	fake.getRouterGroupReturns = struct {
+		result1 repositories.RouterGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouterGroupRepository) GetRouterGroupReturnsOnCall(i int, result1 repositories.RouterGroupRecord, result2 error) {
+	fake.getRouterGroupMutex.Lock()
+	defer fake.getRouterGroupMutex.Unlock()
+	fake.GetRouterGroupStub = nil
+	if fake.getRouterGroupReturnsOnCall == nil {
+		fake.getRouterGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouterGroupRecord
+			result2 error
+		})
+	}
+	fake.getRouterGroupReturnsOnCall[i] = struct {
+		result1 repositories.RouterGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouterGroupRepository) ListRouterGroups(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error) {
+	fake.listRouterGroupsMutex.Lock()
+	ret, specificReturn := fake.listRouterGroupsReturnsOnCall[len(fake.listRouterGroupsArgsForCall)]
+	fake.listRouterGroupsArgsForCall = append(fake.listRouterGroupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListRouterGroupsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListRouterGroupsStub
+	fakeReturns := fake.listRouterGroupsReturns
+	fake.recordInvocation("ListRouterGroups", []interface{}{arg1, arg2, arg3})
+	fake.listRouterGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouterGroupRepository) ListRouterGroupsCallCount() int {
+	fake.listRouterGroupsMutex.RLock()
+	defer fake.listRouterGroupsMutex.RUnlock()
+	return len(fake.listRouterGroupsArgsForCall)
+}
+
+func (fake *CFRouterGroupRepository) ListRouterGroupsCalls(stub func(context.Context, authorization.Info, repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error)) {
+	fake.listRouterGroupsMutex.Lock()
+	defer fake.listRouterGroupsMutex.Unlock()
+	fake.ListRouterGroupsStub = stub
+}
+
+func (fake *CFRouterGroupRepository) ListRouterGroupsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListRouterGroupsMessage) {
+	fake.listRouterGroupsMutex.RLock()
+	defer fake.listRouterGroupsMutex.RUnlock()
+	argsForCall := fake.listRouterGroupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouterGroupRepository) ListRouterGroupsReturns(result1 []repositories.RouterGroupRecord, result2 error) {
+	fake.listRouterGroupsMutex.Lock()
+	defer fake.listRouterGroupsMutex.Unlock()
+	fake.ListRouterGroupsStub = nil
+	fake.listRouterGroupsReturns = struct {
+		result1 []repositories.RouterGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouterGroupRepository) ListRouterGroupsReturnsOnCall(i int, result1 []repositories.RouterGroupRecord, result2 error) {
+	fake.listRouterGroupsMutex.Lock()
+	defer fake.listRouterGroupsMutex.Unlock()
+	fake.ListRouterGroupsStub = nil
+	if fake.listRouterGroupsReturnsOnCall == nil {
+		fake.listRouterGroupsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.RouterGroupRecord
+			result2 error
+		})
+	}
+	fake.listRouterGroupsReturnsOnCall[i] = struct {
+		result1 []repositories.RouterGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouterGroupRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getRouterGroupMutex.RLock()
+	defer fake.getRouterGroupMutex.RUnlock()
+	fake.listRouterGroupsMutex.RLock()
+	defer fake.listRouterGroupsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFRouterGroupRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFRouterGroupRepository = new(CFRouterGroupRepository)
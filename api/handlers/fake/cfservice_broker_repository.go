@@ -69,6 +69,21 @@ type CFServiceBrokerRepository struct {
 		result1 []repositories.ServiceBrokerRecord
 		result2 error
 	}
+	RefreshServiceBrokerCatalogStub        func(context.Context, authorization.Info, string) (repositories.ServiceBrokerRecord, error)
+	refreshServiceBrokerCatalogMutex       sync.RWMutex
+	refreshServiceBrokerCatalogArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	refreshServiceBrokerCatalogReturns struct {
+		result1 repositories.ServiceBrokerRecord
+		result2 error
+	}
+	refreshServiceBrokerCatalogReturnsOnCall map[int]struct {
+		result1 repositories.ServiceBrokerRecord
+		result2 error
+	}
 	UpdateServiceBrokerStub        func(context.Context, authorization.Info, repositories.UpdateServiceBrokerMessage) (repositories.ServiceBrokerRecord, error)
 	updateServiceBrokerMutex       sync.RWMutex
 	updateServiceBrokerArgsForCall []struct {
@@ -349,6 +364,72 @@ func (fake *CFServiceBrokerRepository) ListServiceBrokersReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *CFServiceBrokerRepository) RefreshServiceBrokerCatalog(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServiceBrokerRecord, error) {
+	fake.refreshServiceBrokerCatalogMutex.Lock()
+	ret, specificReturn := fake.refreshServiceBrokerCatalogReturnsOnCall[len(fake.refreshServiceBrokerCatalogArgsForCall)]
+	fake.refreshServiceBrokerCatalogArgsForCall = append(fake.refreshServiceBrokerCatalogArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RefreshServiceBrokerCatalogStub
+	fakeReturns := fake.refreshServiceBrokerCatalogReturns
+	fake.recordInvocation("RefreshServiceBrokerCatalog", []interface{}{arg1, arg2, arg3})
+	fake.refreshServiceBrokerCatalogMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceBrokerRepository) RefreshServiceBrokerCatalogCallCount() int {
+	fake.refreshServiceBrokerCatalogMutex.RLock()
+	defer fake.refreshServiceBrokerCatalogMutex.RUnlock()
+	return len(fake.refreshServiceBrokerCatalogArgsForCall)
+}
+
+func (fake *CFServiceBrokerRepository) RefreshServiceBrokerCatalogCalls(stub func(context.Context, authorization.Info, string) (repositories.ServiceBrokerRecord, error)) {
+	fake.refreshServiceBrokerCatalogMutex.Lock()
+	defer fake.refreshServiceBrokerCatalogMutex.Unlock()
+	fake.RefreshServiceBrokerCatalogStub = stub
+}
+
+func (fake *CFServiceBrokerRepository) RefreshServiceBrokerCatalogArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.refreshServiceBrokerCatalogMutex.RLock()
+	defer fake.refreshServiceBrokerCatalogMutex.RUnlock()
+	argsForCall := fake.refreshServiceBrokerCatalogArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceBrokerRepository) RefreshServiceBrokerCatalogReturns(result1 repositories.ServiceBrokerRecord, result2 error) {
+	fake.refreshServiceBrokerCatalogMutex.Lock()
+	defer fake.refreshServiceBrokerCatalogMutex.Unlock()
+	fake.RefreshServiceBrokerCatalogStub = nil
+	fake.refreshServiceBrokerCatalogReturns = struct {
+		result1 repositories.ServiceBrokerRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceBrokerRepository) RefreshServiceBrokerCatalogReturnsOnCall(i int, result1 repositories.ServiceBrokerRecord, result2 error) {
+	fake.refreshServiceBrokerCatalogMutex.Lock()
+	defer fake.refreshServiceBrokerCatalogMutex.Unlock()
+	fake.RefreshServiceBrokerCatalogStub = nil
+	if fake.refreshServiceBrokerCatalogReturnsOnCall == nil {
+		fake.refreshServiceBrokerCatalogReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceBrokerRecord
+			result2 error
+		})
+	}
+	fake.refreshServiceBrokerCatalogReturnsOnCall[i] = struct {
+		result1 repositories.ServiceBrokerRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServiceBrokerRepository) UpdateServiceBroker(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateServiceBrokerMessage) (repositories.ServiceBrokerRecord, error) {
 	fake.updateServiceBrokerMutex.Lock()
 	ret, specificReturn := fake.updateServiceBrokerReturnsOnCall[len(fake.updateServiceBrokerArgsForCall)]
@@ -426,6 +507,8 @@ func (fake *CFServiceBrokerRepository) Invocations() map[string][][]interface{}
 	defer fake.getServiceBrokerMutex.RUnlock()
 	fake.listServiceBrokersMutex.RLock()
 	defer fake.listServiceBrokersMutex.RUnlock()
+	fake.refreshServiceBrokerCatalogMutex.RLock()
+	defer fake.refreshServiceBrokerCatalogMutex.RUnlock()
 	fake.updateServiceBrokerMutex.RLock()
 	defer fake.updateServiceBrokerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
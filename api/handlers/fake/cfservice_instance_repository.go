@@ -86,6 +86,36 @@ type CFServiceInstanceRepository struct {
 		result1 map[string]any
 		result2 error
 	}
+	GetServiceInstanceParametersStub        func(context.Context, authorization.Info, string) (map[string]any, error)
+	getServiceInstanceParametersMutex       sync.RWMutex
+	getServiceInstanceParametersArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServiceInstanceParametersReturns struct {
+		result1 map[string]any
+		result2 error
+	}
+	getServiceInstanceParametersReturnsOnCall map[int]struct {
+		result1 map[string]any
+		result2 error
+	}
+	GetSharedSpacesUsageSummaryStub        func(context.Context, authorization.Info, string) ([]repositories.SharedSpaceUsageSummaryRecord, error)
+	getSharedSpacesUsageSummaryMutex       sync.RWMutex
+	getSharedSpacesUsageSummaryArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSharedSpacesUsageSummaryReturns struct {
+		result1 []repositories.SharedSpaceUsageSummaryRecord
+		result2 error
+	}
+	getSharedSpacesUsageSummaryReturnsOnCall map[int]struct {
+		result1 []repositories.SharedSpaceUsageSummaryRecord
+		result2 error
+	}
 	ListServiceInstancesStub        func(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 	listServiceInstancesMutex       sync.RWMutex
 	listServiceInstancesArgsForCall []struct {
@@ -116,6 +146,34 @@ type CFServiceInstanceRepository struct {
 		result1 repositories.ServiceInstanceRecord
 		result2 error
 	}
+	ShareServiceInstanceStub        func(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	shareServiceInstanceMutex       sync.RWMutex
+	shareServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareServiceInstanceMessage
+	}
+	shareServiceInstanceReturns struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	shareServiceInstanceReturnsOnCall map[int]struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	UnshareServiceInstanceStub        func(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error
+	unshareServiceInstanceMutex       sync.RWMutex
+	unshareServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareServiceInstanceMessage
+	}
+	unshareServiceInstanceReturns struct {
+		result1 error
+	}
+	unshareServiceInstanceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -450,6 +508,138 @@ func (fake *CFServiceInstanceRepository) GetServiceInstanceCredentialsReturnsOnC
 	}{result1, result2}
 }
 
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParameters(arg1 context.Context, arg2 authorization.Info, arg3 string) (map[string]any, error) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	ret, specificReturn := fake.getServiceInstanceParametersReturnsOnCall[len(fake.getServiceInstanceParametersArgsForCall)]
+	fake.getServiceInstanceParametersArgsForCall = append(fake.getServiceInstanceParametersArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServiceInstanceParametersStub
+	fakeReturns := fake.getServiceInstanceParametersReturns
+	fake.recordInvocation("GetServiceInstanceParameters", []interface{}{arg1, arg2, arg3})
+	fake.getServiceInstanceParametersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersCallCount() int {
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
+	return len(fake.getServiceInstanceParametersArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersCalls(stub func(context.Context, authorization.Info, string) (map[string]any, error)) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	defer fake.getServiceInstanceParametersMutex.Unlock()
+	fake.GetServiceInstanceParametersStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
+	argsForCall := fake.getServiceInstanceParametersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersReturns(result1 map[string]any, result2 error) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	defer fake.getServiceInstanceParametersMutex.Unlock()
+	fake.GetServiceInstanceParametersStub = nil
+	fake.getServiceInstanceParametersReturns = struct {
+		result1 map[string]any
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersReturnsOnCall(i int, result1 map[string]any, result2 error) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	defer fake.getServiceInstanceParametersMutex.Unlock()
+	fake.GetServiceInstanceParametersStub = nil
+	if fake.getServiceInstanceParametersReturnsOnCall == nil {
+		fake.getServiceInstanceParametersReturnsOnCall = make(map[int]struct {
+			result1 map[string]any
+			result2 error
+		})
+	}
+	fake.getServiceInstanceParametersReturnsOnCall[i] = struct {
+		result1 map[string]any
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummary(arg1 context.Context, arg2 authorization.Info, arg3 string) ([]repositories.SharedSpaceUsageSummaryRecord, error) {
+	fake.getSharedSpacesUsageSummaryMutex.Lock()
+	ret, specificReturn := fake.getSharedSpacesUsageSummaryReturnsOnCall[len(fake.getSharedSpacesUsageSummaryArgsForCall)]
+	fake.getSharedSpacesUsageSummaryArgsForCall = append(fake.getSharedSpacesUsageSummaryArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSharedSpacesUsageSummaryStub
+	fakeReturns := fake.getSharedSpacesUsageSummaryReturns
+	fake.recordInvocation("GetSharedSpacesUsageSummary", []interface{}{arg1, arg2, arg3})
+	fake.getSharedSpacesUsageSummaryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryCallCount() int {
+	fake.getSharedSpacesUsageSummaryMutex.RLock()
+	defer fake.getSharedSpacesUsageSummaryMutex.RUnlock()
+	return len(fake.getSharedSpacesUsageSummaryArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryCalls(stub func(context.Context, authorization.Info, string) ([]repositories.SharedSpaceUsageSummaryRecord, error)) {
+	fake.getSharedSpacesUsageSummaryMutex.Lock()
+	defer fake.getSharedSpacesUsageSummaryMutex.Unlock()
+	fake.GetSharedSpacesUsageSummaryStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSharedSpacesUsageSummaryMutex.RLock()
+	defer fake.getSharedSpacesUsageSummaryMutex.RUnlock()
+	argsForCall := fake.getSharedSpacesUsageSummaryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryReturns(result1 []repositories.SharedSpaceUsageSummaryRecord, result2 error) {
+	fake.getSharedSpacesUsageSummaryMutex.Lock()
+	defer fake.getSharedSpacesUsageSummaryMutex.Unlock()
+	fake.GetSharedSpacesUsageSummaryStub = nil
+	fake.getSharedSpacesUsageSummaryReturns = struct {
+		result1 []repositories.SharedSpaceUsageSummaryRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryReturnsOnCall(i int, result1 []repositories.SharedSpaceUsageSummaryRecord, result2 error) {
+	fake.getSharedSpacesUsageSummaryMutex.Lock()
+	defer fake.getSharedSpacesUsageSummaryMutex.Unlock()
+	fake.GetSharedSpacesUsageSummaryStub = nil
+	if fake.getSharedSpacesUsageSummaryReturnsOnCall == nil {
+		fake.getSharedSpacesUsageSummaryReturnsOnCall = make(map[int]struct {
+			result1 []repositories.SharedSpaceUsageSummaryRecord
+			result2 error
+		})
+	}
+	fake.getSharedSpacesUsageSummaryReturnsOnCall[i] = struct {
+		result1 []repositories.SharedSpaceUsageSummaryRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServiceInstanceRepository) ListServiceInstances(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error) {
 	fake.listServiceInstancesMutex.Lock()
 	ret, specificReturn := fake.listServiceInstancesReturnsOnCall[len(fake.listServiceInstancesArgsForCall)]
@@ -582,6 +772,135 @@ func (fake *CFServiceInstanceRepository) PatchServiceInstanceReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *CFServiceInstanceRepository) ShareServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error) {
+	fake.shareServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.shareServiceInstanceReturnsOnCall[len(fake.shareServiceInstanceArgsForCall)]
+	fake.shareServiceInstanceArgsForCall = append(fake.shareServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareServiceInstanceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ShareServiceInstanceStub
+	fakeReturns := fake.shareServiceInstanceReturns
+	fake.recordInvocation("ShareServiceInstance", []interface{}{arg1, arg2, arg3})
+	fake.shareServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceCallCount() int {
+	fake.shareServiceInstanceMutex.RLock()
+	defer fake.shareServiceInstanceMutex.RUnlock()
+	return len(fake.shareServiceInstanceArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceCalls(stub func(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceArgsForCall(i int) (context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) {
+	fake.shareServiceInstanceMutex.RLock()
+	defer fake.shareServiceInstanceMutex.RUnlock()
+	argsForCall := fake.shareServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceReturns(result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = nil
+	fake.shareServiceInstanceReturns = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceReturnsOnCall(i int, result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = nil
+	if fake.shareServiceInstanceReturnsOnCall == nil {
+		fake.shareServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceInstanceRecord
+			result2 error
+		})
+	}
+	fake.shareServiceInstanceReturnsOnCall[i] = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UnshareServiceInstanceMessage) error {
+	fake.unshareServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.unshareServiceInstanceReturnsOnCall[len(fake.unshareServiceInstanceArgsForCall)]
+	fake.unshareServiceInstanceArgsForCall = append(fake.unshareServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareServiceInstanceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UnshareServiceInstanceStub
+	fakeReturns := fake.unshareServiceInstanceReturns
+	fake.recordInvocation("UnshareServiceInstance", []interface{}{arg1, arg2, arg3})
+	fake.unshareServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceCallCount() int {
+	fake.unshareServiceInstanceMutex.RLock()
+	defer fake.unshareServiceInstanceMutex.RUnlock()
+	return len(fake.unshareServiceInstanceArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceCalls(stub func(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceArgsForCall(i int) (context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) {
+	fake.unshareServiceInstanceMutex.RLock()
+	defer fake.unshareServiceInstanceMutex.RUnlock()
+	argsForCall := fake.unshareServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceReturns(result1 error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = nil
+	fake.unshareServiceInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceReturnsOnCall(i int, result1 error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = nil
+	if fake.unshareServiceInstanceReturnsOnCall == nil {
+		fake.unshareServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unshareServiceInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFServiceInstanceRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -595,10 +914,18 @@ func (fake *CFServiceInstanceRepository) Invocations() map[string][][]interface{
 	defer fake.getServiceInstanceMutex.RUnlock()
 	fake.getServiceInstanceCredentialsMutex.RLock()
 	defer fake.getServiceInstanceCredentialsMutex.RUnlock()
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
+	fake.getSharedSpacesUsageSummaryMutex.RLock()
+	defer fake.getSharedSpacesUsageSummaryMutex.RUnlock()
 	fake.listServiceInstancesMutex.RLock()
 	defer fake.listServiceInstancesMutex.RUnlock()
 	fake.patchServiceInstanceMutex.RLock()
 	defer fake.patchServiceInstanceMutex.RUnlock()
+	fake.shareServiceInstanceMutex.RLock()
+	defer fake.shareServiceInstanceMutex.RUnlock()
+	fake.unshareServiceInstanceMutex.RLock()
+	defer fake.unshareServiceInstanceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
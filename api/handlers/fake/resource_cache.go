@@ -0,0 +1,216 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/resourcecache"
+)
+
+type ResourceCache struct {
+	AssembleStub        func(context.Context, io.ReaderAt, int64, []resourcecache.Resource) (io.ReadCloser, error)
+	assembleMutex       sync.RWMutex
+	assembleArgsForCall []struct {
+		arg1 context.Context
+		arg2 io.ReaderAt
+		arg3 int64
+		arg4 []resourcecache.Resource
+	}
+	assembleReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	assembleReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	MatchStub        func(context.Context, []resourcecache.Resource) ([]resourcecache.Resource, error)
+	matchMutex       sync.RWMutex
+	matchArgsForCall []struct {
+		arg1 context.Context
+		arg2 []resourcecache.Resource
+	}
+	matchReturns struct {
+		result1 []resourcecache.Resource
+		result2 error
+	}
+	matchReturnsOnCall map[int]struct {
+		result1 []resourcecache.Resource
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ResourceCache) Assemble(arg1 context.Context, arg2 io.ReaderAt, arg3 int64, arg4 []resourcecache.Resource) (io.ReadCloser, error) {
+	var arg4Copy []resourcecache.Resource
+	if arg4 != nil {
+		arg4Copy = make([]resourcecache.Resource, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.assembleMutex.Lock()
+	ret, specificReturn := fake.assembleReturnsOnCall[len(fake.assembleArgsForCall)]
+	fake.assembleArgsForCall = append(fake.assembleArgsForCall, struct {
+		arg1 context.Context
+		arg2 io.ReaderAt
+		arg3 int64
+		arg4 []resourcecache.Resource
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.AssembleStub
+	fakeReturns := fake.assembleReturns
+	fake.recordInvocation("Assemble", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.assembleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ResourceCache) AssembleCallCount() int {
+	fake.assembleMutex.RLock()
+	defer fake.assembleMutex.RUnlock()
+	return len(fake.assembleArgsForCall)
+}
+
+func (fake *ResourceCache) AssembleCalls(stub func(context.Context, io.ReaderAt, int64, []resourcecache.Resource) (io.ReadCloser, error)) {
+	fake.assembleMutex.Lock()
+	defer fake.assembleMutex.Unlock()
+	fake.AssembleStub = stub
+}
+
+func (fake *ResourceCache) AssembleArgsForCall(i int) (context.Context, io.ReaderAt, int64, []resourcecache.Resource) {
+	fake.assembleMutex.RLock()
+	defer fake.assembleMutex.RUnlock()
+	argsForCall := fake.assembleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ResourceCache) AssembleReturns(result1 io.ReadCloser, result2 error) {
+	fake.assembleMutex.Lock()
+	defer fake.assembleMutex.Unlock()
+	fake.AssembleStub = nil
+	fake.assembleReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCache) AssembleReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.assembleMutex.Lock()
+	defer fake.assembleMutex.Unlock()
+	fake.AssembleStub = nil
+	if fake.assembleReturnsOnCall == nil {
+		fake.assembleReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.assembleReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCache) Match(arg1 context.Context, arg2 []resourcecache.Resource) ([]resourcecache.Resource, error) {
+	var arg2Copy []resourcecache.Resource
+	if arg2 != nil {
+		arg2Copy = make([]resourcecache.Resource, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.matchMutex.Lock()
+	ret, specificReturn := fake.matchReturnsOnCall[len(fake.matchArgsForCall)]
+	fake.matchArgsForCall = append(fake.matchArgsForCall, struct {
+		arg1 context.Context
+		arg2 []resourcecache.Resource
+	}{arg1, arg2Copy})
+	stub := fake.MatchStub
+	fakeReturns := fake.matchReturns
+	fake.recordInvocation("Match", []interface{}{arg1, arg2Copy})
+	fake.matchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ResourceCache) MatchCallCount() int {
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
+	return len(fake.matchArgsForCall)
+}
+
+func (fake *ResourceCache) MatchCalls(stub func(context.Context, []resourcecache.Resource) ([]resourcecache.Resource, error)) {
+	fake.matchMutex.Lock()
+	defer fake.matchMutex.Unlock()
+	fake.MatchStub = stub
+}
+
+func (fake *ResourceCache) MatchArgsForCall(i int) (context.Context, []resourcecache.Resource) {
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
+	argsForCall := fake.matchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ResourceCache) MatchReturns(result1 []resourcecache.Resource, result2 error) {
+	fake.matchMutex.Lock()
+	defer fake.matchMutex.Unlock()
+	fake.MatchStub = nil
+	fake.matchReturns = struct {
+		result1 []resourcecache.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCache) MatchReturnsOnCall(i int, result1 []resourcecache.Resource, result2 error) {
+	fake.matchMutex.Lock()
+	defer fake.matchMutex.Unlock()
+	fake.MatchStub = nil
+	if fake.matchReturnsOnCall == nil {
+		fake.matchReturnsOnCall = make(map[int]struct {
+			result1 []resourcecache.Resource
+			result2 error
+		})
+	}
+	fake.matchReturnsOnCall[i] = struct {
+		result1 []resourcecache.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.assembleMutex.RLock()
+	defer fake.assembleMutex.RUnlock()
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ResourceCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.ResourceCache = new(ResourceCache)
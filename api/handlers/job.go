@@ -28,10 +28,18 @@ const (
 	ServiceBrokerCreateJobType          = "service_broker.create"
 	ServiceBrokerUpdateJobType          = "service_broker.update"
 	ServiceBrokerDeleteJobType          = "service_broker.delete"
+	ServiceBrokerCatalogSyncJobType     = "service_broker.catalog.synchronize"
 	ManagedServiceInstanceDeleteJobType = "managed_service_instance.delete"
 	ManagedServiceInstanceCreateJobType = "managed_service_instance.create"
+	ManagedServiceInstanceUpdateJobType = "managed_service_instance.update"
 	ManagedServiceBindingCreateJobType  = "managed_service_binding.create"
 	ManagedServiceBindingDeleteJobType  = "managed_service_binding.delete"
+	ManagedServiceBindingRotateJobType  = "managed_service_binding.rotate"
+	ServiceRouteBindingCreateJobType    = "service_route_binding.create"
+	ServiceRouteBindingDeleteJobType    = "service_route_binding.delete"
+	SecurityGroupDeleteJobType          = "security_group.delete"
+	OrgQuotaDeleteJobType               = "organization_quota.delete"
+	SpaceQuotaDeleteJobType             = "space_quota.delete"
 	JobTimeoutDuration                  = 120.0
 )
 
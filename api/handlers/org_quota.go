@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	OrgQuotasPath              = "/v3/organization_quotas"
+	OrgQuotaPath               = "/v3/organization_quotas/{guid}"
+	OrgQuotaOrganizationsPath  = "/v3/organization_quotas/{guid}/relationships/organizations"
+	orgQuotaOrgsNotFoundErrFmt = "Organizations with guids %q do not exist, or you do not have access to them."
+)
+
+//counterfeiter:generate -o fake -fake-name CFOrgQuotaRepository . CFOrgQuotaRepository
+type CFOrgQuotaRepository interface {
+	CreateOrgQuota(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	GetOrgQuota(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)
+	ListOrgQuotas(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	UpdateOrgQuota(context.Context, authorization.Info, repositories.UpdateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	ApplyOrgQuota(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	DeleteOrgQuota(context.Context, authorization.Info, string) error
+	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
+}
+
+type OrgQuota struct {
+	serverURL        url.URL
+	orgQuotaRepo     CFOrgQuotaRepository
+	orgRepo          CFOrgRepository
+	requestValidator RequestValidator
+}
+
+func NewOrgQuota(
+	serverURL url.URL,
+	orgQuotaRepo CFOrgQuotaRepository,
+	orgRepo CFOrgRepository,
+	requestValidator RequestValidator,
+) *OrgQuota {
+	return &OrgQuota{
+		serverURL:        serverURL,
+		orgQuotaRepo:     orgQuotaRepo,
+		orgRepo:          orgRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *OrgQuota) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.create")
+
+	payload := new(payloads.OrgQuotaCreate)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	message := payload.ToMessage()
+	if err := h.ensureOrgsExist(r.Context(), authInfo, message.OrgGUIDs); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to verify orgs for org quota")
+	}
+
+	orgQuota, err := h.orgQuotaRepo.CreateOrgQuota(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create org quota", "Name", payload.Name)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.get")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	orgQuota, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org quota", "GUID", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.list")
+
+	payload := new(payloads.OrgQuotaList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	orgQuotas, err := h.orgQuotaRepo.ListOrgQuotas(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list org quotas")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForOrgQuota, orgQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *OrgQuota) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.update")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.OrgQuotaPatch)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org quota", "GUID", orgQuotaGUID)
+	}
+
+	orgQuota, err := h.orgQuotaRepo.UpdateOrgQuota(r.Context(), authInfo, payload.ToMessage(orgQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update org quota", "GUID", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.delete")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	if _, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org quota", "GUID", orgQuotaGUID)
+	}
+
+	if err := h.orgQuotaRepo.DeleteOrgQuota(r.Context(), authInfo, orgQuotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete org quota", "GUID", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(orgQuotaGUID, presenter.OrgQuotaDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *OrgQuota) applyToOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.apply")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.OrgQuotaApply)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org quota", "GUID", orgQuotaGUID)
+	}
+
+	message := payload.ToMessage(orgQuotaGUID)
+	if err := h.ensureOrgsExist(r.Context(), authInfo, message.OrgGUIDs); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to verify orgs for org quota")
+	}
+
+	orgQuota, err := h.orgQuotaRepo.ApplyOrgQuota(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to apply org quota", "GUID", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuotaOrganizations(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) ensureOrgsExist(ctx context.Context, authInfo authorization.Info, orgGUIDs []string) error {
+	if len(orgGUIDs) == 0 {
+		return nil
+	}
+
+	orgs, err := h.orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{GUIDs: orgGUIDs})
+	if err != nil {
+		return err
+	}
+
+	missingOrgGUIDs := slices.DeleteFunc(slices.Clone(orgGUIDs), func(guid string) bool {
+		return slices.ContainsFunc(orgs, func(org repositories.OrgRecord) bool { return org.GUID == guid })
+	})
+	if len(missingOrgGUIDs) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("orgs %v not found", missingOrgGUIDs),
+			fmt.Sprintf(orgQuotaOrgsNotFoundErrFmt, missingOrgGUIDs),
+		)
+	}
+
+	return nil
+}
+
+func (h *OrgQuota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *OrgQuota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: OrgQuotasPath, Handler: h.create},
+		{Method: "GET", Pattern: OrgQuotasPath, Handler: h.list},
+		{Method: "GET", Pattern: OrgQuotaPath, Handler: h.get},
+		{Method: "PATCH", Pattern: OrgQuotaPath, Handler: h.update},
+		{Method: "DELETE", Pattern: OrgQuotaPath, Handler: h.delete},
+		{Method: "POST", Pattern: OrgQuotaOrganizationsPath, Handler: h.applyToOrgs},
+	}
+}
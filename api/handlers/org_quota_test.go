@@ -0,0 +1,378 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("OrgQuota", func() {
+	var (
+		requestMethod    string
+		requestPath      string
+		requestBody      string
+		orgQuotaRepo     *fake.CFOrgQuotaRepository
+		orgRepo          *fake.CFOrgRepository
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		orgQuotaRepo = new(fake.CFOrgQuotaRepository)
+		orgRepo = new(fake.CFOrgRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewOrgQuota(
+			*serverURL,
+			orgQuotaRepo,
+			orgRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{
+			GUID:     "quota-guid",
+			Name:     "my-quota",
+			OrgGUIDs: []string{"org-guid"},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/organization_quotas"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaCreate{
+				Name: "my-quota",
+				Apps: payloads.QuotaAppLimits{
+					TotalMemoryInMB: tools.PtrTo[int64](1024),
+				},
+				Relationships: payloads.OrgQuotaRelationships{
+					Organizations: payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+					},
+				},
+			})
+
+			orgRepo.ListOrgsReturns([]repositories.OrgRecord{{GUID: "org-guid"}}, nil)
+
+			orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.AppQuotaLimits{
+						TotalMemoryInMB: tools.PtrTo[int64](1024),
+					},
+				},
+				OrgGUIDs: []string{"org-guid"},
+			}, nil)
+		})
+
+		It("validates the request", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the org quota", func() {
+			Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+			_, _, listOrgsMessage := orgRepo.ListOrgsArgsForCall(0)
+			Expect(listOrgsMessage.GUIDs).To(ConsistOf("org-guid"))
+
+			Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := orgQuotaRepo.CreateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage.Name).To(Equal("my-quota"))
+			Expect(createMessage.Limits.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(1024)))
+			Expect(createMessage.OrgGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+				MatchJSONPath("$.apps.total_memory_in_mb", BeEquivalentTo(1024)),
+				MatchJSONPath("$.relationships.organizations.data[0].guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid"),
+			)))
+		})
+
+		When("an org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns([]repositories.OrgRecord{}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(`Organizations with guids ["org-guid"] do not exist, or you do not have access to them.`)
+				Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing orgs fails", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the request body is not valid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("repo-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/organization_quotas/quota-guid"
+			requestBody = ""
+		})
+
+		It("returns the org quota", func() {
+			Expect(orgQuotaRepo.GetOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := orgQuotaRepo.GetOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+			)))
+		})
+
+		When("the org quota is not accessible", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/organization_quotas"
+			requestBody = ""
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OrgQuotaList{
+				Names:             "q1,q2",
+				OrganizationGUIDs: "org-guid",
+			})
+
+			orgQuotaRepo.ListOrgQuotasReturns([]repositories.OrgQuotaRecord{
+				{GUID: "q1-guid", Name: "q1"},
+				{GUID: "q2-guid", Name: "q2"},
+			}, nil)
+		})
+
+		It("lists the org quotas", func() {
+			Expect(orgQuotaRepo.ListOrgQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := orgQuotaRepo.ListOrgQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage).To(Equal(repositories.ListOrgQuotasMessage{
+				Names:    []string{"q1", "q2"},
+				OrgGUIDs: []string{"org-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "q1-guid"),
+				MatchJSONPath("$.resources[1].guid", "q2-guid"),
+			)))
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ListOrgQuotasReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/organization_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/organization_quotas/quota-guid"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaPatch{
+				Name: tools.PtrTo("new-name"),
+				QuotaLimitsPatch: payloads.QuotaLimitsPatch{
+					Routes: payloads.QuotaRouteLimitsPatch{
+						TotalRoutes: payloads.QuotaLimitPatch{Set: true},
+					},
+				},
+			})
+
+			orgQuotaRepo.UpdateOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "new-name",
+			}, nil)
+		})
+
+		It("updates the org quota", func() {
+			Expect(orgQuotaRepo.UpdateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, updateMessage := orgQuotaRepo.UpdateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(updateMessage.GUID).To(Equal("quota-guid"))
+			Expect(updateMessage.Name).To(PointTo(Equal("new-name")))
+			Expect(updateMessage.Limits.Routes.TotalRoutes).To(PointTo(Equal(repositories.QuotaLimitPatch{})))
+			Expect(updateMessage.Limits.Apps.TotalMemoryInMB).To(BeNil())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.UpdateOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/organization_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/organization_quotas/quota-guid"
+			requestBody = ""
+		})
+
+		It("deletes the org quota", func() {
+			Expect(orgQuotaRepo.DeleteOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := orgQuotaRepo.DeleteOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/organization_quota.delete~quota-guid"))
+		})
+
+		When("the quota is still applied to orgs", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.DeleteOrgQuotaReturns(apierrors.NewUnprocessableEntityError(nil, "still applied"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("still applied")
+			})
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.DeleteOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /v3/organization_quotas/{guid}/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/organization_quotas/quota-guid/relationships/organizations"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaApply{
+				Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+			})
+
+			orgRepo.ListOrgsReturns([]repositories.OrgRecord{{GUID: "org-1"}, {GUID: "org-2"}}, nil)
+
+			orgQuotaRepo.ApplyOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID:     "quota-guid",
+				OrgGUIDs: []string{"org-1", "org-2"},
+			}, nil)
+		})
+
+		It("applies the quota to the orgs", func() {
+			Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, applyMessage := orgQuotaRepo.ApplyOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(applyMessage).To(Equal(repositories.ApplyOrgQuotaMessage{
+				GUID:     "quota-guid",
+				OrgGUIDs: []string{"org-1", "org-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "org-1"),
+				MatchJSONPath("$.data[1].guid", "org-2"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid/relationships/organizations"),
+			)))
+		})
+
+		When("an org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns([]repositories.OrgRecord{{GUID: "org-1"}}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(`Organizations with guids ["org-2"] do not exist`)
+				Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+			})
+		})
+	})
+})
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -22,6 +23,7 @@ const (
 	PackagesPath        = "/v3/packages"
 	PackageUploadPath   = "/v3/packages/{guid}/upload"
 	PackageDropletsPath = "/v3/packages/{guid}/droplets"
+	PackageDownloadPath = "/v3/packages/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFPackageRepository . CFPackageRepository
@@ -38,6 +40,11 @@ type CFPackageRepository interface {
 
 type ImageRepository interface {
 	UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error)
+	CopySourceImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, dstRepoRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	UploadDropletImage(ctx context.Context, authInfo authorization.Info, repoRef string, tarballReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error)
+	CopyDropletImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, srcCreds repositories.RegistryCredentials, dstRepoRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
 }
 
 type Package struct {
@@ -46,6 +53,7 @@ type Package struct {
 	appRepo             CFAppRepository
 	dropletRepo         CFDropletRepository
 	imageRepo           ImageRepository
+	resourceCache       ResourceCache
 	requestValidator    RequestValidator
 	registrySecretNames []string
 }
@@ -56,6 +64,7 @@ func NewPackage(
 	appRepo CFAppRepository,
 	dropletRepo CFDropletRepository,
 	imageRepo ImageRepository,
+	resourceCache ResourceCache,
 	requestValidator RequestValidator,
 	registrySecretNames []string,
 ) *Package {
@@ -65,6 +74,7 @@ func NewPackage(
 		appRepo:             appRepo,
 		dropletRepo:         dropletRepo,
 		imageRepo:           imageRepo,
+		resourceCache:       resourceCache,
 		registrySecretNames: registrySecretNames,
 		requestValidator:    requestValidator,
 	}
@@ -106,6 +116,15 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.create")
 
+	query := new(payloads.PackageCreateQuery)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, query); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if query.SourceGUID != "" {
+		return h.copy(r, query.SourceGUID)
+	}
+
 	var payload payloads.PackageCreate
 	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
@@ -134,6 +153,74 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
 }
 
+func (h Package) copy(r *http.Request, sourceGUID string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.copy")
+
+	var payload payloads.PackageCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourcePackage, err := h.packageRepo.GetPackage(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching source package", "sourceGUID", sourceGUID)
+	}
+
+	if sourcePackage.Type != "bits" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source package type must be bits."),
+			fmt.Sprintf("copying %s packages is not supported", sourcePackage.Type),
+		)
+	}
+
+	if sourcePackage.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source package has no bits to copy."),
+			"Source package is not ready", "sourceGUID", sourceGUID,
+		)
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", payload.Relationships.App.Data.GUID,
+		)
+	}
+
+	packageRecord, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
+	}
+
+	copiedImageRef, err := h.imageRepo.CopySourceImage(r.Context(), authInfo, sourcePackage.SourceImageRef, packageRecord.ImageRef, packageRecord.SpaceGUID, packageRecord.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling CopySourceImage")
+	}
+
+	packageRecord, err = h.packageRepo.UpdatePackageSource(r.Context(), authInfo, repositories.UpdatePackageSourceMessage{
+		GUID:                packageRecord.GUID,
+		SpaceGUID:           packageRecord.SpaceGUID,
+		ImageRef:            copiedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdatePackageSource")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(packageRecord, h.serverURL)), nil
+}
+
 func (h Package) update(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.update")
@@ -162,11 +249,29 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
 	}
 
-	bitsFile, _, err := r.FormFile("bits")
-	if err != nil {
+	var (
+		bitsFile io.ReaderAt
+		bitsSize int64
+	)
+	formFile, formFileHeader, err := r.FormFile("bits")
+	switch {
+	case err == nil:
+		defer formFile.Close()
+		bitsFile = formFile
+		bitsSize = formFileHeader.Size
+	case !errors.Is(err, http.ErrMissingFile):
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
 	}
-	defer bitsFile.Close()
+
+	payload := new(payloads.PackageUpload)
+	if err = h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request form values")
+	}
+
+	resources := payload.ToResources()
+	if bitsFile == nil && len(resources) == 0 {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Upload must include bits"), "Upload has neither bits nor resources")
+	}
 
 	packageRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
 	if err != nil {
@@ -185,7 +290,13 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewPackageBitsAlreadyUploadedError(err), "Error, cannot call package upload state was not AWAITING_UPLOAD", "packageGUID", packageGUID)
 	}
 
-	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, packageRecord.ImageRef, bitsFile, packageRecord.SpaceGUID, packageGUID)
+	packageBits, err := h.resourceCache.Assemble(r.Context(), bitsFile, bitsSize, resources)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error assembling package bits")
+	}
+	defer packageBits.Close()
+
+	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, packageRecord.ImageRef, packageBits, packageRecord.SpaceGUID, packageGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error calling uploadSourceImage")
 	}
@@ -203,6 +314,43 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(packageRecord, h.serverURL)), nil
 }
 
+func (h Package) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.download")
+
+	packageGUID := routing.URLParam(r, "guid")
+	packageRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching package with repository")
+	}
+
+	if packageRecord.Type != "bits" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Package type must be bits."),
+			fmt.Sprintf("downloading %s packages is not supported", packageRecord.Type),
+		)
+	}
+
+	if packageRecord.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Package has no bits to download."),
+			"Package is not ready", "packageGUID", packageGUID,
+		)
+	}
+
+	srcZip, err := h.imageRepo.DownloadSourceImage(r.Context(), authInfo, packageRecord.SourceImageRef, packageRecord.SpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling DownloadSourceImage")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/zip").
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", packageGUID+".zip")).
+		WithStream(srcZip), nil
+}
+
 func (h Package) listDroplets(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.list-droplets")
@@ -239,5 +387,6 @@ func (h *Package) AuthenticatedRoutes() []routing.Route {
 		{Method: "POST", Pattern: PackagesPath, Handler: h.create},
 		{Method: "POST", Pattern: PackageUploadPath, Handler: h.upload},
 		{Method: "GET", Pattern: PackageDropletsPath, Handler: h.listDroplets},
+		{Method: "GET", Pattern: PackageDownloadPath, Handler: h.download},
 	}
 }
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/resourcecache"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -29,6 +31,7 @@ var _ = Describe("Package", func() {
 		appRepo                     *fake.CFAppRepository
 		dropletRepo                 *fake.CFDropletRepository
 		imageRepo                   *fake.ImageRepository
+		resourceCache               *fake.ResourceCache
 		requestValidator            *fake.RequestValidator
 		packageImagePullSecretNames []string
 
@@ -44,6 +47,7 @@ var _ = Describe("Package", func() {
 		appRepo = new(fake.CFAppRepository)
 		dropletRepo = new(fake.CFDropletRepository)
 		imageRepo = new(fake.ImageRepository)
+		resourceCache = new(fake.ResourceCache)
 		requestValidator = new(fake.RequestValidator)
 		packageImagePullSecretNames = []string{"package-image-pull-secret"}
 
@@ -59,6 +63,7 @@ var _ = Describe("Package", func() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			resourceCache,
 			requestValidator,
 			packageImagePullSecretNames,
 		)
@@ -419,6 +424,226 @@ var _ = Describe("Package", func() {
 		})
 	})
 
+	Describe("the POST /v3/packages?source_guid=:guid endpoint", func() {
+		var (
+			sourcePackageGUID string
+			destAppGUID       string
+			destSpaceGUID     string
+		)
+
+		BeforeEach(func() {
+			sourcePackageGUID = generateGUID("source-package")
+			destAppGUID = generateGUID("dest-app")
+			destSpaceGUID = generateGUID("dest-space")
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.PackageCreateQuery{
+				SourceGUID: sourcePackageGUID,
+			})
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCopy{
+				Relationships: &payloads.PackageRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{
+							GUID: destAppGUID,
+						},
+					},
+				},
+			})
+
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				Type:           "bits",
+				AppGUID:        appGUID,
+				SpaceGUID:      spaceGUID,
+				GUID:           sourcePackageGUID,
+				State:          repositories.PackageStateReady,
+				ImageRef:       "registry.repo/src-packages",
+				SourceImageRef: "registry.repo/src-packages@sha256:abc",
+			}, nil)
+
+			appRepo.GetAppReturns(repositories.AppRecord{
+				SpaceGUID: destSpaceGUID,
+				GUID:      destAppGUID,
+			}, nil)
+
+			packageRepo.CreatePackageReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   destAppGUID,
+				SpaceGUID: destSpaceGUID,
+				GUID:      packageGUID,
+				State:     repositories.PackageStateAwaitingUpload,
+				ImageRef:  "registry.repo/dest-packages",
+			}, nil)
+
+			imageRepo.CopySourceImageReturns("registry.repo/dest-packages@sha256:abc", nil)
+
+			packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   destAppGUID,
+				SpaceGUID: destSpaceGUID,
+				GUID:      packageGUID,
+				State:     repositories.PackageStateReady,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "POST", "/v3/packages?source_guid="+sourcePackageGUID, strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("copies the source package bits to a new package for the destination app", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualReq.URL.String()).To(HaveSuffix("source_guid=" + sourcePackageGUID))
+
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualPackageGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualPackageGUID).To(Equal(sourcePackageGUID))
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(destAppGUID))
+
+			Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+			_, _, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+			Expect(actualCreate).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   destAppGUID,
+				SpaceGUID: destSpaceGUID,
+			}))
+
+			Expect(imageRepo.CopySourceImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, srcRef, dstRef, actualSpaceGUID, actualTags := imageRepo.CopySourceImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(srcRef).To(Equal("registry.repo/src-packages@sha256:abc"))
+			Expect(dstRef).To(Equal("registry.repo/dest-packages"))
+			Expect(actualSpaceGUID).To(Equal(destSpaceGUID))
+			Expect(actualTags).To(ConsistOf(packageGUID))
+
+			Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+			_, _, message := packageRepo.UpdatePackageSourceArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdatePackageSourceMessage{
+				GUID:                packageGUID,
+				SpaceGUID:           destSpaceGUID,
+				ImageRef:            "registry.repo/dest-packages@sha256:abc",
+				RegistrySecretNames: packageImagePullSecretNames,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", packageGUID),
+				MatchJSONPath("$.state", "READY"),
+			)))
+		})
+
+		itDoesntCopyThePackage := func() {
+			It("doesn't copy the package", func() {
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(0))
+				Expect(imageRepo.CopySourceImageCallCount()).To(Equal(0))
+			})
+		}
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "test-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("test-error")
+			})
+			itDoesntCopyThePackage()
+		})
+
+		When("the request JSON is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "test-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("test-error")
+			})
+			itDoesntCopyThePackage()
+		})
+
+		When("the source package is not accessible", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.PackageResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Package")
+			})
+			itDoesntCopyThePackage()
+		})
+
+		When("the source package is not a bits package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "docker",
+					GUID:  sourcePackageGUID,
+					State: repositories.PackageStateReady,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Source package type must be bits.")
+			})
+			itDoesntCopyThePackage()
+		})
+
+		When("the source package has not been uploaded", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "bits",
+					GUID:  sourcePackageGUID,
+					State: repositories.PackageStateAwaitingUpload,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Source package has no bits to copy.")
+			})
+			itDoesntCopyThePackage()
+		})
+
+		When("the destination app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+			itDoesntCopyThePackage()
+		})
+
+		When("copying the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.CopySourceImageReturns("", apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+			})
+
+			It("doesn't update the package source", func() {
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("updating the package source fails", func() {
+			BeforeEach(func() {
+				packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the PATCH /v3/packages/:guid endpoint", func() {
 		BeforeEach(func() {
 			packageGUID = generateGUID("package")
@@ -541,6 +766,10 @@ var _ = Describe("Package", func() {
 			imageRefWithDigest = "some-org/the-package-guid@SHA256:some-sha-256"
 			imageRepo.UploadSourceImageReturns(imageRefWithDigest, nil)
 
+			resourceCache.AssembleStub = func(_ context.Context, bits io.ReaderAt, bitsSize int64, _ []resourcecache.Resource) (io.ReadCloser, error) {
+				return io.NopCloser(io.NewSectionReader(bits, 0, bitsSize)), nil
+			}
+
 			var b bytes.Buffer
 			writer := multipart.NewWriter(&b)
 			part, err := writer.CreateFormFile("bits", "unused.zip")
@@ -579,6 +808,11 @@ var _ = Describe("Package", func() {
 			Expect(actualTags).To(HaveLen(1))
 			Expect(actualTags[0]).To(Equal(packageGUID))
 
+			Expect(resourceCache.AssembleCallCount()).To(Equal(1))
+			_, _, actualBitsSize, actualResources := resourceCache.AssembleArgsForCall(0)
+			Expect(actualBitsSize).To(BeEquivalentTo(len("the-src-file-contents")))
+			Expect(actualResources).To(BeEmpty())
+
 			Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
 			_, actualAuthInfo, message := packageRepo.UpdatePackageSourceArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
@@ -656,6 +890,62 @@ var _ = Describe("Package", func() {
 			})
 			itDoesntUploadSourceImage()
 			itDoesntUpdateAnyPackages()
+
+			When("cached resources are given", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.PackageUpload{
+						Resources: []payloads.ResourceMatch{{
+							Checksum:    payloads.ResourceMatchChecksum{Value: "8b4f1a0bde3e4ed2e57ce3b1d6b6f6e9b2d2a1f0"},
+							SizeInBytes: 42,
+							Path:        "lib/app.js",
+						}},
+					})
+					resourceCache.AssembleReturns(io.NopCloser(strings.NewReader("the-assembled-contents")), nil)
+				})
+
+				It("uploads a package assembled from the cache", func() {
+					Expect(resourceCache.AssembleCallCount()).To(Equal(1))
+					_, actualBits, _, actualResources := resourceCache.AssembleArgsForCall(0)
+					Expect(actualBits).To(BeNil())
+					Expect(actualResources).To(Equal([]resourcecache.Resource{{
+						SHA1: "8b4f1a0bde3e4ed2e57ce3b1d6b6f6e9b2d2a1f0",
+						Size: 42,
+						Path: "lib/app.js",
+					}}))
+
+					Expect(imageRepo.UploadSourceImageCallCount()).To(Equal(1))
+					_, _, _, srcFile, _, _ := imageRepo.UploadSourceImageArgsForCall(0)
+					actualSrcContents, err := io.ReadAll(srcFile)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(actualSrcContents)).To(Equal("the-assembled-contents"))
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				})
+			})
+		})
+
+		When("the form values are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid resources"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid resources")
+			})
+			itDoesntUploadSourceImage()
+			itDoesntUpdateAnyPackages()
+		})
+
+		When("assembling the package bits fails", func() {
+			BeforeEach(func() {
+				resourceCache.AssembleReturns(nil, apierrors.NewUnprocessableEntityError(nil, "Resource \"lib/app.js\" has not been cached and must be uploaded"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Resource \"lib/app.js\" has not been cached and must be uploaded")
+			})
+			itDoesntUploadSourceImage()
+			itDoesntUpdateAnyPackages()
 		})
 
 		When("preparing to upload the source image errors", func() {
@@ -740,6 +1030,98 @@ var _ = Describe("Package", func() {
 		})
 	})
 
+	Describe("the GET /v3/packages/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				Type:           "bits",
+				AppGUID:        appGUID,
+				SpaceGUID:      spaceGUID,
+				GUID:           packageGUID,
+				State:          repositories.PackageStateReady,
+				SourceImageRef: "registry.repo/foo@sha256:abc",
+			}, nil)
+
+			imageRepo.DownloadSourceImageReturns(io.NopCloser(strings.NewReader("the-zip-contents")), nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/v3/packages/%s/download", packageGUID), nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("streams the package bits as a zip", func() {
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualPackageGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualPackageGUID).To(Equal(packageGUID))
+
+			Expect(imageRepo.DownloadSourceImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualImageRef, actualSpaceGUID := imageRepo.DownloadSourceImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualImageRef).To(Equal("registry.repo/foo@sha256:abc"))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/zip"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", fmt.Sprintf("attachment; filename=%q", packageGUID+".zip")))
+			Expect(rr).To(HaveHTTPBody("the-zip-contents"))
+		})
+
+		When("getting the package is forbidden", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.PackageResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Package")
+			})
+		})
+
+		When("the package is not a bits package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "docker",
+					GUID:  packageGUID,
+					State: repositories.PackageStateReady,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Package type must be bits.")
+			})
+		})
+
+		When("the package has not been uploaded", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "bits",
+					GUID:  packageGUID,
+					State: repositories.PackageStateAwaitingUpload,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Package has no bits to download.")
+			})
+
+			It("doesn't download anything", func() {
+				Expect(imageRepo.DownloadSourceImageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("downloading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadSourceImageReturns(nil, apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+			})
+		})
+	})
+
 	Describe("the GET /v3/packages/:guid/droplets endpoint", func() {
 		var dropletGUID string
 		var queryString string
@@ -21,7 +21,6 @@ import (
 
 const (
 	ProcessPath                = "/v3/processes/{guid}"
-	ProcessSidecarsPath        = "/v3/processes/{guid}/sidecars"
 	ProcessScalePath           = "/v3/processes/{guid}/actions/scale"
 	ProcessStatsPath           = "/v3/processes/{guid}/stats"
 	ProcessesPath              = "/v3/processes"
@@ -134,34 +133,6 @@ func (h *Process) restartProcessInstance(r *http.Request) (*routing.Response, er
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
-func (h *Process) getSidecars(r *http.Request) (*routing.Response, error) {
-	authInfo, _ := authorization.InfoFromContext(r.Context())
-	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.get-sidecars")
-
-	processGUID := routing.URLParam(r, "guid")
-
-	_, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
-	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
-	}
-
-	return routing.NewResponse(http.StatusOK).WithBody(map[string]interface{}{
-		"pagination": map[string]interface{}{
-			"total_results": 0,
-			"total_pages":   1,
-			"first": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"last": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"next":     nil,
-			"previous": nil,
-		},
-		"resources": []string{},
-	}), nil
-}
-
 func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.scale")
@@ -262,7 +233,6 @@ func (h *Process) UnauthenticatedRoutes() []routing.Route {
 func (h *Process) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: ProcessPath, Handler: h.get},
-		{Method: "GET", Pattern: ProcessSidecarsPath, Handler: h.getSidecars},
 		{Method: "POST", Pattern: ProcessScalePath, Handler: h.scale},
 		{Method: "GET", Pattern: ProcessStatsPath, Handler: h.getStats},
 		{Method: "GET", Pattern: ProcessesPath, Handler: h.list},
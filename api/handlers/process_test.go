@@ -95,51 +95,6 @@ var _ = Describe("Process", func() {
 		})
 	})
 
-	Describe("the GET /v3/processes/:guid/sidecars endpoint", func() {
-		BeforeEach(func() {
-			processRepo.GetProcessReturns(repositories.ProcessRecord{}, nil)
-		})
-
-		JustBeforeEach(func() {
-			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/processes/process-guid/sidecars", nil)
-			Expect(err).NotTo(HaveOccurred())
-			routerBuilder.Build().ServeHTTP(rr, req)
-		})
-
-		It("returns the empty list of sidecars", func() {
-			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
-			_, actualAuthInfo, _ := processRepo.GetProcessArgsForCall(0)
-			Expect(actualAuthInfo).To(Equal(authInfo))
-
-			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
-			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars"),
-			)))
-		})
-
-		When("the process isn't accessible to the user", func() {
-			BeforeEach(func() {
-				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
-			})
-
-			It("returns an error", func() {
-				expectNotFoundError("Process")
-			})
-		})
-
-		When("there is some other error fetching the process", func() {
-			BeforeEach(func() {
-				processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
-			})
-
-			It("returns an error", func() {
-				expectUnknownError()
-			})
-		})
-	})
-
 	Describe("the POST /v3/processes/:guid/actions/scale endpoint", func() {
 		BeforeEach(func() {
 			processRepo.GetProcessReturns(repositories.ProcessRecord{
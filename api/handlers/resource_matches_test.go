@@ -1,20 +1,31 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/resourcecache"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("ResourceMatches", func() {
-	var req *http.Request
+	var (
+		req              *http.Request
+		resourceCache    *fake.ResourceCache
+		requestValidator *fake.RequestValidator
+	)
 
 	BeforeEach(func() {
-		apiHandler := NewResourceMatches()
+		resourceCache = new(fake.ResourceCache)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewResourceMatches(resourceCache, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -22,19 +33,86 @@ var _ = Describe("ResourceMatches", func() {
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
-	Describe("Get Resource Match Endpoint", func() {
+	Describe("POST /v3/resource_matches", func() {
 		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ResourceMatchesCreate{
+				Resources: []payloads.ResourceMatch{
+					{
+						Checksum:    payloads.ResourceMatchChecksum{Value: "8b4f1a0bde3e4ed2e57ce3b1d6b6f6e9b2d2a1f0"},
+						SizeInBytes: 42,
+						Path:        "lib/app.js",
+						Mode:        "644",
+					},
+					{
+						Checksum:    payloads.ResourceMatchChecksum{Value: "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"},
+						SizeInBytes: 7,
+						Path:        "index.js",
+						Mode:        "644",
+					},
+				},
+			})
+
+			resourceCache.MatchReturns([]resourcecache.Resource{{
+				SHA1: "8b4f1a0bde3e4ed2e57ce3b1d6b6f6e9b2d2a1f0",
+				Size: 42,
+				Path: "lib/app.js",
+				Mode: 0o644,
+			}}, nil)
+
 			var err error
-			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("{}"))
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("the-json-body"))
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("returns an empty list", func() {
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("matches the resources against the cache", func() {
+			Expect(resourceCache.MatchCallCount()).To(Equal(1))
+			_, actualResources := resourceCache.MatchArgsForCall(0)
+			Expect(actualResources).To(HaveLen(2))
+			Expect(actualResources[0].Path).To(Equal("lib/app.js"))
+			Expect(actualResources[1].Path).To(Equal("index.js"))
+		})
+
+		It("returns the matching resources", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-				"resources": []
-			  }`)))
+				"resources": [{
+					"checksum": {"value": "8b4f1a0bde3e4ed2e57ce3b1d6b6f6e9b2d2a1f0"},
+					"size_in_bytes": 42,
+					"path": "lib/app.js",
+					"mode": "644"
+				}]
+			}`)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+
+			It("does not match anything", func() {
+				Expect(resourceCache.MatchCallCount()).To(BeZero())
+			})
+		})
+
+		When("matching the resources fails", func() {
+			BeforeEach(func() {
+				resourceCache.MatchReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
 		})
 	})
 })
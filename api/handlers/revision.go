@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppRevisionsPath         = "/v3/apps/{appGUID}/revisions"
+	AppDeployedRevisionsPath = "/v3/apps/{appGUID}/revisions/deployed"
+	RevisionPath             = "/v3/revisions/{guid}"
+	RevisionEnvVarsPath      = "/v3/revisions/{guid}/environment_variables"
+)
+
+//counterfeiter:generate -o fake -fake-name CFRevisionRepository . CFRevisionRepository
+type CFRevisionRepository interface {
+	GetRevision(context.Context, authorization.Info, string) (repositories.RevisionRecord, error)
+	ListRevisions(context.Context, authorization.Info, repositories.ListRevisionsMessage) ([]repositories.RevisionRecord, error)
+	GetRevisionEnvVars(context.Context, authorization.Info, string) (repositories.RevisionEnvVarsRecord, error)
+}
+
+type Revision struct {
+	serverURL        url.URL
+	appRepo          CFAppRepository
+	revisionRepo     CFRevisionRepository
+	requestValidator RequestValidator
+}
+
+func NewRevision(
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	revisionRepo CFRevisionRepository,
+	requestValidator RequestValidator,
+) *Revision {
+	return &Revision{
+		serverURL:        serverURL,
+		appRepo:          appRepo,
+		revisionRepo:     revisionRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *Revision) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.get")
+
+	revisionGUID := routing.URLParam(r, "guid")
+
+	revision, err := h.revisionRepo.GetRevision(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get revision", "GUID", revisionGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRevision(revision, h.serverURL)), nil
+}
+
+func (h *Revision) getEnvVars(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.get-env-vars")
+
+	revisionGUID := routing.URLParam(r, "guid")
+
+	envVars, err := h.revisionRepo.GetRevisionEnvVars(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get revision environment variables", "GUID", revisionGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRevisionEnvVars(envVars, h.serverURL)), nil
+}
+
+func (h *Revision) listForApp(r *http.Request) (*routing.Response, error) {
+	return h.list(r, "handlers.revision.list-for-app", false)
+}
+
+func (h *Revision) listDeployedForApp(r *http.Request) (*routing.Response, error) {
+	return h.list(r, "handlers.revision.list-deployed-for-app", true)
+}
+
+func (h *Revision) list(r *http.Request, loggerName string, deployedOnly bool) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName(loggerName)
+
+	appGUID := routing.URLParam(r, "appGUID")
+
+	if _, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get app", "appGUID", appGUID)
+	}
+
+	payload := new(payloads.RevisionList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	message := payload.ToMessage(appGUID)
+	if deployedOnly {
+		message.Deployed = tools.PtrTo(true)
+	}
+
+	revisions, err := h.revisionRepo.ListRevisions(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list revisions", "appGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRevision, revisions, h.serverURL, *r.URL)), nil
+}
+
+func (h *Revision) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Revision) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppRevisionsPath, Handler: h.listForApp},
+		{Method: "GET", Pattern: AppDeployedRevisionsPath, Handler: h.listDeployedForApp},
+		{Method: "GET", Pattern: RevisionPath, Handler: h.get},
+		{Method: "GET", Pattern: RevisionEnvVarsPath, Handler: h.getEnvVars},
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -41,6 +42,7 @@ type Route struct {
 	domainRepo       CFDomainRepository
 	appRepo          CFAppRepository
 	spaceRepo        CFSpaceRepository
+	routerGroupRepo  CFRouterGroupRepository
 	requestValidator RequestValidator
 }
 
@@ -50,6 +52,7 @@ func NewRoute(
 	domainRepo CFDomainRepository,
 	appRepo CFAppRepository,
 	spaceRepo CFSpaceRepository,
+	routerGroupRepo CFRouterGroupRepository,
 	requestValidator RequestValidator,
 ) *Route {
 	return &Route{
@@ -58,6 +61,7 @@ func NewRoute(
 		domainRepo:       domainRepo,
 		appRepo:          appRepo,
 		spaceRepo:        spaceRepo,
+		routerGroupRepo:  routerGroupRepo,
 		requestValidator: requestValidator,
 	}
 }
@@ -146,7 +150,12 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
-	createRouteMessage := payload.ToMessage(domain.Namespace, domain.Name)
+	err = h.validateRoutePort(r.Context(), authInfo, domain, payload.Port)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Invalid route port", "Domain GUID", domainGUID)
+	}
+
+	createRouteMessage := payload.ToMessage(domain)
 	responseRouteRecord, err := h.routeRepo.CreateRoute(r.Context(), authInfo, createRouteMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create route", "Route Host", payload.Host)
@@ -157,6 +166,30 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForRoute(responseRouteRecord, h.serverURL)), nil
 }
 
+func (h *Route) validateRoutePort(ctx context.Context, authInfo authorization.Info, domain repositories.DomainRecord, port *int32) error {
+	if domain.RouterGroup == "" {
+		if port != nil {
+			return apierrors.NewUnprocessableEntityError(nil, "Routes with protocol 'http' do not support ports.")
+		}
+		return nil
+	}
+
+	if port == nil {
+		return apierrors.NewUnprocessableEntityError(nil, "Ports are required for TCP routes.")
+	}
+
+	routerGroup, err := h.routerGroupRepo.GetRouterGroup(ctx, authInfo, domain.RouterGroup)
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(err, "Router group of the domain not found.", apierrors.NotFoundError{})
+	}
+
+	if !routerGroup.ReservesPort(*port) {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Port %d is not available in router group '%s'.", *port, routerGroup.Name))
+	}
+
+	return nil
+}
+
 func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.insert-destinations")
@@ -25,6 +25,7 @@ var _ = Describe("Route", func() {
 		domainRepo       *fake.CFDomainRepository
 		appRepo          *fake.CFAppRepository
 		spaceRepo        *fake.CFSpaceRepository
+		routerGroupRepo  *fake.CFRouterGroupRepository
 		requestValidator *fake.RequestValidator
 
 		requestMethod string
@@ -64,6 +65,8 @@ var _ = Describe("Route", func() {
 			Name: "test-space-guid",
 		}, nil)
 
+		routerGroupRepo = new(fake.CFRouterGroupRepository)
+
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewRoute(
@@ -72,6 +75,7 @@ var _ = Describe("Route", func() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			routerGroupRepo,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -250,6 +254,8 @@ var _ = Describe("Route", func() {
 	})
 
 	Describe("the POST /v3/routes endpoint", func() {
+		var payload *payloads.RouteCreate
+
 		BeforeEach(func() {
 			requestMethod = http.MethodPost
 			requestPath = "/v3/routes"
@@ -267,7 +273,7 @@ var _ = Describe("Route", func() {
 
 			requestBody = "the-json-body"
 
-			payload := payloads.RouteCreate{
+			payload = &payloads.RouteCreate{
 				Host: "test-route-host",
 				Path: "/test-route-path",
 				Relationships: &payloads.RouteRelationships{
@@ -283,7 +289,7 @@ var _ = Describe("Route", func() {
 					Annotations: map[string]string{"annotation-key": "annotation-val"},
 				},
 			}
-			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
 		})
 
 		It("creates the route", func() {
@@ -362,6 +368,92 @@ var _ = Describe("Route", func() {
 			})
 		})
 
+		When("the route has a port", func() {
+			BeforeEach(func() {
+				payload.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Routes with protocol 'http' do not support ports.")
+			})
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{
+					GUID:        "test-domain-guid",
+					Name:        "tcp.example.org",
+					RouterGroup: "default-tcp",
+				}, nil)
+				routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{
+					GUID:            "default-tcp",
+					Name:            "default-tcp",
+					ReservablePorts: []int32{1024, 1025},
+				}, nil)
+				routeRepo.CreateRouteReturns(repositories.RouteRecord{
+					GUID:      "test-route-guid",
+					SpaceGUID: "test-space-guid",
+					Domain: repositories.DomainRecord{
+						GUID: "test-domain-guid",
+					},
+					Protocol: "tcp",
+					Port:     tools.PtrTo[int32](1024),
+				}, nil)
+
+				payload.Host = ""
+				payload.Path = ""
+				payload.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("creates a tcp route", func() {
+				Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+				_, _, actualRouterGroupGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+				Expect(actualRouterGroupGUID).To(Equal("default-tcp"))
+
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+				Expect(createRouteMessage.Port).To(PointTo(BeEquivalentTo(1024)))
+				Expect(createRouteMessage.RouterGroup).To(Equal("default-tcp"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.protocol", "tcp"),
+					MatchJSONPath("$.port", BeEquivalentTo(1024)),
+					MatchJSONPath("$.url", "tcp.example.org:1024"),
+				)))
+			})
+
+			When("the route has no port", func() {
+				BeforeEach(func() {
+					payload.Port = nil
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Ports are required for TCP routes.")
+				})
+			})
+
+			When("the port is not reservable in the router group", func() {
+				BeforeEach(func() {
+					payload.Port = tools.PtrTo[int32](2000)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Port 2000 is not available in router group 'default-tcp'.")
+				})
+			})
+
+			When("the router group does not exist", func() {
+				BeforeEach(func() {
+					routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Router group of the domain not found.")
+				})
+			})
+		})
+
 		When("the domain does not exist", func() {
 			BeforeEach(func() {
 				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewNotFoundError(nil, repositories.DomainResourceType))
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	RouterGroupsPath = "/v3/router_groups"
+	RouterGroupPath  = "/v3/router_groups/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFRouterGroupRepository . CFRouterGroupRepository
+
+type CFRouterGroupRepository interface {
+	GetRouterGroup(context.Context, authorization.Info, string) (repositories.RouterGroupRecord, error)
+	ListRouterGroups(context.Context, authorization.Info, repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error)
+}
+
+type RouterGroup struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	routerGroupRepo  CFRouterGroupRepository
+}
+
+func NewRouterGroup(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	routerGroupRepo CFRouterGroupRepository,
+) *RouterGroup {
+	return &RouterGroup{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		routerGroupRepo:  routerGroupRepo,
+	}
+}
+
+func (h *RouterGroup) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.router-group.get")
+
+	routerGroupGUID := routing.URLParam(r, "guid")
+
+	routerGroup, err := h.routerGroupRepo.GetRouterGroup(r.Context(), authInfo, routerGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to get router group", "guid", routerGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouterGroup(routerGroup, h.serverURL)), nil
+}
+
+func (h *RouterGroup) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.router-group.list")
+
+	routerGroupListFilter := new(payloads.RouterGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, routerGroupListFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	routerGroups, err := h.routerGroupRepo.ListRouterGroups(r.Context(), authInfo, routerGroupListFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list router groups")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRouterGroup, routerGroups, h.serverURL, *r.URL)), nil
+}
+
+func (h *RouterGroup) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *RouterGroup) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: RouterGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: RouterGroupPath, Handler: h.get},
+	}
+}
@@ -0,0 +1,115 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroup", func() {
+	var (
+		routerGroupRepo  *fake.CFRouterGroupRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		routerGroupRepo = new(fake.CFRouterGroupRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewRouterGroup(*serverURL, requestValidator, routerGroupRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/router_groups/:guid endpoint", func() {
+		BeforeEach(func() {
+			routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{
+				GUID:            "default-tcp",
+				Name:            "default-tcp",
+				Type:            "tcp",
+				ReservablePorts: []int32{1024, 1025},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/router_groups/default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the router group", func() {
+			Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("default-tcp"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "default-tcp"),
+				MatchJSONPath("$.type", "tcp"),
+				MatchJSONPath("$.reservable_ports", "1024,1025"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/router_groups/default-tcp"),
+			)))
+		})
+
+		When("the router group does not exist", func() {
+			BeforeEach(func() {
+				routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RouterGroupResourceType)
+			})
+		})
+	})
+
+	Describe("the GET /v3/router_groups endpoint", func() {
+		BeforeEach(func() {
+			routerGroupRepo.ListRouterGroupsReturns([]repositories.RouterGroupRecord{
+				{GUID: "default-tcp", Name: "default-tcp", Type: "tcp"},
+			}, nil)
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouterGroupList{
+				Names: "default-tcp",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/router_groups?names=default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the router groups", func() {
+			Expect(routerGroupRepo.ListRouterGroupsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := routerGroupRepo.ListRouterGroupsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.Names).To(ConsistOf("default-tcp"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "default-tcp"),
+			)))
+		})
+
+		When("listing the router groups fails", func() {
+			BeforeEach(func() {
+				routerGroupRepo.ListRouterGroupsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
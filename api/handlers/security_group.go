@@ -18,8 +18,13 @@ import (
 )
 
 const (
-	SecurityGroupsPath = "/v3/security_groups"
-	spaceNotFoundErr   = "Space does not exist, or you do not have access."
+	SecurityGroupsPath             = "/v3/security_groups"
+	SecurityGroupPath              = "/v3/security_groups/{guid}"
+	SecurityGroupRunningSpacesPath = "/v3/security_groups/{guid}/relationships/running_spaces"
+	SecurityGroupRunningSpacePath  = "/v3/security_groups/{guid}/relationships/running_spaces/{space-guid}"
+	SecurityGroupStagingSpacesPath = "/v3/security_groups/{guid}/relationships/staging_spaces"
+	SecurityGroupStagingSpacePath  = "/v3/security_groups/{guid}/relationships/staging_spaces/{space-guid}"
+	spaceNotFoundErr               = "Space does not exist, or you do not have access."
 )
 
 type SecurityGroup struct {
@@ -32,6 +37,12 @@ type SecurityGroup struct {
 //counterfeiter:generate -o fake -fake-name CFSecurityGroupRepository . CFSecurityGroupRepository
 type CFSecurityGroupRepository interface {
 	CreateSecurityGroup(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	GetSecurityGroup(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)
+	ListSecurityGroups(context.Context, authorization.Info, repositories.ListSecurityGroupMessage) ([]repositories.SecurityGroupRecord, error)
+	UpdateSecurityGroup(context.Context, authorization.Info, repositories.UpdateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	DeleteSecurityGroup(context.Context, authorization.Info, string) error
+	BindSecurityGroup(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	UnbindSecurityGroup(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
 }
 
 func NewSecurityGroup(
@@ -83,6 +94,151 @@ func (h *SecurityGroup) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
 }
 
+func (h *SecurityGroup) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.get")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	securityGroup, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "GUID", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.list")
+
+	payload := new(payloads.SecurityGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	securityGroups, err := h.securityGroupRepo.ListSecurityGroups(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list security groups")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSecurityGroup, securityGroups, h.serverURL, *r.URL)), nil
+}
+
+func (h *SecurityGroup) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.update")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.SecurityGroupUpdate)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "GUID", securityGroupGUID)
+	}
+
+	securityGroup, err := h.securityGroupRepo.UpdateSecurityGroup(r.Context(), authInfo, payload.ToMessage(securityGroupGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update security group", "GUID", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.delete")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	if err := h.securityGroupRepo.DeleteSecurityGroup(r.Context(), authInfo, securityGroupGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to delete security group", "GUID", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(securityGroupGUID, presenter.SecurityGroupDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *SecurityGroup) bindRunningSpaces(r *http.Request) (*routing.Response, error) {
+	return h.bind(r, repositories.SecurityGroupRunningSpaces)
+}
+
+func (h *SecurityGroup) bindStagingSpaces(r *http.Request) (*routing.Response, error) {
+	return h.bind(r, repositories.SecurityGroupStagingSpaces)
+}
+
+func (h *SecurityGroup) bind(r *http.Request, workloadType string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.bind").WithValues("workloadType", workloadType)
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.SecurityGroupBind)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "GUID", securityGroupGUID)
+	}
+
+	spaceGUIDs := slices.Compact(slices.Sorted(slices.Values(payload.SpaceGUIDs())))
+	spaces, err := h.spaceRepo.ListSpaces(r.Context(), authInfo, repositories.ListSpacesMessage{GUIDs: spaceGUIDs})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list spaces for binding to security group")
+	}
+
+	if len(spaces) != len(spaceGUIDs) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(fmt.Errorf("failed to bind security group"), spaceNotFoundErr),
+			spaceNotFoundErr,
+		)
+	}
+
+	securityGroup, err := h.securityGroupRepo.BindSecurityGroup(r.Context(), authInfo, payload.ToMessage(securityGroupGUID, workloadType))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to bind security group", "GUID", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupSpaces(securityGroup, workloadType, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) unbindRunningSpace(r *http.Request) (*routing.Response, error) {
+	return h.unbind(r, repositories.SecurityGroupRunningSpaces)
+}
+
+func (h *SecurityGroup) unbindStagingSpace(r *http.Request) (*routing.Response, error) {
+	return h.unbind(r, repositories.SecurityGroupStagingSpaces)
+}
+
+func (h *SecurityGroup) unbind(r *http.Request, workloadType string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.unbind").WithValues("workloadType", workloadType)
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space-guid")
+
+	if _, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "GUID", securityGroupGUID)
+	}
+
+	if _, err := h.securityGroupRepo.UnbindSecurityGroup(r.Context(), authInfo, repositories.UnbindSecurityGroupMessage{
+		GUID:         securityGroupGUID,
+		SpaceGUID:    spaceGUID,
+		WorkloadType: workloadType,
+	}); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unbind security group", "GUID", securityGroupGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
 func (h *SecurityGroup) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -90,5 +246,13 @@ func (h *SecurityGroup) UnauthenticatedRoutes() []routing.Route {
 func (h *SecurityGroup) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "POST", Pattern: SecurityGroupsPath, Handler: h.create},
+		{Method: "GET", Pattern: SecurityGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: SecurityGroupPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SecurityGroupPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SecurityGroupPath, Handler: h.delete},
+		{Method: "POST", Pattern: SecurityGroupRunningSpacesPath, Handler: h.bindRunningSpaces},
+		{Method: "DELETE", Pattern: SecurityGroupRunningSpacePath, Handler: h.unbindRunningSpace},
+		{Method: "POST", Pattern: SecurityGroupStagingSpacesPath, Handler: h.bindStagingSpaces},
+		{Method: "DELETE", Pattern: SecurityGroupStagingSpacePath, Handler: h.unbindStagingSpace},
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -185,4 +186,372 @@ var _ = Describe("SecurityGroup", func() {
 			})
 		})
 	})
+
+	Describe("GET /v3/security_groups/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/security_groups/sg-guid"
+			requestBody = ""
+
+			securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:          "sg-guid",
+				Name:          "test-security-group",
+				RunningSpaces: []string{"space1"},
+			}, nil)
+		})
+
+		It("returns the security group", func() {
+			Expect(securityGroupRepo.GetSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.GetSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sg-guid"),
+				MatchJSONPath("$.name", "test-security-group"),
+				MatchJSONPath("$.relationships.running_spaces.data[0].guid", "space1"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid"),
+			)))
+		})
+
+		When("the security group is not accessible", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/security_groups"
+			requestBody = ""
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SecurityGroupList{
+				Names:                  "sg1,sg2",
+				GloballyEnabledRunning: tools.PtrTo(true),
+				RunningSpaceGUIDs:      "space1",
+			})
+
+			securityGroupRepo.ListSecurityGroupsReturns([]repositories.SecurityGroupRecord{
+				{GUID: "sg1-guid", Name: "sg1"},
+				{GUID: "sg2-guid", Name: "sg2"},
+			}, nil)
+		})
+
+		It("lists the security groups", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+
+			Expect(securityGroupRepo.ListSecurityGroupsCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage).To(Equal(repositories.ListSecurityGroupMessage{
+				Names:                  []string{"sg1", "sg2"},
+				GloballyEnabledRunning: tools.PtrTo(true),
+				RunningSpaceGUIDs:      []string{"space1"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/security_groups"),
+				MatchJSONPath("$.resources[0].guid", "sg1-guid"),
+				MatchJSONPath("$.resources[1].guid", "sg2-guid"),
+			)))
+		})
+
+		When("the query parameters are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				securityGroupRepo.ListSecurityGroupsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/security_groups/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/security_groups/sg-guid"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupUpdate{
+				DisplayName: tools.PtrTo("new-name"),
+				GloballyEnabled: payloads.SecurityGroupWorkloadsUpdate{
+					Staging: tools.PtrTo(true),
+				},
+			})
+
+			securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID: "sg-guid",
+				Name: "new-name",
+			}, nil)
+		})
+
+		It("updates the security group", func() {
+			Expect(securityGroupRepo.UpdateSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, updateMessage := securityGroupRepo.UpdateSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(updateMessage).To(Equal(repositories.UpdateSecurityGroupMessage{
+				GUID:        "sg-guid",
+				DisplayName: tools.PtrTo("new-name"),
+				GloballyEnabled: repositories.UpdateSecurityGroupWorkloads{
+					Staging: tools.PtrTo(true),
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sg-guid"),
+				MatchJSONPath("$.name", "new-name"),
+			)))
+		})
+
+		When("the security group is not accessible", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.UpdateSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("the request body is not valid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/security_groups/sg-guid"
+			requestBody = ""
+		})
+
+		It("deletes the security group", func() {
+			Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.DeleteSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/security_group.delete~sg-guid"))
+		})
+
+		When("the security group is not accessible", func() {
+			BeforeEach(func() {
+				securityGroupRepo.DeleteSecurityGroupReturns(apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/{guid}/relationships/running_spaces", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/security_groups/sg-guid/relationships/running_spaces"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				Data: []payloads.RelationshipData{{GUID: "space1"}, {GUID: "space2"}},
+			})
+			spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "space1"}, {GUID: "space2"}}, nil)
+			securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:          "sg-guid",
+				RunningSpaces: []string{"space1", "space2"},
+			}, nil)
+		})
+
+		It("binds the security group to the running spaces", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listSpacesMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listSpacesMessage.GUIDs).To(ConsistOf("space1", "space2"))
+
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, bindMessage := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(bindMessage).To(Equal(repositories.BindSecurityGroupMessage{
+				GUID:         "sg-guid",
+				SpaceGUIDs:   []string{"space1", "space2"},
+				WorkloadType: repositories.SecurityGroupRunningSpaces,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("space1", "space2")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid/relationships/running_spaces"),
+			)))
+		})
+
+		When("a space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "space1"}}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Space does not exist, or you do not have access.")
+				Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("the security group is not accessible", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+			})
+		})
+
+		When("the request body is not valid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/{guid}/relationships/staging_spaces", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/security_groups/sg-guid/relationships/staging_spaces"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				Data: []payloads.RelationshipData{{GUID: "space1"}},
+			})
+			spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "space1"}}, nil)
+			securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:          "sg-guid",
+				StagingSpaces: []string{"space1"},
+			}, nil)
+		})
+
+		It("binds the security group to the staging spaces", func() {
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, _, bindMessage := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(bindMessage.WorkloadType).To(Equal(repositories.SecurityGroupStagingSpaces))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("space1")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid/relationships/staging_spaces"),
+			)))
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/{guid}/relationships/running_spaces/{space-guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/security_groups/sg-guid/relationships/running_spaces/space1"
+			requestBody = ""
+		})
+
+		It("unbinds the security group from the running space", func() {
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, unbindMessage := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(unbindMessage).To(Equal(repositories.UnbindSecurityGroupMessage{
+				GUID:         "sg-guid",
+				SpaceGUID:    "space1",
+				WorkloadType: repositories.SecurityGroupRunningSpaces,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the security group is not accessible", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UnbindSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/{guid}/relationships/staging_spaces/{space-guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/security_groups/sg-guid/relationships/staging_spaces/space1"
+			requestBody = ""
+		})
+
+		It("unbinds the security group from the staging space", func() {
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, _, unbindMessage := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(unbindMessage.WorkloadType).To(Equal(repositories.SecurityGroupStagingSpaces))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/go-logr/logr"
 
@@ -21,6 +22,7 @@ const (
 	ServiceBindingPath        = "/v3/service_credential_bindings/{guid}"
 	ServiceBindingDetailsPath = "/v3/service_credential_bindings/{guid}/details"
 	ServiceBindingParamsPath  = "/v3/service_credential_bindings/{guid}/parameters"
+	ServiceBindingRotatePath  = "/v3/service_credential_bindings/{guid}/actions/rotate"
 )
 
 type ServiceBinding struct {
@@ -40,6 +42,7 @@ type CFServiceBindingRepository interface {
 	UpdateServiceBinding(context.Context, authorization.Info, repositories.UpdateServiceBindingMessage) (repositories.ServiceBindingRecord, error)
 	GetServiceBindingDetails(context.Context, authorization.Info, string) (repositories.ServiceBindingDetailsRecord, error)
 	GetServiceBindingParameters(context.Context, authorization.Info, string) (map[string]any, error)
+	RotateServiceBindingCredentials(context.Context, authorization.Info, repositories.RotateServiceBindingCredentialsMessage) (repositories.ServiceBindingRecord, error)
 }
 
 func NewServiceBinding(serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, requestValidator RequestValidator) *ServiceBinding {
@@ -68,13 +71,15 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 
 	ctx := logr.NewContext(r.Context(), logger.WithValues("service-instance", serviceInstance.GUID))
 
+	bindingSpaceGUID := serviceInstance.SpaceGUID
 	if payload.Type == korifiv1alpha1.CFServiceBindingTypeApp {
 		var app repositories.AppRecord
 		if app, err = h.appRepo.GetApp(ctx, authInfo, payload.Relationships.App.Data.GUID); err != nil {
 			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.AppResourceType)
 		}
 
-		if app.SpaceGUID != serviceInstance.SpaceGUID {
+		bindingSpaceGUID = app.SpaceGUID
+		if app.SpaceGUID != serviceInstance.SpaceGUID && !slices.Contains(serviceInstance.SharedSpaceGUIDs, app.SpaceGUID) {
 			return nil, apierrors.LogAndReturn(
 				logger,
 				apierrors.NewUnprocessableEntityError(nil, "The service instance and the app are in different spaces"),
@@ -85,13 +90,13 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 	}
 
 	if serviceInstance.Type == korifiv1alpha1.UserProvidedType {
-		return h.createUserProvided(ctx, &payload, serviceInstance)
+		return h.createUserProvided(ctx, &payload, bindingSpaceGUID)
 	}
 
-	return h.createManaged(ctx, &payload, serviceInstance)
+	return h.createManaged(ctx, &payload, bindingSpaceGUID)
 }
 
-func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloads.ServiceBindingCreate, serviceInstance repositories.ServiceInstanceRecord) (*routing.Response, error) {
+func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloads.ServiceBindingCreate, spaceGUID string) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.service-binding.create-user-provided")
 
@@ -103,7 +108,7 @@ func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloa
 		)
 	}
 
-	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
+	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(spaceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logr.FromContextOrDiscard(ctx), err, "failed to create ServiceBinding")
 	}
@@ -111,11 +116,11 @@ func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloa
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
 }
 
-func (h *ServiceBinding) createManaged(ctx context.Context, payload *payloads.ServiceBindingCreate, serviceInstance repositories.ServiceInstanceRecord) (*routing.Response, error) {
+func (h *ServiceBinding) createManaged(ctx context.Context, payload *payloads.ServiceBindingCreate, spaceGUID string) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.service-binding.create-managed")
 
-	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
+	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(spaceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create ServiceBinding")
 	}
@@ -250,6 +255,31 @@ func (h *ServiceBinding) getParameters(r *http.Request) (*routing.Response, erro
 	return routing.NewResponse(http.StatusOK).WithBody(serviceBindingParams), nil
 }
 
+func (h *ServiceBinding) rotate(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.rotate")
+
+	serviceBindingGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.ServiceBindingRotate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.serviceBindingRepo.GetServiceBinding(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceBindingResourceType)
+	}
+
+	serviceBinding, err := h.serviceBindingRepo.RotateServiceBindingCredentials(r.Context(), authInfo, payload.ToMessage(serviceBindingGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to rotate service binding credentials", "guid", serviceBindingGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(serviceBinding.GUID, presenter.ManagedServiceBindingRotateOperation, h.serverURL)), nil
+}
+
 func (h *ServiceBinding) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -263,5 +293,6 @@ func (h *ServiceBinding) AuthenticatedRoutes() []routing.Route {
 		{Method: "PATCH", Pattern: ServiceBindingPath, Handler: h.update},
 		{Method: "GET", Pattern: ServiceBindingPath, Handler: h.get},
 		{Method: "GET", Pattern: ServiceBindingDetailsPath, Handler: h.getDetails},
+		{Method: "POST", Pattern: ServiceBindingRotatePath, Handler: h.rotate},
 	}
 }
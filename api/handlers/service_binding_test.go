@@ -278,6 +278,23 @@ var _ = Describe("ServiceBinding", func() {
 					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
 				})
 			})
+
+			When("the ServiceInstance is shared with the space of the App", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{SpaceGUID: spaceGUID}, nil)
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						SpaceGUID:        "another-space-guid",
+						Type:             korifiv1alpha1.UserProvidedType,
+						SharedSpaceGUIDs: []string{spaceGUID},
+					}, nil)
+				})
+
+				It("creates the ServiceBinding in the space of the App", func() {
+					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+					_, _, message := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+					Expect(message.SpaceGUID).To(Equal(spaceGUID))
+				})
+			})
 		})
 	})
 
@@ -613,6 +630,85 @@ var _ = Describe("ServiceBinding", func() {
 		})
 	})
 
+	Describe("POST /v3/service_credential_bindings/{guid}/actions/rotate", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/service_credential_bindings/service-binding-guid/actions/rotate"
+			requestBody = "the-json-body"
+
+			serviceBindingRepo.RotateServiceBindingCredentialsReturns(repositories.ServiceBindingRecord{
+				GUID: "service-binding-guid",
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceBindingRotate{
+				AppMode: "restart",
+			})
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("requests the credentials rotation", func() {
+			Expect(serviceBindingRepo.RotateServiceBindingCredentialsCallCount()).To(Equal(1))
+			_, actualAuthInfo, rotateMessage := serviceBindingRepo.RotateServiceBindingCredentialsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(rotateMessage).To(Equal(repositories.RotateServiceBindingCredentialsMessage{
+				GUID:    "service-binding-guid",
+				AppMode: "restart",
+			}))
+		})
+
+		It("returns a rotate job", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+				"https://api.example.org/v3/jobs/managed_service_binding.rotate~service-binding-guid"))
+		})
+
+		When("the payload cannot be decoded", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the service binding is not accessible", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(serviceBindingRepo.RotateServiceBindingCredentialsCallCount()).To(BeZero())
+				expectNotFoundError(repositories.ServiceBindingResourceType)
+			})
+		})
+
+		When("the user is not an admin", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.RotateServiceBindingCredentialsReturns(repositories.ServiceBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBindingResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("rotating the credentials fails", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.RotateServiceBindingCredentialsReturns(repositories.ServiceBindingRecord{}, errors.New("rotate-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("GET /v3/service_credential_bindings/{guid}/details", func() {
 		var serviceBindingGUID string = uuid.NewString()
 
@@ -15,8 +15,9 @@ import (
 )
 
 const (
-	ServiceBrokersPath = "/v3/service_brokers"
-	ServiceBrokerPath  = "/v3/service_brokers/{guid}"
+	ServiceBrokersPath       = "/v3/service_brokers"
+	ServiceBrokerPath        = "/v3/service_brokers/{guid}"
+	ServiceBrokerRefreshPath = "/v3/service_brokers/{guid}/actions/refresh"
 )
 
 //counterfeiter:generate -o fake -fake-name CFServiceBrokerRepository . CFServiceBrokerRepository
@@ -26,22 +27,26 @@ type CFServiceBrokerRepository interface {
 	GetServiceBroker(context.Context, authorization.Info, string) (repositories.ServiceBrokerRecord, error)
 	DeleteServiceBroker(context.Context, authorization.Info, string) error
 	UpdateServiceBroker(context.Context, authorization.Info, repositories.UpdateServiceBrokerMessage) (repositories.ServiceBrokerRecord, error)
+	RefreshServiceBrokerCatalog(context.Context, authorization.Info, string) (repositories.ServiceBrokerRecord, error)
 }
 
 type ServiceBroker struct {
 	serverURL         url.URL
 	serviceBrokerRepo CFServiceBrokerRepository
+	spaceRepo         CFSpaceRepository
 	requestValidator  RequestValidator
 }
 
 func NewServiceBroker(
 	serverURL url.URL,
 	serviceBrokerRepo CFServiceBrokerRepository,
+	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
 ) *ServiceBroker {
 	return &ServiceBroker{
 		serverURL:         serverURL,
 		serviceBrokerRepo: serviceBrokerRepo,
+		spaceRepo:         spaceRepo,
 		requestValidator:  requestValidator,
 	}
 }
@@ -55,6 +60,18 @@ func (h *ServiceBroker) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if payload.Relationships != nil {
+		spaceGUID := payload.Relationships.Space.Data.GUID
+		if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, "Invalid space. Ensure that the space exists and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"failed to get space",
+				"guid", spaceGUID,
+			)
+		}
+	}
+
 	broker, err := h.serviceBrokerRepo.CreateServiceBroker(r.Context(), authInfo, payload.ToMessage())
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create service broker")
@@ -141,6 +158,26 @@ func (h *ServiceBroker) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBroker(broker, h.serverURL)), nil
 }
 
+func (h *ServiceBroker) refresh(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-broker.refresh")
+
+	guid := routing.URLParam(r, "guid")
+
+	_, err := h.serviceBrokerRepo.GetServiceBroker(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service broker")
+	}
+
+	broker, err := h.serviceBrokerRepo.RefreshServiceBrokerCatalog(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to refresh service broker catalog", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(broker.GUID, presenter.ServiceBrokerCatalogSynchronizeOperation, h.serverURL)), nil
+}
+
 func (h *ServiceBroker) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -152,5 +189,6 @@ func (h *ServiceBroker) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: ServiceBrokerPath, Handler: h.get},
 		{Method: "DELETE", Pattern: ServiceBrokerPath, Handler: h.delete},
 		{Method: "PATCH", Pattern: ServiceBrokerPath, Handler: h.update},
+		{Method: "POST", Pattern: ServiceBrokerRefreshPath, Handler: h.refresh},
 	}
 }
@@ -20,6 +20,7 @@ import (
 var _ = Describe("ServiceBroker", func() {
 	var (
 		serviceBrokerRepo *fake.CFServiceBrokerRepository
+		spaceRepo         *fake.CFSpaceRepository
 		requestValidator  *fake.RequestValidator
 
 		req     *http.Request
@@ -28,10 +29,12 @@ var _ = Describe("ServiceBroker", func() {
 
 	BeforeEach(func() {
 		serviceBrokerRepo = new(fake.CFServiceBrokerRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
 		requestValidator = new(fake.RequestValidator)
 		handler = handlers.NewServiceBroker(
 			*serverURL,
 			serviceBrokerRepo,
+			spaceRepo,
 			requestValidator,
 		)
 	})
@@ -83,6 +86,40 @@ var _ = Describe("ServiceBroker", func() {
 			})
 		})
 
+		When("the broker is space-scoped", func() {
+			BeforeEach(func() {
+				payload.Relationships = &payloads.ServiceBrokerRelationships{
+					Space: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "space-guid"},
+					},
+				}
+			})
+
+			It("verifies the space is accessible", func() {
+				Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualSpaceGUID).To(Equal("space-guid"))
+			})
+
+			It("creates the broker in the space", func() {
+				Expect(serviceBrokerRepo.CreateServiceBrokerCallCount()).To(Equal(1))
+				_, _, actualCreateMsg := serviceBrokerRepo.CreateServiceBrokerArgsForCall(0)
+				Expect(actualCreateMsg.SpaceGUID).To(Equal("space-guid"))
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(serviceBrokerRepo.CreateServiceBrokerCallCount()).To(BeZero())
+					expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+				})
+			})
+		})
+
 		When("creating the service broker fails", func() {
 			BeforeEach(func() {
 				serviceBrokerRepo.CreateServiceBrokerReturns(repositories.ServiceBrokerRecord{}, errors.New("create-service-broker-error"))
@@ -360,4 +397,49 @@ var _ = Describe("ServiceBroker", func() {
 			})
 		})
 	})
+
+	Describe("POST /v3/service_brokers/guid/actions/refresh", func() {
+		BeforeEach(func() {
+			serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{GUID: "broker-guid"}, nil)
+			serviceBrokerRepo.RefreshServiceBrokerCatalogReturns(repositories.ServiceBrokerRecord{GUID: "broker-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/service_brokers/broker-guid/actions/refresh", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("requests a catalog refresh", func() {
+			Expect(serviceBrokerRepo.RefreshServiceBrokerCatalogCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualBrokerGUID := serviceBrokerRepo.RefreshServiceBrokerCatalogArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualBrokerGUID).To(Equal("broker-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/service_broker.catalog.synchronize~broker-guid"))
+		})
+
+		When("the user doesn't have permission to get the broker", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBrokerResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceBrokerResourceType)
+			})
+
+			It("does not request a refresh", func() {
+				Expect(serviceBrokerRepo.RefreshServiceBrokerCatalogCallCount()).To(Equal(0))
+			})
+		})
+
+		When("refreshing the catalog fails", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.RefreshServiceBrokerCatalogReturns(repositories.ServiceBrokerRecord{}, errors.New("refresh-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -21,9 +21,13 @@ import (
 )
 
 const (
-	ServiceInstancesPath           = "/v3/service_instances"
-	ServiceInstancePath            = "/v3/service_instances/{guid}"
-	ServiceInstanceCredentialsPath = "/v3/service_instances/{guid}/credentials"
+	ServiceInstancesPath                        = "/v3/service_instances"
+	ServiceInstancePath                         = "/v3/service_instances/{guid}"
+	ServiceInstanceCredentialsPath              = "/v3/service_instances/{guid}/credentials"
+	ServiceInstanceParamsPath                   = "/v3/service_instances/{guid}/parameters"
+	ServiceInstanceSharedSpacesPath             = "/v3/service_instances/{guid}/relationships/shared_spaces"
+	ServiceInstanceSharedSpacePath              = "/v3/service_instances/{guid}/relationships/shared_spaces/{space-guid}"
+	ServiceInstanceSharedSpacesUsageSummaryPath = "/v3/service_instances/{guid}/relationships/shared_spaces/usage_summary"
 )
 
 //counterfeiter:generate -o fake -fake-name CFServiceInstanceRepository . CFServiceInstanceRepository
@@ -34,7 +38,11 @@ type CFServiceInstanceRepository interface {
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 	GetServiceInstance(context.Context, authorization.Info, string) (repositories.ServiceInstanceRecord, error)
 	GetServiceInstanceCredentials(context.Context, authorization.Info, string) (map[string]any, error)
+	GetServiceInstanceParameters(context.Context, authorization.Info, string) (map[string]any, error)
 	DeleteServiceInstance(context.Context, authorization.Info, repositories.DeleteServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	ShareServiceInstance(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	UnshareServiceInstance(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error
+	GetSharedSpacesUsageSummary(context.Context, authorization.Info, string) ([]repositories.SharedSpaceUsageSummaryRecord, error)
 }
 
 type ServiceInstance struct {
@@ -112,6 +120,20 @@ func (h *ServiceInstance) getCredentials(r *http.Request) (*routing.Response, er
 	return routing.NewResponse(http.StatusOK).WithBody(credentials), nil
 }
 
+func (h *ServiceInstance) getParameters(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-parameters")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstanceParams, err := h.serviceInstanceRepo.GetServiceInstanceParameters(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance parameters", "GUID", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(serviceInstanceParams), nil
+}
+
 //nolint:dupl
 func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
@@ -191,6 +213,11 @@ func (h *ServiceInstance) patch(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to patch service instance")
 	}
 
+	if patchMessage.RequiresBrokerUpdate() {
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(serviceInstance.GUID, presenter.ManagedServiceInstanceUpdateOperation, h.serverURL)), nil
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstance(serviceInstance, h.serverURL)), nil
 }
 
@@ -240,6 +267,78 @@ func (h *ServiceInstance) delete(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
+func (h *ServiceInstance) getSharedSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-shared-spaces")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "GUID", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) share(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.share")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.ServiceInstanceShare)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "GUID", serviceInstanceGUID)
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.ShareServiceInstance(r.Context(), authInfo, payload.ToMessage(serviceInstanceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to share service instance", "GUID", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) unshare(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.unshare")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space-guid")
+
+	if _, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "GUID", serviceInstanceGUID)
+	}
+
+	if err := h.serviceInstanceRepo.UnshareServiceInstance(r.Context(), authInfo, repositories.UnshareServiceInstanceMessage{
+		GUID:      serviceInstanceGUID,
+		SpaceGUID: spaceGUID,
+	}); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to unshare service instance", "GUID", serviceInstanceGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *ServiceInstance) getSharedSpacesUsageSummary(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-shared-spaces-usage-summary")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	usageSummary, err := h.serviceInstanceRepo.GetSharedSpacesUsageSummary(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get shared spaces usage summary", "GUID", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpacesUsageSummary(serviceInstanceGUID, usageSummary, h.serverURL)), nil
+}
+
 func (h *ServiceInstance) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -251,6 +350,11 @@ func (h *ServiceInstance) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: ServiceInstancesPath, Handler: h.list},
 		{Method: "GET", Pattern: ServiceInstancePath, Handler: h.get},
 		{Method: "GET", Pattern: ServiceInstanceCredentialsPath, Handler: h.getCredentials},
+		{Method: "GET", Pattern: ServiceInstanceParamsPath, Handler: h.getParameters},
 		{Method: "DELETE", Pattern: ServiceInstancePath, Handler: h.delete},
+		{Method: "GET", Pattern: ServiceInstanceSharedSpacesPath, Handler: h.getSharedSpaces},
+		{Method: "POST", Pattern: ServiceInstanceSharedSpacesPath, Handler: h.share},
+		{Method: "GET", Pattern: ServiceInstanceSharedSpacesUsageSummaryPath, Handler: h.getSharedSpacesUsageSummary},
+		{Method: "DELETE", Pattern: ServiceInstanceSharedSpacePath, Handler: h.unshare},
 	}
 }
@@ -18,6 +18,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("ServiceInstance", func() {
@@ -388,6 +389,57 @@ var _ = Describe("ServiceInstance", func() {
 		})
 	})
 
+	Describe("GET /v3/service_instances/:guid/parameters", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceParametersReturns(map[string]any{
+				"foo": "bar",
+			}, nil)
+
+			reqPath += "/service-instance-guid/parameters"
+		})
+
+		It("gets the service instance parameters", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceParametersCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualInstanceGUID := serviceInstanceRepo.GetServiceInstanceParametersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualInstanceGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).Should(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.foo", "bar")))
+		})
+
+		When("the service instance is not accessible", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("the service instance does not support fetching parameters", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, apierrors.NewUnprocessableEntityError(nil, "not supported"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("not supported")
+			})
+		})
+
+		When("getting the parameters fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the POST /v3/service_instances endpoint", func() {
 		BeforeEach(func() {
 			reqMethod = http.MethodPost
@@ -817,6 +869,32 @@ var _ = Describe("ServiceInstance", func() {
 			)))
 		})
 
+		When("the patch requires a service broker update", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+					Parameters:      &map[string]any{"foo": "bar"},
+					MaintenanceInfo: &payloads.ServiceInstanceMaintenanceInfo{Version: "1.2.3"},
+					Relationships: &payloads.ServiceInstancePatchRelationships{
+						ServicePlan: &payloads.Relationship{
+							Data: &payloads.RelationshipData{GUID: "new-plan-guid"},
+						},
+					},
+				})
+			})
+
+			It("updates the service instance asynchronously", func() {
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+				_, _, patchMessage := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+				Expect(patchMessage.PlanGUID).To(PointTo(Equal("new-plan-guid")))
+				Expect(patchMessage.Parameters).To(PointTo(Equal(map[string]any{"foo": "bar"})))
+				Expect(patchMessage.MaintenanceInfoVersion).To(PointTo(Equal("1.2.3")))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+					ContainSubstring("/v3/jobs/managed_service_instance.update~service-instance-guid")))
+			})
+		})
+
 		When("decoding the payload fails", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
@@ -969,4 +1047,181 @@ var _ = Describe("ServiceInstance", func() {
 			})
 		})
 	})
+
+	Describe("GET /v3/service_instances/:guid/relationships/shared_spaces", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				SharedSpaceGUIDs: []string{"shared-space-guid"},
+			}, nil)
+			reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+		})
+
+		It("returns the shared spaces", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "shared-space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		When("the service instance is forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+	})
+
+	Describe("POST /v3/service_instances/:guid/relationships/shared_spaces", func() {
+		BeforeEach(func() {
+			reqMethod = http.MethodPost
+			reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceShare{
+				Data: []payloads.RelationshipData{{GUID: "shared-space-guid"}},
+			})
+
+			serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				SharedSpaceGUIDs: []string{"shared-space-guid"},
+			}, nil)
+		})
+
+		It("shares the service instance", func() {
+			Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.ShareServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareServiceInstanceMessage{
+				GUID:       "service-instance-guid",
+				SpaceGUIDs: []string{"shared-space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "shared-space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("the service instance is forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+
+			It("does not share the service instance", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("sharing the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewUnprocessableEntityError(nil, "cannot share"))
+			})
+
+			It("returns the error", func() {
+				expectUnprocessableEntityError("cannot share")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_instances/:guid/relationships/shared_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			reqMethod = http.MethodDelete
+			reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces/shared-space-guid"
+		})
+
+		It("unshares the service instance", func() {
+			Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.UnshareServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareServiceInstanceMessage{
+				GUID:      "service-instance-guid",
+				SpaceGUID: "shared-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the service instance is forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("unsharing the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.UnshareServiceInstanceReturns(errors.New("boom"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_instances/:guid/relationships/shared_spaces/usage_summary", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces/usage_summary"
+
+			serviceInstanceRepo.GetSharedSpacesUsageSummaryReturns([]repositories.SharedSpaceUsageSummaryRecord{
+				{SpaceGUID: "shared-space-guid", BoundAppCount: 2},
+			}, nil)
+		})
+
+		It("returns the usage summary", func() {
+			Expect(serviceInstanceRepo.GetSharedSpacesUsageSummaryCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetSharedSpacesUsageSummaryArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.usage_summary[0].space.guid", "shared-space-guid"),
+				MatchJSONPath("$.usage_summary[0].bound_app_count", BeEquivalentTo(2)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces/usage_summary"),
+			)))
+		})
+
+		When("getting the usage summary fails with forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetSharedSpacesUsageSummaryReturns(nil, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+	})
 })
@@ -1,31 +1,153 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
 )
 
 const (
 	ServiceRouteBindingsPath = "/v3/service_route_bindings"
+	ServiceRouteBindingPath  = "/v3/service_route_bindings/{guid}"
 )
 
+//counterfeiter:generate -o fake -fake-name CFServiceRouteBindingRepository . CFServiceRouteBindingRepository
+type CFServiceRouteBindingRepository interface {
+	CreateServiceRouteBinding(context.Context, authorization.Info, repositories.CreateServiceRouteBindingMessage) (repositories.ServiceRouteBindingRecord, error)
+	GetServiceRouteBinding(context.Context, authorization.Info, string) (repositories.ServiceRouteBindingRecord, error)
+	ListServiceRouteBindings(context.Context, authorization.Info, repositories.ListServiceRouteBindingsMessage) ([]repositories.ServiceRouteBindingRecord, error)
+	DeleteServiceRouteBinding(context.Context, authorization.Info, string) error
+}
+
 type ServiceRouteBinding struct {
-	serverURL url.URL
+	serverURL               url.URL
+	serviceRouteBindingRepo CFServiceRouteBindingRepository
+	serviceInstanceRepo     CFServiceInstanceRepository
+	requestValidator        RequestValidator
 }
 
 func NewServiceRouteBinding(
 	serverURL url.URL,
+	serviceRouteBindingRepo CFServiceRouteBindingRepository,
+	serviceInstanceRepo CFServiceInstanceRepository,
+	requestValidator RequestValidator,
 ) *ServiceRouteBinding {
 	return &ServiceRouteBinding{
-		serverURL: serverURL,
+		serverURL:               serverURL,
+		serviceRouteBindingRepo: serviceRouteBindingRepo,
+		serviceInstanceRepo:     serviceInstanceRepo,
+		requestValidator:        requestValidator,
+	}
+}
+
+func (h *ServiceRouteBinding) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.create")
+
+	var payload payloads.ServiceRouteBindingCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Unable to bind to instance. Ensure that the instance exists and you have access to it.",
+				apierrors.ForbiddenError{},
+				apierrors.NotFoundError{},
+			),
+			"failed to get "+repositories.ServiceInstanceResourceType,
+		)
+	}
+
+	serviceRouteBinding, err := h.serviceRouteBindingRepo.CreateServiceRouteBinding(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(serviceRouteBinding.GUID, presenter.ServiceRouteBindingCreateOperation, h.serverURL)), nil
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceRouteBinding(serviceRouteBinding, h.serverURL)), nil
+}
+
+func (h *ServiceRouteBinding) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.get")
+
+	serviceRouteBindingGUID := routing.URLParam(r, "guid")
+
+	serviceRouteBinding, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(r.Context(), authInfo, serviceRouteBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceRouteBindingResourceType)
 	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBinding(serviceRouteBinding, h.serverURL)), nil
 }
 
 func (h *ServiceRouteBinding) list(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingsList(h.serverURL, *r.URL)), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.list")
+
+	listFilter := new(payloads.ServiceRouteBindingList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	serviceRouteBindings, err := h.serviceRouteBindingRepo.ListServiceRouteBindings(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingList(serviceRouteBindings, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceRouteBinding) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.delete")
+
+	serviceRouteBindingGUID := routing.URLParam(r, "guid")
+	serviceRouteBinding, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(r.Context(), authInfo, serviceRouteBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceRouteBinding.ServiceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(err, "failed to get service instance"),
+			"failed to get "+repositories.ServiceInstanceResourceType,
+			"instance-guid", serviceRouteBinding.ServiceInstanceGUID,
+		)
+	}
+
+	err = h.serviceRouteBindingRepo.DeleteServiceRouteBinding(r.Context(), authInfo, serviceRouteBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service route binding", "guid", serviceRouteBindingGUID)
+	}
+
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(serviceRouteBinding.GUID, presenter.ServiceRouteBindingDeleteOperation, h.serverURL)), nil
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
 }
 
 func (h *ServiceRouteBinding) UnauthenticatedRoutes() []routing.Route {
@@ -34,6 +156,9 @@ func (h *ServiceRouteBinding) UnauthenticatedRoutes() []routing.Route {
 
 func (h *ServiceRouteBinding) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
+		{Method: "POST", Pattern: ServiceRouteBindingsPath, Handler: h.create},
 		{Method: "GET", Pattern: ServiceRouteBindingsPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceRouteBindingPath, Handler: h.get},
+		{Method: "DELETE", Pattern: ServiceRouteBindingPath, Handler: h.delete},
 	}
 }
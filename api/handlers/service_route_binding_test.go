@@ -1,9 +1,16 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
-	"code.cloudfoundry.org/korifi/api/handlers"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -11,25 +18,276 @@ import (
 )
 
 var _ = Describe("ServiceRouteBinding", func() {
-	Describe("the GET /v3/service_route_binding endpoint", func() {
+	var (
+		requestMethod string
+		requestPath   string
+		requestBody   string
+
+		serviceRouteBindingRepo *fake.CFServiceRouteBindingRepository
+		serviceInstanceRepo     *fake.CFServiceInstanceRepository
+		requestValidator        *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingRepo = new(fake.CFServiceRouteBindingRepository)
+		serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{
+			GUID:                "service-route-binding-guid",
+			ServiceInstanceGUID: "service-instance-guid",
+			RouteGUID:           "route-guid",
+		}, nil)
+
+		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
+		serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+			GUID:      "service-instance-guid",
+			SpaceGUID: "space-guid",
+			Type:      korifiv1alpha1.UserProvidedType,
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewServiceRouteBinding(
+			*serverURL,
+			serviceRouteBindingRepo,
+			serviceInstanceRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/service_route_bindings", func() {
 		BeforeEach(func() {
-			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/service_route_bindings", nil)
-			Expect(err).NotTo(HaveOccurred())
+			requestMethod = http.MethodPost
+			requestPath = "/v3/service_route_bindings"
+			requestBody = "the-json-body"
+
+			serviceRouteBindingRepo.CreateServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{
+				GUID:                "service-route-binding-guid",
+				ServiceInstanceGUID: "service-instance-guid",
+				RouteGUID:           "route-guid",
+			}, nil)
 
-			apiHandler := handlers.NewServiceRouteBinding(
-				*serverURL)
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceRouteBindingCreate{
+				Relationships: &payloads.ServiceRouteBindingRelationships{
+					Route: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "route-guid"},
+					},
+					ServiceInstance: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "service-instance-guid"},
+					},
+				},
+				Parameters: map[string]any{"p1": "v1"},
+			})
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
 
-			routerBuilder.LoadRoutes(apiHandler)
-			routerBuilder.Build().ServeHTTP(rr, req)
+		It("creates the binding", func() {
+			Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceRouteBindingRepo.CreateServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateServiceRouteBindingMessage{
+				RouteGUID:           "route-guid",
+				ServiceInstanceGUID: "service-instance-guid",
+				Parameters:          map[string]any{"p1": "v1"},
+			}))
 		})
 
-		It("returns an empty list", func() {
+		It("returns the binding", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-route-binding-guid"),
+				MatchJSONPath("$.relationships.route.data.guid", "route-guid"),
+			)))
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      korifiv1alpha1.ManagedType,
+				}, nil)
+			})
+
+			It("returns a create job", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+					"https://api.example.org/v3/jobs/service_route_binding.create~service-route-binding-guid"))
+			})
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the service instance is not accessible", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Unable to bind to instance. Ensure that the instance exists and you have access to it.")
+			})
+		})
+
+		When("creating the binding fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.CreateServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, errors.New("create-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_route_bindings", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestBody = ""
+			requestPath = "/v3/service_route_bindings?foo=bar"
+
+			serviceRouteBindingRepo.ListServiceRouteBindingsReturns([]repositories.ServiceRouteBindingRecord{
+				{GUID: "service-route-binding-guid"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceRouteBindingList{
+				RouteGUIDs:           "r1,r2",
+				ServiceInstanceGUIDs: "s1,s2",
+				LabelSelector:        "label=value",
+			})
+		})
+
+		It("returns the list of service route bindings", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualReq.URL.String()).To(HaveSuffix(requestPath))
+
+			Expect(serviceRouteBindingRepo.ListServiceRouteBindingsCallCount()).To(Equal(1))
+			_, _, message := serviceRouteBindingRepo.ListServiceRouteBindingsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListServiceRouteBindingsMessage{
+				RouteGUIDs:           []string{"r1", "r2"},
+				ServiceInstanceGUIDs: []string{"s1", "s2"},
+				LabelSelector:        "label=value",
+			}))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_route_bindings"),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_route_bindings?foo=bar"),
+				MatchJSONPath("$.resources[0].guid", "service-route-binding-guid"),
 			)))
 		})
+
+		When("listing the bindings fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.ListServiceRouteBindingsReturns(nil, errors.New("list-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestBody = ""
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+		})
+
+		It("returns the binding", func() {
+			Expect(serviceRouteBindingRepo.GetServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceRouteBindingRepo.GetServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-route-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "service-route-binding-guid")))
+		})
+
+		When("the binding is not accessible", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceRouteBindingResourceType)
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestBody = ""
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+		})
+
+		It("deletes the binding", func() {
+			Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceRouteBindingRepo.DeleteServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-route-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+					Type: korifiv1alpha1.ManagedType,
+				}, nil)
+			})
+
+			It("returns a delete job", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+					"https://api.example.org/v3/jobs/service_route_binding.delete~service-route-binding-guid"))
+			})
+		})
+
+		When("the binding does not exist", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(BeZero())
+				expectNotFoundError(repositories.ServiceRouteBindingResourceType)
+			})
+		})
+
+		When("deleting the binding fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.DeleteServiceRouteBindingReturns(errors.New("delete-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 })
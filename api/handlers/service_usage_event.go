@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	ServiceUsageEventsPath      = "/v3/service_usage_events"
+	ServiceUsageEventPath       = "/v3/service_usage_events/{guid}"
+	ServiceUsageEventsPurgePath = "/v3/service_usage_events/actions/destructively_purge_all_and_reseed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServiceUsageEventRepository . CFServiceUsageEventRepository
+type CFServiceUsageEventRepository interface {
+	GetServiceUsageEvent(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)
+	ListServiceUsageEvents(context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) ([]repositories.ServiceUsageEventRecord, error)
+	PurgeAndReseedServiceUsageEvents(context.Context, authorization.Info) error
+}
+
+type ServiceUsageEvent struct {
+	serverURL             url.URL
+	serviceUsageEventRepo CFServiceUsageEventRepository
+	requestValidator      RequestValidator
+}
+
+func NewServiceUsageEvent(
+	serverURL url.URL,
+	serviceUsageEventRepo CFServiceUsageEventRepository,
+	requestValidator RequestValidator,
+) *ServiceUsageEvent {
+	return &ServiceUsageEvent{
+		serverURL:             serverURL,
+		serviceUsageEventRepo: serviceUsageEventRepo,
+		requestValidator:      requestValidator,
+	}
+}
+
+func (h *ServiceUsageEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.get")
+
+	serviceUsageEventGUID := routing.URLParam(r, "guid")
+
+	serviceUsageEvent, err := h.serviceUsageEventRepo.GetServiceUsageEvent(r.Context(), authInfo, serviceUsageEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get service usage event", "GUID", serviceUsageEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceUsageEvent(serviceUsageEvent, h.serverURL)), nil
+}
+
+func (h *ServiceUsageEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.list")
+
+	payload := new(payloads.ServiceUsageEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	serviceUsageEvents, err := h.serviceUsageEventRepo.ListServiceUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list service usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForServiceUsageEvent, serviceUsageEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceUsageEvent) purge(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.purge")
+
+	if err := h.serviceUsageEventRepo.PurgeAndReseedServiceUsageEvents(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to purge service usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *ServiceUsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *ServiceUsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: ServiceUsageEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceUsageEventPath, Handler: h.get},
+		{Method: "POST", Pattern: ServiceUsageEventsPurgePath, Handler: h.purge},
+	}
+}
@@ -0,0 +1,193 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceUsageEvent", func() {
+	var (
+		requestMethod         string
+		requestPath           string
+		serviceUsageEventRepo *fake.CFServiceUsageEventRepository
+		requestValidator      *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		serviceUsageEventRepo = new(fake.CFServiceUsageEventRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewServiceUsageEvent(
+			*serverURL,
+			serviceUsageEventRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/service_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_usage_events/event-guid"
+
+			serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{
+				GUID:                "event-guid",
+				State:               "CREATED",
+				ServiceInstance:     repositories.UsageEventResource{GUID: "si-guid"},
+				ServiceInstanceType: "user-provided",
+			}, nil)
+		})
+
+		It("returns the service usage event", func() {
+			Expect(serviceUsageEventRepo.GetServiceUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceUsageEventRepo.GetServiceUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.state", "CREATED"),
+				MatchJSONPath("$.service_instance.guid", "si-guid"),
+				MatchJSONPath("$.service_instance.type", "user_provided_service_instance"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_usage_events/event-guid"),
+			)))
+		})
+
+		When("the service usage event is not accessible", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceUsageEventResourceType)
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_usage_events", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_usage_events"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceUsageEventList{
+				AfterGUID:            "event-0",
+				ServiceInstanceTypes: "managed_service_instance",
+				ServiceOfferingGUIDs: "offering-guid",
+				OrderBy:              "-created_at",
+			})
+
+			serviceUsageEventRepo.ListServiceUsageEventsReturns([]repositories.ServiceUsageEventRecord{
+				{GUID: "event-1"},
+				{GUID: "event-2"},
+			}, nil)
+		})
+
+		It("lists the service usage events", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+
+			Expect(serviceUsageEventRepo.ListServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := serviceUsageEventRepo.ListServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage).To(Equal(repositories.ListServiceUsageEventsMessage{
+				AfterGUID:            "event-0",
+				ServiceInstanceTypes: []string{"managed"},
+				ServiceOfferingGUIDs: []string{"offering-guid"},
+				OrderBy:              "-created_at",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_usage_events"),
+				MatchJSONPath("$.resources[0].guid", "event-1"),
+				MatchJSONPath("$.resources[1].guid", "event-2"),
+			)))
+		})
+
+		When("the query parameters are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.ListServiceUsageEventsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/service_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/service_usage_events/actions/destructively_purge_all_and_reseed"
+		})
+
+		It("purges and reseeds the service usage events", func() {
+			Expect(serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsReturns(apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	SidecarPath         = "/v3/sidecars/{guid}"
+	AppSidecarsPath     = "/v3/apps/{guid}/sidecars"
+	ProcessSidecarsPath = "/v3/processes/{guid}/sidecars"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+type CFSidecarRepository interface {
+	GetSidecar(context.Context, authorization.Info, string) (repositories.SidecarRecord, error)
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	UpdateSidecar(context.Context, authorization.Info, repositories.UpdateSidecarMessage) (repositories.SidecarRecord, error)
+	DeleteSidecar(context.Context, authorization.Info, string) error
+}
+
+type Sidecar struct {
+	serverURL        url.URL
+	appRepo          CFAppRepository
+	processRepo      CFProcessRepository
+	sidecarRepo      CFSidecarRepository
+	requestValidator RequestValidator
+}
+
+func NewSidecar(
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	processRepo CFProcessRepository,
+	sidecarRepo CFSidecarRepository,
+	requestValidator RequestValidator,
+) *Sidecar {
+	return &Sidecar{
+		serverURL:        serverURL,
+		appRepo:          appRepo,
+		processRepo:      processRepo,
+		sidecarRepo:      sidecarRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *Sidecar) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.get")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	sidecar, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch sidecar", "SidecarGUID", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.update")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SidecarUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch sidecar", "SidecarGUID", sidecarGUID)
+	}
+
+	sidecar, err := h.sidecarRepo.UpdateSidecar(r.Context(), authInfo, payload.ToMessage(sidecarGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update sidecar", "SidecarGUID", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.delete")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	if _, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch sidecar", "SidecarGUID", sidecarGUID)
+	}
+
+	if err := h.sidecarRepo.DeleteSidecar(r.Context(), authInfo, sidecarGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete sidecar", "SidecarGUID", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *Sidecar) createForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.create-for-app")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SidecarCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app", "AppGUID", appGUID)
+	}
+
+	sidecar, err := h.sidecarRepo.CreateSidecar(r.Context(), authInfo, payload.ToMessage(app))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create sidecar", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) listForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.list-for-app")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	sidecarListFilter := new(payloads.SidecarList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, sidecarListFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if _, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app", "AppGUID", appGUID)
+	}
+
+	sidecars, err := h.sidecarRepo.ListSidecars(r.Context(), authInfo, sidecarListFilter.ToMessage(appGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list sidecars", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSidecar, sidecars, h.serverURL, *r.URL)), nil
+}
+
+func (h *Sidecar) listForProcess(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.list-for-process")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
+	}
+
+	sidecars, err := h.sidecarRepo.ListSidecars(r.Context(), authInfo, repositories.ListSidecarsMessage{
+		AppGUIDs:     []string{process.AppGUID},
+		ProcessTypes: []string{process.Type},
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list sidecars", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSidecar, sidecars, h.serverURL, *r.URL)), nil
+}
+
+func (h *Sidecar) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Sidecar) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: SidecarPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SidecarPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SidecarPath, Handler: h.delete},
+		{Method: "GET", Pattern: AppSidecarsPath, Handler: h.listForApp},
+		{Method: "POST", Pattern: AppSidecarsPath, Handler: h.createForApp},
+		{Method: "GET", Pattern: ProcessSidecarsPath, Handler: h.listForProcess},
+	}
+}
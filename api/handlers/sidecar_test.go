@@ -0,0 +1,332 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecar", func() {
+	var (
+		requestMethod    string
+		requestPath      string
+		appRepo          *fake.CFAppRepository
+		processRepo      *fake.CFProcessRepository
+		sidecarRepo      *fake.CFSidecarRepository
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "app-guid",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		processRepo = new(fake.CFProcessRepository)
+		processRepo.GetProcessReturns(repositories.ProcessRecord{
+			GUID:    "process-guid",
+			AppGUID: "app-guid",
+			Type:    "web",
+		}, nil)
+
+		sidecarRepo = new(fake.CFSidecarRepository)
+		sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{
+			GUID:    "sidecar-guid",
+			AppGUID: "app-guid",
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := handlers.NewSidecar(*serverURL, appRepo, processRepo, sidecarRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader("the-json-body"))
+		Expect(err).NotTo(HaveOccurred())
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/sidecars/:guid", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/sidecars/sidecar-guid"
+		})
+
+		It("returns the sidecar", func() {
+			Expect(sidecarRepo.GetSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := sidecarRepo.GetSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.relationships.app.data.guid", "app-guid"),
+			)))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/sidecars/:guid", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/sidecars/sidecar-guid"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarUpdate{
+				Command: tools.PtrTo("run-agent --verbose"),
+			})
+
+			sidecarRepo.UpdateSidecarReturns(repositories.SidecarRecord{
+				GUID:    "sidecar-guid",
+				Command: "run-agent --verbose",
+			}, nil)
+		})
+
+		It("updates the sidecar", func() {
+			Expect(sidecarRepo.UpdateSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := sidecarRepo.UpdateSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateSidecarMessage{
+				GUID:    "sidecar-guid",
+				Command: tools.PtrTo("run-agent --verbose"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.command", "run-agent --verbose")))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(sidecarRepo.UpdateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+				Expect(sidecarRepo.UpdateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.UpdateSidecarReturns(repositories.SidecarRecord{}, apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns the error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/sidecars/:guid", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/sidecars/sidecar-guid"
+		})
+
+		It("deletes the sidecar", func() {
+			Expect(sidecarRepo.DeleteSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := sidecarRepo.DeleteSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+				Expect(sidecarRepo.DeleteSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.DeleteSidecarReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/apps/:guid/sidecars", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/apps/app-guid/sidecars"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarCreate{
+				Name:         "agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web"},
+			})
+
+			sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{
+				GUID: "sidecar-guid",
+				Name: "agent",
+			}, nil)
+		})
+
+		It("creates the sidecar", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "sidecar-guid")))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns the error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/:guid/sidecars", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/apps/app-guid/sidecars"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SidecarList{
+				ProcessTypes: "web",
+			})
+
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-guid"},
+			}, nil)
+		})
+
+		It("lists the sidecars of the app", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListSidecarsMessage{
+				AppGUIDs:     []string{"app-guid"},
+				ProcessTypes: []string{"web"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "sidecar-guid"),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+				Expect(sidecarRepo.ListSidecarsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /v3/processes/:guid/sidecars", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/processes/process-guid/sidecars"
+
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-guid"},
+			}, nil)
+		})
+
+		It("lists the sidecars of the process", func() {
+			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal("process-guid"))
+
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, message := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListSidecarsMessage{
+				AppGUIDs:     []string{"app-guid"},
+				ProcessTypes: []string{"web"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars"),
+				MatchJSONPath("$.resources[0].guid", "sidecar-guid"),
+			)))
+		})
+
+		When("the process isn't accessible to the user", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+	})
+})
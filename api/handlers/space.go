@@ -17,8 +17,9 @@ import (
 )
 
 const (
-	SpacesPath = "/v3/spaces"
-	SpacePath  = "/v3/spaces/{guid}"
+	SpacesPath       = "/v3/spaces"
+	SpacePath        = "/v3/spaces/{guid}"
+	SpaceFeaturePath = "/v3/spaces/{guid}/features/{name}"
 )
 
 //counterfeiter:generate -o fake -fake-name CFSpaceRepository . CFSpaceRepository
@@ -29,6 +30,7 @@ type CFSpaceRepository interface {
 	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
 	DeleteSpace(context.Context, authorization.Info, repositories.DeleteSpaceMessage) error
 	PatchSpaceMetadata(context.Context, authorization.Info, repositories.PatchSpaceMetadataMessage) (repositories.SpaceRecord, error)
+	PatchSpaceFeatures(context.Context, authorization.Info, repositories.PatchSpaceFeaturesMessage) (repositories.SpaceRecord, error)
 	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
 }
 
@@ -148,6 +150,54 @@ func (h *Space) get(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpace(space, h.apiBaseURL)), nil
 }
 
+func (h *Space) getFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.get-feature")
+
+	spaceGUID := routing.URLParam(r, "guid")
+	if routing.URLParam(r, "name") != "ssh" {
+		return nil, apierrors.NewNotFoundError(nil, "Feature")
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceSSHFeature(space)), nil
+}
+
+func (h *Space) updateFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.update-feature")
+
+	spaceGUID := routing.URLParam(r, "guid")
+	if routing.URLParam(r, "name") != "ssh" {
+		return nil, apierrors.NewNotFoundError(nil, "Feature")
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	var payload payloads.FeaturePatch
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	space, err = h.spaceRepo.PatchSpaceFeatures(r.Context(), authInfo, repositories.PatchSpaceFeaturesMessage{
+		GUID:     spaceGUID,
+		OrgGUID:  space.OrganizationGUID,
+		AllowSSH: payload.Enabled,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch space features", "GUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceSSHFeature(space)), nil
+}
+
 func (h *Space) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -159,5 +209,7 @@ func (h *Space) AuthenticatedRoutes() []routing.Route {
 		{Method: "PATCH", Pattern: SpacePath, Handler: h.update},
 		{Method: "DELETE", Pattern: SpacePath, Handler: h.delete},
 		{Method: "GET", Pattern: SpacePath, Handler: h.get},
+		{Method: "GET", Pattern: SpaceFeaturePath, Handler: h.getFeature},
+		{Method: "PATCH", Pattern: SpaceFeaturePath, Handler: h.updateFeature},
 	}
 }
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	SpaceQuotasPath                = "/v3/space_quotas"
+	SpaceQuotaPath                 = "/v3/space_quotas/{guid}"
+	SpaceQuotaSpacesPath           = "/v3/space_quotas/{guid}/relationships/spaces"
+	SpaceQuotaSpacePath            = "/v3/space_quotas/{guid}/relationships/spaces/{space-guid}"
+	spaceQuotaSpacesNotFoundErrFmt = "Spaces with guids %q do not exist within the organization specified in this space quota."
+)
+
+//counterfeiter:generate -o fake -fake-name CFSpaceQuotaRepository . CFSpaceQuotaRepository
+type CFSpaceQuotaRepository interface {
+	CreateSpaceQuota(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	GetSpaceQuota(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)
+	ListSpaceQuotas(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	UpdateSpaceQuota(context.Context, authorization.Info, repositories.UpdateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	ApplySpaceQuota(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	RemoveSpaceQuota(context.Context, authorization.Info, repositories.RemoveSpaceQuotaMessage) error
+	DeleteSpaceQuota(context.Context, authorization.Info, string) error
+	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
+}
+
+type SpaceQuota struct {
+	serverURL        url.URL
+	spaceQuotaRepo   CFSpaceQuotaRepository
+	orgRepo          CFOrgRepository
+	spaceRepo        CFSpaceRepository
+	requestValidator RequestValidator
+}
+
+func NewSpaceQuota(
+	serverURL url.URL,
+	spaceQuotaRepo CFSpaceQuotaRepository,
+	orgRepo CFOrgRepository,
+	spaceRepo CFSpaceRepository,
+	requestValidator RequestValidator,
+) *SpaceQuota {
+	return &SpaceQuota{
+		serverURL:        serverURL,
+		spaceQuotaRepo:   spaceQuotaRepo,
+		orgRepo:          orgRepo,
+		spaceRepo:        spaceRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *SpaceQuota) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.create")
+
+	payload := new(payloads.SpaceQuotaCreate)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	message := payload.ToMessage()
+	if _, err := h.orgRepo.GetOrg(r.Context(), authInfo, message.OrgGUID); err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "Organization with guid '"+message.OrgGUID+"' does not exist, or you do not have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+			"failed to get org for space quota",
+			"orgGUID", message.OrgGUID,
+		)
+	}
+
+	if err := h.ensureSpacesInOrg(r.Context(), authInfo, message.OrgGUID, message.SpaceGUIDs); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to verify spaces for space quota")
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.CreateSpaceQuota(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create space quota", "Name", payload.Name)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.get")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	spaceQuota, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get space quota", "GUID", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.list")
+
+	payload := new(payloads.SpaceQuotaList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	spaceQuotas, err := h.spaceQuotaRepo.ListSpaceQuotas(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list space quotas")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSpaceQuota, spaceQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *SpaceQuota) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.update")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.SpaceQuotaPatch)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get space quota", "GUID", spaceQuotaGUID)
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.UpdateSpaceQuota(r.Context(), authInfo, payload.ToMessage(spaceQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update space quota", "GUID", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.delete")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	if _, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get space quota", "GUID", spaceQuotaGUID)
+	}
+
+	if err := h.spaceQuotaRepo.DeleteSpaceQuota(r.Context(), authInfo, spaceQuotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete space quota", "GUID", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(spaceQuotaGUID, presenter.SpaceQuotaDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *SpaceQuota) applyToSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.apply")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.SpaceQuotaApply)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get space quota", "GUID", spaceQuotaGUID)
+	}
+
+	if err = h.ensureSpacesInOrg(r.Context(), authInfo, spaceQuota.OrgGUID, payload.SpaceGUIDs()); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to verify spaces for space quota")
+	}
+
+	spaceQuota, err = h.spaceQuotaRepo.ApplySpaceQuota(r.Context(), authInfo, payload.ToMessage(spaceQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to apply space quota", "GUID", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuotaSpaces(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) removeFromSpace(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.remove")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space-guid")
+
+	if _, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get space quota", "GUID", spaceQuotaGUID)
+	}
+
+	if err := h.spaceQuotaRepo.RemoveSpaceQuota(r.Context(), authInfo, repositories.RemoveSpaceQuotaMessage{
+		GUID:      spaceQuotaGUID,
+		SpaceGUID: spaceGUID,
+	}); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to remove space quota", "GUID", spaceQuotaGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *SpaceQuota) ensureSpacesInOrg(ctx context.Context, authInfo authorization.Info, orgGUID string, spaceGUIDs []string) error {
+	if len(spaceGUIDs) == 0 {
+		return nil
+	}
+
+	spaces, err := h.spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{
+		GUIDs:             spaceGUIDs,
+		OrganizationGUIDs: []string{orgGUID},
+	})
+	if err != nil {
+		return err
+	}
+
+	missingSpaceGUIDs := slices.DeleteFunc(slices.Clone(spaceGUIDs), func(guid string) bool {
+		return slices.ContainsFunc(spaces, func(space repositories.SpaceRecord) bool { return space.GUID == guid })
+	})
+	if len(missingSpaceGUIDs) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("spaces %v not found in org %q", missingSpaceGUIDs, orgGUID),
+			fmt.Sprintf(spaceQuotaSpacesNotFoundErrFmt, missingSpaceGUIDs),
+		)
+	}
+
+	return nil
+}
+
+func (h *SpaceQuota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SpaceQuota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: SpaceQuotasPath, Handler: h.create},
+		{Method: "GET", Pattern: SpaceQuotasPath, Handler: h.list},
+		{Method: "GET", Pattern: SpaceQuotaPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SpaceQuotaPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SpaceQuotaPath, Handler: h.delete},
+		{Method: "POST", Pattern: SpaceQuotaSpacesPath, Handler: h.applyToSpaces},
+		{Method: "DELETE", Pattern: SpaceQuotaSpacePath, Handler: h.removeFromSpace},
+	}
+}
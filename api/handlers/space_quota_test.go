@@ -0,0 +1,349 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SpaceQuota", func() {
+	var (
+		requestMethod    string
+		requestPath      string
+		requestBody      string
+		spaceQuotaRepo   *fake.CFSpaceQuotaRepository
+		orgRepo          *fake.CFOrgRepository
+		spaceRepo        *fake.CFSpaceRepository
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		spaceQuotaRepo = new(fake.CFSpaceQuotaRepository)
+		orgRepo = new(fake.CFOrgRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewSpaceQuota(
+			*serverURL,
+			spaceQuotaRepo,
+			orgRepo,
+			spaceRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+			GUID:    "quota-guid",
+			Name:    "my-quota",
+			OrgGUID: "org-guid",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/space_quotas", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/space_quotas"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaCreate{
+				Name: "my-quota",
+				Routes: payloads.QuotaRouteLimits{
+					TotalRoutes: tools.PtrTo[int64](5),
+				},
+				Relationships: payloads.SpaceQuotaRelationships{
+					Organization: payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "org-guid"},
+					},
+					Spaces: payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+					},
+				},
+			})
+
+			orgRepo.GetOrgReturns(repositories.OrgRecord{GUID: "org-guid"}, nil)
+			spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "space-guid"}}, nil)
+
+			spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID:       "quota-guid",
+				Name:       "my-quota",
+				OrgGUID:    "org-guid",
+				SpaceGUIDs: []string{"space-guid"},
+			}, nil)
+		})
+
+		It("creates the space quota", func() {
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+			_, _, actualOrgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listSpacesMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listSpacesMessage.GUIDs).To(ConsistOf("space-guid"))
+			Expect(listSpacesMessage.OrganizationGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := spaceQuotaRepo.CreateSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage.Name).To(Equal("my-quota"))
+			Expect(createMessage.OrgGUID).To(Equal("org-guid"))
+			Expect(createMessage.Limits.Routes.TotalRoutes).To(PointTo(BeEquivalentTo(5)))
+			Expect(createMessage.SpaceGUIDs).To(ConsistOf("space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.relationships.organization.data.guid", "org-guid"),
+				MatchJSONPath("$.relationships.spaces.data[0].guid", "space-guid"),
+			)))
+		})
+
+		When("the org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organization with guid 'org-guid' does not exist, or you do not have access to it.")
+				Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("a space is not in the org", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(`Spaces with guids ["space-guid"] do not exist within the organization specified in this space quota.`)
+				Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("the repository returns an error", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, errors.New("repo-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/space_quotas/quota-guid"
+			requestBody = ""
+		})
+
+		It("returns the space quota", func() {
+			Expect(spaceQuotaRepo.GetSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := spaceQuotaRepo.GetSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/space_quotas/quota-guid"),
+			)))
+		})
+
+		When("the space quota is not accessible", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/space_quotas"
+			requestBody = ""
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceQuotaList{
+				SpaceGUIDs: "space-guid",
+			})
+
+			spaceQuotaRepo.ListSpaceQuotasReturns([]repositories.SpaceQuotaRecord{
+				{GUID: "q1-guid", Name: "q1"},
+			}, nil)
+		})
+
+		It("lists the space quotas", func() {
+			Expect(spaceQuotaRepo.ListSpaceQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := spaceQuotaRepo.ListSpaceQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage).To(Equal(repositories.ListSpaceQuotasMessage{
+				SpaceGUIDs: []string{"space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "q1-guid"),
+			)))
+		})
+	})
+
+	Describe("PATCH /v3/space_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/space_quotas/quota-guid"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaPatch{
+				QuotaLimitsPatch: payloads.QuotaLimitsPatch{
+					Apps: payloads.QuotaAppLimitsPatch{
+						TotalInstances: payloads.QuotaLimitPatch{Set: true, Value: tools.PtrTo[int64](3)},
+					},
+				},
+			})
+
+			spaceQuotaRepo.UpdateSpaceQuotaReturns(repositories.SpaceQuotaRecord{GUID: "quota-guid"}, nil)
+		})
+
+		It("updates the space quota", func() {
+			Expect(spaceQuotaRepo.UpdateSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, updateMessage := spaceQuotaRepo.UpdateSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(updateMessage.GUID).To(Equal("quota-guid"))
+			Expect(updateMessage.Limits.Apps.TotalInstances).To(PointTo(Equal(repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](3)})))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+	})
+
+	Describe("DELETE /v3/space_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/space_quotas/quota-guid"
+			requestBody = ""
+		})
+
+		It("deletes the space quota", func() {
+			Expect(spaceQuotaRepo.DeleteSpaceQuotaCallCount()).To(Equal(1))
+			_, _, actualGUID := spaceQuotaRepo.DeleteSpaceQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/space_quota.delete~quota-guid"))
+		})
+
+		When("the space quota does not exist", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+				Expect(spaceQuotaRepo.DeleteSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /v3/space_quotas/{guid}/relationships/spaces", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/space_quotas/quota-guid/relationships/spaces"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaApply{
+				Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+			})
+
+			spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "space-guid"}}, nil)
+
+			spaceQuotaRepo.ApplySpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID:       "quota-guid",
+				SpaceGUIDs: []string{"space-guid"},
+			}, nil)
+		})
+
+		It("applies the quota to the spaces", func() {
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listSpacesMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listSpacesMessage.OrganizationGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(spaceQuotaRepo.ApplySpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, applyMessage := spaceQuotaRepo.ApplySpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(applyMessage).To(Equal(repositories.ApplySpaceQuotaMessage{
+				GUID:       "quota-guid",
+				SpaceGUIDs: []string{"space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/space_quotas/quota-guid/relationships/spaces"),
+			)))
+		})
+
+		When("a space is not in the quota's org", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(`Spaces with guids ["space-guid"] do not exist within the organization specified in this space quota.`)
+				Expect(spaceQuotaRepo.ApplySpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/space_quotas/{guid}/relationships/spaces/{space-guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/space_quotas/quota-guid/relationships/spaces/space-guid"
+			requestBody = ""
+		})
+
+		It("removes the quota from the space", func() {
+			Expect(spaceQuotaRepo.RemoveSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, removeMessage := spaceQuotaRepo.RemoveSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(removeMessage).To(Equal(repositories.RemoveSpaceQuotaMessage{
+				GUID:      "quota-guid",
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.RemoveSpaceQuotaReturns(apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+})
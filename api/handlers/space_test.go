@@ -358,4 +358,132 @@ var _ = Describe("Space", func() {
 			})
 		})
 	})
+
+	Describe("get a space feature", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/features/ssh"
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:     "the-space-guid",
+				AllowSSH: true,
+			}, nil)
+		})
+
+		It("returns the ssh feature", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, info, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.description", "Enable SSHing into apps in the space."),
+				MatchJSONPath("$.enabled", BeTrue()),
+			)))
+		})
+
+		When("the feature is unknown", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/features/anything-else"
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature")
+			})
+		})
+
+		When("getting the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("update a space feature", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath += "/the-space-guid/features/ssh"
+
+			spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{
+				GUID:     "the-space-guid",
+				AllowSSH: false,
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeaturePatch{
+				Enabled: tools.PtrTo(false),
+			})
+		})
+
+		It("updates the space ssh feature", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(1))
+			_, info, msg := spaceRepo.PatchSpaceFeaturesArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+			Expect(msg).To(Equal(repositories.PatchSpaceFeaturesMessage{
+				GUID:     "the-space-guid",
+				OrgGUID:  "the-org-guid",
+				AllowSSH: tools.PtrTo(false),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the feature is unknown", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/features/anything-else"
+			})
+
+			It("returns a not found error and does not update the space", func() {
+				expectNotFoundError("Feature")
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the user doesn't have permission to get the space", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error and does not update the space", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error and does not update the space", func() {
+				expectUnknownError()
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(0))
+			})
+		})
+
+		When("patching the space errors", func() {
+			BeforeEach(func() {
+				spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
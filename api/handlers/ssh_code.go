@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	SSHCodePath = "/v3/ssh_code"
+)
+
+//counterfeiter:generate -o fake -fake-name SSHCodeGenerator . SSHCodeGenerator
+type SSHCodeGenerator interface {
+	Generate(context.Context, authorization.Info) (string, error)
+}
+
+type SSHCode struct {
+	codeGenerator SSHCodeGenerator
+}
+
+func NewSSHCode(codeGenerator SSHCodeGenerator) *SSHCode {
+	return &SSHCode{
+		codeGenerator: codeGenerator,
+	}
+}
+
+func (h *SSHCode) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.ssh-code.create")
+
+	code, err := h.codeGenerator.Generate(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to generate ssh code")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSSHCode(code)), nil
+}
+
+func (h *SSHCode) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SSHCode) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: SSHCodePath, Handler: h.create},
+	}
+}
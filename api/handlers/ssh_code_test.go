@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSHCode", func() {
+	var codeGenerator *fake.SSHCodeGenerator
+
+	BeforeEach(func() {
+		codeGenerator = new(fake.SSHCodeGenerator)
+		codeGenerator.GenerateReturns("the-code", nil)
+		ctx = authorization.NewContext(ctx, &authorization.Info{Token: "the-token"})
+		routerBuilder.LoadRoutes(handlers.NewSSHCode(codeGenerator))
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/v3/ssh_code", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	It("generates a code for the caller", func() {
+		Expect(codeGenerator.GenerateCallCount()).To(Equal(1))
+		_, actualAuthInfo := codeGenerator.GenerateArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
+
+		Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+		Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.code", "the-code")))
+	})
+
+	When("generating the code fails", func() {
+		BeforeEach(func() {
+			codeGenerator.GenerateReturns("", errors.New("boom"))
+		})
+
+		It("returns an unknown error", func() {
+			expectUnknownError()
+		})
+	})
+})
@@ -24,7 +24,9 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/conditions"
 	"code.cloudfoundry.org/korifi/api/repositories/k8sklient"
 	"code.cloudfoundry.org/korifi/api/repositories/relationships"
+	"code.cloudfoundry.org/korifi/api/resourcecache"
 	"code.cloudfoundry.org/korifi/api/routing"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/tools"
@@ -34,7 +36,10 @@ import (
 	"code.cloudfoundry.org/korifi/version"
 
 	chiMiddlewares "github.com/go-chi/chi/middleware"
+	"github.com/go-logr/logr"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/cache"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
@@ -154,18 +159,24 @@ func main() {
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFSpace, korifiv1alpha1.CFSpaceList](conditionTimeout),
 	)
 	processRepo := repositories.NewProcessRepo(klient)
+	sidecarRepo := repositories.NewSidecarRepo(klient)
 	podRepo := repositories.NewPodRepo(klientUnfiltered)
 	appRepo := repositories.NewAppRepo(
 		klient,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](conditionTimeout),
 		repositories.NewAppSorter(),
 	)
-	dropletRepo := repositories.NewDropletRepo(klient)
+	dropletRepo := repositories.NewDropletRepo(
+		klient,
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
+	)
 	routeRepo := repositories.NewRouteRepo(klient)
 	domainRepo := repositories.NewDomainRepo(
 		klientUnfiltered,
 		cfg.RootNamespace,
 	)
+	routerGroupRepo := repositories.NewRouterGroupRepo(cfg.RouterGroups)
 	deploymentRepo := repositories.NewDeploymentRepo(
 		klient,
 		repositories.NewDeploymentSorter(),
@@ -190,15 +201,26 @@ func main() {
 	)
 	serviceInstanceRepo := repositories.NewServiceInstanceRepo(
 		klient,
+		privilegedClient,
+		nsPermissions,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceInstance, korifiv1alpha1.CFServiceInstanceList](conditionTimeout),
 		repositories.NewServiceInstanceSorter(),
+		paramsClient,
 		cfg.RootNamespace,
 	)
 	serviceBindingRepo := repositories.NewServiceBindingRepo(
 		klient,
+		serviceInstanceRepo,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBindingList](conditionTimeout),
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](conditionTimeout),
 		paramsClient,
+		cfg.RootNamespace,
+	)
+	serviceRouteBindingRepo := repositories.NewServiceRouteBindingRepo(
+		klient,
+		serviceInstanceRepo,
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBindingList](conditionTimeout),
+		cfg.RootNamespace,
 	)
 	stackRepo := repositories.NewStackRepository(
 		klientUnfiltered,
@@ -226,6 +248,8 @@ func main() {
 		imageClient,
 		cfg.PackageRegistrySecretNames,
 		cfg.RootNamespace,
+		cfg.ContainerRepositoryPrefix,
+		cfg.DropletImageRegistries,
 	)
 	taskRepo := repositories.NewTaskRepo(
 		klient,
@@ -236,14 +260,37 @@ func main() {
 	serviceOfferingRepo := repositories.NewServiceOfferingRepo(klient, cfg.RootNamespace, serviceBrokerRepo, nsPermissions)
 	servicePlanRepo := repositories.NewServicePlanRepo(klient, cfg.RootNamespace, orgRepo)
 	securityGroupRepo := repositories.NewSecurityGroupRepo(klient, cfg.RootNamespace)
+	orgQuotaRepo := repositories.NewOrgQuotaRepo(klient, cfg.RootNamespace)
+	spaceQuotaRepo := repositories.NewSpaceQuotaRepo(klient, cfg.RootNamespace)
+	auditEventRepo := repositories.NewAuditEventRepo(
+		klient,
+		privilegedClient,
+		namespaceRetriever,
+		nsPermissions,
+		repositories.NewAuditEventSorter(),
+		cfg.RootNamespace,
+	)
+	appUsageEventRepo := repositories.NewAppUsageEventRepo(
+		klient,
+		privilegedClient,
+		repositories.NewAppUsageEventSorter(),
+		cfg.RootNamespace,
+	)
+	serviceUsageEventRepo := repositories.NewServiceUsageEventRepo(
+		klient,
+		privilegedClient,
+		repositories.NewServiceUsageEventSorter(),
+		cfg.RootNamespace,
+	)
+	revisionRepo := repositories.NewRevisionRepo(klient, repositories.NewRevisionSorter())
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
 	manifest := actions.NewManifest(
 		domainRepo,
 		cfg.DefaultDomainName,
-		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewStateCollector(appRepo, domainRepo, processRepo, sidecarRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
 		manifest.NewNormalizer(cfg.DefaultDomainName),
-		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewApplier(appRepo, domainRepo, processRepo, sidecarRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
 	)
 
 	requestValidator := validation.NewDefaultDecoderValidator()
@@ -276,6 +323,10 @@ func main() {
 			cfg.RootNamespace,
 			cache.NewExpiring(),
 		),
+		middleware.AuditEvents(
+			auditEventRepo,
+			cachingIdentityProvider,
+		),
 	)
 
 	relationshipsRepo := relationships.NewResourseRelationshipsRepo(
@@ -312,14 +363,60 @@ func main() {
 		)
 	}
 
+	sshHostKeyFingerprint := ""
+	var sshCodes *sshproxy.Codes
+	if cfg.Experimental.SSH.Enabled {
+		var sshHostKey ssh.Signer
+		sshHostKey, err = sshproxy.LoadHostKey(cfg.Experimental.SSH.HostKeyPath)
+		if err != nil {
+			panic(fmt.Sprintf("could not load ssh host key: %v", err))
+		}
+		sshHostKeyFingerprint = sshproxy.HostKeyFingerprint(sshHostKey)
+
+		sshCodes = sshproxy.NewCodes(privilegedClient, cfg.RootNamespace, sshproxy.DefaultCodeTTL)
+
+		sshServer := sshproxy.NewServer(
+			cfg.Experimental.SSH.Port,
+			sshHostKey,
+			sshproxy.NewCodeAuthenticator(
+				sshCodes,
+				cachingIdentityProvider,
+				nsPermissions,
+				processRepo,
+				appRepo,
+				spaceRepo,
+				podRepo,
+				[]string{cfg.RoleMappings["admin"].Name, cfg.RoleMappings["space_developer"].Name},
+			),
+			sshproxy.NewK8sPodExecutor(k8sClientConfig, privilegedClientset),
+		)
+
+		go func() {
+			ctrl.Log.Info("starting ssh proxy", "port", cfg.Experimental.SSH.Port)
+			if err2 := sshServer.Start(logr.NewContext(context.Background(), ctrl.Log)); err2 != nil {
+				ctrl.Log.Error(err2, "error serving ssh")
+				os.Exit(1)
+			}
+		}()
+	}
+
+	var resourceCache handlers.ResourceCache = resourcecache.Disabled{}
+	if cfg.Experimental.ResourceMatching.Enabled {
+		maxSize := resource.MustParse(cfg.Experimental.ResourceMatching.MaxSize)
+		resourceCache, err = resourcecache.New(cfg.Experimental.ResourceMatching.CacheDir, maxSize.Value())
+		if err != nil {
+			panic(fmt.Sprintf("could not create resource cache: %v", err))
+		}
+	}
+
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL),
+		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL, cfg.Experimental.SSH, sshHostKeyFingerprint),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
 		),
-		handlers.NewResourceMatches(),
+		handlers.NewResourceMatches(resourceCache, requestValidator),
 		handlers.NewApp(
 			*serverURL,
 			appRepo,
@@ -333,6 +430,7 @@ func main() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			cfg.Experimental.SSH.Enabled,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -340,10 +438,14 @@ func main() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			routerGroupRepo,
 			requestValidator,
 		),
 		handlers.NewServiceRouteBinding(
 			*serverURL,
+			serviceRouteBindingRepo,
+			serviceInstanceRepo,
+			requestValidator,
 		),
 		handlers.NewPackage(
 			*serverURL,
@@ -351,6 +453,7 @@ func main() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			resourceCache,
 			requestValidator,
 			cfg.PackageRegistrySecretNames,
 		),
@@ -364,7 +467,10 @@ func main() {
 		handlers.NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
+			cfg.PackageRegistrySecretNames,
 		),
 		handlers.NewProcess(
 			*serverURL,
@@ -378,6 +484,12 @@ func main() {
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			routerGroupRepo,
+		),
+		handlers.NewRouterGroup(
+			*serverURL,
+			requestValidator,
+			routerGroupRepo,
 		),
 		handlers.NewDeployment(
 			*serverURL,
@@ -402,12 +514,20 @@ func main() {
 				handlers.ServiceBrokerDeleteJobType:          serviceBrokerRepo,
 				handlers.ManagedServiceInstanceDeleteJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingDeleteJobType:  serviceBindingRepo,
+				handlers.ServiceRouteBindingDeleteJobType:    serviceRouteBindingRepo,
+				handlers.SecurityGroupDeleteJobType:          securityGroupRepo,
+				handlers.OrgQuotaDeleteJobType:               orgQuotaRepo,
+				handlers.SpaceQuotaDeleteJobType:             spaceQuotaRepo,
 			},
 			map[string]handlers.StateRepository{
 				handlers.ServiceBrokerCreateJobType:          serviceBrokerRepo,
 				handlers.ServiceBrokerUpdateJobType:          serviceBrokerRepo,
+				handlers.ServiceBrokerCatalogSyncJobType:     serviceBrokerRepo,
 				handlers.ManagedServiceInstanceCreateJobType: serviceInstanceRepo,
+				handlers.ManagedServiceInstanceUpdateJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingCreateJobType:  serviceBindingRepo,
+				handlers.ManagedServiceBindingRotateJobType:  serviceBindingRepo,
+				handlers.ServiceRouteBindingCreateJobType:    serviceRouteBindingRepo,
 			},
 			500*time.Millisecond,
 		),
@@ -462,12 +582,20 @@ func main() {
 			taskRepo,
 			requestValidator,
 		),
+		handlers.NewSidecar(
+			*serverURL,
+			appRepo,
+			processRepo,
+			sidecarRepo,
+			requestValidator,
+		),
 		handlers.NewOAuth(
 			*serverURL,
 		),
 		handlers.NewServiceBroker(
 			*serverURL,
 			serviceBrokerRepo,
+			spaceRepo,
 			requestValidator,
 		),
 		handlers.NewServiceOffering(
@@ -489,6 +617,44 @@ func main() {
 			spaceRepo,
 			requestValidator,
 		),
+		handlers.NewOrgQuota(
+			*serverURL,
+			orgQuotaRepo,
+			orgRepo,
+			requestValidator,
+		),
+		handlers.NewSpaceQuota(
+			*serverURL,
+			spaceQuotaRepo,
+			orgRepo,
+			spaceRepo,
+			requestValidator,
+		),
+		handlers.NewAuditEvent(
+			*serverURL,
+			auditEventRepo,
+			requestValidator,
+		),
+		handlers.NewAppUsageEvent(
+			*serverURL,
+			appUsageEventRepo,
+			requestValidator,
+		),
+		handlers.NewServiceUsageEvent(
+			*serverURL,
+			serviceUsageEventRepo,
+			requestValidator,
+		),
+		handlers.NewRevision(
+			*serverURL,
+			appRepo,
+			revisionRepo,
+			requestValidator,
+		),
+	}
+
+	if cfg.Experimental.SSH.Enabled {
+		apiHandlers = append(apiHandlers, handlers.NewSSHCode(sshCodes))
 	}
 
 	if !cfg.Experimental.ExternalLogCache.Enabled {
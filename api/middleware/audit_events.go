@@ -0,0 +1,281 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/go-chi/chi"
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+//counterfeiter:generate -o fake -fake-name AuditEventRepository . AuditEventRepository
+
+type AuditEventRepository interface {
+	CreateAuditEvent(context.Context, repositories.CreateAuditEventMessage) (repositories.AuditEventRecord, error)
+}
+
+// auditedResponse holds the fields of a response body needed to find the
+// target of an audit event
+type auditedResponse struct {
+	GUID          string `json:"guid"`
+	Name          string `json:"name"`
+	Relationships struct {
+		App struct {
+			Data struct {
+				GUID string `json:"guid"`
+			} `json:"data"`
+		} `json:"app"`
+	} `json:"relationships"`
+
+	location string
+	actor    string
+}
+
+type targetGUIDFunc func(*http.Request, auditedResponse) string
+
+func fromURLParam(param string) targetGUIDFunc {
+	return func(r *http.Request, _ auditedResponse) string {
+		return chi.URLParam(r, param)
+	}
+}
+
+func fromResponse(_ *http.Request, response auditedResponse) string {
+	if response.GUID != "" {
+		return response.GUID
+	}
+
+	// Asynchronous creates only respond with the location of the job, whose
+	// guid ends with the guid of the created resource
+	_, guid, _ := strings.Cut(path.Base(response.location), presenter.JobGUIDDelimiter)
+	return guid
+}
+
+func fromResponseApp(_ *http.Request, response auditedResponse) string {
+	return response.Relationships.App.Data.GUID
+}
+
+// fromActor targets the actor itself, for requests that do not act on a
+// particular resource
+func fromActor(_ *http.Request, response auditedResponse) string {
+	return response.actor
+}
+
+type auditedRequest struct {
+	eventType  string
+	targetType string
+	targetGUID targetGUIDFunc
+}
+
+var fromGUIDParam = fromURLParam("guid")
+
+// auditedRequests maps the method and pattern of every mutating route to the
+// audit event recorded when it succeeds
+var auditedRequests = map[string]auditedRequest{
+	http.MethodPost + handlers.AppsPath:                           {"audit.app.create", "app", fromResponse},
+	http.MethodPatch + handlers.AppPath:                           {"audit.app.update", "app", fromGUIDParam},
+	http.MethodDelete + handlers.AppPath:                          {"audit.app.delete-request", "app", fromGUIDParam},
+	http.MethodPatch + handlers.AppEnvVarsPath:                    {"audit.app.update", "app", fromGUIDParam},
+	http.MethodPatch + handlers.AppFeaturePath:                    {"audit.app.update", "app", fromGUIDParam},
+	http.MethodPatch + handlers.AppCurrentDropletRelationshipPath: {"audit.app.droplet.mapped", "app", fromGUIDParam},
+	http.MethodPost + handlers.AppStartPath:                       {"audit.app.start", "app", fromGUIDParam},
+	http.MethodPost + handlers.AppStopPath:                        {"audit.app.stop", "app", fromGUIDParam},
+	http.MethodPost + handlers.AppRestartPath:                     {"audit.app.restart", "app", fromGUIDParam},
+	http.MethodPost + handlers.AppSidecarsPath:                    {"audit.app.sidecar.create", "app", fromGUIDParam},
+	http.MethodPost + handlers.AppProcessScalePath:                {"audit.app.process.scale", "app", fromGUIDParam},
+	http.MethodDelete + handlers.AppInstanceRestartPath:           {"audit.app.process.terminate_instance", "app", fromGUIDParam},
+	http.MethodPost + handlers.AppUsageEventsPurgePath:            {"audit.app_usage_events.purge", "user", fromActor},
+	http.MethodPost + handlers.BuildsPath:                         {"audit.app.build.create", "app", fromResponseApp},
+	http.MethodPatch + handlers.BuildPath:                         {"audit.build.update", "build", fromGUIDParam},
+	http.MethodPost + handlers.DeploymentsPath:                    {"audit.app.deployment.create", "app", fromResponseApp},
+	http.MethodPost + handlers.DeploymentContinuePath:             {"audit.app.deployment.continue", "app", fromResponseApp},
+	http.MethodPost + handlers.DeploymentCancelPath:               {"audit.app.deployment.cancel", "app", fromResponseApp},
+	http.MethodPost + handlers.DomainsPath:                        {"audit.domain.create", "domain", fromResponse},
+	http.MethodPatch + handlers.DomainPath:                        {"audit.domain.update", "domain", fromGUIDParam},
+	http.MethodDelete + handlers.DomainPath:                       {"audit.domain.delete-request", "domain", fromGUIDParam},
+	http.MethodPost + handlers.DropletsPath:                       {"audit.app.droplet.create", "app", fromResponseApp},
+	http.MethodPatch + handlers.DropletPath:                       {"audit.droplet.update", "droplet", fromGUIDParam},
+	http.MethodPost + handlers.DropletUploadPath:                  {"audit.app.droplet.upload", "app", fromResponseApp},
+	http.MethodPost + handlers.OrgsPath:                           {"audit.organization.create", "organization", fromResponse},
+	http.MethodPatch + handlers.OrgPath:                           {"audit.organization.update", "organization", fromGUIDParam},
+	http.MethodDelete + handlers.OrgPath:                          {"audit.organization.delete-request", "organization", fromGUIDParam},
+	http.MethodPost + handlers.OrgQuotasPath:                      {"audit.organization_quota.create", "organization_quota", fromResponse},
+	http.MethodPatch + handlers.OrgQuotaPath:                      {"audit.organization_quota.update", "organization_quota", fromGUIDParam},
+	http.MethodDelete + handlers.OrgQuotaPath:                     {"audit.organization_quota.delete", "organization_quota", fromGUIDParam},
+	http.MethodPost + handlers.OrgQuotaOrganizationsPath:          {"audit.organization_quota.apply", "organization_quota", fromGUIDParam},
+	http.MethodPost + handlers.PackagesPath:                       {"audit.app.package.create", "app", fromResponseApp},
+	http.MethodPatch + handlers.PackagePath:                       {"audit.package.update", "package", fromGUIDParam},
+	http.MethodPost + handlers.PackageUploadPath:                  {"audit.app.package.upload", "app", fromResponseApp},
+	http.MethodPatch + handlers.ProcessPath:                       {"audit.app.process.update", "app", fromResponseApp},
+	http.MethodPost + handlers.ProcessScalePath:                   {"audit.app.process.scale", "app", fromResponseApp},
+	http.MethodDelete + handlers.ProcessInstanceRestartPath:       {"audit.app.process.terminate_instance", "process", fromGUIDParam},
+	http.MethodPost + handlers.RolesPath:                          {"audit.role.create", "role", fromResponse},
+	http.MethodDelete + handlers.RolePath:                         {"audit.role.delete-request", "role", fromGUIDParam},
+	http.MethodPost + handlers.RoutesPath:                         {"audit.route.create", "route", fromResponse},
+	http.MethodPatch + handlers.RoutePath:                         {"audit.route.update", "route", fromGUIDParam},
+	http.MethodDelete + handlers.RoutePath:                        {"audit.route.delete-request", "route", fromGUIDParam},
+	http.MethodPost + handlers.RouteDestinationsPath:              {"audit.route.destinations.insert", "route", fromGUIDParam},
+	http.MethodDelete + handlers.RouteDestinationPath:             {"audit.route.destination.delete", "route", fromGUIDParam},
+	http.MethodPost + handlers.SecurityGroupsPath:                 {"audit.security_group.create", "security_group", fromResponse},
+	http.MethodPatch + handlers.SecurityGroupPath:                 {"audit.security_group.update", "security_group", fromGUIDParam},
+	http.MethodDelete + handlers.SecurityGroupPath:                {"audit.security_group.delete-request", "security_group", fromGUIDParam},
+	http.MethodPost + handlers.SecurityGroupRunningSpacesPath:     {"audit.security_group.bind_running_spaces", "security_group", fromGUIDParam},
+	http.MethodDelete + handlers.SecurityGroupRunningSpacePath:    {"audit.security_group.unbind_running_space", "security_group", fromGUIDParam},
+	http.MethodPost + handlers.SecurityGroupStagingSpacesPath:     {"audit.security_group.bind_staging_spaces", "security_group", fromGUIDParam},
+	http.MethodDelete + handlers.SecurityGroupStagingSpacePath:    {"audit.security_group.unbind_staging_space", "security_group", fromGUIDParam},
+	http.MethodPatch + handlers.SidecarPath:                       {"audit.sidecar.update", "sidecar", fromGUIDParam},
+	http.MethodDelete + handlers.SidecarPath:                      {"audit.sidecar.delete", "sidecar", fromGUIDParam},
+	http.MethodPost + handlers.ServiceBindingsPath:                {"audit.service_binding.create", "service_binding", fromResponse},
+	http.MethodPatch + handlers.ServiceBindingPath:                {"audit.service_binding.update", "service_binding", fromGUIDParam},
+	http.MethodDelete + handlers.ServiceBindingPath:               {"audit.service_binding.delete", "service_binding", fromGUIDParam},
+	http.MethodPost + handlers.ServiceBindingRotatePath:           {"audit.service_binding.rotate", "service_binding", fromGUIDParam},
+	http.MethodPost + handlers.ServiceBrokersPath:                 {"audit.service_broker.create", "service_broker", fromResponse},
+	http.MethodPatch + handlers.ServiceBrokerPath:                 {"audit.service_broker.update", "service_broker", fromGUIDParam},
+	http.MethodDelete + handlers.ServiceBrokerPath:                {"audit.service_broker.delete", "service_broker", fromGUIDParam},
+	http.MethodPost + handlers.ServiceBrokerRefreshPath:           {"audit.service_broker.refresh", "service_broker", fromGUIDParam},
+	http.MethodPost + handlers.ServiceInstancesPath:               {"audit.service_instance.create", "service_instance", fromResponse},
+	http.MethodPatch + handlers.ServiceInstancePath:               {"audit.service_instance.update", "service_instance", fromGUIDParam},
+	http.MethodDelete + handlers.ServiceInstancePath:              {"audit.service_instance.delete", "service_instance", fromGUIDParam},
+	http.MethodPost + handlers.ServiceInstanceSharedSpacesPath:    {"audit.service_instance.share", "service_instance", fromGUIDParam},
+	http.MethodDelete + handlers.ServiceInstanceSharedSpacePath:   {"audit.service_instance.unshare", "service_instance", fromGUIDParam},
+	http.MethodDelete + handlers.ServiceOfferingPath:              {"audit.service.delete", "service", fromGUIDParam},
+	http.MethodDelete + handlers.ServicePlanPath:                  {"audit.service_plan.delete", "service_plan", fromGUIDParam},
+	http.MethodPost + handlers.ServicePlanVisibilityPath:          {"audit.service_plan_visibility.update", "service_plan", fromGUIDParam},
+	http.MethodPatch + handlers.ServicePlanVisibilityPath:         {"audit.service_plan_visibility.update", "service_plan", fromGUIDParam},
+	http.MethodDelete + handlers.ServicePlanVisibilityOrgPath:     {"audit.service_plan_visibility.delete", "service_plan", fromGUIDParam},
+	http.MethodPost + handlers.ServiceRouteBindingsPath:           {"audit.service_route_binding.create", "service_route_binding", fromResponse},
+	http.MethodDelete + handlers.ServiceRouteBindingPath:          {"audit.service_route_binding.delete", "service_route_binding", fromGUIDParam},
+	http.MethodPost + handlers.ServiceUsageEventsPurgePath:        {"audit.service_usage_events.purge", "user", fromActor},
+	http.MethodPost + handlers.SpacesPath:                         {"audit.space.create", "space", fromResponse},
+	http.MethodPatch + handlers.SpacePath:                         {"audit.space.update", "space", fromGUIDParam},
+	http.MethodDelete + handlers.SpacePath:                        {"audit.space.delete-request", "space", fromGUIDParam},
+	http.MethodPatch + handlers.SpaceFeaturePath:                  {"audit.space.update", "space", fromGUIDParam},
+	http.MethodPost + handlers.SpaceManifestApplyPath:             {"audit.space.apply_manifest", "space", fromURLParam("spaceGUID")},
+	http.MethodPost + handlers.SpaceQuotasPath:                    {"audit.space_quota.create", "space_quota", fromResponse},
+	http.MethodPatch + handlers.SpaceQuotaPath:                    {"audit.space_quota.update", "space_quota", fromGUIDParam},
+	http.MethodDelete + handlers.SpaceQuotaPath:                   {"audit.space_quota.delete", "space_quota", fromGUIDParam},
+	http.MethodPost + handlers.SpaceQuotaSpacesPath:               {"audit.space_quota.apply", "space_quota", fromGUIDParam},
+	http.MethodDelete + handlers.SpaceQuotaSpacePath:              {"audit.space_quota.remove", "space_quota", fromGUIDParam},
+	http.MethodPost + handlers.SSHCodePath:                        {"audit.ssh_code.create", "user", fromActor},
+	http.MethodPost + handlers.TasksPath:                          {"audit.app.task.create", "app", fromURLParam("appGUID")},
+	http.MethodPatch + handlers.TaskPath:                          {"audit.app.task.update", "app", fromResponseApp},
+	http.MethodPost + handlers.TaskCancelPath:                     {"audit.app.task.cancel", "app", fromResponseApp},
+	http.MethodPut + handlers.TaskCancelPathDeprecated:            {"audit.app.task.cancel", "app", fromResponseApp},
+}
+
+type auditingResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *auditingResponseWriter) Write(bytes []byte) (int, error) {
+	w.body.Write(bytes)
+	return w.ResponseWriter.Write(bytes)
+}
+
+func (w *auditingResponseWriter) WriteHeader(statusCode int) {
+	w.ResponseWriter.WriteHeader(statusCode)
+	w.status = statusCode
+}
+
+type auditEvents struct {
+	auditEventRepo   AuditEventRepository
+	identityProvider IdentityProvider
+}
+
+// AuditEvents records an audit event for every successful request to a
+// mutating route. Failing to record an event does not fail the request.
+func AuditEvents(
+	auditEventRepo AuditEventRepository,
+	identityProvider IdentityProvider,
+) func(http.Handler) http.Handler {
+	return (&auditEvents{
+		auditEventRepo:   auditEventRepo,
+		identityProvider: identityProvider,
+	}).middleware
+}
+
+func (a *auditEvents) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		wrapper := &auditingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(wrapper, r)
+
+		if wrapper.status < 200 || wrapper.status >= 300 {
+			return
+		}
+
+		routeContext := chi.RouteContext(r.Context())
+		if routeContext == nil {
+			return
+		}
+
+		request, ok := auditedRequests[r.Method+routeContext.RoutePattern()]
+		if !ok {
+			return
+		}
+
+		logger := logr.FromContextOrDiscard(r.Context()).WithName("audit-events")
+		if err := a.record(r, request, wrapper); err != nil {
+			logger.Info("failed to record audit event", "type", request.eventType, "reason", err)
+		}
+	})
+}
+
+func (a *auditEvents) record(r *http.Request, request auditedRequest, w *auditingResponseWriter) error {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	identity, err := a.identityProvider.GetIdentity(r.Context(), authInfo)
+	if err != nil {
+		return err
+	}
+
+	response := auditedResponse{location: w.Header().Get("Location"), actor: identity.Name}
+	// Responses without a JSON body (e.g. 202 Accepted or 204 No Content)
+	// leave the response fields empty
+	_ = json.Unmarshal(w.body.Bytes(), &response)
+
+	target := repositories.AuditEventTarget{
+		GUID: request.targetGUID(r, response),
+		Type: request.targetType,
+	}
+	if target.GUID == "" {
+		return errors.New("failed to determine the target of the audit event")
+	}
+	if response.GUID == target.GUID {
+		target.Name = response.Name
+	}
+
+	_, err = a.auditEventRepo.CreateAuditEvent(r.Context(), repositories.CreateAuditEventMessage{
+		Type: request.eventType,
+		Actor: repositories.AuditEventActor{
+			GUID: identity.Name,
+			Type: actorType(identity),
+			Name: identity.Name,
+		},
+		Target: target,
+	})
+
+	return err
+}
+
+func actorType(identity authorization.Identity) string {
+	if identity.Kind == rbacv1.ServiceAccountKind {
+		return repositories.AuditEventActorTypeServiceAccount
+	}
+
+	return repositories.AuditEventActorTypeUser
+}
@@ -0,0 +1,264 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/middleware/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/go-chi/chi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("AuditEvents", func() {
+	var (
+		auditEventRepo   *fake.AuditEventRepository
+		identityProvider *fake.IdentityProvider
+		router           *chi.Mux
+		authInfo         authorization.Info
+		method           string
+		requestPath      string
+		responseStatus   int
+		responseBody     string
+		responseLocation string
+	)
+
+	BeforeEach(func() {
+		auditEventRepo = new(fake.AuditEventRepository)
+		identityProvider = new(fake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{
+			Name: "bob",
+			Kind: rbacv1.UserKind,
+		}, nil)
+
+		authInfo = authorization.Info{Token: "a-token"}
+		method = http.MethodPost
+		requestPath = "/v3/apps"
+		responseStatus = http.StatusCreated
+		responseBody = `{"guid": "app-guid", "name": "my-app"}`
+		responseLocation = ""
+
+		testHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if responseLocation != "" {
+				w.Header().Set("Location", responseLocation)
+			}
+			w.WriteHeader(responseStatus)
+			_, _ = w.Write([]byte(responseBody))
+		})
+
+		router = chi.NewRouter()
+		router.Use(middleware.AuditEvents(auditEventRepo, identityProvider))
+		router.Method(http.MethodGet, handlers.AppsPath, testHandler)
+		router.Method(http.MethodPost, handlers.AppsPath, testHandler)
+		router.Method(http.MethodPost, handlers.AppStartPath, testHandler)
+		router.Method(http.MethodPost, handlers.BuildsPath, testHandler)
+		router.Method(http.MethodPost, handlers.ServiceInstancesPath, testHandler)
+		router.Method(http.MethodPost, handlers.ResourceMatchesPath, testHandler)
+		router.Method(http.MethodPost, handlers.AppUsageEventsPurgePath, testHandler)
+	})
+
+	JustBeforeEach(func() {
+		ctx := authorization.NewContext(context.Background(), &authInfo)
+		request, err := http.NewRequestWithContext(ctx, method, "http://localhost"+requestPath, strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+
+		router.ServeHTTP(rr, request)
+	})
+
+	It("delegates to the next handler", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+		Expect(rr).To(HaveHTTPBody(responseBody))
+	})
+
+	It("records an audit event for the created resource", func() {
+		Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+		_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+
+		Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+		_, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+		Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+			Type: "audit.app.create",
+			Actor: repositories.AuditEventActor{
+				GUID: "bob",
+				Type: "user",
+				Name: "bob",
+			},
+			Target: repositories.AuditEventTarget{
+				GUID: "app-guid",
+				Type: "app",
+				Name: "my-app",
+			},
+		}))
+	})
+
+	When("the actor is a service account", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{
+				Name: "my-sa",
+				Kind: rbacv1.ServiceAccountKind,
+			}, nil)
+		})
+
+		It("records the actor type", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message.Actor.Type).To(Equal("service_account"))
+		})
+	})
+
+	When("the target guid is part of the url", func() {
+		BeforeEach(func() {
+			requestPath = "/v3/apps/app-guid/actions/start"
+			responseStatus = http.StatusOK
+		})
+
+		It("records an audit event for the resource in the url", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.start"))
+			Expect(message.Target).To(Equal(repositories.AuditEventTarget{
+				GUID: "app-guid",
+				Type: "app",
+				Name: "my-app",
+			}))
+		})
+
+		When("the response is about another resource", func() {
+			BeforeEach(func() {
+				responseBody = `{"guid": "another-guid", "name": "another-name"}`
+			})
+
+			It("does not record the target name", func() {
+				Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+				_, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+				Expect(message.Target.GUID).To(Equal("app-guid"))
+				Expect(message.Target.Name).To(BeEmpty())
+			})
+		})
+	})
+
+	When("the target is the app the created resource belongs to", func() {
+		BeforeEach(func() {
+			requestPath = "/v3/builds"
+			responseBody = `{"guid": "build-guid", "relationships": {"app": {"data": {"guid": "app-guid"}}}}`
+		})
+
+		It("records an audit event for the app", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.build.create"))
+			Expect(message.Target).To(Equal(repositories.AuditEventTarget{
+				GUID: "app-guid",
+				Type: "app",
+			}))
+		})
+	})
+
+	When("the resource is created asynchronously", func() {
+		BeforeEach(func() {
+			requestPath = "/v3/service_instances"
+			responseStatus = http.StatusAccepted
+			responseBody = ""
+			responseLocation = "https://api.example.org/v3/jobs/managed_service_instance.create~instance-guid"
+		})
+
+		It("records an audit event for the resource in the job location", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.service_instance.create"))
+			Expect(message.Target.GUID).To(Equal("instance-guid"))
+		})
+	})
+
+	When("the request does not act on a particular resource", func() {
+		BeforeEach(func() {
+			requestPath = "/v3/app_usage_events/actions/destructively_purge_all_and_reseed"
+			responseStatus = http.StatusOK
+			responseBody = "{}"
+		})
+
+		It("records an audit event for the actor", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app_usage_events.purge"))
+			Expect(message.Target).To(Equal(repositories.AuditEventTarget{
+				GUID: "bob",
+				Type: "user",
+			}))
+		})
+	})
+
+	When("the request is not mutating", func() {
+		BeforeEach(func() {
+			method = http.MethodGet
+			responseStatus = http.StatusOK
+		})
+
+		It("does not record an audit event", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	When("the route is not audited", func() {
+		BeforeEach(func() {
+			requestPath = "/v3/resource_matches"
+		})
+
+		It("does not record an audit event", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	When("the request fails", func() {
+		BeforeEach(func() {
+			responseStatus = http.StatusUnprocessableEntity
+		})
+
+		It("does not record an audit event", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	When("the target cannot be determined", func() {
+		BeforeEach(func() {
+			responseBody = ""
+		})
+
+		It("does not record an audit event", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	When("getting the identity fails", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("id-error"))
+		})
+
+		It("does not fail the request", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	When("recording the audit event fails", func() {
+		BeforeEach(func() {
+			auditEventRepo.CreateAuditEventReturns(repositories.AuditEventRecord{}, errors.New("record-error"))
+		})
+
+		It("does not fail the request", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(responseBody))
+		})
+	})
+})
@@ -37,7 +37,7 @@ var _ = Describe("Authentication Middleware", func() {
 		authInfoParser.ParseReturns(authorization.Info{Token: "the-token"}, nil)
 
 		identityProvider = new(fake.IdentityProvider)
-		identityProvider.GetIdentityReturns(authorization.Identity{}, nil)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "the-user", Kind: "User"}, nil)
 
 		authMiddleware = middleware.Authentication(
 			authInfoParser,
@@ -69,6 +69,12 @@ var _ = Describe("Authentication Middleware", func() {
 		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
 	})
 
+	It("injects the identity in the request context", func() {
+		actualIdentity, ok := authorization.IdentityFromContext(actualReq.Context())
+		Expect(ok).To(BeTrue())
+		Expect(actualIdentity).To(Equal(authorization.Identity{Name: "the-user", Kind: "User"}))
+	})
+
 	When("parsing the Authorization header fails", func() {
 		BeforeEach(func() {
 			authInfoParser.ParseReturns(authorization.Info{}, apierrors.NewInvalidAuthError(nil))
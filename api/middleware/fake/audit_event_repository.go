@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AuditEventRepository struct {
+	CreateAuditEventStub        func(context.Context, repositories.CreateAuditEventMessage) (repositories.AuditEventRecord, error)
+	createAuditEventMutex       sync.RWMutex
+	createAuditEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 repositories.CreateAuditEventMessage
+	}
+	createAuditEventReturns struct {
+		result1 repositories.AuditEventRecord
+		result2 error
+	}
+	createAuditEventReturnsOnCall map[int]struct {
+		result1 repositories.AuditEventRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *AuditEventRepository) CreateAuditEvent(arg1 context.Context, arg2 repositories.CreateAuditEventMessage) (repositories.AuditEventRecord, error) {
+	fake.createAuditEventMutex.Lock()
+	ret, specificReturn := fake.createAuditEventReturnsOnCall[len(fake.createAuditEventArgsForCall)]
+	fake.createAuditEventArgsForCall = append(fake.createAuditEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 repositories.CreateAuditEventMessage
+	}{arg1, arg2})
+	stub := fake.CreateAuditEventStub
+	fakeReturns := fake.createAuditEventReturns
+	fake.recordInvocation("CreateAuditEvent", []interface{}{arg1, arg2})
+	fake.createAuditEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *AuditEventRepository) CreateAuditEventCallCount() int {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	return len(fake.createAuditEventArgsForCall)
+}
+
+func (fake *AuditEventRepository) CreateAuditEventCalls(stub func(context.Context, repositories.CreateAuditEventMessage) (repositories.AuditEventRecord, error)) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = stub
+}
+
+func (fake *AuditEventRepository) CreateAuditEventArgsForCall(i int) (context.Context, repositories.CreateAuditEventMessage) {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	argsForCall := fake.createAuditEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *AuditEventRepository) CreateAuditEventReturns(result1 repositories.AuditEventRecord, result2 error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = nil
+	fake.createAuditEventReturns = struct {
+		result1 repositories.AuditEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AuditEventRepository) CreateAuditEventReturnsOnCall(i int, result1 repositories.AuditEventRecord, result2 error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = nil
+	if fake.createAuditEventReturnsOnCall == nil {
+		fake.createAuditEventReturnsOnCall = make(map[int]struct {
+			result1 repositories.AuditEventRecord
+			result2 error
+		})
+	}
+	fake.createAuditEventReturnsOnCall[i] = struct {
+		result1 repositories.AuditEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AuditEventRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *AuditEventRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ middleware.AuditEventRepository = new(AuditEventRepository)
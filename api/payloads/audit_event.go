@@ -0,0 +1,117 @@
+package payloads
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+var createdAtOperators = []string{
+	repositories.TimestampLessThan,
+	repositories.TimestampLessThanOrEqual,
+	repositories.TimestampGreaterThan,
+	repositories.TimestampGreaterThanOrEqual,
+}
+
+type AuditEventList struct {
+	Types             string
+	TargetGUIDs       string
+	SpaceGUIDs        string
+	OrganizationGUIDs string
+	CreatedAts        []time.Time
+	CreatedAtFilters  []repositories.TimestampFilter
+	OrderBy           string
+}
+
+func (l AuditEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+	)
+}
+
+func (l *AuditEventList) ToMessage() repositories.ListAuditEventsMessage {
+	return repositories.ListAuditEventsMessage{
+		Types:             parse.ArrayParam(l.Types),
+		TargetGUIDs:       parse.ArrayParam(l.TargetGUIDs),
+		SpaceGUIDs:        parse.ArrayParam(l.SpaceGUIDs),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		CreatedAts:        l.CreatedAts,
+		CreatedAtFilters:  l.CreatedAtFilters,
+		OrderBy:           l.OrderBy,
+	}
+}
+
+func (l *AuditEventList) SupportedKeys() []string {
+	keys := []string{
+		"types",
+		"target_guids",
+		"space_guids",
+		"organization_guids",
+		"created_ats",
+		"order_by",
+		"per_page",
+		"page",
+	}
+	for _, operator := range createdAtOperators {
+		keys = append(keys, createdAtsKey(operator))
+	}
+
+	return keys
+}
+
+func (l *AuditEventList) DecodeFromURLValues(values url.Values) error {
+	l.Types = values.Get("types")
+	l.TargetGUIDs = values.Get("target_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.OrderBy = values.Get("order_by")
+
+	var err error
+	l.CreatedAts, err = parseTimestamps(values.Get("created_ats"))
+	if err != nil {
+		return fmt.Errorf("failed to parse 'created_ats' query parameter: %w", err)
+	}
+
+	l.CreatedAtFilters = nil
+	for _, operator := range createdAtOperators {
+		key := createdAtsKey(operator)
+		if !values.Has(key) {
+			continue
+		}
+
+		var timestamp time.Time
+		timestamp, err = time.Parse(time.RFC3339, values.Get(key))
+		if err != nil {
+			return fmt.Errorf("failed to parse '%s' query parameter: %w", key, err)
+		}
+
+		l.CreatedAtFilters = append(l.CreatedAtFilters, repositories.TimestampFilter{
+			Operator: operator,
+			Value:    timestamp,
+		})
+	}
+
+	return nil
+}
+
+func createdAtsKey(operator string) string {
+	return fmt.Sprintf("created_ats[%s]", operator)
+}
+
+func parseTimestamps(value string) ([]time.Time, error) {
+	var timestamps []time.Time
+	for _, t := range parse.ArrayParam(value) {
+		timestamp, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return nil, err
+		}
+		timestamps = append(timestamps, timestamp)
+	}
+
+	return timestamps, nil
+}
@@ -0,0 +1,89 @@
+package payloads_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedAuditEventList payloads.AuditEventList) {
+				actualAuditEventList, decodeErr := decodeQuery[payloads.AuditEventList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualAuditEventList).To(Equal(expectedAuditEventList))
+			},
+			Entry("types", "types=audit.app.create,audit.app.start", payloads.AuditEventList{Types: "audit.app.create,audit.app.start"}),
+			Entry("target_guids", "target_guids=g1,g2", payloads.AuditEventList{TargetGUIDs: "g1,g2"}),
+			Entry("space_guids", "space_guids=s1,s2", payloads.AuditEventList{SpaceGUIDs: "s1,s2"}),
+			Entry("organization_guids", "organization_guids=o1,o2", payloads.AuditEventList{OrganizationGUIDs: "o1,o2"}),
+			Entry("created_ats", "created_ats=2024-01-01T00:00:00Z,2024-01-02T00:00:00Z", payloads.AuditEventList{
+				CreatedAts: []time.Time{
+					time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+			}),
+			Entry("created_ats[lt]", "created_ats[lt]=2024-01-01T00:00:00Z", payloads.AuditEventList{
+				CreatedAtFilters: []repositories.TimestampFilter{
+					{Operator: repositories.TimestampLessThan, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			}),
+			Entry("created_ats[gte] and created_ats[lte]", "created_ats[gte]=2024-01-01T00:00:00Z&created_ats[lte]=2024-01-02T00:00:00Z", payloads.AuditEventList{
+				CreatedAtFilters: []repositories.TimestampFilter{
+					{Operator: repositories.TimestampLessThanOrEqual, Value: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+					{Operator: repositories.TimestampGreaterThanOrEqual, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			}),
+			Entry("order_by created_at", "order_by=created_at", payloads.AuditEventList{OrderBy: "created_at"}),
+			Entry("order_by -created_at", "order_by=-created_at", payloads.AuditEventList{OrderBy: "-created_at"}),
+			Entry("order_by updated_at", "order_by=updated_at", payloads.AuditEventList{OrderBy: "updated_at"}),
+			Entry("order_by -updated_at", "order_by=-updated_at", payloads.AuditEventList{OrderBy: "-updated_at"}),
+			Entry("page", "page=3", payloads.AuditEventList{}),
+			Entry("per_page", "per_page=10", payloads.AuditEventList{}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.AuditEventList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("invalid created_ats", "created_ats=yesterday", "failed to parse 'created_ats' query parameter"),
+			Entry("invalid created_ats[gt]", "created_ats[gt]=yesterday", "failed to parse 'created_ats[gt]' query parameter"),
+			Entry("unsupported created_ats operator", "created_ats[foo]=2024-01-01T00:00:00Z", "unsupported query parameter"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			auditEventList := payloads.AuditEventList{
+				Types:             "audit.app.create,audit.app.start",
+				TargetGUIDs:       "g1,g2",
+				SpaceGUIDs:        "s1,s2",
+				OrganizationGUIDs: "o1,o2",
+				CreatedAts:        []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				CreatedAtFilters: []repositories.TimestampFilter{
+					{Operator: repositories.TimestampGreaterThan, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+				OrderBy: "-created_at",
+			}
+			Expect(auditEventList.ToMessage()).To(Equal(repositories.ListAuditEventsMessage{
+				Types:             []string{"audit.app.create", "audit.app.start"},
+				TargetGUIDs:       []string{"g1", "g2"},
+				SpaceGUIDs:        []string{"s1", "s2"},
+				OrganizationGUIDs: []string{"o1", "o2"},
+				CreatedAts:        []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				CreatedAtFilters: []repositories.TimestampFilter{
+					{Operator: repositories.TimestampGreaterThan, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+				OrderBy: "-created_at",
+			}))
+		})
+	})
+})
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"github.com/BooleanCat/go-functional/v2/it"
 	jellidation "github.com/jellydator/validation"
 )
@@ -17,21 +18,67 @@ type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 
+type RevisionGUID struct {
+	Guid string `json:"guid"`
+}
+
 type DeploymentCreate struct {
 	Droplet       DropletGUID              `json:"droplet"`
+	Revision      *RevisionGUID            `json:"revision"`
 	Relationships *DeploymentRelationships `json:"relationships"`
+	Strategy      string                   `json:"strategy"`
+	Options       *DeploymentOptions       `json:"options"`
+}
+
+type DeploymentOptions struct {
+	MaxInFlight *int32 `json:"max_in_flight"`
+}
+
+func (o DeploymentOptions) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.MaxInFlight, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
 }
 
 func (c DeploymentCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
-		jellidation.Field(&c.Relationships, jellidation.NotNil))
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+		jellidation.Field(&c.Strategy, validation.OneOf(
+			korifiv1alpha1.RollingDeploymentStrategy,
+			korifiv1alpha1.CanaryDeploymentStrategy,
+		)),
+		jellidation.Field(&c.Options),
+		jellidation.Field(&c.Revision,
+			jellidation.When(c.Droplet.Guid != "", jellidation.Nil.Error("cannot pass both 'droplet' and 'revision' in a create deployment request")),
+		),
+	)
+}
+
+func (r RevisionGUID) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Guid, jellidation.Required))
 }
 
 func (c *DeploymentCreate) ToMessage() repositories.CreateDeploymentMessage {
-	return repositories.CreateDeploymentMessage{
+	message := repositories.CreateDeploymentMessage{
 		AppGUID:     c.Relationships.App.Data.GUID,
 		DropletGUID: c.Droplet.Guid,
+		Strategy:    korifiv1alpha1.RollingDeploymentStrategy,
 	}
+
+	if c.Strategy != "" {
+		message.Strategy = c.Strategy
+	}
+
+	if c.Options != nil {
+		message.MaxInFlight = c.Options.MaxInFlight
+	}
+
+	if c.Revision != nil {
+		message.RevisionGUID = c.Revision.Guid
+	}
+
+	return message
 }
 
 type DeploymentRelationships struct {
@@ -3,6 +3,7 @@ package payloads_test
 import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -86,6 +87,86 @@ var _ = Describe("DeploymentCreate", func() {
 				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
 			})
 		})
+
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{
+					Guid: "the-revision",
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+
+			When("the revision guid is not specified", func() {
+				BeforeEach(func() {
+					createDeployment.Revision.Guid = ""
+				})
+
+				It("says revision guid is required", func() {
+					expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+				})
+			})
+
+			When("the droplet is specified too", func() {
+				BeforeEach(func() {
+					createDeployment.Droplet = payloads.DropletGUID{
+						Guid: "the-droplet",
+					}
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "cannot pass both 'droplet' and 'revision'")
+				})
+			})
+		})
+
+		When("the canary strategy is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "canary"
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+		})
+
+		When("max in flight is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Options = &payloads.DeploymentOptions{
+					MaxInFlight: tools.PtrTo[int32](3),
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+
+			When("max in flight is not positive", func() {
+				BeforeEach(func() {
+					createDeployment.Options.MaxInFlight = tools.PtrTo[int32](0)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "max_in_flight must be no less than 1")
+				})
+			})
+		})
+
+		When("the strategy is not supported", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "blue-green"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "strategy value must be one of")
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -99,8 +180,48 @@ var _ = Describe("DeploymentCreate", func() {
 			Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
 				AppGUID:     "the-app",
 				DropletGUID: "the-droplet",
+				Strategy:    "rolling",
 			}))
 		})
+
+		When("a strategy is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "canary"
+			})
+
+			It("sets the strategy", func() {
+				Expect(createMessage.Strategy).To(Equal("canary"))
+			})
+		})
+
+		When("max in flight is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Options = &payloads.DeploymentOptions{
+					MaxInFlight: tools.PtrTo[int32](3),
+				}
+			})
+
+			It("sets max in flight", func() {
+				Expect(createMessage.MaxInFlight).To(gstruct.PointTo(BeEquivalentTo(3)))
+			})
+		})
+
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{
+					Guid: "the-revision",
+				}
+			})
+
+			It("sets the revision guid", func() {
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:      "the-app",
+					RevisionGUID: "the-revision",
+					Strategy:     "rolling",
+				}))
+			})
+		})
 	})
 })
 
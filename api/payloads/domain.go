@@ -13,6 +13,7 @@ import (
 type DomainCreate struct {
 	Name          string                  `json:"name"`
 	Internal      bool                    `json:"internal"`
+	RouterGroup   *DomainRouterGroup      `json:"router_group"`
 	Metadata      Metadata                `json:"metadata"`
 	Relationships map[string]Relationship `json:"relationships"`
 }
@@ -20,11 +21,22 @@ type DomainCreate struct {
 func (c DomainCreate) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.Name, payload_validation.StrictlyRequired),
+		validation.Field(&c.RouterGroup),
 		validation.Field(&c.Metadata),
 		validation.Field(&c.Relationships),
 	)
 }
 
+type DomainRouterGroup struct {
+	GUID string `json:"guid"`
+}
+
+func (g DomainRouterGroup) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.GUID, payload_validation.StrictlyRequired),
+	)
+}
+
 func (c *DomainCreate) ToMessage() (repositories.CreateDomainMessage, error) {
 	if c.Internal {
 		return repositories.CreateDomainMessage{}, errors.New("internal domains are not supported")
@@ -34,8 +46,14 @@ func (c *DomainCreate) ToMessage() (repositories.CreateDomainMessage, error) {
 		return repositories.CreateDomainMessage{}, errors.New("private domains are not supported")
 	}
 
+	routerGroup := ""
+	if c.RouterGroup != nil {
+		routerGroup = c.RouterGroup.GUID
+	}
+
 	return repositories.CreateDomainMessage{
-		Name: c.Name,
+		Name:        c.Name,
+		RouterGroup: routerGroup,
 		Metadata: repositories.Metadata{
 			Labels:      c.Metadata.Labels,
 			Annotations: c.Metadata.Annotations,
@@ -68,6 +68,16 @@ var _ = Describe("DomainCreate", func() {
 			})
 		})
 
+		When("the router group guid is empty", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.DomainRouterGroup{}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+			})
+		})
+
 		When("relationship is invalid", func() {
 			BeforeEach(func() {
 				createPayload.Relationships = map[string]payloads.Relationship{
@@ -113,6 +123,17 @@ var _ = Describe("DomainCreate", func() {
 			}))
 		})
 
+		When("the payload has a router group", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.DomainRouterGroup{GUID: "default-tcp"}
+			})
+
+			It("sets the router group on the message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.RouterGroup).To(Equal("default-tcp"))
+			})
+		})
+
 		When("the payload has internal set to true", func() {
 			BeforeEach(func() {
 				createPayload.Internal = true
@@ -1,10 +1,104 @@
 package payloads
 
 import (
+	"net/url"
+
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
 
+type DropletCreate struct {
+	Relationships *DropletRelationships `json:"relationships"`
+	ProcessTypes  map[string]string     `json:"process_types"`
+	Metadata      Metadata              `json:"metadata"`
+}
+
+func (c DropletCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Relationships, validation.NotNil),
+		validation.Field(&c.Metadata),
+	)
+}
+
+func (c DropletCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateDropletMessage {
+	return repositories.CreateDropletMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		ProcessTypes: c.ProcessTypes,
+		Metadata: repositories.Metadata{
+			Annotations: c.Metadata.Annotations,
+			Labels:      c.Metadata.Labels,
+		},
+	}
+}
+
+type DropletCreateQuery struct {
+	SourceGUID string
+}
+
+func (q *DropletCreateQuery) SupportedKeys() []string {
+	return []string{"source_guid"}
+}
+
+func (q *DropletCreateQuery) DecodeFromURLValues(values url.Values) error {
+	q.SourceGUID = values.Get("source_guid")
+	return nil
+}
+
+type DropletCopy struct {
+	Relationships *DropletRelationships `json:"relationships"`
+}
+
+func (c DropletCopy) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Relationships, validation.NotNil),
+	)
+}
+
+func (c DropletCopy) ToMessage(appRecord repositories.AppRecord, source repositories.DropletRecord) repositories.CreateDropletMessage {
+	return repositories.CreateDropletMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		ProcessTypes: source.ProcessTypes,
+		Ports:        source.Ports,
+	}
+}
+
+type DropletRelationships struct {
+	App *Relationship `json:"app"`
+}
+
+func (r DropletRelationships) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.App, validation.NotNil))
+}
+
+type DropletUpload struct {
+	Image string
+	// Username and Password are the credentials to pull Image with, it is
+	// pulled anonymously when unset
+	Username string
+	Password string
+}
+
+func (u *DropletUpload) SupportedKeys() []string {
+	return []string{"image", "username", "password"}
+}
+
+func (u *DropletUpload) DecodeFromURLValues(values url.Values) error {
+	u.Image = values.Get("image")
+	u.Username = values.Get("username")
+	u.Password = values.Get("password")
+	return nil
+}
+
+func (u DropletUpload) ToRegistryCredentials() repositories.RegistryCredentials {
+	return repositories.RegistryCredentials{
+		Username: u.Username,
+		Password: u.Password,
+	}
+}
+
 type DropletUpdate struct {
 	Metadata MetadataPatch `json:"metadata"`
 }
@@ -2,6 +2,7 @@ package payloads_test
 
 import (
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
@@ -9,6 +10,168 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+var _ = Describe("DropletCreate", func() {
+	var (
+		createPayload  payloads.DropletCreate
+		decodedPayload *payloads.DropletCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		createPayload = payloads.DropletCreate{
+			Relationships: &payloads.DropletRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "app-guid",
+					},
+				},
+			},
+			ProcessTypes: map[string]string{
+				"web": "bundle exec rackup",
+			},
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{
+					"foo": "bar",
+				},
+			},
+		}
+		decodedPayload = new(payloads.DropletCreate)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("relationships is not set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the app relationship is not set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.App = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "app is required")
+		})
+	})
+
+	When("metadata.labels contains an invalid key", func() {
+		BeforeEach(func() {
+			createPayload.Metadata.Labels = map[string]string{
+				"foo.cloudfoundry.org/bar": "jim",
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a create droplet message", func() {
+			Expect(createPayload.ToMessage(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("DropletCreateQuery", func() {
+	It("decodes the source guid", func() {
+		query, err := decodeQuery[payloads.DropletCreateQuery]("source_guid=src-droplet-guid")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query.SourceGUID).To(Equal("src-droplet-guid"))
+	})
+})
+
+var _ = Describe("DropletCopy", func() {
+	var (
+		copyPayload    payloads.DropletCopy
+		decodedPayload *payloads.DropletCopy
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		copyPayload = payloads.DropletCopy{
+			Relationships: &payloads.DropletRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "dest-app-guid",
+					},
+				},
+			},
+		}
+		decodedPayload = new(payloads.DropletCopy)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(copyPayload)))
+	})
+
+	When("the app relationship is not set", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships.App = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "app is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("copies the process types and ports of the source droplet", func() {
+			Expect(copyPayload.ToMessage(
+				repositories.AppRecord{GUID: "dest-app-guid", SpaceGUID: "dest-space-guid"},
+				repositories.DropletRecord{ProcessTypes: map[string]string{"web": "run"}, Ports: []int32{8080}},
+			)).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      "dest-app-guid",
+				SpaceGUID:    "dest-space-guid",
+				ProcessTypes: map[string]string{"web": "run"},
+				Ports:        []int32{8080},
+			}))
+		})
+	})
+})
+
+var _ = Describe("DropletUpload", func() {
+	It("decodes the image reference", func() {
+		upload, err := decodeQuery[payloads.DropletUpload]("image=registry.example.com/my/droplet:latest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upload.Image).To(Equal("registry.example.com/my/droplet:latest"))
+	})
+
+	It("decodes the registry credentials", func() {
+		upload, err := decodeQuery[payloads.DropletUpload]("image=registry.example.com/my/droplet:latest&username=user&password=pass")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upload.ToRegistryCredentials()).To(Equal(repositories.RegistryCredentials{
+			Username: "user",
+			Password: "pass",
+		}))
+	})
+})
+
 var _ = Describe("DropletUpdate", func() {
 	Describe("Decode", func() {
 		var (
@@ -0,0 +1,44 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("FeaturePatch", func() {
+	var (
+		payload        payloads.FeaturePatch
+		decodedPayload *payloads.FeaturePatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.FeaturePatch{
+			Enabled: tools.PtrTo(false),
+		}
+
+		decodedPayload = new(payloads.FeaturePatch)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("enabled is not set", func() {
+		BeforeEach(func() {
+			payload.Enabled = nil
+		})
+
+		It("returns an unprocessable entity error", func() {
+			expectUnprocessableEntityError(validatorErr, "enabled is required")
+		})
+	})
+})
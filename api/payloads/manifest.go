@@ -45,6 +45,7 @@ type ManifestApplication struct {
 	Buildpack *string                      `json:"buildpack" yaml:"buildpack"`
 	Metadata  MetadataPatch                `json:"metadata" yaml:"metadata"`
 	Services  []ManifestApplicationService `json:"services" yaml:"services"`
+	Sidecars  []ManifestApplicationSidecar `json:"sidecars" yaml:"sidecars"`
 	Docker    any                          `json:"docker,omitempty" yaml:"docker,omitempty"`
 }
 
@@ -88,6 +89,13 @@ func (s *ManifestApplicationService) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+type ManifestApplicationSidecar struct {
+	Name         string   `json:"name" yaml:"name"`
+	Command      string   `json:"command" yaml:"command"`
+	ProcessTypes []string `json:"process_types" yaml:"process_types"`
+	Memory       *string  `json:"memory" yaml:"memory"`
+}
+
 type ManifestRoute struct {
 	Route *string `json:"route" yaml:"route"`
 }
@@ -208,6 +216,34 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 	return message
 }
 
+func (s ManifestApplicationSidecar) ToSidecarCreateMessage(appGUID, spaceGUID string) repositories.CreateSidecarMessage {
+	msg := repositories.CreateSidecarMessage{
+		AppGUID:      appGUID,
+		SpaceGUID:    spaceGUID,
+		Name:         s.Name,
+		Command:      s.Command,
+		ProcessTypes: s.ProcessTypes,
+	}
+
+	if s.Memory != nil {
+		msg.MemoryMB = parseMegabytes(*s.Memory)
+	}
+
+	return msg
+}
+
+func (s ManifestApplicationSidecar) ToSidecarUpdateMessage(sidecarGUID string) repositories.UpdateSidecarMessage {
+	message := repositories.UpdateSidecarMessage{
+		GUID:         sidecarGUID,
+		Command:      tools.PtrTo(s.Command),
+		ProcessTypes: s.ProcessTypes,
+	}
+	if s.Memory != nil {
+		message.MemoryMB = tools.PtrTo(parseMegabytes(*s.Memory))
+	}
+	return message
+}
+
 func (m Manifest) Validate() error {
 	return validation.ValidateStruct(&m,
 		validation.Field(&m.Applications))
@@ -226,6 +262,7 @@ func (a ManifestApplication) Validate() error {
 		validation.Field(&a.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.Processes),
 		validation.Field(&a.Routes),
+		validation.Field(&a.Sidecars),
 		validation.Field(&a.Docker, validation.When(len(a.Buildpacks) > 0 || a.Buildpack != nil,
 			validation.Nil.Error("must be blank when buildpacks are specified"),
 		)),
@@ -245,6 +282,15 @@ func (p ManifestApplicationProcess) Validate() error {
 	)
 }
 
+func (s ManifestApplicationSidecar) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Required),
+		validation.Field(&s.Command, validation.Required),
+		validation.Field(&s.ProcessTypes, validation.Required, validation.Each(validation.Required)),
+		validation.Field(&s.Memory, validation.By(validateAmountWithUnit)),
+	)
+}
+
 func (m ManifestRoute) Validate() error {
 	routeRegex := regexp.MustCompile(
 		`^(?:https?://|tcp://)?(?:(?:[\w-]+\.)|(?:[*]\.))+\w+(?:\:\d+)?(?:/.*)*(?:\.\w+)?$`,
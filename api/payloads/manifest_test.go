@@ -859,4 +859,103 @@ var _ = Describe("Manifest payload", func() {
 			})
 		})
 	})
+
+	Describe("ManifestApplicationSidecar", func() {
+		var sidecarInfo ManifestApplicationSidecar
+
+		BeforeEach(func() {
+			sidecarInfo = ManifestApplicationSidecar{
+				Name:         "agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web", "worker"},
+				Memory:       tools.PtrTo("64M"),
+			}
+		})
+
+		Describe("Validate", func() {
+			var validateErr error
+
+			JustBeforeEach(func() {
+				validateErr = validator.DecodeAndValidateYAMLPayload(createYAMLRequest(sidecarInfo), &ManifestApplicationSidecar{})
+			})
+
+			It("validates the struct", func() {
+				Expect(validateErr).NotTo(HaveOccurred())
+			})
+
+			When("name is not specified", func() {
+				BeforeEach(func() {
+					sidecarInfo.Name = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "name cannot be blank")
+				})
+			})
+
+			When("command is not specified", func() {
+				BeforeEach(func() {
+					sidecarInfo.Command = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "command cannot be blank")
+				})
+			})
+
+			When("process types are not specified", func() {
+				BeforeEach(func() {
+					sidecarInfo.ProcessTypes = nil
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "process_types cannot be blank")
+				})
+			})
+
+			When("the memory has no unit", func() {
+				BeforeEach(func() {
+					sidecarInfo.Memory = tools.PtrTo("64")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "memory must use a supported unit")
+				})
+			})
+		})
+
+		Describe("ToSidecarCreateMessage", func() {
+			It("converts to a create sidecar message", func() {
+				Expect(sidecarInfo.ToSidecarCreateMessage("app-guid", spaceGUID)).To(Equal(repositories.CreateSidecarMessage{
+					AppGUID:      "app-guid",
+					SpaceGUID:    spaceGUID,
+					Name:         "agent",
+					Command:      "run-agent",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     64,
+				}))
+			})
+		})
+
+		Describe("ToSidecarUpdateMessage", func() {
+			It("converts to an update sidecar message", func() {
+				Expect(sidecarInfo.ToSidecarUpdateMessage("sidecar-guid")).To(Equal(repositories.UpdateSidecarMessage{
+					GUID:         "sidecar-guid",
+					Command:      tools.PtrTo("run-agent"),
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     tools.PtrTo[int64](64),
+				}))
+			})
+
+			When("the memory is not specified", func() {
+				BeforeEach(func() {
+					sidecarInfo.Memory = nil
+				})
+
+				It("leaves the memory unchanged", func() {
+					Expect(sidecarInfo.ToSidecarUpdateMessage("sidecar-guid").MemoryMB).To(BeNil())
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,266 @@
+package payloads
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/BooleanCat/go-functional/v2/it"
+	jellidation "github.com/jellydator/validation"
+)
+
+type QuotaAppLimits struct {
+	TotalMemoryInMB              *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB         *int64 `json:"per_process_memory_in_mb"`
+	TotalInstances               *int64 `json:"total_instances"`
+	PerAppTasks                  *int64 `json:"per_app_tasks"`
+	LogRateLimitInBytesPerSecond *int64 `json:"log_rate_limit_in_bytes_per_second"`
+}
+
+func (l QuotaAppLimits) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.TotalMemoryInMB, jellidation.Min(int64(0))),
+		jellidation.Field(&l.PerProcessMemoryInMB, jellidation.Min(int64(0))),
+		jellidation.Field(&l.TotalInstances, jellidation.Min(int64(0))),
+		jellidation.Field(&l.PerAppTasks, jellidation.Min(int64(0))),
+		jellidation.Field(&l.LogRateLimitInBytesPerSecond, jellidation.Min(int64(0))),
+	)
+}
+
+func (l QuotaAppLimits) toRepo() repositories.AppQuotaLimits {
+	return repositories.AppQuotaLimits{
+		TotalMemoryInMB:              l.TotalMemoryInMB,
+		PerProcessMemoryInMB:         l.PerProcessMemoryInMB,
+		TotalInstances:               l.TotalInstances,
+		PerAppTasks:                  l.PerAppTasks,
+		LogRateLimitInBytesPerSecond: l.LogRateLimitInBytesPerSecond,
+	}
+}
+
+// QuotaServiceLimits accepts all the service limits of the CF API. Korifi
+// has no paid plans and does not limit service keys, so only the service
+// instance limit is kept.
+type QuotaServiceLimits struct {
+	PaidServicesAllowed   *bool  `json:"paid_services_allowed"`
+	TotalServiceInstances *int64 `json:"total_service_instances"`
+	TotalServiceKeys      *int64 `json:"total_service_keys"`
+}
+
+func (l QuotaServiceLimits) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.TotalServiceInstances, jellidation.Min(int64(0))),
+		jellidation.Field(&l.TotalServiceKeys, jellidation.Min(int64(0))),
+	)
+}
+
+// QuotaRouteLimits accepts all the route limits of the CF API. Korifi has no
+// reserved ports, so only the route limit is kept.
+type QuotaRouteLimits struct {
+	TotalRoutes        *int64 `json:"total_routes"`
+	TotalReservedPorts *int64 `json:"total_reserved_ports"`
+}
+
+func (l QuotaRouteLimits) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.TotalRoutes, jellidation.Min(int64(0))),
+		jellidation.Field(&l.TotalReservedPorts, jellidation.Min(int64(0))),
+	)
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+type OrgQuotaCreate struct {
+	Name          string                `json:"name"`
+	Apps          QuotaAppLimits        `json:"apps"`
+	Services      QuotaServiceLimits    `json:"services"`
+	Routes        QuotaRouteLimits      `json:"routes"`
+	Relationships OrgQuotaRelationships `json:"relationships"`
+}
+
+func (c OrgQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.Apps),
+		jellidation.Field(&c.Services),
+		jellidation.Field(&c.Routes),
+	)
+}
+
+func (c OrgQuotaCreate) ToMessage() repositories.CreateOrgQuotaMessage {
+	return repositories.CreateOrgQuotaMessage{
+		Name: c.Name,
+		Limits: repositories.QuotaLimits{
+			Apps: c.Apps.toRepo(),
+			Services: repositories.ServiceQuotaLimits{
+				TotalServiceInstances: c.Services.TotalServiceInstances,
+			},
+			Routes: repositories.RouteQuotaLimits{
+				TotalRoutes: c.Routes.TotalRoutes,
+			},
+		},
+		OrgGUIDs: relationshipGUIDs(c.Relationships.Organizations.Data),
+	}
+}
+
+// QuotaLimitPatch is a single limit in a quota patch request. As opposed to
+// a limit missing from the request, a limit set to null makes it unlimited.
+type QuotaLimitPatch struct {
+	Set   bool
+	Value *int64
+}
+
+func (p *QuotaLimitPatch) UnmarshalJSON(data []byte) error {
+	p.Set = true
+	return json.Unmarshal(data, &p.Value)
+}
+
+func (p QuotaLimitPatch) Validate() error {
+	return jellidation.Validate(p.Value, jellidation.Min(int64(0)))
+}
+
+func (p QuotaLimitPatch) toRepo() *repositories.QuotaLimitPatch {
+	if !p.Set {
+		return nil
+	}
+
+	return &repositories.QuotaLimitPatch{Value: p.Value}
+}
+
+type QuotaAppLimitsPatch struct {
+	TotalMemoryInMB              QuotaLimitPatch `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB         QuotaLimitPatch `json:"per_process_memory_in_mb"`
+	TotalInstances               QuotaLimitPatch `json:"total_instances"`
+	PerAppTasks                  QuotaLimitPatch `json:"per_app_tasks"`
+	LogRateLimitInBytesPerSecond QuotaLimitPatch `json:"log_rate_limit_in_bytes_per_second"`
+}
+
+func (p QuotaAppLimitsPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.TotalMemoryInMB),
+		jellidation.Field(&p.PerProcessMemoryInMB),
+		jellidation.Field(&p.TotalInstances),
+		jellidation.Field(&p.PerAppTasks),
+		jellidation.Field(&p.LogRateLimitInBytesPerSecond),
+	)
+}
+
+type QuotaServiceLimitsPatch struct {
+	PaidServicesAllowed   *bool           `json:"paid_services_allowed"`
+	TotalServiceInstances QuotaLimitPatch `json:"total_service_instances"`
+	TotalServiceKeys      QuotaLimitPatch `json:"total_service_keys"`
+}
+
+func (p QuotaServiceLimitsPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.TotalServiceInstances),
+		jellidation.Field(&p.TotalServiceKeys),
+	)
+}
+
+type QuotaRouteLimitsPatch struct {
+	TotalRoutes        QuotaLimitPatch `json:"total_routes"`
+	TotalReservedPorts QuotaLimitPatch `json:"total_reserved_ports"`
+}
+
+func (p QuotaRouteLimitsPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.TotalRoutes),
+		jellidation.Field(&p.TotalReservedPorts),
+	)
+}
+
+type QuotaLimitsPatch struct {
+	Apps     QuotaAppLimitsPatch     `json:"apps"`
+	Services QuotaServiceLimitsPatch `json:"services"`
+	Routes   QuotaRouteLimitsPatch   `json:"routes"`
+}
+
+func (p QuotaLimitsPatch) toRepo() repositories.QuotaLimitsPatch {
+	return repositories.QuotaLimitsPatch{
+		Apps: repositories.AppQuotaLimitsPatch{
+			TotalMemoryInMB:              p.Apps.TotalMemoryInMB.toRepo(),
+			PerProcessMemoryInMB:         p.Apps.PerProcessMemoryInMB.toRepo(),
+			TotalInstances:               p.Apps.TotalInstances.toRepo(),
+			PerAppTasks:                  p.Apps.PerAppTasks.toRepo(),
+			LogRateLimitInBytesPerSecond: p.Apps.LogRateLimitInBytesPerSecond.toRepo(),
+		},
+		Services: repositories.ServiceQuotaLimitsPatch{
+			TotalServiceInstances: p.Services.TotalServiceInstances.toRepo(),
+		},
+		Routes: repositories.RouteQuotaLimitsPatch{
+			TotalRoutes: p.Routes.TotalRoutes.toRepo(),
+		},
+	}
+}
+
+type OrgQuotaPatch struct {
+	Name             *string `json:"name"`
+	QuotaLimitsPatch `json:",inline"`
+}
+
+func (p OrgQuotaPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&p.Apps),
+		jellidation.Field(&p.Services),
+		jellidation.Field(&p.Routes),
+	)
+}
+
+func (p OrgQuotaPatch) ToMessage(guid string) repositories.UpdateOrgQuotaMessage {
+	return repositories.UpdateOrgQuotaMessage{
+		GUID:   guid,
+		Name:   p.Name,
+		Limits: p.toRepo(),
+	}
+}
+
+type OrgQuotaApply struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (a OrgQuotaApply) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Data, jellidation.Required),
+	)
+}
+
+func (a OrgQuotaApply) ToMessage(guid string) repositories.ApplyOrgQuotaMessage {
+	return repositories.ApplyOrgQuotaMessage{
+		GUID:     guid,
+		OrgGUIDs: relationshipGUIDs(a.Data),
+	}
+}
+
+type OrgQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+}
+
+func (l *OrgQuotaList) ToMessage() repositories.ListOrgQuotasMessage {
+	return repositories.ListOrgQuotasMessage{
+		GUIDs:    parse.ArrayParam(l.GUIDs),
+		Names:    parse.ArrayParam(l.Names),
+		OrgGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *OrgQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "per_page", "page"}
+}
+
+func (l *OrgQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return nil
+}
+
+func relationshipGUIDs(data []RelationshipData) []string {
+	return slices.Collect(it.Map(slices.Values(data), func(d RelationshipData) string { return d.GUID }))
+}
@@ -0,0 +1,225 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("OrgQuotaCreate", func() {
+	var (
+		createPayload  payloads.OrgQuotaCreate
+		orgQuotaCreate *payloads.OrgQuotaCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		orgQuotaCreate = new(payloads.OrgQuotaCreate)
+		createPayload = payloads.OrgQuotaCreate{
+			Name: "my-quota",
+			Apps: payloads.QuotaAppLimits{
+				TotalMemoryInMB:      tools.PtrTo[int64](2048),
+				PerProcessMemoryInMB: tools.PtrTo[int64](1024),
+				TotalInstances:       tools.PtrTo[int64](10),
+				PerAppTasks:          tools.PtrTo[int64](5),
+			},
+			Services: payloads.QuotaServiceLimits{
+				TotalServiceInstances: tools.PtrTo[int64](3),
+			},
+			Routes: payloads.QuotaRouteLimits{
+				TotalRoutes: tools.PtrTo[int64](4),
+			},
+			Relationships: payloads.OrgQuotaRelationships{
+				Organizations: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), orgQuotaCreate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(orgQuotaCreate).To(PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			createPayload.Apps.TotalInstances = tools.PtrTo[int64](-1)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "total_instances must be no less than 0")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		var message repositories.CreateOrgQuotaMessage
+
+		JustBeforeEach(func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			message = orgQuotaCreate.ToMessage()
+		})
+
+		It("converts the payload to a repo message", func() {
+			Expect(message).To(Equal(repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.AppQuotaLimits{
+						TotalMemoryInMB:      tools.PtrTo[int64](2048),
+						PerProcessMemoryInMB: tools.PtrTo[int64](1024),
+						TotalInstances:       tools.PtrTo[int64](10),
+						PerAppTasks:          tools.PtrTo[int64](5),
+					},
+					Services: repositories.ServiceQuotaLimits{
+						TotalServiceInstances: tools.PtrTo[int64](3),
+					},
+					Routes: repositories.RouteQuotaLimits{
+						TotalRoutes: tools.PtrTo[int64](4),
+					},
+				},
+				OrgGUIDs: []string{"org-guid"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaPatch", func() {
+	var (
+		patchPayload  map[string]any
+		orgQuotaPatch *payloads.OrgQuotaPatch
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		orgQuotaPatch = new(payloads.OrgQuotaPatch)
+		patchPayload = map[string]any{
+			"name": "new-name",
+			"apps": map[string]any{
+				"total_memory_in_mb": 4096,
+				"total_instances":    nil,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(patchPayload), orgQuotaPatch)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+	})
+
+	It("distinguishes limits set to null from missing limits", func() {
+		message := orgQuotaPatch.ToMessage("quota-guid")
+		Expect(message.GUID).To(Equal("quota-guid"))
+		Expect(message.Name).To(PointTo(Equal("new-name")))
+		Expect(message.Limits.Apps.TotalMemoryInMB).To(PointTo(Equal(repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](4096)})))
+		Expect(message.Limits.Apps.TotalInstances).To(PointTo(Equal(repositories.QuotaLimitPatch{})))
+		Expect(message.Limits.Apps.PerProcessMemoryInMB).To(BeNil())
+		Expect(message.Limits.Services.TotalServiceInstances).To(BeNil())
+		Expect(message.Limits.Routes.TotalRoutes).To(BeNil())
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			patchPayload["name"] = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			patchPayload["routes"] = map[string]any{"total_routes": -1}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "total_routes must be no less than 0")
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaApply", func() {
+	var (
+		applyPayload  payloads.OrgQuotaApply
+		orgQuotaApply *payloads.OrgQuotaApply
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		orgQuotaApply = new(payloads.OrgQuotaApply)
+		applyPayload = payloads.OrgQuotaApply{
+			Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(applyPayload), orgQuotaApply)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(orgQuotaApply.ToMessage("quota-guid")).To(Equal(repositories.ApplyOrgQuotaMessage{
+			GUID:     "quota-guid",
+			OrgGUIDs: []string{"org-1", "org-2"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			applyPayload.Data = []payloads.RelationshipData{}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedOrgQuotaList payloads.OrgQuotaList) {
+			actualOrgQuotaList, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualOrgQuotaList).To(Equal(expectedOrgQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.OrgQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.OrgQuotaList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.OrgQuotaList{OrganizationGUIDs: "o1,o2"}),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repo message", func() {
+			orgQuotaList := payloads.OrgQuotaList{
+				GUIDs:             "g1,g2",
+				Names:             "n1",
+				OrganizationGUIDs: "o1",
+			}
+			Expect(orgQuotaList.ToMessage()).To(Equal(repositories.ListOrgQuotasMessage{
+				GUIDs:    []string{"g1", "g2"},
+				Names:    []string{"n1"},
+				OrgGUIDs: []string{"o1"},
+			}))
+		})
+	})
+})
@@ -48,6 +48,37 @@ func (c PackageCreate) ToMessage(record repositories.AppRecord) repositories.Cre
 	return message
 }
 
+type PackageCreateQuery struct {
+	SourceGUID string
+}
+
+func (q *PackageCreateQuery) SupportedKeys() []string {
+	return []string{"source_guid"}
+}
+
+func (q *PackageCreateQuery) DecodeFromURLValues(values url.Values) error {
+	q.SourceGUID = values.Get("source_guid")
+	return nil
+}
+
+type PackageCopy struct {
+	Relationships *PackageRelationships `json:"relationships"`
+}
+
+func (c PackageCopy) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c PackageCopy) ToMessage(appRecord repositories.AppRecord) repositories.CreatePackageMessage {
+	return repositories.CreatePackageMessage{
+		Type:      "bits",
+		AppGUID:   appRecord.GUID,
+		SpaceGUID: appRecord.SpaceGUID,
+	}
+}
+
 type PackageData struct {
 	Image    string  `json:"image"`
 	Username *string `json:"username"`
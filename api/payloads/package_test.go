@@ -236,6 +236,74 @@ var _ = Describe("PackageCreate", func() {
 	})
 })
 
+var _ = Describe("PackageCreateQuery", func() {
+	It("decodes the source guid", func() {
+		query, err := decodeQuery[payloads.PackageCreateQuery]("source_guid=src-package-guid")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query.SourceGUID).To(Equal("src-package-guid"))
+	})
+})
+
+var _ = Describe("PackageCopy", func() {
+	var (
+		copyPayload    payloads.PackageCopy
+		decodedPayload *payloads.PackageCopy
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		copyPayload = payloads.PackageCopy{
+			Relationships: &payloads.PackageRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "dest-app-guid",
+					},
+				},
+			},
+		}
+		decodedPayload = new(payloads.PackageCopy)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(copyPayload)))
+	})
+
+	When("relationships is not set", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the app relationship is not set", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships.App = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "app is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a create bits package message", func() {
+			Expect(copyPayload.ToMessage(repositories.AppRecord{GUID: "dest-app-guid", SpaceGUID: "dest-space-guid"})).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   "dest-app-guid",
+				SpaceGUID: "dest-space-guid",
+			}))
+		})
+	})
+})
+
 var _ = Describe("PackageUpdate", func() {
 	var payload payloads.PackageUpdate
 
@@ -0,0 +1,135 @@
+package payloads
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/resourcecache"
+	jellidation "github.com/jellydator/validation"
+)
+
+var (
+	sha1Regex      = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	fileModeRegex  = regexp.MustCompile(`^[0-7]{1,6}$`)
+	errInvalidPath = errors.New("must be a relative path within the package")
+)
+
+type ResourceMatchesCreate struct {
+	Resources []ResourceMatch `json:"resources"`
+}
+
+func (c ResourceMatchesCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Resources),
+	)
+}
+
+func (c ResourceMatchesCreate) ToResources() []resourcecache.Resource {
+	return toResources(c.Resources)
+}
+
+type ResourceMatch struct {
+	Checksum    ResourceMatchChecksum `json:"checksum"`
+	SizeInBytes int64                 `json:"size_in_bytes"`
+	Path        string                `json:"path"`
+	Mode        string                `json:"mode"`
+}
+
+type ResourceMatchChecksum struct {
+	Value string `json:"value"`
+}
+
+func (m ResourceMatch) Validate() error {
+	return jellidation.ValidateStruct(&m,
+		jellidation.Field(&m.Checksum),
+		jellidation.Field(&m.SizeInBytes, jellidation.Min(int64(0)).Error("must be greater than or equal to 0")),
+		jellidation.Field(&m.Path, jellidation.By(validateResourcePath)),
+		jellidation.Field(&m.Mode, jellidation.Match(fileModeRegex).Error("must be an octal file mode")),
+	)
+}
+
+func (c ResourceMatchChecksum) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Value, jellidation.Required, jellidation.Match(sha1Regex).Error("must be a sha1 checksum")),
+	)
+}
+
+func (m ResourceMatch) ToResource() resourcecache.Resource {
+	var mode fs.FileMode
+	if m.Mode != "" {
+		parsedMode, _ := strconv.ParseUint(m.Mode, 8, 32)
+		mode = fs.FileMode(parsedMode)
+	}
+
+	return resourcecache.Resource{
+		SHA1: m.Checksum.Value,
+		Size: m.SizeInBytes,
+		Path: m.Path,
+		Mode: mode,
+	}
+}
+
+func validateResourcePath(value any) error {
+	resourcePath, ok := value.(string)
+	if !ok || resourcePath == "" {
+		return nil
+	}
+
+	cleanPath := path.Clean(resourcePath)
+	if path.IsAbs(cleanPath) || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		return errInvalidPath
+	}
+
+	return nil
+}
+
+type PackageUpload struct {
+	Resources []ResourceMatch
+}
+
+func (u *PackageUpload) SupportedKeys() []string {
+	return []string{"resources"}
+}
+
+func (u *PackageUpload) DecodeFromURLValues(values url.Values) error {
+	resources := values.Get("resources")
+	if resources == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(resources), &u.Resources)
+}
+
+func (u PackageUpload) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Resources, jellidation.Each(jellidation.By(func(value any) error {
+			resource, ok := value.(ResourceMatch)
+			if !ok {
+				return nil
+			}
+
+			return jellidation.ValidateStruct(&resource,
+				jellidation.Field(&resource.Path, jellidation.Required),
+			)
+		}))),
+	)
+}
+
+func (u PackageUpload) ToResources() []resourcecache.Resource {
+	return toResources(u.Resources)
+}
+
+func toResources(matches []ResourceMatch) []resourcecache.Resource {
+	resources := make([]resourcecache.Resource, 0, len(matches))
+	for _, m := range matches {
+		resources = append(resources, m.ToResource())
+	}
+
+	return resources
+}
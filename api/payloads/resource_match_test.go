@@ -0,0 +1,162 @@
+package payloads_test
+
+import (
+	"net/url"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/resourcecache"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+const resourceSHA1 = "8b4f1a0bde3e4ed2e57ce3b1d6b6f6e9b2d2a1f0"
+
+var _ = Describe("ResourceMatchesCreate", func() {
+	var (
+		payload        payloads.ResourceMatchesCreate
+		decodedPayload *payloads.ResourceMatchesCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.ResourceMatchesCreate{
+			Resources: []payloads.ResourceMatch{{
+				Checksum:    payloads.ResourceMatchChecksum{Value: resourceSHA1},
+				SizeInBytes: 42,
+				Path:        "lib/app.js",
+				Mode:        "644",
+			}},
+		}
+
+		decodedPayload = new(payloads.ResourceMatchesCreate)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("the checksum is not a sha1", func() {
+		BeforeEach(func() {
+			payload.Resources[0].Checksum.Value = "not-a-sha"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be a sha1 checksum")
+		})
+	})
+
+	When("the size is negative", func() {
+		BeforeEach(func() {
+			payload.Resources[0].SizeInBytes = -1
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "size_in_bytes must be greater than or equal to 0")
+		})
+	})
+
+	When("the mode is not octal", func() {
+		BeforeEach(func() {
+			payload.Resources[0].Mode = "rwxr-xr-x"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "mode must be an octal file mode")
+		})
+	})
+
+	When("the path escapes the package", func() {
+		BeforeEach(func() {
+			payload.Resources[0].Path = "../../etc/passwd"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "path must be a relative path within the package")
+		})
+	})
+
+	Describe("ToResources", func() {
+		It("converts to cache resources", func() {
+			Expect(payload.ToResources()).To(Equal([]resourcecache.Resource{{
+				SHA1: resourceSHA1,
+				Size: 42,
+				Path: "lib/app.js",
+				Mode: 0o644,
+			}}))
+		})
+	})
+})
+
+var _ = Describe("PackageUpload", func() {
+	var (
+		resources string
+		payload   *payloads.PackageUpload
+		decodeErr error
+	)
+
+	BeforeEach(func() {
+		resources = `[{"checksum":{"value":"` + resourceSHA1 + `"},"size_in_bytes":42,"path":"lib/app.js","mode":"755"}]`
+	})
+
+	JustBeforeEach(func() {
+		payload, decodeErr = decodeQuery[payloads.PackageUpload]("resources=" + url.QueryEscape(resources))
+	})
+
+	It("decodes the resources", func() {
+		Expect(decodeErr).NotTo(HaveOccurred())
+		Expect(payload.ToResources()).To(Equal([]resourcecache.Resource{{
+			SHA1: resourceSHA1,
+			Size: 42,
+			Path: "lib/app.js",
+			Mode: 0o755,
+		}}))
+	})
+
+	When("there are no resources", func() {
+		BeforeEach(func() {
+			resources = ""
+		})
+
+		It("succeeds", func() {
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(payload.ToResources()).To(BeEmpty())
+		})
+	})
+
+	When("the resources are not valid json", func() {
+		BeforeEach(func() {
+			resources = "not-json"
+		})
+
+		It("returns a message parse error", func() {
+			Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.MessageParseError{}))
+		})
+	})
+
+	When("a resource has no path", func() {
+		BeforeEach(func() {
+			resources = `[{"checksum":{"value":"` + resourceSHA1 + `"},"size_in_bytes":42}]`
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(decodeErr, "path cannot be blank")
+		})
+	})
+
+	When("a resource is invalid", func() {
+		BeforeEach(func() {
+			resources = `[{"checksum":{"value":"foo"},"size_in_bytes":42,"path":"lib/app.js"}]`
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(decodeErr, "must be a sha1 checksum")
+		})
+	})
+})
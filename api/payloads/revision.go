@@ -0,0 +1,50 @@
+package payloads
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type RevisionList struct {
+	Versions []int64
+	OrderBy  string
+}
+
+func (l RevisionList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "version")),
+	)
+}
+
+func (l *RevisionList) ToMessage(appGUID string) repositories.ListRevisionsMessage {
+	return repositories.ListRevisionsMessage{
+		AppGUIDs: []string{appGUID},
+		Versions: l.Versions,
+		OrderBy:  l.OrderBy,
+	}
+}
+
+func (l *RevisionList) SupportedKeys() []string {
+	return []string{"versions", "order_by", "per_page", "page"}
+}
+
+func (l *RevisionList) DecodeFromURLValues(values url.Values) error {
+	l.OrderBy = values.Get("order_by")
+
+	l.Versions = nil
+	for _, versionStr := range parse.ArrayParam(values.Get("versions")) {
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse 'versions' query parameter: %w", err)
+		}
+		l.Versions = append(l.Versions, version)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RevisionList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedRevisionList payloads.RevisionList) {
+				actualRevisionList, decodeErr := decodeQuery[payloads.RevisionList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualRevisionList).To(Equal(expectedRevisionList))
+			},
+			Entry("versions", "versions=1,2", payloads.RevisionList{Versions: []int64{1, 2}}),
+			Entry("order_by created_at", "order_by=created_at", payloads.RevisionList{OrderBy: "created_at"}),
+			Entry("order_by -updated_at", "order_by=-updated_at", payloads.RevisionList{OrderBy: "-updated_at"}),
+			Entry("order_by version", "order_by=version", payloads.RevisionList{OrderBy: "version"}),
+			Entry("page", "page=3", payloads.RevisionList{}),
+			Entry("per_page", "per_page=10", payloads.RevisionList{}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.RevisionList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("invalid versions", "versions=one", "failed to parse 'versions' query parameter"),
+			Entry("unsupported parameter", "foo=bar", "unsupported query parameter"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			revisionList := payloads.RevisionList{
+				Versions: []int64{1, 2},
+				OrderBy:  "-version",
+			}
+			Expect(revisionList.ToMessage("app-guid")).To(Equal(repositories.ListRevisionsMessage{
+				AppGUIDs: []string{"app-guid"},
+				Versions: []int64{1, 2},
+				OrderBy:  "-version",
+			}))
+		})
+	})
+})
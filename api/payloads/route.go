@@ -13,26 +13,30 @@ import (
 type RouteCreate struct {
 	Host          string              `json:"host"`
 	Path          string              `json:"path"`
+	Port          *int32              `json:"port"`
 	Relationships *RouteRelationships `json:"relationships"`
 	Metadata      Metadata            `json:"metadata"`
 }
 
 func (p RouteCreate) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Host, jellidation.Required),
+		jellidation.Field(&p.Host, jellidation.When(p.Port == nil, jellidation.Required)),
+		jellidation.Field(&p.Port, jellidation.Min(1), jellidation.Max(65535), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
 		jellidation.Field(&p.Relationships, jellidation.NotNil),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
-func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.CreateRouteMessage {
+func (p RouteCreate) ToMessage(domain repositories.DomainRecord) repositories.CreateRouteMessage {
 	return repositories.CreateRouteMessage{
 		Host:            p.Host,
 		Path:            p.Path,
+		Port:            p.Port,
 		SpaceGUID:       p.Relationships.Space.Data.GUID,
 		DomainGUID:      p.Relationships.Domain.Data.GUID,
-		DomainNamespace: domainNamespace,
-		DomainName:      domainName,
+		DomainNamespace: domain.Namespace,
+		DomainName:      domain.Name,
+		RouterGroup:     domain.RouterGroup,
 		Labels:          p.Metadata.Labels,
 		Annotations:     p.Metadata.Annotations,
 	}
@@ -116,12 +120,14 @@ type RouteDestination struct {
 	App      AppResource `json:"app"`
 	Port     *int32      `json:"port"`
 	Protocol *string     `json:"protocol"`
+	Weight   *int32      `json:"weight"`
 }
 
 func (r RouteDestination) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.App),
-		jellidation.Field(&r.Protocol, validation.OneOf("http1")),
+		jellidation.Field(&r.Protocol, validation.OneOf("http1", "tcp")),
+		jellidation.Field(&r.Weight, jellidation.Min(1), jellidation.Max(100), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
 	)
 }
 
@@ -160,6 +166,7 @@ func (dc RouteDestinationCreate) ToMessage(routeRecord repositories.RouteRecord)
 			ProcessType: processType,
 			Port:        destination.Port,
 			Protocol:    destination.Protocol,
+			Weight:      destination.Weight,
 		})
 	}
 	return repositories.AddDestinationsMessage{
@@ -106,6 +106,30 @@ var _ = Describe("RouteCreate", func() {
 		})
 	})
 
+	When("the route has a port", func() {
+		BeforeEach(func() {
+			createPayload.Host = ""
+			createPayload.Path = ""
+			createPayload.Port = tools.PtrTo[int32](1024)
+		})
+
+		It("does not require a host", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(routeCreate.Port).To(gstruct.PointTo(BeEquivalentTo(1024)))
+		})
+
+		When("the port is not valid", func() {
+			BeforeEach(func() {
+				createPayload.Port = tools.PtrTo[int32](0)
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("port must be no less than 1"))
+			})
+		})
+	})
+
 	When("relationships is empty", func() {
 		BeforeEach(func() {
 			createPayload.Relationships = nil
@@ -264,4 +288,26 @@ var _ = Describe("Add destination", func() {
 			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1"))
 		})
 	})
+
+	When("weight is less than 1", func() {
+		BeforeEach(func() {
+			addPayload.Destinations[1].Weight = tools.PtrTo[int32](0)
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("must be no less than 1"))
+		})
+	})
+
+	When("weight is greater than 100", func() {
+		BeforeEach(func() {
+			addPayload.Destinations[1].Weight = tools.PtrTo[int32](101)
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("must be no greater than 100"))
+		})
+	})
 })
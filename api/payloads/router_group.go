@@ -0,0 +1,27 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type RouterGroupList struct {
+	Names string
+}
+
+func (l *RouterGroupList) ToMessage() repositories.ListRouterGroupsMessage {
+	return repositories.ListRouterGroupsMessage{
+		Names: parse.ArrayParam(l.Names),
+	}
+}
+
+func (l *RouterGroupList) SupportedKeys() []string {
+	return []string{"names", "per_page", "page"}
+}
+
+func (l *RouterGroupList) DecodeFromURLValues(values url.Values) error {
+	l.Names = values.Get("names")
+	return nil
+}
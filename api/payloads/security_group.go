@@ -1,8 +1,11 @@
 package payloads
 
 import (
+	"fmt"
+	"net/url"
 	"slices"
 
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/BooleanCat/go-functional/v2/it"
 	jellidation "github.com/jellydator/validation"
@@ -43,18 +46,6 @@ func (c SecurityGroupCreate) Validate() error {
 }
 
 func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage {
-	rules := slices.Collect(it.Map(slices.Values(c.Rules), func(r SecurityGroupRule) repositories.SecurityGroupRule {
-		return repositories.SecurityGroupRule{
-			Protocol:    r.Protocol,
-			Destination: r.Destination,
-			Ports:       r.Ports,
-			Type:        r.Type,
-			Code:        r.Code,
-			Description: r.Description,
-			Log:         r.Log,
-		}
-	}))
-
 	spaces := make(map[string]repositories.SecurityGroupWorkloads)
 	runningSpaces := slices.Collect(it.Map(slices.Values(c.Relationships.RunningSpaces.Data), func(d RelationshipData) string { return d.GUID }))
 	stagingSpaces := slices.Collect(it.Map(slices.Values(c.Relationships.StagingSpaces.Data), func(d RelationshipData) string { return d.GUID }))
@@ -73,7 +64,7 @@ func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage
 
 	return repositories.CreateSecurityGroupMessage{
 		DisplayName: c.DisplayName,
-		Rules:       rules,
+		Rules:       toRepoSecurityGroupRules(c.Rules),
 		GloballyEnabled: repositories.SecurityGroupWorkloads{
 			Running: c.GloballyEnabled.Running,
 			Staging: c.GloballyEnabled.Staging,
@@ -81,3 +72,126 @@ func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage
 		Spaces: spaces,
 	}
 }
+
+type SecurityGroupWorkloadsUpdate struct {
+	Running *bool `json:"running"`
+	Staging *bool `json:"staging"`
+}
+
+type SecurityGroupUpdate struct {
+	DisplayName     *string                      `json:"name"`
+	Rules           []SecurityGroupRule          `json:"rules"`
+	GloballyEnabled SecurityGroupWorkloadsUpdate `json:"globally_enabled"`
+}
+
+func (u SecurityGroupUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.DisplayName, jellidation.NilOrNotEmpty),
+	)
+}
+
+func (u SecurityGroupUpdate) ToMessage(guid string) repositories.UpdateSecurityGroupMessage {
+	message := repositories.UpdateSecurityGroupMessage{
+		GUID:        guid,
+		DisplayName: u.DisplayName,
+		GloballyEnabled: repositories.UpdateSecurityGroupWorkloads{
+			Running: u.GloballyEnabled.Running,
+			Staging: u.GloballyEnabled.Staging,
+		},
+	}
+
+	if u.Rules != nil {
+		message.Rules = toRepoSecurityGroupRules(u.Rules)
+	}
+
+	return message
+}
+
+type SecurityGroupBind struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (b SecurityGroupBind) Validate() error {
+	return jellidation.ValidateStruct(&b,
+		jellidation.Field(&b.Data, jellidation.Required),
+	)
+}
+
+func (b SecurityGroupBind) SpaceGUIDs() []string {
+	return slices.Collect(it.Map(slices.Values(b.Data), func(d RelationshipData) string { return d.GUID }))
+}
+
+func (b SecurityGroupBind) ToMessage(guid, workloadType string) repositories.BindSecurityGroupMessage {
+	return repositories.BindSecurityGroupMessage{
+		GUID:         guid,
+		SpaceGUIDs:   b.SpaceGUIDs(),
+		WorkloadType: workloadType,
+	}
+}
+
+type SecurityGroupList struct {
+	GUIDs                  string
+	Names                  string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      string
+	StagingSpaceGUIDs      string
+}
+
+func (l *SecurityGroupList) ToMessage() repositories.ListSecurityGroupMessage {
+	return repositories.ListSecurityGroupMessage{
+		GUIDs:                  parse.ArrayParam(l.GUIDs),
+		Names:                  parse.ArrayParam(l.Names),
+		GloballyEnabledRunning: l.GloballyEnabledRunning,
+		GloballyEnabledStaging: l.GloballyEnabledStaging,
+		RunningSpaceGUIDs:      parse.ArrayParam(l.RunningSpaceGUIDs),
+		StagingSpaceGUIDs:      parse.ArrayParam(l.StagingSpaceGUIDs),
+	}
+}
+
+func (l *SecurityGroupList) SupportedKeys() []string {
+	return []string{
+		"guids",
+		"names",
+		"globally_enabled_running",
+		"globally_enabled_staging",
+		"running_space_guids",
+		"staging_space_guids",
+		"per_page",
+		"page",
+	}
+}
+
+func (l *SecurityGroupList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.RunningSpaceGUIDs = values.Get("running_space_guids")
+	l.StagingSpaceGUIDs = values.Get("staging_space_guids")
+
+	var err error
+	l.GloballyEnabledRunning, err = parseBool(values.Get("globally_enabled_running"))
+	if err != nil {
+		return fmt.Errorf("failed to parse 'globally_enabled_running' query parameter: %w", err)
+	}
+
+	l.GloballyEnabledStaging, err = parseBool(values.Get("globally_enabled_staging"))
+	if err != nil {
+		return fmt.Errorf("failed to parse 'globally_enabled_staging' query parameter: %w", err)
+	}
+
+	return nil
+}
+
+func toRepoSecurityGroupRules(rules []SecurityGroupRule) []repositories.SecurityGroupRule {
+	return slices.Collect(it.Map(slices.Values(rules), func(r SecurityGroupRule) repositories.SecurityGroupRule {
+		return repositories.SecurityGroupRule{
+			Protocol:    r.Protocol,
+			Destination: r.Destination,
+			Ports:       r.Ports,
+			Type:        r.Type,
+			Code:        r.Code,
+			Description: r.Description,
+			Log:         r.Log,
+		}
+	}))
+}
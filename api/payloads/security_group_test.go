@@ -4,6 +4,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
@@ -92,3 +93,166 @@ var _ = Describe("SecurityGroupCreate", func() {
 		})
 	})
 })
+
+var _ = Describe("SecurityGroupUpdate", func() {
+	var (
+		updatePayload       payloads.SecurityGroupUpdate
+		securityGroupUpdate *payloads.SecurityGroupUpdate
+		validatorErr        error
+	)
+
+	BeforeEach(func() {
+		securityGroupUpdate = new(payloads.SecurityGroupUpdate)
+		updatePayload = payloads.SecurityGroupUpdate{
+			DisplayName: tools.PtrTo("new-name"),
+			Rules: []payloads.SecurityGroupRule{{
+				Protocol:    korifiv1alpha1.ProtocolUDP,
+				Ports:       "53",
+				Destination: "10.0.0.0/8",
+			}},
+			GloballyEnabled: payloads.SecurityGroupWorkloadsUpdate{
+				Running: tools.PtrTo(true),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), securityGroupUpdate)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(securityGroupUpdate).To(PointTo(Equal(updatePayload)))
+	})
+
+	When("the display name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.DisplayName = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repo message", func() {
+			Expect(updatePayload.ToMessage("sg-guid")).To(Equal(repositories.UpdateSecurityGroupMessage{
+				GUID:        "sg-guid",
+				DisplayName: tools.PtrTo("new-name"),
+				Rules: []repositories.SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolUDP,
+					Ports:       "53",
+					Destination: "10.0.0.0/8",
+				}},
+				GloballyEnabled: repositories.UpdateSecurityGroupWorkloads{
+					Running: tools.PtrTo(true),
+				},
+			}))
+		})
+
+		When("the rules are not set", func() {
+			BeforeEach(func() {
+				updatePayload.Rules = nil
+			})
+
+			It("leaves the rules unchanged", func() {
+				Expect(updatePayload.ToMessage("sg-guid").Rules).To(BeNil())
+			})
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupBind", func() {
+	var (
+		bindPayload       payloads.SecurityGroupBind
+		securityGroupBind *payloads.SecurityGroupBind
+		validatorErr      error
+	)
+
+	BeforeEach(func() {
+		securityGroupBind = new(payloads.SecurityGroupBind)
+		bindPayload = payloads.SecurityGroupBind{
+			Data: []payloads.RelationshipData{{GUID: "space1"}, {GUID: "space2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(bindPayload), securityGroupBind)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(securityGroupBind).To(PointTo(Equal(bindPayload)))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			bindPayload.Data = []payloads.RelationshipData{}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repo message", func() {
+			Expect(bindPayload.ToMessage("sg-guid", repositories.SecurityGroupRunningSpaces)).To(Equal(repositories.BindSecurityGroupMessage{
+				GUID:         "sg-guid",
+				SpaceGUIDs:   []string{"space1", "space2"},
+				WorkloadType: repositories.SecurityGroupRunningSpaces,
+			}))
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedSecurityGroupList payloads.SecurityGroupList) {
+				actualSecurityGroupList, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualSecurityGroupList).To(Equal(expectedSecurityGroupList))
+			},
+
+			Entry("guids", "guids=g1,g2", payloads.SecurityGroupList{GUIDs: "g1,g2"}),
+			Entry("names", "names=n1,n2", payloads.SecurityGroupList{Names: "n1,n2"}),
+			Entry("globally_enabled_running", "globally_enabled_running=true", payloads.SecurityGroupList{GloballyEnabledRunning: tools.PtrTo(true)}),
+			Entry("globally_enabled_staging", "globally_enabled_staging=false", payloads.SecurityGroupList{GloballyEnabledStaging: tools.PtrTo(false)}),
+			Entry("running_space_guids", "running_space_guids=s1,s2", payloads.SecurityGroupList{RunningSpaceGUIDs: "s1,s2"}),
+			Entry("staging_space_guids", "staging_space_guids=s1,s2", payloads.SecurityGroupList{StagingSpaceGUIDs: "s1,s2"}),
+			Entry("per_page", "per_page=few", payloads.SecurityGroupList{}),
+			Entry("page", "page=3", payloads.SecurityGroupList{}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid globally_enabled_running", "globally_enabled_running=maybe", "failed to parse 'globally_enabled_running' query parameter"),
+			Entry("invalid globally_enabled_staging", "globally_enabled_staging=maybe", "failed to parse 'globally_enabled_staging' query parameter"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("splits the comma separated values", func() {
+			securityGroupList := payloads.SecurityGroupList{
+				GUIDs:                  "g1,g2",
+				Names:                  "n1,n2",
+				GloballyEnabledRunning: tools.PtrTo(true),
+				RunningSpaceGUIDs:      "s1,s2",
+				StagingSpaceGUIDs:      "s3",
+			}
+			Expect(securityGroupList.ToMessage()).To(Equal(repositories.ListSecurityGroupMessage{
+				GUIDs:                  []string{"g1", "g2"},
+				Names:                  []string{"n1", "n2"},
+				GloballyEnabledRunning: tools.PtrTo(true),
+				RunningSpaceGUIDs:      []string{"s1", "s2"},
+				StagingSpaceGUIDs:      []string{"s3"},
+			}))
+		})
+	})
+})
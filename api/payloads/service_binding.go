@@ -129,3 +129,20 @@ func (c *ServiceBindingUpdate) ToMessage(serviceBindingGUID string) repositories
 		},
 	}
 }
+
+type ServiceBindingRotate struct {
+	AppMode string `json:"app_mode"`
+}
+
+func (r ServiceBindingRotate) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.AppMode, validation.OneOf("none", "restart")),
+	)
+}
+
+func (r ServiceBindingRotate) ToMessage(serviceBindingGUID string) repositories.RotateServiceBindingCredentialsMessage {
+	return repositories.RotateServiceBindingCredentialsMessage{
+		GUID:    serviceBindingGUID,
+		AppMode: r.AppMode,
+	}
+}
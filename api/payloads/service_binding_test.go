@@ -269,3 +269,56 @@ var _ = Describe("ServiceBindingUpdate", func() {
 		})
 	})
 })
+
+var _ = Describe("ServiceBindingRotate", func() {
+	var (
+		rotatePayload  payloads.ServiceBindingRotate
+		serviceBinding *payloads.ServiceBindingRotate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		serviceBinding = new(payloads.ServiceBindingRotate)
+		rotatePayload = payloads.ServiceBindingRotate{
+			AppMode: "restart",
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(rotatePayload), serviceBinding)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(serviceBinding).To(PointTo(Equal(rotatePayload)))
+	})
+
+	When("the app mode is not set", func() {
+		BeforeEach(func() {
+			rotatePayload.AppMode = ""
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the app mode is invalid", func() {
+		BeforeEach(func() {
+			rotatePayload.AppMode = "restage"
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "app_mode value must be one of: none, restart")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to repo message correctly", func() {
+			Expect(rotatePayload.ToMessage("binding-guid")).To(Equal(repositories.RotateServiceBindingCredentialsMessage{
+				GUID:    "binding-guid",
+				AppMode: "restart",
+			}))
+		})
+	})
+})
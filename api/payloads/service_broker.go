@@ -33,12 +33,23 @@ func (c BrokerCredentials) Validate() error {
 	)
 }
 
+type ServiceBrokerRelationships struct {
+	Space *Relationship `json:"space"`
+}
+
+func (r ServiceBrokerRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Space, jellidation.NotNil),
+	)
+}
+
 type ServiceBrokerCreate struct {
-	Name           string                `json:"name"`
-	URL            string                `json:"url"`
-	Labels         map[string]string     `json:"labels,omitempty"`
-	Annotations    map[string]string     `json:"annotations,omitempty"`
-	Authentication *BrokerAuthentication `json:"authentication"`
+	Name           string                      `json:"name"`
+	URL            string                      `json:"url"`
+	Labels         map[string]string           `json:"labels,omitempty"`
+	Annotations    map[string]string           `json:"annotations,omitempty"`
+	Authentication *BrokerAuthentication       `json:"authentication"`
+	Relationships  *ServiceBrokerRelationships `json:"relationships,omitempty"`
 }
 
 func (c ServiceBrokerCreate) Validate() error {
@@ -46,11 +57,12 @@ func (c ServiceBrokerCreate) Validate() error {
 		jellidation.Field(&c.Name, jellidation.Required),
 		jellidation.Field(&c.URL, jellidation.Required),
 		jellidation.Field(&c.Authentication, jellidation.Required),
+		jellidation.Field(&c.Relationships),
 	)
 }
 
 func (c ServiceBrokerCreate) ToMessage() repositories.CreateServiceBrokerMessage {
-	return repositories.CreateServiceBrokerMessage{
+	message := repositories.CreateServiceBrokerMessage{
 		Name: c.Name,
 		URL:  c.URL,
 		Metadata: repositories.Metadata{
@@ -62,24 +74,33 @@ func (c ServiceBrokerCreate) ToMessage() repositories.CreateServiceBrokerMessage
 			Password: c.Authentication.Credentials.Password,
 		},
 	}
+
+	if c.Relationships != nil {
+		message.SpaceGUID = c.Relationships.Space.Data.GUID
+	}
+
+	return message
 }
 
 type ServiceBrokerList struct {
-	Names string
+	Names      string
+	SpaceGUIDs string
 }
 
 func (b *ServiceBrokerList) DecodeFromURLValues(values url.Values) error {
 	b.Names = values.Get("names")
+	b.SpaceGUIDs = values.Get("space_guids")
 	return nil
 }
 
 func (b *ServiceBrokerList) SupportedKeys() []string {
-	return []string{"names", "page", "per_page"}
+	return []string{"names", "space_guids", "page", "per_page"}
 }
 
 func (b *ServiceBrokerList) ToMessage() repositories.ListServiceBrokerMessage {
 	return repositories.ListServiceBrokerMessage{
-		Names: parse.ArrayParam(b.Names),
+		Names:      parse.ArrayParam(b.Names),
+		SpaceGUIDs: parse.ArrayParam(b.SpaceGUIDs),
 	}
 }
 
@@ -88,6 +88,49 @@ var _ = Describe("ServiceBrokerCreate", func() {
 		})
 	})
 
+	When("the space relationship is set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = &payloads.ServiceBrokerRelationships{
+				Space: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "space-guid"},
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceBrokerCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		It("sets the space guid in the message", func() {
+			Expect(serviceBrokerCreate.ToMessage().SpaceGUID).To(Equal("space-guid"))
+		})
+	})
+
+	When("the relationships do not contain a space", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = &payloads.ServiceBrokerRelationships{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships.space is required")
+		})
+	})
+
+	When("the space relationship guid is empty", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = &payloads.ServiceBrokerRelationships{
+				Space: &payloads.Relationship{
+					Data: &payloads.RelationshipData{},
+				},
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
 	Describe("ToMessage()", func() {
 		It("converts to repo message correctly", func() {
 			msg := serviceBrokerCreate.ToMessage()
@@ -118,19 +161,25 @@ var _ = Describe("ServiceBrokerList", func() {
 
 	Describe("decodes from url values", func() {
 		It("succeeds", func() {
-			req, err := http.NewRequest("GET", "http://foo.com/bar?names=foo,bar", nil)
+			req, err := http.NewRequest("GET", "http://foo.com/bar?names=foo,bar&space_guids=s1,s2", nil)
 			Expect(err).NotTo(HaveOccurred())
 			err = validator.DecodeAndValidateURLValues(req, &serviceBrokerList)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(serviceBrokerList.Names).To(Equal("foo,bar"))
+			Expect(serviceBrokerList.SpaceGUIDs).To(Equal("s1,s2"))
 		})
 	})
 
 	Describe("ToMessage", func() {
+		BeforeEach(func() {
+			serviceBrokerList.SpaceGUIDs = "s1, s2"
+		})
+
 		It("converts to repo message correctly", func() {
 			Expect(serviceBrokerList.ToMessage()).To(Equal(repositories.ListServiceBrokerMessage{
-				Names: []string{"b1", "b2"},
+				Names:      []string{"b1", "b2"},
+				SpaceGUIDs: []string{"s1", "s2"},
 			}))
 		})
 	})
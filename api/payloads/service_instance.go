@@ -16,13 +16,14 @@ import (
 )
 
 type ServiceInstanceCreate struct {
-	Name          string                        `json:"name"`
-	Type          string                        `json:"type"`
-	Tags          []string                      `json:"tags"`
-	Credentials   map[string]any                `json:"credentials"`
-	Parameters    map[string]any                `json:"parameters"`
-	Relationships *ServiceInstanceRelationships `json:"relationships"`
-	Metadata      Metadata                      `json:"metadata"`
+	Name            string                        `json:"name"`
+	Type            string                        `json:"type"`
+	Tags            []string                      `json:"tags"`
+	Credentials     map[string]any                `json:"credentials"`
+	Parameters      map[string]any                `json:"parameters"`
+	RouteServiceURL *string                       `json:"route_service_url"`
+	Relationships   *ServiceInstanceRelationships `json:"relationships"`
+	Metadata        Metadata                      `json:"metadata"`
 }
 
 const maxTagsLength = 2048
@@ -44,11 +45,20 @@ func validateTagLength(tags any) error {
 	return nil
 }
 
+var routeServiceURLRule = jellidation.NewStringRule(func(value string) bool {
+	u, err := url.ParseRequestURI(value)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}, "must be a valid https URL")
+
 func (c ServiceInstanceCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
 		jellidation.Field(&c.Name, jellidation.Required),
 		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf("user-provided", "managed")),
 		jellidation.Field(&c.Tags, jellidation.By(validateTagLength)),
+		jellidation.Field(&c.RouteServiceURL,
+			jellidation.When(c.Type == "managed", jellidation.Nil.Error("is only supported for user-provided service instances")),
+			routeServiceURLRule,
+		),
 		jellidation.Field(&c.Relationships, jellidation.NotNil, jellidation.By(func(r any) error {
 			rel := r.(*ServiceInstanceRelationships)
 			if c.Type == "user-provided" {
@@ -63,12 +73,13 @@ func (c ServiceInstanceCreate) Validate() error {
 
 func (p ServiceInstanceCreate) ToUPSICreateMessage() repositories.CreateUPSIMessage {
 	return repositories.CreateUPSIMessage{
-		Name:        p.Name,
-		SpaceGUID:   p.Relationships.Space.Data.GUID,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
-		Labels:      p.Metadata.Labels,
-		Annotations: p.Metadata.Annotations,
+		Name:            p.Name,
+		SpaceGUID:       p.Relationships.Space.Data.GUID,
+		Credentials:     p.Credentials,
+		RouteServiceURL: p.RouteServiceURL,
+		Tags:            p.Tags,
+		Labels:          p.Metadata.Labels,
+		Annotations:     p.Metadata.Annotations,
 	}
 }
 
@@ -172,30 +183,69 @@ func (g *ServiceInstanceGet) DecodeFromURLValues(values url.Values) error {
 }
 
 type ServiceInstancePatch struct {
-	Name        *string         `json:"name,omitempty"`
-	Tags        *[]string       `json:"tags,omitempty"`
-	Credentials *map[string]any `json:"credentials,omitempty"`
-	Metadata    MetadataPatch   `json:"metadata"`
+	Name            *string                            `json:"name,omitempty"`
+	Tags            *[]string                          `json:"tags,omitempty"`
+	Credentials     *map[string]any                    `json:"credentials,omitempty"`
+	RouteServiceURL *string                            `json:"route_service_url,omitempty"`
+	Parameters      *map[string]any                    `json:"parameters,omitempty"`
+	MaintenanceInfo *ServiceInstanceMaintenanceInfo    `json:"maintenance_info,omitempty"`
+	Relationships   *ServiceInstancePatchRelationships `json:"relationships,omitempty"`
+	Metadata        MetadataPatch                      `json:"metadata"`
+}
+
+type ServiceInstanceMaintenanceInfo struct {
+	Version string `json:"version"`
+}
+
+func (m ServiceInstanceMaintenanceInfo) Validate() error {
+	return jellidation.ValidateStruct(&m,
+		jellidation.Field(&m.Version, jellidation.Required),
+	)
+}
+
+type ServiceInstancePatchRelationships struct {
+	ServicePlan *Relationship `json:"service_plan"`
+}
+
+func (r ServiceInstancePatchRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.ServicePlan, jellidation.NotNil),
+	)
 }
 
 func (p ServiceInstancePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.RouteServiceURL, routeServiceURLRule),
+		jellidation.Field(&p.MaintenanceInfo),
+		jellidation.Field(&p.Relationships),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
 func (p ServiceInstancePatch) ToServiceInstancePatchMessage(spaceGUID, appGUID string) repositories.PatchServiceInstanceMessage {
-	return repositories.PatchServiceInstanceMessage{
-		SpaceGUID:   spaceGUID,
-		GUID:        appGUID,
-		Name:        p.Name,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
+	message := repositories.PatchServiceInstanceMessage{
+		SpaceGUID:       spaceGUID,
+		GUID:            appGUID,
+		Name:            p.Name,
+		Credentials:     p.Credentials,
+		RouteServiceURL: p.RouteServiceURL,
+		Parameters:      p.Parameters,
+		Tags:            p.Tags,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      p.Metadata.Labels,
 			Annotations: p.Metadata.Annotations,
 		},
 	}
+
+	if p.Relationships != nil {
+		message.PlanGUID = &p.Relationships.ServicePlan.Data.GUID
+	}
+
+	if p.MaintenanceInfo != nil {
+		message.MaintenanceInfoVersion = &p.MaintenanceInfo.Version
+	}
+
+	return message
 }
 
 func (p *ServiceInstancePatch) UnmarshalJSON(data []byte) error {
@@ -363,3 +413,24 @@ func (d *ServiceInstanceDelete) DecodeFromURLValues(values url.Values) error {
 
 	return nil
 }
+
+type ServiceInstanceShare struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (s ServiceInstanceShare) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.Data, jellidation.Required),
+	)
+}
+
+func (s ServiceInstanceShare) SpaceGUIDs() []string {
+	return relationshipGUIDs(s.Data)
+}
+
+func (s ServiceInstanceShare) ToMessage(guid string) repositories.ShareServiceInstanceMessage {
+	return repositories.ShareServiceInstanceMessage{
+		GUID:       guid,
+		SpaceGUIDs: s.SpaceGUIDs(),
+	}
+}
@@ -283,6 +283,42 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			})
 		})
 
+		When("the route service url is set", func() {
+			BeforeEach(func() {
+				createPayload.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+			})
+
+			When("the route service url is not https", func() {
+				BeforeEach(func() {
+					createPayload.RouteServiceURL = tools.PtrTo("http://route-service.example.com")
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "route_service_url must be a valid https URL")
+				})
+			})
+
+			When("the instance type is managed", func() {
+				BeforeEach(func() {
+					createPayload.Type = "managed"
+					createPayload.Relationships.ServicePlan = &payloads.Relationship{
+						Data: &payloads.RelationshipData{
+							GUID: "plan_guid",
+						},
+					}
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "route_service_url is only supported for user-provided service instances")
+				})
+			})
+		})
+
 		When("the instance type is managed", func() {
 			BeforeEach(func() {
 				createPayload.Type = "managed"
@@ -326,6 +362,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 						"a": "b",
 					},
 				},
+				RouteServiceURL: tools.PtrTo("https://route-service.example.com"),
 				Relationships: &payloads.ServiceInstanceRelationships{
 					Space: &payloads.Relationship{
 						Data: &payloads.RelationshipData{
@@ -347,6 +384,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 		It("converts to repo message correctly", func() {
 			Expect(msg.Name).To(Equal("service-instance-name"))
 			Expect(msg.SpaceGUID).To(Equal("space-guid"))
+			Expect(msg.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
 			Expect(msg.Tags).To(ConsistOf("foo", "bar"))
 			Expect(msg.Annotations).To(HaveLen(1))
 			Expect(msg.Annotations).To(HaveKeyWithValue("ann1", "val_ann1"))
@@ -520,13 +558,84 @@ var _ = Describe("ServiceInstancePatch", func() {
 		})
 	})
 
+	When("the service plan relationship is set", func() {
+		BeforeEach(func() {
+			patchPayload.Relationships = &payloads.ServiceInstancePatchRelationships{
+				ServicePlan: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "plan-guid"},
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceInstancePatch.Relationships.ServicePlan.Data.GUID).To(Equal("plan-guid"))
+		})
+
+		When("the service plan guid is empty", func() {
+			BeforeEach(func() {
+				patchPayload.Relationships.ServicePlan.Data.GUID = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+			})
+		})
+	})
+
+	When("the route service url is invalid", func() {
+		BeforeEach(func() {
+			patchPayload.RouteServiceURL = tools.PtrTo("not-a-url")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "route_service_url must be a valid https URL")
+		})
+	})
+
+	When("the maintenance info version is empty", func() {
+		BeforeEach(func() {
+			patchPayload.MaintenanceInfo = &payloads.ServiceInstanceMaintenanceInfo{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "version cannot be blank")
+		})
+	})
+
 	Context("ToServiceInstancePatchMessage", func() {
+		It("does not require a broker update", func() {
+			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
+			Expect(msg.RequiresBrokerUpdate()).To(BeFalse())
+		})
+
+		When("plan, parameters and maintenance info are set", func() {
+			BeforeEach(func() {
+				patchPayload.Parameters = &map[string]any{"p1": "v1"}
+				patchPayload.MaintenanceInfo = &payloads.ServiceInstanceMaintenanceInfo{Version: "1.2.3"}
+				patchPayload.Relationships = &payloads.ServiceInstancePatchRelationships{
+					ServicePlan: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "plan-guid"},
+					},
+				}
+			})
+
+			It("converts them to a broker update", func() {
+				msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
+				Expect(msg.PlanGUID).To(PointTo(Equal("plan-guid")))
+				Expect(msg.Parameters).To(PointTo(Equal(map[string]any{"p1": "v1"})))
+				Expect(msg.MaintenanceInfoVersion).To(PointTo(Equal("1.2.3")))
+				Expect(msg.RequiresBrokerUpdate()).To(BeTrue())
+			})
+		})
+
 		It("converts to repo message correctly", func() {
 			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
 			Expect(msg.SpaceGUID).To(Equal("space-guid"))
 			Expect(msg.GUID).To(Equal("app-guid"))
 			Expect(msg.Name).To(PointTo(Equal("service-instance-name")))
 			Expect(msg.Tags).To(PointTo(ConsistOf("foo", "bar")))
+			Expect(msg.RouteServiceURL).To(BeNil())
 			Expect(msg.Annotations).To(MatchAllKeys(Keys{
 				"ann1": PointTo(Equal("val_ann1")),
 			}))
@@ -562,3 +671,40 @@ var _ = Describe("ServiceInstanceDelete", func() {
 		Entry("invalid value for purge", "purge=foo", "invalid syntax"),
 	)
 })
+
+var _ = Describe("ServiceInstanceShare", func() {
+	var (
+		sharePayload         payloads.ServiceInstanceShare
+		serviceInstanceShare *payloads.ServiceInstanceShare
+		validatorErr         error
+	)
+
+	BeforeEach(func() {
+		serviceInstanceShare = new(payloads.ServiceInstanceShare)
+		sharePayload = payloads.ServiceInstanceShare{
+			Data: []payloads.RelationshipData{{GUID: "space-1"}, {GUID: "space-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), serviceInstanceShare)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(serviceInstanceShare.ToMessage("instance-guid")).To(Equal(repositories.ShareServiceInstanceMessage{
+			GUID:       "instance-guid",
+			SpaceGUIDs: []string{"space-1", "space-2"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
@@ -0,0 +1,69 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type ServiceRouteBindingCreate struct {
+	Relationships *ServiceRouteBindingRelationships `json:"relationships"`
+	Parameters    map[string]any                    `json:"parameters"`
+	Metadata      Metadata                          `json:"metadata"`
+}
+
+func (p ServiceRouteBindingCreate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Relationships, jellidation.NotNil),
+		jellidation.Field(&p.Metadata),
+	)
+}
+
+func (p ServiceRouteBindingCreate) ToMessage() repositories.CreateServiceRouteBindingMessage {
+	return repositories.CreateServiceRouteBindingMessage{
+		RouteGUID:           p.Relationships.Route.Data.GUID,
+		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
+		Parameters:          p.Parameters,
+		Labels:              p.Metadata.Labels,
+		Annotations:         p.Metadata.Annotations,
+	}
+}
+
+type ServiceRouteBindingRelationships struct {
+	Route           *Relationship `json:"route"`
+	ServiceInstance *Relationship `json:"service_instance"`
+}
+
+func (r ServiceRouteBindingRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Route, jellidation.NotNil),
+		jellidation.Field(&r.ServiceInstance, jellidation.NotNil),
+	)
+}
+
+type ServiceRouteBindingList struct {
+	RouteGUIDs           string
+	ServiceInstanceGUIDs string
+	LabelSelector        string
+}
+
+func (l *ServiceRouteBindingList) ToMessage() repositories.ListServiceRouteBindingsMessage {
+	return repositories.ListServiceRouteBindingsMessage{
+		RouteGUIDs:           parse.ArrayParam(l.RouteGUIDs),
+		ServiceInstanceGUIDs: parse.ArrayParam(l.ServiceInstanceGUIDs),
+		LabelSelector:        l.LabelSelector,
+	}
+}
+
+func (l *ServiceRouteBindingList) SupportedKeys() []string {
+	return []string{"route_guids", "service_instance_guids", "label_selector", "per_page", "page"}
+}
+
+func (l *ServiceRouteBindingList) DecodeFromURLValues(values url.Values) error {
+	l.RouteGUIDs = values.Get("route_guids")
+	l.ServiceInstanceGUIDs = values.Get("service_instance_guids")
+	l.LabelSelector = values.Get("label_selector")
+	return nil
+}
@@ -0,0 +1,152 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ServiceRouteBindingList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedServiceRouteBindingList payloads.ServiceRouteBindingList) {
+			actualServiceRouteBindingList, decodeErr := decodeQuery[payloads.ServiceRouteBindingList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualServiceRouteBindingList).To(Equal(expectedServiceRouteBindingList))
+		},
+		Entry("route_guids", "route_guids=route_guid", payloads.ServiceRouteBindingList{RouteGUIDs: "route_guid"}),
+		Entry("service_instance_guids", "service_instance_guids=si_guid", payloads.ServiceRouteBindingList{ServiceInstanceGUIDs: "si_guid"}),
+		Entry("label_selector=foo", "label_selector=foo", payloads.ServiceRouteBindingList{LabelSelector: "foo"}),
+		Entry("page", "page=3", payloads.ServiceRouteBindingList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.ServiceRouteBindingList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unsupported key", "foo=bar", "unsupported query parameter: foo"),
+	)
+
+	Describe("ToMessage", func() {
+		It("returns a list service route bindings message", func() {
+			payload := payloads.ServiceRouteBindingList{
+				RouteGUIDs:           "r1,r2",
+				ServiceInstanceGUIDs: "s1,s2",
+				LabelSelector:        "foo=bar",
+			}
+
+			Expect(payload.ToMessage()).To(Equal(repositories.ListServiceRouteBindingsMessage{
+				RouteGUIDs:           []string{"r1", "r2"},
+				ServiceInstanceGUIDs: []string{"s1", "s2"},
+				LabelSelector:        "foo=bar",
+			}))
+		})
+	})
+})
+
+var _ = Describe("ServiceRouteBindingCreate", func() {
+	var (
+		createPayload             payloads.ServiceRouteBindingCreate
+		serviceRouteBindingCreate *payloads.ServiceRouteBindingCreate
+		validatorErr              error
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingCreate = new(payloads.ServiceRouteBindingCreate)
+		createPayload = payloads.ServiceRouteBindingCreate{
+			Relationships: &payloads.ServiceRouteBindingRelationships{
+				Route: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "route-guid",
+					},
+				},
+				ServiceInstance: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "service-instance-guid",
+					},
+				},
+			},
+			Parameters: map[string]any{
+				"p1": "p1-value",
+			},
+			Metadata: payloads.Metadata{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"bar": "baz"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), serviceRouteBindingCreate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(serviceRouteBindingCreate).To(PointTo(Equal(createPayload)))
+	})
+
+	When("relationships are missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the route relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Route = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "route is required")
+		})
+	})
+
+	When("the service instance relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.ServiceInstance = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "service_instance is required")
+		})
+	})
+
+	When("the route guid is blank", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Route.Data.GUID = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
+	When("metadata is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Metadata.Labels["foo.cloudfoundry.org/bar"] = "baz"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "label/annotation key cannot use the cloudfoundry.org domain")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to repo message correctly", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateServiceRouteBindingMessage{
+				RouteGUID:           "route-guid",
+				ServiceInstanceGUID: "service-instance-guid",
+				Parameters:          map[string]any{"p1": "p1-value"},
+				Labels:              map[string]string{"foo": "bar"},
+				Annotations:         map[string]string{"bar": "baz"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/jellydator/validation"
+)
+
+type SidecarCreate struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryMB     *int64   `json:"memory_in_mb"`
+}
+
+func (c SidecarCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required),
+		validation.Field(&c.Command, validation.Required),
+		validation.Field(&c.ProcessTypes, validation.Required, validation.Each(validation.Required)),
+		validation.Field(&c.MemoryMB, validation.Min(1).Error("must be greater than 0")),
+	)
+}
+
+func (c SidecarCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateSidecarMessage {
+	var memoryMB int64
+	if c.MemoryMB != nil {
+		memoryMB = *c.MemoryMB
+	}
+
+	return repositories.CreateSidecarMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		Name:         c.Name,
+		Command:      c.Command,
+		ProcessTypes: c.ProcessTypes,
+		MemoryMB:     memoryMB,
+	}
+}
+
+type SidecarUpdate struct {
+	Name         *string  `json:"name"`
+	Command      *string  `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryMB     *int64   `json:"memory_in_mb"`
+}
+
+func (u SidecarUpdate) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Name, validation.NilOrNotEmpty),
+		validation.Field(&u.Command, validation.NilOrNotEmpty),
+		validation.Field(&u.ProcessTypes, validation.NilOrNotEmpty, validation.Each(validation.Required)),
+		validation.Field(&u.MemoryMB, validation.Min(1).Error("must be greater than 0")),
+	)
+}
+
+func (u SidecarUpdate) ToMessage(sidecarGUID string) repositories.UpdateSidecarMessage {
+	return repositories.UpdateSidecarMessage{
+		GUID:         sidecarGUID,
+		Name:         u.Name,
+		Command:      u.Command,
+		ProcessTypes: u.ProcessTypes,
+		MemoryMB:     u.MemoryMB,
+	}
+}
+
+type SidecarList struct {
+	ProcessTypes string
+}
+
+func (l *SidecarList) ToMessage(appGUID string) repositories.ListSidecarsMessage {
+	return repositories.ListSidecarsMessage{
+		AppGUIDs:     []string{appGUID},
+		ProcessTypes: parse.ArrayParam(l.ProcessTypes),
+	}
+}
+
+func (l *SidecarList) SupportedKeys() []string {
+	return []string{"process_types", "per_page", "page"}
+}
+
+func (l *SidecarList) DecodeFromURLValues(values url.Values) error {
+	l.ProcessTypes = values.Get("process_types")
+	return nil
+}
@@ -0,0 +1,151 @@
+package payloads_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SidecarList", func() {
+	It("decodes from url values", func() {
+		sidecarList := payloads.SidecarList{}
+		req, err := http.NewRequest("GET", "http://foo.com/bar?process_types=web,worker", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(validator.DecodeAndValidateURLValues(req, &sidecarList)).To(Succeed())
+		Expect(sidecarList.ToMessage("app-guid")).To(Equal(repositories.ListSidecarsMessage{
+			AppGUIDs:     []string{"app-guid"},
+			ProcessTypes: []string{"web", "worker"},
+		}))
+	})
+})
+
+var _ = Describe("SidecarCreate", func() {
+	var (
+		payload        payloads.SidecarCreate
+		decodedPayload *payloads.SidecarCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.SidecarCreate{
+			Name:         "agent",
+			Command:      "run-agent",
+			ProcessTypes: []string{"web"},
+			MemoryMB:     tools.PtrTo[int64](64),
+		}
+
+		decodedPayload = new(payloads.SidecarCreate)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("the name is missing", func() {
+		BeforeEach(func() {
+			payload.Name = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the command is missing", func() {
+		BeforeEach(func() {
+			payload.Command = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "command cannot be blank")
+		})
+	})
+
+	When("no process types are given", func() {
+		BeforeEach(func() {
+			payload.ProcessTypes = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+		})
+	})
+
+	When("memory is negative", func() {
+		BeforeEach(func() {
+			payload.MemoryMB = tools.PtrTo[int64](-1)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "memory_in_mb must be greater than 0")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a create sidecar message", func() {
+			Expect(payload.ToMessage(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     64,
+			}))
+		})
+	})
+})
+
+var _ = Describe("SidecarUpdate", func() {
+	var (
+		payload        payloads.SidecarUpdate
+		decodedPayload *payloads.SidecarUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.SidecarUpdate{
+			Command: tools.PtrTo("run-agent --verbose"),
+		}
+
+		decodedPayload = new(payloads.SidecarUpdate)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("the process types are emptied", func() {
+		BeforeEach(func() {
+			payload.ProcessTypes = []string{}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to an update sidecar message", func() {
+			Expect(payload.ToMessage("sidecar-guid")).To(Equal(repositories.UpdateSidecarMessage{
+				GUID:    "sidecar-guid",
+				Command: tools.PtrTo("run-agent --verbose"),
+			}))
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SpaceQuotaRelationships struct {
+	Organization Relationship       `json:"organization"`
+	Spaces       ToManyRelationship `json:"spaces"`
+}
+
+func (r SpaceQuotaRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Organization),
+	)
+}
+
+type SpaceQuotaCreate struct {
+	Name          string                  `json:"name"`
+	Apps          QuotaAppLimits          `json:"apps"`
+	Services      QuotaServiceLimits      `json:"services"`
+	Routes        QuotaRouteLimits        `json:"routes"`
+	Relationships SpaceQuotaRelationships `json:"relationships"`
+}
+
+func (c SpaceQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.Apps),
+		jellidation.Field(&c.Services),
+		jellidation.Field(&c.Routes),
+		jellidation.Field(&c.Relationships),
+	)
+}
+
+func (c SpaceQuotaCreate) ToMessage() repositories.CreateSpaceQuotaMessage {
+	return repositories.CreateSpaceQuotaMessage{
+		Name:    c.Name,
+		OrgGUID: c.Relationships.Organization.Data.GUID,
+		Limits: repositories.QuotaLimits{
+			Apps: c.Apps.toRepo(),
+			Services: repositories.ServiceQuotaLimits{
+				TotalServiceInstances: c.Services.TotalServiceInstances,
+			},
+			Routes: repositories.RouteQuotaLimits{
+				TotalRoutes: c.Routes.TotalRoutes,
+			},
+		},
+		SpaceGUIDs: relationshipGUIDs(c.Relationships.Spaces.Data),
+	}
+}
+
+type SpaceQuotaPatch struct {
+	Name             *string `json:"name"`
+	QuotaLimitsPatch `json:",inline"`
+}
+
+func (p SpaceQuotaPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&p.Apps),
+		jellidation.Field(&p.Services),
+		jellidation.Field(&p.Routes),
+	)
+}
+
+func (p SpaceQuotaPatch) ToMessage(guid string) repositories.UpdateSpaceQuotaMessage {
+	return repositories.UpdateSpaceQuotaMessage{
+		GUID:   guid,
+		Name:   p.Name,
+		Limits: p.toRepo(),
+	}
+}
+
+type SpaceQuotaApply struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (a SpaceQuotaApply) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Data, jellidation.Required),
+	)
+}
+
+func (a SpaceQuotaApply) SpaceGUIDs() []string {
+	return relationshipGUIDs(a.Data)
+}
+
+func (a SpaceQuotaApply) ToMessage(guid string) repositories.ApplySpaceQuotaMessage {
+	return repositories.ApplySpaceQuotaMessage{
+		GUID:       guid,
+		SpaceGUIDs: a.SpaceGUIDs(),
+	}
+}
+
+type SpaceQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+	SpaceGUIDs        string
+}
+
+func (l *SpaceQuotaList) ToMessage() repositories.ListSpaceQuotasMessage {
+	return repositories.ListSpaceQuotasMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		Names:      parse.ArrayParam(l.Names),
+		OrgGUIDs:   parse.ArrayParam(l.OrganizationGUIDs),
+		SpaceGUIDs: parse.ArrayParam(l.SpaceGUIDs),
+	}
+}
+
+func (l *SpaceQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "space_guids", "per_page", "page"}
+}
+
+func (l *SpaceQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	return nil
+}
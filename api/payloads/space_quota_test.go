@@ -0,0 +1,205 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SpaceQuotaCreate", func() {
+	var (
+		createPayload    payloads.SpaceQuotaCreate
+		spaceQuotaCreate *payloads.SpaceQuotaCreate
+		validatorErr     error
+	)
+
+	BeforeEach(func() {
+		spaceQuotaCreate = new(payloads.SpaceQuotaCreate)
+		createPayload = payloads.SpaceQuotaCreate{
+			Name: "my-quota",
+			Apps: payloads.QuotaAppLimits{
+				TotalMemoryInMB: tools.PtrTo[int64](2048),
+			},
+			Relationships: payloads.SpaceQuotaRelationships{
+				Organization: payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "org-guid"},
+				},
+				Spaces: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), spaceQuotaCreate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(spaceQuotaCreate).To(PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the organization relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Organization = payloads.Relationship{}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships.organization.data is required")
+		})
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			createPayload.Services.TotalServiceInstances = tools.PtrTo[int64](-1)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "total_service_instances must be no less than 0")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repo message", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(spaceQuotaCreate.ToMessage()).To(Equal(repositories.CreateSpaceQuotaMessage{
+				Name:    "my-quota",
+				OrgGUID: "org-guid",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.AppQuotaLimits{
+						TotalMemoryInMB: tools.PtrTo[int64](2048),
+					},
+				},
+				SpaceGUIDs: []string{"space-guid"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaPatch", func() {
+	var (
+		patchPayload    map[string]any
+		spaceQuotaPatch *payloads.SpaceQuotaPatch
+		validatorErr    error
+	)
+
+	BeforeEach(func() {
+		spaceQuotaPatch = new(payloads.SpaceQuotaPatch)
+		patchPayload = map[string]any{
+			"services": map[string]any{
+				"total_service_instances": nil,
+			},
+			"routes": map[string]any{
+				"total_routes": 7,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(patchPayload), spaceQuotaPatch)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+
+		message := spaceQuotaPatch.ToMessage("quota-guid")
+		Expect(message.GUID).To(Equal("quota-guid"))
+		Expect(message.Name).To(BeNil())
+		Expect(message.Limits.Services.TotalServiceInstances).To(PointTo(Equal(repositories.QuotaLimitPatch{})))
+		Expect(message.Limits.Routes.TotalRoutes).To(PointTo(Equal(repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](7)})))
+		Expect(message.Limits.Apps.TotalMemoryInMB).To(BeNil())
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			patchPayload["apps"] = map[string]any{"per_app_tasks": -1}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "per_app_tasks must be no less than 0")
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaApply", func() {
+	var (
+		applyPayload    payloads.SpaceQuotaApply
+		spaceQuotaApply *payloads.SpaceQuotaApply
+		validatorErr    error
+	)
+
+	BeforeEach(func() {
+		spaceQuotaApply = new(payloads.SpaceQuotaApply)
+		applyPayload = payloads.SpaceQuotaApply{
+			Data: []payloads.RelationshipData{{GUID: "space-1"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(applyPayload), spaceQuotaApply)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(spaceQuotaApply.ToMessage("quota-guid")).To(Equal(repositories.ApplySpaceQuotaMessage{
+			GUID:       "quota-guid",
+			SpaceGUIDs: []string{"space-1"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			applyPayload.Data = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSpaceQuotaList payloads.SpaceQuotaList) {
+			actualSpaceQuotaList, decodeErr := decodeQuery[payloads.SpaceQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSpaceQuotaList).To(Equal(expectedSpaceQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.SpaceQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.SpaceQuotaList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1", payloads.SpaceQuotaList{OrganizationGUIDs: "o1"}),
+		Entry("space_guids", "space_guids=s1", payloads.SpaceQuotaList{SpaceGUIDs: "s1"}),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repo message", func() {
+			spaceQuotaList := payloads.SpaceQuotaList{
+				GUIDs:             "g1",
+				Names:             "n1,n2",
+				OrganizationGUIDs: "o1",
+				SpaceGUIDs:        "s1",
+			}
+			Expect(spaceQuotaList.ToMessage()).To(Equal(repositories.ListSpaceQuotasMessage{
+				GUIDs:      []string{"g1"},
+				Names:      []string{"n1", "n2"},
+				OrgGUIDs:   []string{"o1"},
+				SpaceGUIDs: []string{"s1"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,104 @@
+package payloads
+
+import (
+	"fmt"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AppUsageEventList struct {
+	GUIDs     string
+	AfterGUID string
+	OrderBy   string
+}
+
+func (l AppUsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+	)
+}
+
+func (l *AppUsageEventList) ToMessage() repositories.ListAppUsageEventsMessage {
+	return repositories.ListAppUsageEventsMessage{
+		GUIDs:     parse.ArrayParam(l.GUIDs),
+		AfterGUID: l.AfterGUID,
+		OrderBy:   l.OrderBy,
+	}
+}
+
+func (l *AppUsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "order_by", "per_page", "page"}
+}
+
+func (l *AppUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	l.OrderBy = values.Get("order_by")
+	return nil
+}
+
+type ServiceUsageEventList struct {
+	GUIDs                string
+	AfterGUID            string
+	ServiceInstanceTypes string
+	ServiceOfferingGUIDs string
+	OrderBy              string
+}
+
+func (l ServiceUsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.ServiceInstanceTypes, jellidation.By(func(value any) error {
+			serviceInstanceTypes, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%T is not supported, string is expected", value)
+			}
+
+			return jellidation.Each(validation.OneOf(
+				"managed_service_instance",
+				"user_provided_service_instance",
+			)).Validate(parse.ArrayParam(serviceInstanceTypes))
+		})),
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+	)
+}
+
+func (l *ServiceUsageEventList) ToMessage() repositories.ListServiceUsageEventsMessage {
+	return repositories.ListServiceUsageEventsMessage{
+		GUIDs:                parse.ArrayParam(l.GUIDs),
+		AfterGUID:            l.AfterGUID,
+		ServiceInstanceTypes: toInstanceTypes(parse.ArrayParam(l.ServiceInstanceTypes)),
+		ServiceOfferingGUIDs: parse.ArrayParam(l.ServiceOfferingGUIDs),
+		OrderBy:              l.OrderBy,
+	}
+}
+
+func (l *ServiceUsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "service_instance_types", "service_offering_guids", "order_by", "per_page", "page"}
+}
+
+func (l *ServiceUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	l.ServiceInstanceTypes = values.Get("service_instance_types")
+	l.ServiceOfferingGUIDs = values.Get("service_offering_guids")
+	l.OrderBy = values.Get("order_by")
+	return nil
+}
+
+func toInstanceTypes(serviceInstanceTypes []string) []string {
+	var instanceTypes []string
+	for _, t := range serviceInstanceTypes {
+		switch t {
+		case "managed_service_instance":
+			instanceTypes = append(instanceTypes, korifiv1alpha1.ManagedType)
+		case "user_provided_service_instance":
+			instanceTypes = append(instanceTypes, korifiv1alpha1.UserProvidedType)
+		}
+	}
+	return instanceTypes
+}
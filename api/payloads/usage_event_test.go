@@ -0,0 +1,103 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEventList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedAppUsageEventList payloads.AppUsageEventList) {
+				actualAppUsageEventList, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualAppUsageEventList).To(Equal(expectedAppUsageEventList))
+			},
+			Entry("guids", "guids=g1,g2", payloads.AppUsageEventList{GUIDs: "g1,g2"}),
+			Entry("after_guid", "after_guid=g1", payloads.AppUsageEventList{AfterGUID: "g1"}),
+			Entry("order_by created_at", "order_by=created_at", payloads.AppUsageEventList{OrderBy: "created_at"}),
+			Entry("order_by -updated_at", "order_by=-updated_at", payloads.AppUsageEventList{OrderBy: "-updated_at"}),
+			Entry("page", "page=3", payloads.AppUsageEventList{}),
+			Entry("per_page", "per_page=10", payloads.AppUsageEventList{}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("unsupported parameter", "foo=bar", "unsupported query parameter"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			appUsageEventList := payloads.AppUsageEventList{
+				GUIDs:     "g1,g2",
+				AfterGUID: "g0",
+				OrderBy:   "-created_at",
+			}
+			Expect(appUsageEventList.ToMessage()).To(Equal(repositories.ListAppUsageEventsMessage{
+				GUIDs:     []string{"g1", "g2"},
+				AfterGUID: "g0",
+				OrderBy:   "-created_at",
+			}))
+		})
+	})
+})
+
+var _ = Describe("ServiceUsageEventList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedServiceUsageEventList payloads.ServiceUsageEventList) {
+				actualServiceUsageEventList, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualServiceUsageEventList).To(Equal(expectedServiceUsageEventList))
+			},
+			Entry("guids", "guids=g1,g2", payloads.ServiceUsageEventList{GUIDs: "g1,g2"}),
+			Entry("after_guid", "after_guid=g1", payloads.ServiceUsageEventList{AfterGUID: "g1"}),
+			Entry("service_instance_types", "service_instance_types=managed_service_instance,user_provided_service_instance", payloads.ServiceUsageEventList{
+				ServiceInstanceTypes: "managed_service_instance,user_provided_service_instance",
+			}),
+			Entry("service_offering_guids", "service_offering_guids=o1,o2", payloads.ServiceUsageEventList{ServiceOfferingGUIDs: "o1,o2"}),
+			Entry("order_by created_at", "order_by=created_at", payloads.ServiceUsageEventList{OrderBy: "created_at"}),
+			Entry("order_by -updated_at", "order_by=-updated_at", payloads.ServiceUsageEventList{OrderBy: "-updated_at"}),
+			Entry("page", "page=3", payloads.ServiceUsageEventList{}),
+			Entry("per_page", "per_page=10", payloads.ServiceUsageEventList{}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("invalid service_instance_types", "service_instance_types=foo", "value must be one of"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			serviceUsageEventList := payloads.ServiceUsageEventList{
+				GUIDs:                "g1,g2",
+				AfterGUID:            "g0",
+				ServiceInstanceTypes: "managed_service_instance,user_provided_service_instance",
+				ServiceOfferingGUIDs: "o1,o2",
+				OrderBy:              "-created_at",
+			}
+			Expect(serviceUsageEventList.ToMessage()).To(Equal(repositories.ListServiceUsageEventsMessage{
+				GUIDs:                []string{"g1", "g2"},
+				AfterGUID:            "g0",
+				ServiceInstanceTypes: []string{"managed", "user-provided"},
+				ServiceOfferingGUIDs: []string{"o1", "o2"},
+				OrderBy:              "-created_at",
+			}))
+		})
+	})
+})
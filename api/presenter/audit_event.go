@@ -0,0 +1,75 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const auditEventsBase = "/v3/audit_events"
+
+type AuditEventResponse struct {
+	GUID         string                   `json:"guid"`
+	CreatedAt    string                   `json:"created_at"`
+	UpdatedAt    string                   `json:"updated_at"`
+	Type         string                   `json:"type"`
+	Actor        AuditEventActorResponse  `json:"actor"`
+	Target       AuditEventTargetResponse `json:"target"`
+	Data         map[string]any           `json:"data"`
+	Space        *RelationshipData        `json:"space"`
+	Organization *RelationshipData        `json:"organization"`
+	Links        AuditEventLinks          `json:"links"`
+}
+
+type AuditEventActorResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventTargetResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAuditEvent(auditEventRecord repositories.AuditEventRecord, baseURL url.URL, includes ...include.Resource) AuditEventResponse {
+	return AuditEventResponse{
+		GUID:      auditEventRecord.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&auditEventRecord.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(auditEventRecord.UpdatedAt)),
+		Type:      auditEventRecord.Type,
+		Actor: AuditEventActorResponse{
+			GUID: auditEventRecord.Actor.GUID,
+			Type: auditEventRecord.Actor.Type,
+			Name: auditEventRecord.Actor.Name,
+		},
+		Target: AuditEventTargetResponse{
+			GUID: auditEventRecord.Target.GUID,
+			Type: auditEventRecord.Target.Type,
+			Name: auditEventRecord.Target.Name,
+		},
+		Data:         map[string]any{},
+		Space:        toOptionalRelationshipData(auditEventRecord.SpaceGUID),
+		Organization: toOptionalRelationshipData(auditEventRecord.OrganizationGUID),
+		Links: AuditEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(auditEventsBase, auditEventRecord.GUID).build(),
+			},
+		},
+	}
+}
+
+func toOptionalRelationshipData(guid string) *RelationshipData {
+	if guid == "" {
+		return nil
+	}
+
+	return &RelationshipData{GUID: guid}
+}
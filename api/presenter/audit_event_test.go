@@ -0,0 +1,96 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvent", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.AuditEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.AuditEventRecord{
+			GUID:      "audit-event-guid",
+			CreatedAt: time.UnixMilli(1000).UTC(),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000).UTC()),
+			Type:      "audit.app.start",
+			Actor: repositories.AuditEventActor{
+				GUID: "bob",
+				Type: "user",
+				Name: "bob",
+			},
+			Target: repositories.AuditEventTarget{
+				GUID: "app-guid",
+				Type: "app",
+				Name: "my-app",
+			},
+			SpaceGUID:        "space-guid",
+			OrganizationGUID: "org-guid",
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForAuditEvent(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "audit-event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"type": "audit.app.start",
+			"actor": {
+				"guid": "bob",
+				"type": "user",
+				"name": "bob"
+			},
+			"target": {
+				"guid": "app-guid",
+				"type": "app",
+				"name": "my-app"
+			},
+			"data": {},
+			"space": {
+				"guid": "space-guid"
+			},
+			"organization": {
+				"guid": "org-guid"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/audit_events/audit-event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the event does not belong to a space or an org", func() {
+		BeforeEach(func() {
+			record.SpaceGUID = ""
+			record.OrganizationGUID = ""
+		})
+
+		It("presents null space and organization", func() {
+			Expect(output).To(MatchJSONPath("$.space", BeNil()))
+			Expect(output).To(MatchJSONPath("$.organization", BeNil()))
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
 )
 
@@ -23,6 +24,8 @@ type DropletGUID struct {
 type DeploymentResponse struct {
 	GUID          string                       `json:"guid"`
 	Status        DeploymentStatus             `json:"status"`
+	Strategy      string                       `json:"strategy"`
+	Options       DeploymentOptions            `json:"options"`
 	Droplet       DropletGUID                  `json:"droplet"`
 	Relationships map[string]ToOneRelationship `json:"relationships"`
 	Links         DeploymentLinks              `json:"links"`
@@ -30,18 +33,28 @@ type DeploymentResponse struct {
 	UpdatedAt     string                       `json:"updated_at"`
 }
 
+type DeploymentOptions struct {
+	MaxInFlight int32 `json:"max_in_flight"`
+}
+
 type DeploymentLinks struct {
-	Self Link `json:"self"`
-	App  Link `json:"app"`
+	Self     Link  `json:"self"`
+	App      Link  `json:"app"`
+	Cancel   *Link `json:"cancel,omitempty"`
+	Continue *Link `json:"continue,omitempty"`
 }
 
 func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url.URL, includes ...include.Resource) DeploymentResponse {
-	return DeploymentResponse{
+	response := DeploymentResponse{
 		GUID: responseDeployment.GUID,
 		Status: DeploymentStatus{
 			Value:  string(responseDeployment.Status.Value),
 			Reason: string(responseDeployment.Status.Reason),
 		},
+		Strategy: responseDeployment.Strategy,
+		Options: DeploymentOptions{
+			MaxInFlight: responseDeployment.MaxInFlight,
+		},
 		Droplet: DropletGUID{
 			Guid: responseDeployment.DropletGUID,
 		},
@@ -57,4 +70,17 @@ func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url
 			},
 		},
 	}
+
+	if responseDeployment.Strategy == korifiv1alpha1.CanaryDeploymentStrategy {
+		response.Links.Cancel = &Link{
+			HRef:   buildURL(baseURL).appendPath(deploymentsBase, responseDeployment.GUID, "actions/cancel").build(),
+			Method: "POST",
+		}
+		response.Links.Continue = &Link{
+			HRef:   buildURL(baseURL).appendPath(deploymentsBase, responseDeployment.GUID, "actions/continue").build(),
+			Method: "POST",
+		}
+	}
+
+	return response
 }
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -27,6 +28,8 @@ var _ = Describe("Deployments", func() {
 		record = repositories.DeploymentRecord{
 			GUID:        "app-guid",
 			DropletGUID: "droplet-guid",
+			Strategy:    "rolling",
+			MaxInFlight: 2,
 			CreatedAt:   time.UnixMilli(1000),
 			UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
 			Status: repositories.DeploymentStatus{
@@ -50,6 +53,10 @@ var _ = Describe("Deployments", func() {
 				"value": "deployment-status-value",
 				"reason": "deployment-status-reason"
 			},
+			"strategy": "rolling",
+			"options": {
+				"max_in_flight": 2
+			},
 			"droplet": {
 				"guid": "droplet-guid"
 			},
@@ -72,4 +79,20 @@ var _ = Describe("Deployments", func() {
 			}
 		}`))
 	})
+
+	When("the deployment uses the canary strategy", func() {
+		BeforeEach(func() {
+			record.Strategy = "canary"
+		})
+
+		It("includes the cancel and continue action links", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.strategy", "canary"),
+				MatchJSONPath("$.links.cancel.href", "https://api.example.org/v3/deployments/app-guid/actions/cancel"),
+				MatchJSONPath("$.links.cancel.method", "POST"),
+				MatchJSONPath("$.links.continue.href", "https://api.example.org/v3/deployments/app-guid/actions/continue"),
+				MatchJSONPath("$.links.continue.method", "POST"),
+			))
+		})
+	})
 })
@@ -82,6 +82,18 @@ var _ = Describe("Domains", func() {
 		}`))
 	})
 
+	When("the domain has a router group", func() {
+		BeforeEach(func() {
+			record.RouterGroup = "default-tcp"
+		})
+
+		It("presents the router group", func() {
+			Expect(output).To(MatchJSONPath("$.router_group.guid", "default-tcp"))
+			Expect(output).To(MatchJSONPath("$.supported_protocols", ConsistOf("tcp")))
+			Expect(output).To(MatchJSONPath("$.links.router_group.href", "https://api.example.org/v3/router_groups/default-tcp"))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -83,5 +83,19 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL, inclu
 	if dropletRecord.Lifecycle.Type == "docker" {
 		toReturn.Image = &dropletRecord.Image
 	}
+	if dropletRecord.PackageGUID == "" {
+		delete(toReturn.Links, "package")
+	}
+	switch {
+	case dropletRecord.State == repositories.DropletStateAwaitingUpload:
+		toReturn.Links["upload"] = &Link{
+			HRef:   buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "upload").build(),
+			Method: "POST",
+		}
+	case dropletRecord.Lifecycle.Type != "docker":
+		toReturn.Links["download"] = &Link{
+			HRef: buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "download").build(),
+		}
+	}
 	return toReturn
 }
@@ -95,7 +95,9 @@ var _ = Describe("Droplet", func() {
 					"href": "https://api.example.org/v3/apps/the-app-guid/relationships/current_droplet",
 					"method": "PATCH"
 				},
-				"download": null
+				"download": {
+					"href": "https://api.example.org/v3/droplets/the-droplet-guid/download"
+				}
 			},
 			"metadata": {
 				"labels": {
@@ -174,6 +176,28 @@ var _ = Describe("Droplet", func() {
 		})
 	})
 
+	When("the droplet has been created via the API", func() {
+		BeforeEach(func() {
+			record.PackageGUID = ""
+		})
+
+		It("does not link to a package", func() {
+			Expect(output).NotTo(ContainSubstring("/v3/packages/"))
+		})
+
+		When("the droplet is awaiting upload", func() {
+			BeforeEach(func() {
+				record.State = "AWAITING_UPLOAD"
+			})
+
+			It("links to the upload endpoint", func() {
+				Expect(output).To(MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/droplets/the-droplet-guid/upload"))
+				Expect(output).To(MatchJSONPath("$.links.upload.method", "POST"))
+				Expect(output).To(MatchJSONPath("$.links.download", BeNil()))
+			})
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -0,0 +1,43 @@
+package presenter
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+type FeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func ForAppSSHFeature(app repositories.AppRecord) FeatureResponse {
+	return FeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into the app.",
+		Enabled:     app.EnableSSH,
+	}
+}
+
+func ForAppRevisionsFeature() FeatureResponse {
+	return FeatureResponse{
+		Name:        "revisions",
+		Description: "Enable versioning of an application",
+		Enabled:     false,
+	}
+}
+
+func ForSpaceSSHFeature(space repositories.SpaceRecord) FeatureResponse {
+	return FeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into apps in the space.",
+		Enabled:     space.AllowSSH,
+	}
+}
+
+type SSHCodeResponse struct {
+	Code string `json:"code"`
+}
+
+func ForSSHCode(code string) SSHCodeResponse {
+	return SSHCodeResponse{
+		Code: code,
+	}
+}
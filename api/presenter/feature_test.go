@@ -0,0 +1,63 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature", func() {
+	var (
+		response any
+		output   []byte
+	)
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForAppSSHFeature", func() {
+		BeforeEach(func() {
+			response = presenter.ForAppSSHFeature(repositories.AppRecord{EnableSSH: true})
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"name": "ssh",
+				"description": "Enable SSHing into the app.",
+				"enabled": true
+			}`))
+		})
+	})
+
+	Describe("ForSpaceSSHFeature", func() {
+		BeforeEach(func() {
+			response = presenter.ForSpaceSSHFeature(repositories.SpaceRecord{AllowSSH: false})
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"name": "ssh",
+				"description": "Enable SSHing into apps in the space.",
+				"enabled": false
+			}`))
+		})
+	})
+
+	Describe("ForSSHCode", func() {
+		BeforeEach(func() {
+			response = presenter.ForSSHCode("the-code")
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"code": "the-code"
+			}`))
+		})
+	})
+})
@@ -26,18 +26,28 @@ const (
 	ServiceBrokerCreateOperation = "service_broker.create"
 	ServiceBrokerDeleteOperation = "service_broker.delete"
 	ServiceBrokerUpdateOperation = "service_broker.update"
+	SecurityGroupDeleteOperation = "security_group.delete"
+	OrgQuotaDeleteOperation      = "organization_quota.delete"
+	SpaceQuotaDeleteOperation    = "space_quota.delete"
+
+	ServiceBrokerCatalogSynchronizeOperation = "service_broker.catalog.synchronize"
 
 	ManagedServiceInstanceResourceType    = "managed_service_instance"
 	ManagedServiceBindingResourceType     = "managed_service_binding"
+	ServiceRouteBindingResourceType       = "service_route_binding"
 	ManagedServiceInstanceCreateOperation = ManagedServiceInstanceResourceType + ".create"
 	ManagedServiceInstanceDeleteOperation = ManagedServiceInstanceResourceType + ".delete"
+	ManagedServiceInstanceUpdateOperation = ManagedServiceInstanceResourceType + ".update"
 	ManagedServiceBindingCreateOperation  = ManagedServiceBindingResourceType + ".create"
 	ManagedServiceBindingDeleteOperation  = ManagedServiceBindingResourceType + ".delete"
+	ManagedServiceBindingRotateOperation  = ManagedServiceBindingResourceType + ".rotate"
+	ServiceRouteBindingCreateOperation    = ServiceRouteBindingResourceType + ".create"
+	ServiceRouteBindingDeleteOperation    = ServiceRouteBindingResourceType + ".delete"
 )
 
 var (
-	jobOperationPattern       = `(([a-z_\-]+)\.([a-z_]+))` // (e.g. app.delete, space.apply_manifest, etc.)
-	resourceIdentifierPattern = `([A-Za-z0-9\-\.]+)`       // (e.g. cf-space-a4cd478b-0b02-452f-8498-ce87ec5c6649, CUSTOM_ORG_ID, etc.)
+	jobOperationPattern       = `(([a-z_\-]+)\.([a-z_\.]+))` // (e.g. app.delete, space.apply_manifest, service_broker.catalog.synchronize, etc.)
+	resourceIdentifierPattern = `([A-Za-z0-9\-\.]+)`         // (e.g. cf-space-a4cd478b-0b02-452f-8498-ce87ec5c6649, CUSTOM_ORG_ID, etc.)
 	jobRegexp                 = regexp.MustCompile(jobOperationPattern + JobGUIDDelimiter + resourceIdentifierPattern)
 )
 
@@ -114,7 +124,8 @@ func forJobState(job Job, state repositories.ResourceState, errors []JobResponse
 	}
 
 	if job.ResourceType == ManagedServiceInstanceResourceType ||
-		job.ResourceType == ManagedServiceBindingResourceType {
+		job.ResourceType == ManagedServiceBindingResourceType ||
+		job.ResourceType == ServiceRouteBindingResourceType {
 		return StatePolling
 	}
 
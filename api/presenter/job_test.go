@@ -48,6 +48,22 @@ var _ = Describe("", func() {
 				ResourceType: "resource",
 			}))
 		})
+
+		When("the operation has multiple segments", func() {
+			BeforeEach(func() {
+				guid = "resource.sub.operation~guid"
+			})
+
+			It("keeps them in the job type", func() {
+				Expect(match).To(BeTrue())
+				Expect(job).To(Equal(presenter.Job{
+					GUID:         "resource.sub.operation~guid",
+					Type:         "resource.sub.operation",
+					ResourceGUID: "guid",
+					ResourceType: "resource",
+				}))
+			})
+		})
 	})
 
 	Describe("ForManifestApplyJob", func() {
@@ -171,5 +187,16 @@ var _ = Describe("", func() {
 				Expect(output).To(matchers.MatchJSONPath("$.state", Equal("POLLING")))
 			})
 		})
+
+		When("the job refers to a service route binding that is not ready", func() {
+			BeforeEach(func() {
+				job.ResourceType = presenter.ServiceRouteBindingResourceType
+				state = repositories.ResourceStateUnknown
+			})
+
+			It("renders the job as POLLING", func() {
+				Expect(output).To(matchers.MatchJSONPath("$.state", Equal("POLLING")))
+			})
+		})
 	})
 })
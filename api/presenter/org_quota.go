@@ -0,0 +1,119 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const orgQuotasBase = "/v3/organization_quotas"
+
+type QuotaAppsResponse struct {
+	TotalMemoryInMB              *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB         *int64 `json:"per_process_memory_in_mb"`
+	TotalInstances               *int64 `json:"total_instances"`
+	PerAppTasks                  *int64 `json:"per_app_tasks"`
+	LogRateLimitInBytesPerSecond *int64 `json:"log_rate_limit_in_bytes_per_second"`
+}
+
+// QuotaServicesResponse always allows paid services and has no service key
+// limit, as Korifi does not restrict either
+type QuotaServicesResponse struct {
+	PaidServicesAllowed   bool   `json:"paid_services_allowed"`
+	TotalServiceInstances *int64 `json:"total_service_instances"`
+	TotalServiceKeys      *int64 `json:"total_service_keys"`
+}
+
+type QuotaRoutesResponse struct {
+	TotalRoutes        *int64 `json:"total_routes"`
+	TotalReservedPorts *int64 `json:"total_reserved_ports"`
+}
+
+type QuotaDomainsResponse struct {
+	TotalDomains *int64 `json:"total_domains"`
+}
+
+type OrgQuotaResponse struct {
+	GUID          string                `json:"guid"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedAt     string                `json:"updated_at"`
+	Name          string                `json:"name"`
+	Apps          QuotaAppsResponse     `json:"apps"`
+	Services      QuotaServicesResponse `json:"services"`
+	Routes        QuotaRoutesResponse   `json:"routes"`
+	Domains       QuotaDomainsResponse  `json:"domains"`
+	Relationships OrgQuotaRelationships `json:"relationships"`
+	Links         OrgQuotaLinks         `json:"links"`
+}
+
+type OrgQuotaRelationships struct {
+	Organizations payloads.ToManyRelationship `json:"organizations"`
+}
+
+type OrgQuotaLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForOrgQuota(orgQuotaRecord repositories.OrgQuotaRecord, baseURL url.URL, includes ...include.Resource) OrgQuotaResponse {
+	return OrgQuotaResponse{
+		GUID:      orgQuotaRecord.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&orgQuotaRecord.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(orgQuotaRecord.UpdatedAt)),
+		Name:      orgQuotaRecord.Name,
+		Apps:      forQuotaApps(orgQuotaRecord.Limits),
+		Services:  forQuotaServices(orgQuotaRecord.Limits),
+		Routes:    forQuotaRoutes(orgQuotaRecord.Limits),
+		Relationships: OrgQuotaRelationships{
+			Organizations: payloads.ToManyRelationship{
+				Data: toManyRelationshipData(orgQuotaRecord.OrgGUIDs),
+			},
+		},
+		Links: OrgQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, orgQuotaRecord.GUID).build(),
+			},
+		},
+	}
+}
+
+type OrgQuotaOrganizationsResponse struct {
+	Data  []payloads.RelationshipData `json:"data"`
+	Links OrgQuotaLinks               `json:"links"`
+}
+
+func ForOrgQuotaOrganizations(orgQuotaRecord repositories.OrgQuotaRecord, baseURL url.URL) OrgQuotaOrganizationsResponse {
+	return OrgQuotaOrganizationsResponse{
+		Data: toManyRelationshipData(orgQuotaRecord.OrgGUIDs),
+		Links: OrgQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, orgQuotaRecord.GUID, "relationships", "organizations").build(),
+			},
+		},
+	}
+}
+
+func forQuotaApps(limits repositories.QuotaLimits) QuotaAppsResponse {
+	return QuotaAppsResponse{
+		TotalMemoryInMB:              limits.Apps.TotalMemoryInMB,
+		PerProcessMemoryInMB:         limits.Apps.PerProcessMemoryInMB,
+		TotalInstances:               limits.Apps.TotalInstances,
+		PerAppTasks:                  limits.Apps.PerAppTasks,
+		LogRateLimitInBytesPerSecond: limits.Apps.LogRateLimitInBytesPerSecond,
+	}
+}
+
+func forQuotaServices(limits repositories.QuotaLimits) QuotaServicesResponse {
+	return QuotaServicesResponse{
+		PaidServicesAllowed:   true,
+		TotalServiceInstances: limits.Services.TotalServiceInstances,
+	}
+}
+
+func forQuotaRoutes(limits repositories.QuotaLimits) QuotaRoutesResponse {
+	return QuotaRoutesResponse{
+		TotalRoutes: limits.Routes.TotalRoutes,
+	}
+}
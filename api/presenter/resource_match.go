@@ -0,0 +1,38 @@
+package presenter
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/resourcecache"
+)
+
+type ResourceMatchesResponse struct {
+	Resources []ResourceMatchResponse `json:"resources"`
+}
+
+type ResourceMatchResponse struct {
+	Checksum    ResourceMatchChecksum `json:"checksum"`
+	SizeInBytes int64                 `json:"size_in_bytes"`
+	Path        string                `json:"path"`
+	Mode        string                `json:"mode"`
+}
+
+type ResourceMatchChecksum struct {
+	Value string `json:"value"`
+}
+
+func ForResourceMatches(resources []resourcecache.Resource) ResourceMatchesResponse {
+	matches := make([]ResourceMatchResponse, 0, len(resources))
+	for _, r := range resources {
+		matches = append(matches, ResourceMatchResponse{
+			Checksum:    ResourceMatchChecksum{Value: r.SHA1},
+			SizeInBytes: r.Size,
+			Path:        r.Path,
+			Mode:        fmt.Sprintf("%o", r.Mode.Perm()),
+		})
+	}
+
+	return ResourceMatchesResponse{
+		Resources: matches,
+	}
+}
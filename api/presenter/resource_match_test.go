@@ -0,0 +1,55 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/resourcecache"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resource Matches", func() {
+	var (
+		resources []resourcecache.Resource
+		output    []byte
+	)
+
+	BeforeEach(func() {
+		resources = []resourcecache.Resource{{
+			SHA1: "8b4f1a0bde3e4ed2e57ce3b1d6b6f6e9b2d2a1f0",
+			Size: 42,
+			Path: "lib/app.js",
+			Mode: 0o755,
+		}}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForResourceMatches(resources))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"resources": [{
+				"checksum": {
+					"value": "8b4f1a0bde3e4ed2e57ce3b1d6b6f6e9b2d2a1f0"
+				},
+				"size_in_bytes": 42,
+				"path": "lib/app.js",
+				"mode": "755"
+			}]
+		}`))
+	})
+
+	When("nothing matches", func() {
+		BeforeEach(func() {
+			resources = nil
+		})
+
+		It("returns an empty list", func() {
+			Expect(output).To(MatchJSON(`{"resources": []}`))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	revisionsBase = "/v3/revisions"
+)
+
+type RevisionResponse struct {
+	GUID          string                       `json:"guid"`
+	Version       int64                        `json:"version"`
+	Droplet       DropletGUID                  `json:"droplet"`
+	Processes     map[string]RevisionProcess   `json:"processes"`
+	Sidecars      []RevisionSidecar            `json:"sidecars"`
+	Description   string                       `json:"description"`
+	Deployed      bool                         `json:"deployed"`
+	Relationships map[string]ToOneRelationship `json:"relationships"`
+	Metadata      Metadata                     `json:"metadata"`
+	CreatedAt     string                       `json:"created_at"`
+	UpdatedAt     string                       `json:"updated_at"`
+	Links         RevisionLinks                `json:"links"`
+}
+
+type RevisionProcess struct {
+	Command *string `json:"command"`
+}
+
+type RevisionSidecar struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryMB     *int64   `json:"memory_in_mb"`
+}
+
+type RevisionLinks struct {
+	Self                 Link `json:"self"`
+	App                  Link `json:"app"`
+	EnvironmentVariables Link `json:"environment_variables"`
+}
+
+func ForRevision(record repositories.RevisionRecord, baseURL url.URL, includes ...include.Resource) RevisionResponse {
+	processes := map[string]RevisionProcess{}
+	for processType, command := range record.Processes {
+		process := RevisionProcess{}
+		if command != "" {
+			process.Command = tools.PtrTo(command)
+		}
+		processes[processType] = process
+	}
+
+	sidecars := []RevisionSidecar{}
+	for _, sidecar := range record.Sidecars {
+		revisionSidecar := RevisionSidecar{
+			Name:         sidecar.Name,
+			Command:      sidecar.Command,
+			ProcessTypes: sidecar.ProcessTypes,
+		}
+		if sidecar.MemoryMB > 0 {
+			revisionSidecar.MemoryMB = tools.PtrTo(sidecar.MemoryMB)
+		}
+		sidecars = append(sidecars, revisionSidecar)
+	}
+
+	return RevisionResponse{
+		GUID:          record.GUID,
+		Version:       record.Version,
+		Droplet:       DropletGUID{Guid: record.DropletGUID},
+		Processes:     processes,
+		Sidecars:      sidecars,
+		Description:   record.Description,
+		Deployed:      record.Deployed,
+		Relationships: ForRelationships(record.Relationships()),
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+		Links: RevisionLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.GUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
+			},
+			EnvironmentVariables: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.GUID, "environment_variables").build(),
+			},
+		},
+	}
+}
+
+type RevisionEnvVarsResponse struct {
+	Var   map[string]string    `json:"var"`
+	Links RevisionEnvVarsLinks `json:"links"`
+}
+
+type RevisionEnvVarsLinks struct {
+	Self     Link `json:"self"`
+	Revision Link `json:"revision"`
+}
+
+func ForRevisionEnvVars(record repositories.RevisionEnvVarsRecord, baseURL url.URL) RevisionEnvVarsResponse {
+	return RevisionEnvVarsResponse{
+		Var: record.EnvironmentVariables,
+		Links: RevisionEnvVarsLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID, "environment_variables").build(),
+			},
+			Revision: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID).build(),
+			},
+		},
+	}
+}
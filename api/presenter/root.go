@@ -12,7 +12,9 @@ type APILink struct {
 }
 
 type APILinkMeta struct {
-	Version string `json:"version"`
+	Version            string `json:"version"`
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
+	OAuthClient        string `json:"oauth_client,omitempty"`
 }
 
 type RootResponse struct {
@@ -20,9 +22,12 @@ type RootResponse struct {
 	CFOnK8s bool                `json:"cf_on_k8s"`
 }
 
-const V3APIVersion = "3.117.0+cf-k8s"
+const (
+	V3APIVersion   = "3.117.0+cf-k8s"
+	SSHOAuthClient = "ssh-proxy"
+)
 
-func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootResponse {
+func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL, sshConfig config.SSH, sshHostKeyFingerprint string) RootResponse {
 	rootResponse := RootResponse{
 		Links: map[string]*APILink{
 			"self": {
@@ -76,6 +81,18 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootRes
 		}
 	}
 
+	if sshConfig.Enabled {
+		rootResponse.Links["app_ssh"] = &APILink{
+			Link: Link{
+				HRef: sshConfig.ExternalAddress,
+			},
+			Meta: APILinkMeta{
+				HostKeyFingerprint: sshHostKeyFingerprint,
+				OAuthClient:        SSHOAuthClient,
+			},
+		}
+	}
+
 	return rootResponse
 }
 
@@ -27,14 +27,18 @@ var _ = Describe("Root endpoints", func() {
 	})
 
 	Context("/", func() {
-		var uaaConfig config.UAA
+		var (
+			uaaConfig config.UAA
+			sshConfig config.SSH
+		)
 
 		BeforeEach(func() {
 			uaaConfig = config.UAA{}
+			sshConfig = config.SSH{}
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL)
+			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL, sshConfig, "the-fingerprint")
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -137,6 +141,28 @@ var _ = Describe("Root endpoints", func() {
 			}`))
 			})
 		})
+
+		When("SSH support is enabled", func() {
+			BeforeEach(func() {
+				sshConfig = config.SSH{
+					Enabled:         true,
+					ExternalAddress: "ssh.example.org:2222",
+				}
+			})
+
+			It("produces the app_ssh link", func() {
+				var rootResponse map[string]any
+				Expect(json.Unmarshal(output, &rootResponse)).To(Succeed())
+				Expect(rootResponse).To(HaveKeyWithValue("links", HaveKeyWithValue("app_ssh", Equal(map[string]any{
+					"href": "ssh.example.org:2222",
+					"meta": map[string]any{
+						"version":              "",
+						"host_key_fingerprint": "the-fingerprint",
+						"oauth_client":         "ssh-proxy",
+					},
+				}))))
+			})
+		})
 	})
 
 	Context("/v3", func() {
@@ -16,7 +16,7 @@ const (
 type RouteResponse struct {
 	GUID         string             `json:"guid"`
 	Protocol     string             `json:"protocol"`
-	Port         *int               `json:"port"`
+	Port         *int32             `json:"port"`
 	Host         string             `json:"host"`
 	Path         string             `json:"path"`
 	URL          string             `json:"url"`
@@ -37,7 +37,7 @@ type RouteDestinationsResponse struct {
 type routeDestination struct {
 	GUID     string              `json:"guid"`
 	App      routeDestinationApp `json:"app"`
-	Weight   *int                `json:"weight"`
+	Weight   *int32              `json:"weight"`
 	Port     *int32              `json:"port"`
 	Protocol *string             `json:"protocol"`
 }
@@ -71,6 +71,7 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL, includes ...inclu
 	return RouteResponse{
 		GUID:          route.GUID,
 		Protocol:      route.Protocol,
+		Port:          route.Port,
 		Host:          route.Host,
 		Path:          route.Path,
 		URL:           routeURL(route),
@@ -108,7 +109,7 @@ func forDestination(destination repositories.DestinationRecord) routeDestination
 				Type: destination.ProcessType,
 			},
 		},
-		Weight:   nil,
+		Weight:   destination.Weight,
 		Port:     destination.Port,
 		Protocol: destination.Protocol,
 	}
@@ -133,6 +134,10 @@ func ForRouteDestinations(route repositories.RouteRecord, baseURL url.URL) Route
 }
 
 func routeURL(route repositories.RouteRecord) string {
+	if route.Port != nil {
+		return fmt.Sprintf("%s:%d", route.Domain.Name, *route.Port)
+	}
+
 	if route.Host != "" {
 		return fmt.Sprintf("%s.%s%s", route.Host, route.Domain.Name, route.Path)
 	} else {
@@ -41,6 +41,7 @@ var _ = Describe("Route", func() {
 					ProcessType: "web",
 					Port:        tools.PtrTo[int32](1234),
 					Protocol:    tools.PtrTo("http1"),
+					Weight:      tools.PtrTo[int32](80),
 				},
 				{
 					GUID:        "dest-2-guid",
@@ -48,6 +49,7 @@ var _ = Describe("Route", func() {
 					ProcessType: "queue",
 					Port:        tools.PtrTo[int32](5678),
 					Protocol:    tools.PtrTo("http2"),
+					Weight:      tools.PtrTo[int32](20),
 				},
 			},
 			Labels:      nil,
@@ -84,7 +86,7 @@ var _ = Describe("Route", func() {
 								"type": "web"
 							}
 						},
-						"weight": null,
+						"weight": 80,
 						"port": 1234,
 						"protocol": "http1"
 					},
@@ -96,7 +98,7 @@ var _ = Describe("Route", func() {
 								"type": "queue"
 							}
 						},
-						"weight": null,
+						"weight": 20,
 						"port": 5678,
 						"protocol": "http2"
 					}
@@ -143,6 +145,21 @@ var _ = Describe("Route", func() {
 				Expect(output).To(MatchJSONPath("$.url", "example.org/some_path"))
 			})
 		})
+
+		When("the route is a tcp route", func() {
+			BeforeEach(func() {
+				record.Host = ""
+				record.Path = ""
+				record.Protocol = "tcp"
+				record.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("presents the port", func() {
+				Expect(output).To(MatchJSONPath("$.protocol", "tcp"))
+				Expect(output).To(MatchJSONPath("$.port", BeEquivalentTo(1024)))
+				Expect(output).To(MatchJSONPath("$.url", "example.org:1024"))
+			})
+		})
 	})
 
 	Describe("destinations", func() {
@@ -164,7 +181,7 @@ var _ = Describe("Route", func() {
 								"type": "web"
 							}
 						},
-						"weight": null,
+						"weight": 80,
 						"port": 1234,
 						"protocol": "http1"
 					},
@@ -176,7 +193,7 @@ var _ = Describe("Route", func() {
 								"type": "queue"
 							}
 						},
-						"weight": null,
+						"weight": 20,
 						"port": 5678,
 						"protocol": "http2"
 					}
@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+)
+
+const (
+	routerGroupsBase = "/v3/router_groups"
+)
+
+type RouterGroupResponse struct {
+	GUID            string           `json:"guid"`
+	Name            string           `json:"name"`
+	Type            string           `json:"type"`
+	ReservablePorts string           `json:"reservable_ports"`
+	Links           RouterGroupLinks `json:"links"`
+}
+
+type RouterGroupLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForRouterGroup(routerGroupRecord repositories.RouterGroupRecord, baseURL url.URL, includes ...include.Resource) RouterGroupResponse {
+	ports := make([]string, 0, len(routerGroupRecord.ReservablePorts))
+	for _, port := range routerGroupRecord.ReservablePorts {
+		ports = append(ports, strconv.Itoa(int(port)))
+	}
+
+	return RouterGroupResponse{
+		GUID:            routerGroupRecord.GUID,
+		Name:            routerGroupRecord.Name,
+		Type:            routerGroupRecord.Type,
+		ReservablePorts: strings.Join(ports, ","),
+		Links: RouterGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(routerGroupsBase, routerGroupRecord.GUID).build(),
+			},
+		},
+	}
+}
@@ -61,3 +61,24 @@ func toManyRelationshipData(guids []string) []payloads.RelationshipData {
 		return payloads.RelationshipData{GUID: guid}
 	}))
 }
+
+type SecurityGroupSpacesResponse struct {
+	Data  []payloads.RelationshipData `json:"data"`
+	Links SecurityGroupLinks          `json:"links"`
+}
+
+func ForSecurityGroupSpaces(securityGroupRecord repositories.SecurityGroupRecord, workloadType string, baseURL url.URL) SecurityGroupSpacesResponse {
+	spaces := securityGroupRecord.RunningSpaces
+	if workloadType == repositories.SecurityGroupStagingSpaces {
+		spaces = securityGroupRecord.StagingSpaces
+	}
+
+	return SecurityGroupSpacesResponse{
+		Data: toManyRelationshipData(spaces),
+		Links: SecurityGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(securityGroupBase, securityGroupRecord.GUID, "relationships", workloadType+"_spaces").build(),
+			},
+		},
+	}
+}
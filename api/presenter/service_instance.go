@@ -3,6 +3,7 @@ package presenter
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/tools"
@@ -46,14 +47,16 @@ type ServiceInstanceLinks struct {
 	Credentials               Link `json:"credentials"`
 	ServiceCredentialBindings Link `json:"service_credential_bindings"`
 	ServiceRouteBindings      Link `json:"service_route_bindings"`
+	SharedSpaces              Link `json:"shared_spaces"`
 }
 
 func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL, includes ...include.Resource) ServiceInstanceResponse {
 	response := ServiceInstanceResponse{
-		Name: serviceInstanceRecord.Name,
-		GUID: serviceInstanceRecord.GUID,
-		Type: serviceInstanceRecord.Type,
-		Tags: emptySliceIfNil(serviceInstanceRecord.Tags),
+		Name:            serviceInstanceRecord.Name,
+		GUID:            serviceInstanceRecord.GUID,
+		Type:            serviceInstanceRecord.Type,
+		Tags:            emptySliceIfNil(serviceInstanceRecord.Tags),
+		RouteServiceURL: serviceInstanceRecord.RouteServiceURL,
 		LastOperation: lastOperation{
 			CreatedAt:   tools.ZeroIfNil(formatTimestamp(&serviceInstanceRecord.CreatedAt)),
 			UpdatedAt:   tools.ZeroIfNil(formatTimestamp(serviceInstanceRecord.UpdatedAt)),
@@ -84,6 +87,9 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 			ServiceRouteBindings: Link{
 				HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase).setQuery("service_instance_guids=" + serviceInstanceRecord.GUID).build(),
 			},
+			SharedSpaces: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
+			},
 		},
 		Included: includedResources(includes...),
 	}
@@ -95,3 +101,64 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 
 	return response
 }
+
+type ServiceInstanceSharedSpacesResponse struct {
+	Data  []payloads.RelationshipData      `json:"data"`
+	Links ServiceInstanceSharedSpacesLinks `json:"links"`
+}
+
+type ServiceInstanceSharedSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForServiceInstanceSharedSpaces(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceSharedSpacesResponse {
+	return ServiceInstanceSharedSpacesResponse{
+		Data: toManyRelationshipData(serviceInstanceRecord.SharedSpaceGUIDs),
+		Links: ServiceInstanceSharedSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
+			},
+		},
+	}
+}
+
+type ServiceInstanceSharedSpacesUsageSummaryResponse struct {
+	UsageSummary []SharedSpaceUsageSummary                    `json:"usage_summary"`
+	Links        ServiceInstanceSharedSpacesUsageSummaryLinks `json:"links"`
+}
+
+type SharedSpaceUsageSummary struct {
+	Space         payloads.RelationshipData `json:"space"`
+	BoundAppCount int                       `json:"bound_app_count"`
+}
+
+type ServiceInstanceSharedSpacesUsageSummaryLinks struct {
+	Self            Link `json:"self"`
+	SharedSpaces    Link `json:"shared_spaces"`
+	ServiceInstance Link `json:"service_instance"`
+}
+
+func ForServiceInstanceSharedSpacesUsageSummary(serviceInstanceGUID string, usageSummary []repositories.SharedSpaceUsageSummaryRecord, baseURL url.URL) ServiceInstanceSharedSpacesUsageSummaryResponse {
+	spacesUsageSummary := []SharedSpaceUsageSummary{}
+	for _, spaceUsageSummary := range usageSummary {
+		spacesUsageSummary = append(spacesUsageSummary, SharedSpaceUsageSummary{
+			Space:         payloads.RelationshipData{GUID: spaceUsageSummary.SpaceGUID},
+			BoundAppCount: spaceUsageSummary.BoundAppCount,
+		})
+	}
+
+	return ServiceInstanceSharedSpacesUsageSummaryResponse{
+		UsageSummary: spacesUsageSummary,
+		Links: ServiceInstanceSharedSpacesUsageSummaryLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID, "relationships", "shared_spaces", "usage_summary").build(),
+			},
+			SharedSpaces: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID, "relationships", "shared_spaces").build(),
+			},
+			ServiceInstance: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID).build(),
+			},
+		},
+	}
+}
@@ -72,6 +72,9 @@ var _ = Describe("Service Instance", func() {
 				"service_route_bindings": {
 					"href": "https://api.example.org/v3/service_route_bindings?service_instance_guids=service-instance-guid"
 				},
+				"shared_spaces": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+				},
 				"space": {
 					"href": "https://api.example.org/v3/spaces/space-guid"
 				}
@@ -129,6 +132,16 @@ var _ = Describe("Service Instance", func() {
 		})
 	})
 
+	When("the service instance has a route service url", func() {
+		BeforeEach(func() {
+			record.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+		})
+
+		It("returns the route service url", func() {
+			Expect(output).To(MatchJSONPath("$.route_service_url", Equal("https://route-service.example.com")))
+		})
+	})
+
 	When("the service instance is managed", func() {
 		BeforeEach(func() {
 			record.Type = "managed"
@@ -147,3 +160,73 @@ var _ = Describe("Service Instance", func() {
 		})
 	})
 })
+
+var _ = Describe("Service Instance Shared Spaces", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForServiceInstanceSharedSpaces", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForServiceInstanceSharedSpaces(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SharedSpaceGUIDs: []string{"space-1", "space-2"},
+			}, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "space-1" },
+					{ "guid": "space-2" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForServiceInstanceSharedSpacesUsageSummary", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForServiceInstanceSharedSpacesUsageSummary("service-instance-guid", []repositories.SharedSpaceUsageSummaryRecord{
+				{SpaceGUID: "space-1", BoundAppCount: 2},
+			}, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"usage_summary": [
+					{
+						"space": { "guid": "space-1" },
+						"bound_app_count": 2
+					}
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces/usage_summary"
+					},
+					"shared_spaces": {
+						"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+					},
+					"service_instance": {
+						"href": "https://api.example.org/v3/service_instances/service-instance-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -29,6 +29,8 @@ type ServiceOfferingResponse struct {
 	Tags             []string                     `json:"tags,omitempty"`
 	Requires         []string                     `json:"requires,omitempty"`
 	DocumentationURL *string                      `json:"documentation_url"`
+	Shareable        bool                         `json:"shareable"`
+	Available        bool                         `json:"available"`
 	BrokerCatalog    ServiceBrokerCatalog         `json:"broker_catalog"`
 	Relationships    ServiceOfferingRelationships `json:"relationships"`
 	Links            ServiceOfferingLinks         `json:"links"`
@@ -67,6 +69,8 @@ func ForServiceOffering(serviceOffering repositories.ServiceOfferingRecord, base
 		Tags:             serviceOffering.Tags,
 		Requires:         serviceOffering.Requires,
 		DocumentationURL: serviceOffering.DocumentationURL,
+		Shareable:        serviceOffering.Shareable,
+		Available:        serviceOffering.Available,
 		BrokerCatalog: ServiceBrokerCatalog{
 			ID:       serviceOffering.BrokerCatalog.ID,
 			Metadata: serviceOffering.BrokerCatalog.Metadata,
@@ -29,6 +29,8 @@ var _ = Describe("Service Offering", func() {
 			Tags:             []string{"t1"},
 			Requires:         []string{"r1"},
 			DocumentationURL: tools.PtrTo("https://doc.url"),
+			Shareable:        true,
+			Available:        true,
 			BrokerCatalog: repositories.ServiceBrokerCatalog{
 				ID: "catalog-id",
 				Metadata: map[string]any{
@@ -75,6 +77,8 @@ var _ = Describe("Service Offering", func() {
 			  "r1"
 			],
 			"documentation_url": "https://doc.url",
+			"shareable": true,
+			"available": true,
 			"broker_catalog": {
 			  "id": "catalog-id",
 			  "metadata": {
@@ -120,6 +120,7 @@ func ForServicePlan(servicePlan repositories.ServicePlanRecord, baseURL url.URL,
 type ServicePlanVisibilityResponse struct {
 	Type          string                   `json:"type"`
 	Organizations []VisibilityOrganization `json:"organizations,omitempty"`
+	Space         *VisibilitySpace         `json:"space,omitempty"`
 }
 
 type VisibilityOrganization struct {
@@ -127,7 +128,16 @@ type VisibilityOrganization struct {
 	Name string `json:"name"`
 }
 
+type VisibilitySpace struct {
+	GUID string `json:"guid"`
+}
+
 func ForServicePlanVisibility(plan repositories.ServicePlanRecord, _ url.URL) ServicePlanVisibilityResponse {
+	var space *VisibilitySpace
+	if plan.Visibility.SpaceGUID != "" {
+		space = &VisibilitySpace{GUID: plan.Visibility.SpaceGUID}
+	}
+
 	return ServicePlanVisibilityResponse{
 		Type: plan.Visibility.Type,
 		Organizations: slices.Collect(
@@ -137,5 +147,6 @@ func ForServicePlanVisibility(plan repositories.ServicePlanRecord, _ url.URL) Se
 				},
 			),
 		),
+		Space: space,
 	}
 }
@@ -199,5 +199,23 @@ var _ = Describe("Service Plan", func() {
 				]
 			}`))
 		})
+
+		When("the plan is space-scoped", func() {
+			BeforeEach(func() {
+				record.Visibility = repositories.PlanVisibility{
+					Type:      "space",
+					SpaceGUID: "space-guid",
+				}
+			})
+
+			It("returns the space", func() {
+				Expect(output).To(MatchJSON(`{
+					"type": "space",
+					"space": {
+						"guid": "space-guid"
+					}
+				}`))
+			})
+		})
 	})
 })
@@ -3,11 +3,65 @@ package presenter
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
 )
 
-type ServiceRouteBinding struct{}
+type ServiceRouteBindingResponse struct {
+	GUID            string                              `json:"guid"`
+	RouteServiceURL *string                             `json:"route_service_url"`
+	CreatedAt       string                              `json:"created_at"`
+	UpdatedAt       string                              `json:"updated_at"`
+	LastOperation   ServiceBindingLastOperationResponse `json:"last_operation"`
+	Relationships   map[string]ToOneRelationship        `json:"relationships"`
+	Links           ServiceRouteBindingLinks            `json:"links"`
+	Metadata        Metadata                            `json:"metadata"`
+}
+
+type ServiceRouteBindingLinks struct {
+	Self            Link `json:"self"`
+	ServiceInstance Link `json:"service_instance"`
+	Route           Link `json:"route"`
+}
+
+func ForServiceRouteBinding(record repositories.ServiceRouteBindingRecord, baseURL url.URL, includes ...include.Resource) ServiceRouteBindingResponse {
+	var routeServiceURL *string
+	if record.RouteServiceURL != "" {
+		routeServiceURL = tools.PtrTo(record.RouteServiceURL)
+	}
+
+	return ServiceRouteBindingResponse{
+		GUID:            record.GUID,
+		RouteServiceURL: routeServiceURL,
+		CreatedAt:       tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt:       tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+		LastOperation: ServiceBindingLastOperationResponse{
+			Type:        record.LastOperation.Type,
+			State:       record.LastOperation.State,
+			Description: record.LastOperation.Description,
+			CreatedAt:   tools.ZeroIfNil(formatTimestamp(&record.LastOperation.CreatedAt)),
+			UpdatedAt:   tools.ZeroIfNil(formatTimestamp(record.LastOperation.UpdatedAt)),
+		},
+		Relationships: ForRelationships(record.Relationships()),
+		Links: ServiceRouteBindingLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase, record.GUID).build(),
+			},
+			ServiceInstance: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, record.ServiceInstanceGUID).build(),
+			},
+			Route: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, record.RouteGUID).build(),
+			},
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+	}
+}
 
-func ForServiceRouteBindingsList(baseURL, requestURL url.URL) ListResponse[any] {
-	return ForList(func(a any, _ url.URL, includes ...include.Resource) any { return a }, []any{}, baseURL, requestURL)
+func ForServiceRouteBindingList(records []repositories.ServiceRouteBindingRecord, baseURL, requestURL url.URL) ListResponse[ServiceRouteBindingResponse] {
+	return ForList(ForServiceRouteBinding, records, baseURL, requestURL)
 }
@@ -0,0 +1,40 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+type SidecarResponse struct {
+	GUID          string                       `json:"guid"`
+	Name          string                       `json:"name"`
+	Command       string                       `json:"command"`
+	ProcessTypes  []string                     `json:"process_types"`
+	MemoryMB      *int64                       `json:"memory_in_mb"`
+	Origin        string                       `json:"origin"`
+	Relationships map[string]ToOneRelationship `json:"relationships"`
+	CreatedAt     string                       `json:"created_at"`
+	UpdatedAt     string                       `json:"updated_at"`
+}
+
+func ForSidecar(record repositories.SidecarRecord, _ url.URL, _ ...include.Resource) SidecarResponse {
+	var memoryMB *int64
+	if record.MemoryMB > 0 {
+		memoryMB = tools.PtrTo(record.MemoryMB)
+	}
+
+	return SidecarResponse{
+		GUID:          record.GUID,
+		Name:          record.Name,
+		Command:       record.Command,
+		ProcessTypes:  record.ProcessTypes,
+		MemoryMB:      memoryMB,
+		Origin:        record.Origin,
+		Relationships: ForRelationships(record.Relationships()),
+		CreatedAt:     tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt:     tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+	}
+}
@@ -0,0 +1,82 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const spaceQuotasBase = "/v3/space_quotas"
+
+type SpaceQuotaResponse struct {
+	GUID          string                  `json:"guid"`
+	CreatedAt     string                  `json:"created_at"`
+	UpdatedAt     string                  `json:"updated_at"`
+	Name          string                  `json:"name"`
+	Apps          QuotaAppsResponse       `json:"apps"`
+	Services      QuotaServicesResponse   `json:"services"`
+	Routes        QuotaRoutesResponse     `json:"routes"`
+	Relationships SpaceQuotaRelationships `json:"relationships"`
+	Links         SpaceQuotaLinks         `json:"links"`
+}
+
+type SpaceQuotaRelationships struct {
+	Organization payloads.Relationship       `json:"organization"`
+	Spaces       payloads.ToManyRelationship `json:"spaces"`
+}
+
+type SpaceQuotaLinks struct {
+	Self         Link `json:"self"`
+	Organization Link `json:"organization"`
+}
+
+func ForSpaceQuota(spaceQuotaRecord repositories.SpaceQuotaRecord, baseURL url.URL, includes ...include.Resource) SpaceQuotaResponse {
+	return SpaceQuotaResponse{
+		GUID:      spaceQuotaRecord.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&spaceQuotaRecord.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(spaceQuotaRecord.UpdatedAt)),
+		Name:      spaceQuotaRecord.Name,
+		Apps:      forQuotaApps(spaceQuotaRecord.Limits),
+		Services:  forQuotaServices(spaceQuotaRecord.Limits),
+		Routes:    forQuotaRoutes(spaceQuotaRecord.Limits),
+		Relationships: SpaceQuotaRelationships{
+			Organization: payloads.Relationship{
+				Data: &payloads.RelationshipData{GUID: spaceQuotaRecord.OrgGUID},
+			},
+			Spaces: payloads.ToManyRelationship{
+				Data: toManyRelationshipData(spaceQuotaRecord.SpaceGUIDs),
+			},
+		},
+		Links: SpaceQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, spaceQuotaRecord.GUID).build(),
+			},
+			Organization: Link{
+				HRef: buildURL(baseURL).appendPath(orgsBase, spaceQuotaRecord.OrgGUID).build(),
+			},
+		},
+	}
+}
+
+type SpaceQuotaSpacesResponse struct {
+	Data  []payloads.RelationshipData `json:"data"`
+	Links SpaceQuotaSpacesLinks       `json:"links"`
+}
+
+type SpaceQuotaSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForSpaceQuotaSpaces(spaceQuotaRecord repositories.SpaceQuotaRecord, baseURL url.URL) SpaceQuotaSpacesResponse {
+	return SpaceQuotaSpacesResponse{
+		Data: toManyRelationshipData(spaceQuotaRecord.SpaceGUIDs),
+		Links: SpaceQuotaSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, spaceQuotaRecord.GUID, "relationships", "spaces").build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceQuota", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.SpaceQuotaRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.SpaceQuotaRecord{
+			GUID:      "quota-guid",
+			Name:      "my-quota",
+			OrgGUID:   "org-guid",
+			CreatedAt: time.UnixMilli(1000).UTC(),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000).UTC()),
+			Limits: repositories.QuotaLimits{
+				Apps: repositories.AppQuotaLimits{
+					PerProcessMemoryInMB: tools.PtrTo[int64](512),
+					PerAppTasks:          tools.PtrTo[int64](2),
+				},
+			},
+			SpaceGUIDs: []string{"space-1"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForSpaceQuota(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "quota-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"name": "my-quota",
+			"apps": {
+				"total_memory_in_mb": null,
+				"per_process_memory_in_mb": 512,
+				"total_instances": null,
+				"per_app_tasks": 2,
+				"log_rate_limit_in_bytes_per_second": null
+			},
+			"services": {
+				"paid_services_allowed": true,
+				"total_service_instances": null,
+				"total_service_keys": null
+			},
+			"routes": {
+				"total_routes": null,
+				"total_reserved_ports": null
+			},
+			"relationships": {
+				"organization": {
+					"data": {"guid": "org-guid"}
+				},
+				"spaces": {
+					"data": [{"guid": "space-1"}]
+				}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/space_quotas/quota-guid"
+				},
+				"organization": {
+					"href": "https://api.example.org/v3/organizations/org-guid"
+				}
+			}
+		}`))
+	})
+
+	Describe("ForSpaceQuotaSpaces", func() {
+		It("returns the expected JSON", func() {
+			output, err := json.Marshal(presenter.ForSpaceQuotaSpaces(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "space-1"}],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/space_quotas/quota-guid/relationships/spaces"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	appUsageEventsBase     = "/v3/app_usage_events"
+	serviceUsageEventsBase = "/v3/service_usage_events"
+)
+
+type AppUsageEventResponse struct {
+	GUID                  string                       `json:"guid"`
+	CreatedAt             string                       `json:"created_at"`
+	UpdatedAt             string                       `json:"updated_at"`
+	State                 UsageEventTransition[string] `json:"state"`
+	App                   UsageEventResourceResponse   `json:"app"`
+	Process               UsageEventProcessResponse    `json:"process"`
+	Space                 UsageEventResourceResponse   `json:"space"`
+	Organization          UsageEventResourceResponse   `json:"organization"`
+	MemoryInMBPerInstance UsageEventTransition[int64]  `json:"memory_in_mb_per_instance"`
+	InstanceCount         UsageEventTransition[int32]  `json:"instance_count"`
+	Links                 UsageEventLinks              `json:"links"`
+}
+
+type ServiceUsageEventResponse struct {
+	GUID            string                            `json:"guid"`
+	CreatedAt       string                            `json:"created_at"`
+	UpdatedAt       string                            `json:"updated_at"`
+	State           string                            `json:"state"`
+	ServiceInstance UsageEventServiceInstanceResponse `json:"service_instance"`
+	ServicePlan     *UsageEventResourceResponse       `json:"service_plan"`
+	ServiceOffering *UsageEventResourceResponse       `json:"service_offering"`
+	ServiceBroker   *UsageEventResourceResponse       `json:"service_broker"`
+	Space           UsageEventResourceResponse        `json:"space"`
+	Organization    UsageEventResourceResponse        `json:"organization"`
+	Links           UsageEventLinks                   `json:"links"`
+}
+
+type UsageEventTransition[T any] struct {
+	Current  T `json:"current"`
+	Previous T `json:"previous"`
+}
+
+type UsageEventResourceResponse struct {
+	GUID string `json:"guid"`
+	Name string `json:"name,omitempty"`
+}
+
+type UsageEventProcessResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+type UsageEventServiceInstanceResponse struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type UsageEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAppUsageEvent(record repositories.AppUsageEventRecord, baseURL url.URL, includes ...include.Resource) AppUsageEventResponse {
+	return AppUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+		State: UsageEventTransition[string]{
+			Current:  record.State,
+			Previous: record.PreviousState,
+		},
+		App: toUsageEventResourceResponse(record.App),
+		Process: UsageEventProcessResponse{
+			GUID: record.ProcessGUID,
+			Type: record.ProcessType,
+		},
+		Space:        toUsageEventResourceResponse(record.Space),
+		Organization: toUsageEventResourceResponse(record.Organization),
+		MemoryInMBPerInstance: UsageEventTransition[int64]{
+			Current:  record.MemoryInMBPerInstance,
+			Previous: record.PreviousMemoryInMBPerInstance,
+		},
+		InstanceCount: UsageEventTransition[int32]{
+			Current:  record.InstanceCount,
+			Previous: record.PreviousInstanceCount,
+		},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(appUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForServiceUsageEvent(record repositories.ServiceUsageEventRecord, baseURL url.URL, includes ...include.Resource) ServiceUsageEventResponse {
+	return ServiceUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+		State:     record.State,
+		ServiceInstance: UsageEventServiceInstanceResponse{
+			GUID: record.ServiceInstance.GUID,
+			Name: record.ServiceInstance.Name,
+			Type: toServiceInstanceUsageType(record.ServiceInstanceType),
+		},
+		ServicePlan:     toOptionalUsageEventResourceResponse(record.ServicePlan),
+		ServiceOffering: toOptionalUsageEventResourceResponse(record.ServiceOffering),
+		ServiceBroker:   toOptionalUsageEventResourceResponse(record.ServiceBroker),
+		Space:           toUsageEventResourceResponse(record.Space),
+		Organization:    toUsageEventResourceResponse(record.Organization),
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func toUsageEventResourceResponse(resource repositories.UsageEventResource) UsageEventResourceResponse {
+	return UsageEventResourceResponse{
+		GUID: resource.GUID,
+		Name: resource.Name,
+	}
+}
+
+func toOptionalUsageEventResourceResponse(resource *repositories.UsageEventResource) *UsageEventResourceResponse {
+	if resource == nil {
+		return nil
+	}
+
+	return tools.PtrTo(toUsageEventResourceResponse(*resource))
+}
+
+func toServiceInstanceUsageType(instanceType string) string {
+	if instanceType == korifiv1alpha1.ManagedType {
+		return "managed_service_instance"
+	}
+
+	return "user_provided_service_instance"
+}
@@ -0,0 +1,181 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEvent", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.AppUsageEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.AppUsageEventRecord{
+			GUID:                          "app-usage-event-guid",
+			CreatedAt:                     time.UnixMilli(1000).UTC(),
+			UpdatedAt:                     tools.PtrTo(time.UnixMilli(2000).UTC()),
+			State:                         "SCALED",
+			PreviousState:                 "STARTED",
+			App:                           repositories.UsageEventResource{GUID: "app-guid", Name: "my-app"},
+			ProcessGUID:                   "process-guid",
+			ProcessType:                   "web",
+			Space:                         repositories.UsageEventResource{GUID: "space-guid", Name: "my-space"},
+			Organization:                  repositories.UsageEventResource{GUID: "org-guid", Name: "my-org"},
+			MemoryInMBPerInstance:         512,
+			PreviousMemoryInMBPerInstance: 256,
+			InstanceCount:                 3,
+			PreviousInstanceCount:         1,
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForAppUsageEvent(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "app-usage-event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"state": {
+				"current": "SCALED",
+				"previous": "STARTED"
+			},
+			"app": {
+				"guid": "app-guid",
+				"name": "my-app"
+			},
+			"process": {
+				"guid": "process-guid",
+				"type": "web"
+			},
+			"space": {
+				"guid": "space-guid",
+				"name": "my-space"
+			},
+			"organization": {
+				"guid": "org-guid",
+				"name": "my-org"
+			},
+			"memory_in_mb_per_instance": {
+				"current": 512,
+				"previous": 256
+			},
+			"instance_count": {
+				"current": 3,
+				"previous": 1
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/app_usage_events/app-usage-event-guid"
+				}
+			}
+		}`))
+	})
+})
+
+var _ = Describe("ServiceUsageEvent", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceUsageEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceUsageEventRecord{
+			GUID:                "service-usage-event-guid",
+			CreatedAt:           time.UnixMilli(1000).UTC(),
+			UpdatedAt:           tools.PtrTo(time.UnixMilli(2000).UTC()),
+			State:               "CREATED",
+			ServiceInstance:     repositories.UsageEventResource{GUID: "si-guid", Name: "my-si"},
+			ServiceInstanceType: "managed",
+			ServicePlan:         &repositories.UsageEventResource{GUID: "plan-guid", Name: "my-plan"},
+			ServiceOffering:     &repositories.UsageEventResource{GUID: "offering-guid", Name: "my-offering"},
+			ServiceBroker:       &repositories.UsageEventResource{GUID: "broker-guid", Name: "my-broker"},
+			Space:               repositories.UsageEventResource{GUID: "space-guid", Name: "my-space"},
+			Organization:        repositories.UsageEventResource{GUID: "org-guid", Name: "my-org"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForServiceUsageEvent(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "service-usage-event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"state": "CREATED",
+			"service_instance": {
+				"guid": "si-guid",
+				"name": "my-si",
+				"type": "managed_service_instance"
+			},
+			"service_plan": {
+				"guid": "plan-guid",
+				"name": "my-plan"
+			},
+			"service_offering": {
+				"guid": "offering-guid",
+				"name": "my-offering"
+			},
+			"service_broker": {
+				"guid": "broker-guid",
+				"name": "my-broker"
+			},
+			"space": {
+				"guid": "space-guid",
+				"name": "my-space"
+			},
+			"organization": {
+				"guid": "org-guid",
+				"name": "my-org"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_usage_events/service-usage-event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the service instance is user-provided", func() {
+		BeforeEach(func() {
+			record.ServiceInstanceType = "user-provided"
+			record.ServicePlan = nil
+			record.ServiceOffering = nil
+			record.ServiceBroker = nil
+		})
+
+		It("omits the plan details", func() {
+			Expect(output).To(MatchJSONPath("$.service_instance.type", "user_provided_service_instance"))
+			Expect(output).To(MatchJSONPath("$.service_plan", BeNil()))
+			Expect(output).To(MatchJSONPath("$.service_offering", BeNil()))
+			Expect(output).To(MatchJSONPath("$.service_broker", BeNil()))
+		})
+	})
+})
@@ -114,6 +114,7 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	EnableSSH             bool
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
@@ -186,6 +187,7 @@ type PatchAppMessage struct {
 	Name                 string
 	Lifecycle            *LifecyclePatch
 	EnvironmentVariables map[string]string
+	EnableSSH            *bool
 	MetadataPatch
 }
 
@@ -612,6 +614,10 @@ func (m *PatchAppMessage) Apply(app *korifiv1alpha1.CFApp) {
 		}
 	}
 
+	if m.EnableSSH != nil {
+		app.Spec.EnableSSH = m.EnableSSH
+	}
+
 	m.MetadataPatch.Apply(app)
 }
 
@@ -637,6 +643,7 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 		UpdatedAt:             getLastUpdatedTime(&cfApp),
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              cfApp.Spec.CurrentDropletRef.Name != "",
+		EnableSSH:             tools.ZeroIfNil(tools.IfNil(cfApp.Spec.EnableSSH, tools.PtrTo(true))),
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
@@ -565,6 +565,7 @@ var _ = Describe("AppRepository", func() {
 						Stack: "cflinuxfs3",
 					},
 				},
+				EnableSSH: tools.PtrTo(false),
 				MetadataPatch: repositories.MetadataPatch{
 					Labels:      map[string]*string{"l": tools.PtrTo("lv")},
 					Annotations: map[string]*string{"a": tools.PtrTo("av")},
@@ -605,6 +606,8 @@ var _ = Describe("AppRepository", func() {
 						Stack:      "cflinuxfs3",
 					},
 				}))
+				Expect(patchedAppRecord.EnableSSH).To(BeFalse())
+				Expect(cfApp.Spec.EnableSSH).To(PointTo(BeFalse()))
 				Expect(cfApp.Labels).To(HaveKeyWithValue("l", "lv"))
 				Expect(cfApp.Annotations).To(HaveKeyWithValue("a", "av"))
 			})
@@ -677,6 +680,17 @@ var _ = Describe("AppRepository", func() {
 						Expect(cfApp.Spec.Lifecycle.Data.Stack).To(Equal(originalCFApp.Spec.Lifecycle.Data.Stack))
 					})
 				})
+
+				When("enable ssh is not specified", func() {
+					BeforeEach(func() {
+						appPatchMessage.EnableSSH = nil
+					})
+
+					It("does not change the app ssh setting", func() {
+						Expect(patchedAppRecord.EnableSSH).To(BeTrue())
+						Expect(cfApp.Spec.EnableSSH).To(Equal(originalCFApp.Spec.EnableSSH))
+					})
+				})
 			})
 		})
 
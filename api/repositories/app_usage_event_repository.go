@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/status,verbs=patch
+
+const AppUsageEventResourceType = "App Usage Event"
+
+type UsageEventResource struct {
+	GUID string
+	Name string
+}
+
+type AppUsageEventRecord struct {
+	GUID                          string
+	CreatedAt                     time.Time
+	UpdatedAt                     *time.Time
+	State                         string
+	PreviousState                 string
+	App                           UsageEventResource
+	ProcessGUID                   string
+	ProcessType                   string
+	Space                         UsageEventResource
+	Organization                  UsageEventResource
+	MemoryInMBPerInstance         int64
+	PreviousMemoryInMBPerInstance int64
+	InstanceCount                 int32
+	PreviousInstanceCount         int32
+}
+
+type ListAppUsageEventsMessage struct {
+	GUIDs     []string
+	AfterGUID string
+	OrderBy   string
+}
+
+func (m *ListAppUsageEventsMessage) matches(cfAppUsageEvent korifiv1alpha1.CFAppUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, cfAppUsageEvent.Name) &&
+		isAfterGUID(cfAppUsageEvent.Name, m.AfterGUID)
+}
+
+// isAfterGUID relies on usage event guids being time ordered (see
+// CFAppUsageEvent)
+func isAfterGUID(guid, afterGUID string) bool {
+	return afterGUID == "" || strings.Compare(guid, afterGUID) > 0
+}
+
+//counterfeiter:generate -o fake -fake-name AppUsageEventSorter . AppUsageEventSorter
+type AppUsageEventSorter interface {
+	Sort(records []AppUsageEventRecord, order string) []AppUsageEventRecord
+}
+
+type appUsageEventSorter struct {
+	sorter *compare.Sorter[AppUsageEventRecord]
+}
+
+func NewAppUsageEventSorter() *appUsageEventSorter {
+	return &appUsageEventSorter{
+		sorter: compare.NewSorter(AppUsageEventComparator),
+	}
+}
+
+func (s *appUsageEventSorter) Sort(records []AppUsageEventRecord, order string) []AppUsageEventRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func AppUsageEventComparator(fieldName string) func(AppUsageEventRecord, AppUsageEventRecord) int {
+	return func(e1, e2 AppUsageEventRecord) int {
+		switch fieldName {
+		case "", "created_at":
+			// guids are time ordered and more precise than the creation
+			// timestamp
+			return strings.Compare(e1.GUID, e2.GUID)
+		case "updated_at":
+			return tools.CompareTimePtr(e1.UpdatedAt, e2.UpdatedAt)
+		}
+		return 0
+	}
+}
+
+type AppUsageEventRepo struct {
+	klient           Klient
+	privilegedClient client.Client
+	sorter           AppUsageEventSorter
+	rootNamespace    string
+}
+
+func NewAppUsageEventRepo(
+	klient Klient,
+	privilegedClient client.Client,
+	sorter AppUsageEventSorter,
+	rootNamespace string,
+) *AppUsageEventRepo {
+	return &AppUsageEventRepo{
+		klient:           klient,
+		privilegedClient: privilegedClient,
+		sorter:           sorter,
+		rootNamespace:    rootNamespace,
+	}
+}
+
+func (r *AppUsageEventRepo) GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (AppUsageEventRecord, error) {
+	cfAppUsageEvent := &korifiv1alpha1.CFAppUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfAppUsageEvent); err != nil {
+		return AppUsageEventRecord{}, fmt.Errorf("failed to get app usage event: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	return toAppUsageEventRecord(*cfAppUsageEvent), nil
+}
+
+func (r *AppUsageEventRepo) ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message ListAppUsageEventsMessage) ([]AppUsageEventRecord, error) {
+	cfAppUsageEventList := &korifiv1alpha1.CFAppUsageEventList{}
+	if err := r.klient.List(ctx, cfAppUsageEventList, InNamespace(r.rootNamespace)); err != nil {
+		return []AppUsageEventRecord{}, fmt.Errorf("failed to list app usage events: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	appUsageEventRecords := slices.Collect(it.Map(
+		itx.FromSlice(cfAppUsageEventList.Items).Filter(message.matches),
+		toAppUsageEventRecord,
+	))
+
+	return r.sorter.Sort(appUsageEventRecords, message.OrderBy), nil
+}
+
+// PurgeAndReseedAppUsageEvents deletes all app usage events and makes the
+// process controller emit a STARTED event for every process that is
+// currently started. Deleting the events with the user client ensures that
+// only admins can do that.
+func (r *AppUsageEventRepo) PurgeAndReseedAppUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	cfAppUsageEventList := &korifiv1alpha1.CFAppUsageEventList{}
+	if err := r.klient.List(ctx, cfAppUsageEventList, InNamespace(r.rootNamespace)); err != nil {
+		return fmt.Errorf("failed to list app usage events: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	for i := range cfAppUsageEventList.Items {
+		if err := r.klient.Delete(ctx, &cfAppUsageEventList.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete app usage event: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+		}
+	}
+
+	cfProcessList := &korifiv1alpha1.CFProcessList{}
+	if err := r.privilegedClient.List(ctx, cfProcessList); err != nil {
+		return fmt.Errorf("failed to list processes: %w", apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	for i := range cfProcessList.Items {
+		cfProcess := &cfProcessList.Items[i]
+		if cfProcess.Status.Usage == nil || cfProcess.Status.Usage.State != korifiv1alpha1.AppUsageStateStarted {
+			continue
+		}
+
+		original := cfProcess.DeepCopy()
+		cfProcess.Status.Usage = nil
+		if err := r.privilegedClient.Status().Patch(ctx, cfProcess, client.MergeFrom(original)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to reseed process usage: %w", apierrors.FromK8sError(err, ProcessResourceType))
+		}
+	}
+
+	return nil
+}
+
+func toAppUsageEventRecord(cfAppUsageEvent korifiv1alpha1.CFAppUsageEvent) AppUsageEventRecord {
+	return AppUsageEventRecord{
+		GUID:                          cfAppUsageEvent.Name,
+		CreatedAt:                     cfAppUsageEvent.CreationTimestamp.Time,
+		UpdatedAt:                     getLastUpdatedTime(&cfAppUsageEvent),
+		State:                         cfAppUsageEvent.Spec.State,
+		PreviousState:                 cfAppUsageEvent.Spec.PreviousState,
+		App:                           toUsageEventResource(cfAppUsageEvent.Spec.App),
+		ProcessGUID:                   cfAppUsageEvent.Spec.Process.GUID,
+		ProcessType:                   cfAppUsageEvent.Spec.Process.Type,
+		Space:                         toUsageEventResource(cfAppUsageEvent.Spec.Space),
+		Organization:                  toUsageEventResource(cfAppUsageEvent.Spec.Organization),
+		MemoryInMBPerInstance:         cfAppUsageEvent.Spec.MemoryInMBPerInstance,
+		PreviousMemoryInMBPerInstance: cfAppUsageEvent.Spec.PreviousMemoryInMBPerInstance,
+		InstanceCount:                 cfAppUsageEvent.Spec.InstanceCount,
+		PreviousInstanceCount:         cfAppUsageEvent.Spec.PreviousInstanceCount,
+	}
+}
+
+func toUsageEventResource(resource korifiv1alpha1.UsageEventResource) UsageEventResource {
+	return UsageEventResource{
+		GUID: resource.GUID,
+		Name: resource.Name,
+	}
+}
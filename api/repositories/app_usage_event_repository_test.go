@@ -0,0 +1,261 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppUsageEventRepository", func() {
+	var (
+		appUsageEventRepo *repositories.AppUsageEventRepo
+		sorter            *fake.AppUsageEventSorter
+	)
+
+	createAppUsageEvent := func() *korifiv1alpha1.CFAppUsageEvent {
+		GinkgoHelper()
+
+		cfAppUsageEvent := &korifiv1alpha1.CFAppUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.Must(uuid.NewV7()).String(),
+			},
+			Spec: korifiv1alpha1.CFAppUsageEventSpec{
+				State:         korifiv1alpha1.AppUsageStateStarted,
+				PreviousState: korifiv1alpha1.AppUsageStateStopped,
+				App: korifiv1alpha1.UsageEventResource{
+					GUID: "app-guid",
+					Name: "my-app",
+				},
+				Process: korifiv1alpha1.UsageEventProcess{
+					GUID: "process-guid",
+					Type: "web",
+				},
+				Space: korifiv1alpha1.UsageEventResource{
+					GUID: "space-guid",
+					Name: "my-space",
+				},
+				Organization: korifiv1alpha1.UsageEventResource{
+					GUID: "org-guid",
+					Name: "my-org",
+				},
+				MemoryInMBPerInstance: 256,
+				InstanceCount:         2,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfAppUsageEvent)).To(Succeed())
+
+		return cfAppUsageEvent
+	}
+
+	BeforeEach(func() {
+		sorter = new(fake.AppUsageEventSorter)
+		sorter.SortStub = func(records []repositories.AppUsageEventRecord, _ string) []repositories.AppUsageEventRecord {
+			return records
+		}
+
+		appUsageEventRepo = repositories.NewAppUsageEventRepo(klient, k8sClient, sorter, rootNamespace)
+	})
+
+	Describe("GetAppUsageEvent", func() {
+		var (
+			appUsageEventGUID string
+			record            repositories.AppUsageEventRecord
+			getErr            error
+		)
+
+		BeforeEach(func() {
+			appUsageEventGUID = createAppUsageEvent().Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = appUsageEventRepo.GetAppUsageEvent(ctx, authInfo, appUsageEventGUID)
+		})
+
+		It("returns a forbidden error to non-admin users", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the app usage event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                  Equal(appUsageEventGUID),
+					"State":                 Equal(korifiv1alpha1.AppUsageStateStarted),
+					"PreviousState":         Equal(korifiv1alpha1.AppUsageStateStopped),
+					"App":                   Equal(repositories.UsageEventResource{GUID: "app-guid", Name: "my-app"}),
+					"ProcessGUID":           Equal("process-guid"),
+					"ProcessType":           Equal("web"),
+					"Space":                 Equal(repositories.UsageEventResource{GUID: "space-guid", Name: "my-space"}),
+					"Organization":          Equal(repositories.UsageEventResource{GUID: "org-guid", Name: "my-org"}),
+					"MemoryInMBPerInstance": BeEquivalentTo(256),
+					"InstanceCount":         BeEquivalentTo(2),
+				}))
+				Expect(record.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
+			})
+
+			When("the app usage event does not exist", func() {
+				BeforeEach(func() {
+					appUsageEventGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListAppUsageEvents", func() {
+		var (
+			event1, event2 *korifiv1alpha1.CFAppUsageEvent
+			message        repositories.ListAppUsageEventsMessage
+			records        []repositories.AppUsageEventRecord
+			listErr        error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+
+			event1 = createAppUsageEvent()
+			event2 = createAppUsageEvent()
+			message = repositories.ListAppUsageEventsMessage{
+				GUIDs:   []string{event1.Name, event2.Name},
+				OrderBy: "created_at",
+			}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = appUsageEventRepo.ListAppUsageEvents(ctx, authInfo, message)
+		})
+
+		It("lists the app usage events", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(event1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(event2.Name)}),
+			))
+		})
+
+		It("sorts the app usage events", func() {
+			Expect(sorter.SortCallCount()).To(Equal(1))
+			sortedRecords, field := sorter.SortArgsForCall(0)
+			Expect(field).To(Equal("created_at"))
+			Expect(sortedRecords).To(HaveLen(2))
+		})
+
+		When("filtering by after_guid", func() {
+			BeforeEach(func() {
+				message.AfterGUID = event1.Name
+			})
+
+			It("returns the events recorded after the given one", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(event2.Name)}),
+				))
+			})
+		})
+	})
+
+	Describe("PurgeAndReseedAppUsageEvents", func() {
+		var (
+			cfAppUsageEvent *korifiv1alpha1.CFAppUsageEvent
+			cfProcess       *korifiv1alpha1.CFProcess
+			purgeErr        error
+		)
+
+		BeforeEach(func() {
+			cfAppUsageEvent = createAppUsageEvent()
+
+			cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace := createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+			cfProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfSpace.Name,
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:           corev1.LocalObjectReference{Name: uuid.NewString()},
+					ProcessType:      "web",
+					DesiredInstances: tools.PtrTo[int32](2),
+					MemoryMB:         256,
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfProcess)).To(Succeed())
+			Expect(k8s.Patch(ctx, k8sClient, cfProcess, func() {
+				cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.AppUsageStateStarted,
+					MemoryMB:  256,
+					Instances: 2,
+				}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = appUsageEventRepo.PurgeAndReseedAppUsageEvents(ctx, authInfo)
+		})
+
+		It("returns a forbidden error to non-admin users", func() {
+			Expect(purgeErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the app usage events", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfAppUsageEvent), cfAppUsageEvent)).To(MatchError(ContainSubstring("not found")))
+			})
+
+			It("resets the recorded usage of started processes", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				Expect(cfProcess.Status.Usage).To(BeNil())
+			})
+		})
+	})
+})
+
+var _ = DescribeTable("AppUsageEventSorter",
+	func(e1, e2 repositories.AppUsageEventRecord, field string, match types.GomegaMatcher) {
+		Expect(repositories.AppUsageEventComparator(field)(e1, e2)).To(match)
+	},
+	Entry("default",
+		repositories.AppUsageEventRecord{GUID: "0190a1b2-0000-7000-8000-000000000001"},
+		repositories.AppUsageEventRecord{GUID: "0190a1b2-0000-7000-8000-000000000002"},
+		"",
+		BeNumerically("<", 0),
+	),
+	Entry("created_at",
+		repositories.AppUsageEventRecord{GUID: "0190a1b2-0000-7000-8000-000000000001"},
+		repositories.AppUsageEventRecord{GUID: "0190a1b2-0000-7000-8000-000000000002"},
+		"created_at",
+		BeNumerically("<", 0),
+	),
+	Entry("updated_at",
+		repositories.AppUsageEventRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.AppUsageEventRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"updated_at",
+		BeNumerically("<", 0),
+	),
+)
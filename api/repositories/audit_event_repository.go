@@ -0,0 +1,351 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=create
+
+const (
+	AuditEventResourceType = "Audit Event"
+
+	AuditEventActorTypeUser           = "user"
+	AuditEventActorTypeServiceAccount = "service_account"
+)
+
+// auditEventTargetResourceTypes maps the CF types of space-scoped audit event
+// targets to the resource types the namespace retriever knows about
+var auditEventTargetResourceTypes = map[string]string{
+	"app":              AppResourceType,
+	"build":            BuildResourceType,
+	"droplet":          DropletResourceType,
+	"package":          PackageResourceType,
+	"process":          ProcessResourceType,
+	"route":            RouteResourceType,
+	"service_binding":  ServiceBindingResourceType,
+	"service_instance": ServiceInstanceResourceType,
+	"task":             TaskResourceType,
+}
+
+type AuditEventActor struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventTarget struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventRecord struct {
+	GUID             string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+	Type             string
+	Actor            AuditEventActor
+	Target           AuditEventTarget
+	SpaceGUID        string
+	OrganizationGUID string
+}
+
+type CreateAuditEventMessage struct {
+	Type   string
+	Actor  AuditEventActor
+	Target AuditEventTarget
+}
+
+const (
+	TimestampLessThan           = "lt"
+	TimestampLessThanOrEqual    = "lte"
+	TimestampGreaterThan        = "gt"
+	TimestampGreaterThanOrEqual = "gte"
+)
+
+type TimestampFilter struct {
+	Operator string
+	Value    time.Time
+}
+
+func (f TimestampFilter) matches(t time.Time) bool {
+	switch f.Operator {
+	case TimestampLessThan:
+		return t.Before(f.Value)
+	case TimestampLessThanOrEqual:
+		return !t.After(f.Value)
+	case TimestampGreaterThan:
+		return t.After(f.Value)
+	case TimestampGreaterThanOrEqual:
+		return !t.Before(f.Value)
+	}
+
+	return t.Equal(f.Value)
+}
+
+type ListAuditEventsMessage struct {
+	Types             []string
+	TargetGUIDs       []string
+	SpaceGUIDs        []string
+	OrganizationGUIDs []string
+	CreatedAts        []time.Time
+	CreatedAtFilters  []TimestampFilter
+	OrderBy           string
+}
+
+func (m *ListAuditEventsMessage) matches(cfAuditEvent korifiv1alpha1.CFAuditEvent) bool {
+	return tools.EmptyOrContains(m.Types, cfAuditEvent.Spec.Type) &&
+		tools.EmptyOrContains(m.TargetGUIDs, cfAuditEvent.Spec.Target.GUID) &&
+		tools.EmptyOrContains(m.SpaceGUIDs, cfAuditEvent.Spec.SpaceGUID) &&
+		tools.EmptyOrContains(m.OrganizationGUIDs, cfAuditEvent.Spec.OrgGUID) &&
+		m.matchesCreatedAt(cfAuditEvent.CreationTimestamp.Time)
+}
+
+func (m *ListAuditEventsMessage) matchesCreatedAt(createdAt time.Time) bool {
+	if len(m.CreatedAts) > 0 && !slices.ContainsFunc(m.CreatedAts, createdAt.Equal) {
+		return false
+	}
+
+	for _, filter := range m.CreatedAtFilters {
+		if !filter.matches(createdAt) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//counterfeiter:generate -o fake -fake-name AuditEventSorter . AuditEventSorter
+type AuditEventSorter interface {
+	Sort(records []AuditEventRecord, order string) []AuditEventRecord
+}
+
+type auditEventSorter struct {
+	sorter *compare.Sorter[AuditEventRecord]
+}
+
+func NewAuditEventSorter() *auditEventSorter {
+	return &auditEventSorter{
+		sorter: compare.NewSorter(AuditEventComparator),
+	}
+}
+
+func (s *auditEventSorter) Sort(records []AuditEventRecord, order string) []AuditEventRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func AuditEventComparator(fieldName string) func(AuditEventRecord, AuditEventRecord) int {
+	return func(e1, e2 AuditEventRecord) int {
+		switch fieldName {
+		case "", "created_at":
+			return tools.CompareTimePtr(&e1.CreatedAt, &e2.CreatedAt)
+		case "updated_at":
+			return tools.CompareTimePtr(e1.UpdatedAt, e2.UpdatedAt)
+		}
+		return 0
+	}
+}
+
+type AuditEventRepo struct {
+	klient             Klient
+	privilegedClient   client.Client
+	namespaceRetriever NamespaceRetriever
+	nsPerms            *authorization.NamespacePermissions
+	sorter             AuditEventSorter
+	rootNamespace      string
+}
+
+func NewAuditEventRepo(
+	klient Klient,
+	privilegedClient client.Client,
+	namespaceRetriever NamespaceRetriever,
+	nsPerms *authorization.NamespacePermissions,
+	sorter AuditEventSorter,
+	rootNamespace string,
+) *AuditEventRepo {
+	return &AuditEventRepo{
+		klient:             klient,
+		privilegedClient:   privilegedClient,
+		namespaceRetriever: namespaceRetriever,
+		nsPerms:            nsPerms,
+		sorter:             sorter,
+		rootNamespace:      rootNamespace,
+	}
+}
+
+// CreateAuditEvent records an event on behalf of the API. Events are created
+// with the privileged client so that users cannot forge them. They are stored
+// in the space of the target, or in the org of the target, or in the root
+// namespace when the target does not belong to an org.
+func (r *AuditEventRepo) CreateAuditEvent(ctx context.Context, message CreateAuditEventMessage) (AuditEventRecord, error) {
+	spaceGUID, orgGUID, err := r.resolveSpaceAndOrg(ctx, message.Target)
+	if err != nil {
+		return AuditEventRecord{}, err
+	}
+
+	cfAuditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tools.IfZero(tools.IfZero(spaceGUID, orgGUID), r.rootNamespace),
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: message.Type,
+			Actor: korifiv1alpha1.CFAuditEventActor{
+				GUID: message.Actor.GUID,
+				Type: message.Actor.Type,
+				Name: message.Actor.Name,
+			},
+			Target: korifiv1alpha1.CFAuditEventTarget{
+				GUID: message.Target.GUID,
+				Type: message.Target.Type,
+				Name: message.Target.Name,
+			},
+			SpaceGUID: spaceGUID,
+			OrgGUID:   orgGUID,
+		},
+	}
+
+	if spaceGUID != "" {
+		cfAuditEvent.Labels = map[string]string{
+			korifiv1alpha1.SpaceGUIDKey: spaceGUID,
+		}
+	}
+
+	if err = r.privilegedClient.Create(ctx, cfAuditEvent); err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to create audit event: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return toAuditEventRecord(*cfAuditEvent), nil
+}
+
+func (r *AuditEventRepo) resolveSpaceAndOrg(ctx context.Context, target AuditEventTarget) (string, string, error) {
+	switch target.Type {
+	case "organization":
+		return "", target.GUID, nil
+	case "space":
+		orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, target.GUID, SpaceResourceType)
+		if err != nil {
+			return "", "", ignoreNotFound(err)
+		}
+		return target.GUID, orgGUID, nil
+	}
+
+	resourceType, ok := auditEventTargetResourceTypes[target.Type]
+	if !ok {
+		return "", "", nil
+	}
+
+	spaceGUID, err := r.namespaceRetriever.NamespaceFor(ctx, target.GUID, resourceType)
+	if err != nil {
+		return "", "", ignoreNotFound(err)
+	}
+
+	orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return "", "", ignoreNotFound(err)
+	}
+
+	return spaceGUID, orgGUID, nil
+}
+
+// ignoreNotFound lets events about targets that are already gone (e.g. after
+// a delete) be recorded in the root namespace
+func ignoreNotFound(err error) error {
+	if errors.As(err, &apierrors.NotFoundError{}) {
+		return nil
+	}
+
+	return err
+}
+
+func (r *AuditEventRepo) GetAuditEvent(ctx context.Context, authInfo authorization.Info, guid string) (AuditEventRecord, error) {
+	cfAuditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfAuditEvent); err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to get audit event: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return toAuditEventRecord(*cfAuditEvent), nil
+}
+
+func (r *AuditEventRepo) ListAuditEvents(ctx context.Context, authInfo authorization.Info, message ListAuditEventsMessage) ([]AuditEventRecord, error) {
+	spaceEvents := &korifiv1alpha1.CFAuditEventList{}
+	if err := r.klient.List(ctx, spaceEvents); err != nil {
+		return []AuditEventRecord{}, fmt.Errorf("failed to list audit events: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+	cfAuditEvents := spaceEvents.Items
+
+	orgNamespaces, err := authorizedOrgNamespaces(ctx, authInfo, r.nsPerms)
+	if err != nil {
+		return []AuditEventRecord{}, err
+	}
+
+	for ns := range it.Chain(orgNamespaces, it.Once(r.rootNamespace)) {
+		events, err := r.listInNamespace(ctx, ns)
+		if err != nil {
+			return []AuditEventRecord{}, err
+		}
+		cfAuditEvents = append(cfAuditEvents, events...)
+	}
+
+	auditEventRecords := slices.Collect(it.Map(
+		itx.FromSlice(cfAuditEvents).Filter(message.matches),
+		toAuditEventRecord,
+	))
+
+	return r.sorter.Sort(auditEventRecords, message.OrderBy), nil
+}
+
+func (r *AuditEventRepo) listInNamespace(ctx context.Context, namespace string) ([]korifiv1alpha1.CFAuditEvent, error) {
+	cfAuditEventList := &korifiv1alpha1.CFAuditEventList{}
+	err := r.klient.List(ctx, cfAuditEventList, InNamespace(namespace))
+	if k8serrors.IsForbidden(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events in namespace %s: %w", namespace, apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return cfAuditEventList.Items, nil
+}
+
+func toAuditEventRecord(cfAuditEvent korifiv1alpha1.CFAuditEvent) AuditEventRecord {
+	return AuditEventRecord{
+		GUID:      cfAuditEvent.Name,
+		CreatedAt: cfAuditEvent.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&cfAuditEvent),
+		Type:      cfAuditEvent.Spec.Type,
+		Actor: AuditEventActor{
+			GUID: cfAuditEvent.Spec.Actor.GUID,
+			Type: cfAuditEvent.Spec.Actor.Type,
+			Name: cfAuditEvent.Spec.Actor.Name,
+		},
+		Target: AuditEventTarget{
+			GUID: cfAuditEvent.Spec.Target.GUID,
+			Type: cfAuditEvent.Spec.Target.Type,
+			Name: cfAuditEvent.Spec.Target.Name,
+		},
+		SpaceGUID:        cfAuditEvent.Spec.SpaceGUID,
+		OrganizationGUID: cfAuditEvent.Spec.OrgGUID,
+	}
+}
@@ -0,0 +1,422 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AuditEventRepository", func() {
+	var (
+		auditEventRepo *repositories.AuditEventRepo
+		sorter         *fake.AuditEventSorter
+		cfOrg          *korifiv1alpha1.CFOrg
+		cfSpace        *korifiv1alpha1.CFSpace
+		cfApp          *korifiv1alpha1.CFApp
+		actor          repositories.AuditEventActor
+	)
+
+	createAuditEvent := func(eventType string, target repositories.AuditEventTarget) repositories.AuditEventRecord {
+		GinkgoHelper()
+
+		record, err := auditEventRepo.CreateAuditEvent(ctx, repositories.CreateAuditEventMessage{
+			Type:   eventType,
+			Actor:  actor,
+			Target: target,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return record
+	}
+
+	BeforeEach(func() {
+		dynamicClient, err := dynamic.NewForConfig(testEnv.Config)
+		Expect(err).NotTo(HaveOccurred())
+
+		sorter = new(fake.AuditEventSorter)
+		sorter.SortStub = func(records []repositories.AuditEventRecord, _ string) []repositories.AuditEventRecord {
+			return records
+		}
+
+		auditEventRepo = repositories.NewAuditEventRepo(
+			klient,
+			k8sClient,
+			repositories.NewNamespaceRetriever(dynamicClient),
+			nsPerms,
+			sorter,
+			rootNamespace,
+		)
+
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+
+		actor = repositories.AuditEventActor{
+			GUID: userName,
+			Type: repositories.AuditEventActorTypeUser,
+			Name: userName,
+		}
+	})
+
+	Describe("CreateAuditEvent", func() {
+		var (
+			target       repositories.AuditEventTarget
+			record       repositories.AuditEventRecord
+			cfAuditEvent *korifiv1alpha1.CFAuditEvent
+			createErr    error
+		)
+
+		BeforeEach(func() {
+			target = repositories.AuditEventTarget{
+				GUID: cfApp.Name,
+				Type: "app",
+				Name: cfApp.Spec.DisplayName,
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = auditEventRepo.CreateAuditEvent(ctx, repositories.CreateAuditEventMessage{
+				Type:   "audit.app.start",
+				Actor:  actor,
+				Target: target,
+			})
+
+			cfAuditEvent = &korifiv1alpha1.CFAuditEvent{}
+		})
+
+		getCFAuditEvent := func(namespace string) {
+			GinkgoHelper()
+
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: record.GUID}, cfAuditEvent)).To(Succeed())
+		}
+
+		It("records the event in the space of the target", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			getCFAuditEvent(cfSpace.Name)
+
+			Expect(cfAuditEvent.Labels).To(HaveKeyWithValue(korifiv1alpha1.SpaceGUIDKey, cfSpace.Name))
+			Expect(cfAuditEvent.Spec).To(Equal(korifiv1alpha1.CFAuditEventSpec{
+				Type: "audit.app.start",
+				Actor: korifiv1alpha1.CFAuditEventActor{
+					GUID: userName,
+					Type: "user",
+					Name: userName,
+				},
+				Target: korifiv1alpha1.CFAuditEventTarget{
+					GUID: cfApp.Name,
+					Type: "app",
+					Name: cfApp.Spec.DisplayName,
+				},
+				SpaceGUID: cfSpace.Name,
+				OrgGUID:   cfOrg.Name,
+			}))
+		})
+
+		It("returns the audit event record", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(matchers.BeValidUUID())
+			Expect(record.Type).To(Equal("audit.app.start"))
+			Expect(record.Actor).To(Equal(actor))
+			Expect(record.Target).To(Equal(target))
+			Expect(record.SpaceGUID).To(Equal(cfSpace.Name))
+			Expect(record.OrganizationGUID).To(Equal(cfOrg.Name))
+			Expect(record.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
+		})
+
+		When("the target is a space", func() {
+			BeforeEach(func() {
+				target = repositories.AuditEventTarget{GUID: cfSpace.Name, Type: "space"}
+			})
+
+			It("records the event in the space", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				getCFAuditEvent(cfSpace.Name)
+				Expect(cfAuditEvent.Spec.SpaceGUID).To(Equal(cfSpace.Name))
+				Expect(cfAuditEvent.Spec.OrgGUID).To(Equal(cfOrg.Name))
+			})
+		})
+
+		When("the target is an org", func() {
+			BeforeEach(func() {
+				target = repositories.AuditEventTarget{GUID: cfOrg.Name, Type: "organization"}
+			})
+
+			It("records the event in the org", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				getCFAuditEvent(cfOrg.Name)
+				Expect(cfAuditEvent.Labels).NotTo(HaveKey(korifiv1alpha1.SpaceGUIDKey))
+				Expect(cfAuditEvent.Spec.SpaceGUID).To(BeEmpty())
+				Expect(cfAuditEvent.Spec.OrgGUID).To(Equal(cfOrg.Name))
+			})
+		})
+
+		When("the target does not belong to an org", func() {
+			BeforeEach(func() {
+				target = repositories.AuditEventTarget{GUID: uuid.NewString(), Type: "security_group"}
+			})
+
+			It("records the event in the root namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				getCFAuditEvent(rootNamespace)
+				Expect(cfAuditEvent.Spec.SpaceGUID).To(BeEmpty())
+				Expect(cfAuditEvent.Spec.OrgGUID).To(BeEmpty())
+			})
+		})
+
+		When("the target does not exist anymore", func() {
+			BeforeEach(func() {
+				target = repositories.AuditEventTarget{GUID: uuid.NewString(), Type: "app"}
+			})
+
+			It("records the event in the root namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				getCFAuditEvent(rootNamespace)
+			})
+		})
+	})
+
+	Describe("GetAuditEvent", func() {
+		var (
+			auditEventGUID string
+			record         repositories.AuditEventRecord
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			auditEventGUID = createAuditEvent("audit.app.start", repositories.AuditEventTarget{GUID: cfApp.Name, Type: "app"}).GUID
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = auditEventRepo.GetAuditEvent(ctx, authInfo, auditEventGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the audit event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(auditEventGUID))
+				Expect(record.Type).To(Equal("audit.app.start"))
+				Expect(record.Target.GUID).To(Equal(cfApp.Name))
+			})
+		})
+
+		When("the audit event does not exist", func() {
+			BeforeEach(func() {
+				auditEventGUID = "does-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListAuditEvents", func() {
+		var (
+			appEvent, orgEvent, globalEvent repositories.AuditEventRecord
+			message                         repositories.ListAuditEventsMessage
+			records                         []repositories.AuditEventRecord
+			listErr                         error
+		)
+
+		BeforeEach(func() {
+			appEvent = createAuditEvent("audit.app.start", repositories.AuditEventTarget{GUID: cfApp.Name, Type: "app"})
+			orgEvent = createAuditEvent("audit.organization.update", repositories.AuditEventTarget{GUID: cfOrg.Name, Type: "organization"})
+			globalEvent = createAuditEvent("audit.security_group.create", repositories.AuditEventTarget{GUID: uuid.NewString(), Type: "security_group"})
+
+			message = repositories.ListAuditEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = auditEventRepo.ListAuditEvents(ctx, authInfo, message)
+		})
+
+		It("returns an empty list", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the events of the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEvent.GUID)}),
+				))
+			})
+
+			It("sorts the events", func() {
+				Expect(sorter.SortCallCount()).To(Equal(1))
+				_, order := sorter.SortArgsForCall(0)
+				Expect(order).To(BeEmpty())
+			})
+		})
+
+		When("the user is an org manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, cfOrg.Name)
+			})
+
+			It("returns the events of the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ContainElement(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgEvent.GUID)}),
+				))
+				Expect(records).NotTo(ContainElement(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(globalEvent.GUID)}),
+				))
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+			})
+
+			It("returns all the events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEvent.GUID)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgEvent.GUID)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(globalEvent.GUID)}),
+				))
+			})
+
+			When("filtering by type", func() {
+				BeforeEach(func() {
+					message.Types = []string{"audit.organization.update"}
+				})
+
+				It("returns the matching events", func() {
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgEvent.GUID)}),
+					))
+				})
+			})
+
+			When("filtering by target guid", func() {
+				BeforeEach(func() {
+					message.TargetGUIDs = []string{cfApp.Name}
+				})
+
+				It("returns the matching events", func() {
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEvent.GUID)}),
+					))
+				})
+			})
+
+			When("filtering by space guid", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{cfSpace.Name}
+				})
+
+				It("returns the matching events", func() {
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEvent.GUID)}),
+					))
+				})
+			})
+
+			When("filtering by organization guid", func() {
+				BeforeEach(func() {
+					message.OrganizationGUIDs = []string{cfOrg.Name}
+				})
+
+				It("returns the matching events", func() {
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEvent.GUID)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgEvent.GUID)}),
+					))
+				})
+			})
+
+			When("filtering by creation time", func() {
+				BeforeEach(func() {
+					message.TargetGUIDs = []string{cfApp.Name}
+					message.CreatedAtFilters = []repositories.TimestampFilter{{
+						Operator: repositories.TimestampGreaterThan,
+						Value:    appEvent.CreatedAt.Add(-time.Hour),
+					}}
+				})
+
+				It("returns the matching events", func() {
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEvent.GUID)}),
+					))
+				})
+
+				When("no event matches", func() {
+					BeforeEach(func() {
+						message.CreatedAts = []time.Time{appEvent.CreatedAt.Add(time.Hour)}
+					})
+
+					It("returns an empty list", func() {
+						Expect(records).To(BeEmpty())
+					})
+				})
+			})
+
+			When("ordering the events", func() {
+				BeforeEach(func() {
+					message.OrderBy = "-created_at"
+				})
+
+				It("sorts the events by the requested order", func() {
+					Expect(sorter.SortCallCount()).To(Equal(1))
+					_, order := sorter.SortArgsForCall(0)
+					Expect(order).To(Equal("-created_at"))
+				})
+			})
+		})
+	})
+})
+
+var _ = DescribeTable("AuditEventSorter",
+	func(e1, e2 repositories.AuditEventRecord, field string, match types.GomegaMatcher) {
+		Expect(repositories.AuditEventComparator(field)(e1, e2)).To(match)
+	},
+	Entry("default",
+		repositories.AuditEventRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.AuditEventRecord{CreatedAt: time.UnixMilli(2)},
+		"",
+		BeNumerically("<", 0),
+	),
+	Entry("created_at",
+		repositories.AuditEventRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.AuditEventRecord{CreatedAt: time.UnixMilli(2)},
+		"created_at",
+		BeNumerically("<", 0),
+	),
+	Entry("updated_at",
+		repositories.AuditEventRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.AuditEventRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"updated_at",
+		BeNumerically("<", 0),
+	),
+)
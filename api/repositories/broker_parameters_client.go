@@ -6,6 +6,9 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -28,6 +31,7 @@ func NewServiceBrokerClient(clientFactory osbapi.BrokerClientFactory, k8sClient
 }
 
 func (c *ServiceBrokerClient) GetServiceBindingParameters(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (map[string]any, error) {
+	ctx = osbapi.WithOriginatingIdentity(ctx, originatingIdentity(ctx))
 	assetsClient := osbapi.NewAssets(c.k8sClient, c.rootNamespace)
 	sbAssets, err := assetsClient.GetServiceBindingAssets(ctx, serviceBinding)
 	if err != nil {
@@ -51,3 +55,58 @@ func (c *ServiceBrokerClient) GetServiceBindingParameters(ctx context.Context, s
 
 	return binding.Parameters, nil
 }
+
+// GetServiceInstanceParameters fetches the service instance parameters from
+// the broker if the service offering supports it. Otherwise, the parameters
+// last sent to the broker are read from the instance parameters secret.
+func (c *ServiceBrokerClient) GetServiceInstanceParameters(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (map[string]any, error) {
+	ctx = osbapi.WithOriginatingIdentity(ctx, originatingIdentity(ctx))
+	assetsClient := osbapi.NewAssets(c.k8sClient, c.rootNamespace)
+	siAssets, err := assetsClient.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		return map[string]any{}, fmt.Errorf("failed to get service instance assets: %w", err)
+	}
+
+	if !siAssets.ServiceOffering.Spec.BrokerCatalog.Features.InstancesRetrievable {
+		return c.getStoredParameters(ctx, serviceInstance)
+	}
+
+	osbapiClient, err := c.clientFactory.CreateClient(ctx, siAssets.ServiceBroker)
+	if err != nil {
+		return map[string]any{}, fmt.Errorf("failed to create osbapi client: %w", err)
+	}
+
+	instance, err := osbapiClient.GetServiceInstance(ctx, osbapi.GetServiceInstanceRequest{
+		InstanceID: serviceInstance.Name,
+		ServiceId:  siAssets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PlanID:     siAssets.ServicePlan.Spec.BrokerCatalog.ID,
+	})
+	if err != nil {
+		return map[string]any{}, fmt.Errorf("failed to get service instance from broker: %w", err)
+	}
+
+	if instance.Parameters == nil {
+		return map[string]any{}, nil
+	}
+
+	return instance.Parameters, nil
+}
+
+func (c *ServiceBrokerClient) getStoredParameters(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (map[string]any, error) {
+	if serviceInstance.Status.Parameters.Name == "" {
+		return map[string]any{}, nil
+	}
+
+	paramsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: serviceInstance.Namespace,
+			Name:      serviceInstance.Status.Parameters.Name,
+		},
+	}
+	err := c.k8sClient.Get(ctx, client.ObjectKeyFromObject(paramsSecret), paramsSecret)
+	if err != nil {
+		return map[string]any{}, fmt.Errorf("failed to get parameters secret: %w", err)
+	}
+
+	return tools.FromParametersSecretData(paramsSecret.Data)
+}
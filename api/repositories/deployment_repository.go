@@ -17,6 +17,8 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/go-logr/logr"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -35,6 +37,8 @@ type DeploymentRecord struct {
 	CreatedAt   time.Time
 	UpdatedAt   *time.Time
 	DropletGUID string
+	Strategy    string
+	MaxInFlight int32
 	Status      DeploymentStatus
 }
 
@@ -87,6 +91,9 @@ type DeploymentStatusReason string
 const (
 	DeploymentStatusReasonDeploying DeploymentStatusReason = "DEPLOYING"
 	DeploymentStatusReasonDeployed  DeploymentStatusReason = "DEPLOYED"
+	DeploymentStatusReasonPaused    DeploymentStatusReason = "PAUSED"
+	DeploymentStatusReasonCanceling DeploymentStatusReason = "CANCELING"
+	DeploymentStatusReasonCanceled  DeploymentStatusReason = "CANCELED"
 )
 
 type DeploymentStatus struct {
@@ -95,8 +102,11 @@ type DeploymentStatus struct {
 }
 
 type CreateDeploymentMessage struct {
-	AppGUID     string
-	DropletGUID string
+	AppGUID      string
+	DropletGUID  string
+	RevisionGUID string
+	Strategy     string
+	MaxInFlight  *int32
 }
 
 type ListDeploymentsMessage struct {
@@ -148,7 +158,7 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
 	}
 
-	if err = r.ensureSupport(ctx, app); err != nil {
+	if err = r.ensureSupport(ctx, app, message.Strategy); err != nil {
 		return DeploymentRecord{}, err
 	}
 
@@ -157,18 +167,37 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		dropletGUID = message.DropletGUID
 	}
 
+	var rollbackRevision *korifiv1alpha1.CFAppRevision
+	if message.RevisionGUID != "" {
+		rollbackRevision, err = r.getRollbackRevision(ctx, app, message.RevisionGUID)
+		if err != nil {
+			return DeploymentRecord{}, err
+		}
+
+		dropletGUID = rollbackRevision.Spec.DropletRef.Name
+	}
+
 	appRev := app.Annotations[korifiv1alpha1.CFAppRevisionKey]
-	newRev, err := bumpAppRev(appRev)
+	newRev, err := bumpAppRev(app.LatestRevision())
 	if err != nil {
 		return DeploymentRecord{}, fmt.Errorf("expected app-rev to be an integer: %w", err)
 	}
 
 	err = r.klient.Patch(ctx, app, func() error {
-		app.Spec.CurrentDropletRef.Name = dropletGUID
+		app.Spec.Canary = nextCanary(app, message.Strategy)
+		app.Spec.MaxInFlight = message.MaxInFlight
 		if app.Annotations == nil {
 			app.Annotations = map[string]string{}
 		}
+		app.Annotations[korifiv1alpha1.CFAppPreviousDropletKey] = app.Spec.CurrentDropletRef.Name
+		app.Annotations[korifiv1alpha1.CFAppPreviousRevisionKey] = appRev
+		app.Spec.CurrentDropletRef.Name = dropletGUID
 		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
+		app.Annotations[korifiv1alpha1.CFAppDeploymentStrategyKey] = message.Strategy
+		if rollbackRevision != nil {
+			app.Annotations[korifiv1alpha1.CFAppRevisionDescriptionKey] = fmt.Sprintf("Rolled back to revision %d.", rollbackRevision.Spec.Version)
+			app.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] = rollbackRevision.Name
+		}
 		app.Spec.DesiredState = korifiv1alpha1.StartedState
 
 		return nil
@@ -180,6 +209,148 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 	return appToDeploymentRecord(*app), nil
 }
 
+// nextCanary returns the canary the app should run once the deployment is
+// created. A canary deployment keeps the instances that are currently running
+// as the stable ones; when a canary deployment is already in progress the
+// original stable instances are kept as they are the last known good ones.
+func nextCanary(app *korifiv1alpha1.CFApp, strategy string) *korifiv1alpha1.CanaryDeployment {
+	if strategy != korifiv1alpha1.CanaryDeploymentStrategy || app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return nil
+	}
+
+	if app.Spec.Canary != nil {
+		return app.Spec.Canary
+	}
+
+	return &korifiv1alpha1.CanaryDeployment{
+		StableRevision:   app.Annotations[korifiv1alpha1.CFAppRevisionKey],
+		StableDropletRef: app.Spec.CurrentDropletRef,
+	}
+}
+
+func (r *DeploymentRepo) ContinueDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	app := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: deploymentGUID,
+		},
+	}
+	err := r.klient.Get(ctx, app)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	deployment := appToDeploymentRecord(*app)
+	if deployment.Status.Reason != DeploymentStatusReasonPaused {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"Cannot continue a deployment with status: %s and reason: %s", deployment.Status.Value, deployment.Status.Reason,
+		))
+	}
+
+	err = r.klient.Patch(ctx, app, func() error {
+		app.Spec.Canary = nil
+		return nil
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return appToDeploymentRecord(*app), nil
+}
+
+func (r *DeploymentRepo) CancelDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	app := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: deploymentGUID,
+		},
+	}
+	err := r.klient.Get(ctx, app)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	deployment := appToDeploymentRecord(*app)
+	if deployment.Status.Value == DeploymentStatusValueFinalized {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot cancel a %s deployment", deployment.Status.Value))
+	}
+
+	if deployment.Status.Reason == DeploymentStatusReasonCanceling {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "Cannot cancel a deployment that is already being canceled")
+	}
+
+	previousRevision, previousDropletGUID := previousDeployment(app)
+	if previousRevision == "" || previousDropletGUID == "" {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "Unable to cancel. There is no previous droplet to roll back to.")
+	}
+
+	// Going back to the previous app-rev leaves the stable instances of a
+	// canary deployment untouched rather than rolling them to a new app-rev
+	err = r.klient.Patch(ctx, app, func() error {
+		app.Spec.CurrentDropletRef.Name = previousDropletGUID
+		app.Annotations[korifiv1alpha1.CFAppCanceledRevisionKey] = app.LatestRevision()
+		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = previousRevision
+		app.Spec.Canary = nil
+
+		return nil
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return appToDeploymentRecord(*app), nil
+}
+
+// previousDeployment returns the app-rev and droplet the app was running
+// before the current deployment started
+func previousDeployment(app *korifiv1alpha1.CFApp) (string, string) {
+	if app.Spec.Canary != nil {
+		return app.Spec.Canary.StableRevision, app.Spec.Canary.StableDropletRef.Name
+	}
+
+	return app.Annotations[korifiv1alpha1.CFAppPreviousRevisionKey], app.Annotations[korifiv1alpha1.CFAppPreviousDropletKey]
+}
+
+// getRollbackRevision returns the revision a rollback deploys, making sure
+// that it can be restored. The revision is only recorded on the app, the CFApp
+// controller restores the environment variables, process commands and user
+// sidecars it captured, so that the rollback is applied by a single patch of
+// the app
+func (r *DeploymentRepo) getRollbackRevision(ctx context.Context, app *korifiv1alpha1.CFApp, revisionGUID string) (*korifiv1alpha1.CFAppRevision, error) {
+	revision := &korifiv1alpha1.CFAppRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: app.Namespace,
+			Name:      revisionGUID,
+		},
+	}
+	err := r.klient.Get(ctx, revision)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, apierrors.NewUnprocessableEntityError(err, "Unable to rollback. The revision does not exist.")
+		}
+		return nil, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	if revision.Spec.AppRef.Name != app.Name {
+		return nil, apierrors.NewUnprocessableEntityError(nil, "Unable to rollback. The revision does not belong to the app.")
+	}
+
+	if revision.Spec.EnvSecretName != "" {
+		err = r.klient.Get(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: revision.Namespace,
+				Name:      revision.Spec.EnvSecretName,
+			},
+		})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, apierrors.NewUnprocessableEntityError(err, "Unable to rollback. The revision environment variables do not exist.")
+			}
+			return nil, apierrors.FromK8sError(err, RevisionEnvVarsResourceType)
+		}
+	}
+
+	return revision, nil
+}
+
 func (r *DeploymentRepo) ListDeployments(ctx context.Context, authInfo authorization.Info, message ListDeploymentsMessage) ([]DeploymentRecord, error) {
 	appList := &korifiv1alpha1.CFAppList{}
 	err := r.klient.List(ctx, appList)
@@ -208,23 +379,44 @@ func appToDeploymentRecord(cfApp korifiv1alpha1.CFApp) DeploymentRecord {
 		CreatedAt:   cfApp.CreationTimestamp.Time,
 		UpdatedAt:   getLastUpdatedTime(&cfApp),
 		DropletGUID: cfApp.Spec.CurrentDropletRef.Name,
-		Status: DeploymentStatus{
-			Value:  DeploymentStatusValueActive,
-			Reason: DeploymentStatusReasonDeploying,
-		},
+		Strategy:    korifiv1alpha1.RollingDeploymentStrategy,
+		MaxInFlight: 1,
+		Status:      deploymentStatus(cfApp),
 	}
 
-	if meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
-		deploymentRecord.Status = DeploymentStatus{
-			Value:  DeploymentStatusValueFinalized,
-			Reason: DeploymentStatusReasonDeployed,
-		}
+	if cfApp.Spec.MaxInFlight != nil {
+		deploymentRecord.MaxInFlight = *cfApp.Spec.MaxInFlight
+	}
+
+	if strategy := cfApp.Annotations[korifiv1alpha1.CFAppDeploymentStrategyKey]; strategy != "" {
+		deploymentRecord.Strategy = strategy
 	}
 
 	return deploymentRecord
 }
 
-func (r *DeploymentRepo) ensureSupport(ctx context.Context, app *korifiv1alpha1.CFApp) error {
+func deploymentStatus(cfApp korifiv1alpha1.CFApp) DeploymentStatus {
+	ready := meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+
+	if cfApp.LatestRevision() != cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] {
+		if ready {
+			return DeploymentStatus{Value: DeploymentStatusValueFinalized, Reason: DeploymentStatusReasonCanceled}
+		}
+		return DeploymentStatus{Value: DeploymentStatusValueActive, Reason: DeploymentStatusReasonCanceling}
+	}
+
+	if !ready {
+		return DeploymentStatus{Value: DeploymentStatusValueActive, Reason: DeploymentStatusReasonDeploying}
+	}
+
+	if cfApp.Spec.Canary != nil {
+		return DeploymentStatus{Value: DeploymentStatusValueActive, Reason: DeploymentStatusReasonPaused}
+	}
+
+	return DeploymentStatus{Value: DeploymentStatusValueFinalized, Reason: DeploymentStatusReasonDeployed}
+}
+
+func (r *DeploymentRepo) ensureSupport(ctx context.Context, app *korifiv1alpha1.CFApp, strategy string) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("repo.deployment.ensureSupport")
 
 	appGuidReq, err := labels.NewRequirement(korifiv1alpha1.CFAppGUIDLabelKey, selection.Equals, []string{app.Name})
@@ -253,7 +445,10 @@ func (r *DeploymentRepo) ensureSupport(ctx context.Context, app *korifiv1alpha1.
 				"version", appWorkload.Annotations[version.KorifiCreationVersionKey],
 			)
 		}
-		return apierrors.NewUnprocessableEntityError(nil, "App instances created with an older version of Korifi can't use the rolling strategy. Please restart/restage/re-push app before using the rolling strategy")
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"App instances created with an older version of Korifi can't use the %[1]s strategy. Please restart/restage/re-push app before using the %[1]s strategy",
+			strategy,
+		))
 	}
 
 	return nil
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/version"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -110,9 +111,44 @@ var _ = Describe("DeploymentRepository", func() {
 				It("returns a finalized deployment", func() {
 					Expect(getErr).NotTo(HaveOccurred())
 
+					Expect(deployment.Strategy).To(Equal("rolling"))
 					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
 					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeployed))
 				})
+
+				When("a canary deployment is in progress", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+							cfApp.Annotations[korifiv1alpha1.CFAppDeploymentStrategyKey] = "canary"
+							cfApp.Spec.Canary = &korifiv1alpha1.CanaryDeployment{
+								StableRevision: "0",
+							}
+						})).To(Succeed())
+					})
+
+					It("returns a paused canary deployment", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+
+						Expect(deployment.Strategy).To(Equal("canary"))
+						Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+						Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonPaused))
+					})
+				})
+
+				When("the deployment has been canceled", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+							cfApp.Annotations[korifiv1alpha1.CFAppCanceledRevisionKey] = "2"
+						})).To(Succeed())
+					})
+
+					It("returns a canceled deployment", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+
+						Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+						Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceled))
+					})
+				})
 			})
 
 			When("the app does not exist", func() {
@@ -136,7 +172,8 @@ var _ = Describe("DeploymentRepository", func() {
 
 		BeforeEach(func() {
 			createDeploymentMessage = repositories.CreateDeploymentMessage{
-				AppGUID: cfApp.Name,
+				AppGUID:  cfApp.Name,
+				Strategy: "rolling",
 			}
 		})
 
@@ -172,6 +209,21 @@ var _ = Describe("DeploymentRepository", func() {
 				Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "2"))
 			})
 
+			When("the last deployment has been canceled", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppCanceledRevisionKey] = "3"
+					})).To(Succeed())
+				})
+
+				It("does not reuse the app-rev of the canceled deployment", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "4"))
+				})
+			})
+
 			It("sets the app desired state to STARTED", func() {
 				Expect(createErr).NotTo(HaveOccurred())
 
@@ -206,6 +258,112 @@ var _ = Describe("DeploymentRepository", func() {
 				})
 			})
 
+			It("records what the app was running before the deployment", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				previousDropletGUID := cfApp.Spec.CurrentDropletRef.Name
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Annotations).To(SatisfyAll(
+					HaveKeyWithValue(korifiv1alpha1.CFAppPreviousRevisionKey, "1"),
+					HaveKeyWithValue(korifiv1alpha1.CFAppPreviousDropletKey, previousDropletGUID),
+				))
+			})
+
+			It("defaults max in flight to one instance at a time", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(deployment.MaxInFlight).To(BeEquivalentTo(1))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.MaxInFlight).To(BeNil())
+			})
+
+			When("max in flight is set on the create message", func() {
+				BeforeEach(func() {
+					createDeploymentMessage.MaxInFlight = tools.PtrTo[int32](3)
+				})
+
+				It("sets max in flight on the app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.MaxInFlight).To(BeEquivalentTo(3))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.MaxInFlight).To(PointTo(BeEquivalentTo(3)))
+				})
+			})
+
+			It("records the deployment strategy", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(deployment.Strategy).To(Equal("rolling"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppDeploymentStrategyKey, "rolling"))
+				Expect(cfApp.Spec.Canary).To(BeNil())
+			})
+
+			When("the canary strategy is requested", func() {
+				var stableDropletGUID string
+
+				BeforeEach(func() {
+					stableDropletGUID = cfApp.Spec.CurrentDropletRef.Name
+					createDeploymentMessage.Strategy = "canary"
+					createDeploymentMessage.DropletGUID = "new-droplet"
+
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+					})).To(Succeed())
+				})
+
+				It("starts a canary deployment keeping the running revision as stable", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.Strategy).To(Equal("canary"))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppDeploymentStrategyKey, "canary"))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "2"))
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("new-droplet"))
+					Expect(cfApp.Spec.Canary).To(PointTo(Equal(korifiv1alpha1.CanaryDeployment{
+						StableRevision:   "1",
+						StableDropletRef: corev1.LocalObjectReference{Name: stableDropletGUID},
+					})))
+				})
+
+				When("a canary deployment is already in progress", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.Canary = &korifiv1alpha1.CanaryDeployment{
+								StableRevision:   "0",
+								StableDropletRef: corev1.LocalObjectReference{Name: "stable-droplet"},
+							}
+						})).To(Succeed())
+					})
+
+					It("keeps the original stable revision", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Spec.Canary).To(PointTo(Equal(korifiv1alpha1.CanaryDeployment{
+							StableRevision:   "0",
+							StableDropletRef: corev1.LocalObjectReference{Name: "stable-droplet"},
+						})))
+					})
+				})
+
+				When("the app is stopped", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+						})).To(Succeed())
+					})
+
+					It("does not start a canary as there is nothing to compare it with", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Spec.Canary).To(BeNil())
+					})
+				})
+			})
+
 			When("droplet guid is set on the create message", func() {
 				var newDropletGUID string
 
@@ -222,6 +380,171 @@ var _ = Describe("DeploymentRepository", func() {
 				})
 			})
 
+			When("revision guid is set on the create message", func() {
+				var (
+					revision  *korifiv1alpha1.CFAppRevision
+					envSecret *corev1.Secret
+					cfProcess *korifiv1alpha1.CFProcess
+				)
+
+				BeforeEach(func() {
+					envSecret = &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      cfApp.Spec.EnvSecretName,
+						},
+						StringData: map[string]string{
+							"FOO": "current",
+						},
+					}
+					Expect(k8sClient.Create(ctx, envSecret)).To(Succeed())
+
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      "revision-env",
+						},
+						StringData: map[string]string{
+							"FOO": "previous",
+						},
+					})).To(Succeed())
+
+					cfProcess = &korifiv1alpha1.CFProcess{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+							Labels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFProcessSpec{
+							AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+							ProcessType: "web",
+							Command:     "current-command",
+							Sidecars: []korifiv1alpha1.Sidecar{
+								{GUID: "current-sidecar-guid", Name: "current-sidecar", Command: "run-current", Origin: korifiv1alpha1.SidecarOriginUser},
+								{GUID: "buildpack-sidecar-guid", Name: "buildpack-sidecar", Command: "run-buildpack", Origin: korifiv1alpha1.SidecarOriginBuildpack},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, cfProcess)).To(Succeed())
+
+					revision = &korifiv1alpha1.CFAppRevision{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+							Labels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFAppRevisionSpec{
+							AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+							Version:       1,
+							DropletRef:    corev1.LocalObjectReference{Name: "previous-droplet"},
+							EnvSecretName: "revision-env",
+							Processes: []korifiv1alpha1.RevisionProcess{{
+								Type:    "web",
+								Command: "previous-command",
+							}},
+							Sidecars: []korifiv1alpha1.RevisionSidecar{
+								{GUID: "previous-sidecar-guid", Name: "previous-sidecar", Command: "run-previous", MemoryMB: 64, ProcessTypes: []string{"web"}, Origin: korifiv1alpha1.SidecarOriginUser},
+								{GUID: "worker-sidecar-guid", Name: "worker-sidecar", Command: "run-worker", ProcessTypes: []string{"worker"}, Origin: korifiv1alpha1.SidecarOriginUser},
+								{GUID: "old-buildpack-sidecar-guid", Name: "old-buildpack-sidecar", Command: "run-old-buildpack", ProcessTypes: []string{"web"}, Origin: korifiv1alpha1.SidecarOriginBuildpack},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+
+					createDeploymentMessage.RevisionGUID = revision.Name
+				})
+
+				It("deploys the revision droplet", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.DropletGUID).To(Equal("previous-droplet"))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("previous-droplet"))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "2"))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionDescriptionKey, "Rolled back to revision 1."))
+				})
+
+				It("records the revision to restore on the app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRollbackRevisionKey, revision.Name))
+				})
+
+				It("leaves restoring the environment variables and processes to the app controller", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+					Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("current")}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					Expect(cfProcess.Spec.Command).To(Equal("current-command"))
+				})
+
+				When("patching the app fails", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.MaxInFlight = tools.PtrTo[int32](0)
+					})
+
+					It("returns an error", func() {
+						Expect(createErr).To(HaveOccurred())
+					})
+
+					It("does not roll the app back", func() {
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Spec.CurrentDropletRef.Name).NotTo(Equal("previous-droplet"))
+						Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRollbackRevisionKey))
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+						Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("current")}))
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+						Expect(cfProcess.Spec.Command).To(Equal("current-command"))
+					})
+				})
+
+				When("the revision environment variables do not exist", func() {
+					BeforeEach(func() {
+						Expect(k8sClient.Delete(ctx, &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: cfSpace.Name,
+								Name:      "revision-env",
+							},
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("the revision does not exist", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.RevisionGUID = "i-do-not-exist"
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("the revision belongs to another app", func() {
+					BeforeEach(func() {
+						original := revision.DeepCopy()
+						revision.Spec.AppRef.Name = "another-app"
+						Expect(k8sClient.Patch(ctx, revision, client.MergeFrom(original))).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
 			When("the app does not exist", func() {
 				BeforeEach(func() {
 					createDeploymentMessage.AppGUID = "i-do-not-exist"
@@ -252,6 +575,179 @@ var _ = Describe("DeploymentRepository", func() {
 		})
 	})
 
+	Describe("ContinueDeployment", func() {
+		var (
+			deployment  repositories.DeploymentRecord
+			continueErr error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				cfApp.Annotations[korifiv1alpha1.CFAppDeploymentStrategyKey] = "canary"
+				cfApp.Spec.Canary = &korifiv1alpha1.CanaryDeployment{
+					StableRevision:   "0",
+					StableDropletRef: corev1.LocalObjectReference{Name: "stable-droplet"},
+				}
+				meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+					Type:   korifiv1alpha1.StatusConditionReady,
+					Status: metav1.ConditionTrue,
+					Reason: "ready",
+				})
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deployment, continueErr = deploymentRepo.ContinueDeployment(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get apps)", func() {
+			Expect(continueErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("ends the canary", func() {
+				Expect(continueErr).NotTo(HaveOccurred())
+				Expect(deployment.GUID).To(Equal(cfApp.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Canary).To(BeNil())
+			})
+
+			When("the deployment is not paused", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(continueErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(continueErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal("Cannot continue a deployment with status: FINALIZED and reason: DEPLOYED"))
+				})
+			})
+		})
+	})
+
+	Describe("CancelDeployment", func() {
+		var (
+			deployment repositories.DeploymentRecord
+			cancelErr  error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				cfApp.Annotations[korifiv1alpha1.CFAppDeploymentStrategyKey] = "canary"
+				cfApp.Spec.Canary = &korifiv1alpha1.CanaryDeployment{
+					StableRevision:   "0",
+					StableDropletRef: corev1.LocalObjectReference{Name: "stable-droplet"},
+				}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deployment, cancelErr = deploymentRepo.CancelDeployment(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get apps)", func() {
+			Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("rolls the app back to the stable droplet and app-rev", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(deployment.DropletGUID).To(Equal("stable-droplet"))
+				Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceling))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Canary).To(BeNil())
+				Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("stable-droplet"))
+				Expect(cfApp.Annotations).To(SatisfyAll(
+					HaveKeyWithValue(CFAppRevisionKey, "0"),
+					HaveKeyWithValue(korifiv1alpha1.CFAppCanceledRevisionKey, "1"),
+				))
+			})
+
+			When("the deployment uses the rolling strategy", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppDeploymentStrategyKey] = "rolling"
+						cfApp.Annotations[korifiv1alpha1.CFAppPreviousRevisionKey] = "0"
+						cfApp.Annotations[korifiv1alpha1.CFAppPreviousDropletKey] = "previous-droplet"
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+				})
+
+				It("rolls the app back to the previous droplet and app-rev", func() {
+					Expect(cancelErr).NotTo(HaveOccurred())
+					Expect(deployment.DropletGUID).To(Equal("previous-droplet"))
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceling))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("previous-droplet"))
+					Expect(cfApp.Annotations).To(SatisfyAll(
+						HaveKeyWithValue(CFAppRevisionKey, "0"),
+						HaveKeyWithValue(korifiv1alpha1.CFAppCanceledRevisionKey, "1"),
+					))
+				})
+
+				When("there is no previous droplet", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+							delete(cfApp.Annotations, korifiv1alpha1.CFAppPreviousDropletKey)
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(cancelErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
+			When("the deployment is already being canceled", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppCanceledRevisionKey] = "2"
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(cancelErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the deployment is finalized", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+						meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+							Type:   korifiv1alpha1.StatusConditionReady,
+							Status: metav1.ConditionTrue,
+							Reason: "ready",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(cancelErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(cancelErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal("Cannot cancel a FINALIZED deployment"))
+				})
+			})
+		})
+	})
+
 	Describe("ListDeployments", func() {
 		var (
 			message     repositories.ListDeploymentsMessage
@@ -41,6 +41,7 @@ func NewDomainRepo(
 type DomainRecord struct {
 	Name        string
 	GUID        string
+	RouterGroup string
 	Labels      map[string]string
 	Annotations map[string]string
 	Namespace   string
@@ -54,8 +55,9 @@ func (r DomainRecord) GetResourceType() string {
 }
 
 type CreateDomainMessage struct {
-	Name     string
-	Metadata Metadata
+	Name        string
+	RouterGroup string
+	Metadata    Metadata
 }
 
 type UpdateDomainMessage struct {
@@ -94,7 +96,8 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 			Annotations: message.Metadata.Annotations,
 		},
 		Spec: korifiv1alpha1.CFDomainSpec{
-			Name: message.Name,
+			Name:        message.Name,
+			RouterGroup: message.RouterGroup,
 		},
 	}
 
@@ -176,6 +179,7 @@ func cfDomainToDomainRecord(cfDomain korifiv1alpha1.CFDomain) DomainRecord {
 	return DomainRecord{
 		Name:        cfDomain.Spec.Name,
 		GUID:        cfDomain.Name,
+		RouterGroup: cfDomain.Spec.RouterGroup,
 		Namespace:   cfDomain.Namespace,
 		CreatedAt:   cfDomain.CreationTimestamp.Time,
 		UpdatedAt:   getLastUpdatedTime(&cfDomain),
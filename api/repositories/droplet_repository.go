@@ -2,41 +2,60 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"sort"
 	"time"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// No kubebuilder RBAC tags required, because Build and Droplet are the same CR
+// No kubebuilder RBAC tags required, because droplets are either views over
+// staged CFBuilds or CFDroplets created via the API, both accessed as the user
 
 const (
-	DropletResourceType = "Droplet"
+	DropletResourceType         = "Droplet"
+	UploadedDropletResourceType = "UploadedDroplet"
+
+	DropletStateAwaitingUpload = "AWAITING_UPLOAD"
+	DropletStateStaged         = "STAGED"
 )
 
 type DropletRepo struct {
-	klient Klient
+	klient            Klient
+	repositoryCreator RepositoryCreator
+	repositoryPrefix  string
 }
 
 func NewDropletRepo(
 	klient Klient,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
 ) *DropletRepo {
 	return &DropletRepo{
-		klient: klient,
+		klient:            klient,
+		repositoryCreator: repositoryCreator,
+		repositoryPrefix:  repositoryPrefix,
 	}
 }
 
 type DropletRecord struct {
 	GUID            string
+	SpaceGUID       string
 	State           string
 	CreatedAt       time.Time
 	UpdatedAt       *time.Time
@@ -50,6 +69,10 @@ type DropletRecord struct {
 	Annotations     map[string]string
 	Image           string
 	Ports           []int32
+	// The image holding the droplet, empty until its bits have been uploaded
+	ImageRef string
+	// The repository droplet images of the app are pushed to
+	RepositoryRef string
 }
 
 func (r DropletRecord) Relationships() map[string]string {
@@ -74,13 +97,64 @@ func (m *ListDropletsMessage) createSelector() map[string]string {
 	return newSelector
 }
 
+type CreateDropletMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	ProcessTypes map[string]string
+	Ports        []int32
+	Metadata     Metadata
+}
+
+func (m CreateDropletMessage) toCFDroplet(cfApp *korifiv1alpha1.CFApp) *korifiv1alpha1.CFDroplet {
+	processTypes := []korifiv1alpha1.ProcessType{}
+	for processType, command := range m.ProcessTypes {
+		processTypes = append(processTypes, korifiv1alpha1.ProcessType{Type: processType, Command: command})
+	}
+	sort.Slice(processTypes, func(i, j int) bool {
+		return processTypes[i].Type < processTypes[j].Type
+	})
+
+	cfDroplet := &korifiv1alpha1.CFDroplet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   m.SpaceGUID,
+			Labels:      tools.SetMapValue(m.Metadata.Labels, korifiv1alpha1.CFAppGUIDLabelKey, m.AppGUID),
+			Annotations: m.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFDropletSpec{
+			AppRef:       corev1.LocalObjectReference{Name: m.AppGUID},
+			Lifecycle:    cfApp.Spec.Lifecycle,
+			ProcessTypes: processTypes,
+			Ports:        m.Ports,
+		},
+	}
+	_ = controllerutil.SetControllerReference(cfApp, cfDroplet, scheme.Scheme)
+
+	return cfDroplet
+}
+
+type UpdateDropletImageMessage struct {
+	GUID                string
+	SpaceGUID           string
+	ImageRef            string
+	RegistrySecretNames []string
+}
+
 func (r *DropletRepo) GetDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (DropletRecord, error) {
 	build, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, dropletGUID)
+	if err == nil {
+		return r.cfBuildToDroplet(build)
+	}
+	if !errors.As(err, &apierrors.NotFoundError{}) {
+		return DropletRecord{}, err
+	}
+
+	cfDroplet, err := r.getCFDroplet(ctx, dropletGUID)
 	if err != nil {
 		return DropletRecord{}, err
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfDropletToDropletRecord(*cfDroplet), nil
 }
 
 func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (*korifiv1alpha1.CFBuild, error) {
@@ -96,17 +170,30 @@ func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInf
 	return build, nil
 }
 
-func cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
+func (r *DropletRepo) getCFDroplet(ctx context.Context, dropletGUID string) (*korifiv1alpha1.CFDroplet, error) {
+	cfDroplet := &korifiv1alpha1.CFDroplet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: dropletGUID,
+		},
+	}
+	err := r.klient.Get(ctx, cfDroplet)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, DropletResourceType)
+	}
+	return cfDroplet, nil
+}
+
+func (r *DropletRepo) cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
 	stagingStatus := getConditionValue(&cfBuild.Status.Conditions, StagingConditionType)
 	succeededStatus := getConditionValue(&cfBuild.Status.Conditions, SucceededConditionType)
 	if stagingStatus == metav1.ConditionFalse &&
 		succeededStatus == metav1.ConditionTrue {
-		return cfBuildToDropletRecord(*cfBuild), nil
+		return r.cfBuildToDropletRecord(*cfBuild), nil
 	}
 	return DropletRecord{}, apierrors.NewNotFoundError(nil, DropletResourceType)
 }
 
-func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+func (r *DropletRepo) cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 	processTypesMap := make(map[string]string)
 	processTypesArrayObject := cfBuild.Status.Droplet.ProcessTypes
 	for index := range processTypesArrayObject {
@@ -115,7 +202,8 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 
 	result := DropletRecord{
 		GUID:      cfBuild.Name,
-		State:     "STAGED",
+		SpaceGUID: cfBuild.Namespace,
+		State:     DropletStateStaged,
 		CreatedAt: cfBuild.CreationTimestamp.Time,
 		UpdatedAt: getLastUpdatedTime(&cfBuild),
 		Lifecycle: Lifecycle{
@@ -125,13 +213,15 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 				Stack:      cfBuild.Spec.Lifecycle.Data.Stack,
 			},
 		},
-		Stack:        cfBuild.Status.Droplet.Stack,
-		ProcessTypes: processTypesMap,
-		AppGUID:      cfBuild.Spec.AppRef.Name,
-		PackageGUID:  cfBuild.Spec.PackageRef.Name,
-		Labels:       cfBuild.Labels,
-		Annotations:  cfBuild.Annotations,
-		Ports:        cfBuild.Status.Droplet.Ports,
+		Stack:         cfBuild.Status.Droplet.Stack,
+		ProcessTypes:  processTypesMap,
+		AppGUID:       cfBuild.Spec.AppRef.Name,
+		PackageGUID:   cfBuild.Spec.PackageRef.Name,
+		Labels:        cfBuild.Labels,
+		Annotations:   cfBuild.Annotations,
+		Ports:         cfBuild.Status.Droplet.Ports,
+		ImageRef:      cfBuild.Status.Droplet.Registry.Image,
+		RepositoryRef: r.repositoryRef(cfBuild.Spec.AppRef.Name),
 	}
 
 	if cfBuild.Spec.Lifecycle.Type == "docker" {
@@ -142,6 +232,51 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 	return result
 }
 
+func (r *DropletRepo) cfDropletToDropletRecord(cfDroplet korifiv1alpha1.CFDroplet) DropletRecord {
+	processTypesMap := make(map[string]string)
+	for _, processType := range cfDroplet.Spec.ProcessTypes {
+		processTypesMap[processType.Type] = processType.Command
+	}
+
+	result := DropletRecord{
+		GUID:      cfDroplet.Name,
+		SpaceGUID: cfDroplet.Namespace,
+		State:     DropletStateAwaitingUpload,
+		CreatedAt: cfDroplet.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&cfDroplet),
+		Lifecycle: Lifecycle{
+			Type: string(cfDroplet.Spec.Lifecycle.Type),
+			Data: LifecycleData{
+				Buildpacks: []string{},
+				Stack:      cfDroplet.Spec.Lifecycle.Data.Stack,
+			},
+		},
+		Stack:         cfDroplet.Spec.Lifecycle.Data.Stack,
+		ProcessTypes:  processTypesMap,
+		AppGUID:       cfDroplet.Spec.AppRef.Name,
+		Labels:        cfDroplet.Labels,
+		Annotations:   cfDroplet.Annotations,
+		Ports:         cfDroplet.Spec.Ports,
+		RepositoryRef: r.repositoryRef(cfDroplet.Spec.AppRef.Name),
+	}
+
+	if cfDroplet.Spec.Registry != nil {
+		result.State = DropletStateStaged
+		result.ImageRef = cfDroplet.Spec.Registry.Image
+	}
+
+	if cfDroplet.Spec.Lifecycle.Type == "docker" {
+		result.Lifecycle.Data = LifecycleData{}
+		result.Image = result.ImageRef
+	}
+
+	return result
+}
+
+func (r *DropletRepo) repositoryRef(appGUID string) string {
+	return r.repositoryPrefix + appGUID + "-droplets"
+}
+
 func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.Info, message ListDropletsMessage) ([]DropletRecord, error) {
 	buildList := &korifiv1alpha1.CFBuildList{}
 	err := r.klient.List(ctx, buildList, WithLabels{Selector: labels.SelectorFromValidatedSet(message.createSelector())})
@@ -150,7 +285,82 @@ func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.I
 	}
 
 	filteredBuilds := itx.FromSlice(buildList.Items)
-	return slices.Collect(it.Map(filteredBuilds, cfBuildToDropletRecord)), nil
+	records := slices.Collect(it.Map(filteredBuilds, r.cfBuildToDropletRecord))
+
+	// uploaded droplets are not associated with a package
+	if len(message.PackageGUIDs) > 0 {
+		return records, nil
+	}
+
+	dropletList := &korifiv1alpha1.CFDropletList{}
+	err = r.klient.List(ctx, dropletList, WithLabels{Selector: labels.SelectorFromValidatedSet(message.createSelector())})
+	if err != nil {
+		return []DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	return append(records, slices.Collect(it.Map(itx.FromSlice(dropletList.Items), r.cfDropletToDropletRecord))...), nil
+}
+
+func (r *DropletRepo) CreateDroplet(ctx context.Context, authInfo authorization.Info, message CreateDropletMessage) (DropletRecord, error) {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.AppGUID,
+		},
+	}
+
+	err := r.klient.Get(ctx, cfApp)
+	if err != nil {
+		return DropletRecord{},
+			apierrors.AsUnprocessableEntity(
+				apierrors.FromK8sError(err, DropletResourceType),
+				"Referenced app not found. Ensure that the app exists and you have access to it.",
+				apierrors.ForbiddenError{},
+				apierrors.NotFoundError{},
+			)
+	}
+
+	cfDroplet := message.toCFDroplet(cfApp)
+	err = r.klient.Create(ctx, cfDroplet)
+	if err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(message.AppGUID))
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to create droplet repository: %w", err)
+	}
+
+	return r.cfDropletToDropletRecord(*cfDroplet), nil
+}
+
+func (r *DropletRepo) UpdateDropletImage(ctx context.Context, authInfo authorization.Info, message UpdateDropletImageMessage) (DropletRecord, error) {
+	cfDroplet := &korifiv1alpha1.CFDroplet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.GUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfDroplet); err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to get droplet: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	err := r.klient.Patch(ctx, cfDroplet, func() error {
+		cfDroplet.Spec.Registry = &korifiv1alpha1.Registry{
+			Image: message.ImageRef,
+			ImagePullSecrets: slices.Collect(
+				it.Map(slices.Values(message.RegistrySecretNames), func(secret string) corev1.LocalObjectReference {
+					return corev1.LocalObjectReference{Name: secret}
+				}),
+			),
+		}
+		return nil
+	})
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to update droplet image: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return r.cfDropletToDropletRecord(*cfDroplet), nil
 }
 
 type UpdateDropletMessage struct {
@@ -161,7 +371,11 @@ type UpdateDropletMessage struct {
 func (r *DropletRepo) UpdateDroplet(ctx context.Context, authInfo authorization.Info, message UpdateDropletMessage) (DropletRecord, error) {
 	build, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, message.GUID)
 	if err != nil {
-		return DropletRecord{}, err
+		if !errors.As(err, &apierrors.NotFoundError{}) {
+			return DropletRecord{}, err
+		}
+
+		return r.updateCFDroplet(ctx, message)
 	}
 
 	err = r.klient.Patch(ctx, build, func() error {
@@ -173,5 +387,23 @@ func (r *DropletRepo) UpdateDroplet(ctx context.Context, authInfo authorization.
 		return DropletRecord{}, fmt.Errorf("failed to patch droplet metadata: %w", apierrors.FromK8sError(err, DropletResourceType))
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
+}
+
+func (r *DropletRepo) updateCFDroplet(ctx context.Context, message UpdateDropletMessage) (DropletRecord, error) {
+	cfDroplet, err := r.getCFDroplet(ctx, message.GUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	err = r.klient.Patch(ctx, cfDroplet, func() error {
+		message.MetadataPatch.Apply(cfDroplet)
+
+		return nil
+	})
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to patch droplet metadata: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return r.cfDropletToDropletRecord(*cfDroplet), nil
 }
@@ -5,6 +5,7 @@ import (
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -32,6 +33,7 @@ var _ = Describe("DropletRepository", func() {
 
 	var (
 		dropletRepo *repositories.DropletRepo
+		repoCreator *fake.RepositoryCreator
 		org         *korifiv1alpha1.CFOrg
 		space       *korifiv1alpha1.CFSpace
 		build       *korifiv1alpha1.CFBuild
@@ -47,7 +49,8 @@ var _ = Describe("DropletRepository", func() {
 		org = createOrgWithCleanup(ctx, orgName)
 		space = createSpaceWithCleanup(ctx, org.Name, spaceName)
 
-		dropletRepo = repositories.NewDropletRepo(klient)
+		repoCreator = new(fake.RepositoryCreator)
+		dropletRepo = repositories.NewDropletRepo(klient, repoCreator, "container.registry/foo/my/prefix-")
 
 		build = &korifiv1alpha1.CFBuild{
 			ObjectMeta: metav1.ObjectMeta{
@@ -153,6 +156,9 @@ var _ = Describe("DropletRepository", func() {
 					Expect(dropletRecord.Lifecycle.Data.Buildpacks).To(BeEmpty())
 					Expect(dropletRecord.Lifecycle.Data.Stack).To(Equal(build.Spec.Lifecycle.Data.Stack))
 					Expect(dropletRecord.Image).To(BeEmpty())
+					Expect(dropletRecord.ImageRef).To(Equal(registryImage))
+					Expect(dropletRecord.RepositoryRef).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+					Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
 					Expect(dropletRecord.Ports).To(ConsistOf(int32(1234), int32(2345)))
 					Expect(dropletRecord.AppGUID).To(Equal(build.Spec.AppRef.Name))
 					Expect(dropletRecord.PackageGUID).To(Equal(build.Spec.PackageRef.Name))
@@ -262,6 +268,43 @@ var _ = Describe("DropletRepository", func() {
 				})
 			})
 
+			When("the droplet has been created via the API", func() {
+				var cfDroplet *korifiv1alpha1.CFDroplet
+
+				BeforeEach(func() {
+					cfDroplet = createUploadedDroplet(space.Name, appGUID)
+					fetchBuildGUID = cfDroplet.Name
+				})
+
+				It("returns a droplet awaiting upload", func() {
+					Expect(fetchErr).NotTo(HaveOccurred())
+					Expect(dropletRecord.GUID).To(Equal(cfDroplet.Name))
+					Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
+					Expect(dropletRecord.State).To(Equal("AWAITING_UPLOAD"))
+					Expect(dropletRecord.AppGUID).To(Equal(appGUID))
+					Expect(dropletRecord.PackageGUID).To(BeEmpty())
+					Expect(dropletRecord.Lifecycle.Type).To(Equal("buildpack"))
+					Expect(dropletRecord.Stack).To(Equal(dropletStack))
+					Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{"web": "bundle exec rackup"}))
+					Expect(dropletRecord.ImageRef).To(BeEmpty())
+					Expect(dropletRecord.RepositoryRef).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+				})
+
+				When("the droplet image has been set", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfDroplet, func() {
+							cfDroplet.Spec.Registry = &korifiv1alpha1.Registry{Image: registryImage}
+						})).To(Succeed())
+					})
+
+					It("returns a staged droplet", func() {
+						Expect(fetchErr).NotTo(HaveOccurred())
+						Expect(dropletRecord.State).To(Equal("STAGED"))
+						Expect(dropletRecord.ImageRef).To(Equal(registryImage))
+					})
+				})
+			})
+
 			When("build does not exist", func() {
 				BeforeEach(func() {
 					meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
@@ -395,6 +438,45 @@ var _ = Describe("DropletRepository", func() {
 					Expect(dropletRecords[0].AppGUID).To(Equal(appGUID))
 				})
 			})
+
+			When("there are droplets created via the API", func() {
+				var cfDroplet *korifiv1alpha1.CFDroplet
+
+				BeforeEach(func() {
+					cfDroplet = createUploadedDroplet(space.Name, appGUID)
+				})
+
+				It("returns them alongside the staged droplets", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(dropletRecords).To(HaveLen(3))
+				})
+
+				When("filtering by app guid", func() {
+					BeforeEach(func() {
+						message.AppGUIDs = []string{appGUID}
+					})
+
+					It("returns the matching droplets", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(dropletRecords).To(ConsistOf(
+							gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(buildGUID)}),
+							gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(cfDroplet.Name)}),
+						))
+					})
+				})
+
+				When("filtering by package guid", func() {
+					BeforeEach(func() {
+						message.PackageGUIDs = []string{packageGUID}
+					})
+
+					It("does not return them", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(dropletRecords).To(HaveLen(1))
+						Expect(dropletRecords[0].GUID).To(Equal(buildGUID))
+					})
+				})
+			})
 		})
 	})
 
@@ -613,6 +695,24 @@ var _ = Describe("DropletRepository", func() {
 				})
 			})
 
+			When("the droplet has been created via the API", func() {
+				var cfDroplet *korifiv1alpha1.CFDroplet
+
+				BeforeEach(func() {
+					cfDroplet = createUploadedDroplet(space.Name, appGUID)
+					dropletUpdateMsg.GUID = cfDroplet.Name
+				})
+
+				It("updates the droplet metadata in kubernetes", func() {
+					Expect(updateError).NotTo(HaveOccurred())
+					Expect(dropletRecord.GUID).To(Equal(cfDroplet.Name))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDroplet), cfDroplet)).To(Succeed())
+					Expect(cfDroplet.Labels).To(HaveKeyWithValue("key3", "val3"))
+					Expect(cfDroplet.Annotations).To(HaveKeyWithValue("key3", "val3"))
+				})
+			})
+
 			When("build does not exist", func() {
 				BeforeEach(func() {
 					meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
@@ -667,4 +767,163 @@ var _ = Describe("DropletRepository", func() {
 			})
 		})
 	})
+
+	Describe("CreateDroplet", func() {
+		var (
+			cfApp         *korifiv1alpha1.CFApp
+			message       repositories.CreateDropletMessage
+			dropletRecord repositories.DropletRecord
+			createErr     error
+		)
+
+		BeforeEach(func() {
+			cfApp = createApp(space.Name)
+			message = repositories.CreateDropletMessage{
+				AppGUID:      cfApp.Name,
+				SpaceGUID:    space.Name,
+				ProcessTypes: map[string]string{"web": "bundle exec rackup", "worker": "bundle exec sidekiq"},
+				Ports:        []int32{8080},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, createErr = dropletRepo.CreateDroplet(ctx, authInfo, message)
+		})
+
+		It("returns an unprocessable entity error to users who cannot see the app", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a droplet awaiting upload", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.GUID).To(matchers.BeValidUUID())
+				Expect(dropletRecord.State).To(Equal("AWAITING_UPLOAD"))
+				Expect(dropletRecord.AppGUID).To(Equal(cfApp.Name))
+				Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(dropletRecord.Lifecycle.Type).To(Equal("buildpack"))
+				Expect(dropletRecord.ProcessTypes).To(Equal(message.ProcessTypes))
+				Expect(dropletRecord.Ports).To(ConsistOf(int32(8080)))
+				Expect(dropletRecord.RepositoryRef).To(Equal("container.registry/foo/my/prefix-" + cfApp.Name + "-droplets"))
+
+				cfDroplet := &korifiv1alpha1.CFDroplet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: space.Name, Name: dropletRecord.GUID},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDroplet), cfDroplet)).To(Succeed())
+				Expect(cfDroplet.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name))
+				Expect(cfDroplet.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(cfDroplet.Spec.AppRef.Name).To(Equal(cfApp.Name))
+				Expect(cfDroplet.Spec.ProcessTypes).To(Equal([]korifiv1alpha1.ProcessType{
+					{Type: "web", Command: "bundle exec rackup"},
+					{Type: "worker", Command: "bundle exec sidekiq"},
+				}))
+				Expect(cfDroplet.Spec.Registry).To(BeNil())
+			})
+
+			It("makes the app the controller of the droplet", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfDroplet := &korifiv1alpha1.CFDroplet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: space.Name, Name: dropletRecord.GUID},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDroplet), cfDroplet)).To(Succeed())
+				Expect(cfDroplet.OwnerReferences).To(ConsistOf(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Kind":       Equal("CFApp"),
+					"Name":       Equal(cfApp.Name),
+					"UID":        Equal(cfApp.UID),
+					"Controller": gstruct.PointTo(BeTrue()),
+				})))
+			})
+
+			It("creates the droplet repository", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("container.registry/foo/my/prefix-" + cfApp.Name + "-droplets"))
+			})
+
+			When("the app does not exist", func() {
+				BeforeEach(func() {
+					message.AppGUID = "i-do-not-exist"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateDropletImage", func() {
+		var (
+			cfDroplet     *korifiv1alpha1.CFDroplet
+			dropletRecord repositories.DropletRecord
+			updateErr     error
+		)
+
+		BeforeEach(func() {
+			cfDroplet = createUploadedDroplet(space.Name, appGUID)
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, updateErr = dropletRepo.UpdateDropletImage(ctx, authInfo, repositories.UpdateDropletImageMessage{
+				GUID:                cfDroplet.Name,
+				SpaceGUID:           space.Name,
+				ImageRef:            "my-droplet@sha256:abc",
+				RegistrySecretNames: []string{"registry-secret"},
+			})
+		})
+
+		It("returns a forbidden error to users without access", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("sets the droplet image", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.State).To(Equal("STAGED"))
+				Expect(dropletRecord.ImageRef).To(Equal("my-droplet@sha256:abc"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDroplet), cfDroplet)).To(Succeed())
+				Expect(cfDroplet.Spec.Registry).To(gstruct.PointTo(Equal(korifiv1alpha1.Registry{
+					Image:            "my-droplet@sha256:abc",
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-secret"}},
+				})))
+			})
+		})
+	})
 })
+
+func createUploadedDroplet(spaceGUID, appGUID string) *korifiv1alpha1.CFDroplet {
+	cfDroplet := &korifiv1alpha1.CFDroplet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: spaceGUID,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				korifiv1alpha1.SpaceGUIDKey:      spaceGUID,
+			},
+		},
+		Spec: korifiv1alpha1.CFDropletSpec{
+			AppRef: corev1.LocalObjectReference{Name: appGUID},
+			Lifecycle: korifiv1alpha1.Lifecycle{
+				Type: "buildpack",
+				Data: korifiv1alpha1.LifecycleData{Stack: "cflinuxfs3"},
+			},
+			ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "bundle exec rackup"}},
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfDroplet)).To(Succeed())
+
+	return cfDroplet
+}
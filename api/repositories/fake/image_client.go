@@ -0,0 +1,469 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools/image"
+)
+
+type ImageClient struct {
+	CopyStub        func(context.Context, image.Creds, string, image.Creds, string, ...string) (string, error)
+	copyMutex       sync.RWMutex
+	copyArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 image.Creds
+		arg5 string
+		arg6 []string
+	}
+	copyReturns struct {
+		result1 string
+		result2 error
+	}
+	copyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	DownloadStub        func(context.Context, image.Creds, string) (io.ReadCloser, error)
+	downloadMutex       sync.RWMutex
+	downloadArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	downloadReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	downloadReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	DownloadTarballStub        func(context.Context, image.Creds, string) (io.ReadCloser, error)
+	downloadTarballMutex       sync.RWMutex
+	downloadTarballArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	downloadTarballReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	downloadTarballReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	PushStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
+	pushMutex       sync.RWMutex
+	pushArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	pushReturns struct {
+		result1 string
+		result2 error
+	}
+	pushReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PushTarballStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
+	pushTarballMutex       sync.RWMutex
+	pushTarballArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	pushTarballReturns struct {
+		result1 string
+		result2 error
+	}
+	pushTarballReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ImageClient) Copy(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 image.Creds, arg5 string, arg6 ...string) (string, error) {
+	fake.copyMutex.Lock()
+	ret, specificReturn := fake.copyReturnsOnCall[len(fake.copyArgsForCall)]
+	fake.copyArgsForCall = append(fake.copyArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 image.Creds
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CopyStub
+	fakeReturns := fake.copyReturns
+	fake.recordInvocation("Copy", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.copyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageClient) CopyCallCount() int {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	return len(fake.copyArgsForCall)
+}
+
+func (fake *ImageClient) CopyCalls(stub func(context.Context, image.Creds, string, image.Creds, string, ...string) (string, error)) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = stub
+}
+
+func (fake *ImageClient) CopyArgsForCall(i int) (context.Context, image.Creds, string, image.Creds, string, []string) {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	argsForCall := fake.copyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImageClient) CopyReturns(result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	fake.copyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) CopyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	if fake.copyReturnsOnCall == nil {
+		fake.copyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) Download(arg1 context.Context, arg2 image.Creds, arg3 string) (io.ReadCloser, error) {
+	fake.downloadMutex.Lock()
+	ret, specificReturn := fake.downloadReturnsOnCall[len(fake.downloadArgsForCall)]
+	fake.downloadArgsForCall = append(fake.downloadArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DownloadStub
+	fakeReturns := fake.downloadReturns
+	fake.recordInvocation("Download", []interface{}{arg1, arg2, arg3})
+	fake.downloadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageClient) DownloadCallCount() int {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return len(fake.downloadArgsForCall)
+}
+
+func (fake *ImageClient) DownloadCalls(stub func(context.Context, image.Creds, string) (io.ReadCloser, error)) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = stub
+}
+
+func (fake *ImageClient) DownloadArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	argsForCall := fake.downloadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImageClient) DownloadReturns(result1 io.ReadCloser, result2 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	fake.downloadReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) DownloadReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	if fake.downloadReturnsOnCall == nil {
+		fake.downloadReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.downloadReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) DownloadTarball(arg1 context.Context, arg2 image.Creds, arg3 string) (io.ReadCloser, error) {
+	fake.downloadTarballMutex.Lock()
+	ret, specificReturn := fake.downloadTarballReturnsOnCall[len(fake.downloadTarballArgsForCall)]
+	fake.downloadTarballArgsForCall = append(fake.downloadTarballArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DownloadTarballStub
+	fakeReturns := fake.downloadTarballReturns
+	fake.recordInvocation("DownloadTarball", []interface{}{arg1, arg2, arg3})
+	fake.downloadTarballMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageClient) DownloadTarballCallCount() int {
+	fake.downloadTarballMutex.RLock()
+	defer fake.downloadTarballMutex.RUnlock()
+	return len(fake.downloadTarballArgsForCall)
+}
+
+func (fake *ImageClient) DownloadTarballCalls(stub func(context.Context, image.Creds, string) (io.ReadCloser, error)) {
+	fake.downloadTarballMutex.Lock()
+	defer fake.downloadTarballMutex.Unlock()
+	fake.DownloadTarballStub = stub
+}
+
+func (fake *ImageClient) DownloadTarballArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.downloadTarballMutex.RLock()
+	defer fake.downloadTarballMutex.RUnlock()
+	argsForCall := fake.downloadTarballArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImageClient) DownloadTarballReturns(result1 io.ReadCloser, result2 error) {
+	fake.downloadTarballMutex.Lock()
+	defer fake.downloadTarballMutex.Unlock()
+	fake.DownloadTarballStub = nil
+	fake.downloadTarballReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) DownloadTarballReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.downloadTarballMutex.Lock()
+	defer fake.downloadTarballMutex.Unlock()
+	fake.DownloadTarballStub = nil
+	if fake.downloadTarballReturnsOnCall == nil {
+		fake.downloadTarballReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.downloadTarballReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) Push(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.pushMutex.Lock()
+	ret, specificReturn := fake.pushReturnsOnCall[len(fake.pushArgsForCall)]
+	fake.pushArgsForCall = append(fake.pushArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PushStub
+	fakeReturns := fake.pushReturns
+	fake.recordInvocation("Push", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.pushMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageClient) PushCallCount() int {
+	fake.pushMutex.RLock()
+	defer fake.pushMutex.RUnlock()
+	return len(fake.pushArgsForCall)
+}
+
+func (fake *ImageClient) PushCalls(stub func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)) {
+	fake.pushMutex.Lock()
+	defer fake.pushMutex.Unlock()
+	fake.PushStub = stub
+}
+
+func (fake *ImageClient) PushArgsForCall(i int) (context.Context, image.Creds, string, io.Reader, []string) {
+	fake.pushMutex.RLock()
+	defer fake.pushMutex.RUnlock()
+	argsForCall := fake.pushArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImageClient) PushReturns(result1 string, result2 error) {
+	fake.pushMutex.Lock()
+	defer fake.pushMutex.Unlock()
+	fake.PushStub = nil
+	fake.pushReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) PushReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushMutex.Lock()
+	defer fake.pushMutex.Unlock()
+	fake.PushStub = nil
+	if fake.pushReturnsOnCall == nil {
+		fake.pushReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) PushTarball(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.pushTarballMutex.Lock()
+	ret, specificReturn := fake.pushTarballReturnsOnCall[len(fake.pushTarballArgsForCall)]
+	fake.pushTarballArgsForCall = append(fake.pushTarballArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PushTarballStub
+	fakeReturns := fake.pushTarballReturns
+	fake.recordInvocation("PushTarball", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.pushTarballMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageClient) PushTarballCallCount() int {
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	return len(fake.pushTarballArgsForCall)
+}
+
+func (fake *ImageClient) PushTarballCalls(stub func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = stub
+}
+
+func (fake *ImageClient) PushTarballArgsForCall(i int) (context.Context, image.Creds, string, io.Reader, []string) {
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	argsForCall := fake.pushTarballArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImageClient) PushTarballReturns(result1 string, result2 error) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = nil
+	fake.pushTarballReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) PushTarballReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = nil
+	if fake.pushTarballReturnsOnCall == nil {
+		fake.pushTarballReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushTarballReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	fake.downloadTarballMutex.RLock()
+	defer fake.downloadTarballMutex.RUnlock()
+	fake.pushMutex.RLock()
+	defer fake.pushMutex.RUnlock()
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ImageClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repositories.ImageClient = new(ImageClient)
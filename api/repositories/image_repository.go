@@ -5,13 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"github.com/google/go-containerregistry/pkg/name"
 
 	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get,namespace=ROOT_NAMESPACE
@@ -19,35 +23,52 @@ import (
 
 const SourceImageResourceType = "SourceImage"
 
-//counterfeiter:generate -o fake -fake-name ImagePusher . ImagePusher
+//counterfeiter:generate -o fake -fake-name ImageClient . ImageClient
 
-type ImagePusher interface {
+type ImageClient interface {
 	Push(ctx context.Context, creds image.Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error)
+	Download(ctx context.Context, creds image.Creds, imageRef string) (io.ReadCloser, error)
+	Copy(ctx context.Context, srcCreds image.Creds, srcImageRef string, dstCreds image.Creds, dstRepoRef string, tags ...string) (string, error)
+	PushTarball(ctx context.Context, creds image.Creds, repoRef string, tarballReader io.Reader, tags ...string) (string, error)
+	DownloadTarball(ctx context.Context, creds image.Creds, imageRef string) (io.ReadCloser, error)
+}
+
+// RegistryCredentials are supplied by users to pull images of their own from
+// private registries
+type RegistryCredentials struct {
+	Username string
+	Password string
 }
 
 type ImageRepository struct {
 	klient              Klient
-	pusher              ImagePusher
+	imageClient         ImageClient
 	pushSecretNames     []string
 	pushSecretNamespace string
+	repositoryPrefix    string
+	importRegistries    []string
 }
 
 func NewImageRepository(
 	klient Klient,
-	pusher ImagePusher,
+	imageClient ImageClient,
 	pushSecretNames []string,
 	pushSecretNamespace string,
+	repositoryPrefix string,
+	importRegistries []string,
 ) *ImageRepository {
 	return &ImageRepository{
 		klient:              klient,
-		pusher:              pusher,
+		imageClient:         imageClient,
 		pushSecretNames:     pushSecretNames,
 		pushSecretNamespace: pushSecretNamespace,
+		repositoryPrefix:    normalizeRepositoryPrefix(repositoryPrefix),
+		importRegistries:    normalizeRegistries(importRegistries),
 	}
 }
 
 func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (string, error) {
-	authorized, err := r.canIPatchCFPackage(ctx, authInfo, spaceGUID)
+	authorized, err := r.canIOnCFPackages(ctx, authInfo, spaceGUID, "patch")
 	if err != nil {
 		return "", fmt.Errorf("checking auth to upload source image for failed: %w", err)
 	}
@@ -61,10 +82,7 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
 	}
 
-	pushedRef, err := r.pusher.Push(ctx, image.Creds{
-		Namespace:   r.pushSecretNamespace,
-		SecretNames: r.pushSecretNames,
-	}, imageRef, srcReader, tags...)
+	pushedRef, err := r.imageClient.Push(ctx, r.creds(), imageRef, srcReader, tags...)
 	if err != nil {
 		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pushing image ref '%s' failed: %w", imageRef, err))
 	}
@@ -72,19 +90,245 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
-func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
+func (r *ImageRepository) DownloadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error) {
+	authorized, err := r.canIOnCFPackages(ctx, authInfo, spaceGUID, "get")
+	if err != nil {
+		return nil, fmt.Errorf("checking auth to download source image failed: %w", err)
+	}
+
+	if !authorized {
+		return nil, apierrors.NewForbiddenError(errors.New("not authorized to get cfpackage"), PackageResourceType)
+	}
+
+	srcZip, err := r.imageClient.Download(ctx, r.creds(), imageRef)
+	if err != nil {
+		return nil, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("downloading image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return srcZip, nil
+}
+
+func (r *ImageRepository) CopySourceImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, dstRepoRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIOnCFPackages(ctx, authInfo, spaceGUID, "patch")
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy source image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
+	}
+
+	_, err = name.ParseReference(dstRepoRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", dstRepoRef))
+	}
+
+	copiedRef, err := r.imageClient.Copy(ctx, r.creds(), srcImageRef, r.creds(), dstRepoRef, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying image ref '%s' to '%s' failed: %w", srcImageRef, dstRepoRef, err))
+	}
+
+	return copiedRef, nil
+}
+
+func (r *ImageRepository) UploadDropletImage(ctx context.Context, authInfo authorization.Info, repoRef string, tarballReader io.Reader, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIOnCFDroplets(ctx, authInfo, spaceGUID, "patch")
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfdroplet"), DropletResourceType)
+	}
+
+	_, err = name.ParseReference(repoRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", repoRef))
+	}
+
+	pushedRef, err := r.imageClient.PushTarball(ctx, r.creds(), repoRef, tarballReader, tags...)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(fmt.Errorf("pushing image tarball to '%s' failed: %w", repoRef, err), "Droplet bits must be a container image tarball.")
+	}
+
+	return pushedRef, nil
+}
+
+func (r *ImageRepository) DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error) {
+	authorized, err := r.canIOnCFDroplets(ctx, authInfo, spaceGUID, "get")
+	if err != nil {
+		return nil, fmt.Errorf("checking auth to download droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return nil, apierrors.NewForbiddenError(errors.New("not authorized to get cfdroplet"), DropletResourceType)
+	}
+
+	imageTarball, err := r.imageClient.DownloadTarball(ctx, r.creds(), imageRef)
+	if err != nil {
+		return nil, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("downloading image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return imageTarball, nil
+}
+
+// CopyDropletImage copies srcImageRef into the droplet repository dstRepoRef.
+// Images from the platform repositories are read with the push secret, but
+// only when the user can read the droplets or packages of the app owning the
+// repository. Any other image is read with the credentials the user supplied,
+// or anonymously, so that users cannot get the API to read images on their
+// behalf that only the platform has access to.
+func (r *ImageRepository) CopyDropletImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, srcCreds RegistryCredentials, dstRepoRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIOnCFDroplets(ctx, authInfo, spaceGUID, "patch")
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfdroplet"), DropletResourceType)
+	}
+
+	srcRef, err := name.ParseReference(srcImageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", srcImageRef))
+	}
+
+	_, err = name.ParseReference(dstRepoRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", dstRepoRef))
+	}
+
+	srcImageCreds, err := r.sourceImageCreds(ctx, authInfo, srcRef, srcCreds)
+	if err != nil {
+		return "", err
+	}
+
+	copiedRef, err := r.imageClient.Copy(ctx, srcImageCreds, srcImageRef, r.creds(), dstRepoRef, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying image ref '%s' to '%s' failed: %w", srcImageRef, dstRepoRef, err))
+	}
+
+	return copiedRef, nil
+}
+
+func (r *ImageRepository) sourceImageCreds(ctx context.Context, authInfo authorization.Info, srcRef name.Reference, srcCreds RegistryCredentials) (image.Creds, error) {
+	repoName, isPlatformImage := strings.CutPrefix(srcRef.Context().Name(), r.repositoryPrefix)
+	if !isPlatformImage {
+		if !slices.Contains(r.importRegistries, srcRef.Context().RegistryStr()) {
+			return image.Creds{}, apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("registry %q is not allowed", srcRef.Context().RegistryStr()),
+				fmt.Sprintf("Image %q must be in a registry droplet images may be imported from.", srcRef.Name()),
+			)
+		}
+
+		if srcCreds.Username != "" {
+			return image.Creds{Username: srcCreds.Username, Password: srcCreds.Password}, nil
+		}
+		return image.Creds{Anonymous: true}, nil
+	}
+
+	notPermittedErr := apierrors.NewUnprocessableEntityError(
+		fmt.Errorf("image %q is in the platform repositories", srcRef.Name()),
+		fmt.Sprintf("Image %q must be an image of a droplet or package you have access to.", srcRef.Name()),
+	)
+
+	appGUID, isDropletImage := strings.CutSuffix(repoName, "-droplets")
+	if !isDropletImage {
+		var isPackageImage bool
+		if appGUID, isPackageImage = strings.CutSuffix(repoName, "-packages"); !isPackageImage {
+			return image.Creds{}, notPermittedErr
+		}
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: appGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfApp); err != nil {
+		err = apierrors.FromK8sError(err, AppResourceType)
+		if errors.As(err, &apierrors.NotFoundError{}) || errors.As(err, &apierrors.ForbiddenError{}) {
+			return image.Creds{}, notPermittedErr
+		}
+		return image.Creds{}, fmt.Errorf("failed to get the app owning image %q: %w", srcRef.Name(), err)
+	}
+
+	canRead := r.canIOnCFPackages
+	if isDropletImage {
+		canRead = r.canIOnCFDroplets
+	}
+
+	authorized, err := canRead(ctx, authInfo, cfApp.Namespace, "get")
+	if err != nil {
+		return image.Creds{}, fmt.Errorf("checking auth to read image %q failed: %w", srcRef.Name(), err)
+	}
+
+	if !authorized {
+		return image.Creds{}, notPermittedErr
+	}
+
+	return r.creds(), nil
+}
+
+// normalizeRegistries expands the registries the way image references are
+// expanded, e.g. docker.io to index.docker.io, so that they can be matched
+// against the registry of parsed references
+func normalizeRegistries(registries []string) []string {
+	normalized := []string{}
+	for _, registry := range registries {
+		reg, err := name.NewRegistry(registry)
+		if err != nil {
+			normalized = append(normalized, registry)
+			continue
+		}
+		normalized = append(normalized, reg.RegistryStr())
+	}
+
+	return normalized
+}
+
+// normalizeRepositoryPrefix expands the registry of the prefix the way image
+// references are expanded, e.g. docker.io/ to index.docker.io/, so that it
+// can be matched against the repository name of parsed references
+func normalizeRepositoryPrefix(repositoryPrefix string) string {
+	const placeholder = "placeholder"
+
+	repo, err := name.NewRepository(repositoryPrefix + placeholder)
+	if err != nil {
+		return repositoryPrefix
+	}
+
+	return strings.TrimSuffix(repo.Name(), placeholder)
+}
+
+func (r *ImageRepository) creds() image.Creds {
+	return image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}
+}
+
+func (r *ImageRepository) canIOnCFPackages(ctx context.Context, authInfo authorization.Info, spaceGUID string, verb string) (bool, error) {
+	return r.canI(ctx, spaceGUID, verb, "cfpackages", PackageResourceType)
+}
+
+func (r *ImageRepository) canIOnCFDroplets(ctx context.Context, authInfo authorization.Info, spaceGUID string, verb string) (bool, error) {
+	return r.canI(ctx, spaceGUID, verb, "cfdroplets", DropletResourceType)
+}
+
+func (r *ImageRepository) canI(ctx context.Context, spaceGUID string, verb string, resource string, resourceType string) (bool, error) {
 	review := authv1.SelfSubjectAccessReview{
 		Spec: authv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authv1.ResourceAttributes{
 				Namespace: spaceGUID,
-				Verb:      "patch",
+				Verb:      verb,
 				Group:     "korifi.cloudfoundry.org",
-				Resource:  "cfpackages",
+				Resource:  resource,
 			},
 		},
 	}
 	if err := r.klient.Create(ctx, &review); err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create self subject access review: %w", apierrors.FromK8sError(err, PackageResourceType))
+		return false, fmt.Errorf("failed to create self subject access review on %s: %w", resource, apierrors.FromK8sError(err, resourceType))
 	}
 
 	return review.Status.Allowed, nil
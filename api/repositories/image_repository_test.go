@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -16,90 +18,479 @@ import (
 
 var _ = Describe("ImageRepository", func() {
 	var (
-		imagePusher *fake.ImagePusher
-		imageSource io.Reader
+		imageClient *fake.ImageClient
 		imageRepo   *repositories.ImageRepository
-		imageName   string
-		imageRef    string
-		tags        []string
-		uploadErr   error
 		org         *korifiv1alpha1.CFOrg
 		space       *korifiv1alpha1.CFSpace
 	)
 
 	BeforeEach(func() {
-		imageName = "my-image"
-		imagePusher = new(fake.ImagePusher)
-		imagePusher.PushReturns("my-pushed-image", nil)
-
-		imageSource = bytes.NewBufferString("")
-
-		var err error
-		Expect(err).NotTo(HaveOccurred())
+		imageClient = new(fake.ImageClient)
 
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
 
-		tags = []string{"foo", "bar"}
-
 		imageRepo = repositories.NewImageRepository(
 			klientUnfiltered,
-			imagePusher,
+			imageClient,
 			[]string{"push-secret-name"},
 			rootNamespace,
+			"docker.io/korifi/prefix-",
+			[]string{"registry.example.com"},
 		)
 	})
 
-	JustBeforeEach(func() {
-		imageRef, uploadErr = imageRepo.UploadSourceImage(ctx, authInfo, imageName, imageSource, space.Name, tags...)
+	Describe("UploadSourceImage", func() {
+		var (
+			imageSource io.Reader
+			imageName   string
+			imageRef    string
+			tags        []string
+			uploadErr   error
+		)
+
+		BeforeEach(func() {
+			imageName = "my-image"
+			imageClient.PushReturns("my-pushed-image", nil)
+
+			imageSource = bytes.NewBufferString("")
+
+			tags = []string{"foo", "bar"}
+		})
+
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadSourceImage(ctx, authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("succeeds", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(imageClient.PushCallCount()).To(Equal(1))
+				_, creds, actualRef, zipReader, actualTags := imageClient.PushArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(zipReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("fails with an easy to understand unprocessible entity error ", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+				})
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imageClient.PushReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				})
+			})
+		})
 	})
 
-	It("fails with unauthorized error without a valid role in the space", func() {
-		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+	Describe("DownloadSourceImage", func() {
+		var (
+			srcZip      io.ReadCloser
+			downloadErr error
+		)
+
+		BeforeEach(func() {
+			imageClient.DownloadReturns(io.NopCloser(strings.NewReader("the-zip")), nil)
+		})
+
+		JustBeforeEach(func() {
+			srcZip, downloadErr = imageRepo.DownloadSourceImage(ctx, authInfo, "my-image@sha256:abc", space.Name)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("downloads the image from the registry", func() {
+				Expect(downloadErr).NotTo(HaveOccurred())
+				content, err := io.ReadAll(srcZip)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("the-zip"))
+
+				Expect(imageClient.DownloadCallCount()).To(Equal(1))
+				_, creds, actualRef := imageClient.DownloadArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image@sha256:abc"))
+			})
+
+			When("downloading the image fails", func() {
+				BeforeEach(func() {
+					imageClient.DownloadReturns(nil, errors.New("download-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(downloadErr).To(MatchError(ContainSubstring("download-error")))
+					Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
+
+		When("user has role SpaceAuditor", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("fails with unauthorized error", func() {
+				Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
 	})
 
-	When("user has role SpaceDeveloper", func() {
+	Describe("CopySourceImage", func() {
+		var (
+			dstRepoRef string
+			copiedRef  string
+			copyErr    error
+		)
+
 		BeforeEach(func() {
-			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			dstRepoRef = "my-other-image"
+			imageClient.CopyReturns("my-copied-image", nil)
 		})
 
-		It("succeeds", func() {
-			Expect(uploadErr).NotTo(HaveOccurred())
-			Expect(imageRef).To(Equal("my-pushed-image"))
+		JustBeforeEach(func() {
+			copiedRef, copyErr = imageRepo.CopySourceImage(ctx, authInfo, "my-image@sha256:abc", dstRepoRef, space.Name, "pkg-guid")
 		})
 
-		It("uploads the image to the registry", func() {
-			Expect(imagePusher.PushCallCount()).To(Equal(1))
-			_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
-			Expect(creds.Namespace).To(Equal(rootNamespace))
-			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
-			Expect(actualRef).To(Equal("my-image"))
-			Expect(zipReader).To(Equal(imageSource))
-			Expect(actualTags).To(Equal(tags))
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
-		When("the image name is invalid", func() {
+		When("user has role SpaceDeveloper", func() {
 			BeforeEach(func() {
-				imageName = "invAlid-image"
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("copies the image within the registry", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(copiedRef).To(Equal("my-copied-image"))
+
+				Expect(imageClient.CopyCallCount()).To(Equal(1))
+				_, srcCreds, actualSrcRef, dstCreds, actualDstRef, actualTags := imageClient.CopyArgsForCall(0)
+				Expect(srcCreds.Namespace).To(Equal(rootNamespace))
+				Expect(srcCreds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(dstCreds).To(Equal(srcCreds))
+				Expect(actualSrcRef).To(Equal("my-image@sha256:abc"))
+				Expect(actualDstRef).To(Equal("my-other-image"))
+				Expect(actualTags).To(ConsistOf("pkg-guid"))
 			})
 
-			It("fails with an easy to understand unprocessible entity error ", func() {
-				var apiError apierrors.UnprocessableEntityError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+			When("the destination ref is invalid", func() {
+				BeforeEach(func() {
+					dstRepoRef = "invAlid-image"
+				})
+
+				It("fails with an unprocessable entity error", func() {
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imageClient.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
 			})
 		})
+	})
+
+	Describe("UploadDropletImage", func() {
+		var (
+			imageTarball io.Reader
+			repoRef      string
+			imageRef     string
+			uploadErr    error
+		)
+
+		BeforeEach(func() {
+			repoRef = "my-droplets"
+			imageClient.PushTarballReturns("my-pushed-droplet", nil)
+
+			imageTarball = bytes.NewBufferString("the-tarball")
+		})
+
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadDropletImage(ctx, authInfo, repoRef, imageTarball, space.Name, "droplet-guid")
+		})
 
-		When("pushing the image fails", func() {
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
 			BeforeEach(func() {
-				imagePusher.PushReturns("", errors.New("push-error"))
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
 			})
 
-			It("fails with a blobstore unavailable error", func() {
-				Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
-				var apiError apierrors.BlobstoreUnavailableError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+			It("pushes the image tarball to the registry", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-droplet"))
+
+				Expect(imageClient.PushTarballCallCount()).To(Equal(1))
+				_, creds, actualRef, tarballReader, actualTags := imageClient.PushTarballArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-droplets"))
+				Expect(tarballReader).To(Equal(imageTarball))
+				Expect(actualTags).To(ConsistOf("droplet-guid"))
+			})
+
+			When("the repository ref is invalid", func() {
+				BeforeEach(func() {
+					repoRef = "invAlid-image"
+				})
+
+				It("fails with an unprocessable entity error", func() {
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("pushing the tarball fails", func() {
+				BeforeEach(func() {
+					imageClient.PushTarballReturns("", errors.New("push-error"))
+				})
+
+				It("fails with an unprocessable entity error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal("Droplet bits must be a container image tarball."))
+				})
+			})
+		})
+	})
+
+	Describe("DownloadDropletImage", func() {
+		var (
+			imageTarball io.ReadCloser
+			downloadErr  error
+		)
+
+		BeforeEach(func() {
+			imageClient.DownloadTarballReturns(io.NopCloser(strings.NewReader("the-tarball")), nil)
+		})
+
+		JustBeforeEach(func() {
+			imageTarball, downloadErr = imageRepo.DownloadDropletImage(ctx, authInfo, "my-droplet@sha256:abc", space.Name)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("downloads the image from the registry as a tarball", func() {
+				Expect(downloadErr).NotTo(HaveOccurred())
+				content, err := io.ReadAll(imageTarball)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("the-tarball"))
+
+				Expect(imageClient.DownloadTarballCallCount()).To(Equal(1))
+				_, creds, actualRef := imageClient.DownloadTarballArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(actualRef).To(Equal("my-droplet@sha256:abc"))
+			})
+
+			When("downloading the image fails", func() {
+				BeforeEach(func() {
+					imageClient.DownloadTarballReturns(nil, errors.New("download-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(downloadErr).To(MatchError(ContainSubstring("download-error")))
+					Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
+
+		When("user has role SpaceAuditor", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("fails with unauthorized error", func() {
+				Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("CopyDropletImage", func() {
+		const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+		var (
+			srcImageRef string
+			srcCreds    repositories.RegistryCredentials
+			copiedRef   string
+			copyErr     error
+		)
+
+		BeforeEach(func() {
+			srcImageRef = "registry.example.com/my-droplet@" + digest
+			srcCreds = repositories.RegistryCredentials{}
+			imageClient.CopyReturns("my-copied-droplet", nil)
+		})
+
+		JustBeforeEach(func() {
+			copiedRef, copyErr = imageRepo.CopyDropletImage(ctx, authInfo, srcImageRef, srcCreds, "my-other-droplets", space.Name, "droplet-guid")
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("copies the image into the destination repository", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(copiedRef).To(Equal("my-copied-droplet"))
+
+				Expect(imageClient.CopyCallCount()).To(Equal(1))
+				_, _, actualSrcRef, dstCreds, actualDstRef, actualTags := imageClient.CopyArgsForCall(0)
+				Expect(actualSrcRef).To(Equal("registry.example.com/my-droplet@" + digest))
+				Expect(dstCreds.Namespace).To(Equal(rootNamespace))
+				Expect(dstCreds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualDstRef).To(Equal("my-other-droplets"))
+				Expect(actualTags).To(ConsistOf("droplet-guid"))
+			})
+
+			It("pulls the image anonymously", func() {
+				Expect(imageClient.CopyCallCount()).To(Equal(1))
+				_, actualSrcCreds, _, _, _, _ := imageClient.CopyArgsForCall(0)
+				Expect(actualSrcCreds).To(Equal(image.Creds{Anonymous: true}))
+			})
+
+			When("the user supplies registry credentials", func() {
+				BeforeEach(func() {
+					srcCreds = repositories.RegistryCredentials{Username: "user", Password: "pass"}
+				})
+
+				It("pulls the image with them", func() {
+					Expect(imageClient.CopyCallCount()).To(Equal(1))
+					_, actualSrcCreds, _, _, _, _ := imageClient.CopyArgsForCall(0)
+					Expect(actualSrcCreds).To(Equal(image.Creds{Username: "user", Password: "pass"}))
+				})
+			})
+
+			When("the image is a droplet image of an app in the space", func() {
+				BeforeEach(func() {
+					cfApp := createApp(space.Name)
+					srcImageRef = "korifi/prefix-" + cfApp.Name + "-droplets@" + digest
+				})
+
+				It("pulls the image with the push secret", func() {
+					Expect(copyErr).NotTo(HaveOccurred())
+					Expect(imageClient.CopyCallCount()).To(Equal(1))
+					_, actualSrcCreds, _, _, _, _ := imageClient.CopyArgsForCall(0)
+					Expect(actualSrcCreds.Namespace).To(Equal(rootNamespace))
+					Expect(actualSrcCreds.SecretNames).To(ConsistOf("push-secret-name"))
+				})
+			})
+
+			When("the image is a droplet image of an app the user cannot access", func() {
+				BeforeEach(func() {
+					otherSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+					cfApp := createApp(otherSpace.Name)
+					srcImageRef = "docker.io/korifi/prefix-" + cfApp.Name + "-droplets@" + digest
+				})
+
+				It("fails with an unprocessable entity error", func() {
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(imageClient.CopyCallCount()).To(BeZero())
+				})
+			})
+
+			When("the image is in a platform repository that does not belong to an app", func() {
+				BeforeEach(func() {
+					srcImageRef = "korifi/prefix-builder@" + digest
+				})
+
+				It("fails with an unprocessable entity error", func() {
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(imageClient.CopyCallCount()).To(BeZero())
+				})
+			})
+
+			When("the image is in a registry droplet images may not be imported from", func() {
+				BeforeEach(func() {
+					srcImageRef = "internal-registry.cluster.local:5000/my-droplet@" + digest
+				})
+
+				It("fails with an unprocessable entity error", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(copyErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(ContainSubstring("must be in a registry droplet images may be imported from"))
+					Expect(imageClient.CopyCallCount()).To(BeZero())
+				})
+			})
+
+			When("the source ref is invalid", func() {
+				BeforeEach(func() {
+					srcImageRef = "invAlid-image"
+				})
+
+				It("fails with an unprocessable entity error", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(copyErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+				})
+			})
+
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imageClient.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
 			})
 		})
 	})
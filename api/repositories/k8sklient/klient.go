@@ -138,10 +138,16 @@ func getResourceType(obj client.Object) (string, error) {
 	switch obj.(type) {
 	case *korifiv1alpha1.CFApp:
 		return repositories.AppResourceType, nil
+	case *korifiv1alpha1.CFAppRevision:
+		return repositories.RevisionResourceType, nil
+	case *korifiv1alpha1.CFAuditEvent:
+		return repositories.AuditEventResourceType, nil
 	case *korifiv1alpha1.CFBuild:
 		return repositories.BuildResourceType, nil
 	case *korifiv1alpha1.CFDomain:
 		return repositories.DomainResourceType, nil
+	case *korifiv1alpha1.CFDroplet:
+		return repositories.UploadedDropletResourceType, nil
 	case *korifiv1alpha1.CFPackage:
 		return repositories.PackageResourceType, nil
 	case *korifiv1alpha1.CFProcess:
@@ -152,6 +158,8 @@ func getResourceType(obj client.Object) (string, error) {
 		return repositories.RouteResourceType, nil
 	case *korifiv1alpha1.CFServiceBinding:
 		return repositories.ServiceBindingResourceType, nil
+	case *korifiv1alpha1.CFServiceBroker:
+		return repositories.ServiceBrokerResourceType, nil
 	case *korifiv1alpha1.CFServiceInstance:
 		return repositories.ServiceInstanceResourceType, nil
 	case *korifiv1alpha1.CFTask:
@@ -11,9 +11,11 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfdroplets;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains;cfroutes,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapprevisions,verbs=list
 
 var (
 	CFAppsGVR = schema.GroupVersionResource{
@@ -22,6 +24,18 @@ var (
 		Resource: "cfapps",
 	}
 
+	CFAppRevisionsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfapprevisions",
+	}
+
+	CFAuditEventsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfauditevents",
+	}
+
 	CFBuildsGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -40,6 +54,12 @@ var (
 		Resource: "cfbuilds",
 	}
 
+	CFUploadedDropletsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfdroplets",
+	}
+
 	CFPackagesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -64,6 +84,12 @@ var (
 		Resource: "cfservicebindings",
 	}
 
+	CFServiceBrokersGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfservicebrokers",
+	}
+
 	CFServiceInstancesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -84,16 +110,20 @@ var (
 
 	ResourceMap = map[string]schema.GroupVersionResource{
 		AppResourceType:             CFAppsGVR,
+		AuditEventResourceType:      CFAuditEventsGVR,
 		BuildResourceType:           CFBuildsGVR,
 		DropletResourceType:         CFDropletsGVR,
 		DomainResourceType:          CFDomainsGVR,
 		PackageResourceType:         CFPackagesGVR,
 		ProcessResourceType:         CFProcessesGVR,
+		RevisionResourceType:        CFAppRevisionsGVR,
 		RouteResourceType:           CFRoutesGVR,
 		ServiceBindingResourceType:  CFServiceBindingsGVR,
+		ServiceBrokerResourceType:   CFServiceBrokersGVR,
 		ServiceInstanceResourceType: CFServiceInstancesGVR,
 		SpaceResourceType:           CFSpacesGVR,
 		TaskResourceType:            CFTasksGVR,
+		UploadedDropletResourceType: CFUploadedDropletsGVR,
 	}
 )
 
@@ -0,0 +1,376 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const OrgQuotaResourceType = "Organization Quota"
+
+// QuotaLimits are the limits of an org or space quota. A nil limit is unlimited.
+type QuotaLimits struct {
+	Apps     AppQuotaLimits
+	Services ServiceQuotaLimits
+	Routes   RouteQuotaLimits
+}
+
+type AppQuotaLimits struct {
+	TotalMemoryInMB              *int64
+	PerProcessMemoryInMB         *int64
+	TotalInstances               *int64
+	PerAppTasks                  *int64
+	LogRateLimitInBytesPerSecond *int64
+}
+
+type ServiceQuotaLimits struct {
+	TotalServiceInstances *int64
+}
+
+type RouteQuotaLimits struct {
+	TotalRoutes *int64
+}
+
+// QuotaLimitPatch sets a single quota limit. A patch with a nil value makes
+// the limit unlimited.
+type QuotaLimitPatch struct {
+	Value *int64
+}
+
+// QuotaLimitsPatch is a partial update of quota limits. Limits with a nil
+// patch are left unchanged.
+type QuotaLimitsPatch struct {
+	Apps     AppQuotaLimitsPatch
+	Services ServiceQuotaLimitsPatch
+	Routes   RouteQuotaLimitsPatch
+}
+
+type AppQuotaLimitsPatch struct {
+	TotalMemoryInMB              *QuotaLimitPatch
+	PerProcessMemoryInMB         *QuotaLimitPatch
+	TotalInstances               *QuotaLimitPatch
+	PerAppTasks                  *QuotaLimitPatch
+	LogRateLimitInBytesPerSecond *QuotaLimitPatch
+}
+
+type ServiceQuotaLimitsPatch struct {
+	TotalServiceInstances *QuotaLimitPatch
+}
+
+type RouteQuotaLimitsPatch struct {
+	TotalRoutes *QuotaLimitPatch
+}
+
+func (p QuotaLimitsPatch) apply(limits *korifiv1alpha1.QuotaLimits) {
+	applyQuotaLimitPatch(&limits.Apps.TotalMemoryInMB, p.Apps.TotalMemoryInMB)
+	applyQuotaLimitPatch(&limits.Apps.PerProcessMemoryInMB, p.Apps.PerProcessMemoryInMB)
+	applyQuotaLimitPatch(&limits.Apps.TotalInstances, p.Apps.TotalInstances)
+	applyQuotaLimitPatch(&limits.Apps.PerAppTasks, p.Apps.PerAppTasks)
+	applyQuotaLimitPatch(&limits.Apps.LogRateLimitInBytesPerSecond, p.Apps.LogRateLimitInBytesPerSecond)
+	applyQuotaLimitPatch(&limits.Services.TotalServiceInstances, p.Services.TotalServiceInstances)
+	applyQuotaLimitPatch(&limits.Routes.TotalRoutes, p.Routes.TotalRoutes)
+}
+
+func applyQuotaLimitPatch(limit **int64, patch *QuotaLimitPatch) {
+	if patch == nil {
+		return
+	}
+
+	*limit = patch.Value
+}
+
+func toCFQuotaLimits(limits QuotaLimits) korifiv1alpha1.QuotaLimits {
+	return korifiv1alpha1.QuotaLimits{
+		Apps: korifiv1alpha1.QuotaAppLimits{
+			TotalMemoryInMB:              limits.Apps.TotalMemoryInMB,
+			PerProcessMemoryInMB:         limits.Apps.PerProcessMemoryInMB,
+			TotalInstances:               limits.Apps.TotalInstances,
+			PerAppTasks:                  limits.Apps.PerAppTasks,
+			LogRateLimitInBytesPerSecond: limits.Apps.LogRateLimitInBytesPerSecond,
+		},
+		Services: korifiv1alpha1.QuotaServiceLimits{
+			TotalServiceInstances: limits.Services.TotalServiceInstances,
+		},
+		Routes: korifiv1alpha1.QuotaRouteLimits{
+			TotalRoutes: limits.Routes.TotalRoutes,
+		},
+	}
+}
+
+func toQuotaLimits(limits korifiv1alpha1.QuotaLimits) QuotaLimits {
+	return QuotaLimits{
+		Apps: AppQuotaLimits{
+			TotalMemoryInMB:              limits.Apps.TotalMemoryInMB,
+			PerProcessMemoryInMB:         limits.Apps.PerProcessMemoryInMB,
+			TotalInstances:               limits.Apps.TotalInstances,
+			PerAppTasks:                  limits.Apps.PerAppTasks,
+			LogRateLimitInBytesPerSecond: limits.Apps.LogRateLimitInBytesPerSecond,
+		},
+		Services: ServiceQuotaLimits{
+			TotalServiceInstances: limits.Services.TotalServiceInstances,
+		},
+		Routes: RouteQuotaLimits{
+			TotalRoutes: limits.Routes.TotalRoutes,
+		},
+	}
+}
+
+type OrgQuotaRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewOrgQuotaRepo(
+	klient Klient,
+	rootNamespace string,
+) *OrgQuotaRepo {
+	return &OrgQuotaRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+type CreateOrgQuotaMessage struct {
+	Name     string
+	Limits   QuotaLimits
+	OrgGUIDs []string
+}
+
+type ListOrgQuotasMessage struct {
+	GUIDs    []string
+	Names    []string
+	OrgGUIDs []string
+}
+
+func (m *ListOrgQuotasMessage) matches(record OrgQuotaRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, record.GUID) &&
+		tools.EmptyOrContains(m.Names, record.Name) &&
+		emptyOrIntersects(m.OrgGUIDs, record.OrgGUIDs)
+}
+
+type UpdateOrgQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits QuotaLimitsPatch
+}
+
+func (m UpdateOrgQuotaMessage) apply(cfOrgQuota *korifiv1alpha1.CFOrgQuota) {
+	if m.Name != nil {
+		cfOrgQuota.Spec.DisplayName = *m.Name
+	}
+
+	m.Limits.apply(&cfOrgQuota.Spec.QuotaLimits)
+}
+
+type ApplyOrgQuotaMessage struct {
+	GUID     string
+	OrgGUIDs []string
+}
+
+type OrgQuotaRecord struct {
+	GUID      string
+	Name      string
+	Limits    QuotaLimits
+	OrgGUIDs  []string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+}
+
+func (r *OrgQuotaRepo) CreateOrgQuota(ctx context.Context, authInfo authorization.Info, message CreateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFOrgQuotaSpec{
+			DisplayName: message.Name,
+			QuotaLimits: toCFQuotaLimits(message.Limits),
+		},
+	}
+
+	if err := r.klient.Create(ctx, cfOrgQuota); err != nil {
+		return OrgQuotaRecord{}, apierrors.FromK8sError(err, OrgQuotaResourceType)
+	}
+
+	if err := r.setOrgsQuota(ctx, cfOrgQuota.Name, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota, message.OrgGUIDs), nil
+}
+
+func (r *OrgQuotaRepo) GetOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) (OrgQuotaRecord, error) {
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfOrgQuota); err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to get org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	orgGUIDsByQuota, err := r.orgGUIDsByQuota(ctx)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota, orgGUIDsByQuota[guid]), nil
+}
+
+func (r *OrgQuotaRepo) ListOrgQuotas(ctx context.Context, authInfo authorization.Info, message ListOrgQuotasMessage) ([]OrgQuotaRecord, error) {
+	cfOrgQuotaList := &korifiv1alpha1.CFOrgQuotaList{}
+	if err := r.klient.List(ctx, cfOrgQuotaList, InNamespace(r.rootNamespace)); err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []OrgQuotaRecord{}, nil
+		}
+		return []OrgQuotaRecord{}, fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	orgGUIDsByQuota, err := r.orgGUIDsByQuota(ctx)
+	if err != nil {
+		return []OrgQuotaRecord{}, err
+	}
+
+	orgQuotaRecords := slices.Collect(itx.From(it.Map(
+		slices.Values(cfOrgQuotaList.Items),
+		func(cfOrgQuota korifiv1alpha1.CFOrgQuota) OrgQuotaRecord {
+			return toOrgQuotaRecord(cfOrgQuota, orgGUIDsByQuota[cfOrgQuota.Name])
+		},
+	)).Filter(message.matches).Seq())
+	sort.Slice(orgQuotaRecords, func(i, j int) bool {
+		return orgQuotaRecords[i].CreatedAt.Before(orgQuotaRecords[j].CreatedAt)
+	})
+
+	return orgQuotaRecords, nil
+}
+
+func (r *OrgQuotaRepo) UpdateOrgQuota(ctx context.Context, authInfo authorization.Info, message UpdateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	if err := GetAndPatch(ctx, r.klient, cfOrgQuota, func() error {
+		message.apply(cfOrgQuota)
+		return nil
+	}); err != nil {
+		return OrgQuotaRecord{}, apierrors.FromK8sError(err, OrgQuotaResourceType)
+	}
+
+	orgGUIDsByQuota, err := r.orgGUIDsByQuota(ctx)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota, orgGUIDsByQuota[message.GUID]), nil
+}
+
+func (r *OrgQuotaRepo) ApplyOrgQuota(ctx context.Context, authInfo authorization.Info, message ApplyOrgQuotaMessage) (OrgQuotaRecord, error) {
+	if err := r.setOrgsQuota(ctx, message.GUID, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return r.GetOrgQuota(ctx, authInfo, message.GUID)
+}
+
+func (r *OrgQuotaRepo) DeleteOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	orgGUIDsByQuota, err := r.orgGUIDsByQuota(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(orgGUIDsByQuota[guid]) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, "This quota is applied to one or more orgs. Remove this quota from all orgs before deleting.")
+	}
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Delete(ctx, cfOrgQuota); err != nil {
+		return apierrors.FromK8sError(err, OrgQuotaResourceType)
+	}
+
+	return nil
+}
+
+func (r *OrgQuotaRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	orgQuota, err := r.GetOrgQuota(ctx, authInfo, guid)
+	return orgQuota.DeletedAt, err
+}
+
+func (r *OrgQuotaRepo) setOrgsQuota(ctx context.Context, quotaGUID string, orgGUIDs []string) error {
+	for _, orgGUID := range orgGUIDs {
+		cfOrg := &korifiv1alpha1.CFOrg{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.rootNamespace,
+				Name:      orgGUID,
+			},
+		}
+
+		if err := GetAndPatch(ctx, r.klient, cfOrg, func() error {
+			cfOrg.Spec.QuotaGUID = quotaGUID
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to apply quota to org %q: %w", orgGUID, apierrors.FromK8sError(err, OrgResourceType))
+		}
+	}
+
+	return nil
+}
+
+func (r *OrgQuotaRepo) orgGUIDsByQuota(ctx context.Context) (map[string][]string, error) {
+	cfOrgList := &korifiv1alpha1.CFOrgList{}
+	if err := r.klient.List(ctx, cfOrgList, InNamespace(r.rootNamespace)); err != nil {
+		if k8serrors.IsForbidden(err) {
+			return map[string][]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list orgs: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	orgGUIDsByQuota := map[string][]string{}
+	for _, cfOrg := range cfOrgList.Items {
+		if cfOrg.Spec.QuotaGUID == "" {
+			continue
+		}
+		orgGUIDsByQuota[cfOrg.Spec.QuotaGUID] = append(orgGUIDsByQuota[cfOrg.Spec.QuotaGUID], cfOrg.Name)
+	}
+
+	return orgGUIDsByQuota, nil
+}
+
+func toOrgQuotaRecord(cfOrgQuota korifiv1alpha1.CFOrgQuota, orgGUIDs []string) OrgQuotaRecord {
+	if orgGUIDs == nil {
+		orgGUIDs = []string{}
+	}
+
+	return OrgQuotaRecord{
+		GUID:      cfOrgQuota.Name,
+		Name:      cfOrgQuota.Spec.DisplayName,
+		Limits:    toQuotaLimits(cfOrgQuota.Spec.QuotaLimits),
+		OrgGUIDs:  orgGUIDs,
+		CreatedAt: cfOrgQuota.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&cfOrgQuota),
+		DeletedAt: golangTime(cfOrgQuota.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,363 @@
+package repositories_test
+
+import (
+	"context"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("OrgQuotaRepo", func() {
+	var (
+		repo *repositories.OrgQuotaRepo
+		org  *korifiv1alpha1.CFOrg
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewOrgQuotaRepo(klient, rootNamespace)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+	})
+
+	Describe("CreateOrgQuota", func() {
+		var (
+			orgQuotaRecord repositories.OrgQuotaRecord
+			createMessage  repositories.CreateOrgQuotaMessage
+			createErr      error
+		)
+
+		BeforeEach(func() {
+			createMessage = repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.AppQuotaLimits{
+						TotalMemoryInMB: tools.PtrTo[int64](2048),
+					},
+					Routes: repositories.RouteQuotaLimits{
+						TotalRoutes: tools.PtrTo[int64](5),
+					},
+				},
+				OrgGUIDs: []string{org.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, createErr = repo.CreateOrgQuota(ctx, authInfo, createMessage)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates a CFOrgQuota", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      orgQuotaRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.DisplayName).To(Equal("my-quota"))
+				Expect(cfOrgQuota.Spec.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(2048)))
+				Expect(cfOrgQuota.Spec.Apps.TotalInstances).To(BeNil())
+				Expect(cfOrgQuota.Spec.Routes.TotalRoutes).To(PointTo(BeEquivalentTo(5)))
+			})
+
+			It("applies the quota to the orgs", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(org), org)).To(Succeed())
+				Expect(org.Spec.QuotaGUID).To(Equal(orgQuotaRecord.GUID))
+			})
+
+			It("returns an org quota record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(orgQuotaRecord.GUID).To(matchers.BeValidUUID())
+				Expect(orgQuotaRecord.Name).To(Equal("my-quota"))
+				Expect(orgQuotaRecord.Limits).To(Equal(createMessage.Limits))
+				Expect(orgQuotaRecord.OrgGUIDs).To(ConsistOf(org.Name))
+			})
+		})
+	})
+
+	Describe("GetOrgQuota", func() {
+		var (
+			cfOrgQuota     *korifiv1alpha1.CFOrgQuota
+			orgQuotaRecord repositories.OrgQuotaRecord
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			cfOrgQuota = createOrgQuota(ctx, "get-quota", org)
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, getErr = repo.GetOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the org quota with the orgs it is applied to", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecord.GUID).To(Equal(cfOrgQuota.Name))
+				Expect(orgQuotaRecord.Name).To(Equal("get-quota"))
+				Expect(orgQuotaRecord.Limits.Apps.TotalInstances).To(PointTo(BeEquivalentTo(10)))
+				Expect(orgQuotaRecord.OrgGUIDs).To(ConsistOf(org.Name))
+			})
+
+			When("the org quota does not exist", func() {
+				BeforeEach(func() {
+					cfOrgQuota.Name = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListOrgQuotas", func() {
+		var (
+			quota1, quota2  *korifiv1alpha1.CFOrgQuota
+			orgQuotaRecords []repositories.OrgQuotaRecord
+			listMessage     repositories.ListOrgQuotasMessage
+			listErr         error
+		)
+
+		BeforeEach(func() {
+			quota1 = createOrgQuota(ctx, "quota-1", org)
+			quota2 = createOrgQuota(ctx, "quota-2")
+			listMessage = repositories.ListOrgQuotasMessage{}
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecords, listErr = repo.ListOrgQuotas(ctx, authInfo, listMessage)
+		})
+
+		It("returns an empty list for users with no permissions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(orgQuotaRecords).To(BeEmpty())
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns all the org quotas", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecords).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name), "OrgGUIDs": ConsistOf(org.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name), "OrgGUIDs": BeEmpty()}),
+				))
+			})
+
+			When("filtering by org guid", func() {
+				BeforeEach(func() {
+					listMessage.OrgGUIDs = []string{org.Name}
+				})
+
+				It("returns the quotas applied to the org", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(orgQuotaRecords).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)})))
+				})
+			})
+
+			When("filtering by name", func() {
+				BeforeEach(func() {
+					listMessage.Names = []string{"quota-2"}
+				})
+
+				It("returns the matching quotas", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(orgQuotaRecords).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name)})))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateOrgQuota", func() {
+		var (
+			cfOrgQuota     *korifiv1alpha1.CFOrgQuota
+			orgQuotaRecord repositories.OrgQuotaRecord
+			updateErr      error
+		)
+
+		BeforeEach(func() {
+			cfOrgQuota = createOrgQuota(ctx, "update-quota")
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, updateErr = repo.UpdateOrgQuota(ctx, authInfo, repositories.UpdateOrgQuotaMessage{
+				GUID: cfOrgQuota.Name,
+				Name: tools.PtrTo("new-name"),
+				Limits: repositories.QuotaLimitsPatch{
+					Apps: repositories.AppQuotaLimitsPatch{
+						TotalInstances:  &repositories.QuotaLimitPatch{},
+						TotalMemoryInMB: &repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](512)},
+					},
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the patched limits only", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecord.Name).To(Equal("new-name"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.DisplayName).To(Equal("new-name"))
+				Expect(cfOrgQuota.Spec.Apps.TotalInstances).To(BeNil())
+				Expect(cfOrgQuota.Spec.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(512)))
+				Expect(cfOrgQuota.Spec.Services.TotalServiceInstances).To(PointTo(BeEquivalentTo(2)))
+			})
+		})
+	})
+
+	Describe("ApplyOrgQuota", func() {
+		var (
+			cfOrgQuota     *korifiv1alpha1.CFOrgQuota
+			orgQuotaRecord repositories.OrgQuotaRecord
+			applyErr       error
+		)
+
+		BeforeEach(func() {
+			cfOrgQuota = createOrgQuota(ctx, "apply-quota")
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, applyErr = repo.ApplyOrgQuota(ctx, authInfo, repositories.ApplyOrgQuotaMessage{
+				GUID:     cfOrgQuota.Name,
+				OrgGUIDs: []string{org.Name},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(applyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("applies the quota to the org", func() {
+				Expect(applyErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecord.OrgGUIDs).To(ConsistOf(org.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(org), org)).To(Succeed())
+				Expect(org.Spec.QuotaGUID).To(Equal(cfOrgQuota.Name))
+			})
+		})
+	})
+
+	Describe("DeleteOrgQuota", func() {
+		var (
+			cfOrgQuota *korifiv1alpha1.CFOrgQuota
+			deleteErr  error
+		)
+
+		BeforeEach(func() {
+			cfOrgQuota = createOrgQuota(ctx, "delete-quota")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the org quota", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("the quota is applied to an org", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, org, func() {
+						org.Spec.QuotaGUID = cfOrgQuota.Name
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				})
+			})
+		})
+	})
+})
+
+func createOrgQuota(ctx context.Context, displayName string, orgs ...*korifiv1alpha1.CFOrg) *korifiv1alpha1.CFOrgQuota {
+	GinkgoHelper()
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFOrgQuotaSpec{
+			DisplayName: displayName,
+			QuotaLimits: korifiv1alpha1.QuotaLimits{
+				Apps: korifiv1alpha1.QuotaAppLimits{
+					TotalInstances: tools.PtrTo[int64](10),
+				},
+				Services: korifiv1alpha1.QuotaServiceLimits{
+					TotalServiceInstances: tools.PtrTo[int64](2),
+				},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfOrgQuota)).To(Succeed())
+
+	for _, org := range orgs {
+		Expect(k8s.PatchResource(ctx, k8sClient, org, func() {
+			org.Spec.QuotaGUID = cfOrgQuota.Name
+		})).To(Succeed())
+	}
+
+	return cfOrgQuota
+}
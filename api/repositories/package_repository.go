@@ -65,17 +65,18 @@ func NewPackageRepo(
 }
 
 type PackageRecord struct {
-	GUID        string
-	UID         types.UID
-	Type        string
-	AppGUID     string
-	SpaceGUID   string
-	State       string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	Labels      map[string]string
-	Annotations map[string]string
-	ImageRef    string
+	GUID           string
+	UID            types.UID
+	Type           string
+	AppGUID        string
+	SpaceGUID      string
+	State          string
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+	Labels         map[string]string
+	Annotations    map[string]string
+	ImageRef       string
+	SourceImageRef string
 }
 
 func (r PackageRecord) Relationships() map[string]string {
@@ -386,17 +387,18 @@ func (r *PackageRepo) cfPackageToPackageRecord(cfPackage korifiv1alpha1.CFPackag
 		state = PackageStateReady
 	}
 	return PackageRecord{
-		GUID:        cfPackage.Name,
-		UID:         cfPackage.UID,
-		SpaceGUID:   cfPackage.Namespace,
-		Type:        string(cfPackage.Spec.Type),
-		AppGUID:     cfPackage.Spec.AppRef.Name,
-		State:       state,
-		CreatedAt:   cfPackage.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfPackage),
-		Labels:      cfPackage.Labels,
-		Annotations: cfPackage.Annotations,
-		ImageRef:    r.repositoryRef(cfPackage),
+		GUID:           cfPackage.Name,
+		UID:            cfPackage.UID,
+		SpaceGUID:      cfPackage.Namespace,
+		Type:           string(cfPackage.Spec.Type),
+		AppGUID:        cfPackage.Spec.AppRef.Name,
+		State:          state,
+		CreatedAt:      cfPackage.CreationTimestamp.Time,
+		UpdatedAt:      getLastUpdatedTime(&cfPackage),
+		Labels:         cfPackage.Labels,
+		Annotations:    cfPackage.Annotations,
+		ImageRef:       r.repositoryRef(cfPackage),
+		SourceImageRef: cfPackage.Spec.Source.Registry.Image,
 	}
 }
 
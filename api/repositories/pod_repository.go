@@ -3,10 +3,12 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	corev1 "k8s.io/api/core/v1"
@@ -57,3 +59,27 @@ func (r *PodRepo) DeletePod(ctx context.Context, authInfo authorization.Info, ap
 	}
 	return nil
 }
+
+func (r *PodRepo) GetRunningPodName(ctx context.Context, authInfo authorization.Info, process ProcessRecord, instance int) (string, error) {
+	labelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
+		"korifi.cloudfoundry.org/guid":  process.GUID,
+		korifiv1alpha1.PodIndexLabelKey: strconv.Itoa(instance),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build labelSelector: %w", apierrors.FromK8sError(err, PodResourceType))
+	}
+
+	podList := corev1.PodList{}
+	err = r.klient.List(ctx, &podList, InNamespace(process.SpaceGUID), WithLabels{Selector: labelSelector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", apierrors.FromK8sError(err, PodResourceType))
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			return pod.Name, nil
+		}
+	}
+
+	return "", apierrors.NewNotFoundError(nil, PodResourceType)
+}
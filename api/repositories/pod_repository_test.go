@@ -156,4 +156,77 @@ var _ = Describe("PodRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetRunningPodName", func() {
+		var (
+			instancePod *corev1.Pod
+			podName     string
+			err         error
+		)
+
+		BeforeEach(func() {
+			process.GUID = uuid.NewString()
+			instancePod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "instance-pod-2",
+					Namespace: space.Name,
+					Labels: map[string]string{
+						"korifi.cloudfoundry.org/guid":  process.GUID,
+						korifiv1alpha1.PodIndexLabelKey: "2",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "application",
+							Image: "nginx",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, instancePod)).To(Succeed())
+			instancePod.Status.Phase = corev1.PodRunning
+			Expect(k8sClient.Status().Update(ctx, instancePod)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			podName, err = podRepo.GetRunningPodName(ctx, authInfo, process, 2)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the name of the pod running the instance", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podName).To(Equal("instance-pod-2"))
+			})
+
+			When("the instance pod is not running", func() {
+				BeforeEach(func() {
+					instancePod.Status.Phase = corev1.PodPending
+					Expect(k8sClient.Status().Update(ctx, instancePod)).To(Succeed())
+				})
+
+				It("returns a not found error", func() {
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+
+			When("the pod belongs to another process", func() {
+				BeforeEach(func() {
+					process.GUID = "another-process-guid"
+				})
+
+				It("returns a not found error", func() {
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
 })
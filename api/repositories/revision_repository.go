@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	RevisionResourceType        = "Revision"
+	RevisionEnvVarsResourceType = "Revision Environment Variables"
+)
+
+type RevisionRecord struct {
+	GUID        string
+	SpaceGUID   string
+	AppGUID     string
+	Version     int64
+	DropletGUID string
+	Processes   map[string]string
+	Sidecars    []RevisionSidecarRecord
+	Description string
+	Deployed    bool
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+
+	envSecretName string
+}
+
+func (r RevisionRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": r.AppGUID,
+	}
+}
+
+type RevisionSidecarRecord struct {
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+}
+
+type RevisionEnvVarsRecord struct {
+	RevisionGUID         string
+	EnvironmentVariables map[string]string
+}
+
+type ListRevisionsMessage struct {
+	AppGUIDs []string
+	Versions []int64
+	Deployed *bool
+	OrderBy  string
+}
+
+func (m *ListRevisionsMessage) matches(revision RevisionRecord) bool {
+	return tools.EmptyOrContains(m.Versions, revision.Version) &&
+		(m.Deployed == nil || *m.Deployed == revision.Deployed)
+}
+
+//counterfeiter:generate -o fake -fake-name RevisionSorter . RevisionSorter
+type RevisionSorter interface {
+	Sort(records []RevisionRecord, order string) []RevisionRecord
+}
+
+type revisionSorter struct {
+	sorter *compare.Sorter[RevisionRecord]
+}
+
+func NewRevisionSorter() *revisionSorter {
+	return &revisionSorter{
+		sorter: compare.NewSorter(RevisionComparator),
+	}
+}
+
+func (s *revisionSorter) Sort(records []RevisionRecord, order string) []RevisionRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func RevisionComparator(fieldName string) func(RevisionRecord, RevisionRecord) int {
+	return func(r1, r2 RevisionRecord) int {
+		switch fieldName {
+		case "", "version":
+			return cmp.Compare(r1.Version, r2.Version)
+		case "created_at":
+			return tools.CompareTimePtr(&r1.CreatedAt, &r2.CreatedAt)
+		case "updated_at":
+			return tools.CompareTimePtr(r1.UpdatedAt, r2.UpdatedAt)
+		}
+		return 0
+	}
+}
+
+type RevisionRepo struct {
+	klient Klient
+	sorter RevisionSorter
+}
+
+func NewRevisionRepo(klient Klient, sorter RevisionSorter) *RevisionRepo {
+	return &RevisionRepo{
+		klient: klient,
+		sorter: sorter,
+	}
+}
+
+func (r *RevisionRepo) GetRevision(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionRecord, error) {
+	revision := &korifiv1alpha1.CFAppRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: revisionGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, revision); err != nil {
+		return RevisionRecord{}, fmt.Errorf("failed to get revision %q: %w", revisionGUID, apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	cfApp, err := r.getApp(ctx, revision.Namespace, revision.Spec.AppRef.Name)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	return toRevisionRecord(*revision, cfApp), nil
+}
+
+func (r *RevisionRepo) ListRevisions(ctx context.Context, authInfo authorization.Info, message ListRevisionsMessage) ([]RevisionRecord, error) {
+	var opts []ListOption
+	if len(message.AppGUIDs) > 0 {
+		appGUIDsReq, err := labels.NewRequirement(korifiv1alpha1.CFAppGUIDLabelKey, "in", message.AppGUIDs)
+		if err != nil {
+			return nil, apierrors.NewUnprocessableEntityError(err, "invalid app guids")
+		}
+		opts = append(opts, WithLabels{Selector: labels.NewSelector().Add(*appGUIDsReq)})
+	}
+
+	revisionList := &korifiv1alpha1.CFAppRevisionList{}
+	if err := r.klient.List(ctx, revisionList, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	apps := map[string]*korifiv1alpha1.CFApp{}
+	records := []RevisionRecord{}
+	for _, revision := range revisionList.Items {
+		appKey := revision.Namespace + "/" + revision.Spec.AppRef.Name
+		cfApp, ok := apps[appKey]
+		if !ok {
+			var err error
+			cfApp, err = r.getApp(ctx, revision.Namespace, revision.Spec.AppRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			apps[appKey] = cfApp
+		}
+
+		record := toRevisionRecord(revision, cfApp)
+		if message.matches(record) {
+			records = append(records, record)
+		}
+	}
+
+	return r.sorter.Sort(records, message.OrderBy), nil
+}
+
+func (r *RevisionRepo) GetRevisionEnvVars(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionEnvVarsRecord, error) {
+	revision, err := r.GetRevision(ctx, authInfo, revisionGUID)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, err
+	}
+
+	envVars, err := r.getEnvSnapshot(ctx, revision)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, err
+	}
+
+	return RevisionEnvVarsRecord{
+		RevisionGUID:         revision.GUID,
+		EnvironmentVariables: convertByteSliceValuesToStrings(envVars),
+	}, nil
+}
+
+func (r *RevisionRepo) getEnvSnapshot(ctx context.Context, revision RevisionRecord) (map[string][]byte, error) {
+	if revision.envSecretName == "" {
+		return map[string][]byte{}, nil
+	}
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: revision.SpaceGUID,
+			Name:      revision.envSecretName,
+		},
+	}
+	if err := r.klient.Get(ctx, envSecret); err != nil {
+		return nil, fmt.Errorf("failed to get environment variables of revision %q: %w", revision.GUID, apierrors.FromK8sError(err, RevisionEnvVarsResourceType))
+	}
+
+	return envSecret.Data, nil
+}
+
+// getApp returns nil if the app has been deleted, so that its revisions (which
+// are about to be garbage collected) can still be presented
+func (r *RevisionRepo) getApp(ctx context.Context, namespace, appGUID string) (*korifiv1alpha1.CFApp, error) {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      appGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfApp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get app %q: %w", appGUID, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfApp, nil
+}
+
+func toRevisionRecord(revision korifiv1alpha1.CFAppRevision, cfApp *korifiv1alpha1.CFApp) RevisionRecord {
+	processes := map[string]string{}
+	for _, process := range revision.Spec.Processes {
+		processes[process.Type] = process.Command
+	}
+
+	sidecars := []RevisionSidecarRecord{}
+	for _, sidecar := range revision.Spec.Sidecars {
+		sidecars = append(sidecars, RevisionSidecarRecord{
+			Name:         sidecar.Name,
+			Command:      sidecar.Command,
+			ProcessTypes: sidecar.ProcessTypes,
+			MemoryMB:     sidecar.MemoryMB,
+		})
+	}
+
+	return RevisionRecord{
+		GUID:          revision.Name,
+		SpaceGUID:     revision.Namespace,
+		AppGUID:       revision.Spec.AppRef.Name,
+		Version:       revision.Spec.Version,
+		DropletGUID:   revision.Spec.DropletRef.Name,
+		Processes:     processes,
+		Sidecars:      sidecars,
+		Description:   revision.Spec.Description,
+		Deployed:      isDeployed(revision, cfApp),
+		Labels:        revision.Labels,
+		Annotations:   revision.Annotations,
+		CreatedAt:     revision.CreationTimestamp.Time,
+		UpdatedAt:     getLastUpdatedTime(&revision),
+		envSecretName: revision.Spec.EnvSecretName,
+	}
+}
+
+// isDeployed checks whether the revision is the one currently running. The
+// app-rev is bumped on every deployment and when the app is stopped, so only
+// the revision of the current app-rev of a started app is deployed.
+func isDeployed(revision korifiv1alpha1.CFAppRevision, cfApp *korifiv1alpha1.CFApp) bool {
+	if cfApp == nil || cfApp.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return false
+	}
+
+	return cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] == strconv.FormatInt(revision.Spec.Version, 10)
+}
@@ -0,0 +1,344 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RevisionRepository", func() {
+	var (
+		revisionRepo *repositories.RevisionRepo
+		sorter       *fake.RevisionSorter
+		cfOrg        *korifiv1alpha1.CFOrg
+		cfSpace      *korifiv1alpha1.CFSpace
+		cfApp        *korifiv1alpha1.CFApp
+		revision1    *korifiv1alpha1.CFAppRevision
+		revision2    *korifiv1alpha1.CFAppRevision
+	)
+
+	createRevision := func(app *korifiv1alpha1.CFApp, version int64, envSecretName string) *korifiv1alpha1.CFAppRevision {
+		GinkgoHelper()
+
+		revision := &korifiv1alpha1.CFAppRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: app.Namespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: app.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFAppRevisionSpec{
+				AppRef:        corev1.LocalObjectReference{Name: app.Name},
+				Version:       version,
+				DropletRef:    corev1.LocalObjectReference{Name: "droplet-guid"},
+				EnvSecretName: envSecretName,
+				Processes: []korifiv1alpha1.RevisionProcess{
+					{Type: "web", Command: "bundle exec rackup"},
+					{Type: "worker"},
+				},
+				Sidecars: []korifiv1alpha1.RevisionSidecar{
+					{Name: "agent", Command: "run-agent", ProcessTypes: []string{"web"}, MemoryMB: 64},
+				},
+				Description: "Initial revision.",
+			},
+		}
+		Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+
+		return revision
+	}
+
+	BeforeEach(func() {
+		sorter = new(fake.RevisionSorter)
+		sorter.SortStub = func(records []repositories.RevisionRecord, _ string) []repositories.RevisionRecord {
+			return records
+		}
+		revisionRepo = repositories.NewRevisionRepo(klient, sorter)
+
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      "revision-env",
+			},
+			StringData: map[string]string{
+				"FOO": "BAR",
+			},
+		})).To(Succeed())
+
+		revision1 = createRevision(cfApp, 1, "revision-env")
+		revision2 = createRevision(cfApp, 2, "")
+	})
+
+	Describe("GetRevision", func() {
+		var (
+			revisionGUID string
+			record       repositories.RevisionRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			revisionGUID = revision1.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = revisionRepo.GetRevision(ctx, authInfo, revisionGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the revision", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":        Equal(revision1.Name),
+					"SpaceGUID":   Equal(cfSpace.Name),
+					"AppGUID":     Equal(cfApp.Name),
+					"Version":     BeEquivalentTo(1),
+					"DropletGUID": Equal("droplet-guid"),
+					"Processes": Equal(map[string]string{
+						"web":    "bundle exec rackup",
+						"worker": "",
+					}),
+					"Sidecars": ConsistOf(repositories.RevisionSidecarRecord{
+						Name:         "agent",
+						Command:      "run-agent",
+						ProcessTypes: []string{"web"},
+						MemoryMB:     64,
+					}),
+					"Description": Equal("Initial revision."),
+					"Deployed":    BeFalse(),
+					"CreatedAt":   BeTemporally("~", time.Now(), timeCheckThreshold),
+				}))
+			})
+
+			When("the app is started at the revision version", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "1"
+					})).To(Succeed())
+				})
+
+				It("returns a deployed revision", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(record.Deployed).To(BeTrue())
+				})
+			})
+
+			When("the revision does not exist", func() {
+				BeforeEach(func() {
+					revisionGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListRevisions", func() {
+		var (
+			message     repositories.ListRevisionsMessage
+			otherApp    *korifiv1alpha1.CFApp
+			records     []repositories.RevisionRecord
+			otherRecord *korifiv1alpha1.CFAppRevision
+			listErr     error
+		)
+
+		BeforeEach(func() {
+			otherApp = createApp(cfSpace.Name)
+			otherRecord = createRevision(otherApp, 1, "")
+
+			message = repositories.ListRevisionsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = revisionRepo.ListRevisions(ctx, authInfo, message)
+		})
+
+		It("returns an empty list as the user is not authorized", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns all revisions", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision2.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherRecord.Name)}),
+				))
+			})
+
+			It("sorts the revisions", func() {
+				Expect(sorter.SortCallCount()).To(Equal(1))
+			})
+
+			When("filtering by app guid", func() {
+				BeforeEach(func() {
+					message.AppGUIDs = []string{cfApp.Name}
+				})
+
+				It("returns the app revisions only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision1.Name)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision2.Name)}),
+					))
+				})
+			})
+
+			When("filtering by version", func() {
+				BeforeEach(func() {
+					message.AppGUIDs = []string{cfApp.Name}
+					message.Versions = []int64{2}
+				})
+
+				It("returns the matching revisions only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision2.Name)}),
+					))
+				})
+			})
+
+			When("filtering by deployed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "2"
+					})).To(Succeed())
+
+					message.Deployed = tools.PtrTo(true)
+				})
+
+				It("returns the deployed revisions only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"GUID":     Equal(revision2.Name),
+							"Deployed": BeTrue(),
+						}),
+					))
+				})
+			})
+		})
+	})
+
+	Describe("GetRevisionEnvVars", func() {
+		var (
+			revisionGUID string
+			record       repositories.RevisionEnvVarsRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			revisionGUID = revision1.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = revisionRepo.GetRevisionEnvVars(ctx, authInfo, revisionGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the environment variables snapshot", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.RevisionGUID).To(Equal(revision1.Name))
+				Expect(record.EnvironmentVariables).To(Equal(map[string]string{"FOO": "BAR"}))
+			})
+
+			When("the revision has no environment variables snapshot", func() {
+				BeforeEach(func() {
+					revisionGUID = revision2.Name
+				})
+
+				It("returns empty environment variables", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(record.EnvironmentVariables).To(BeEmpty())
+				})
+			})
+
+			When("the snapshot secret does not exist", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Delete(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      "revision-env",
+						},
+					})).To(Succeed())
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+})
+
+var _ = DescribeTable("RevisionSorter",
+	func(r1, r2 repositories.RevisionRecord, field string, match types.GomegaMatcher) {
+		Expect(repositories.RevisionComparator(field)(r1, r2)).To(match)
+	},
+	Entry("default",
+		repositories.RevisionRecord{Version: 1},
+		repositories.RevisionRecord{Version: 2},
+		"",
+		BeNumerically("<", 0),
+	),
+	Entry("version",
+		repositories.RevisionRecord{Version: 1},
+		repositories.RevisionRecord{Version: 2},
+		"version",
+		BeNumerically("<", 0),
+	),
+	Entry("created_at",
+		repositories.RevisionRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.RevisionRecord{CreatedAt: time.UnixMilli(2)},
+		"created_at",
+		BeNumerically("<", 0),
+	),
+	Entry("updated_at",
+		repositories.RevisionRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.RevisionRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"updated_at",
+		BeNumerically("<", 0),
+	),
+)
@@ -38,7 +38,7 @@ type DestinationRecord struct {
 	ProcessType string
 	Port        *int32
 	Protocol    *string
-	// Weight intentionally omitted as experimental features
+	Weight      *int32
 }
 
 type RouteRecord struct {
@@ -48,6 +48,7 @@ type RouteRecord struct {
 	Host         string
 	Path         string
 	Protocol     string
+	Port         *int32
 	Destinations []DestinationRecord
 	Labels       map[string]string
 	Annotations  map[string]string
@@ -68,7 +69,7 @@ type DesiredDestination struct {
 	ProcessType string
 	Port        *int32
 	Protocol    *string
-	// Weight intentionally omitted as experimental features
+	Weight      *int32
 }
 
 type AddDestinationsMessage struct {
@@ -123,10 +124,12 @@ func (m *ListRoutesMessage) matchesApp(r korifiv1alpha1.CFRoute) bool {
 type CreateRouteMessage struct {
 	Host            string
 	Path            string
+	Port            *int32
 	SpaceGUID       string
 	DomainGUID      string
 	DomainName      string
 	DomainNamespace string
+	RouterGroup     string
 	Labels          map[string]string
 	Annotations     map[string]string
 }
@@ -137,6 +140,13 @@ type DeleteRouteMessage struct {
 }
 
 func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
+	// only routes on tcp domains have a port, the route webhook rejects
+	// ports on http routes
+	protocol := korifiv1alpha1.RouteProtocolHTTP
+	if m.Port != nil {
+		protocol = korifiv1alpha1.RouteProtocolTCP
+	}
+
 	return korifiv1alpha1.CFRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        uuid.NewString(),
@@ -145,9 +155,11 @@ func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
 			Annotations: m.Annotations,
 		},
 		Spec: korifiv1alpha1.CFRouteSpec{
-			Host:     m.Host,
-			Path:     m.Path,
-			Protocol: "http",
+			Host:        m.Host,
+			Path:        m.Path,
+			Protocol:    protocol,
+			Port:        m.Port,
+			RouterGroup: m.RouterGroup,
 			DomainRef: v1.ObjectReference{
 				Name:      m.DomainGUID,
 				Namespace: m.DomainNamespace,
@@ -190,7 +202,8 @@ func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 		},
 		Host:         cfRoute.Spec.Host,
 		Path:         cfRoute.Spec.Path,
-		Protocol:     "http", // TODO: Create a mutating webhook to set this default on the CFRoute
+		Protocol:     routeProtocol(cfRoute),
+		Port:         cfRoute.Spec.Port,
 		Destinations: cfRouteDestinationsToDestinationRecords(cfRoute),
 		CreatedAt:    cfRoute.CreationTimestamp.Time,
 		UpdatedAt:    getLastUpdatedTime(&cfRoute),
@@ -200,6 +213,15 @@ func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 	}
 }
 
+func routeProtocol(cfRoute korifiv1alpha1.CFRoute) string {
+	// TODO: Create a mutating webhook to set this default on the CFRoute
+	if cfRoute.Spec.Protocol == "" {
+		return string(korifiv1alpha1.RouteProtocolHTTP)
+	}
+
+	return string(cfRoute.Spec.Protocol)
+}
+
 func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []DestinationRecord {
 	return slices.Collect(it.Map(slices.Values(cfRoute.Spec.Destinations), func(specDestination korifiv1alpha1.Destination) DestinationRecord {
 		record := DestinationRecord{
@@ -208,6 +230,7 @@ func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []D
 			ProcessType: specDestination.ProcessType,
 			Port:        specDestination.Port,
 			Protocol:    specDestination.Protocol,
+			Weight:      specDestination.Weight,
 		}
 
 		if record.Port == nil {
@@ -343,6 +366,7 @@ func destinationMessageToDestination(m DesiredDestination) korifiv1alpha1.Destin
 		},
 		ProcessType: m.ProcessType,
 		Protocol:    m.Protocol,
+		Weight:      m.Weight,
 	}
 }
 
@@ -397,6 +421,7 @@ func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord)
 			},
 			ProcessType: destinationRecord.ProcessType,
 			Protocol:    destinationRecord.Protocol,
+			Weight:      destinationRecord.Weight,
 		}
 	}))
 }
@@ -551,6 +551,8 @@ var _ = Describe("RouteRepository", func() {
 			createdRouteErr    error
 			routeHost          string
 			routePath          string
+			routePort          *int32
+			routeRouterGroup   string
 			routeNamespace     string
 		)
 
@@ -558,6 +560,8 @@ var _ = Describe("RouteRepository", func() {
 			routeNamespace = space.Name
 			routeHost = prefixedGUID("route-host-")
 			routePath = prefixedGUID("/test/route/")
+			routePort = nil
+			routeRouterGroup = ""
 			createdRouteRecord = RouteRecord{}
 			createdRouteErr = nil
 		})
@@ -566,9 +570,11 @@ var _ = Describe("RouteRepository", func() {
 			createdRouteRecord, createdRouteErr = routeRepo.CreateRoute(ctx, authInfo, CreateRouteMessage{
 				Host:            routeHost,
 				Path:            routePath,
+				Port:            routePort,
 				SpaceGUID:       routeNamespace,
 				DomainGUID:      domainGUID,
 				DomainNamespace: rootNamespace,
+				RouterGroup:     routeRouterGroup,
 			})
 		})
 
@@ -604,6 +610,30 @@ var _ = Describe("RouteRepository", func() {
 
 				Expect(createdRouteRecord.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
 				Expect(createdRouteRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
+				Expect(createdRouteRecord.Protocol).To(Equal("http"))
+				Expect(createdRouteRecord.Port).To(BeNil())
+			})
+
+			When("the route has a port", func() {
+				BeforeEach(func() {
+					routeHost = ""
+					routePath = ""
+					routePort = tools.PtrTo[int32](1024)
+					routeRouterGroup = "default-tcp"
+				})
+
+				It("creates a tcp route", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					createdCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdRouteRecord.GUID, Namespace: space.Name}, createdCFRoute)).To(Succeed())
+
+					Expect(createdCFRoute.Spec.Protocol).To(Equal(korifiv1alpha1.RouteProtocolTCP))
+					Expect(createdCFRoute.Spec.Port).To(PointTo(BeEquivalentTo(1024)))
+					Expect(createdCFRoute.Spec.RouterGroup).To(Equal("default-tcp"))
+
+					Expect(createdRouteRecord.Protocol).To(Equal("tcp"))
+					Expect(createdRouteRecord.Port).To(PointTo(BeEquivalentTo(1024)))
+				})
 			})
 
 			When("target namespace isn't set", func() {
@@ -878,6 +908,7 @@ var _ = Describe("RouteRepository", func() {
 							"AppGUID":     Equal(appGUID),
 							"ProcessType": Equal("web"),
 							"Protocol":    PointTo(Equal("http1")),
+							"Weight":      BeNil(),
 						},
 					),
 				))
@@ -892,6 +923,7 @@ var _ = Describe("RouteRepository", func() {
 							}),
 							"ProcessType": Equal("web"),
 							"Protocol":    PointTo(Equal("http1")),
+							"Weight":      BeNil(),
 						},
 					),
 				))
@@ -925,6 +957,31 @@ var _ = Describe("RouteRepository", func() {
 				})
 			})
 
+			When("the destination is weighted", func() {
+				BeforeEach(func() {
+					addDestinationsMessage.NewDestinations[0].Weight = tools.PtrTo[int32](100)
+				})
+
+				It("adds the destination with its weight", func() {
+					Expect(addDestinationErr).NotTo(HaveOccurred())
+					Expect(routeRecord.Destinations).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"AppGUID": Equal(appGUID),
+							"Weight":  PointTo(BeEquivalentTo(100)),
+						}),
+					))
+
+					Expect(cfRoute.Spec.Destinations).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"AppRef": Equal(corev1.LocalObjectReference{
+								Name: appGUID,
+							}),
+							"Weight": PointTo(BeEquivalentTo(100)),
+						}),
+					))
+				})
+			})
+
 			When("the route destination has an invalid protocol", func() {
 				BeforeEach(func() {
 					addDestinationsMessage.NewDestinations[0].Protocol = tools.PtrTo("bad-protocol")
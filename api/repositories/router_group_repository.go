@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+)
+
+const (
+	RouterGroupResourceType = "Router Group"
+	RouterGroupTypeTCP      = "tcp"
+)
+
+// RouterGroupRepo serves the router groups configured at deployment time.
+// Router groups are not stored in kubernetes, each of them is backed by a set
+// of TCP listeners on the gateway. The router group name doubles as its GUID.
+type RouterGroupRepo struct {
+	routerGroups []config.RouterGroup
+}
+
+func NewRouterGroupRepo(routerGroups []config.RouterGroup) *RouterGroupRepo {
+	return &RouterGroupRepo{
+		routerGroups: routerGroups,
+	}
+}
+
+type RouterGroupRecord struct {
+	GUID            string
+	Name            string
+	Type            string
+	ReservablePorts []int32
+}
+
+func (r RouterGroupRecord) ReservesPort(port int32) bool {
+	return slices.Contains(r.ReservablePorts, port)
+}
+
+type ListRouterGroupsMessage struct {
+	Names []string
+}
+
+func (m *ListRouterGroupsMessage) matches(g config.RouterGroup) bool {
+	return tools.EmptyOrContains(m.Names, g.Name)
+}
+
+func (r *RouterGroupRepo) GetRouterGroup(ctx context.Context, authInfo authorization.Info, guid string) (RouterGroupRecord, error) {
+	for _, routerGroup := range r.routerGroups {
+		if routerGroup.Name == guid {
+			return toRouterGroupRecord(routerGroup), nil
+		}
+	}
+
+	return RouterGroupRecord{}, apierrors.NewNotFoundError(fmt.Errorf("router group %q not found", guid), RouterGroupResourceType)
+}
+
+func (r *RouterGroupRepo) ListRouterGroups(ctx context.Context, authInfo authorization.Info, message ListRouterGroupsMessage) ([]RouterGroupRecord, error) {
+	return slices.Collect(it.Map(
+		itx.FromSlice(r.routerGroups).Filter(message.matches),
+		toRouterGroupRecord,
+	)), nil
+}
+
+func toRouterGroupRecord(routerGroup config.RouterGroup) RouterGroupRecord {
+	return RouterGroupRecord{
+		GUID:            routerGroup.Name,
+		Name:            routerGroup.Name,
+		Type:            RouterGroupTypeTCP,
+		ReservablePorts: routerGroup.ReservablePorts,
+	}
+}
@@ -0,0 +1,97 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("RouterGroupRepository", func() {
+	var routerGroupRepo *repositories.RouterGroupRepo
+
+	BeforeEach(func() {
+		routerGroupRepo = repositories.NewRouterGroupRepo([]config.RouterGroup{
+			{Name: "default-tcp", ReservablePorts: []int32{1024, 1025}},
+			{Name: "other-tcp", ReservablePorts: []int32{2000}},
+		})
+	})
+
+	Describe("GetRouterGroup", func() {
+		var (
+			routerGroup repositories.RouterGroupRecord
+			getErr      error
+			guid        string
+		)
+
+		BeforeEach(func() {
+			guid = "default-tcp"
+		})
+
+		JustBeforeEach(func() {
+			routerGroup, getErr = routerGroupRepo.GetRouterGroup(ctx, authInfo, guid)
+		})
+
+		It("returns the router group", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(routerGroup).To(Equal(repositories.RouterGroupRecord{
+				GUID:            "default-tcp",
+				Name:            "default-tcp",
+				Type:            "tcp",
+				ReservablePorts: []int32{1024, 1025},
+			}))
+			Expect(routerGroup.ReservesPort(1024)).To(BeTrue())
+			Expect(routerGroup.ReservesPort(2000)).To(BeFalse())
+		})
+
+		When("the router group does not exist", func() {
+			BeforeEach(func() {
+				guid = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListRouterGroups", func() {
+		var (
+			routerGroups []repositories.RouterGroupRecord
+			message      repositories.ListRouterGroupsMessage
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListRouterGroupsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			routerGroups, err = routerGroupRepo.ListRouterGroups(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns all router groups", func() {
+			Expect(routerGroups).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("default-tcp")}),
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("other-tcp")}),
+			))
+		})
+
+		When("filtering by name", func() {
+			BeforeEach(func() {
+				message.Names = []string{"other-tcp"}
+			})
+
+			It("returns the matching router groups", func() {
+				Expect(routerGroups).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Name": Equal("other-tcp")}),
+				))
+			})
+		})
+	})
+})
@@ -2,19 +2,29 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"slices"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const SecurityGroupResourceType = "Security Group"
+const (
+	SecurityGroupResourceType = "Security Group"
+
+	SecurityGroupRunningSpaces = korifiv1alpha1.SecurityGroupRunningWorkloadType
+	SecurityGroupStagingSpaces = korifiv1alpha1.SecurityGroupStagingWorkloadType
+)
 
 type SecurityGroupRule struct {
 	Protocol    string `json:"protocol"`
@@ -53,6 +63,114 @@ type CreateSecurityGroupMessage struct {
 	GloballyEnabled SecurityGroupWorkloads
 }
 
+type ListSecurityGroupMessage struct {
+	GUIDs                  []string
+	Names                  []string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+}
+
+func (m *ListSecurityGroupMessage) matches(cfSecurityGroup korifiv1alpha1.CFSecurityGroup) bool {
+	record := toSecurityGroupRecord(cfSecurityGroup)
+
+	return tools.EmptyOrContains(m.GUIDs, record.GUID) &&
+		tools.EmptyOrContains(m.Names, record.Name) &&
+		tools.NilOrEquals(m.GloballyEnabledRunning, record.GloballyEnabled.Running) &&
+		tools.NilOrEquals(m.GloballyEnabledStaging, record.GloballyEnabled.Staging) &&
+		emptyOrIntersects(m.RunningSpaceGUIDs, record.RunningSpaces) &&
+		emptyOrIntersects(m.StagingSpaceGUIDs, record.StagingSpaces)
+}
+
+func emptyOrIntersects(filter []string, values []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(values, func(v string) bool {
+		return slices.Contains(filter, v)
+	})
+}
+
+type UpdateSecurityGroupMessage struct {
+	GUID            string
+	DisplayName     *string
+	Rules           []SecurityGroupRule
+	GloballyEnabled UpdateSecurityGroupWorkloads
+}
+
+type UpdateSecurityGroupWorkloads struct {
+	Running *bool
+	Staging *bool
+}
+
+func (m UpdateSecurityGroupMessage) apply(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) {
+	if m.DisplayName != nil {
+		cfSecurityGroup.Spec.DisplayName = *m.DisplayName
+	}
+
+	if m.Rules != nil {
+		cfSecurityGroup.Spec.Rules = toCFSecurityGroupRules(m.Rules)
+	}
+
+	if m.GloballyEnabled.Running != nil {
+		cfSecurityGroup.Spec.GloballyEnabled.Running = *m.GloballyEnabled.Running
+	}
+
+	if m.GloballyEnabled.Staging != nil {
+		cfSecurityGroup.Spec.GloballyEnabled.Staging = *m.GloballyEnabled.Staging
+	}
+}
+
+type BindSecurityGroupMessage struct {
+	GUID         string
+	SpaceGUIDs   []string
+	WorkloadType string
+}
+
+func (m BindSecurityGroupMessage) apply(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) {
+	if cfSecurityGroup.Spec.Spaces == nil {
+		cfSecurityGroup.Spec.Spaces = map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+	}
+
+	for _, spaceGUID := range m.SpaceGUIDs {
+		workloads := cfSecurityGroup.Spec.Spaces[spaceGUID]
+		setWorkload(&workloads, m.WorkloadType, true)
+		cfSecurityGroup.Spec.Spaces[spaceGUID] = workloads
+	}
+}
+
+type UnbindSecurityGroupMessage struct {
+	GUID         string
+	SpaceGUID    string
+	WorkloadType string
+}
+
+func (m UnbindSecurityGroupMessage) apply(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) {
+	workloads, ok := cfSecurityGroup.Spec.Spaces[m.SpaceGUID]
+	if !ok {
+		return
+	}
+
+	setWorkload(&workloads, m.WorkloadType, false)
+	if !workloads.Running && !workloads.Staging {
+		delete(cfSecurityGroup.Spec.Spaces, m.SpaceGUID)
+		return
+	}
+
+	cfSecurityGroup.Spec.Spaces[m.SpaceGUID] = workloads
+}
+
+func setWorkload(workloads *korifiv1alpha1.SecurityGroupWorkloads, workloadType string, value bool) {
+	switch workloadType {
+	case SecurityGroupRunningSpaces:
+		workloads.Running = value
+	case SecurityGroupStagingSpaces:
+		workloads.Staging = value
+	}
+}
+
 type SecurityGroupRecord struct {
 	GUID            string
 	CreatedAt       time.Time
@@ -73,17 +191,7 @@ func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo au
 		},
 		Spec: korifiv1alpha1.CFSecurityGroupSpec{
 			DisplayName: message.DisplayName,
-			Rules: slices.Collect(it.Map(slices.Values(message.Rules), func(r SecurityGroupRule) korifiv1alpha1.SecurityGroupRule {
-				return korifiv1alpha1.SecurityGroupRule{
-					Protocol:    r.Protocol,
-					Destination: r.Destination,
-					Ports:       r.Ports,
-					Type:        r.Type,
-					Code:        r.Code,
-					Description: r.Description,
-					Log:         r.Log,
-				}
-			})),
+			Rules:       toCFSecurityGroupRules(message.Rules),
 			Spaces: func() map[string]korifiv1alpha1.SecurityGroupWorkloads {
 				spaces := make(map[string]korifiv1alpha1.SecurityGroupWorkloads, len(message.Spaces))
 				for guid, workloads := range message.Spaces {
@@ -114,6 +222,115 @@ func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo au
 	return toSecurityGroupRecord(*cfSecurityGroup), nil
 }
 
+func (r *SecurityGroupRepo) GetSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (SecurityGroupRecord, error) {
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfSecurityGroup); err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to get security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return toSecurityGroupRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) ListSecurityGroups(ctx context.Context, authInfo authorization.Info, message ListSecurityGroupMessage) ([]SecurityGroupRecord, error) {
+	cfSecurityGroupList := &korifiv1alpha1.CFSecurityGroupList{}
+	if err := r.klient.List(ctx, cfSecurityGroupList, InNamespace(r.rootNamespace)); err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []SecurityGroupRecord{}, nil
+		}
+		return []SecurityGroupRecord{}, fmt.Errorf("failed to list security groups: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	securityGroupRecords := slices.Collect(it.Map(
+		itx.FromSlice(cfSecurityGroupList.Items).Filter(message.matches),
+		toSecurityGroupRecord,
+	))
+	sort.Slice(securityGroupRecords, func(i, j int) bool {
+		return securityGroupRecords[i].CreatedAt.Before(securityGroupRecords[j].CreatedAt)
+	})
+
+	return securityGroupRecords, nil
+}
+
+func (r *SecurityGroupRepo) UpdateSecurityGroup(ctx context.Context, authInfo authorization.Info, message UpdateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	return r.patchSecurityGroup(ctx, message.GUID, message.apply)
+}
+
+func (r *SecurityGroupRepo) BindSecurityGroup(ctx context.Context, authInfo authorization.Info, message BindSecurityGroupMessage) (SecurityGroupRecord, error) {
+	return r.patchSecurityGroup(ctx, message.GUID, message.apply)
+}
+
+func (r *SecurityGroupRepo) UnbindSecurityGroup(ctx context.Context, authInfo authorization.Info, message UnbindSecurityGroupMessage) (SecurityGroupRecord, error) {
+	return r.patchSecurityGroup(ctx, message.GUID, message.apply)
+}
+
+func (r *SecurityGroupRepo) patchSecurityGroup(
+	ctx context.Context,
+	guid string,
+	patchFunc func(*korifiv1alpha1.CFSecurityGroup),
+) (SecurityGroupRecord, error) {
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := GetAndPatch(ctx, r.klient, cfSecurityGroup, func() error {
+		patchFunc(cfSecurityGroup)
+		return nil
+	}); err != nil {
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == validation.DuplicateNameErrorType {
+				return SecurityGroupRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
+		return SecurityGroupRecord{}, apierrors.FromK8sError(err, SecurityGroupResourceType)
+	}
+
+	return toSecurityGroupRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) DeleteSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Delete(ctx, cfSecurityGroup); err != nil {
+		return apierrors.FromK8sError(err, SecurityGroupResourceType)
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	securityGroup, err := r.GetSecurityGroup(ctx, authInfo, guid)
+	return securityGroup.DeletedAt, err
+}
+
+func toCFSecurityGroupRules(rules []SecurityGroupRule) []korifiv1alpha1.SecurityGroupRule {
+	return slices.Collect(it.Map(slices.Values(rules), func(r SecurityGroupRule) korifiv1alpha1.SecurityGroupRule {
+		return korifiv1alpha1.SecurityGroupRule{
+			Protocol:    r.Protocol,
+			Destination: r.Destination,
+			Ports:       r.Ports,
+			Type:        r.Type,
+			Code:        r.Code,
+			Description: r.Description,
+			Log:         r.Log,
+		}
+	}))
+}
+
 func toSecurityGroupRecord(cfSecurityGroup korifiv1alpha1.CFSecurityGroup) SecurityGroupRecord {
 	runningSpaces := []string{}
 	stagingSpaces := []string{}
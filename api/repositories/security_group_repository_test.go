@@ -1,12 +1,18 @@
 package repositories_test
 
 import (
+	"context"
+
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -101,4 +107,310 @@ var _ = Describe("SecurityGroupRepo", func() {
 			})
 		})
 	})
+
+	Describe("GetSecurityGroup", func() {
+		var (
+			cfSecurityGroup     *korifiv1alpha1.CFSecurityGroup
+			securityGroupRecord repositories.SecurityGroupRecord
+			getErr              error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(ctx, "get-security-group", space.Name)
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecord, getErr = repo.GetSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the security group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.GUID).To(Equal(cfSecurityGroup.Name))
+				Expect(securityGroupRecord.Name).To(Equal("get-security-group"))
+				Expect(securityGroupRecord.RunningSpaces).To(ConsistOf(space.Name))
+				Expect(securityGroupRecord.StagingSpaces).To(BeEmpty())
+			})
+
+			When("the security group does not exist", func() {
+				BeforeEach(func() {
+					cfSecurityGroup.Name = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListSecurityGroups", func() {
+		var (
+			sg1, sg2             *korifiv1alpha1.CFSecurityGroup
+			message              repositories.ListSecurityGroupMessage
+			securityGroupRecords []repositories.SecurityGroupRecord
+			listErr              error
+		)
+
+		BeforeEach(func() {
+			sg1 = createSecurityGroup(ctx, prefixedGUID("sg1"), space.Name)
+			sg2 = createSecurityGroup(ctx, prefixedGUID("sg2"))
+			message = repositories.ListSecurityGroupMessage{}
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecords, listErr = repo.ListSecurityGroups(ctx, authInfo, message)
+		})
+
+		It("returns an empty list for users with no permissions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(securityGroupRecords).To(BeEmpty())
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns all security groups", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecords).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg2.Name)}),
+				))
+			})
+
+			When("filtering by name", func() {
+				BeforeEach(func() {
+					message.Names = []string{sg2.Spec.DisplayName}
+				})
+
+				It("returns the matching security groups", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroupRecords).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg2.Name)}),
+					))
+				})
+			})
+
+			When("filtering by running space guids", func() {
+				BeforeEach(func() {
+					message.RunningSpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns the security groups bound to the space", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroupRecords).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg1.Name)}),
+					))
+				})
+			})
+
+			When("filtering by staging space guids", func() {
+				BeforeEach(func() {
+					message.StagingSpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns no security groups", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroupRecords).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("UpdateSecurityGroup", func() {
+		var (
+			cfSecurityGroup     *korifiv1alpha1.CFSecurityGroup
+			message             repositories.UpdateSecurityGroupMessage
+			securityGroupRecord repositories.SecurityGroupRecord
+			updateErr           error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(ctx, prefixedGUID("update"), space.Name)
+			message = repositories.UpdateSecurityGroupMessage{
+				GUID:        cfSecurityGroup.Name,
+				DisplayName: tools.PtrTo("updated-name"),
+				Rules: []repositories.SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolUDP,
+					Ports:       "53",
+					Destination: "10.0.0.0/8",
+				}},
+				GloballyEnabled: repositories.UpdateSecurityGroupWorkloads{
+					Staging: tools.PtrTo(true),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecord, updateErr = repo.UpdateSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the security group", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.Name).To(Equal("updated-name"))
+				Expect(securityGroupRecord.GloballyEnabled).To(Equal(repositories.SecurityGroupWorkloads{Running: false, Staging: true}))
+				Expect(securityGroupRecord.RunningSpaces).To(ConsistOf(space.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.DisplayName).To(Equal("updated-name"))
+				Expect(cfSecurityGroup.Spec.Rules).To(ConsistOf(korifiv1alpha1.SecurityGroupRule{
+					Protocol:    korifiv1alpha1.ProtocolUDP,
+					Ports:       "53",
+					Destination: "10.0.0.0/8",
+				}))
+			})
+		})
+	})
+
+	Describe("BindSecurityGroup", func() {
+		var (
+			cfSecurityGroup     *korifiv1alpha1.CFSecurityGroup
+			securityGroupRecord repositories.SecurityGroupRecord
+			bindErr             error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(ctx, prefixedGUID("bind"), space.Name)
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecord, bindErr = repo.BindSecurityGroup(ctx, authInfo, repositories.BindSecurityGroupMessage{
+				GUID:         cfSecurityGroup.Name,
+				SpaceGUIDs:   []string{space.Name},
+				WorkloadType: repositories.SecurityGroupStagingSpaces,
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(bindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("binds the space for the given workload type", func() {
+				Expect(bindErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.RunningSpaces).To(ConsistOf(space.Name))
+				Expect(securityGroupRecord.StagingSpaces).To(ConsistOf(space.Name))
+			})
+		})
+	})
+
+	Describe("UnbindSecurityGroup", func() {
+		var (
+			cfSecurityGroup     *korifiv1alpha1.CFSecurityGroup
+			securityGroupRecord repositories.SecurityGroupRecord
+			unbindErr           error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(ctx, prefixedGUID("unbind"), space.Name)
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecord, unbindErr = repo.UnbindSecurityGroup(ctx, authInfo, repositories.UnbindSecurityGroupMessage{
+				GUID:         cfSecurityGroup.Name,
+				SpaceGUID:    space.Name,
+				WorkloadType: repositories.SecurityGroupRunningSpaces,
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(unbindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("removes the space from the security group", func() {
+				Expect(unbindErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.RunningSpaces).To(BeEmpty())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.Spaces).NotTo(HaveKey(space.Name))
+			})
+		})
+	})
+
+	Describe("DeleteSecurityGroup", func() {
+		var (
+			cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+			deleteErr       error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(ctx, prefixedGUID("delete"))
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the security group", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
 })
+
+func createSecurityGroup(ctx context.Context, displayName string, runningSpaces ...string) *korifiv1alpha1.CFSecurityGroup {
+	GinkgoHelper()
+
+	spaces := map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+	for _, s := range runningSpaces {
+		spaces[s] = korifiv1alpha1.SecurityGroupWorkloads{Running: true}
+	}
+
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFSecurityGroupSpec{
+			DisplayName: displayName,
+			Rules: []korifiv1alpha1.SecurityGroupRule{{
+				Protocol:    korifiv1alpha1.ProtocolTCP,
+				Ports:       "443",
+				Destination: "10.0.0.1",
+			}},
+			Spaces: spaces,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+
+	return cfSecurityGroup
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,27 +33,34 @@ const (
 )
 
 type ParametersClient interface {
+	GetServiceInstanceParameters(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (map[string]any, error)
 	GetServiceBindingParameters(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (map[string]any, error)
 }
 
 type ServiceBindingRepo struct {
 	klient                  Klient
+	serviceInstanceRepo     *ServiceInstanceRepo
 	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceBinding]
 	appConditionAwaiter     Awaiter[*korifiv1alpha1.CFApp]
 	paramsClient            ParametersClient
+	rootNamespace           string
 }
 
 func NewServiceBindingRepo(
 	klient Klient,
+	serviceInstanceRepo *ServiceInstanceRepo,
 	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceBinding],
 	appConditionAwaiter Awaiter[*korifiv1alpha1.CFApp],
 	paramsClient ParametersClient,
+	rootNamespace string,
 ) *ServiceBindingRepo {
 	return &ServiceBindingRepo{
 		klient:                  klient,
+		serviceInstanceRepo:     serviceInstanceRepo,
 		bindingConditionAwaiter: bindingConditionAwaiter,
 		appConditionAwaiter:     appConditionAwaiter,
 		paramsClient:            paramsClient,
+		rootNamespace:           rootNamespace,
 	}
 }
 
@@ -112,13 +120,14 @@ type ListServiceBindingsMessage struct {
 }
 
 func (m *ListServiceBindingsMessage) matches(serviceBinding korifiv1alpha1.CFServiceBinding) bool {
-	return tools.EmptyOrContains(m.ServiceInstanceGUIDs, serviceBinding.Spec.Service.Name) &&
+	return serviceBinding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeRoute &&
+		tools.EmptyOrContains(m.ServiceInstanceGUIDs, serviceBinding.Spec.Service.Name) &&
 		tools.EmptyOrContains(m.AppGUIDs, serviceBinding.Spec.AppRef.Name) &&
 		tools.EmptyOrContains(m.PlanGUIDs, serviceBinding.Labels[korifiv1alpha1.PlanGUIDLabelKey]) &&
 		tools.ZeroOrEquals(m.Type, serviceBinding.Spec.Type)
 }
 
-func (m CreateServiceBindingMessage) toCFServiceBinding(instanceType korifiv1alpha1.InstanceType) *korifiv1alpha1.CFServiceBinding {
+func (m CreateServiceBindingMessage) toCFServiceBinding(serviceInstance ServiceInstanceRecord) *korifiv1alpha1.CFServiceBinding {
 	binding := &korifiv1alpha1.CFServiceBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      uuid.NewString(),
@@ -138,7 +147,11 @@ func (m CreateServiceBindingMessage) toCFServiceBinding(instanceType korifiv1alp
 		},
 	}
 
-	if instanceType == korifiv1alpha1.ManagedType {
+	if serviceInstance.SpaceGUID != m.SpaceGUID {
+		binding.Spec.Service.Namespace = serviceInstance.SpaceGUID
+	}
+
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
 		binding.Spec.Parameters.Name = uuid.NewString()
 	}
 
@@ -154,15 +167,20 @@ type UpdateServiceBindingMessage struct {
 	MetadataPatch MetadataPatch
 }
 
+type RotateServiceBindingCredentialsMessage struct {
+	GUID    string
+	AppMode string
+}
+
 func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
 	if message.Type == korifiv1alpha1.CFServiceBindingTypeApp {
-		return r.createAppServiceBinding(ctx, message)
+		return r.createAppServiceBinding(ctx, authInfo, message)
 	}
 
-	return r.createServiceBinding(ctx, message)
+	return r.createServiceBinding(ctx, authInfo, message)
 }
 
-func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
+func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
 	cfApp := &korifiv1alpha1.CFApp{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: message.SpaceGUID,
@@ -180,7 +198,7 @@ func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, messag
 			)
 	}
 
-	bindingRecord, err := r.createServiceBinding(ctx, message)
+	bindingRecord, err := r.createServiceBinding(ctx, authInfo, message)
 	if err != nil {
 		return ServiceBindingRecord{}, err
 	}
@@ -203,25 +221,22 @@ func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, messag
 	return bindingRecord, nil
 }
 
-func (r *ServiceBindingRepo) createServiceBinding(ctx context.Context, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
-	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: message.SpaceGUID,
-			Name:      message.ServiceInstanceGUID,
-		},
-	}
-	err := r.klient.Get(ctx, cfServiceInstance)
+func (r *ServiceBindingRepo) createServiceBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
+	serviceInstance, err := r.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, message.ServiceInstanceGUID)
 	if err != nil {
 		return ServiceBindingRecord{},
 			apierrors.AsUnprocessableEntity(
-				apierrors.FromK8sError(err, ServiceBindingResourceType),
+				err,
 				"Unable to bind to instance. Ensure that the instance exists and you have access to it.",
 				apierrors.ForbiddenError{},
 				apierrors.NotFoundError{},
 			)
 	}
 
-	cfServiceBinding := message.toCFServiceBinding(cfServiceInstance.Spec.Type)
+	cfServiceBinding := message.toCFServiceBinding(serviceInstance)
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		setOriginatingIdentity(ctx, cfServiceBinding)
+	}
 	err = r.klient.Create(ctx, cfServiceBinding)
 	if err != nil {
 		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
@@ -233,14 +248,14 @@ func (r *ServiceBindingRepo) createServiceBinding(ctx context.Context, message C
 		return ServiceBindingRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
 	}
 
-	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
 		err = r.createParametersSecret(ctx, cfServiceBinding, message.Parameters)
 		if err != nil {
 			return ServiceBindingRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
 		}
 	}
 
-	if cfServiceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
+	if serviceInstance.Type == korifiv1alpha1.UserProvidedType {
 		cfServiceBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, r.klient, cfServiceBinding, korifiv1alpha1.StatusConditionReady)
 		if err != nil {
 			return ServiceBindingRecord{}, err
@@ -287,6 +302,16 @@ func (r *ServiceBindingRepo) DeleteServiceBinding(ctx context.Context, authInfo
 		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceBindingResourceType))
 	}
 
+	if binding.Annotations[korifiv1alpha1.ServiceInstanceTypeAnnotation] == korifiv1alpha1.ManagedType {
+		err = r.klient.Patch(ctx, binding, func() error {
+			setOriginatingIdentity(ctx, binding)
+			return nil
+		})
+		if err != nil {
+			return apierrors.FromK8sError(err, ServiceBindingResourceType)
+		}
+	}
+
 	err = r.klient.Delete(ctx, binding)
 	if err != nil {
 		return apierrors.FromK8sError(err, ServiceBindingResourceType)
@@ -463,6 +488,102 @@ func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo
 	return serviceBindingToRecord(*serviceBinding), nil
 }
 
+// RotateServiceBindingCredentials requests the controllers to bind to the
+// managed service instance again and replace the binding credentials with the
+// ones returned by the broker. Rotating credentials is an admin only operation,
+// so the user is required to be able to patch service bindings in the root
+// namespace
+func (r *ServiceBindingRepo) RotateServiceBindingCredentials(ctx context.Context, authInfo authorization.Info, message RotateServiceBindingCredentialsMessage) (ServiceBindingRecord, error) {
+	authorized, err := r.canIRotateCredentials(ctx)
+	if err != nil {
+		return ServiceBindingRecord{}, err
+	}
+
+	if !authorized {
+		return ServiceBindingRecord{}, apierrors.NewForbiddenError(errors.New("only admins can rotate service binding credentials"), ServiceBindingResourceType)
+	}
+
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.GUID,
+		},
+	}
+
+	err = r.klient.Get(ctx, serviceBinding)
+	if err != nil {
+		return ServiceBindingRecord{}, fmt.Errorf("failed to get service binding: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	if serviceBinding.Annotations[korifiv1alpha1.ServiceInstanceTypeAnnotation] != korifiv1alpha1.ManagedType {
+		return ServiceBindingRecord{}, apierrors.NewUnprocessableEntityError(nil, "Credentials can only be rotated for bindings to managed service instances.")
+	}
+
+	rotatable, err := r.isBindingRotatable(ctx, serviceBinding)
+	if err != nil {
+		return ServiceBindingRecord{}, err
+	}
+
+	if !rotatable {
+		return ServiceBindingRecord{}, apierrors.NewUnprocessableEntityError(nil, "The service plan does not support binding rotation.")
+	}
+
+	err = r.klient.Patch(ctx, serviceBinding, func() error {
+		serviceBinding.Spec.CredentialsRotation = &korifiv1alpha1.CredentialsRotation{
+			ID:      uuid.NewString(),
+			AppMode: tools.IfZero(message.AppMode, korifiv1alpha1.CredentialsRotationAppModeNone),
+		}
+		setOriginatingIdentity(ctx, serviceBinding)
+		return nil
+	})
+	if err != nil {
+		return ServiceBindingRecord{}, fmt.Errorf("failed to request service binding credentials rotation: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	return serviceBindingToRecord(*serviceBinding), nil
+}
+
+func (r *ServiceBindingRepo) isBindingRotatable(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (bool, error) {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: serviceBinding.ServiceInstanceNamespace(),
+			Name:      serviceBinding.Spec.Service.Name,
+		},
+	}
+	if err := r.klient.Get(ctx, serviceInstance); err != nil {
+		return false, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	servicePlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      serviceInstance.Spec.PlanGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, servicePlan); err != nil {
+		return false, fmt.Errorf("failed to get service plan: %w", apierrors.FromK8sError(err, ServicePlanResourceType))
+	}
+
+	return servicePlan.Spec.BrokerCatalog.Features.BindingRotatable, nil
+}
+
+func (r *ServiceBindingRepo) canIRotateCredentials(ctx context.Context) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.rootNamespace,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfservicebindings",
+			},
+		},
+	}
+	if err := r.klient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canIRotateCredentials: failed to create self subject access review: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
 func (r *ServiceBindingRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (ResourceState, error) {
 	bindingRecord, err := r.GetServiceBinding(ctx, authInfo, guid)
 	if err != nil {
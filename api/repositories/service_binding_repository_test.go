@@ -71,11 +71,27 @@ var _ = Describe("ServiceBindingRepo", func() {
 			rootNamespace,
 		)
 
+		serviceInstanceRepo := repositories.NewServiceInstanceRepo(
+			klient,
+			k8sClient,
+			nsPerms,
+			&fakeawaiter.FakeAwaiter[
+				*korifiv1alpha1.CFServiceInstance,
+				korifiv1alpha1.CFServiceInstanceList,
+				*korifiv1alpha1.CFServiceInstanceList,
+			]{},
+			repositories.NewServiceInstanceSorter(),
+			paramsClient,
+			rootNamespace,
+		)
+
 		repo = repositories.NewServiceBindingRepo(
 			klient,
+			serviceInstanceRepo,
 			bindingConditionAwaiter,
 			appConditionAwaiter,
 			paramsClient,
+			rootNamespace,
 		)
 
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
@@ -1511,6 +1527,163 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 	})
 
+	Describe("RotateServiceBindingCredentials", func() {
+		var (
+			servicePlan    *korifiv1alpha1.CFServicePlan
+			serviceBinding *korifiv1alpha1.CFServiceBinding
+			bindingRecord  repositories.ServiceBindingRecord
+			rotateMessage  repositories.RotateServiceBindingCredentialsMessage
+			rotateErr      error
+		)
+
+		BeforeEach(func() {
+			servicePlan = &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServicePlanSpec{
+					Visibility: korifiv1alpha1.ServicePlanVisibility{
+						Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+					},
+					BrokerCatalog: korifiv1alpha1.ServicePlanBrokerCatalog{
+						Features: korifiv1alpha1.ServicePlanFeatures{
+							BindingRotatable: true,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, servicePlan)).To(Succeed())
+
+			serviceInstance := &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type:     korifiv1alpha1.ManagedType,
+					PlanGUID: servicePlan.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceInstance)).To(Succeed())
+
+			serviceBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("binding"),
+					Namespace: space.Name,
+					Annotations: map[string]string{
+						korifiv1alpha1.ServiceInstanceTypeAnnotation: korifiv1alpha1.ManagedType,
+					},
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type: korifiv1alpha1.CFServiceBindingTypeApp,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       serviceInstance.Name,
+					},
+					AppRef: corev1.LocalObjectReference{
+						Name: appGUID,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceBinding)).To(Succeed())
+
+			rotateMessage = repositories.RotateServiceBindingCredentialsMessage{
+				GUID:    serviceBinding.Name,
+				AppMode: korifiv1alpha1.CredentialsRotationAppModeRestart,
+			}
+		})
+
+		JustBeforeEach(func() {
+			bindingRecord, rotateErr = repo.RotateServiceBindingCredentials(ctx, authInfo, rotateMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(rotateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(rotateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is a CFAdmin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, space.Name)
+			})
+
+			It("returns the binding record", func() {
+				Expect(rotateErr).NotTo(HaveOccurred())
+				Expect(bindingRecord.GUID).To(Equal(serviceBinding.Name))
+			})
+
+			It("requests the credentials rotation", func() {
+				Expect(rotateErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBinding), serviceBinding)).To(Succeed())
+				Expect(serviceBinding.Spec.CredentialsRotation).To(PointTo(MatchAllFields(Fields{
+					"ID":      Not(BeEmpty()),
+					"AppMode": Equal(korifiv1alpha1.CredentialsRotationAppModeRestart),
+				})))
+			})
+
+			When("the app mode is not specified", func() {
+				BeforeEach(func() {
+					rotateMessage.AppMode = ""
+				})
+
+				It("defaults it to none", func() {
+					Expect(rotateErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBinding), serviceBinding)).To(Succeed())
+					Expect(serviceBinding.Spec.CredentialsRotation.AppMode).To(Equal(korifiv1alpha1.CredentialsRotationAppModeNone))
+				})
+			})
+
+			When("the binding is to a user-provided service instance", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceBinding, func() {
+						serviceBinding.Annotations[korifiv1alpha1.ServiceInstanceTypeAnnotation] = korifiv1alpha1.UserProvidedType
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(rotateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the service plan does not support binding rotation", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
+						servicePlan.Spec.BrokerCatalog.Features.BindingRotatable = false
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(rotateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(rotateErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal("The service plan does not support binding rotation."))
+				})
+			})
+
+			When("the service binding does not exist", func() {
+				BeforeEach(func() {
+					rotateMessage.GUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(rotateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
 	Describe("GetServiceBindingDetails", func() {
 		var (
 			serviceBindingGUID   string
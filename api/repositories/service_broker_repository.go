@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"time"
 
@@ -32,16 +33,19 @@ type CreateServiceBrokerMessage struct {
 	URL         string
 	Credentials BrokerCredentials
 	Metadata    Metadata
+	SpaceGUID   string
 }
 
 type ListServiceBrokerMessage struct {
-	Names []string
-	GUIDs []string
+	Names      []string
+	GUIDs      []string
+	SpaceGUIDs []string
 }
 
 func (l ListServiceBrokerMessage) matches(b korifiv1alpha1.CFServiceBroker) bool {
 	return tools.EmptyOrContains(l.Names, b.Spec.Name) &&
-		tools.EmptyOrContains(l.GUIDs, b.Name)
+		tools.EmptyOrContains(l.GUIDs, b.Name) &&
+		tools.EmptyOrContains(l.SpaceGUIDs, b.Labels[korifiv1alpha1.SpaceGUIDKey])
 }
 
 type UpdateServiceBrokerMessage struct {
@@ -73,13 +77,20 @@ type ServiceBrokerRecord struct {
 	GUID      string
 	Name      string
 	URL       string
+	SpaceGUID string
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 	Metadata  Metadata
 }
 
 func (r ServiceBrokerRecord) Relationships() map[string]string {
-	return nil
+	if r.SpaceGUID == "" {
+		return nil
+	}
+
+	return map[string]string{
+		"space": r.SpaceGUID,
+	}
 }
 
 func NewServiceBrokerRepo(
@@ -101,12 +112,21 @@ func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo au
 		return ServiceBrokerRecord{}, fmt.Errorf("failed to create credentials secret data: %w", err)
 	}
 
+	// Space-scoped brokers live in their space namespace, global ones in the
+	// root namespace
+	namespace := r.rootNamespace
+	labels := message.Metadata.Labels
+	if message.SpaceGUID != "" {
+		namespace = message.SpaceGUID
+		labels = tools.SetMapValue(maps.Clone(labels), korifiv1alpha1.SpaceGUIDKey, message.SpaceGUID)
+	}
+
 	credentialsSecretName := uuid.NewString()
 	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:   r.rootNamespace,
+			Namespace:   namespace,
 			Name:        uuid.NewString(),
-			Labels:      message.Metadata.Labels,
+			Labels:      labels,
 			Annotations: message.Metadata.Annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceBrokerSpec{
@@ -123,7 +143,7 @@ func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo au
 
 	credentialsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: namespace,
 			Name:      credentialsSecretName,
 		},
 		Data: credsSecretData,
@@ -145,6 +165,7 @@ func toServiceBrokerRecord(cfServiceBroker korifiv1alpha1.CFServiceBroker) Servi
 		Name:      cfServiceBroker.Spec.Name,
 		URL:       cfServiceBroker.Spec.URL,
 		GUID:      cfServiceBroker.Name,
+		SpaceGUID: cfServiceBroker.Labels[korifiv1alpha1.SpaceGUIDKey],
 		CreatedAt: cfServiceBroker.CreationTimestamp.Time,
 		Metadata: Metadata{
 			Labels:      cfServiceBroker.Labels,
@@ -156,8 +177,7 @@ func toServiceBrokerRecord(cfServiceBroker korifiv1alpha1.CFServiceBroker) Servi
 func (r *ServiceBrokerRepo) GetState(ctx context.Context, authInfo authorization.Info, brokerGUID string) (ResourceState, error) {
 	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      brokerGUID,
+			Name: brokerGUID,
 		},
 	}
 
@@ -186,7 +206,15 @@ func (r *ServiceBrokerRepo) ListServiceBrokers(ctx context.Context, authInfo aut
 		return nil, fmt.Errorf("failed to list brokers: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
 	}
 
-	brokers := itx.FromSlice(brokersList.Items).Filter(message.matches)
+	// Listing without a namespace only returns the space-scoped brokers in
+	// the spaces the user has access to
+	spaceBrokersList := &korifiv1alpha1.CFServiceBrokerList{}
+	err = r.klient.List(ctx, spaceBrokersList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space-scoped brokers: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	brokers := itx.FromSlice(slices.Concat(brokersList.Items, spaceBrokersList.Items)).Filter(message.matches)
 
 	return slices.Collect(it.Map(brokers, toServiceBrokerRecord)), nil
 }
@@ -202,8 +230,7 @@ func (r *ServiceBrokerRepo) GetServiceBroker(ctx context.Context, authInfo autho
 func (r *ServiceBrokerRepo) getServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) (*korifiv1alpha1.CFServiceBroker, error) {
 	serviceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      guid,
+			Name: guid,
 		},
 	}
 
@@ -215,11 +242,9 @@ func (r *ServiceBrokerRepo) getServiceBroker(ctx context.Context, authInfo autho
 }
 
 func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo authorization.Info, message UpdateServiceBrokerMessage) (ServiceBrokerRecord, error) {
-	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      message.GUID,
-			Namespace: r.rootNamespace,
-		},
+	cfServiceBroker, err := r.getServiceBroker(ctx, authInfo, message.GUID)
+	if err != nil {
+		return ServiceBrokerRecord{}, err
 	}
 
 	if err := GetAndPatch(ctx, r.klient, cfServiceBroker, func() error {
@@ -240,7 +265,7 @@ func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo au
 
 		credentialsSecret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: r.rootNamespace,
+				Namespace: cfServiceBroker.Namespace,
 				Name:      cfServiceBroker.Spec.Credentials.Name,
 			},
 		}
@@ -256,12 +281,26 @@ func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo au
 	return toServiceBrokerRecord(*cfServiceBroker), nil
 }
 
+func (r *ServiceBrokerRepo) RefreshServiceBrokerCatalog(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBrokerRecord, error) {
+	cfServiceBroker, err := r.getServiceBroker(ctx, authInfo, guid)
+	if err != nil {
+		return ServiceBrokerRecord{}, err
+	}
+
+	if err := GetAndPatch(ctx, r.klient, cfServiceBroker, func() error {
+		cfServiceBroker.Spec.CatalogRefreshRequestID = uuid.NewString()
+		return nil
+	}); err != nil {
+		return ServiceBrokerRecord{}, apierrors.FromK8sError(err, ServiceBrokerResourceType)
+	}
+
+	return toServiceBrokerRecord(*cfServiceBroker), nil
+}
+
 func (r *ServiceBrokerRepo) DeleteServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) error {
-	serviceBroker := &korifiv1alpha1.CFServiceBroker{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      guid,
-			Namespace: r.rootNamespace,
-		},
+	serviceBroker, err := r.getServiceBroker(ctx, authInfo, guid)
+	if err != nil {
+		return err
 	}
 
 	return apierrors.FromK8sError(
@@ -139,6 +139,47 @@ var _ = Describe("ServiceBrokerRepo", func() {
 				})))
 			})
 		})
+
+		When("the broker is space-scoped", func() {
+			var cfSpace *korifiv1alpha1.CFSpace
+
+			BeforeEach(func() {
+				cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+				cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+
+				createMsg.SpaceGUID = cfSpace.Name
+			})
+
+			It("returns a space-scoped ServiceBrokerRecord", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(brokerRecord.SpaceGUID).To(Equal(cfSpace.Name))
+				Expect(brokerRecord.Relationships()).To(Equal(map[string]string{"space": cfSpace.Name}))
+			})
+
+			It("creates the CFServiceBroker and its credentials secret in the space namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cfSpace.Name,
+						Name:      brokerRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), cfServiceBroker)).To(Succeed())
+				Expect(cfServiceBroker.Labels).To(SatisfyAll(
+					HaveKeyWithValue("label", "label-value"),
+					HaveKeyWithValue(korifiv1alpha1.SpaceGUIDKey, cfSpace.Name),
+				))
+
+				credentialsSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cfSpace.Name,
+						Name:      cfServiceBroker.Spec.Credentials.Name,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)).To(Succeed())
+			})
+		})
 	})
 
 	Describe("GetState", func() {
@@ -338,6 +379,56 @@ var _ = Describe("ServiceBrokerRepo", func() {
 				Expect(brokers).To(BeEmpty())
 			})
 		})
+
+		When("there are space-scoped brokers", func() {
+			var cfSpace, otherSpace *korifiv1alpha1.CFSpace
+
+			BeforeEach(func() {
+				cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+				cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+				otherSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+
+				for _, space := range []*korifiv1alpha1.CFSpace{cfSpace, otherSpace} {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBroker{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      "broker-" + space.Name,
+							Labels: map[string]string{
+								korifiv1alpha1.SpaceGUIDKey: space.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFServiceBrokerSpec{
+							Name: "space-broker-" + space.Name,
+							URL:  "https://space.broker",
+						},
+					})).To(Succeed())
+				}
+			})
+
+			It("returns the global brokers and the brokers in spaces the user has access to", func() {
+				Expect(brokers).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal("broker-1")}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal("broker-2")}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal("broker-" + cfSpace.Name),
+						"SpaceGUID": Equal(cfSpace.Name),
+					}),
+				))
+			})
+
+			When("a space guid filter is applied", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{cfSpace.Name}
+				})
+
+				It("only returns the brokers in that space", func() {
+					Expect(brokers).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal("broker-" + cfSpace.Name)}),
+					))
+				})
+			})
+		})
 	})
 
 	Describe("GetServiceBroker", func() {
@@ -534,6 +625,64 @@ var _ = Describe("ServiceBrokerRepo", func() {
 		})
 	})
 
+	Describe("RefreshServiceBrokerCatalog", func() {
+		var (
+			cfServiceBroker *korifiv1alpha1.CFServiceBroker
+			brokerRecord    repositories.ServiceBrokerRecord
+			refreshErr      error
+		)
+
+		BeforeEach(func() {
+			cfServiceBroker = &korifiv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBrokerSpec{
+					Name: "my-broker",
+					URL:  "https://my.broker",
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfServiceBroker)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			brokerRecord, refreshErr = repo.RefreshServiceBrokerCatalog(ctx, authInfo, cfServiceBroker.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(refreshErr).To(WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user has permissions to update brokers", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the broker record", func() {
+				Expect(refreshErr).NotTo(HaveOccurred())
+				Expect(brokerRecord.GUID).To(Equal(cfServiceBroker.Name))
+			})
+
+			It("requests a catalog refresh", func() {
+				Expect(refreshErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), cfServiceBroker)).To(Succeed())
+				Expect(cfServiceBroker.Spec.CatalogRefreshRequestID).NotTo(BeEmpty())
+			})
+		})
+
+		When("the broker does not exist", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				cfServiceBroker.Name = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(refreshErr).To(WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
 	Describe("DeleteServiceBroker", func() {
 		var (
 			deleteErr  error
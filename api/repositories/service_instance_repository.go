@@ -36,10 +36,13 @@ type NamespaceGetter interface {
 }
 
 type ServiceInstanceRepo struct {
-	klient        Klient
-	awaiter       Awaiter[*korifiv1alpha1.CFServiceInstance]
-	sorter        ServiceInstanceSorter
-	rootNamespace string
+	klient               Klient
+	privilegedClient     client.Client
+	namespacePermissions *authorization.NamespacePermissions
+	awaiter              Awaiter[*korifiv1alpha1.CFServiceInstance]
+	sorter               ServiceInstanceSorter
+	paramsClient         ParametersClient
+	rootNamespace        string
 }
 
 //counterfeiter:generate -o fake -fake-name ServiceInstanceSorter . ServiceInstanceSorter
@@ -83,25 +86,32 @@ func ServiceInstanceComparator(fieldName string) func(ServiceInstanceRecord, Ser
 
 func NewServiceInstanceRepo(
 	klient Klient,
+	privilegedClient client.Client,
+	namespacePermissions *authorization.NamespacePermissions,
 	awaiter Awaiter[*korifiv1alpha1.CFServiceInstance],
 	sorter ServiceInstanceSorter,
+	paramsClient ParametersClient,
 	rootNamespace string,
 ) *ServiceInstanceRepo {
 	return &ServiceInstanceRepo{
-		klient:        klient,
-		awaiter:       awaiter,
-		sorter:        sorter,
-		rootNamespace: rootNamespace,
+		klient:               klient,
+		privilegedClient:     privilegedClient,
+		namespacePermissions: namespacePermissions,
+		awaiter:              awaiter,
+		sorter:               sorter,
+		paramsClient:         paramsClient,
+		rootNamespace:        rootNamespace,
 	}
 }
 
 type CreateUPSIMessage struct {
-	Name        string
-	SpaceGUID   string
-	Credentials map[string]any
-	Tags        []string
-	Labels      map[string]string
-	Annotations map[string]string
+	Name            string
+	SpaceGUID       string
+	Credentials     map[string]any
+	RouteServiceURL *string
+	Tags            []string
+	Labels          map[string]string
+	Annotations     map[string]string
 }
 
 type CreateManagedSIMessage struct {
@@ -115,14 +125,24 @@ type CreateManagedSIMessage struct {
 }
 
 type PatchServiceInstanceMessage struct {
-	GUID        string
-	SpaceGUID   string
-	Name        *string
-	Credentials *map[string]any
-	Tags        *[]string
+	GUID                   string
+	SpaceGUID              string
+	Name                   *string
+	Credentials            *map[string]any
+	RouteServiceURL        *string
+	Tags                   *[]string
+	PlanGUID               *string
+	Parameters             *map[string]any
+	MaintenanceInfoVersion *string
 	MetadataPatch
 }
 
+// RequiresBrokerUpdate returns true if the patch changes the service instance
+// in a way the service broker has to be notified about
+func (p PatchServiceInstanceMessage) RequiresBrokerUpdate() bool {
+	return p.PlanGUID != nil || p.Parameters != nil || p.MaintenanceInfoVersion != nil
+}
+
 func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFServiceInstance) {
 	if p.Name != nil {
 		cfServiceInstance.Spec.DisplayName = *p.Name
@@ -130,6 +150,17 @@ func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFS
 	if p.Tags != nil {
 		cfServiceInstance.Spec.Tags = *p.Tags
 	}
+	if p.RouteServiceURL != nil {
+		cfServiceInstance.Spec.RouteServiceURL = p.RouteServiceURL
+	}
+	if p.PlanGUID != nil && *p.PlanGUID != cfServiceInstance.Spec.PlanGUID {
+		cfServiceInstance.Spec.PlanGUID = *p.PlanGUID
+		// The instance gets the maintenance info of the new plan
+		cfServiceInstance.Spec.MaintenanceInfo = korifiv1alpha1.MaintenanceInfo{}
+	}
+	if p.MaintenanceInfoVersion != nil {
+		cfServiceInstance.Spec.MaintenanceInfo.Version = *p.MaintenanceInfoVersion
+	}
 	p.MetadataPatch.Apply(cfServiceInstance)
 }
 
@@ -147,15 +178,40 @@ func (m *ListServiceInstanceMessage) matches(serviceInstance korifiv1alpha1.CFSe
 	return tools.EmptyOrContains(m.Names, serviceInstance.Spec.DisplayName) &&
 		tools.EmptyOrContains(m.GUIDs, serviceInstance.Name) &&
 		tools.EmptyOrContains(m.PlanGUIDs, serviceInstance.Spec.PlanGUID) &&
-		tools.EmptyOrContains(m.SpaceGUIDs, serviceInstance.Namespace) &&
+		m.matchesSpace(serviceInstance) &&
 		tools.ZeroOrEquals(korifiv1alpha1.InstanceType(m.Type), serviceInstance.Spec.Type)
 }
 
+func (m *ListServiceInstanceMessage) matchesSpace(serviceInstance korifiv1alpha1.CFServiceInstance) bool {
+	if tools.EmptyOrContains(m.SpaceGUIDs, serviceInstance.Namespace) {
+		return true
+	}
+
+	return slices.ContainsFunc(serviceInstance.Spec.SharedSpaces, func(spaceGUID string) bool {
+		return slices.Contains(m.SpaceGUIDs, spaceGUID)
+	})
+}
+
 type DeleteServiceInstanceMessage struct {
 	GUID  string
 	Purge bool
 }
 
+type ShareServiceInstanceMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+}
+
+type UnshareServiceInstanceMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+type SharedSpaceUsageSummaryRecord struct {
+	SpaceGUID     string
+	BoundAppCount int
+}
+
 type ServiceInstanceRecord struct {
 	Name             string
 	GUID             string
@@ -163,6 +219,7 @@ type ServiceInstanceRecord struct {
 	PlanGUID         string
 	Tags             []string
 	Type             string
+	RouteServiceURL  *string
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -172,6 +229,7 @@ type ServiceInstanceRecord struct {
 	Ready            bool
 	MaintenanceInfo  MaintenanceInfo
 	UpgradeAvailable bool
+	SharedSpaceGUIDs []string
 }
 
 func (r ServiceInstanceRecord) Relationships() map[string]string {
@@ -194,10 +252,11 @@ func (r *ServiceInstanceRepo) CreateUserProvidedServiceInstance(ctx context.Cont
 			Annotations: message.Annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
-			DisplayName: message.Name,
-			SecretName:  uuid.NewString(),
-			Type:        korifiv1alpha1.UserProvidedType,
-			Tags:        message.Tags,
+			DisplayName:     message.Name,
+			SecretName:      uuid.NewString(),
+			Type:            korifiv1alpha1.UserProvidedType,
+			Tags:            message.Tags,
+			RouteServiceURL: message.RouteServiceURL,
 		},
 	}
 	err := r.klient.Create(ctx, cfServiceInstance)
@@ -240,13 +299,14 @@ func (r *ServiceInstanceRepo) CreateManagedServiceInstance(ctx context.Context,
 			},
 		},
 	}
+	setOriginatingIdentity(ctx, cfServiceInstance)
 
 	err = r.klient.Create(ctx, cfServiceInstance)
 	if err != nil {
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
-	err = r.createParametersSecret(ctx, cfServiceInstance, message.Parameters)
+	err = r.createParametersSecret(ctx, cfServiceInstance, cfServiceInstance.Spec.Parameters.Name, message.Parameters)
 	if err != nil {
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
 	}
@@ -254,7 +314,7 @@ func (r *ServiceInstanceRepo) CreateManagedServiceInstance(ctx context.Context,
 	return cfServiceInstanceToRecord(*cfServiceInstance), nil
 }
 
-func (r *ServiceInstanceRepo) createParametersSecret(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, parameters map[string]any) error {
+func (r *ServiceInstanceRepo) createParametersSecret(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, secretName string, parameters map[string]any) error {
 	parametersData, err := tools.ToParametersSecretData(parameters)
 	if err != nil {
 		return err
@@ -263,7 +323,7 @@ func (r *ServiceInstanceRepo) createParametersSecret(ctx context.Context, cfServ
 	paramsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfServiceInstance.Namespace,
-			Name:      cfServiceInstance.Spec.Parameters.Name,
+			Name:      secretName,
 		},
 		Data: parametersData,
 	}
@@ -285,6 +345,10 @@ func (r *ServiceInstanceRepo) servicePlanVisible(ctx context.Context, planGUID s
 		return false, err
 	}
 
+	if servicePlan.Spec.Unavailable {
+		return false, nil
+	}
+
 	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.PublicServicePlanVisibilityType {
 		return true, nil
 	}
@@ -293,6 +357,10 @@ func (r *ServiceInstanceRepo) servicePlanVisible(ctx context.Context, planGUID s
 		return false, nil
 	}
 
+	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.SpaceServicePlanVisibilityType {
+		return servicePlan.Spec.Visibility.Space == spaceGUID, nil
+	}
+
 	space := &korifiv1alpha1.CFSpace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: spaceGUID,
@@ -318,8 +386,34 @@ func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	if message.RouteServiceURL != nil && cfServiceInstance.Spec.Type != korifiv1alpha1.UserProvidedType {
+		return ServiceInstanceRecord{}, apierrors.NewUnprocessableEntityError(nil, "Route service URL can only be updated for user-provided service instances.")
+	}
+
+	if message.RequiresBrokerUpdate() {
+		if err := r.validateBrokerUpdate(ctx, cfServiceInstance, message); err != nil {
+			return ServiceInstanceRecord{}, err
+		}
+	}
+
+	parametersSecretName := ""
+	if message.Parameters != nil {
+		parametersSecretName = uuid.NewString()
+		if err := r.createParametersSecret(ctx, cfServiceInstance, parametersSecretName, *message.Parameters); err != nil {
+			return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
+		}
+	}
+
 	err := r.klient.Patch(ctx, cfServiceInstance, func() error {
 		message.Apply(cfServiceInstance)
+		if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+			setOriginatingIdentity(ctx, cfServiceInstance)
+		}
+		if parametersSecretName != "" {
+			// The controller sends the parameters to the broker whenever the
+			// parameters secret reference changes
+			cfServiceInstance.Spec.Parameters.Name = parametersSecretName
+		}
 		return nil
 	})
 	if err != nil {
@@ -340,6 +434,73 @@ func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo
 	return cfServiceInstanceToRecord(*cfServiceInstance), nil
 }
 
+func (r *ServiceInstanceRepo) validateBrokerUpdate(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, message PatchServiceInstanceMessage) error {
+	if cfServiceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return apierrors.NewUnprocessableEntityError(nil, "Service plan, parameters and maintenance info can only be updated for managed service instances.")
+	}
+
+	if cfServiceInstance.Status.LastOperation.State == "initial" || cfServiceInstance.Status.LastOperation.State == "in progress" {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("An operation for service instance %s is in progress.", cfServiceInstance.Spec.DisplayName))
+	}
+
+	targetPlanGUID := cfServiceInstance.Spec.PlanGUID
+	if message.PlanGUID != nil {
+		targetPlanGUID = *message.PlanGUID
+	}
+
+	targetPlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      targetPlanGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, targetPlan); err != nil {
+		return apierrors.NewUnprocessableEntityError(err, "Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.")
+	}
+
+	if targetPlanGUID != cfServiceInstance.Spec.PlanGUID {
+		if err := r.validatePlanChange(ctx, cfServiceInstance, targetPlan); err != nil {
+			return err
+		}
+	}
+
+	if message.MaintenanceInfoVersion != nil && *message.MaintenanceInfoVersion != targetPlan.Spec.MaintenanceInfo.Version {
+		return apierrors.NewUnprocessableEntityError(nil, "The maintenance_info.version requested is invalid. Please ensure it matches what the service plan's latest version is.")
+	}
+
+	return nil
+}
+
+func (r *ServiceInstanceRepo) validatePlanChange(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, targetPlan *korifiv1alpha1.CFServicePlan) error {
+	planVisible, err := r.servicePlanVisible(ctx, targetPlan.Name, cfServiceInstance.Namespace)
+	if err != nil || !planVisible {
+		return apierrors.NewUnprocessableEntityError(err, "Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.")
+	}
+
+	currentPlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      cfServiceInstance.Spec.PlanGUID,
+		},
+	}
+	if err = r.klient.Get(ctx, currentPlan); err != nil {
+		return apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	offeringGUID := currentPlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]
+	if targetPlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel] != offeringGUID {
+		return apierrors.NewUnprocessableEntityError(nil, "The service plan must belong to the service offering of the service instance.")
+	}
+
+	// The plan carries the resolved plan_updateable value, so an explicit
+	// plan-level false takes precedence over the offering
+	if !currentPlan.Spec.BrokerCatalog.Features.PlanUpdateable {
+		return apierrors.NewUnprocessableEntityError(nil, "The service does not support changing plans.")
+	}
+
+	return nil
+}
+
 func (r *ServiceInstanceRepo) migrateLegacyCredentials(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (*korifiv1alpha1.CFServiceInstance, error) {
 	cfServiceInstance, err := r.awaiter.AwaitCondition(ctx, r.klient, cfServiceInstance, korifiv1alpha1.StatusConditionReady)
 	if err != nil {
@@ -419,10 +580,44 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 		)
 	}
 
-	filteredServiceInstances := itx.FromSlice(serviceInstanceList.Items).Filter(message.matches)
+	sharedServiceInstances, err := r.listSharedServiceInstances(ctx, authInfo, client.MatchingLabelsSelector{Selector: labelSelector})
+	if err != nil {
+		return []ServiceInstanceRecord{}, err
+	}
+
+	serviceInstances := serviceInstanceList.Items
+	for _, sharedServiceInstance := range sharedServiceInstances {
+		if !slices.ContainsFunc(serviceInstances, func(si korifiv1alpha1.CFServiceInstance) bool { return si.Name == sharedServiceInstance.Name }) {
+			serviceInstances = append(serviceInstances, sharedServiceInstance)
+		}
+	}
+
+	filteredServiceInstances := itx.FromSlice(serviceInstances).Filter(message.matches)
 	return r.sorter.Sort(slices.Collect(it.Map(filteredServiceInstances, cfServiceInstanceToRecord)), message.OrderBy), nil
 }
 
+// listSharedServiceInstances returns the service instances shared with the
+// spaces the user has a role in. The user is not necessarily allowed to read
+// service instances in the space they have been created in, therefore they
+// are listed with the privileged client
+func (r *ServiceInstanceRepo) listSharedServiceInstances(ctx context.Context, authInfo authorization.Info, opts ...client.ListOption) ([]korifiv1alpha1.CFServiceInstance, error) {
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	serviceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
+	if err = r.privilegedClient.List(ctx, serviceInstanceList, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list shared service instances: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return itx.FromSlice(serviceInstanceList.Items).Filter(func(serviceInstance korifiv1alpha1.CFServiceInstance) bool {
+		return slices.ContainsFunc(serviceInstance.Spec.SharedSpaces, func(spaceGUID string) bool {
+			return authorizedSpaces[spaceGUID]
+		})
+	}).Collect(), nil
+}
+
 func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo authorization.Info, guid string) (ServiceInstanceRecord, error) {
 	serviceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
@@ -430,7 +625,12 @@ func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo a
 		},
 	}
 	if err := r.klient.Get(ctx, serviceInstance); err != nil {
-		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		sharedServiceInstances, sharedErr := r.listSharedServiceInstances(ctx, authInfo, client.MatchingFields{"metadata.name": guid})
+		if sharedErr != nil || len(sharedServiceInstances) == 0 {
+			return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		}
+
+		return cfServiceInstanceToRecord(sharedServiceInstances[0]), nil
 	}
 
 	return cfServiceInstanceToRecord(*serviceInstance), nil
@@ -465,6 +665,28 @@ func (r *ServiceInstanceRepo) GetServiceInstanceCredentials(ctx context.Context,
 	return credentials, nil
 }
 
+func (r *ServiceInstanceRepo) GetServiceInstanceParameters(ctx context.Context, authInfo authorization.Info, instanceGUID string) (map[string]any, error) {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: instanceGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, serviceInstance); err != nil {
+		return map[string]any{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	if serviceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return map[string]any{}, apierrors.NewUnprocessableEntityError(nil, "This service instance does not support fetching service instance parameters.")
+	}
+
+	params, err := r.paramsClient.GetServiceInstanceParameters(ctx, serviceInstance)
+	if err != nil {
+		return map[string]any{}, err
+	}
+
+	return params, nil
+}
+
 func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInfo authorization.Info, message DeleteServiceInstanceMessage) (ServiceInstanceRecord, error) {
 	serviceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
@@ -476,9 +698,12 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
 	}
 
-	if message.Purge {
+	if message.Purge || serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
 		if err := r.klient.Patch(ctx, serviceInstance, func() error {
-			serviceInstance.Annotations = tools.SetMapValue(serviceInstance.Annotations, korifiv1alpha1.DeprovisionWithoutBrokerAnnotation, "true")
+			setOriginatingIdentity(ctx, serviceInstance)
+			if message.Purge {
+				serviceInstance.Annotations = tools.SetMapValue(serviceInstance.Annotations, korifiv1alpha1.DeprovisionWithoutBrokerAnnotation, "true")
+			}
 			return nil
 		}); err != nil {
 			return ServiceInstanceRecord{}, fmt.Errorf("failed to remove finalizer for service instance: %s, %w", message.GUID, apierrors.FromK8sError(err, ServiceInstanceResourceType))
@@ -493,6 +718,201 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 	return cfServiceInstanceToRecord(*serviceInstance), nil
 }
 
+func (r *ServiceInstanceRepo) ShareServiceInstance(ctx context.Context, authInfo authorization.Info, message ShareServiceInstanceMessage) (ServiceInstanceRecord, error) {
+	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.GUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfServiceInstance); err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	if err := r.validateShareable(ctx, cfServiceInstance); err != nil {
+		return ServiceInstanceRecord{}, err
+	}
+
+	if err := r.validateShareTargets(ctx, authInfo, cfServiceInstance, message.SpaceGUIDs); err != nil {
+		return ServiceInstanceRecord{}, err
+	}
+
+	err := r.klient.Patch(ctx, cfServiceInstance, func() error {
+		for _, spaceGUID := range message.SpaceGUIDs {
+			if !slices.Contains(cfServiceInstance.Spec.SharedSpaces, spaceGUID) {
+				cfServiceInstance.Spec.SharedSpaces = append(cfServiceInstance.Spec.SharedSpaces, spaceGUID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to share service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return cfServiceInstanceToRecord(*cfServiceInstance), nil
+}
+
+func (r *ServiceInstanceRepo) validateShareable(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) error {
+	if cfServiceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return nil
+	}
+
+	servicePlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      cfServiceInstance.Spec.PlanGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, servicePlan); err != nil {
+		return apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	serviceOffering := &korifiv1alpha1.CFServiceOffering{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel],
+		},
+	}
+	if err := r.klient.Get(ctx, serviceOffering); err != nil {
+		return apierrors.FromK8sError(err, ServiceOfferingResourceType)
+	}
+
+	metadata, err := korifiv1alpha1.AsMap(serviceOffering.Spec.BrokerCatalog.Metadata)
+	if err != nil {
+		return err
+	}
+
+	if metadata["shareable"] != true {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("The %s service does not support service instance sharing.", serviceOffering.Spec.Name))
+	}
+
+	return nil
+}
+
+func (r *ServiceInstanceRepo) validateShareTargets(ctx context.Context, authInfo authorization.Info, cfServiceInstance *korifiv1alpha1.CFServiceInstance, spaceGUIDs []string) error {
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	for _, spaceGUID := range spaceGUIDs {
+		if spaceGUID == cfServiceInstance.Namespace {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+				"Unable to share service instance %s with space %s. Service instances cannot be shared into the space where they were created.",
+				cfServiceInstance.Spec.DisplayName, spaceGUID,
+			))
+		}
+
+		if !authorizedSpaces[spaceGUID] {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+				"Unable to share service instance %s with spaces ['%s']. Ensure the spaces exist and that you have access to them.",
+				cfServiceInstance.Spec.DisplayName, spaceGUID,
+			))
+		}
+
+		serviceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
+		if err = r.klient.List(ctx, serviceInstanceList, InNamespace(spaceGUID)); err != nil {
+			return fmt.Errorf("failed to list service instances: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		}
+
+		if slices.ContainsFunc(serviceInstanceList.Items, func(si korifiv1alpha1.CFServiceInstance) bool {
+			return si.Spec.DisplayName == cfServiceInstance.Spec.DisplayName
+		}) {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+				"A service instance called %s already exists in %s.",
+				cfServiceInstance.Spec.DisplayName, spaceGUID,
+			))
+		}
+	}
+
+	return nil
+}
+
+func (r *ServiceInstanceRepo) UnshareServiceInstance(ctx context.Context, authInfo authorization.Info, message UnshareServiceInstanceMessage) error {
+	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.GUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfServiceInstance); err != nil {
+		return fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	if !slices.Contains(cfServiceInstance.Spec.SharedSpaces, message.SpaceGUID) {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"Unable to unshare service instance from space %s. Ensure the space exists and the service instance has been shared to this space.",
+			message.SpaceGUID,
+		))
+	}
+
+	sharedBindings, err := r.listSharedServiceBindings(ctx, cfServiceInstance, message.SpaceGUID)
+	if err != nil {
+		return err
+	}
+
+	// Apps in the space lose access to the service instance, hence their
+	// bindings are deleted on behalf of the service instance owner
+	for _, binding := range sharedBindings {
+		if err = client.IgnoreNotFound(r.privilegedClient.Delete(ctx, &binding)); err != nil {
+			return fmt.Errorf("failed to delete service binding %q: %w", binding.Name, apierrors.FromK8sError(err, ServiceBindingResourceType))
+		}
+	}
+
+	err = r.klient.Patch(ctx, cfServiceInstance, func() error {
+		cfServiceInstance.Spec.SharedSpaces = slices.DeleteFunc(cfServiceInstance.Spec.SharedSpaces, func(spaceGUID string) bool {
+			return spaceGUID == message.SpaceGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return nil
+}
+
+func (r *ServiceInstanceRepo) GetSharedSpacesUsageSummary(ctx context.Context, authInfo authorization.Info, guid string) ([]SharedSpaceUsageSummaryRecord, error) {
+	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+	if err := r.klient.Get(ctx, cfServiceInstance); err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	usageSummary := []SharedSpaceUsageSummaryRecord{}
+	for _, spaceGUID := range cfServiceInstance.Spec.SharedSpaces {
+		sharedBindings, err := r.listSharedServiceBindings(ctx, cfServiceInstance, spaceGUID)
+		if err != nil {
+			return nil, err
+		}
+
+		usageSummary = append(usageSummary, SharedSpaceUsageSummaryRecord{
+			SpaceGUID: spaceGUID,
+			BoundAppCount: len(slices.DeleteFunc(sharedBindings, func(binding korifiv1alpha1.CFServiceBinding) bool {
+				return binding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeApp
+			})),
+		})
+	}
+
+	return usageSummary, nil
+}
+
+// listSharedServiceBindings returns the bindings to the service instance in a
+// space it has been shared with. The owner of the service instance is not
+// necessarily allowed to read bindings in that space, therefore they are
+// listed with the privileged client
+func (r *ServiceInstanceRepo) listSharedServiceBindings(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, spaceGUID string) ([]korifiv1alpha1.CFServiceBinding, error) {
+	serviceBindingList := new(korifiv1alpha1.CFServiceBindingList)
+	if err := r.privilegedClient.List(ctx, serviceBindingList, client.InNamespace(spaceGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list service bindings: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	return slices.DeleteFunc(serviceBindingList.Items, func(binding korifiv1alpha1.CFServiceBinding) bool {
+		return binding.Spec.Service.Name != cfServiceInstance.Name
+	}), nil
+}
+
 func (r ServiceInstanceRecord) GetResourceType() string {
 	return ServiceInstanceResourceType
 }
@@ -537,6 +957,8 @@ func cfServiceInstanceToRecord(cfServiceInstance korifiv1alpha1.CFServiceInstanc
 			Version: cfServiceInstance.Status.MaintenanceInfo.Version,
 		},
 		UpgradeAvailable: cfServiceInstance.Status.UpgradeAvailable,
+		SharedSpaceGUIDs: cfServiceInstance.Spec.SharedSpaces,
+		RouteServiceURL:  cfServiceInstance.Spec.RouteServiceURL,
 	}
 }
 
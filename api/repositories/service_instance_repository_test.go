@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	osbapifake "code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -26,6 +29,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -37,7 +41,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			korifiv1alpha1.CFServiceInstanceList,
 			*korifiv1alpha1.CFServiceInstanceList,
 		]
-		sorter *fake.ServiceInstanceSorter
+		sorter       *fake.ServiceInstanceSorter
+		brokerClient *osbapifake.BrokerClient
 
 		org                 *korifiv1alpha1.CFOrg
 		space               *korifiv1alpha1.CFSpace
@@ -55,10 +60,17 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			return records
 		}
 
+		brokerClient = new(osbapifake.BrokerClient)
+		brokerClientFactory := new(osbapifake.BrokerClientFactory)
+		brokerClientFactory.CreateClientReturns(brokerClient, nil)
+
 		serviceInstanceRepo = repositories.NewServiceInstanceRepo(
 			klient,
+			k8sClient,
+			nsPerms,
 			conditionAwaiter,
 			sorter,
+			repositories.NewServiceBrokerClient(brokerClientFactory, k8sClient, rootNamespace),
 			rootNamespace,
 		)
 
@@ -129,6 +141,27 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(cfServiceInstance.Spec.SecretName).NotTo(BeEmpty())
 				Expect(cfServiceInstance.Spec.Type).To(BeEquivalentTo(korifiv1alpha1.UserProvidedType))
 				Expect(cfServiceInstance.Spec.Tags).To(ConsistOf("foo", "bar"))
+				Expect(cfServiceInstance.Spec.RouteServiceURL).To(BeNil())
+			})
+
+			When("a route service url is provided", func() {
+				BeforeEach(func() {
+					serviceInstanceCreateMessage.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+				})
+
+				It("sets the route service url", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(record.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+
+					cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: record.SpaceGUID,
+							Name:      record.GUID,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)).To(Succeed())
+					Expect(cfServiceInstance.Spec.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+				})
 			})
 
 			It("creates the credentials secret", func() {
@@ -243,6 +276,25 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(cfServiceInstance.Spec.Parameters.Name).NotTo(BeNil())
 			})
 
+			When("the request carries the user identity", func() {
+				BeforeEach(func() {
+					ctx = authorization.NewIdentityContext(ctx, authorization.Identity{Name: "the-user", Kind: "User"})
+				})
+
+				It("records the originating identity on the CFServiceInstance", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: record.SpaceGUID,
+							Name:      record.GUID,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)).To(Succeed())
+					Expect(cfServiceInstance.Annotations).To(HaveKeyWithValue(korifiv1alpha1.OriginatingIdentityAnnotation, "the-user"))
+				})
+			})
+
 			It("creates the parameters secret", func() {
 				serviceInstance := new(korifiv1alpha1.CFServiceInstance)
 				Expect(
@@ -281,6 +333,43 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				})
 			})
 
+			When("the service plan has been removed from the broker catalog", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
+						servicePlan.Spec.Unavailable = true
+					})).To(Succeed())
+				})
+
+				It("returns unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the service plan visibility type is space", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
+						servicePlan.Spec.Visibility.Type = korifiv1alpha1.SpaceServicePlanVisibilityType
+						servicePlan.Spec.Visibility.Space = "another-space-guid"
+					})).To(Succeed())
+				})
+
+				It("returns unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+
+				When("the plan is visible in the current space", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
+							servicePlan.Spec.Visibility.Space = space.Name
+						})).To(Succeed())
+					})
+
+					It("succeeds", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+
 			When("the service plan visibility type is organization", func() {
 				BeforeEach(func() {
 					Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
@@ -585,6 +674,33 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				}).Should(Succeed())
 			})
 
+			When("the route service url is provided", func() {
+				BeforeEach(func() {
+					patchMessage.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+				})
+
+				It("updates the route service url", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(serviceInstanceRecord.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+				})
+
+				When("the service instance is managed", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+							cfServiceInstance.Spec.Type = korifiv1alpha1.ManagedType
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
 			When("tags is an empty list", func() {
 				BeforeEach(func() {
 					patchMessage.Tags = &[]string{}
@@ -692,6 +808,199 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					})
 				})
 			})
+
+			When("the patch requires a service broker update", func() {
+				BeforeEach(func() {
+					patchMessage.Parameters = &map[string]any{"p1": "v1"}
+				})
+
+				It("returns an unprocessable entity error for user-provided service instances", func() {
+					Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the service instance is managed", func() {
+				var (
+					serviceOffering *korifiv1alpha1.CFServiceOffering
+					servicePlan     *korifiv1alpha1.CFServicePlan
+					newServicePlan  *korifiv1alpha1.CFServicePlan
+				)
+
+				createServicePlan := func(offeringGUID, version string) *korifiv1alpha1.CFServicePlan {
+					plan := &korifiv1alpha1.CFServicePlan{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+							Labels: map[string]string{
+								korifiv1alpha1.RelServiceOfferingGUIDLabel: offeringGUID,
+							},
+						},
+						Spec: korifiv1alpha1.CFServicePlanSpec{
+							Visibility: korifiv1alpha1.ServicePlanVisibility{
+								Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+							},
+							MaintenanceInfo: korifiv1alpha1.MaintenanceInfo{
+								Version: version,
+							},
+							BrokerCatalog: korifiv1alpha1.ServicePlanBrokerCatalog{
+								Features: korifiv1alpha1.ServicePlanFeatures{
+									PlanUpdateable: true,
+								},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, plan)).To(Succeed())
+					return plan
+				}
+
+				BeforeEach(func() {
+					serviceOffering = &korifiv1alpha1.CFServiceOffering{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServiceOfferingSpec{
+							Name: "my-offering",
+							BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+								Features: korifiv1alpha1.BrokerCatalogFeatures{
+									PlanUpdateable: true,
+								},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, serviceOffering)).To(Succeed())
+
+					servicePlan = createServicePlan(serviceOffering.Name, "1.0.0")
+					newServicePlan = createServicePlan(serviceOffering.Name, "2.0.0")
+
+					cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServiceInstanceSpec{
+							DisplayName: "managed-instance",
+							Type:        korifiv1alpha1.ManagedType,
+							PlanGUID:    servicePlan.Name,
+							Parameters: corev1.LocalObjectReference{
+								Name: "previous-params",
+							},
+							MaintenanceInfo: korifiv1alpha1.MaintenanceInfo{
+								Version: "1.0.0",
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, cfServiceInstance)).To(Succeed())
+
+					patchMessage = repositories.PatchServiceInstanceMessage{
+						GUID:       cfServiceInstance.Name,
+						SpaceGUID:  space.Name,
+						PlanGUID:   tools.PtrTo(newServicePlan.Name),
+						Parameters: &map[string]any{"p1": "v1"},
+					}
+				})
+
+				It("changes the plan of the service instance", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(serviceInstanceRecord.PlanGUID).To(Equal(newServicePlan.Name))
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.PlanGUID).To(Equal(newServicePlan.Name))
+					Expect(serviceInstance.Spec.MaintenanceInfo).To(BeZero())
+				})
+
+				It("creates a new parameters secret", func() {
+					Expect(err).NotTo(HaveOccurred())
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.Parameters.Name).NotTo(Equal("previous-params"))
+
+					paramsSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{
+						Namespace: space.Name,
+						Name:      serviceInstance.Spec.Parameters.Name,
+					}, paramsSecret)).To(Succeed())
+					Expect(paramsSecret.Data).To(MatchAllKeys(Keys{
+						tools.ParametersSecretKey: MatchJSON(`{"p1":"v1"}`),
+					}))
+				})
+
+				When("the maintenance info matches the version of the plan", func() {
+					BeforeEach(func() {
+						patchMessage.MaintenanceInfoVersion = tools.PtrTo("2.0.0")
+					})
+
+					It("requests an upgrade of the service instance", func() {
+						Expect(err).NotTo(HaveOccurred())
+
+						serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+						Expect(serviceInstance.Spec.MaintenanceInfo.Version).To(Equal("2.0.0"))
+					})
+				})
+
+				When("the maintenance info does not match the version of the plan", func() {
+					BeforeEach(func() {
+						patchMessage.MaintenanceInfoVersion = tools.PtrTo("1.0.0")
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(err.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring("maintenance_info.version"))
+					})
+				})
+
+				When("the current plan does not support plan changes", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
+							servicePlan.Spec.BrokerCatalog.Features.PlanUpdateable = false
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(err.(apierrors.UnprocessableEntityError).Detail()).To(Equal("The service does not support changing plans."))
+					})
+				})
+
+				When("the new plan belongs to another service offering", func() {
+					BeforeEach(func() {
+						patchMessage.PlanGUID = tools.PtrTo(createServicePlan(uuid.NewString(), "2.0.0").Name)
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("the new plan does not exist", func() {
+					BeforeEach(func() {
+						patchMessage.PlanGUID = tools.PtrTo("i-do-not-exist")
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("an operation is in progress", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+							cfServiceInstance.Status.LastOperation = korifiv1alpha1.LastOperation{
+								Type:  "create",
+								State: "in progress",
+							}
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(err.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring("is in progress"))
+					})
+				})
+			})
 		})
 	})
 
@@ -1178,6 +1487,173 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("GetServiceInstanceParameters", func() {
+		var (
+			instanceGUID    string
+			offering        *korifiv1alpha1.CFServiceOffering
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			instanceParams  map[string]any
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			serviceBrokerGUID := uuid.NewString()
+			serviceOfferingGUID := uuid.NewString()
+			servicePlanGUID := uuid.NewString()
+			instanceGUID = uuid.NewString()
+
+			brokerClient.GetServiceInstanceReturns(osbapi.ServiceInstanceResponse{
+				Parameters: map[string]any{
+					"foo": "broker-val",
+				},
+			}, nil)
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      serviceBrokerGUID,
+				},
+				Spec: korifiv1alpha1.CFServiceBrokerSpec{
+					Name: uuid.NewString(),
+				},
+			})).To(Succeed())
+
+			offering = &korifiv1alpha1.CFServiceOffering{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      serviceOfferingGUID,
+				},
+				Spec: korifiv1alpha1.CFServiceOfferingSpec{
+					BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+						ID: "offering-id",
+						Features: korifiv1alpha1.BrokerCatalogFeatures{
+							InstancesRetrievable: true,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, offering)).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      servicePlanGUID,
+					Labels: map[string]string{
+						korifiv1alpha1.RelServiceBrokerGUIDLabel:   serviceBrokerGUID,
+						korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOfferingGUID,
+					},
+				},
+				Spec: korifiv1alpha1.CFServicePlanSpec{
+					BrokerCatalog: korifiv1alpha1.ServicePlanBrokerCatalog{
+						ID: "plan-id",
+					},
+					Visibility: korifiv1alpha1.ServicePlanVisibility{
+						Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+					},
+				},
+			})).To(Succeed())
+
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      instanceGUID,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type:     korifiv1alpha1.ManagedType,
+					PlanGUID: servicePlanGUID,
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceInstance)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			instanceParams, getErr = serviceInstanceRepo.GetServiceInstanceParameters(ctx, authInfo, instanceGUID)
+		})
+
+		It("returns a forbidden error as no user bindings are in place", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("fetches the parameters from the broker", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(instanceParams).To(Equal(map[string]any{"foo": "broker-val"}))
+
+				Expect(brokerClient.GetServiceInstanceCallCount()).To(Equal(1))
+				_, actualReq := brokerClient.GetServiceInstanceArgsForCall(0)
+				Expect(actualReq).To(Equal(osbapi.GetServiceInstanceRequest{
+					InstanceID: instanceGUID,
+					ServiceId:  "offering-id",
+					PlanID:     "plan-id",
+				}))
+			})
+
+			When("the broker request fails", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceInstanceReturns(osbapi.ServiceInstanceResponse{}, errors.New("get-instance-err"))
+				})
+
+				It("returns an error", func() {
+					Expect(getErr).To(MatchError(ContainSubstring("get-instance-err")))
+				})
+			})
+
+			When("the service offering does not support instance retrieval", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, offering, func() {
+						offering.Spec.BrokerCatalog.Features.InstancesRetrievable = false
+					})).To(Succeed())
+
+					paramsSecretData, err := tools.ToParametersSecretData(map[string]any{"foo": "stored-val"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      "params-" + instanceGUID,
+						},
+						Data: paramsSecretData,
+					})).To(Succeed())
+
+					Expect(k8s.Patch(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Status.Parameters = corev1.LocalObjectReference{Name: "params-" + instanceGUID}
+					})).To(Succeed())
+				})
+
+				It("returns the parameters stored in the parameters secret", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(instanceParams).To(Equal(map[string]any{"foo": "stored-val"}))
+					Expect(brokerClient.GetServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+
+			When("the service instance is user-provided", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.Type = korifiv1alpha1.UserProvidedType
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					instanceGUID = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
 	Describe("DeleteServiceInstance", func() {
 		var (
 			serviceInstance *korifiv1alpha1.CFServiceInstance
@@ -1253,6 +1729,329 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			})
 		})
 	})
+
+	Describe("ShareServiceInstance", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			sharedSpace     *korifiv1alpha1.CFSpace
+			shareMessage    repositories.ShareServiceInstanceMessage
+			record          repositories.ServiceInstanceRecord
+			shareErr        error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			sharedSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+
+			shareMessage = repositories.ShareServiceInstanceMessage{
+				GUID:       serviceInstance.Name,
+				SpaceGUIDs: []string{sharedSpace.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, shareErr = serviceInstanceRepo.ShareServiceInstance(ctx, authInfo, shareMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sharedSpace.Name)
+			})
+
+			It("shares the service instance", func() {
+				Expect(shareErr).NotTo(HaveOccurred())
+				Expect(record.SharedSpaceGUIDs).To(ConsistOf(sharedSpace.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+				Expect(serviceInstance.Spec.SharedSpaces).To(ConsistOf(sharedSpace.Name))
+			})
+
+			When("the service instance is already shared with the space", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name}
+					})).To(Succeed())
+				})
+
+				It("does not share it twice", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(record.SharedSpaceGUIDs).To(ConsistOf(sharedSpace.Name))
+				})
+			})
+
+			When("sharing into the space of the service instance", func() {
+				BeforeEach(func() {
+					shareMessage.SpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(shareErr).To(MatchError(ContainSubstring("cannot be shared into the space where they were created")))
+				})
+			})
+
+			When("a service instance with the same name exists in the target space", func() {
+				BeforeEach(func() {
+					createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), sharedSpace.Name, "the-service-instance", prefixedGUID("secret"))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(shareErr).To(MatchError(ContainSubstring("already exists")))
+				})
+			})
+
+			When("the service instance is managed", func() {
+				var serviceOffering *korifiv1alpha1.CFServiceOffering
+
+				BeforeEach(func() {
+					serviceOffering = &korifiv1alpha1.CFServiceOffering{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServiceOfferingSpec{
+							Name: "my-offering",
+							BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+								Metadata: &runtime.RawExtension{
+									Raw: []byte(`{"shareable": true}`),
+								},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, serviceOffering)).To(Succeed())
+
+					servicePlan := &korifiv1alpha1.CFServicePlan{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+							Labels: map[string]string{
+								korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOffering.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFServicePlanSpec{
+							Visibility: korifiv1alpha1.ServicePlanVisibility{
+								Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, servicePlan)).To(Succeed())
+
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.Type = korifiv1alpha1.ManagedType
+						serviceInstance.Spec.PlanGUID = servicePlan.Name
+					})).To(Succeed())
+				})
+
+				It("shares the service instance", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(record.SharedSpaceGUIDs).To(ConsistOf(sharedSpace.Name))
+				})
+
+				When("the service offering is not shareable", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, serviceOffering, func() {
+							serviceOffering.Spec.BrokerCatalog.Metadata = &runtime.RawExtension{
+								Raw: []byte(`{"shareable": false}`),
+							}
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(shareErr).To(MatchError(ContainSubstring("The my-offering service does not support service instance sharing.")))
+					})
+				})
+			})
+		})
+
+		When("the user is a space developer in the service instance space only", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				Expect(shareErr).To(MatchError(ContainSubstring("Ensure the spaces exist and that you have access to them")))
+			})
+		})
+	})
+
+	Describe("UnshareServiceInstance", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			sharedSpace     *korifiv1alpha1.CFSpace
+			sharedBinding   *korifiv1alpha1.CFServiceBinding
+			unshareMessage  repositories.UnshareServiceInstanceMessage
+			unshareErr      error
+		)
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+				serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name}
+			})).To(Succeed())
+
+			sharedBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: sharedSpace.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type: korifiv1alpha1.CFServiceBindingTypeApp,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       serviceInstance.Name,
+						Namespace:  space.Name,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, sharedBinding)).To(Succeed())
+
+			unshareMessage = repositories.UnshareServiceInstanceMessage{
+				GUID:      serviceInstance.Name,
+				SpaceGUID: sharedSpace.Name,
+			}
+		})
+
+		JustBeforeEach(func() {
+			unshareErr = serviceInstanceRepo.UnshareServiceInstance(ctx, authInfo, unshareMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("unshares the service instance", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+				Expect(serviceInstance.Spec.SharedSpaces).To(BeEmpty())
+			})
+
+			It("deletes the bindings in the unshared space", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), &korifiv1alpha1.CFServiceBinding{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("the service instance is not shared with the space", func() {
+				BeforeEach(func() {
+					unshareMessage.SpaceGUID = uuid.NewString()
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetSharedSpacesUsageSummary", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			sharedSpace     *korifiv1alpha1.CFSpace
+			usageSummary    []repositories.SharedSpaceUsageSummaryRecord
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+				serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name}
+			})).To(Succeed())
+
+			for _, bindingType := range []string{korifiv1alpha1.CFServiceBindingTypeApp, korifiv1alpha1.CFServiceBindingTypeApp, korifiv1alpha1.CFServiceBindingTypeKey} {
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: sharedSpace.Name,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Type: bindingType,
+						Service: corev1.ObjectReference{
+							Kind:       "CFServiceInstance",
+							APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+							Name:       serviceInstance.Name,
+							Namespace:  space.Name,
+						},
+					},
+				})).To(Succeed())
+			}
+		})
+
+		JustBeforeEach(func() {
+			usageSummary, getErr = serviceInstanceRepo.GetSharedSpacesUsageSummary(ctx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("counts the app bindings in each shared space", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(usageSummary).To(ConsistOf(repositories.SharedSpaceUsageSummaryRecord{
+					SpaceGUID:     sharedSpace.Name,
+					BoundAppCount: 2,
+				}))
+			})
+		})
+	})
+
+	Describe("shared service instances", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			sharedSpace     *korifiv1alpha1.CFSpace
+		)
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+				serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name}
+			})).To(Succeed())
+
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sharedSpace.Name)
+		})
+
+		It("lists the service instance for users in the shared space", func() {
+			serviceInstances, err := serviceInstanceRepo.ListServiceInstances(ctx, authInfo, repositories.ListServiceInstanceMessage{
+				SpaceGUIDs: []string{sharedSpace.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serviceInstances).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"GUID":             Equal(serviceInstance.Name),
+				"SpaceGUID":        Equal(space.Name),
+				"SharedSpaceGUIDs": ConsistOf(sharedSpace.Name),
+			})))
+		})
+
+		It("gets the service instance for users in the shared space", func() {
+			record, err := serviceInstanceRepo.GetServiceInstance(ctx, authInfo, serviceInstance.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(serviceInstance.Name))
+			Expect(record.SpaceGUID).To(Equal(space.Name))
+		})
+	})
 })
 
 var _ = DescribeTable("ServiceInstanceSorter",
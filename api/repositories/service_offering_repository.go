@@ -29,6 +29,8 @@ type ServiceOfferingRecord struct {
 	Tags              []string
 	Requires          []string
 	DocumentationURL  *string
+	Shareable         bool
+	Available         bool
 	BrokerCatalog     ServiceBrokerCatalog
 	ServiceBrokerGUID string
 }
@@ -159,6 +161,8 @@ func offeringToRecord(offering korifiv1alpha1.CFServiceOffering) (ServiceOfferin
 		Tags:             offering.Spec.Tags,
 		Requires:         offering.Spec.Requires,
 		DocumentationURL: offering.Spec.DocumentationURL,
+		Shareable:        metadata["shareable"] == true,
+		Available:        !offering.Spec.Unavailable,
 		BrokerCatalog: ServiceBrokerCatalog{
 			ID:       offering.Spec.BrokerCatalog.ID,
 			Metadata: metadata,
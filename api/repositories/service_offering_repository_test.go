@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -67,6 +68,7 @@ var _ = Describe("ServiceOfferingRepo", func() {
 
 			metadata, err := korifiv1alpha1.AsRawExtension(map[string]any{
 				"offering-md": "offering-md-value",
+				"shareable":   true,
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceOffering{
@@ -115,10 +117,13 @@ var _ = Describe("ServiceOfferingRepo", func() {
 					"Tags":             ConsistOf("t1"),
 					"Requires":         ConsistOf("r1"),
 					"DocumentationURL": PointTo(Equal("https://my.offering.com")),
+					"Shareable":        BeTrue(),
+					"Available":        BeTrue(),
 					"BrokerCatalog": MatchFields(IgnoreExtras, Fields{
 						"ID": Equal("offering-catalog-guid"),
 						"Metadata": MatchAllKeys(Keys{
 							"offering-md": Equal("offering-md-value"),
+							"shareable":   BeTrue(),
 						}),
 						"Features": MatchFields(IgnoreExtras, Fields{
 							"PlanUpdateable":       BeTrue(),
@@ -140,6 +145,25 @@ var _ = Describe("ServiceOfferingRepo", func() {
 			)
 		})
 
+		When("the service offering has been removed from the broker catalog", func() {
+			BeforeEach(func() {
+				cfServiceOffering := &korifiv1alpha1.CFServiceOffering{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      offeringGUID,
+					},
+				}
+				Expect(k8s.PatchResource(ctx, k8sClient, cfServiceOffering, func() {
+					cfServiceOffering.Spec.Unavailable = true
+				})).To(Succeed())
+			})
+
+			It("returns an unavailable offering", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(desiredOffering.Available).To(BeFalse())
+			})
+		})
+
 		When("the service offering does not exist", func() {
 			BeforeEach(func() {
 				offeringGUID = "does-not-exist"
@@ -61,8 +61,9 @@ type ServicePlanSchemas struct {
 }
 
 type ServicePlanFeatures struct {
-	PlanUpdateable bool
-	Bindable       bool
+	PlanUpdateable   bool
+	Bindable         bool
+	BindingRotatable bool
 }
 
 type MaintenanceInfo struct {
@@ -78,6 +79,7 @@ func (r ServicePlanRecord) Relationships() map[string]string {
 type PlanVisibility struct {
 	Type          string
 	Organizations []VisibilityOrganization
+	SpaceGUID     string
 }
 
 type VisibilityOrganization struct {
@@ -112,7 +114,7 @@ func (m *ListServicePlanMessage) matches(cfServicePlan korifiv1alpha1.CFServiceP
 }
 
 func isAvailable(cfServicePlan korifiv1alpha1.CFServicePlan) bool {
-	return cfServicePlan.Spec.Visibility.Type != korifiv1alpha1.AdminServicePlanVisibilityType
+	return !cfServicePlan.Spec.Unavailable && cfServicePlan.Spec.Visibility.Type != korifiv1alpha1.AdminServicePlanVisibilityType
 }
 
 type ApplyServicePlanVisibilityMessage struct {
@@ -239,6 +241,10 @@ func (r *ServicePlanRepo) patchServicePlan(
 	}
 
 	if err := GetAndPatch(ctx, r.klient, cfServicePlan, func() error {
+		if cfServicePlan.Spec.Visibility.Type == korifiv1alpha1.SpaceServicePlanVisibilityType {
+			return apierrors.NewUnprocessableEntityError(nil, "Cannot update visibility of a plan offered by a space-scoped broker.")
+		}
+
 		patchFunc(cfServicePlan)
 		return nil
 	}); err != nil {
@@ -312,6 +318,7 @@ func (r *ServicePlanRepo) planToRecord(ctx context.Context, authInfo authorizati
 		Visibility: PlanVisibility{
 			Type:          plan.Spec.Visibility.Type,
 			Organizations: organizations,
+			SpaceGUID:     plan.Spec.Visibility.Space,
 		},
 		ServiceOfferingGUID: plan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel],
 		Available:           isAvailable(plan),
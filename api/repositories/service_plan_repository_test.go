@@ -157,6 +157,7 @@ var _ = Describe("ServicePlanRepo", func() {
 				"Visibility": MatchAllFields(Fields{
 					"Type":          Equal(korifiv1alpha1.AdminServicePlanVisibilityType),
 					"Organizations": BeEmpty(),
+					"SpaceGUID":     BeEmpty(),
 				}),
 				"Available":           BeFalse(),
 				"ServiceOfferingGUID": Equal("offering-guid"),
@@ -179,6 +180,50 @@ var _ = Describe("ServicePlanRepo", func() {
 			It("returns an available plan", func() {
 				Expect(plan.Available).To(BeTrue())
 			})
+
+			When("the plan has been removed from the broker catalog", func() {
+				BeforeEach(func() {
+					cfServicePlan := &korifiv1alpha1.CFServicePlan{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      planGUID,
+						},
+					}
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServicePlan, func() {
+						cfServicePlan.Spec.Unavailable = true
+					})).To(Succeed())
+				})
+
+				It("returns an unavailable plan", func() {
+					Expect(plan.Available).To(BeFalse())
+				})
+			})
+		})
+
+		When("the plan is offered by a space-scoped broker", func() {
+			BeforeEach(func() {
+				cfServicePlan := &korifiv1alpha1.CFServicePlan{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      planGUID,
+					},
+				}
+				Expect(k8s.PatchResource(ctx, k8sClient, cfServicePlan, func() {
+					cfServicePlan.Spec.Visibility = korifiv1alpha1.ServicePlanVisibility{
+						Type:  korifiv1alpha1.SpaceServicePlanVisibilityType,
+						Space: "space-guid",
+					}
+				})).To(Succeed())
+			})
+
+			It("returns the space visibility", func() {
+				Expect(plan.Visibility).To(Equal(repositories.PlanVisibility{
+					Type:          korifiv1alpha1.SpaceServicePlanVisibilityType,
+					Organizations: []repositories.VisibilityOrganization{},
+					SpaceGUID:     "space-guid",
+				}))
+				Expect(plan.Available).To(BeTrue())
+			})
 		})
 	})
 
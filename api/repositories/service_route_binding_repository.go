@@ -0,0 +1,347 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	ServiceRouteBindingResourceType = "Service Route Binding"
+
+	routeForwardingRequirement = "route_forwarding"
+)
+
+type ServiceRouteBindingRepo struct {
+	klient                  Klient
+	serviceInstanceRepo     *ServiceInstanceRepo
+	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceBinding]
+	rootNamespace           string
+}
+
+func NewServiceRouteBindingRepo(
+	klient Klient,
+	serviceInstanceRepo *ServiceInstanceRepo,
+	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceBinding],
+	rootNamespace string,
+) *ServiceRouteBindingRepo {
+	return &ServiceRouteBindingRepo{
+		klient:                  klient,
+		serviceInstanceRepo:     serviceInstanceRepo,
+		bindingConditionAwaiter: bindingConditionAwaiter,
+		rootNamespace:           rootNamespace,
+	}
+}
+
+type ServiceRouteBindingRecord struct {
+	GUID                string
+	RouteServiceURL     string
+	ServiceInstanceGUID string
+	RouteGUID           string
+	SpaceGUID           string
+	Labels              map[string]string
+	Annotations         map[string]string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+	DeletedAt           *time.Time
+	LastOperation       ServiceBindingLastOperation
+	Ready               bool
+}
+
+func (r ServiceRouteBindingRecord) Relationships() map[string]string {
+	return map[string]string{
+		"route":            r.RouteGUID,
+		"service_instance": r.ServiceInstanceGUID,
+	}
+}
+
+type CreateServiceRouteBindingMessage struct {
+	RouteGUID           string
+	ServiceInstanceGUID string
+	Parameters          map[string]any
+	Labels              map[string]string
+	Annotations         map[string]string
+}
+
+type ListServiceRouteBindingsMessage struct {
+	RouteGUIDs           []string
+	ServiceInstanceGUIDs []string
+	LabelSelector        string
+}
+
+func (m *ListServiceRouteBindingsMessage) matches(serviceBinding korifiv1alpha1.CFServiceBinding) bool {
+	return serviceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeRoute &&
+		tools.EmptyOrContains(m.ServiceInstanceGUIDs, serviceBinding.Spec.Service.Name) &&
+		tools.EmptyOrContains(m.RouteGUIDs, serviceBinding.Spec.RouteRef.Name)
+}
+
+func (r *ServiceRouteBindingRepo) CreateServiceRouteBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceRouteBindingMessage) (ServiceRouteBindingRecord, error) {
+	serviceInstance, err := r.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, message.ServiceInstanceGUID)
+	if err != nil {
+		return ServiceRouteBindingRecord{},
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Unable to bind to instance. Ensure that the instance exists and you have access to it.",
+				apierrors.ForbiddenError{},
+				apierrors.NotFoundError{},
+			)
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.RouteGUID,
+		},
+	}
+	err = r.klient.Get(ctx, cfRoute)
+	if err != nil {
+		return ServiceRouteBindingRecord{},
+			apierrors.AsUnprocessableEntity(
+				apierrors.FromK8sError(err, RouteResourceType),
+				"Unable to use route. Ensure that the route exists and you have access to it.",
+				apierrors.ForbiddenError{},
+				apierrors.NotFoundError{},
+			)
+	}
+
+	if cfRoute.Namespace != serviceInstance.SpaceGUID {
+		return ServiceRouteBindingRecord{}, apierrors.NewUnprocessableEntityError(nil, "The service instance and the route are in different spaces.")
+	}
+
+	if err = r.validateRouteBindable(ctx, serviceInstance); err != nil {
+		return ServiceRouteBindingRecord{}, err
+	}
+
+	cfServiceBinding := &korifiv1alpha1.CFServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   cfRoute.Namespace,
+			Labels:      tools.SetMapValue(message.Labels, korifiv1alpha1.CFRouteGUIDLabelKey, cfRoute.Name),
+			Annotations: message.Annotations,
+		},
+		Spec: korifiv1alpha1.CFServiceBindingSpec{
+			Type: korifiv1alpha1.CFServiceBindingTypeRoute,
+			Service: corev1.ObjectReference{
+				Kind:       "CFServiceInstance",
+				APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+				Name:       serviceInstance.GUID,
+			},
+			RouteRef: corev1.LocalObjectReference{Name: cfRoute.Name},
+		},
+	}
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		cfServiceBinding.Spec.Parameters.Name = uuid.NewString()
+		setOriginatingIdentity(ctx, cfServiceBinding)
+	}
+
+	// The binding goes away together with the route
+	_ = controllerutil.SetOwnerReference(cfRoute, cfServiceBinding, scheme.Scheme)
+
+	err = r.klient.Create(ctx, cfServiceBinding)
+	if err != nil {
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == bindings.ServiceBindingErrorType {
+				return ServiceRouteBindingRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
+		return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		err = r.createParametersSecret(ctx, cfServiceBinding, message.Parameters)
+		if err != nil {
+			return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+		}
+	}
+
+	if serviceInstance.Type == korifiv1alpha1.UserProvidedType {
+		cfServiceBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, r.klient, cfServiceBinding, korifiv1alpha1.StatusConditionReady)
+		if err != nil {
+			return ServiceRouteBindingRecord{}, err
+		}
+	}
+
+	return serviceRouteBindingToRecord(*cfServiceBinding), nil
+}
+
+func (r *ServiceRouteBindingRepo) validateRouteBindable(ctx context.Context, serviceInstance ServiceInstanceRecord) error {
+	notSupportedErr := apierrors.NewUnprocessableEntityError(nil, "This service instance does not support route binding.")
+
+	if serviceInstance.Type == korifiv1alpha1.UserProvidedType {
+		if serviceInstance.RouteServiceURL == nil {
+			return notSupportedErr
+		}
+		return nil
+	}
+
+	servicePlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      serviceInstance.PlanGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, servicePlan); err != nil {
+		return apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	serviceOffering := &korifiv1alpha1.CFServiceOffering{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel],
+		},
+	}
+	if err := r.klient.Get(ctx, serviceOffering); err != nil {
+		return apierrors.FromK8sError(err, ServiceOfferingResourceType)
+	}
+
+	if !slices.Contains(serviceOffering.Spec.Requires, routeForwardingRequirement) {
+		return notSupportedErr
+	}
+
+	return nil
+}
+
+func (r *ServiceRouteBindingRepo) createParametersSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, parameters map[string]any) error {
+	parametersData, err := tools.ToParametersSecretData(parameters)
+	if err != nil {
+		return err
+	}
+
+	paramsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfServiceBinding.Namespace,
+			Name:      cfServiceBinding.Spec.Parameters.Name,
+		},
+		Data: parametersData,
+	}
+
+	_ = controllerutil.SetOwnerReference(cfServiceBinding, paramsSecret, scheme.Scheme)
+
+	return r.klient.Create(ctx, paramsSecret)
+}
+
+func (r *ServiceRouteBindingRepo) GetServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) (ServiceRouteBindingRecord, error) {
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+	err := r.klient.Get(ctx, serviceBinding)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to get service route binding: %w", apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	if serviceBinding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeRoute {
+		return ServiceRouteBindingRecord{}, apierrors.NewNotFoundError(nil, ServiceRouteBindingResourceType)
+	}
+
+	return serviceRouteBindingToRecord(*serviceBinding), nil
+}
+
+// nolint:dupl
+func (r *ServiceRouteBindingRepo) ListServiceRouteBindings(ctx context.Context, authInfo authorization.Info, message ListServiceRouteBindingsMessage) ([]ServiceRouteBindingRecord, error) {
+	labelSelector, err := labels.Parse(message.LabelSelector)
+	if err != nil {
+		return []ServiceRouteBindingRecord{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
+	}
+
+	serviceBindingList := new(korifiv1alpha1.CFServiceBindingList)
+	err = r.klient.List(ctx, serviceBindingList, WithLabels{Selector: labelSelector})
+	if err != nil {
+		return []ServiceRouteBindingRecord{}, fmt.Errorf("failed to list service route bindings: %w",
+			apierrors.FromK8sError(err, ServiceRouteBindingResourceType),
+		)
+	}
+
+	filteredServiceBindings := itx.FromSlice(serviceBindingList.Items).Filter(message.matches)
+	return slices.Collect(it.Map(filteredServiceBindings, serviceRouteBindingToRecord)), nil
+}
+
+func (r *ServiceRouteBindingRepo) DeleteServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) error {
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+
+	err := r.klient.Get(ctx, serviceBinding)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	if serviceBinding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeRoute {
+		return apierrors.NewNotFoundError(nil, ServiceRouteBindingResourceType)
+	}
+
+	if serviceBinding.Annotations[korifiv1alpha1.ServiceInstanceTypeAnnotation] == korifiv1alpha1.ManagedType {
+		err = r.klient.Patch(ctx, serviceBinding, func() error {
+			setOriginatingIdentity(ctx, serviceBinding)
+			return nil
+		})
+		if err != nil {
+			return apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+		}
+	}
+
+	err = r.klient.Delete(ctx, serviceBinding)
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	return nil
+}
+
+func (r *ServiceRouteBindingRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (ResourceState, error) {
+	bindingRecord, err := r.GetServiceRouteBinding(ctx, authInfo, guid)
+	if err != nil {
+		return ResourceStateUnknown, err
+	}
+
+	if bindingRecord.Ready {
+		return ResourceStateReady, nil
+	}
+
+	return ResourceStateUnknown, nil
+}
+
+func (r *ServiceRouteBindingRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	bindingRecord, err := r.GetServiceRouteBinding(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+	return bindingRecord.DeletedAt, nil
+}
+
+func serviceRouteBindingToRecord(binding korifiv1alpha1.CFServiceBinding) ServiceRouteBindingRecord {
+	return ServiceRouteBindingRecord{
+		GUID:                binding.Name,
+		RouteServiceURL:     binding.Status.RouteServiceURL,
+		ServiceInstanceGUID: binding.Spec.Service.Name,
+		RouteGUID:           binding.Spec.RouteRef.Name,
+		SpaceGUID:           binding.Namespace,
+		Labels:              binding.Labels,
+		Annotations:         binding.Annotations,
+		CreatedAt:           binding.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(&binding),
+		DeletedAt:           golangTime(binding.DeletionTimestamp),
+		LastOperation:       serviceBindingRecordLastOperation(binding),
+		Ready:               isBindingReady(binding),
+	}
+}
@@ -0,0 +1,408 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceRouteBindingRepo", func() {
+	var (
+		repo  *repositories.ServiceRouteBindingRepo
+		org   *korifiv1alpha1.CFOrg
+		space *korifiv1alpha1.CFSpace
+
+		cfRoute                 *korifiv1alpha1.CFRoute
+		bindingConditionAwaiter *fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFServiceBinding,
+			korifiv1alpha1.CFServiceBindingList,
+			*korifiv1alpha1.CFServiceBindingList,
+		]
+	)
+
+	BeforeEach(func() {
+		bindingConditionAwaiter = &fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFServiceBinding,
+			korifiv1alpha1.CFServiceBindingList,
+			*korifiv1alpha1.CFServiceBindingList,
+		]{}
+
+		serviceInstanceRepo := repositories.NewServiceInstanceRepo(
+			klient,
+			k8sClient,
+			nsPerms,
+			&fakeawaiter.FakeAwaiter[
+				*korifiv1alpha1.CFServiceInstance,
+				korifiv1alpha1.CFServiceInstanceList,
+				*korifiv1alpha1.CFServiceInstanceList,
+			]{},
+			repositories.NewServiceInstanceSorter(),
+			repositories.NewServiceBrokerClient(new(fake.BrokerClientFactory), k8sClient, rootNamespace),
+			rootNamespace,
+		)
+
+		repo = repositories.NewServiceRouteBindingRepo(
+			klient,
+			serviceInstanceRepo,
+			bindingConditionAwaiter,
+			rootNamespace,
+		)
+
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+
+		cfRoute = &korifiv1alpha1.CFRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: space.Name,
+			},
+			Spec: korifiv1alpha1.CFRouteSpec{
+				Host:     "my-host",
+				Protocol: "http",
+				DomainRef: corev1.ObjectReference{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfRoute)).To(Succeed())
+	})
+
+	Describe("CreateServiceRouteBinding", func() {
+		var (
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			message           repositories.CreateServiceRouteBindingMessage
+			bindingRecord     repositories.ServiceRouteBindingRecord
+			createErr         error
+		)
+
+		BeforeEach(func() {
+			cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type:            korifiv1alpha1.UserProvidedType,
+					RouteServiceURL: tools.PtrTo("https://route-service.example.com"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfServiceInstance)).To(Succeed())
+
+			bindingConditionAwaiter.AwaitConditionStub = func(ctx context.Context, _ repositories.Klient, object client.Object, _ string) (*korifiv1alpha1.CFServiceBinding, error) {
+				cfServiceBinding, ok := object.(*korifiv1alpha1.CFServiceBinding)
+				Expect(ok).To(BeTrue())
+
+				Expect(k8s.Patch(ctx, k8sClient, cfServiceBinding, func() {
+					cfServiceBinding.Status.RouteServiceURL = "https://route-service.example.com"
+					meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+						Type:    korifiv1alpha1.StatusConditionReady,
+						Status:  metav1.ConditionTrue,
+						Reason:  "blah",
+						Message: "blah",
+					})
+				})).To(Succeed())
+
+				return cfServiceBinding, nil
+			}
+
+			message = repositories.CreateServiceRouteBindingMessage{
+				RouteGUID:           cfRoute.Name,
+				ServiceInstanceGUID: cfServiceInstance.Name,
+				Labels:              map[string]string{"foo": "bar"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			bindingRecord, createErr = repo.CreateServiceRouteBinding(ctx, authInfo, message)
+		})
+
+		It("returns an unprocessable entity error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a route binding", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(bindingRecord.GUID).To(matchers.BeValidUUID())
+				Expect(bindingRecord.RouteGUID).To(Equal(cfRoute.Name))
+				Expect(bindingRecord.ServiceInstanceGUID).To(Equal(cfServiceInstance.Name))
+				Expect(bindingRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(bindingRecord.RouteServiceURL).To(Equal("https://route-service.example.com"))
+				Expect(bindingRecord.Ready).To(BeTrue())
+
+				cfServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: bindingRecord.GUID}, cfServiceBinding)).To(Succeed())
+				Expect(cfServiceBinding.Labels).To(MatchAllKeys(Keys{
+					"foo":                              Equal("bar"),
+					korifiv1alpha1.CFRouteGUIDLabelKey: Equal(cfRoute.Name),
+				}))
+				Expect(cfServiceBinding.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("CFRoute"),
+					"Name": Equal(cfRoute.Name),
+				})))
+				Expect(cfServiceBinding.Spec.Type).To(Equal(korifiv1alpha1.CFServiceBindingTypeRoute))
+				Expect(cfServiceBinding.Spec.RouteRef.Name).To(Equal(cfRoute.Name))
+				Expect(cfServiceBinding.Spec.Service.Name).To(Equal(cfServiceInstance.Name))
+				Expect(cfServiceBinding.Spec.Parameters.Name).To(BeEmpty())
+			})
+
+			It("awaits the binding to become ready", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(bindingConditionAwaiter.AwaitConditionCallCount()).To(Equal(1))
+				obj, conditionType := bindingConditionAwaiter.AwaitConditionArgsForCall(0)
+				Expect(obj.GetName()).To(Equal(bindingRecord.GUID))
+				Expect(conditionType).To(Equal(korifiv1alpha1.StatusConditionReady))
+			})
+
+			When("the binding never becomes ready", func() {
+				BeforeEach(func() {
+					bindingConditionAwaiter.AwaitConditionStub = nil
+					bindingConditionAwaiter.AwaitConditionReturns(nil, errors.New("time-out-err"))
+				})
+
+				It("errors", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("time-out-err")))
+				})
+			})
+
+			When("the user-provided service instance has no route service url", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+						cfServiceInstance.Spec.RouteServiceURL = nil
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(SatisfyAll(
+						BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+						MatchError(ContainSubstring("does not support route binding")),
+					))
+				})
+			})
+
+			When("the route does not exist", func() {
+				BeforeEach(func() {
+					message.RouteGUID = "i-do-not-exist"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(SatisfyAll(
+						BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+						MatchError(ContainSubstring("Unable to use route")),
+					))
+				})
+			})
+
+			When("the route is in another space", func() {
+				BeforeEach(func() {
+					otherSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, otherSpace.Name)
+
+					otherRoute := &korifiv1alpha1.CFRoute{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: otherSpace.Name,
+						},
+						Spec: cfRoute.Spec,
+					}
+					Expect(k8sClient.Create(ctx, otherRoute)).To(Succeed())
+					message.RouteGUID = otherRoute.Name
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(SatisfyAll(
+						BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+						MatchError(ContainSubstring("different spaces")),
+					))
+				})
+			})
+
+			When("the service instance is managed", func() {
+				var offering *korifiv1alpha1.CFServiceOffering
+
+				BeforeEach(func() {
+					offering = &korifiv1alpha1.CFServiceOffering{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServiceOfferingSpec{
+							Name:     "my-offering",
+							Requires: []string{"route_forwarding"},
+						},
+					}
+					Expect(k8sClient.Create(ctx, offering)).To(Succeed())
+
+					plan := &korifiv1alpha1.CFServicePlan{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+							Labels: map[string]string{
+								korifiv1alpha1.RelServiceOfferingGUIDLabel: offering.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFServicePlanSpec{
+							Visibility: korifiv1alpha1.ServicePlanVisibility{
+								Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, plan)).To(Succeed())
+
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+						cfServiceInstance.Spec.Type = korifiv1alpha1.ManagedType
+						cfServiceInstance.Spec.PlanGUID = plan.Name
+						cfServiceInstance.Spec.RouteServiceURL = nil
+					})).To(Succeed())
+
+					message.Parameters = map[string]any{"p1": "v1"}
+				})
+
+				It("creates the binding with a parameters secret", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(bindingConditionAwaiter.AwaitConditionCallCount()).To(BeZero())
+
+					cfServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: bindingRecord.GUID}, cfServiceBinding)).To(Succeed())
+					Expect(cfServiceBinding.Spec.Parameters.Name).NotTo(BeEmpty())
+
+					paramsSecret := new(corev1.Secret)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: cfServiceBinding.Spec.Parameters.Name}, paramsSecret)).To(Succeed())
+					Expect(paramsSecret.Data).To(MatchAllKeys(Keys{
+						tools.ParametersSecretKey: MatchJSON(`{"p1":"v1"}`),
+					}))
+				})
+
+				When("the offering does not require route forwarding", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, offering, func() {
+							offering.Spec.Requires = nil
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(SatisfyAll(
+							BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+							MatchError(ContainSubstring("does not support route binding")),
+						))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("Get, List and Delete", func() {
+		var (
+			routeBinding      *korifiv1alpha1.CFServiceBinding
+			credentialBinding *korifiv1alpha1.CFServiceBinding
+		)
+
+		BeforeEach(func() {
+			routeBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type: korifiv1alpha1.CFServiceBindingTypeRoute,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       "instance-guid",
+					},
+					RouteRef: corev1.LocalObjectReference{Name: cfRoute.Name},
+				},
+			}
+			Expect(k8sClient.Create(ctx, routeBinding)).To(Succeed())
+
+			credentialBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type:        korifiv1alpha1.CFServiceBindingTypeKey,
+					DisplayName: tools.PtrTo("my-key"),
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       "instance-guid",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, credentialBinding)).To(Succeed())
+		})
+
+		It("forbids getting the binding to users without access to the space", func() {
+			_, err := repo.GetServiceRouteBinding(ctx, authInfo, routeBinding.Name)
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("gets the route binding", func() {
+				record, err := repo.GetServiceRouteBinding(ctx, authInfo, routeBinding.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(routeBinding.Name))
+				Expect(record.RouteGUID).To(Equal(cfRoute.Name))
+				Expect(record.ServiceInstanceGUID).To(Equal("instance-guid"))
+			})
+
+			It("does not get credential bindings", func() {
+				_, err := repo.GetServiceRouteBinding(ctx, authInfo, credentialBinding.Name)
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+
+			It("lists route bindings only", func() {
+				records, err := repo.ListServiceRouteBindings(ctx, authInfo, repositories.ListServiceRouteBindingsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(routeBinding.Name),
+				})))
+			})
+
+			It("filters route bindings by route guid", func() {
+				records, err := repo.ListServiceRouteBindings(ctx, authInfo, repositories.ListServiceRouteBindingsMessage{
+					RouteGUIDs: []string{"another-route"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+			})
+
+			It("deletes the route binding", func() {
+				Expect(repo.DeleteServiceRouteBinding(ctx, authInfo, routeBinding.Name)).To(Succeed())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(MatchError(ContainSubstring("not found")))
+			})
+
+			It("does not delete credential bindings", func() {
+				err := repo.DeleteServiceRouteBinding(ctx, authInfo, credentialBinding.Name)
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
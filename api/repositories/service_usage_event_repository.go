@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/status,verbs=patch
+
+const ServiceUsageEventResourceType = "Service Usage Event"
+
+type ServiceUsageEventRecord struct {
+	GUID                string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+	State               string
+	ServiceInstance     UsageEventResource
+	ServiceInstanceType string
+	Space               UsageEventResource
+	Organization        UsageEventResource
+	ServicePlan         *UsageEventResource
+	ServiceOffering     *UsageEventResource
+	ServiceBroker       *UsageEventResource
+}
+
+type ListServiceUsageEventsMessage struct {
+	GUIDs                []string
+	AfterGUID            string
+	ServiceInstanceTypes []string
+	ServiceOfferingGUIDs []string
+	OrderBy              string
+}
+
+func (m *ListServiceUsageEventsMessage) matches(cfServiceUsageEvent korifiv1alpha1.CFServiceUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, cfServiceUsageEvent.Name) &&
+		isAfterGUID(cfServiceUsageEvent.Name, m.AfterGUID) &&
+		tools.EmptyOrContains(m.ServiceInstanceTypes, string(cfServiceUsageEvent.Spec.ServiceInstance.Type)) &&
+		m.matchesServiceOffering(cfServiceUsageEvent)
+}
+
+func (m *ListServiceUsageEventsMessage) matchesServiceOffering(cfServiceUsageEvent korifiv1alpha1.CFServiceUsageEvent) bool {
+	if len(m.ServiceOfferingGUIDs) == 0 {
+		return true
+	}
+
+	if cfServiceUsageEvent.Spec.ServiceOffering == nil {
+		return false
+	}
+
+	return slices.Contains(m.ServiceOfferingGUIDs, cfServiceUsageEvent.Spec.ServiceOffering.GUID)
+}
+
+//counterfeiter:generate -o fake -fake-name ServiceUsageEventSorter . ServiceUsageEventSorter
+type ServiceUsageEventSorter interface {
+	Sort(records []ServiceUsageEventRecord, order string) []ServiceUsageEventRecord
+}
+
+type serviceUsageEventSorter struct {
+	sorter *compare.Sorter[ServiceUsageEventRecord]
+}
+
+func NewServiceUsageEventSorter() *serviceUsageEventSorter {
+	return &serviceUsageEventSorter{
+		sorter: compare.NewSorter(ServiceUsageEventComparator),
+	}
+}
+
+func (s *serviceUsageEventSorter) Sort(records []ServiceUsageEventRecord, order string) []ServiceUsageEventRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func ServiceUsageEventComparator(fieldName string) func(ServiceUsageEventRecord, ServiceUsageEventRecord) int {
+	return func(e1, e2 ServiceUsageEventRecord) int {
+		switch fieldName {
+		case "", "created_at":
+			return strings.Compare(e1.GUID, e2.GUID)
+		case "updated_at":
+			return tools.CompareTimePtr(e1.UpdatedAt, e2.UpdatedAt)
+		}
+		return 0
+	}
+}
+
+type ServiceUsageEventRepo struct {
+	klient           Klient
+	privilegedClient client.Client
+	sorter           ServiceUsageEventSorter
+	rootNamespace    string
+}
+
+func NewServiceUsageEventRepo(
+	klient Klient,
+	privilegedClient client.Client,
+	sorter ServiceUsageEventSorter,
+	rootNamespace string,
+) *ServiceUsageEventRepo {
+	return &ServiceUsageEventRepo{
+		klient:           klient,
+		privilegedClient: privilegedClient,
+		sorter:           sorter,
+		rootNamespace:    rootNamespace,
+	}
+}
+
+func (r *ServiceUsageEventRepo) GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (ServiceUsageEventRecord, error) {
+	cfServiceUsageEvent := &korifiv1alpha1.CFServiceUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfServiceUsageEvent); err != nil {
+		return ServiceUsageEventRecord{}, fmt.Errorf("failed to get service usage event: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	return toServiceUsageEventRecord(*cfServiceUsageEvent), nil
+}
+
+func (r *ServiceUsageEventRepo) ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message ListServiceUsageEventsMessage) ([]ServiceUsageEventRecord, error) {
+	cfServiceUsageEventList := &korifiv1alpha1.CFServiceUsageEventList{}
+	if err := r.klient.List(ctx, cfServiceUsageEventList, InNamespace(r.rootNamespace)); err != nil {
+		return []ServiceUsageEventRecord{}, fmt.Errorf("failed to list service usage events: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	serviceUsageEventRecords := slices.Collect(it.Map(
+		itx.FromSlice(cfServiceUsageEventList.Items).Filter(message.matches),
+		toServiceUsageEventRecord,
+	))
+
+	return r.sorter.Sort(serviceUsageEventRecords, message.OrderBy), nil
+}
+
+// PurgeAndReseedServiceUsageEvents deletes all service usage events and
+// makes the service instance controllers emit a CREATED event for every
+// existing service instance.
+func (r *ServiceUsageEventRepo) PurgeAndReseedServiceUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	cfServiceUsageEventList := &korifiv1alpha1.CFServiceUsageEventList{}
+	if err := r.klient.List(ctx, cfServiceUsageEventList, InNamespace(r.rootNamespace)); err != nil {
+		return fmt.Errorf("failed to list service usage events: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	for i := range cfServiceUsageEventList.Items {
+		if err := r.klient.Delete(ctx, &cfServiceUsageEventList.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete service usage event: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+		}
+	}
+
+	cfServiceInstanceList := &korifiv1alpha1.CFServiceInstanceList{}
+	if err := r.privilegedClient.List(ctx, cfServiceInstanceList); err != nil {
+		return fmt.Errorf("failed to list service instances: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	for i := range cfServiceInstanceList.Items {
+		cfServiceInstance := &cfServiceInstanceList.Items[i]
+		if cfServiceInstance.Status.Usage == nil || !cfServiceInstance.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		original := cfServiceInstance.DeepCopy()
+		cfServiceInstance.Status.Usage = nil
+		if err := r.privilegedClient.Status().Patch(ctx, cfServiceInstance, client.MergeFrom(original)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to reseed service instance usage: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		}
+	}
+
+	return nil
+}
+
+func toServiceUsageEventRecord(cfServiceUsageEvent korifiv1alpha1.CFServiceUsageEvent) ServiceUsageEventRecord {
+	return ServiceUsageEventRecord{
+		GUID:      cfServiceUsageEvent.Name,
+		CreatedAt: cfServiceUsageEvent.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&cfServiceUsageEvent),
+		State:     cfServiceUsageEvent.Spec.State,
+		ServiceInstance: UsageEventResource{
+			GUID: cfServiceUsageEvent.Spec.ServiceInstance.GUID,
+			Name: cfServiceUsageEvent.Spec.ServiceInstance.Name,
+		},
+		ServiceInstanceType: string(cfServiceUsageEvent.Spec.ServiceInstance.Type),
+		Space:               toUsageEventResource(cfServiceUsageEvent.Spec.Space),
+		Organization:        toUsageEventResource(cfServiceUsageEvent.Spec.Organization),
+		ServicePlan:         toOptionalUsageEventResource(cfServiceUsageEvent.Spec.ServicePlan),
+		ServiceOffering:     toOptionalUsageEventResource(cfServiceUsageEvent.Spec.ServiceOffering),
+		ServiceBroker:       toOptionalUsageEventResource(cfServiceUsageEvent.Spec.ServiceBroker),
+	}
+}
+
+func toOptionalUsageEventResource(resource *korifiv1alpha1.UsageEventResource) *UsageEventResource {
+	if resource == nil {
+		return nil
+	}
+
+	return tools.PtrTo(toUsageEventResource(*resource))
+}
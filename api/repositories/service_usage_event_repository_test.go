@@ -0,0 +1,269 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceUsageEventRepository", func() {
+	var (
+		serviceUsageEventRepo *repositories.ServiceUsageEventRepo
+		sorter                *fake.ServiceUsageEventSorter
+	)
+
+	createServiceUsageEvent := func(instanceType korifiv1alpha1.InstanceType, offering *korifiv1alpha1.UsageEventResource) *korifiv1alpha1.CFServiceUsageEvent {
+		GinkgoHelper()
+
+		cfServiceUsageEvent := &korifiv1alpha1.CFServiceUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.Must(uuid.NewV7()).String(),
+			},
+			Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+				State: korifiv1alpha1.ServiceUsageStateCreated,
+				ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+					GUID: "si-guid",
+					Name: "my-si",
+					Type: instanceType,
+				},
+				Space: korifiv1alpha1.UsageEventResource{
+					GUID: "space-guid",
+					Name: "my-space",
+				},
+				Organization: korifiv1alpha1.UsageEventResource{
+					GUID: "org-guid",
+				},
+				ServiceOffering: offering,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfServiceUsageEvent)).To(Succeed())
+
+		return cfServiceUsageEvent
+	}
+
+	BeforeEach(func() {
+		sorter = new(fake.ServiceUsageEventSorter)
+		sorter.SortStub = func(records []repositories.ServiceUsageEventRecord, _ string) []repositories.ServiceUsageEventRecord {
+			return records
+		}
+
+		serviceUsageEventRepo = repositories.NewServiceUsageEventRepo(klient, k8sClient, sorter, rootNamespace)
+	})
+
+	Describe("GetServiceUsageEvent", func() {
+		var (
+			serviceUsageEventGUID string
+			record                repositories.ServiceUsageEventRecord
+			getErr                error
+		)
+
+		BeforeEach(func() {
+			serviceUsageEventGUID = createServiceUsageEvent(
+				korifiv1alpha1.ManagedType,
+				&korifiv1alpha1.UsageEventResource{GUID: "offering-guid", Name: "my-offering"},
+			).Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = serviceUsageEventRepo.GetServiceUsageEvent(ctx, authInfo, serviceUsageEventGUID)
+		})
+
+		It("returns a forbidden error to non-admin users", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the service usage event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                Equal(serviceUsageEventGUID),
+					"State":               Equal(korifiv1alpha1.ServiceUsageStateCreated),
+					"ServiceInstance":     Equal(repositories.UsageEventResource{GUID: "si-guid", Name: "my-si"}),
+					"ServiceInstanceType": Equal(korifiv1alpha1.ManagedType),
+					"ServiceOffering":     PointTo(Equal(repositories.UsageEventResource{GUID: "offering-guid", Name: "my-offering"})),
+					"ServicePlan":         BeNil(),
+					"Space":               Equal(repositories.UsageEventResource{GUID: "space-guid", Name: "my-space"}),
+					"Organization":        Equal(repositories.UsageEventResource{GUID: "org-guid"}),
+				}))
+			})
+
+			When("the service usage event does not exist", func() {
+				BeforeEach(func() {
+					serviceUsageEventGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListServiceUsageEvents", func() {
+		var (
+			managedEvent, upsiEvent *korifiv1alpha1.CFServiceUsageEvent
+			message                 repositories.ListServiceUsageEventsMessage
+			records                 []repositories.ServiceUsageEventRecord
+			listErr                 error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+
+			managedEvent = createServiceUsageEvent(
+				korifiv1alpha1.ManagedType,
+				&korifiv1alpha1.UsageEventResource{GUID: "offering-guid"},
+			)
+			upsiEvent = createServiceUsageEvent(korifiv1alpha1.UserProvidedType, nil)
+			message = repositories.ListServiceUsageEventsMessage{
+				GUIDs:   []string{managedEvent.Name, upsiEvent.Name},
+				OrderBy: "created_at",
+			}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = serviceUsageEventRepo.ListServiceUsageEvents(ctx, authInfo, message)
+		})
+
+		It("lists the service usage events", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(managedEvent.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(upsiEvent.Name)}),
+			))
+		})
+
+		It("sorts the service usage events", func() {
+			Expect(sorter.SortCallCount()).To(Equal(1))
+			_, field := sorter.SortArgsForCall(0)
+			Expect(field).To(Equal("created_at"))
+		})
+
+		When("filtering by service instance type", func() {
+			BeforeEach(func() {
+				message.ServiceInstanceTypes = []string{korifiv1alpha1.UserProvidedType}
+			})
+
+			It("returns the matching events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(upsiEvent.Name)}),
+				))
+			})
+		})
+
+		When("filtering by service offering", func() {
+			BeforeEach(func() {
+				message.ServiceOfferingGUIDs = []string{"offering-guid"}
+			})
+
+			It("returns the matching events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(managedEvent.Name)}),
+				))
+			})
+		})
+
+		When("filtering by after_guid", func() {
+			BeforeEach(func() {
+				message.AfterGUID = managedEvent.Name
+			})
+
+			It("returns the events recorded after the given one", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(upsiEvent.Name)}),
+				))
+			})
+		})
+	})
+
+	Describe("PurgeAndReseedServiceUsageEvents", func() {
+		var (
+			cfServiceUsageEvent *korifiv1alpha1.CFServiceUsageEvent
+			cfServiceInstance   *korifiv1alpha1.CFServiceInstance
+			purgeErr            error
+		)
+
+		BeforeEach(func() {
+			cfServiceUsageEvent = createServiceUsageEvent(korifiv1alpha1.UserProvidedType, nil)
+
+			cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace := createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+			cfServiceInstance = createServiceInstanceCR(ctx, k8sClient, uuid.NewString(), cfSpace.Name, "my-si", "secret")
+			Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+				cfServiceInstance.Status.Usage = &korifiv1alpha1.ServiceInstanceUsage{
+					State: korifiv1alpha1.ServiceUsageStateCreated,
+					Name:  "my-si",
+				}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = serviceUsageEventRepo.PurgeAndReseedServiceUsageEvents(ctx, authInfo)
+		})
+
+		It("returns a forbidden error to non-admin users", func() {
+			Expect(purgeErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the service usage events", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceUsageEvent), cfServiceUsageEvent)).To(MatchError(ContainSubstring("not found")))
+			})
+
+			It("resets the recorded usage of service instances", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)).To(Succeed())
+				Expect(cfServiceInstance.Status.Usage).To(BeNil())
+			})
+		})
+	})
+})
+
+var _ = DescribeTable("ServiceUsageEventSorter",
+	func(e1, e2 repositories.ServiceUsageEventRecord, field string, match types.GomegaMatcher) {
+		Expect(repositories.ServiceUsageEventComparator(field)(e1, e2)).To(match)
+	},
+	Entry("default",
+		repositories.ServiceUsageEventRecord{GUID: "0190a1b2-0000-7000-8000-000000000001"},
+		repositories.ServiceUsageEventRecord{GUID: "0190a1b2-0000-7000-8000-000000000002"},
+		"",
+		BeNumerically("<", 0),
+	),
+	Entry("created_at",
+		repositories.ServiceUsageEventRecord{GUID: "0190a1b2-0000-7000-8000-000000000001"},
+		repositories.ServiceUsageEventRecord{GUID: "0190a1b2-0000-7000-8000-000000000002"},
+		"created_at",
+		BeNumerically("<", 0),
+	),
+	Entry("updated_at",
+		repositories.ServiceUsageEventRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.ServiceUsageEventRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"updated_at",
+		BeNumerically("<", 0),
+	),
+)
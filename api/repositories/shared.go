@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -78,6 +80,24 @@ func getLabelOrAnnotation(mapObj map[string]string, key string) string {
 	return mapObj[key]
 }
 
+// originatingIdentity returns the name of the user making the request. It is
+// sent to service brokers to attribute operations to CF users.
+func originatingIdentity(ctx context.Context) string {
+	identity, _ := authorization.IdentityFromContext(ctx)
+	return identity.Name
+}
+
+// setOriginatingIdentity records the user making the request on objects whose
+// controllers call service brokers on the user's behalf
+func setOriginatingIdentity(ctx context.Context, obj client.Object) {
+	userName := originatingIdentity(ctx)
+	if userName == "" {
+		return
+	}
+
+	obj.SetAnnotations(tools.SetMapValue(maps.Clone(obj.GetAnnotations()), korifiv1alpha1.OriginatingIdentityAnnotation, userName))
+}
+
 func authorizedSpaceNamespaces(ctx context.Context, authInfo authorization.Info, namespacePermissions *authorization.NamespacePermissions) (itx.Iterator[string], error) {
 	nsList, err := namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
 	if err != nil {
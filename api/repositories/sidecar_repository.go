@@ -0,0 +1,368 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const SidecarResourceType = "Sidecar"
+
+type SidecarRecord struct {
+	GUID         string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+	Origin       string
+	AppGUID      string
+	SpaceGUID    string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
+func (r SidecarRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": r.AppGUID,
+	}
+}
+
+type ListSidecarsMessage struct {
+	AppGUIDs     []string
+	ProcessTypes []string
+}
+
+func (m *ListSidecarsMessage) matches(sidecar SidecarRecord) bool {
+	return tools.EmptyOrContains(m.AppGUIDs, sidecar.AppGUID) &&
+		(len(m.ProcessTypes) == 0 || slices.ContainsFunc(sidecar.ProcessTypes, func(processType string) bool {
+			return slices.Contains(m.ProcessTypes, processType)
+		}))
+}
+
+type CreateSidecarMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+}
+
+type UpdateSidecarMessage struct {
+	GUID         string
+	Name         *string
+	Command      *string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+// SidecarRepo manages the sidecars of apps. Sidecars are not resources on
+// their own but are stored on each of the CFProcesses they run alongside.
+type SidecarRepo struct {
+	klient Klient
+}
+
+func NewSidecarRepo(klient Klient) *SidecarRepo {
+	return &SidecarRepo{
+		klient: klient,
+	}
+}
+
+func (r *SidecarRepo) GetSidecar(ctx context.Context, authInfo authorization.Info, guid string) (SidecarRecord, error) {
+	processList := &korifiv1alpha1.CFProcessList{}
+	if err := r.klient.List(ctx, processList); err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to list processes: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return findSidecar(processList.Items, guid)
+}
+
+func (r *SidecarRepo) ListSidecars(ctx context.Context, authInfo authorization.Info, message ListSidecarsMessage) ([]SidecarRecord, error) {
+	processList := &korifiv1alpha1.CFProcessList{}
+	if err := r.klient.List(ctx, processList); err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return slices.DeleteFunc(toSidecarRecords(processList.Items), func(sidecar SidecarRecord) bool {
+		return !message.matches(sidecar)
+	}), nil
+}
+
+func (r *SidecarRepo) CreateSidecar(ctx context.Context, authInfo authorization.Info, message CreateSidecarMessage) (SidecarRecord, error) {
+	processes, err := r.listAppProcesses(ctx, message.SpaceGUID, message.AppGUID)
+	if err != nil {
+		return SidecarRecord{}, err
+	}
+
+	sidecar := korifiv1alpha1.Sidecar{
+		GUID:     uuid.NewString(),
+		Name:     message.Name,
+		Command:  message.Command,
+		MemoryMB: message.MemoryMB,
+		Origin:   korifiv1alpha1.SidecarOriginUser,
+	}
+
+	if err = validateSidecar(processes, sidecar, message.ProcessTypes); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	if err = r.applySidecar(ctx, message.SpaceGUID, message.AppGUID, processes, sidecar, message.ProcessTypes); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	return r.getAppSidecar(ctx, message.SpaceGUID, message.AppGUID, sidecar.GUID)
+}
+
+func (r *SidecarRepo) UpdateSidecar(ctx context.Context, authInfo authorization.Info, message UpdateSidecarMessage) (SidecarRecord, error) {
+	current, err := r.GetSidecar(ctx, authInfo, message.GUID)
+	if err != nil {
+		return SidecarRecord{}, err
+	}
+
+	if err = ensureUserSidecar(current); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	processes, err := r.listAppProcesses(ctx, current.SpaceGUID, current.AppGUID)
+	if err != nil {
+		return SidecarRecord{}, err
+	}
+
+	sidecar := korifiv1alpha1.Sidecar{
+		GUID:     current.GUID,
+		Name:     tools.ZeroIfNil(tools.IfNil(message.Name, &current.Name)),
+		Command:  tools.ZeroIfNil(tools.IfNil(message.Command, &current.Command)),
+		MemoryMB: tools.ZeroIfNil(tools.IfNil(message.MemoryMB, &current.MemoryMB)),
+		Origin:   korifiv1alpha1.SidecarOriginUser,
+	}
+
+	processTypes := current.ProcessTypes
+	if message.ProcessTypes != nil {
+		processTypes = message.ProcessTypes
+	}
+
+	if err = validateSidecar(processes, sidecar, processTypes); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	if err = r.applySidecar(ctx, current.SpaceGUID, current.AppGUID, processes, sidecar, processTypes); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	return r.getAppSidecar(ctx, current.SpaceGUID, current.AppGUID, sidecar.GUID)
+}
+
+func (r *SidecarRepo) DeleteSidecar(ctx context.Context, authInfo authorization.Info, guid string) error {
+	current, err := r.GetSidecar(ctx, authInfo, guid)
+	if err != nil {
+		return err
+	}
+
+	if err = ensureUserSidecar(current); err != nil {
+		return err
+	}
+
+	processes, err := r.listAppProcesses(ctx, current.SpaceGUID, current.AppGUID)
+	if err != nil {
+		return err
+	}
+
+	return r.applySidecar(ctx, current.SpaceGUID, current.AppGUID, processes, korifiv1alpha1.Sidecar{GUID: guid}, nil)
+}
+
+func (r *SidecarRepo) listAppProcesses(ctx context.Context, spaceGUID, appGUID string) ([]korifiv1alpha1.CFProcess, error) {
+	processList := &korifiv1alpha1.CFProcessList{}
+	err := r.klient.List(ctx, processList, InNamespace(spaceGUID), WithLabels{
+		Selector: labels.SelectorFromSet(map[string]string{korifiv1alpha1.CFAppGUIDLabelKey: appGUID}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes of app %q: %w", appGUID, apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return processList.Items, nil
+}
+
+func (r *SidecarRepo) getAppSidecar(ctx context.Context, spaceGUID, appGUID, guid string) (SidecarRecord, error) {
+	processes, err := r.listAppProcesses(ctx, spaceGUID, appGUID)
+	if err != nil {
+		return SidecarRecord{}, err
+	}
+
+	return findSidecar(processes, guid)
+}
+
+// applySidecar makes sure that the sidecar is present on the processes of the
+// given types and absent from all other processes of the app. Processes that
+// do not exist yet are created, the CFApp controller fills in their details
+// once the app is staged.
+func (r *SidecarRepo) applySidecar(
+	ctx context.Context,
+	spaceGUID string,
+	appGUID string,
+	processes []korifiv1alpha1.CFProcess,
+	sidecar korifiv1alpha1.Sidecar,
+	processTypes []string,
+) error {
+	for i := range processes {
+		process := &processes[i]
+		wanted := slices.Contains(processTypes, process.Spec.ProcessType)
+		index := slices.IndexFunc(process.Spec.Sidecars, func(s korifiv1alpha1.Sidecar) bool {
+			return s.GUID == sidecar.GUID
+		})
+
+		if !wanted && index < 0 {
+			continue
+		}
+
+		err := r.klient.Patch(ctx, process, func() error {
+			switch {
+			case !wanted:
+				process.Spec.Sidecars = slices.Delete(process.Spec.Sidecars, index, index+1)
+			case index < 0:
+				process.Spec.Sidecars = append(process.Spec.Sidecars, sidecar)
+			default:
+				process.Spec.Sidecars[index] = sidecar
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to patch process %q: %w", process.Name, apierrors.FromK8sError(err, ProcessResourceType))
+		}
+	}
+
+	for _, processType := range processTypes {
+		if slices.ContainsFunc(processes, func(p korifiv1alpha1.CFProcess) bool { return p.Spec.ProcessType == processType }) {
+			continue
+		}
+
+		process := &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: spaceGUID,
+				Name:      tools.NamespacedUUID(appGUID, processType),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+					korifiv1alpha1.CFProcessTypeLabelKey: processType,
+				},
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:      corev1.LocalObjectReference{Name: appGUID},
+				ProcessType: processType,
+				Sidecars:    []korifiv1alpha1.Sidecar{sidecar},
+			},
+		}
+		if err := r.klient.Create(ctx, process); err != nil {
+			return fmt.Errorf("failed to create process %q: %w", processType, apierrors.FromK8sError(err, ProcessResourceType))
+		}
+	}
+
+	return nil
+}
+
+func validateSidecar(processes []korifiv1alpha1.CFProcess, sidecar korifiv1alpha1.Sidecar, processTypes []string) error {
+	for _, process := range processes {
+		for _, s := range process.Spec.Sidecars {
+			if s.Name == sidecar.Name && s.GUID != sidecar.GUID {
+				return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Sidecar with name '%s' already exists for given app", sidecar.Name))
+			}
+		}
+
+		if !slices.Contains(processTypes, process.Spec.ProcessType) {
+			continue
+		}
+
+		sidecarsMemoryMB := sidecar.MemoryMB
+		for _, s := range process.Spec.Sidecars {
+			if s.GUID != sidecar.GUID {
+				sidecarsMemoryMB += s.MemoryMB
+			}
+		}
+
+		if sidecar.MemoryMB > 0 && process.Spec.MemoryMB > 0 && sidecarsMemoryMB >= process.Spec.MemoryMB {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("The memory allocation defined is too large to run with the dependent %q process", process.Spec.ProcessType))
+		}
+	}
+
+	return nil
+}
+
+func ensureUserSidecar(sidecar SidecarRecord) error {
+	if sidecar.Origin != string(korifiv1alpha1.SidecarOriginUser) {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Sidecar '%s' has been declared by a buildpack and cannot be changed", sidecar.Name))
+	}
+
+	return nil
+}
+
+func findSidecar(processes []korifiv1alpha1.CFProcess, guid string) (SidecarRecord, error) {
+	for _, record := range toSidecarRecords(processes) {
+		if record.GUID == guid {
+			return record, nil
+		}
+	}
+
+	return SidecarRecord{}, apierrors.NewNotFoundError(nil, SidecarResourceType)
+}
+
+// toSidecarRecords collects the sidecars of the processes, merging the
+// sidecars that run alongside several processes into a single record
+func toSidecarRecords(processes []korifiv1alpha1.CFProcess) []SidecarRecord {
+	records := map[string]*SidecarRecord{}
+	for _, process := range processes {
+		updatedAt := getLastUpdatedTime(&process)
+
+		for _, sidecar := range process.Spec.Sidecars {
+			record, ok := records[sidecar.GUID]
+			if !ok {
+				record = &SidecarRecord{
+					GUID:      sidecar.GUID,
+					Name:      sidecar.Name,
+					Command:   sidecar.Command,
+					MemoryMB:  sidecar.MemoryMB,
+					Origin:    string(sidecar.Origin),
+					AppGUID:   process.Spec.AppRef.Name,
+					SpaceGUID: process.Namespace,
+					CreatedAt: process.CreationTimestamp.Time,
+					UpdatedAt: updatedAt,
+				}
+				records[sidecar.GUID] = record
+			}
+
+			record.ProcessTypes = append(record.ProcessTypes, process.Spec.ProcessType)
+			if process.CreationTimestamp.Time.Before(record.CreatedAt) {
+				record.CreatedAt = process.CreationTimestamp.Time
+			}
+			if tools.CompareTimePtr(updatedAt, record.UpdatedAt) > 0 {
+				record.UpdatedAt = updatedAt
+			}
+		}
+	}
+
+	result := []SidecarRecord{}
+	for _, record := range records {
+		slices.Sort(record.ProcessTypes)
+		result = append(result, *record)
+	}
+
+	slices.SortFunc(result, func(r1, r2 SidecarRecord) int {
+		return cmp.Or(
+			strings.Compare(r1.AppGUID, r2.AppGUID),
+			strings.Compare(r1.Name, r2.Name),
+		)
+	})
+
+	return result
+}
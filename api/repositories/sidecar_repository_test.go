@@ -0,0 +1,333 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SidecarRepo", func() {
+	var (
+		sidecarRepo   *repositories.SidecarRepo
+		space         *korifiv1alpha1.CFSpace
+		appGUID       string
+		webProcess    *korifiv1alpha1.CFProcess
+		workerProcess *korifiv1alpha1.CFProcess
+	)
+
+	createProcess := func(processType string, sidecars ...korifiv1alpha1.Sidecar) *korifiv1alpha1.CFProcess {
+		process := &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tools.NamespacedUUID(appGUID, processType),
+				Namespace: space.Name,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+					korifiv1alpha1.CFProcessTypeLabelKey: processType,
+				},
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:      corev1.LocalObjectReference{Name: appGUID},
+				ProcessType: processType,
+				MemoryMB:    256,
+				Sidecars:    sidecars,
+			},
+		}
+		Expect(k8sClient.Create(ctx, process)).To(Succeed())
+		return process
+	}
+
+	getSidecars := func(process *korifiv1alpha1.CFProcess) []korifiv1alpha1.Sidecar {
+		GinkgoHelper()
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(process), process)).To(Succeed())
+		return process.Spec.Sidecars
+	}
+
+	BeforeEach(func() {
+		sidecarRepo = repositories.NewSidecarRepo(klient)
+		org := createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		appGUID = uuid.NewString()
+
+		agent := korifiv1alpha1.Sidecar{GUID: "agent-guid", Name: "agent", Command: "run-agent", MemoryMB: 64, Origin: korifiv1alpha1.SidecarOriginUser}
+		webProcess = createProcess("web", agent, korifiv1alpha1.Sidecar{
+			GUID:    "reloader-guid",
+			Name:    "reloader",
+			Command: "reload",
+			Origin:  korifiv1alpha1.SidecarOriginBuildpack,
+		})
+		workerProcess = createProcess("worker", agent)
+	})
+
+	Describe("GetSidecar", func() {
+		var (
+			sidecar repositories.SidecarRecord
+			getErr  error
+		)
+
+		JustBeforeEach(func() {
+			sidecar, getErr = sidecarRepo.GetSidecar(ctx, authInfo, "agent-guid")
+		})
+
+		It("returns a not found error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user has permissions in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the sidecar with the types of all processes it runs alongside", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(sidecar).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":         Equal("agent-guid"),
+					"Name":         Equal("agent"),
+					"Command":      Equal("run-agent"),
+					"MemoryMB":     BeEquivalentTo(64),
+					"Origin":       Equal("user"),
+					"ProcessTypes": Equal([]string{"web", "worker"}),
+					"AppGUID":      Equal(appGUID),
+					"SpaceGUID":    Equal(space.Name),
+				}))
+			})
+		})
+	})
+
+	Describe("ListSidecars", func() {
+		var (
+			message  repositories.ListSidecarsMessage
+			sidecars []repositories.SidecarRecord
+			listErr  error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			message = repositories.ListSidecarsMessage{AppGUIDs: []string{appGUID}}
+		})
+
+		JustBeforeEach(func() {
+			sidecars, listErr = sidecarRepo.ListSidecars(ctx, authInfo, message)
+		})
+
+		It("lists the sidecars of the app", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(sidecars).To(HaveLen(2))
+			Expect(sidecars[0].Name).To(Equal("agent"))
+			Expect(sidecars[1].Name).To(Equal("reloader"))
+			Expect(sidecars[1].Origin).To(Equal("buildpack"))
+		})
+
+		When("filtering by process type", func() {
+			BeforeEach(func() {
+				message.ProcessTypes = []string{"worker"}
+			})
+
+			It("lists the sidecars of processes of that type", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(sidecars).To(HaveLen(1))
+				Expect(sidecars[0].Name).To(Equal("agent"))
+			})
+		})
+
+		When("filtering by another app", func() {
+			BeforeEach(func() {
+				message.AppGUIDs = []string{"another-app"}
+			})
+
+			It("returns an empty list", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(sidecars).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("CreateSidecar", func() {
+		var (
+			message   repositories.CreateSidecarMessage
+			sidecar   repositories.SidecarRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSidecarMessage{
+				AppGUID:      appGUID,
+				SpaceGUID:    space.Name,
+				Name:         "logger",
+				Command:      "run-logger",
+				ProcessTypes: []string{"web", "clock"},
+				MemoryMB:     32,
+			}
+		})
+
+		JustBeforeEach(func() {
+			sidecar, createErr = sidecarRepo.CreateSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates the sidecar", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(sidecar.GUID).NotTo(BeEmpty())
+				Expect(sidecar.Name).To(Equal("logger"))
+				Expect(sidecar.Command).To(Equal("run-logger"))
+				Expect(sidecar.MemoryMB).To(BeEquivalentTo(32))
+				Expect(sidecar.Origin).To(Equal("user"))
+				Expect(sidecar.ProcessTypes).To(Equal([]string{"clock", "web"}))
+			})
+
+			It("adds the sidecar to the processes", func() {
+				Expect(getSidecars(webProcess)).To(ContainElement(korifiv1alpha1.Sidecar{
+					GUID:     sidecar.GUID,
+					Name:     "logger",
+					Command:  "run-logger",
+					MemoryMB: 32,
+					Origin:   korifiv1alpha1.SidecarOriginUser,
+				}))
+				Expect(getSidecars(workerProcess)).NotTo(ContainElement(HaveField("GUID", sidecar.GUID)))
+			})
+
+			It("creates the processes that do not exist yet", func() {
+				clockProcess := &korifiv1alpha1.CFProcess{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      tools.NamespacedUUID(appGUID, "clock"),
+					},
+				}
+				Expect(getSidecars(clockProcess)).To(ConsistOf(HaveField("GUID", sidecar.GUID)))
+				Expect(clockProcess.Spec.AppRef.Name).To(Equal(appGUID))
+				Expect(clockProcess.Spec.ProcessType).To(Equal("clock"))
+			})
+
+			When("the app already has a sidecar with that name", func() {
+				BeforeEach(func() {
+					message.Name = "reloader"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the sidecar needs as much memory as a process", func() {
+				BeforeEach(func() {
+					message.MemoryMB = 256
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(SatisfyAll(
+						matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+						MatchError(ContainSubstring(`dependent "web" process`)),
+					))
+				})
+			})
+
+			When("the sidecars of a process together need as much memory as the process", func() {
+				BeforeEach(func() {
+					message.MemoryMB = 192
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(SatisfyAll(
+						matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+						MatchError(ContainSubstring(`dependent "web" process`)),
+					))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateSidecar", func() {
+		var (
+			message   repositories.UpdateSidecarMessage
+			sidecar   repositories.SidecarRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.UpdateSidecarMessage{
+				GUID:         "agent-guid",
+				Command:      tools.PtrTo("run-agent --verbose"),
+				ProcessTypes: []string{"worker"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			sidecar, updateErr = sidecarRepo.UpdateSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a not found error", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("updates the sidecar", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(sidecar.Name).To(Equal("agent"))
+				Expect(sidecar.Command).To(Equal("run-agent --verbose"))
+				Expect(sidecar.MemoryMB).To(BeEquivalentTo(64))
+				Expect(sidecar.ProcessTypes).To(Equal([]string{"worker"}))
+			})
+
+			It("removes the sidecar from processes of other types", func() {
+				Expect(getSidecars(webProcess)).NotTo(ContainElement(HaveField("GUID", "agent-guid")))
+				Expect(getSidecars(workerProcess)).To(ContainElement(HaveField("Command", "run-agent --verbose")))
+			})
+
+			When("the sidecar has been declared by a buildpack", func() {
+				BeforeEach(func() {
+					message.GUID = "reloader-guid"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DeleteSidecar", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = sidecarRepo.DeleteSidecar(ctx, authInfo, "agent-guid")
+		})
+
+		It("returns a not found error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("removes the sidecar from all processes", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Expect(getSidecars(webProcess)).To(ConsistOf(HaveField("GUID", "reloader-guid")))
+				Expect(getSidecars(workerProcess)).To(BeEmpty())
+			})
+		})
+	})
+})
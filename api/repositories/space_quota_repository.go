@@ -0,0 +1,326 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const SpaceQuotaResourceType = "Space Quota"
+
+type SpaceQuotaRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewSpaceQuotaRepo(
+	klient Klient,
+	rootNamespace string,
+) *SpaceQuotaRepo {
+	return &SpaceQuotaRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+type CreateSpaceQuotaMessage struct {
+	Name       string
+	OrgGUID    string
+	Limits     QuotaLimits
+	SpaceGUIDs []string
+}
+
+type ListSpaceQuotasMessage struct {
+	GUIDs      []string
+	Names      []string
+	OrgGUIDs   []string
+	SpaceGUIDs []string
+}
+
+func (m *ListSpaceQuotasMessage) matches(record SpaceQuotaRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, record.GUID) &&
+		tools.EmptyOrContains(m.Names, record.Name) &&
+		tools.EmptyOrContains(m.OrgGUIDs, record.OrgGUID) &&
+		emptyOrIntersects(m.SpaceGUIDs, record.SpaceGUIDs)
+}
+
+type UpdateSpaceQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits QuotaLimitsPatch
+}
+
+func (m UpdateSpaceQuotaMessage) apply(cfSpaceQuota *korifiv1alpha1.CFSpaceQuota) {
+	if m.Name != nil {
+		cfSpaceQuota.Spec.DisplayName = *m.Name
+	}
+
+	m.Limits.apply(&cfSpaceQuota.Spec.QuotaLimits)
+}
+
+type ApplySpaceQuotaMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+}
+
+type RemoveSpaceQuotaMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+type SpaceQuotaRecord struct {
+	GUID       string
+	Name       string
+	OrgGUID    string
+	Limits     QuotaLimits
+	SpaceGUIDs []string
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+	DeletedAt  *time.Time
+}
+
+func (r *SpaceQuotaRepo) CreateSpaceQuota(ctx context.Context, authInfo authorization.Info, message CreateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+			DisplayName: message.Name,
+			OrgGUID:     message.OrgGUID,
+			QuotaLimits: toCFQuotaLimits(message.Limits),
+		},
+	}
+
+	if err := r.klient.Create(ctx, cfSpaceQuota); err != nil {
+		return SpaceQuotaRecord{}, apierrors.FromK8sError(err, SpaceQuotaResourceType)
+	}
+
+	if err := r.setSpacesQuota(ctx, message.OrgGUID, message.SpaceGUIDs, cfSpaceQuota.Name); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota, message.SpaceGUIDs), nil
+}
+
+func (r *SpaceQuotaRepo) GetSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) (SpaceQuotaRecord, error) {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, guid)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	spaceGUIDsByQuota, err := r.spaceGUIDsByQuota(ctx, cfSpaceQuota.Spec.OrgGUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota, spaceGUIDsByQuota[guid]), nil
+}
+
+func (r *SpaceQuotaRepo) ListSpaceQuotas(ctx context.Context, authInfo authorization.Info, message ListSpaceQuotasMessage) ([]SpaceQuotaRecord, error) {
+	cfSpaceQuotaList := &korifiv1alpha1.CFSpaceQuotaList{}
+	if err := r.klient.List(ctx, cfSpaceQuotaList, InNamespace(r.rootNamespace)); err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []SpaceQuotaRecord{}, nil
+		}
+		return []SpaceQuotaRecord{}, fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	spaceGUIDsByOrg := map[string]map[string][]string{}
+	for _, cfSpaceQuota := range cfSpaceQuotaList.Items {
+		orgGUID := cfSpaceQuota.Spec.OrgGUID
+		if _, ok := spaceGUIDsByOrg[orgGUID]; ok {
+			continue
+		}
+
+		spaceGUIDsByQuota, err := r.spaceGUIDsByQuota(ctx, orgGUID)
+		if err != nil {
+			return []SpaceQuotaRecord{}, err
+		}
+		spaceGUIDsByOrg[orgGUID] = spaceGUIDsByQuota
+	}
+
+	spaceQuotaRecords := slices.Collect(itx.From(it.Map(
+		slices.Values(cfSpaceQuotaList.Items),
+		func(cfSpaceQuota korifiv1alpha1.CFSpaceQuota) SpaceQuotaRecord {
+			return toSpaceQuotaRecord(cfSpaceQuota, spaceGUIDsByOrg[cfSpaceQuota.Spec.OrgGUID][cfSpaceQuota.Name])
+		},
+	)).Filter(message.matches).Seq())
+	sort.Slice(spaceQuotaRecords, func(i, j int) bool {
+		return spaceQuotaRecords[i].CreatedAt.Before(spaceQuotaRecords[j].CreatedAt)
+	})
+
+	return spaceQuotaRecords, nil
+}
+
+func (r *SpaceQuotaRepo) UpdateSpaceQuota(ctx context.Context, authInfo authorization.Info, message UpdateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	if err := GetAndPatch(ctx, r.klient, cfSpaceQuota, func() error {
+		message.apply(cfSpaceQuota)
+		return nil
+	}); err != nil {
+		return SpaceQuotaRecord{}, apierrors.FromK8sError(err, SpaceQuotaResourceType)
+	}
+
+	spaceGUIDsByQuota, err := r.spaceGUIDsByQuota(ctx, cfSpaceQuota.Spec.OrgGUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota, spaceGUIDsByQuota[message.GUID]), nil
+}
+
+func (r *SpaceQuotaRepo) ApplySpaceQuota(ctx context.Context, authInfo authorization.Info, message ApplySpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = r.setSpacesQuota(ctx, cfSpaceQuota.Spec.OrgGUID, message.SpaceGUIDs, message.GUID); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return r.GetSpaceQuota(ctx, authInfo, message.GUID)
+}
+
+func (r *SpaceQuotaRepo) RemoveSpaceQuota(ctx context.Context, authInfo authorization.Info, message RemoveSpaceQuotaMessage) error {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfSpaceQuota.Spec.OrgGUID,
+			Name:      message.SpaceGUID,
+		},
+	}
+
+	if err := GetAndPatch(ctx, r.klient, cfSpace, func() error {
+		if cfSpace.Spec.QuotaGUID == message.GUID {
+			cfSpace.Spec.QuotaGUID = ""
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to remove quota from space %q: %w", message.SpaceGUID, apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) DeleteSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, guid)
+	if err != nil {
+		return err
+	}
+
+	spaceGUIDsByQuota, err := r.spaceGUIDsByQuota(ctx, cfSpaceQuota.Spec.OrgGUID)
+	if err != nil {
+		return err
+	}
+
+	if len(spaceGUIDsByQuota[guid]) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, "This quota is applied to one or more spaces. Remove this quota from all spaces before deleting.")
+	}
+
+	if err := r.klient.Delete(ctx, cfSpaceQuota); err != nil {
+		return apierrors.FromK8sError(err, SpaceQuotaResourceType)
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	spaceQuota, err := r.GetSpaceQuota(ctx, authInfo, guid)
+	return spaceQuota.DeletedAt, err
+}
+
+func (r *SpaceQuotaRepo) getCFSpaceQuota(ctx context.Context, guid string) (*korifiv1alpha1.CFSpaceQuota, error) {
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfSpaceQuota); err != nil {
+		return nil, fmt.Errorf("failed to get space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuota, nil
+}
+
+func (r *SpaceQuotaRepo) setSpacesQuota(ctx context.Context, orgGUID string, spaceGUIDs []string, quotaGUID string) error {
+	for _, spaceGUID := range spaceGUIDs {
+		cfSpace := &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: orgGUID,
+				Name:      spaceGUID,
+			},
+		}
+
+		if err := GetAndPatch(ctx, r.klient, cfSpace, func() error {
+			cfSpace.Spec.QuotaGUID = quotaGUID
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to apply quota to space %q: %w", spaceGUID, apierrors.FromK8sError(err, SpaceResourceType))
+		}
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) spaceGUIDsByQuota(ctx context.Context, orgGUID string) (map[string][]string, error) {
+	cfSpaceList := &korifiv1alpha1.CFSpaceList{}
+	if err := r.klient.List(ctx, cfSpaceList, InNamespace(orgGUID)); err != nil {
+		if k8serrors.IsForbidden(err) {
+			return map[string][]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list spaces: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	spaceGUIDsByQuota := map[string][]string{}
+	for _, cfSpace := range cfSpaceList.Items {
+		if cfSpace.Spec.QuotaGUID == "" {
+			continue
+		}
+		spaceGUIDsByQuota[cfSpace.Spec.QuotaGUID] = append(spaceGUIDsByQuota[cfSpace.Spec.QuotaGUID], cfSpace.Name)
+	}
+
+	return spaceGUIDsByQuota, nil
+}
+
+func toSpaceQuotaRecord(cfSpaceQuota korifiv1alpha1.CFSpaceQuota, spaceGUIDs []string) SpaceQuotaRecord {
+	if spaceGUIDs == nil {
+		spaceGUIDs = []string{}
+	}
+
+	return SpaceQuotaRecord{
+		GUID:       cfSpaceQuota.Name,
+		Name:       cfSpaceQuota.Spec.DisplayName,
+		OrgGUID:    cfSpaceQuota.Spec.OrgGUID,
+		Limits:     toQuotaLimits(cfSpaceQuota.Spec.QuotaLimits),
+		SpaceGUIDs: spaceGUIDs,
+		CreatedAt:  cfSpaceQuota.CreationTimestamp.Time,
+		UpdatedAt:  getLastUpdatedTime(&cfSpaceQuota),
+		DeletedAt:  golangTime(cfSpaceQuota.DeletionTimestamp),
+	}
+}
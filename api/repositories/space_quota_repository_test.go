@@ -0,0 +1,364 @@
+package repositories_test
+
+import (
+	"context"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SpaceQuotaRepo", func() {
+	var (
+		repo  *repositories.SpaceQuotaRepo
+		org   *korifiv1alpha1.CFOrg
+		space *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewSpaceQuotaRepo(klient, rootNamespace)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+	})
+
+	Describe("CreateSpaceQuota", func() {
+		var (
+			spaceQuotaRecord repositories.SpaceQuotaRecord
+			createMessage    repositories.CreateSpaceQuotaMessage
+			createErr        error
+		)
+
+		BeforeEach(func() {
+			createMessage = repositories.CreateSpaceQuotaMessage{
+				Name:    "my-quota",
+				OrgGUID: org.Name,
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.AppQuotaLimits{
+						PerProcessMemoryInMB: tools.PtrTo[int64](256),
+					},
+				},
+				SpaceGUIDs: []string{space.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			spaceQuotaRecord, createErr = repo.CreateSpaceQuota(ctx, authInfo, createMessage)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+			})
+
+			It("creates a CFSpaceQuota", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      spaceQuotaRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)).To(Succeed())
+				Expect(cfSpaceQuota.Spec.DisplayName).To(Equal("my-quota"))
+				Expect(cfSpaceQuota.Spec.OrgGUID).To(Equal(org.Name))
+				Expect(cfSpaceQuota.Spec.Apps.PerProcessMemoryInMB).To(PointTo(BeEquivalentTo(256)))
+			})
+
+			It("applies the quota to the spaces", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(space), space)).To(Succeed())
+				Expect(space.Spec.QuotaGUID).To(Equal(spaceQuotaRecord.GUID))
+			})
+
+			It("returns a space quota record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(spaceQuotaRecord.GUID).To(matchers.BeValidUUID())
+				Expect(spaceQuotaRecord.Name).To(Equal("my-quota"))
+				Expect(spaceQuotaRecord.OrgGUID).To(Equal(org.Name))
+				Expect(spaceQuotaRecord.SpaceGUIDs).To(ConsistOf(space.Name))
+			})
+		})
+	})
+
+	Describe("GetSpaceQuota", func() {
+		var (
+			cfSpaceQuota     *korifiv1alpha1.CFSpaceQuota
+			spaceQuotaRecord repositories.SpaceQuotaRecord
+			getErr           error
+		)
+
+		BeforeEach(func() {
+			cfSpaceQuota = createSpaceQuota(ctx, "get-quota", org.Name, space)
+		})
+
+		JustBeforeEach(func() {
+			spaceQuotaRecord, getErr = repo.GetSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+			})
+
+			It("returns the space quota with the spaces it is applied to", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(spaceQuotaRecord.GUID).To(Equal(cfSpaceQuota.Name))
+				Expect(spaceQuotaRecord.Name).To(Equal("get-quota"))
+				Expect(spaceQuotaRecord.OrgGUID).To(Equal(org.Name))
+				Expect(spaceQuotaRecord.Limits.Routes.TotalRoutes).To(PointTo(BeEquivalentTo(3)))
+				Expect(spaceQuotaRecord.SpaceGUIDs).To(ConsistOf(space.Name))
+			})
+		})
+	})
+
+	Describe("ListSpaceQuotas", func() {
+		var (
+			quota1, quota2    *korifiv1alpha1.CFSpaceQuota
+			spaceQuotaRecords []repositories.SpaceQuotaRecord
+			listMessage       repositories.ListSpaceQuotasMessage
+			listErr           error
+		)
+
+		BeforeEach(func() {
+			quota1 = createSpaceQuota(ctx, "quota-1", org.Name, space)
+			quota2 = createSpaceQuota(ctx, "quota-2", org.Name)
+			listMessage = repositories.ListSpaceQuotasMessage{}
+		})
+
+		JustBeforeEach(func() {
+			spaceQuotaRecords, listErr = repo.ListSpaceQuotas(ctx, authInfo, listMessage)
+		})
+
+		It("returns an empty list for users with no permissions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(spaceQuotaRecords).To(BeEmpty())
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+			})
+
+			It("returns all the space quotas", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(spaceQuotaRecords).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name), "SpaceGUIDs": ConsistOf(space.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name), "SpaceGUIDs": BeEmpty()}),
+				))
+			})
+
+			When("filtering by space guid", func() {
+				BeforeEach(func() {
+					listMessage.SpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns the quotas applied to the space", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(spaceQuotaRecords).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)})))
+				})
+			})
+
+			When("filtering by org guid", func() {
+				BeforeEach(func() {
+					listMessage.OrgGUIDs = []string{"another-org"}
+				})
+
+				It("returns the quotas of the org", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(spaceQuotaRecords).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("UpdateSpaceQuota", func() {
+		var (
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			updateErr    error
+		)
+
+		BeforeEach(func() {
+			cfSpaceQuota = createSpaceQuota(ctx, "update-quota", org.Name)
+		})
+
+		JustBeforeEach(func() {
+			_, updateErr = repo.UpdateSpaceQuota(ctx, authInfo, repositories.UpdateSpaceQuotaMessage{
+				GUID: cfSpaceQuota.Name,
+				Limits: repositories.QuotaLimitsPatch{
+					Routes: repositories.RouteQuotaLimitsPatch{
+						TotalRoutes: &repositories.QuotaLimitPatch{},
+					},
+					Apps: repositories.AppQuotaLimitsPatch{
+						PerAppTasks: &repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](1)},
+					},
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+			})
+
+			It("updates the patched limits only", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)).To(Succeed())
+				Expect(cfSpaceQuota.Spec.DisplayName).To(Equal("update-quota"))
+				Expect(cfSpaceQuota.Spec.Routes.TotalRoutes).To(BeNil())
+				Expect(cfSpaceQuota.Spec.Apps.PerAppTasks).To(PointTo(BeEquivalentTo(1)))
+			})
+		})
+	})
+
+	Describe("ApplySpaceQuota and RemoveSpaceQuota", func() {
+		var cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+
+		BeforeEach(func() {
+			cfSpaceQuota = createSpaceQuota(ctx, "apply-quota", org.Name)
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+		})
+
+		It("applies the quota to the space and removes it again", func() {
+			spaceQuotaRecord, err := repo.ApplySpaceQuota(ctx, authInfo, repositories.ApplySpaceQuotaMessage{
+				GUID:       cfSpaceQuota.Name,
+				SpaceGUIDs: []string{space.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spaceQuotaRecord.SpaceGUIDs).To(ConsistOf(space.Name))
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(space), space)).To(Succeed())
+			Expect(space.Spec.QuotaGUID).To(Equal(cfSpaceQuota.Name))
+
+			Expect(repo.RemoveSpaceQuota(ctx, authInfo, repositories.RemoveSpaceQuotaMessage{
+				GUID:      cfSpaceQuota.Name,
+				SpaceGUID: space.Name,
+			})).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(space), space)).To(Succeed())
+			Expect(space.Spec.QuotaGUID).To(BeEmpty())
+		})
+
+		When("the space is not in the quota's org", func() {
+			var otherSpace *korifiv1alpha1.CFSpace
+
+			BeforeEach(func() {
+				otherOrg := createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+				otherSpace = createSpaceWithCleanup(ctx, otherOrg.Name, prefixedGUID("other-space"))
+			})
+
+			It("returns a not found error", func() {
+				_, err := repo.ApplySpaceQuota(ctx, authInfo, repositories.ApplySpaceQuotaMessage{
+					GUID:       cfSpaceQuota.Name,
+					SpaceGUIDs: []string{otherSpace.Name},
+				})
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("DeleteSpaceQuota", func() {
+		var (
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			deleteErr    error
+		)
+
+		BeforeEach(func() {
+			cfSpaceQuota = createSpaceQuota(ctx, "delete-quota", org.Name)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+			})
+
+			It("deletes the space quota", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("the quota is applied to a space", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, space, func() {
+						space.Spec.QuotaGUID = cfSpaceQuota.Name
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)).To(Succeed())
+				})
+			})
+		})
+	})
+})
+
+func createSpaceQuota(ctx context.Context, displayName, orgGUID string, spaces ...*korifiv1alpha1.CFSpace) *korifiv1alpha1.CFSpaceQuota {
+	GinkgoHelper()
+
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+			DisplayName: displayName,
+			OrgGUID:     orgGUID,
+			QuotaLimits: korifiv1alpha1.QuotaLimits{
+				Routes: korifiv1alpha1.QuotaRouteLimits{
+					TotalRoutes: tools.PtrTo[int64](3),
+				},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfSpaceQuota)).To(Succeed())
+
+	for _, space := range spaces {
+		Expect(k8s.PatchResource(ctx, k8sClient, space, func() {
+			space.Spec.QuotaGUID = cfSpaceQuota.Name
+		})).To(Succeed())
+	}
+
+	return cfSpaceQuota
+}
@@ -55,6 +55,18 @@ type PatchSpaceMetadataMessage struct {
 	OrgGUID string
 }
 
+type PatchSpaceFeaturesMessage struct {
+	GUID     string
+	OrgGUID  string
+	AllowSSH *bool
+}
+
+func (m *PatchSpaceFeaturesMessage) Apply(space *korifiv1alpha1.CFSpace) {
+	if m.AllowSSH != nil {
+		space.Spec.AllowSSH = m.AllowSSH
+	}
+}
+
 type SpaceRecord struct {
 	Name             string
 	GUID             string
@@ -64,6 +76,7 @@ type SpaceRecord struct {
 	CreatedAt        time.Time
 	UpdatedAt        *time.Time
 	DeletedAt        *time.Time
+	AllowSSH         bool
 }
 
 func (r SpaceRecord) Relationships() map[string]string {
@@ -178,6 +191,7 @@ func cfSpaceToSpaceRecord(cfSpace korifiv1alpha1.CFSpace) SpaceRecord {
 		CreatedAt:        cfSpace.CreationTimestamp.Time,
 		UpdatedAt:        getLastUpdatedTime(&cfSpace),
 		DeletedAt:        golangTime(cfSpace.DeletionTimestamp),
+		AllowSSH:         tools.ZeroIfNil(tools.IfNil(cfSpace.Spec.AllowSSH, tools.PtrTo(true))),
 	}
 }
 
@@ -215,6 +229,29 @@ func (r *SpaceRepo) PatchSpaceMetadata(ctx context.Context, authInfo authorizati
 	return cfSpaceToSpaceRecord(*cfSpace), nil
 }
 
+func (r *SpaceRepo) PatchSpaceFeatures(ctx context.Context, authInfo authorization.Info, message PatchSpaceFeaturesMessage) (SpaceRecord, error) {
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.OrgGUID,
+			Name:      message.GUID,
+		},
+	}
+	err := r.klient.Get(ctx, cfSpace)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	err = r.klient.Patch(ctx, cfSpace, func() error {
+		message.Apply(cfSpace)
+		return nil
+	})
+	if err != nil {
+		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return cfSpaceToSpaceRecord(*cfSpace), nil
+}
+
 func (r *SpaceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, spaceGUID string) (*time.Time, error) {
 	space, err := r.GetSpace(ctx, authInfo, spaceGUID)
 	if err != nil {
@@ -392,6 +392,21 @@ var _ = Describe("SpaceRepository", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(spaceRecord.Name).To(Equal("the-space"))
 				Expect(spaceRecord.OrganizationGUID).To(Equal(cfOrg.Name))
+				Expect(spaceRecord.AllowSSH).To(BeTrue())
+			})
+
+			When("ssh is not allowed in the space", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfSpace, func() {
+						cfSpace.Spec.AllowSSH = tools.PtrTo(false)
+					})).To(Succeed())
+				})
+
+				It("returns a space record with ssh not allowed", func() {
+					spaceRecord, err := spaceRepo.GetSpace(ctx, authInfo, cfSpace.Name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(spaceRecord.AllowSSH).To(BeFalse())
+				})
 			})
 		})
 
@@ -684,6 +699,65 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("PatchSpaceFeatures", func() {
+		var (
+			cfOrg       *korifiv1alpha1.CFOrg
+			cfSpace     *korifiv1alpha1.CFSpace
+			spaceGUID   string
+			patchErr    error
+			spaceRecord repositories.SpaceRecord
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "the-space")
+			spaceGUID = cfSpace.Name
+		})
+
+		JustBeforeEach(func() {
+			spaceRecord, patchErr = spaceRepo.PatchSpaceFeatures(ctx, authInfo, repositories.PatchSpaceFeaturesMessage{
+				GUID:     spaceGUID,
+				OrgGUID:  cfOrg.Name,
+				AllowSSH: tools.PtrTo(false),
+			})
+		})
+
+		When("the user is authorized and the space exists", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("returns the updated space record", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.GUID).To(Equal(spaceGUID))
+				Expect(spaceRecord.AllowSSH).To(BeFalse())
+			})
+
+			It("updates the k8s CFSpace resource", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				updatedCFSpace := new(korifiv1alpha1.CFSpace)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), updatedCFSpace)).To(Succeed())
+				Expect(updatedCFSpace.Spec.AllowSSH).To(PointTo(BeFalse()))
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					spaceGUID = "invalidSpaceGUID"
+				})
+
+				It("returns a not found error", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized", func() {
+			It("return a forbidden error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			cfSpace      *korifiv1alpha1.CFSpace
@@ -0,0 +1,457 @@
+package resourcecache
+
+import (
+	"archive/zip"
+	"cmp"
+	"container/list"
+	"context"
+	"crypto/sha1" // #nosec G505 -- the CF resource matching protocol identifies files by their SHA1
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	defaultFileMode fs.FileMode = 0o644
+	blockSize       int64       = 4096
+)
+
+var sha1Regexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// Resource identifies an app file by its content. Path and Mode describe
+// where the file goes when it is added back to a package.
+type Resource struct {
+	SHA1 string
+	Size int64
+	Path string
+	Mode fs.FileMode
+}
+
+// Cache is a content-addressed store of the files uploaded as part of
+// packages. It lets cf push skip uploading files that have not changed since
+// a previous push. The files are stored on the local filesystem, so API
+// replicas can only share the cache when its directory is on a shared volume.
+//
+// The cache keeps the files it stores under maxSize by evicting the least
+// recently used ones. A file is used when it is matched or merged into a
+// package.
+type Cache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	used    int64
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type entry struct {
+	sha1 string
+	size int64
+}
+
+func New(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create resource cache directory %q: %w", dir, err)
+	}
+
+	c := &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+	}
+	if err := c.load(); err != nil {
+		return nil, fmt.Errorf("failed to load resource cache directory %q: %w", dir, err)
+	}
+
+	return c, nil
+}
+
+// load indexes the files left in the cache directory by a previous run, the
+// least recently modified first, and evicts them down to maxSize
+func (c *Cache) load() error {
+	type cachedFile struct {
+		sha1    string
+		size    int64
+		modTime int64
+	}
+
+	files := []cachedFile{}
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		if strings.HasPrefix(d.Name(), ".upload-") {
+			return os.Remove(path)
+		}
+
+		if !sha1Regexp.MatchString(d.Name()) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, cachedFile{sha1: d.Name(), size: info.Size(), modTime: info.ModTime().UnixNano()})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(files, func(a, b cachedFile) int {
+		return cmp.Compare(a.modTime, b.modTime)
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, f := range files {
+		c.add(f.sha1, f.size)
+	}
+
+	return c.evict(0)
+}
+
+// Match returns the resources that are present in the cache
+func (c *Cache) Match(ctx context.Context, resources []Resource) ([]Resource, error) {
+	matches := []Resource{}
+	for _, resource := range resources {
+		ok, err := c.contains(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			matches = append(matches, resource)
+		}
+	}
+
+	return matches, nil
+}
+
+// Assemble caches the files of the uploaded bits and returns a zip with both
+// the uploaded files and the given cached resources. Failing to cache the
+// uploaded files does not fail the upload.
+func (c *Cache) Assemble(ctx context.Context, bits io.ReaderAt, bitsSize int64, resources []Resource) (io.ReadCloser, error) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("resource-cache")
+
+	var bitsZip *zip.Reader
+	if bits != nil {
+		var err error
+		bitsZip, err = zip.NewReader(bits, bitsSize)
+		if err != nil && len(resources) > 0 {
+			return nil, apierrors.NewUnprocessableEntityError(err, "Bits must be a zip file")
+		}
+
+		if bitsZip != nil {
+			if err = c.store(bitsZip.File); err != nil {
+				logger.Info("failed to cache uploaded files", "reason", err)
+			}
+		}
+	}
+
+	if len(resources) == 0 {
+		return io.NopCloser(io.NewSectionReader(bits, 0, bitsSize)), nil
+	}
+
+	return c.merge(bitsZip, resources)
+}
+
+func (c *Cache) merge(bitsZip *zip.Reader, resources []Resource) (io.ReadCloser, error) {
+	tmpFile, err := os.CreateTemp("", "package-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package file: %w", err)
+	}
+	merged := &tempFile{File: tmpFile}
+
+	if err = c.writeMerged(tmpFile, bitsZip, resources); err != nil {
+		merged.Close()
+		return nil, err
+	}
+
+	if _, err = tmpFile.Seek(0, io.SeekStart); err != nil {
+		merged.Close()
+		return nil, fmt.Errorf("failed to rewind package file: %w", err)
+	}
+
+	return merged, nil
+}
+
+func (c *Cache) writeMerged(dst io.Writer, bitsZip *zip.Reader, resources []Resource) error {
+	zipWriter := zip.NewWriter(dst)
+
+	paths := map[string]bool{}
+	if bitsZip != nil {
+		for _, f := range bitsZip.File {
+			if err := zipWriter.Copy(f); err != nil {
+				return fmt.Errorf("failed to copy %q: %w", f.Name, err)
+			}
+			paths[f.Name] = true
+		}
+	}
+
+	for _, resource := range resources {
+		if paths[resource.Path] {
+			continue
+		}
+
+		if err := c.writeResource(zipWriter, resource); err != nil {
+			return err
+		}
+		paths[resource.Path] = true
+	}
+
+	return zipWriter.Close()
+}
+
+func (c *Cache) writeResource(zipWriter *zip.Writer, resource Resource) error {
+	ok, err := c.contains(resource)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return resourceMissingError(resource)
+	}
+
+	src, err := os.Open(c.path(resource.SHA1))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return resourceMissingError(resource)
+		}
+		return fmt.Errorf("failed to open cached resource %q: %w", resource.Path, err)
+	}
+	defer src.Close()
+
+	header := &zip.FileHeader{
+		Name:   resource.Path,
+		Method: zip.Deflate,
+	}
+	header.SetMode(defaultFileMode)
+	if resource.Mode != 0 {
+		header.SetMode(resource.Mode)
+	}
+
+	dst, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add %q to package: %w", resource.Path, err)
+	}
+
+	if _, err = io.Copy(dst, src); err != nil {
+		return fmt.Errorf("failed to add %q to package: %w", resource.Path, err)
+	}
+
+	return nil
+}
+
+func (c *Cache) store(files []*zip.File) error {
+	for _, f := range files {
+		if !f.Mode().IsRegular() || f.UncompressedSize64 == 0 {
+			continue
+		}
+
+		// files that would not fit are not cached rather than evicting
+		// everything else to make room for them
+		if f.UncompressedSize64 > uint64(c.maxSize) || diskUsage(int64(f.UncompressedSize64)) > c.maxSize {
+			continue
+		}
+
+		if err := c.storeFile(f); err != nil {
+			return fmt.Errorf("failed to cache %q: %w", f.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cache) storeFile(f *zip.File) error {
+	size := int64(f.UncompressedSize64)
+	if err := c.reserve(diskUsage(size)); err != nil {
+		return err
+	}
+
+	sha1, err := c.writeFile(f, size)
+	if err != nil {
+		c.release(diskUsage(size))
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.used -= diskUsage(size)
+	if elem, ok := c.entries[sha1]; ok {
+		c.lru.MoveToBack(elem)
+		return nil
+	}
+	c.add(sha1, size)
+
+	return nil
+}
+
+// writeFile writes the content of f to the cache unless it is already there,
+// and returns its sha1. The content is limited to the size reserved for it,
+// as the size in the zip header is not to be trusted.
+func (c *Cache) writeFile(f *zip.File, size int64) (string, error) {
+	src, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	tmpFile, err := os.CreateTemp(c.dir, ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	hash := sha1.New() // #nosec G401
+	written, err := io.Copy(io.MultiWriter(tmpFile, hash), io.LimitReader(src, size+1))
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	if written != size {
+		return "", fmt.Errorf("expected %d bytes, got %d", size, written)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	path := c.path(sum)
+	if _, err = os.Stat(path); err == nil {
+		return sum, nil
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+
+	return sum, os.Rename(tmpFile.Name(), path)
+}
+
+// reserve makes room for a file of the given size, counting it as used while
+// it is being written so that concurrent uploads cannot exceed maxSize
+func (c *Cache) reserve(size int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.evict(size); err != nil {
+		return err
+	}
+	c.used += size
+
+	return nil
+}
+
+func (c *Cache) release(size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.used -= size
+}
+
+// evict removes the least recently used files until there is room for size
+// more bytes. It must be called with c.mu held.
+func (c *Cache) evict(size int64) error {
+	for c.used+size > c.maxSize {
+		oldest := c.lru.Front()
+		if oldest == nil {
+			return errors.New("the cache is full with files being uploaded")
+		}
+
+		e := oldest.Value.(entry)
+		if err := os.Remove(c.path(e.sha1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to evict %q: %w", e.sha1, err)
+		}
+		c.lru.Remove(oldest)
+		delete(c.entries, e.sha1)
+		c.used -= e.size
+	}
+
+	return nil
+}
+
+// add must be called with c.mu held
+func (c *Cache) add(sha1 string, size int64) {
+	c.entries[sha1] = c.lru.PushBack(entry{sha1: sha1, size: diskUsage(size)})
+	c.used += diskUsage(size)
+}
+
+// diskUsage rounds the size of a file up to the blocks it takes on disk, which
+// is what counts towards the size limit of the volume the cache is stored on
+func diskUsage(size int64) int64 {
+	return (size + blockSize - 1) / blockSize * blockSize
+}
+
+// touch marks the resource as recently used, so that it is evicted last
+func (c *Cache) touch(sha1 string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[sha1]; ok {
+		c.lru.MoveToBack(elem)
+	}
+}
+
+func (c *Cache) contains(resource Resource) (bool, error) {
+	if !sha1Regexp.MatchString(resource.SHA1) {
+		return false, nil
+	}
+
+	info, err := os.Stat(c.path(resource.SHA1))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to look up resource %q: %w", resource.SHA1, err)
+	}
+
+	if info.Size() != resource.Size {
+		return false, nil
+	}
+	c.touch(resource.SHA1)
+
+	return true, nil
+}
+
+// resourceMissingError is returned when a resource that was matched is no
+// longer in the cache, e.g. because it has been evicted since or because the
+// upload is served by another API replica with a cache of its own. Pushing
+// again matches the resources against the current state of the cache.
+func resourceMissingError(resource Resource) error {
+	return apierrors.NewServiceUnavailableError(nil, fmt.Sprintf("Resource %q is no longer cached, please push again", resource.Path))
+}
+
+func (c *Cache) path(sha1 string) string {
+	return filepath.Join(c.dir, sha1[:2], sha1)
+}
+
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	closeErr := f.File.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return err
+	}
+
+	return closeErr
+}
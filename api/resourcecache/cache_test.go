@@ -0,0 +1,337 @@
+package resourcecache_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/resourcecache"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		cache    *resourcecache.Cache
+		cacheDir string
+		bits     []byte
+		indexSum string
+		libSum   string
+	)
+
+	checksum := func(content string) string {
+		sum := sha1.Sum([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+
+	createZip := func(files map[string]string) []byte {
+		buf := new(bytes.Buffer)
+		zipWriter := zip.NewWriter(buf)
+		for name, content := range files {
+			w, err := zipWriter.Create(name)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Write([]byte(content))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(zipWriter.Close()).To(Succeed())
+
+		return buf.Bytes()
+	}
+
+	readZip := func(r io.Reader) map[string]string {
+		content, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+
+		zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		Expect(err).NotTo(HaveOccurred())
+
+		files := map[string]string{}
+		for _, f := range zipReader.File {
+			src, err := f.Open()
+			Expect(err).NotTo(HaveOccurred())
+			fileContent, err := io.ReadAll(src)
+			Expect(err).NotTo(HaveOccurred())
+			files[f.Name] = string(fileContent)
+		}
+
+		return files
+	}
+
+	BeforeEach(func() {
+		var err error
+		cacheDir = GinkgoT().TempDir()
+		cache, err = resourcecache.New(cacheDir, 1024*1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		indexSum = checksum("index")
+		libSum = checksum("a large library")
+		bits = createZip(map[string]string{
+			"index.js":   "index",
+			"lib/big.js": "a large library",
+		})
+	})
+
+	Describe("Match", func() {
+		var (
+			resources []resourcecache.Resource
+			matches   []resourcecache.Resource
+			matchErr  error
+		)
+
+		BeforeEach(func() {
+			resources = []resourcecache.Resource{
+				{SHA1: indexSum, Size: 5, Path: "index.js"},
+				{SHA1: libSum, Size: 15, Path: "lib/big.js"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			matches, matchErr = cache.Match(ctx, resources)
+		})
+
+		It("does not match anything", func() {
+			Expect(matchErr).NotTo(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		When("the files have been uploaded before", func() {
+			BeforeEach(func() {
+				packageBits, err := cache.Assemble(ctx, bytes.NewReader(bits), int64(len(bits)), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(packageBits.Close()).To(Succeed())
+			})
+
+			It("matches them", func() {
+				Expect(matchErr).NotTo(HaveOccurred())
+				Expect(matches).To(Equal(resources))
+			})
+
+			When("the size does not match", func() {
+				BeforeEach(func() {
+					resources[1].Size = 16
+				})
+
+				It("does not match the resource", func() {
+					Expect(matchErr).NotTo(HaveOccurred())
+					Expect(matches).To(ConsistOf(resources[0]))
+				})
+			})
+		})
+
+		When("the checksum is not a sha1", func() {
+			BeforeEach(func() {
+				resources = []resourcecache.Resource{{SHA1: "../../etc/passwd", Size: 5}}
+			})
+
+			It("does not match it", func() {
+				Expect(matchErr).NotTo(HaveOccurred())
+				Expect(matches).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Assemble", func() {
+		var (
+			uploadedBits io.ReaderAt
+			resources    []resourcecache.Resource
+			packageBits  io.ReadCloser
+			assembleErr  error
+		)
+
+		BeforeEach(func() {
+			uploadedBits = bytes.NewReader(bits)
+			resources = nil
+		})
+
+		JustBeforeEach(func() {
+			packageBits, assembleErr = cache.Assemble(ctx, uploadedBits, int64(len(bits)), resources)
+		})
+
+		AfterEach(func() {
+			if packageBits != nil {
+				Expect(packageBits.Close()).To(Succeed())
+			}
+		})
+
+		It("returns the uploaded bits", func() {
+			Expect(assembleErr).NotTo(HaveOccurred())
+			content, err := io.ReadAll(packageBits)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(bits))
+		})
+
+		When("the upload refers to cached resources", func() {
+			BeforeEach(func() {
+				previousBits, err := cache.Assemble(ctx, bytes.NewReader(bits), int64(len(bits)), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(previousBits.Close()).To(Succeed())
+
+				bits = createZip(map[string]string{"index.js": "changed"})
+				uploadedBits = bytes.NewReader(bits)
+				resources = []resourcecache.Resource{
+					{SHA1: libSum, Size: 15, Path: "lib/big.js", Mode: 0o755},
+				}
+			})
+
+			It("merges the cached resources into the uploaded bits", func() {
+				Expect(assembleErr).NotTo(HaveOccurred())
+				Expect(readZip(packageBits)).To(Equal(map[string]string{
+					"index.js":   "changed",
+					"lib/big.js": "a large library",
+				}))
+			})
+
+			When("there are no uploaded bits", func() {
+				BeforeEach(func() {
+					uploadedBits = nil
+				})
+
+				It("creates a package from the cached resources", func() {
+					Expect(assembleErr).NotTo(HaveOccurred())
+					Expect(readZip(packageBits)).To(Equal(map[string]string{
+						"lib/big.js": "a large library",
+					}))
+				})
+			})
+
+			When("a resource is not in the cache", func() {
+				BeforeEach(func() {
+					resources = append(resources, resourcecache.Resource{SHA1: checksum("unknown"), Size: 7, Path: "unknown.js"})
+				})
+
+				It("returns a retryable error", func() {
+					Expect(assembleErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ServiceUnavailableError{}))
+					Expect(assembleErr.(apierrors.ServiceUnavailableError).Detail()).To(ContainSubstring("unknown.js"))
+				})
+			})
+
+			It("keeps the mode of cached resources", func() {
+				content, err := io.ReadAll(packageBits)
+				Expect(err).NotTo(HaveOccurred())
+				zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, f := range zipReader.File {
+					if f.Name == "lib/big.js" {
+						Expect(f.Mode()).To(Equal(fs.FileMode(0o755)))
+					}
+				}
+			})
+		})
+
+		When("the uploaded bits are not a zip", func() {
+			BeforeEach(func() {
+				bits = []byte("not-a-zip")
+				uploadedBits = bytes.NewReader(bits)
+			})
+
+			It("passes them on unchanged", func() {
+				Expect(assembleErr).NotTo(HaveOccurred())
+				content, err := io.ReadAll(packageBits)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("not-a-zip"))
+			})
+
+			When("cached resources need to be merged in", func() {
+				BeforeEach(func() {
+					resources = []resourcecache.Resource{{SHA1: libSum, Size: 15, Path: "lib/big.js"}}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(assembleErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("eviction", func() {
+		upload := func(files map[string]string) {
+			uploadBits := createZip(files)
+			packageBits, err := cache.Assemble(ctx, bytes.NewReader(uploadBits), int64(len(uploadBits)), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(packageBits.Close()).To(Succeed())
+		}
+
+		cached := func(contents ...string) []string {
+			resources := []resourcecache.Resource{}
+			for _, content := range contents {
+				resources = append(resources, resourcecache.Resource{SHA1: checksum(content), Size: int64(len(content)), Path: content})
+			}
+
+			matches, err := cache.Match(ctx, resources)
+			Expect(err).NotTo(HaveOccurred())
+
+			paths := []string{}
+			for _, match := range matches {
+				paths = append(paths, match.Path)
+			}
+			return paths
+		}
+
+		BeforeEach(func() {
+			var err error
+			cache, err = resourcecache.New(cacheDir, 2*4096)
+			Expect(err).NotTo(HaveOccurred())
+
+			upload(map[string]string{"a.js": "a"})
+			upload(map[string]string{"b.js": "b"})
+		})
+
+		It("keeps the files that fit", func() {
+			Expect(cached("a", "b")).To(ConsistOf("a", "b"))
+		})
+
+		When("more files are uploaded than fit", func() {
+			BeforeEach(func() {
+				Expect(cached("a")).To(ConsistOf("a"))
+				upload(map[string]string{"c.js": "c"})
+			})
+
+			It("evicts the least recently used files", func() {
+				Expect(cached("a", "b", "c")).To(ConsistOf("a", "c"))
+			})
+		})
+
+		When("a file is larger than the cache", func() {
+			BeforeEach(func() {
+				upload(map[string]string{"huge.js": strings.Repeat("x", 3*4096)})
+			})
+
+			It("does not cache it", func() {
+				Expect(cached(strings.Repeat("x", 3*4096))).To(BeEmpty())
+			})
+
+			It("does not evict other files for it", func() {
+				Expect(cached("a", "b")).To(ConsistOf("a", "b"))
+			})
+		})
+
+		When("the cache is created on a directory used before", func() {
+			BeforeEach(func() {
+				for i, content := range []string{"a", "b"} {
+					sum := checksum(content)
+					modTime := time.Now().Add(time.Duration(i-2) * time.Hour)
+					Expect(os.Chtimes(filepath.Join(cacheDir, sum[:2], sum), modTime, modTime)).To(Succeed())
+				}
+
+				var err error
+				cache, err = resourcecache.New(cacheDir, 4096)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the most recent files that fit", func() {
+				Expect(cached("a", "b")).To(ConsistOf("b"))
+			})
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package resourcecache
+
+import (
+	"context"
+	"io"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+)
+
+// Disabled is used when resource matching is turned off. It never matches
+// any resource, so clients always upload all the files of their apps.
+type Disabled struct{}
+
+func (Disabled) Match(context.Context, []Resource) ([]Resource, error) {
+	return []Resource{}, nil
+}
+
+func (Disabled) Assemble(_ context.Context, bits io.ReaderAt, bitsSize int64, resources []Resource) (io.ReadCloser, error) {
+	if len(resources) > 0 {
+		return nil, apierrors.NewUnprocessableEntityError(nil, "Resource matching is disabled, all files must be uploaded")
+	}
+
+	return io.NopCloser(io.NewSectionReader(bits, 0, bitsSize)), nil
+}
@@ -0,0 +1,20 @@
+package resourcecache_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var ctx context.Context
+
+func TestResourceCache(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Resource Cache Suite")
+}
+
+var _ = BeforeEach(func() {
+	ctx = context.Background()
+})
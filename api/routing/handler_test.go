@@ -2,7 +2,9 @@ package routing_test
 
 import (
 	"errors"
+	"io"
 	"net/http"
+	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/routing"
@@ -59,6 +61,25 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response has a stream", func() {
+		var stream *closeTrackingReader
+
+		BeforeEach(func() {
+			stream = &closeTrackingReader{Reader: strings.NewReader("the-stream-content")}
+			response = response.WithHeader("Content-Type", "application/zip").WithStream(stream)
+		})
+
+		It("copies the stream to the response as is", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/zip"))
+			Expect(rr).To(HaveHTTPBody("the-stream-content"))
+		})
+
+		It("closes the stream", func() {
+			Expect(stream.closed).To(BeTrue())
+		})
+	})
+
 	When("the response sets header values", func() {
 		BeforeEach(func() {
 			response = response.WithHeader("Location", "/home")
@@ -115,3 +136,13 @@ var _ = Describe("Handler", func() {
 		})
 	})
 })
+
+type closeTrackingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeTrackingReader) Close() error {
+	r.closed = true
+	return nil
+}
@@ -0,0 +1,165 @@
+package sshproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const ApplicationContainerName = "application"
+
+//counterfeiter:generate -o fake -fake-name ProcessRepository . ProcessRepository
+type ProcessRepository interface {
+	GetProcess(context.Context, authorization.Info, string) (repositories.ProcessRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name AppRepository . AppRepository
+type AppRepository interface {
+	GetApp(context.Context, authorization.Info, string) (repositories.AppRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name SpaceRepository . SpaceRepository
+type SpaceRepository interface {
+	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name PodRepository . PodRepository
+type PodRepository interface {
+	GetRunningPodName(context.Context, authorization.Info, repositories.ProcessRecord, int) (string, error)
+}
+
+//counterfeiter:generate -o fake -fake-name RoleChecker . RoleChecker
+type RoleChecker interface {
+	HasRoleIn(context.Context, authorization.Identity, string, ...string) (bool, error)
+}
+
+// Target is the container an SSH session is proxied to
+type Target struct {
+	Namespace string
+	PodName   string
+	Container string
+}
+
+// CodeAuthenticator authenticates SSH clients using the CF username scheme
+// (cf:<process-guid>/<index>) and a one-time code as password. The code owner
+// must hold one of the allowed roles in the space of the process, and SSH
+// must be enabled for both the space and the app.
+type CodeAuthenticator struct {
+	codes            *Codes
+	identityProvider authorization.IdentityProvider
+	roleChecker      RoleChecker
+	processRepo      ProcessRepository
+	appRepo          AppRepository
+	spaceRepo        SpaceRepository
+	podRepo          PodRepository
+	allowedRoles     []string
+}
+
+func NewCodeAuthenticator(
+	codes *Codes,
+	identityProvider authorization.IdentityProvider,
+	roleChecker RoleChecker,
+	processRepo ProcessRepository,
+	appRepo AppRepository,
+	spaceRepo SpaceRepository,
+	podRepo PodRepository,
+	allowedRoles []string,
+) *CodeAuthenticator {
+	return &CodeAuthenticator{
+		codes:            codes,
+		identityProvider: identityProvider,
+		roleChecker:      roleChecker,
+		processRepo:      processRepo,
+		appRepo:          appRepo,
+		spaceRepo:        spaceRepo,
+		podRepo:          podRepo,
+		allowedRoles:     allowedRoles,
+	}
+}
+
+func (a *CodeAuthenticator) Authenticate(ctx context.Context, username, code string) (Target, error) {
+	processGUID, instance, err := ParseUsername(username)
+	if err != nil {
+		return Target{}, err
+	}
+
+	authInfo, ok := a.codes.Redeem(ctx, code)
+	if !ok {
+		return Target{}, errors.New("invalid or expired code")
+	}
+
+	identity, err := a.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	process, err := a.processRepo.GetProcess(ctx, authInfo, processGUID)
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to get process %q: %w", processGUID, err)
+	}
+
+	hasRole, err := a.roleChecker.HasRoleIn(ctx, identity, process.SpaceGUID, a.allowedRoles...)
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to check roles of %q: %w", identity.Name, err)
+	}
+	if !hasRole {
+		return Target{}, fmt.Errorf("%q is not allowed to ssh into apps in space %q", identity.Name, process.SpaceGUID)
+	}
+
+	space, err := a.spaceRepo.GetSpace(ctx, authInfo, process.SpaceGUID)
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to get space %q: %w", process.SpaceGUID, err)
+	}
+	if !space.AllowSSH {
+		return Target{}, fmt.Errorf("ssh is disabled for space %q", space.Name)
+	}
+
+	app, err := a.appRepo.GetApp(ctx, authInfo, process.AppGUID)
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to get app %q: %w", process.AppGUID, err)
+	}
+	if !app.EnableSSH {
+		return Target{}, fmt.Errorf("ssh is disabled for app %q", app.Name)
+	}
+
+	if instance >= int(process.DesiredInstances) {
+		return Target{}, fmt.Errorf("instance %d of process %q does not exist", instance, processGUID)
+	}
+
+	podName, err := a.podRepo.GetRunningPodName(ctx, authInfo, process, instance)
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to find running instance %d of process %q: %w", instance, processGUID, err)
+	}
+
+	return Target{
+		Namespace: process.SpaceGUID,
+		PodName:   podName,
+		Container: ApplicationContainerName,
+	}, nil
+}
+
+// ParseUsername extracts the process GUID and instance index from a username
+// of the form cf:<process-guid>/<index>
+func ParseUsername(username string) (string, int, error) {
+	processAndIndex, found := strings.CutPrefix(username, "cf:")
+	if !found {
+		return "", 0, fmt.Errorf("invalid username %q: expected cf:<process-guid>/<index>", username)
+	}
+
+	processGUID, indexString, found := strings.Cut(processAndIndex, "/")
+	if !found || processGUID == "" {
+		return "", 0, fmt.Errorf("invalid username %q: expected cf:<process-guid>/<index>", username)
+	}
+
+	index, err := strconv.Atoi(indexString)
+	if err != nil || index < 0 {
+		return "", 0, fmt.Errorf("invalid username %q: instance index must be a non-negative integer", username)
+	}
+
+	return processGUID, index, nil
+}
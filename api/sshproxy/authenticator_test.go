@@ -0,0 +1,249 @@
+package sshproxy_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	authfake "code.cloudfoundry.org/korifi/api/authorization/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+	"code.cloudfoundry.org/korifi/api/sshproxy/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("CodeAuthenticator", func() {
+	var (
+		codes            *sshproxy.Codes
+		identityProvider *authfake.IdentityProvider
+		roleChecker      *fake.RoleChecker
+		processRepo      *fake.ProcessRepository
+		appRepo          *fake.AppRepository
+		spaceRepo        *fake.SpaceRepository
+		podRepo          *fake.PodRepository
+		authenticator    *sshproxy.CodeAuthenticator
+
+		authInfo authorization.Info
+		username string
+		code     string
+		target   sshproxy.Target
+		authErr  error
+	)
+
+	BeforeEach(func() {
+		codes = sshproxy.NewCodes(k8sfake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), "root-ns", time.Minute)
+		authInfo = authorization.Info{Token: "the-token"}
+
+		var err error
+		code, err = codes.Generate(ctx, authInfo)
+		Expect(err).NotTo(HaveOccurred())
+		username = "cf:the-process-guid/1"
+
+		identityProvider = new(authfake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "the-user", Kind: "User"}, nil)
+
+		roleChecker = new(fake.RoleChecker)
+		roleChecker.HasRoleInReturns(true, nil)
+
+		processRepo = new(fake.ProcessRepository)
+		processRepo.GetProcessReturns(repositories.ProcessRecord{
+			GUID:             "the-process-guid",
+			SpaceGUID:        "the-space-guid",
+			AppGUID:          "the-app-guid",
+			DesiredInstances: 2,
+		}, nil)
+
+		spaceRepo = new(fake.SpaceRepository)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+			GUID:     "the-space-guid",
+			Name:     "the-space",
+			AllowSSH: true,
+		}, nil)
+
+		appRepo = new(fake.AppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "the-app-guid",
+			Name:      "the-app",
+			EnableSSH: true,
+		}, nil)
+
+		podRepo = new(fake.PodRepository)
+		podRepo.GetRunningPodNameReturns("the-pod", nil)
+
+		authenticator = sshproxy.NewCodeAuthenticator(
+			codes,
+			identityProvider,
+			roleChecker,
+			processRepo,
+			appRepo,
+			spaceRepo,
+			podRepo,
+			[]string{"admin-role", "space-developer-role"},
+		)
+	})
+
+	JustBeforeEach(func() {
+		target, authErr = authenticator.Authenticate(ctx, username, code)
+	})
+
+	It("returns the target container", func() {
+		Expect(authErr).NotTo(HaveOccurred())
+		Expect(target).To(Equal(sshproxy.Target{
+			Namespace: "the-space-guid",
+			PodName:   "the-pod",
+			Container: "application",
+		}))
+	})
+
+	It("uses the credentials of the code owner", func() {
+		Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+		_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+
+		Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualProcessGUID).To(Equal("the-process-guid"))
+
+		Expect(podRepo.GetRunningPodNameCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualProcess, actualInstance := podRepo.GetRunningPodNameArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualProcess.GUID).To(Equal("the-process-guid"))
+		Expect(actualInstance).To(Equal(1))
+	})
+
+	It("checks the space roles of the code owner", func() {
+		Expect(roleChecker.HasRoleInCallCount()).To(Equal(1))
+		_, actualIdentity, actualNamespace, actualRoles := roleChecker.HasRoleInArgsForCall(0)
+		Expect(actualIdentity).To(Equal(authorization.Identity{Name: "the-user", Kind: "User"}))
+		Expect(actualNamespace).To(Equal("the-space-guid"))
+		Expect(actualRoles).To(ConsistOf("admin-role", "space-developer-role"))
+	})
+
+	When("the code is used again", func() {
+		JustBeforeEach(func() {
+			target, authErr = authenticator.Authenticate(ctx, username, code)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError("invalid or expired code"))
+		})
+	})
+
+	When("the code is unknown", func() {
+		BeforeEach(func() {
+			code = "unknown-code"
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError("invalid or expired code"))
+		})
+	})
+
+	When("the username is invalid", func() {
+		BeforeEach(func() {
+			username = "the-user"
+		})
+
+		It("returns an error without redeeming the code", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("invalid username")))
+			_, ok := codes.Redeem(ctx, code)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	When("the code owner does not have an allowed role in the space", func() {
+		BeforeEach(func() {
+			roleChecker.HasRoleInReturns(false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("not allowed to ssh")))
+			Expect(podRepo.GetRunningPodNameCallCount()).To(BeZero())
+		})
+	})
+
+	When("checking the roles fails", func() {
+		BeforeEach(func() {
+			roleChecker.HasRoleInReturns(false, errors.New("role-err"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("role-err")))
+		})
+	})
+
+	When("getting the process fails", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("process-err"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("process-err")))
+		})
+	})
+
+	When("ssh is not allowed in the space", func() {
+		BeforeEach(func() {
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{Name: "the-space", AllowSSH: false}, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(`ssh is disabled for space "the-space"`))
+		})
+	})
+
+	When("ssh is disabled for the app", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{Name: "the-app", EnableSSH: false}, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(`ssh is disabled for app "the-app"`))
+		})
+	})
+
+	When("the instance index exceeds the desired instances", func() {
+		BeforeEach(func() {
+			username = "cf:the-process-guid/2"
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("does not exist")))
+		})
+	})
+
+	When("the instance is not running", func() {
+		BeforeEach(func() {
+			podRepo.GetRunningPodNameReturns("", errors.New("pod-err"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("pod-err")))
+		})
+	})
+})
+
+var _ = DescribeTable("ParseUsername",
+	func(username string, expectedProcessGUID string, expectedIndex int, expectedErr string) {
+		processGUID, index, err := sshproxy.ParseUsername(username)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			return
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processGUID).To(Equal(expectedProcessGUID))
+		Expect(index).To(Equal(expectedIndex))
+	},
+	Entry("valid", "cf:the-process-guid/3", "the-process-guid", 3, ""),
+	Entry("missing prefix", "the-process-guid/3", "", 0, "expected cf:<process-guid>/<index>"),
+	Entry("missing index", "cf:the-process-guid", "", 0, "expected cf:<process-guid>/<index>"),
+	Entry("missing process guid", "cf:/3", "", 0, "expected cf:<process-guid>/<index>"),
+	Entry("non numeric index", "cf:the-process-guid/abc", "", 0, "non-negative integer"),
+	Entry("negative index", "cf:the-process-guid/-1", "", 0, "non-negative integer"),
+)
@@ -0,0 +1,142 @@
+package sshproxy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;create;delete,namespace=ROOT_NAMESPACE
+
+const (
+	DefaultCodeTTL = 5 * time.Minute
+
+	CodeLabelKey       = "korifi.cloudfoundry.org/ssh-code"
+	codeSecretPrefix   = "ssh-code-"
+	codeAuthInfoKey    = "auth_info"
+	codeExpiresAtKey   = "expires_at"
+	codeSecretLabelVal = "true"
+)
+
+// Codes hands out one-time codes that stand in for the caller's credentials
+// when authenticating against the SSH proxy. Codes are random and opaque, the
+// credentials they stand for are kept in secrets in the root namespace so
+// that a code issued by one API instance can be redeemed on any other, but
+// only once.
+type Codes struct {
+	k8sClient client.Client
+	namespace string
+	ttl       time.Duration
+}
+
+func NewCodes(k8sClient client.Client, namespace string, ttl time.Duration) *Codes {
+	return &Codes{
+		k8sClient: k8sClient,
+		namespace: namespace,
+		ttl:       ttl,
+	}
+}
+
+func (c *Codes) Generate(ctx context.Context, authInfo authorization.Info) (string, error) {
+	c.deleteExpired(ctx)
+
+	codeBytes := make([]byte, 24)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return "", fmt.Errorf("failed to generate ssh code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(codeBytes)
+
+	rawAuthInfo, err := json.Marshal(authInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal ssh code: %w", err)
+	}
+
+	err = c.k8sClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.namespace,
+			Name:      codeSecretName(code),
+			Labels:    map[string]string{CodeLabelKey: codeSecretLabelVal},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			codeAuthInfoKey:  rawAuthInfo,
+			codeExpiresAtKey: []byte(time.Now().Add(c.ttl).UTC().Format(time.RFC3339Nano)),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store ssh code: %w", err)
+	}
+
+	return code, nil
+}
+
+// Redeem returns the credentials the code stands for and deletes the code.
+// The deletion is preconditioned on the secret being unchanged, so when the
+// same code is redeemed concurrently only one of the callers succeeds.
+func (c *Codes) Redeem(ctx context.Context, code string) (authorization.Info, bool) {
+	secret := &corev1.Secret{}
+	err := c.k8sClient.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: codeSecretName(code)}, secret)
+	if err != nil {
+		return authorization.Info{}, false
+	}
+
+	err = c.k8sClient.Delete(ctx, secret, client.Preconditions{
+		UID:             &secret.UID,
+		ResourceVersion: &secret.ResourceVersion,
+	})
+	if err != nil {
+		return authorization.Info{}, false
+	}
+
+	if isExpired(secret) {
+		return authorization.Info{}, false
+	}
+
+	var authInfo authorization.Info
+	if err = json.Unmarshal(secret.Data[codeAuthInfoKey], &authInfo); err != nil {
+		return authorization.Info{}, false
+	}
+
+	return authInfo, true
+}
+
+func (c *Codes) deleteExpired(ctx context.Context) {
+	secrets := &corev1.SecretList{}
+	err := c.k8sClient.List(ctx, secrets, client.InNamespace(c.namespace), client.MatchingLabels{CodeLabelKey: codeSecretLabelVal})
+	if err != nil {
+		return
+	}
+
+	for i := range secrets.Items {
+		if isExpired(&secrets.Items[i]) {
+			err = c.k8sClient.Delete(ctx, &secrets.Items[i])
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return
+			}
+		}
+	}
+}
+
+func isExpired(secret *corev1.Secret) bool {
+	expiresAt, err := time.Parse(time.RFC3339Nano, string(secret.Data[codeExpiresAtKey]))
+	return err != nil || time.Now().After(expiresAt)
+}
+
+// codeSecretName derives the secret name from a hash of the code, so that the
+// codes themselves cannot be recovered by listing the secrets
+func codeSecretName(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return codeSecretPrefix + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,120 @@
+package sshproxy_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Codes", func() {
+	var (
+		k8sClient client.Client
+		codes     *sshproxy.Codes
+		code      string
+	)
+
+	BeforeEach(func() {
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		codes = sshproxy.NewCodes(k8sClient, "root-ns", time.Minute)
+
+		var err error
+		code, err = codes.Generate(ctx, authorization.Info{Token: "the-token"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	listCodeSecrets := func() []corev1.Secret {
+		secrets := &corev1.SecretList{}
+		Expect(k8sClient.List(ctx, secrets, client.InNamespace("root-ns"), client.HasLabels{sshproxy.CodeLabelKey})).To(Succeed())
+		return secrets.Items
+	}
+
+	It("generates unique codes", func() {
+		otherCode, err := codes.Generate(ctx, authorization.Info{Token: "the-token"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(otherCode).NotTo(Equal(code))
+	})
+
+	It("does not reveal the credentials in the code", func() {
+		Expect(code).NotTo(ContainSubstring("the-token"))
+	})
+
+	It("stores the code in the namespace without revealing it", func() {
+		secrets := listCodeSecrets()
+		Expect(secrets).To(HaveLen(1))
+		Expect(secrets[0].Name).NotTo(ContainSubstring(code))
+	})
+
+	It("redeems the code for the auth info it was generated for", func() {
+		authInfo, ok := codes.Redeem(ctx, code)
+		Expect(ok).To(BeTrue())
+		Expect(authInfo).To(Equal(authorization.Info{Token: "the-token"}))
+	})
+
+	It("redeems the code with other codes sharing the namespace", func() {
+		otherCodes := sshproxy.NewCodes(k8sClient, "root-ns", time.Minute)
+
+		authInfo, ok := otherCodes.Redeem(ctx, code)
+		Expect(ok).To(BeTrue())
+		Expect(authInfo).To(Equal(authorization.Info{Token: "the-token"}))
+	})
+
+	It("redeems the code only once", func() {
+		_, ok := codes.Redeem(ctx, code)
+		Expect(ok).To(BeTrue())
+		Expect(listCodeSecrets()).To(BeEmpty())
+
+		otherCodes := sshproxy.NewCodes(k8sClient, "root-ns", time.Minute)
+		_, ok = otherCodes.Redeem(ctx, code)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("does not redeem codes generated in another namespace", func() {
+		otherCodes := sshproxy.NewCodes(k8sClient, "another-ns", time.Minute)
+
+		_, ok := otherCodes.Redeem(ctx, code)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("does not redeem unknown codes", func() {
+		_, ok := codes.Redeem(ctx, "unknown-code")
+		Expect(ok).To(BeFalse())
+	})
+
+	When("the code has expired", func() {
+		BeforeEach(func() {
+			k8sClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+			codes = sshproxy.NewCodes(k8sClient, "root-ns", time.Millisecond)
+
+			var err error
+			code, err = codes.Generate(ctx, authorization.Info{Token: "the-token"})
+			Expect(err).NotTo(HaveOccurred())
+
+			time.Sleep(10 * time.Millisecond)
+		})
+
+		It("does not redeem it", func() {
+			_, ok := codes.Redeem(ctx, code)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("deletes it when generating another code", func() {
+			expiredSecrets := listCodeSecrets()
+			Expect(expiredSecrets).To(HaveLen(1))
+
+			_, err := codes.Generate(ctx, authorization.Info{Token: "the-token"})
+			Expect(err).NotTo(HaveOccurred())
+
+			secrets := listCodeSecrets()
+			Expect(secrets).To(HaveLen(1))
+			Expect(secrets[0].Name).NotTo(Equal(expiredSecrets[0].Name))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package sshproxy
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+//counterfeiter:generate -o fake -fake-name PodExecutor . PodExecutor
+type PodExecutor interface {
+	Exec(context.Context, Target, []string, remotecommand.StreamOptions) error
+}
+
+type K8sPodExecutor struct {
+	restConfig *rest.Config
+	clientset  kubernetes.Interface
+}
+
+func NewK8sPodExecutor(restConfig *rest.Config, clientset kubernetes.Interface) *K8sPodExecutor {
+	return &K8sPodExecutor{
+		restConfig: restConfig,
+		clientset:  clientset,
+	}
+}
+
+func (e *K8sPodExecutor) Exec(ctx context.Context, target Target, command []string, streams remotecommand.StreamOptions) error {
+	req := e.clientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(target.Namespace).
+		Name(target.PodName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: target.Container,
+			Command:   command,
+			Stdin:     streams.Stdin != nil,
+			Stdout:    streams.Stdout != nil,
+			Stderr:    streams.Stderr != nil,
+			TTY:       streams.Tty,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.restConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor for pod %s/%s: %w", target.Namespace, target.PodName, err)
+	}
+
+	return executor.StreamWithContext(ctx, streams)
+}
@@ -0,0 +1,125 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+)
+
+type PodRepository struct {
+	GetRunningPodNameStub        func(context.Context, authorization.Info, repositories.ProcessRecord, int) (string, error)
+	getRunningPodNameMutex       sync.RWMutex
+	getRunningPodNameArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ProcessRecord
+		arg4 int
+	}
+	getRunningPodNameReturns struct {
+		result1 string
+		result2 error
+	}
+	getRunningPodNameReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PodRepository) GetRunningPodName(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ProcessRecord, arg4 int) (string, error) {
+	fake.getRunningPodNameMutex.Lock()
+	ret, specificReturn := fake.getRunningPodNameReturnsOnCall[len(fake.getRunningPodNameArgsForCall)]
+	fake.getRunningPodNameArgsForCall = append(fake.getRunningPodNameArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ProcessRecord
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetRunningPodNameStub
+	fakeReturns := fake.getRunningPodNameReturns
+	fake.recordInvocation("GetRunningPodName", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getRunningPodNameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodRepository) GetRunningPodNameCallCount() int {
+	fake.getRunningPodNameMutex.RLock()
+	defer fake.getRunningPodNameMutex.RUnlock()
+	return len(fake.getRunningPodNameArgsForCall)
+}
+
+func (fake *PodRepository) GetRunningPodNameCalls(stub func(context.Context, authorization.Info, repositories.ProcessRecord, int) (string, error)) {
+	fake.getRunningPodNameMutex.Lock()
+	defer fake.getRunningPodNameMutex.Unlock()
+	fake.GetRunningPodNameStub = stub
+}
+
+func (fake *PodRepository) GetRunningPodNameArgsForCall(i int) (context.Context, authorization.Info, repositories.ProcessRecord, int) {
+	fake.getRunningPodNameMutex.RLock()
+	defer fake.getRunningPodNameMutex.RUnlock()
+	argsForCall := fake.getRunningPodNameArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *PodRepository) GetRunningPodNameReturns(result1 string, result2 error) {
+	fake.getRunningPodNameMutex.Lock()
+	defer fake.getRunningPodNameMutex.Unlock()
+	fake.GetRunningPodNameStub = nil
+	fake.getRunningPodNameReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodRepository) GetRunningPodNameReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getRunningPodNameMutex.Lock()
+	defer fake.getRunningPodNameMutex.Unlock()
+	fake.GetRunningPodNameStub = nil
+	if fake.getRunningPodNameReturnsOnCall == nil {
+		fake.getRunningPodNameReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getRunningPodNameReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getRunningPodNameMutex.RLock()
+	defer fake.getRunningPodNameMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PodRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sshproxy.PodRepository = new(PodRepository)
@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+)
+
+type RoleChecker struct {
+	HasRoleInStub        func(context.Context, authorization.Identity, string, ...string) (bool, error)
+	hasRoleInMutex       sync.RWMutex
+	hasRoleInArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Identity
+		arg3 string
+		arg4 []string
+	}
+	hasRoleInReturns struct {
+		result1 bool
+		result2 error
+	}
+	hasRoleInReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RoleChecker) HasRoleIn(arg1 context.Context, arg2 authorization.Identity, arg3 string, arg4 ...string) (bool, error) {
+	fake.hasRoleInMutex.Lock()
+	ret, specificReturn := fake.hasRoleInReturnsOnCall[len(fake.hasRoleInArgsForCall)]
+	fake.hasRoleInArgsForCall = append(fake.hasRoleInArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Identity
+		arg3 string
+		arg4 []string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.HasRoleInStub
+	fakeReturns := fake.hasRoleInReturns
+	fake.recordInvocation("HasRoleIn", []interface{}{arg1, arg2, arg3, arg4})
+	fake.hasRoleInMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RoleChecker) HasRoleInCallCount() int {
+	fake.hasRoleInMutex.RLock()
+	defer fake.hasRoleInMutex.RUnlock()
+	return len(fake.hasRoleInArgsForCall)
+}
+
+func (fake *RoleChecker) HasRoleInCalls(stub func(context.Context, authorization.Identity, string, ...string) (bool, error)) {
+	fake.hasRoleInMutex.Lock()
+	defer fake.hasRoleInMutex.Unlock()
+	fake.HasRoleInStub = stub
+}
+
+func (fake *RoleChecker) HasRoleInArgsForCall(i int) (context.Context, authorization.Identity, string, []string) {
+	fake.hasRoleInMutex.RLock()
+	defer fake.hasRoleInMutex.RUnlock()
+	argsForCall := fake.hasRoleInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *RoleChecker) HasRoleInReturns(result1 bool, result2 error) {
+	fake.hasRoleInMutex.Lock()
+	defer fake.hasRoleInMutex.Unlock()
+	fake.HasRoleInStub = nil
+	fake.hasRoleInReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RoleChecker) HasRoleInReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.hasRoleInMutex.Lock()
+	defer fake.hasRoleInMutex.Unlock()
+	fake.HasRoleInStub = nil
+	if fake.hasRoleInReturnsOnCall == nil {
+		fake.hasRoleInReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.hasRoleInReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RoleChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.hasRoleInMutex.RLock()
+	defer fake.hasRoleInMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RoleChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sshproxy.RoleChecker = new(RoleChecker)
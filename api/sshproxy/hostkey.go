@@ -0,0 +1,31 @@
+package sshproxy
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func LoadHostKey(path string) (ssh.Signer, error) {
+	keyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ssh host key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ssh host key: %w", err)
+	}
+
+	return signer, nil
+}
+
+// HostKeyFingerprint returns the unpadded base64 SHA256 fingerprint the CF
+// CLI expects in the app_ssh root link
+func HostKeyFingerprint(hostKey ssh.Signer) string {
+	sum := sha256.Sum256(hostKey.PublicKey().Marshal())
+	return base64.RawStdEncoding.EncodeToString(sum[:])
+}
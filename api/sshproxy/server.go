@@ -0,0 +1,273 @@
+package sshproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+const (
+	namespaceExtension = "korifi-namespace"
+	podExtension       = "korifi-pod"
+	containerExtension = "korifi-container"
+)
+
+var defaultShellCommand = []string{"/bin/sh", "-c", "if [ -x /bin/bash ]; then exec /bin/bash; fi; exec /bin/sh"}
+
+//counterfeiter:generate -o fake -fake-name Authenticator . Authenticator
+type Authenticator interface {
+	Authenticate(ctx context.Context, username, password string) (Target, error)
+}
+
+type Server struct {
+	port          int
+	hostKey       ssh.Signer
+	authenticator Authenticator
+	executor      PodExecutor
+}
+
+func NewServer(port int, hostKey ssh.Signer, authenticator Authenticator, executor PodExecutor) *Server {
+	return &Server{
+		port:          port,
+		hostKey:       hostKey,
+		authenticator: authenticator,
+		executor:      executor,
+	}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", s.port, err)
+	}
+
+	return s.Serve(ctx, listener)
+}
+
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	logger := logr.FromContextOrDiscard(ctx).WithName("ssh-proxy")
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(connMeta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			target, err := s.authenticator.Authenticate(ctx, connMeta.User(), string(password))
+			if err != nil {
+				logger.Info("authentication failed", "user", connMeta.User(), "remoteAddr", connMeta.RemoteAddr().String(), "reason", err.Error())
+				return nil, errors.New("authentication failed")
+			}
+
+			return &ssh.Permissions{
+				Extensions: map[string]string{
+					namespaceExtension: target.Namespace,
+					podExtension:       target.PodName,
+					containerExtension: target.Container,
+				},
+			}, nil
+		},
+	}
+	serverConfig.AddHostKey(s.hostKey)
+
+	logger.Info("listening", "address", listener.Addr().String())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		go s.handleConnection(ctx, logger, serverConfig, conn)
+	}
+}
+
+func (s *Server) handleConnection(ctx context.Context, logger logr.Logger, serverConfig *ssh.ServerConfig, conn net.Conn) {
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, serverConfig)
+	if err != nil {
+		logger.V(1).Info("ssh handshake failed", "remoteAddr", conn.RemoteAddr().String(), "reason", err.Error())
+		conn.Close()
+		return
+	}
+	defer serverConn.Close()
+
+	target := Target{
+		Namespace: serverConn.Permissions.Extensions[namespaceExtension],
+		PodName:   serverConn.Permissions.Extensions[podExtension],
+		Container: serverConn.Permissions.Extensions[containerExtension],
+	}
+	logger = logger.WithValues("user", serverConn.User(), "namespace", target.Namespace, "pod", target.PodName)
+	logger.Info("session opened")
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "only session channels are supported")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			logger.Error(err, "failed to accept channel")
+			continue
+		}
+
+		go s.handleSession(ctx, logger, target, channel, channelRequests)
+	}
+
+	logger.Info("session closed")
+}
+
+func (s *Server) handleSession(ctx context.Context, logger logr.Logger, target Target, channel ssh.Channel, requests <-chan *ssh.Request) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sizeQueue := newTerminalSizeQueue()
+	defer sizeQueue.close()
+
+	tty := false
+	started := false
+
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			var ptyReq struct {
+				Term    string
+				Columns uint32
+				Rows    uint32
+				Width   uint32
+				Height  uint32
+				Modes   string
+			}
+			if err := ssh.Unmarshal(req.Payload, &ptyReq); err != nil {
+				reply(req, false)
+				continue
+			}
+			tty = true
+			sizeQueue.push(ptyReq.Columns, ptyReq.Rows)
+			reply(req, true)
+		case "window-change":
+			var windowChange struct {
+				Columns uint32
+				Rows    uint32
+				Width   uint32
+				Height  uint32
+			}
+			if err := ssh.Unmarshal(req.Payload, &windowChange); err == nil {
+				sizeQueue.push(windowChange.Columns, windowChange.Rows)
+			}
+			reply(req, true)
+		case "shell", "exec":
+			if started {
+				reply(req, false)
+				continue
+			}
+
+			command := defaultShellCommand
+			if req.Type == "exec" {
+				var execReq struct {
+					Command string
+				}
+				if err := ssh.Unmarshal(req.Payload, &execReq); err != nil {
+					reply(req, false)
+					continue
+				}
+				command = []string{"/bin/sh", "-c", execReq.Command}
+			}
+
+			started = true
+			reply(req, true)
+
+			streams := remotecommand.StreamOptions{
+				Stdin:  channel,
+				Stdout: channel,
+				Stderr: channel.Stderr(),
+			}
+			if tty {
+				streams.Stderr = nil
+				streams.Tty = true
+				streams.TerminalSizeQueue = sizeQueue
+			}
+
+			go func() {
+				err := s.executor.Exec(ctx, target, command, streams)
+				if err != nil {
+					logger.Info("command failed", "reason", err.Error())
+				}
+
+				_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{exitStatus(err)}))
+				channel.Close()
+			}()
+		default:
+			reply(req, false)
+		}
+	}
+}
+
+func reply(req *ssh.Request, ok bool) {
+	if req.WantReply {
+		_ = req.Reply(ok, nil)
+	}
+}
+
+func exitStatus(err error) uint32 {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr exec.ExitError
+	if errors.As(err, &exitErr) {
+		return uint32(exitErr.ExitStatus())
+	}
+
+	return 1
+}
+
+type terminalSizeQueue struct {
+	sizes chan remotecommand.TerminalSize
+}
+
+func newTerminalSizeQueue() *terminalSizeQueue {
+	return &terminalSizeQueue{
+		sizes: make(chan remotecommand.TerminalSize, 1),
+	}
+}
+
+// push replaces any size that has not been consumed yet, only the latest
+// size matters
+func (q *terminalSizeQueue) push(columns, rows uint32) {
+	size := remotecommand.TerminalSize{Width: uint16(columns), Height: uint16(rows)}
+	for {
+		select {
+		case q.sizes <- size:
+			return
+		default:
+			select {
+			case <-q.sizes:
+			default:
+			}
+		}
+	}
+}
+
+func (q *terminalSizeQueue) close() {
+	close(q.sizes)
+}
+
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
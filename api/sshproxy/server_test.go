@@ -0,0 +1,153 @@
+package sshproxy_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+	"code.cloudfoundry.org/korifi/api/sshproxy/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		authenticator *fake.Authenticator
+		executor      *fake.PodExecutor
+		hostKey       ssh.Signer
+		address       string
+		cancel        context.CancelFunc
+		password      string
+		client        *ssh.Client
+		dialErr       error
+	)
+
+	BeforeEach(func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err = ssh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		authenticator = new(fake.Authenticator)
+		authenticator.AuthenticateStub = func(_ context.Context, username, password string) (sshproxy.Target, error) {
+			if username != "cf:the-process-guid/0" || password != "the-code" {
+				return sshproxy.Target{}, errors.New("nope")
+			}
+
+			return sshproxy.Target{Namespace: "the-namespace", PodName: "the-pod", Container: "application"}, nil
+		}
+
+		executor = new(fake.PodExecutor)
+		executor.ExecStub = func(_ context.Context, _ sshproxy.Target, command []string, streams remotecommand.StreamOptions) error {
+			_, err := fmt.Fprintf(streams.Stdout, "ran %q", command[len(command)-1])
+			return err
+		}
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		address = listener.Addr().String()
+
+		var serverCtx context.Context
+		serverCtx, cancel = context.WithCancel(ctx)
+		server := sshproxy.NewServer(0, hostKey, authenticator, executor)
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Serve(serverCtx, listener)).To(Succeed())
+		}()
+
+		password = "the-code"
+	})
+
+	AfterEach(func() {
+		if client != nil {
+			client.Close()
+		}
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		client, dialErr = ssh.Dial("tcp", address, &ssh.ClientConfig{
+			User:            "cf:the-process-guid/0",
+			Auth:            []ssh.AuthMethod{ssh.Password(password)},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		})
+	})
+
+	It("runs commands in the authenticated target", func() {
+		Expect(dialErr).NotTo(HaveOccurred())
+
+		session, err := client.NewSession()
+		Expect(err).NotTo(HaveOccurred())
+		defer session.Close()
+
+		output, err := session.Output("echo hello")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(output)).To(Equal(`ran "echo hello"`))
+
+		Expect(executor.ExecCallCount()).To(Equal(1))
+		_, actualTarget, actualCommand, actualStreams := executor.ExecArgsForCall(0)
+		Expect(actualTarget).To(Equal(sshproxy.Target{Namespace: "the-namespace", PodName: "the-pod", Container: "application"}))
+		Expect(actualCommand).To(Equal([]string{"/bin/sh", "-c", "echo hello"}))
+		Expect(actualStreams.Tty).To(BeFalse())
+		Expect(actualStreams.Stderr).NotTo(BeNil())
+	})
+
+	When("a pty is requested", func() {
+		It("starts an interactive shell with a tty", func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+
+			session, err := client.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+			defer session.Close()
+
+			Expect(session.RequestPty("xterm", 40, 80, ssh.TerminalModes{})).To(Succeed())
+			Expect(session.Shell()).To(Succeed())
+			Expect(session.Wait()).To(Succeed())
+
+			Expect(executor.ExecCallCount()).To(Equal(1))
+			_, _, actualCommand, actualStreams := executor.ExecArgsForCall(0)
+			Expect(actualCommand[0]).To(Equal("/bin/sh"))
+			Expect(actualStreams.Tty).To(BeTrue())
+			Expect(actualStreams.Stderr).To(BeNil())
+			Expect(actualStreams.TerminalSizeQueue.Next()).To(Equal(&remotecommand.TerminalSize{Width: 80, Height: 40}))
+		})
+	})
+
+	When("the command exits with a non-zero status", func() {
+		BeforeEach(func() {
+			executor.ExecReturns(exec.CodeExitError{Err: errors.New("boom"), Code: 42})
+			executor.ExecStub = nil
+		})
+
+		It("returns the exit status to the client", func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+
+			session, err := client.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+			defer session.Close()
+
+			var exitErr *ssh.ExitError
+			Expect(errors.As(session.Run("false"), &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(42))
+		})
+	})
+
+	When("authentication fails", func() {
+		BeforeEach(func() {
+			password = "wrong-code"
+		})
+
+		It("rejects the connection", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+			Expect(executor.ExecCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,20 @@
+package sshproxy_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var ctx context.Context
+
+func TestSSHProxy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SSH Proxy Suite")
+}
+
+var _ = BeforeEach(func() {
+	ctx = context.Background()
+})
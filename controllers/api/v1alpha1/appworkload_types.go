@@ -46,6 +46,11 @@ type AppWorkloadSpec struct {
 	// +kubebuilder:default:=1
 	Instances int32 `json:"instances"`
 
+	// The maximum number of instances to be replaced at once when the AppWorkload is updated
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxInFlight *int32 `json:"maxInFlight,omitempty"`
+
 	// The name of the runner that should reconcile this AppWorkload resource and execute running its instances
 	// +kubebuilder:validation:Required
 	RunnerName string `json:"runnerName"`
@@ -56,6 +61,19 @@ type AppWorkloadSpec struct {
 	// Reference to service credentials secrets to be projected onto the app workload
 	// They are in the [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/) format
 	Services []ServiceBinding `json:"services,omitempty"`
+
+	// Additional containers to run alongside the application container in each instance
+	// +kubebuilder:validation:Optional
+	Sidecars []AppWorkloadSidecar `json:"sidecars,omitempty"`
+}
+
+type AppWorkloadSidecar struct {
+	// The name of the sidecar, unique within the AppWorkload
+	Name    string   `json:"name"`
+	Command []string `json:"command"`
+
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
@@ -18,12 +18,32 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// CFAppDeploymentStrategyKey records the strategy of the last deployment of the app
+	CFAppDeploymentStrategyKey = "korifi.cloudfoundry.org/deployment-strategy"
+	// CFAppCanceledRevisionKey records the app-rev of the last cancelled deployment. Cancelling moves the app
+	// back to the app-rev it was running before, so this app-rev must not be reused by later deployments
+	CFAppCanceledRevisionKey = "korifi.cloudfoundry.org/canceled-app-rev"
+	// CFAppPreviousRevisionKey records the app-rev the app was running before the last deployment
+	CFAppPreviousRevisionKey = "korifi.cloudfoundry.org/previous-app-rev"
+	// CFAppPreviousDropletKey records the droplet the app was running before the last deployment
+	CFAppPreviousDropletKey = "korifi.cloudfoundry.org/previous-droplet"
+	// CFAppCredentialsRotationKeyPrefix prefixes the annotations recording the
+	// latest credentials rotation of a service binding the app has been
+	// restarted for. The annotation name is the prefix followed by the binding guid
+	CFAppCredentialsRotationKeyPrefix = "credentials-rotation.korifi.cloudfoundry.org/"
+
+	RollingDeploymentStrategy = "rolling"
+	CanaryDeploymentStrategy  = "canary"
+)
+
 // CFAppSpec defines the desired state of CFApp
 type CFAppSpec struct {
 	// The mutable, user-friendly name of the app. Unlike metadata.name, the user can change this field.
@@ -44,6 +64,30 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef corev1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// Set while a canary deployment of the app is paused. The app processes keep running the stable revision
+	// and a single canary instance of the current revision is started beside them.
+	//+kubebuilder:validation:Optional
+	Canary *CanaryDeployment `json:"canary,omitempty"`
+
+	// The maximum number of instances of each process to be replaced at once when the app is deployed.
+	// Defaults to one instance at a time.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	MaxInFlight *int32 `json:"maxInFlight,omitempty"`
+
+	// Whether SSH access to the app instances is enabled. Defaults to enabled when not set.
+	//+kubebuilder:validation:Optional
+	EnableSSH *bool `json:"enableSSH,omitempty"`
+}
+
+type CanaryDeployment struct {
+	// The app-rev the app was running before the canary deployment started
+	StableRevision string `json:"stableRevision"`
+
+	// A reference to the CFBuild the app was running before the canary deployment started. The app goes back to
+	// it if the deployment is cancelled.
+	StableDropletRef corev1.LocalObjectReference `json:"stableDropletRef"`
 }
 
 // AppState defines the desired state of CFApp.
@@ -118,3 +162,21 @@ func (a CFApp) UniqueName() string {
 func (a CFApp) UniqueValidationErrorMessage() string {
 	return fmt.Sprintf("App with the name '%s' already exists.", a.Spec.DisplayName)
 }
+
+// LatestRevision returns the highest app-rev the app has been deployed at. It
+// is ahead of the app-rev when the last deployment has been cancelled, in which
+// case the app is back at the app-rev it was running before the deployment.
+func (a CFApp) LatestRevision() string {
+	appRev := a.Annotations[CFAppRevisionKey]
+
+	canceledRev, err := strconv.Atoi(a.Annotations[CFAppCanceledRevisionKey])
+	if err != nil {
+		return appRev
+	}
+
+	if rev, err := strconv.Atoi(appRev); err == nil && rev >= canceledRev {
+		return appRev
+	}
+
+	return strconv.Itoa(canceledRev)
+}
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CFAppRevisionDescriptionKey can be set on a CFApp to override the
+	// description of the revision that is created for its next app-rev
+	CFAppRevisionDescriptionKey = "korifi.cloudfoundry.org/app-rev-description"
+	// CFAppRollbackRevisionKey is set on a CFApp to the guid of the revision
+	// a rollback deploys. The CFApp controller restores the environment
+	// variables, process commands and user sidecars captured in the revision
+	// and removes it
+	CFAppRollbackRevisionKey = "korifi.cloudfoundry.org/rollback-revision"
+)
+
+type RevisionProcess struct {
+	// The name of the process within the CFApp (e.g. "web")
+	Type string `json:"type"`
+
+	// The custom command of the process at the time the revision was created
+	//+kubebuilder:validation:Optional
+	Command string `json:"command,omitempty"`
+}
+
+type RevisionSidecar struct {
+	// The guid of the sidecar
+	//+kubebuilder:validation:Optional
+	GUID string `json:"guid,omitempty"`
+
+	// The name of the sidecar
+	Name string `json:"name"`
+
+	// The command of the sidecar
+	Command string `json:"command"`
+
+	// The types of the processes the sidecar runs alongside
+	ProcessTypes []string `json:"processTypes"`
+
+	// The memory limit of the sidecar in MiB
+	//+kubebuilder:validation:Optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+
+	// Whether the sidecar was declared by a user or by a buildpack
+	//+kubebuilder:validation:Optional
+	Origin SidecarOrigin `json:"origin,omitempty"`
+}
+
+// CFAppRevisionSpec defines the desired state of CFAppRevision
+type CFAppRevisionSpec struct {
+	// A reference to the CFApp that owns this CFAppRevision. The CFApp must be in the same namespace.
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// The app-rev of the CFApp this revision has been created for
+	Version int64 `json:"version"`
+
+	// A reference to the CFBuild that was assigned to the app. The CFBuild must be in the same namespace.
+	DropletRef corev1.LocalObjectReference `json:"dropletRef"`
+
+	// The name of a Secret in the same namespace, which contains a snapshot of the app environment variables
+	//+kubebuilder:validation:Optional
+	EnvSecretName string `json:"envSecretName,omitempty"`
+
+	// The processes of the app at the time the revision was created
+	//+kubebuilder:validation:Optional
+	Processes []RevisionProcess `json:"processes,omitempty"`
+
+	// The sidecars of the app at the time the revision was created
+	//+kubebuilder:validation:Optional
+	Sidecars []RevisionSidecar `json:"sidecars,omitempty"`
+
+	// A short summary of what changed in this revision
+	//+kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Version",type=integer,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Droplet",type=string,JSONPath=`.spec.dropletRef.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppRevision is the Schema for the cfapprevisions API. Revisions are
+// created by the CFApp controller every time a started app gets a new app-rev,
+// i.e. on each deployment or restart, and are immutable.
+type CFAppRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAppRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppRevisionList contains a list of CFAppRevision
+type CFAppRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAppRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAppRevision{}, &CFAppRevisionList{})
+}
@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AppUsageStateStarted = "STARTED"
+	AppUsageStateStopped = "STOPPED"
+	AppUsageStateScaled  = "SCALED"
+)
+
+type UsageEventResource struct {
+	GUID string `json:"guid"`
+	//+kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+type UsageEventProcess struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+// CFAppUsageEventSpec defines the desired state of CFAppUsageEvent
+type CFAppUsageEventSpec struct {
+	// The state of the process when the event was emitted. One of `STARTED`, `STOPPED` or `SCALED`
+	//+kubebuilder:validation:Enum=STARTED;STOPPED;SCALED
+	State string `json:"state"`
+	// The state of the process before the event was emitted
+	//+kubebuilder:validation:Optional
+	PreviousState string `json:"previousState,omitempty"`
+
+	App          UsageEventResource `json:"app"`
+	Process      UsageEventProcess  `json:"process"`
+	Space        UsageEventResource `json:"space"`
+	Organization UsageEventResource `json:"organization"`
+
+	// The memory of each process instance when the event was emitted
+	MemoryInMBPerInstance int64 `json:"memoryInMBPerInstance"`
+	// The memory of each process instance before the event was emitted
+	//+kubebuilder:validation:Optional
+	PreviousMemoryInMBPerInstance int64 `json:"previousMemoryInMBPerInstance,omitempty"`
+
+	// The number of process instances when the event was emitted
+	InstanceCount int32 `json:"instanceCount"`
+	// The number of process instances before the event was emitted
+	//+kubebuilder:validation:Optional
+	PreviousInstanceCount int32 `json:"previousInstanceCount,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.app.name`
+//+kubebuilder:printcolumn:name="Process",type=string,JSONPath=`.spec.process.type`
+//+kubebuilder:printcolumn:name="Instances",type=integer,JSONPath=`.spec.instanceCount`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppUsageEvent is the Schema for the cfappusageevents API. App usage
+// events are emitted by the CFProcess controller in the root namespace
+// whenever a process starts, stops or is scaled. Their names are time
+// ordered, so that consumers can page through them by guid.
+type CFAppUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAppUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppUsageEventList contains a list of CFAppUsageEvent
+type CFAppUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAppUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAppUsageEvent{}, &CFAppUsageEventList{})
+}
@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CFAuditEventActor struct {
+	// The guid of the actor, e.g. the user name or the service account name
+	GUID string `json:"guid"`
+	// The type of the actor, e.g. `user`
+	Type string `json:"type"`
+	// The name of the actor
+	//+kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+type CFAuditEventTarget struct {
+	// The guid of the resource the event is about
+	GUID string `json:"guid"`
+	// The type of the resource the event is about, e.g. `app`
+	Type string `json:"type"`
+	// The name of the resource the event is about
+	//+kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+// CFAuditEventSpec defines the desired state of CFAuditEvent
+type CFAuditEventSpec struct {
+	// The CF type of the event, e.g. `audit.app.create`
+	Type string `json:"type"`
+
+	Actor  CFAuditEventActor  `json:"actor"`
+	Target CFAuditEventTarget `json:"target"`
+
+	// The guid of the space the target belongs to, if any
+	//+kubebuilder:validation:Optional
+	SpaceGUID string `json:"spaceGUID,omitempty"`
+
+	// The guid of the org the target belongs to, if any
+	//+kubebuilder:validation:Optional
+	OrgGUID string `json:"orgGUID,omitempty"`
+}
+
+// CFAuditEventStatus defines the observed state of CFAuditEvent
+type CFAuditEventStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFAuditEvent that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Actor",type=string,JSONPath=`.spec.actor.name`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target.guid`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEvent is the Schema for the cfauditevents API. Audit events are
+// recorded by the API for every successful mutating request. They live in
+// the namespace of the space the target belongs to (or the org, or the root
+// namespace for global resources) and are deleted once their retention
+// period is over.
+type CFAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFAuditEventSpec   `json:"spec,omitempty"`
+	Status CFAuditEventStatus `json:"status,omitempty"`
+}
+
+func (e *CFAuditEvent) StatusConditions() *[]metav1.Condition {
+	return &e.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEventList contains a list of CFAuditEvent
+type CFAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAuditEvent{}, &CFAuditEventList{})
+}
@@ -63,6 +63,23 @@ type BuildDropletStatus struct {
 	// The exposed ports for the application
 	//+kubebuilder:validation:Optional
 	Ports []int32 `json:"ports"`
+
+	// The sidecars declared by the buildpacks that built the Droplet
+	//+kubebuilder:validation:Optional
+	Sidecars []DropletSidecar `json:"sidecars,omitempty"`
+}
+
+// DropletSidecar is a sidecar declared by a buildpack
+type DropletSidecar struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+
+	// The types of the processes the sidecar runs alongside
+	ProcessTypes []string `json:"processTypes"`
+
+	// The memory limit of the sidecar in MiB
+	//+kubebuilder:validation:Optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
 }
 
 // ProcessType is a map of process names and associated start commands for the Droplet
@@ -28,6 +28,10 @@ const (
 type CFDomainSpec struct {
 	// The domain name. It is required and must conform to RFC 1035
 	Name string `json:"name"`
+	// The name of the router group of the domain. Domains with a router group are tcp domains, their routes are
+	// identified by a port rather than by a host and a path
+	//+kubebuilder:validation:Optional
+	RouterGroup string `json:"routerGroup,omitempty"`
 }
 
 // CFDomainStatus defines the observed state of CFDomain
@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFDropletFinalizerName = "korifi.cloudfoundry.org/cfDropletController"
+)
+
+// CFDropletSpec defines the desired state of CFDroplet
+type CFDropletSpec struct {
+	// The CFApp associated with this droplet. Must be in the same namespace
+	AppRef v1.LocalObjectReference `json:"appRef"`
+
+	// The lifecycle of the app at the time the droplet was created
+	Lifecycle Lifecycle `json:"lifecycle"`
+
+	// The process types and associated start commands for the droplet
+	//+kubebuilder:validation:Optional
+	ProcessTypes []ProcessType `json:"processTypes,omitempty"`
+
+	// The exposed ports for the application
+	//+kubebuilder:validation:Optional
+	Ports []int32 `json:"ports,omitempty"`
+
+	// The container image of the droplet, and secrets to access it. Unset
+	// until the droplet bits have been uploaded
+	//+kubebuilder:validation:Optional
+	Registry *Registry `json:"registry,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="AppGUID",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.registry.image`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFDroplet is the Schema for the cfdroplets API. Unlike the droplets
+// produced by staging a CFBuild, CFDroplets are created via the API and
+// their image is uploaded or copied from another droplet.
+type CFDroplet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFDropletSpec `json:"spec,omitempty"`
+}
+
+// DropletStatus returns the droplet in the same shape as a staged CFBuild
+// droplet, or nil if the droplet bits have not been uploaded yet
+func (d *CFDroplet) DropletStatus() *BuildDropletStatus {
+	if d.Spec.Registry == nil {
+		return nil
+	}
+
+	return &BuildDropletStatus{
+		Registry:     *d.Spec.Registry,
+		Stack:        d.Spec.Lifecycle.Data.Stack,
+		ProcessTypes: d.Spec.ProcessTypes,
+		Ports:        d.Spec.Ports,
+	}
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFDropletList contains a list of CFDroplet
+type CFDropletList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFDroplet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFDroplet{}, &CFDropletList{})
+}
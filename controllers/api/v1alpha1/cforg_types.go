@@ -37,6 +37,10 @@ type CFOrgSpec struct {
 	// The mutable, user-friendly name of the CFOrg. Unlike metadata.name, the user can change this field.
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUID of the CFOrgQuota applied to the org. The org is unlimited when not set
+	//+kubebuilder:validation:Optional
+	QuotaGUID string `json:"quotaGuid,omitempty"`
 }
 
 // CFOrgStatus defines the observed state of CFOrg
@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QuotaAppLimits are the limits applying to the processes and tasks of all
+// the apps in the org or space. A limit that is not set is unlimited.
+type QuotaAppLimits struct {
+	// Total memory of all the started process instances
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	TotalMemoryInMB *int64 `json:"totalMemoryInMB,omitempty"`
+
+	// Maximum memory of a single process instance
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	PerProcessMemoryInMB *int64 `json:"perProcessMemoryInMB,omitempty"`
+
+	// Total number of started process instances
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	TotalInstances *int64 `json:"totalInstances,omitempty"`
+
+	// Maximum number of tasks of a single app that are not yet completed
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	PerAppTasks *int64 `json:"perAppTasks,omitempty"`
+
+	// Total log rate of all the started process instances. Korifi processes
+	// have no log rate limit of their own, so while this limit is set no
+	// process instances can be started.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	LogRateLimitInBytesPerSecond *int64 `json:"logRateLimitInBytesPerSecond,omitempty"`
+}
+
+type QuotaServiceLimits struct {
+	// Total number of service instances
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	TotalServiceInstances *int64 `json:"totalServiceInstances,omitempty"`
+}
+
+type QuotaRouteLimits struct {
+	// Total number of routes
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	TotalRoutes *int64 `json:"totalRoutes,omitempty"`
+}
+
+type QuotaLimits struct {
+	//+kubebuilder:validation:Optional
+	Apps QuotaAppLimits `json:"apps,omitempty"`
+	//+kubebuilder:validation:Optional
+	Services QuotaServiceLimits `json:"services,omitempty"`
+	//+kubebuilder:validation:Optional
+	Routes QuotaRouteLimits `json:"routes,omitempty"`
+}
+
+// CFOrgQuotaSpec defines the desired state of CFOrgQuota
+type CFOrgQuotaSpec struct {
+	// The mutable, user-friendly name of the quota
+	DisplayName string `json:"displayName"`
+
+	QuotaLimits `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFOrgQuota is the Schema for the cforgquotas API. Org quotas live in the
+// root namespace and are applied to orgs via CFOrg.Spec.QuotaGUID
+type CFOrgQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFOrgQuotaSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFOrgQuotaList contains a list of CFOrgQuota
+type CFOrgQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFOrgQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFOrgQuota{}, &CFOrgQuotaList{})
+}
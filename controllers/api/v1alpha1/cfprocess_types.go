@@ -23,6 +23,11 @@ import (
 
 const (
 	ProcessTypeWeb = "web"
+
+	CFProcessFinalizerName = "cfProcess.korifi.cloudfoundry.org"
+
+	SidecarOriginUser      SidecarOrigin = "user"
+	SidecarOriginBuildpack SidecarOrigin = "buildpack"
 )
 
 // CFProcessSpec defines the desired state of CFProcess
@@ -55,8 +60,36 @@ type CFProcessSpec struct {
 	// Deprecated: No longer used
 	// +kubebuilder:validation:Optional
 	Ports []int32 `json:"ports,omitempty"`
+
+	// Additional commands that run alongside the process in each of its instances
+	// +kubebuilder:validation:Optional
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
 }
 
+type Sidecar struct {
+	// The guid of the sidecar. A sidecar that applies to several processes of an app has the same guid in each of them
+	GUID string `json:"guid"`
+
+	// The name of the sidecar, unique within the app
+	Name string `json:"name"`
+
+	// The command used to start the sidecar
+	Command string `json:"command"`
+
+	// The memory of the sidecar in MiB, it is carved out of the memory of the process. When not set, the sidecar shares
+	// the memory of the process that is not reserved by other sidecars evenly with the process and the other sidecars
+	// that do not set it
+	// +kubebuilder:validation:Optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+
+	// Whether the sidecar was declared by a user or by a buildpack. Buildpack sidecars are managed by the CFApp controller
+	Origin SidecarOrigin `json:"origin"`
+}
+
+// SidecarOrigin is where a sidecar has been declared
+// +kubebuilder:validation:Enum=user;buildpack
+type SidecarOrigin string
+
 type HealthCheck struct {
 	// The type of Health Check the App process will use
 	// Valid values are "http", "port", and "process".
@@ -93,6 +126,19 @@ type CFProcessStatus struct {
 
 	//+kubebuilder:validation:Optional
 	InstancesStatus map[string]InstanceStatus `json:"instancesStatus"`
+
+	// The usage of the process as recorded by the last app usage event
+	//+kubebuilder:validation:Optional
+	Usage *ProcessUsage `json:"usage,omitempty"`
+}
+
+type ProcessUsage struct {
+	// The usage state of the process, `STARTED` or `STOPPED`
+	State string `json:"state"`
+	//+kubebuilder:validation:Optional
+	MemoryMB int64 `json:"memoryMB"`
+	//+kubebuilder:validation:Optional
+	Instances int32 `json:"instances"`
 }
 
 //+kubebuilder:object:root=true
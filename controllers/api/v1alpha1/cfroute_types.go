@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
@@ -42,16 +43,28 @@ type Destination struct {
 	AppRef v1.LocalObjectReference `json:"appRef"`
 	// The process type on the CFApp app which will receive traffic
 	ProcessType string `json:"processType"`
-	// Protocol is optional, when set must be "http1"
-	// +kubebuilder:validation:Enum=http1
+	// Protocol is optional, when set must be "http1" for http routes or "tcp" for tcp routes
+	// +kubebuilder:validation:Enum=http1;tcp
 	//+kubebuilder:validation:Optional
 	Protocol *string `json:"protocol,omitempty"`
+	// The percentage of the route traffic the destination receives. Weight is optional, when set on any of the
+	// route destinations it must be set on all of them and the weights must total 100. Removing weighted destinations
+	// splits the traffic among the remaining ones in proportion to their weights
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	Weight *int32 `json:"weight,omitempty"`
 }
 
 // Protocol defines the transport protocol of the route
 // +kubebuilder:validation:Enum=http;tcp
 type Protocol string
 
+const (
+	RouteProtocolHTTP Protocol = "http"
+	RouteProtocolTCP  Protocol = "tcp"
+)
+
 // CFRouteSpec defines the desired state of CFRoute
 type CFRouteSpec struct {
 	// The subdomain of the route within the domain. Host is optional and defaults to empty.
@@ -59,8 +72,17 @@ type CFRouteSpec struct {
 	Host string `json:"host,omitempty"`
 	// Path is optional, defaults to empty
 	Path string `json:"path,omitempty"`
-	// Protocol is optional and defaults to http. Currently only http is supported
+	// Protocol is optional and defaults to http. tcp routes are only supported on domains with a router group
 	Protocol Protocol `json:"protocol,omitempty"`
+	// The port the route listens on. Port is required for tcp routes and must not be set for http routes
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+	// The router group of the domain of a tcp route. All the domains of a router group share its ports, so the
+	// port of a tcp route is unique within its router group. Required for tcp routes and immutable
+	//+kubebuilder:validation:Optional
+	RouterGroup string `json:"routerGroup,omitempty"`
 	// A reference to the CFDomain this CFRoute is assigned to, including name and namespace
 	DomainRef v1.ObjectReference `json:"domainRef"`
 	// Destinations are optional. A route can exist without any destinations, independently of any CFApps
@@ -115,10 +137,18 @@ func init() {
 }
 
 func (r CFRoute) UniqueName() string {
+	if r.Spec.Port != nil {
+		return strings.Join([]string{"tcp", r.Spec.RouterGroup, strconv.Itoa(int(*r.Spec.Port))}, "::")
+	}
+
 	return strings.Join([]string{strings.ToLower(r.Spec.Host), r.Spec.DomainRef.Namespace, r.Spec.DomainRef.Name, r.Spec.Path}, "::")
 }
 
 func (r CFRoute) UniqueValidationErrorMessage() string {
+	if r.Spec.Port != nil {
+		return fmt.Sprintf("Port %d is not available on this domain's router group.", *r.Spec.Port)
+	}
+
 	pathDetails := ""
 
 	if r.Spec.Path != "" {
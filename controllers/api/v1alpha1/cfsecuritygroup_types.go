@@ -11,6 +11,16 @@ const (
 	ProtocolTCP = "tcp"
 	ProtocolUDP = "udp"
 	ProtocolALL = "all"
+
+	CFSecurityGroupFinalizerName = "cfSecurityGroup.korifi.cloudfoundry.org"
+
+	CFSecurityGroupGUIDLabelKey         = "korifi.cloudfoundry.org/security-group-guid"
+	CFSecurityGroupWorkloadTypeLabelKey = "korifi.cloudfoundry.org/security-group-workload-type"
+
+	SecurityGroupRunningWorkloadType = "running"
+	SecurityGroupStagingWorkloadType = "staging"
+
+	SecurityGroupRulesValidConditionType = "RulesValid"
 )
 
 type SecurityGroupRule struct {
@@ -14,6 +14,11 @@ type CFServiceOfferingSpec struct {
 	// +kubebuilder:validation:Optional
 	DocumentationURL *string              `json:"documentationUrl"`
 	BrokerCatalog    ServiceBrokerCatalog `json:"brokerCatalog"`
+
+	// Set when the offering has been removed from the broker catalog while
+	// some of its plans are still in use by service instances
+	// +kubebuilder:validation:Optional
+	Unavailable bool `json:"unavailable,omitempty"`
 }
 
 type ServiceBrokerCatalog struct {
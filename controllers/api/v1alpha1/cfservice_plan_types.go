@@ -13,6 +13,12 @@ type CFServicePlanSpec struct {
 	Schemas         ServicePlanSchemas       `json:"schemas"`
 	MaintenanceInfo MaintenanceInfo          `json:"maintenanceInfo"`
 	Visibility      ServicePlanVisibility    `json:"visibility"`
+
+	// Set when the plan has been removed from the broker catalog while
+	// service instances still use it. No new instances can be created for
+	// unavailable plans
+	// +kubebuilder:validation:Optional
+	Unavailable bool `json:"unavailable,omitempty"`
 }
 
 type ServicePlanBrokerCatalog struct {
@@ -21,6 +27,10 @@ type ServicePlanBrokerCatalog struct {
 	Metadata *runtime.RawExtension `json:"metadata,omitempty"`
 	// +kubebuilder:validation:Optional
 	Features ServicePlanFeatures `json:"features"`
+	// The maximum duration in seconds asynchronous operations on instances of
+	// the plan are polled for, as advertised by the broker catalog
+	// +kubebuilder:validation:Optional
+	MaximumPollingDuration *int32 `json:"maximumPollingDuration,omitempty"`
 }
 
 type InputParameterSchema struct {
@@ -43,8 +53,14 @@ type ServicePlanSchemas struct {
 }
 
 type ServicePlanFeatures struct {
+	// Whether instances can change to another plan. Inherited from the
+	// service offering unless the broker catalog sets it on the plan
 	PlanUpdateable bool `json:"planUpdateable"`
 	Bindable       bool `json:"bindable"`
+	// Whether the credentials of bindings to instances of the plan can be
+	// rotated by creating a new binding with the current one as predecessor
+	// +optional
+	BindingRotatable bool `json:"bindingRotatable,omitempty"`
 }
 
 type VisibilityOrganization struct {
@@ -60,13 +76,18 @@ const (
 	AdminServicePlanVisibilityType        = "admin"
 	PublicServicePlanVisibilityType       = "public"
 	OrganizationServicePlanVisibilityType = "organization"
+	SpaceServicePlanVisibilityType        = "space"
 )
 
 type ServicePlanVisibility struct {
-	// +kubebuilder:validation:Enum=admin;public;organization
+	// +kubebuilder:validation:Enum=admin;public;organization;space
 	Type string `json:"type"`
 	// +kubebuilder:validation:Optional
 	Organizations []string `json:"organizations,omitempty"`
+	// The GUID of the space the plan is visible in. Only set for plans of
+	// space-scoped service brokers
+	// +kubebuilder:validation:Optional
+	Space string `json:"space,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -28,14 +28,22 @@ const (
 
 	UnbindingFailedCondition = "UnbindingFailed"
 
-	CFServiceBindingTypeKey = "key"
-	CFServiceBindingTypeApp = "app"
+	CFServiceBindingTypeKey   = "key"
+	CFServiceBindingTypeApp   = "app"
+	CFServiceBindingTypeRoute = "route"
+
+	CredentialsRotationAppModeNone    = "none"
+	CredentialsRotationAppModeRestart = "restart"
 
 	ServiceInstanceTypeAnnotation = "korifi.cloudfoundry.org/service-instance-type"
 	PlanGUIDLabelKey              = "korifi.cloudfoundry.org/plan-guid"
 
 	ServiceBindingGUIDLabel       = "korifi.cloudfoundry.org/service-binding-guid"
 	CFServiceBindingFinalizerName = "cfServiceBinding.korifi.cloudfoundry.org"
+
+	// Comma separated list of CFServiceBinding GUIDs whose credentials are
+	// projected into the pod template of the annotated Deployment
+	ServiceBindingsProjectionAnnotation = "korifi.cloudfoundry.org/service-bindings"
 )
 
 // CFServiceBindingSpec defines the desired state of CFServiceBinding
@@ -43,19 +51,49 @@ type CFServiceBindingSpec struct {
 	// The mutable, user-friendly name of the service binding. Unlike metadata.name, the user can change this field
 	DisplayName *string `json:"displayName,omitempty"`
 
-	// The Service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance
+	// The Service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance.
+	// The namespace is only set when the service instance has been shared from another namespace
 	Service v1.ObjectReference `json:"service"`
 
 	// A reference to the CFApp that owns this service binding. The CFApp must be in the same namespace
 	AppRef v1.LocalObjectReference `json:"appRef"`
 
+	// A reference to the CFRoute whose traffic is forwarded to the route
+	// service. Only makes sense for bindings of type "route". The CFRoute must
+	// be in the same namespace
+	// +optional
+	RouteRef v1.LocalObjectReference `json:"routeRef,omitempty"`
+
 	// A reference to the secret that contains the service binding parameters.
 	// Only makes sense for bindings to managed service instances
 	Parameters v1.LocalObjectReference `json:"parameters"`
 
-	// The type of the binding. There are two possible values - "key" or "app"
-	// +kubebuilder:validation:Enum=app;key
+	// The type of the binding. There are three possible values - "key", "app" or "route"
+	// +kubebuilder:validation:Enum=app;key;route
 	Type string `json:"type"`
+
+	// Requests the binding credentials to be rotated. Setting a new ID makes
+	// the controller create a new broker binding with the current one as its
+	// predecessor, switch the credentials to the ones of the new broker
+	// binding and unbind the predecessor. Only makes sense for bindings to
+	// managed service instances whose plan is binding_rotatable
+	// +optional
+	CredentialsRotation *CredentialsRotation `json:"credentialsRotation,omitempty"`
+}
+
+type CredentialsRotation struct {
+	// A unique identifier of the rotation request
+	ID string `json:"id"`
+
+	// What happens to the bound app once the credentials have been rotated.
+	// "none" leaves the app running and it picks up the new credentials on
+	// its next restart; "restart" rolls the app instances so that they get
+	// the new credentials without downtime. Only makes sense for bindings of
+	// type "app"
+	// +kubebuilder:validation:Enum=none;restart
+	// +kubebuilder:default=none
+	// +optional
+	AppMode string `json:"appMode,omitempty"`
 }
 
 // CFServiceBindingStatus defines the observed state of CFServiceBinding
@@ -76,6 +114,31 @@ type CFServiceBindingStatus struct {
 	// +optional
 	EnvSecretRef v1.LocalObjectReference `json:"envSecretRef"`
 
+	// The URL of the route service that traffic to the bound route is
+	// forwarded to. Only set for bindings of type "route"
+	// +optional
+	RouteServiceURL string `json:"routeServiceUrl,omitempty"`
+
+	// The ID of the latest credentials rotation that has been completed
+	// +optional
+	CredentialsRotationID string `json:"credentialsRotationId,omitempty"`
+
+	// The ID the broker knows the binding by. Empty until the binding
+	// credentials are rotated for the first time, meaning that the broker
+	// binding ID is the binding name
+	// +optional
+	BrokerBindingID string `json:"brokerBindingId,omitempty"`
+
+	// The ID of the broker binding replaced by the latest credentials
+	// rotation. It is unbound once the binding credentials have been switched
+	// to the ones of its successor
+	// +optional
+	PredecessorBrokerBindingID string `json:"predecessorBrokerBindingId,omitempty"`
+
+	// The asynchronous bind or unbind operation that is currently being polled
+	// +optional
+	AsyncOperation *AsyncOperation `json:"asyncOperation,omitempty"`
+
 	//+kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -113,11 +176,29 @@ func (b *CFServiceBinding) StatusConditions() *[]metav1.Condition {
 	return &b.Status.Conditions
 }
 
+// ServiceInstanceNamespace returns the namespace of the service instance the
+// binding refers to
+func (b CFServiceBinding) ServiceInstanceNamespace() string {
+	if b.Spec.Service.Namespace != "" {
+		return b.Spec.Service.Namespace
+	}
+
+	return b.Namespace
+}
+
 func (b CFServiceBinding) UniqueName() string {
+	if b.Spec.Type == CFServiceBindingTypeRoute {
+		return fmt.Sprintf("sb::route::%s", b.Spec.RouteRef.Name)
+	}
+
 	return fmt.Sprintf("sb::%s::%s::%s", b.Spec.AppRef.Name, b.Spec.Service.Namespace, b.Spec.Service.Name)
 }
 
 func (b CFServiceBinding) UniqueValidationErrorMessage() string {
+	if b.Spec.Type == CFServiceBindingTypeRoute {
+		return "A route may only be bound to a single service instance"
+	}
+
 	return fmt.Sprintf("Service binding already exists: App: %s Service Instance: %s", b.Spec.AppRef.Name, b.Spec.Service.Name)
 }
 
@@ -8,14 +8,50 @@ import (
 )
 
 const (
-	UsernameCredentialsKey = "username"
-	PasswordCredentialsKey = "password"
+	UsernameCredentialsKey      = "username"
+	PasswordCredentialsKey      = "password"
+	TokenCredentialsKey         = "token"
+	CertificateCredentialsKey   = "certificate"
+	PrivateKeyCredentialsKey    = "private_key"
+	CACertificateCredentialsKey = "ca_certificate"
+
+	BasicAuthenticationType             = "basic"
+	BearerAuthenticationType            = "bearer"
+	ClientCertificateAuthenticationType = "client_certificate"
+
+	CatalogDriftCondition = "CatalogDrift"
 )
 
 type CFServiceBrokerSpec struct {
-	Name        string                      `json:"name"`
-	URL         string                      `json:"url"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+
+	// The way Korifi authenticates against the broker. The credentials secret
+	// is expected to contain username and password for basic, token for bearer
+	// and certificate, private_key and optionally ca_certificate for
+	// client_certificate authentication
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=basic;bearer;client_certificate
+	//+kubebuilder:default=basic
+	AuthenticationType string `json:"authenticationType,omitempty"`
+
 	Credentials corev1.LocalObjectReference `json:"credentials"`
+
+	// The timeout of requests to the broker. Defaults to 60 seconds
+	//+kubebuilder:validation:Optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+
+	// The maximum duration asynchronous operations of the broker are polled
+	// for before they are considered failed. Plans can override it via the
+	// maximum_polling_duration catalog field. Defaults to 7 days
+	//+kubebuilder:validation:Optional
+	MaximumPollingDuration *metav1.Duration `json:"maximumPollingDuration,omitempty"`
+
+	// Requests an immediate refresh of the broker catalog. Setting a new ID
+	// makes the controller fetch the catalog again, regardless of the
+	// periodic catalog refresh
+	//+kubebuilder:validation:Optional
+	CatalogRefreshRequestID string `json:"catalogRefreshRequestId,omitempty"`
 }
 
 type CFServiceBrokerStatus struct {
@@ -33,6 +33,7 @@ const (
 
 	ProvisioningFailedCondition   = "ProvisioningFailed"
 	DeprovisioningFailedCondition = "DeprovisioningFailed"
+	UpdateFailedCondition         = "UpdateFailed"
 )
 
 // CFServiceInstanceSpec defines the desired state of CFServiceInstance
@@ -58,6 +59,21 @@ type CFServiceInstanceSpec struct {
 	PlanGUID string `json:"planGuid"`
 
 	Parameters corev1.LocalObjectReference `json:"parameters,omitempty"`
+
+	// The maintenance info version the service instance should be upgraded
+	// to. Only makes sense for managed service instances
+	// +optional
+	MaintenanceInfo MaintenanceInfo `json:"maintenanceInfo,omitempty"`
+
+	// The URL of the route service that traffic to bound routes is forwarded
+	// to. Only makes sense for user-provided service instances
+	// +optional
+	RouteServiceURL *string `json:"routeServiceUrl,omitempty"`
+
+	// The GUIDs of the spaces the service instance is shared with. Apps in
+	// these spaces can bind to the service instance
+	// +optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
@@ -85,6 +101,10 @@ type CFServiceInstanceStatus struct {
 	//+kubebuilder:validation:Optional
 	LastOperation LastOperation `json:"lastOperation"`
 
+	// The asynchronous broker operation that is currently being polled
+	//+kubebuilder:validation:Optional
+	AsyncOperation *AsyncOperation `json:"asyncOperation,omitempty"`
+
 	// The service instance maintenance info. Only makes seense for managed service instances
 	//+kubebuilder:validation:Optional
 	MaintenanceInfo MaintenanceInfo `json:"maintenanceInfo"`
@@ -92,6 +112,27 @@ type CFServiceInstanceStatus struct {
 	// True if there is an upgrade available for for the service instance (i.e. the plan has a new version). Only makes seense for managed service instances
 	//+kubebuilder:validation:Optional
 	UpgradeAvailable bool `json:"upgradeAvailable"`
+
+	// The service plan the service instance has last been provisioned or updated with by the broker. Only makes sense for managed service instances
+	//+kubebuilder:validation:Optional
+	PlanGUID string `json:"planGuid,omitempty"`
+
+	// A reference to the parameters secret that has last been sent to the broker. Only makes sense for managed service instances
+	//+kubebuilder:validation:Optional
+	Parameters corev1.LocalObjectReference `json:"parameters"`
+
+	// The service instance as recorded by the last service usage event
+	//+kubebuilder:validation:Optional
+	Usage *ServiceInstanceUsage `json:"usage,omitempty"`
+}
+
+type ServiceInstanceUsage struct {
+	// The usage state of the service instance, `CREATED` or `DELETED`
+	State string `json:"state"`
+	//+kubebuilder:validation:Optional
+	Name string `json:"name"`
+	//+kubebuilder:validation:Optional
+	PlanGUID string `json:"planGuid"`
 }
 
 type LastOperation struct {
@@ -104,6 +145,16 @@ type LastOperation struct {
 	Description string `json:"description"`
 }
 
+// AsyncOperation tracks an asynchronous operation accepted by a service broker
+type AsyncOperation struct {
+	// +kubebuilder:validation:Enum=create;update;delete
+	Type string `json:"type"`
+
+	// The time the broker has accepted the operation. Operations that have not
+	// completed within the maximum polling duration are considered failed
+	StartedAt metav1.Time `json:"startedAt"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
@@ -0,0 +1,70 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ServiceUsageStateCreated = "CREATED"
+	ServiceUsageStateDeleted = "DELETED"
+	ServiceUsageStateUpdated = "UPDATED"
+)
+
+type UsageEventServiceInstance struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	// The type of the service instance, `managed` or `user-provided`
+	Type InstanceType `json:"type"`
+}
+
+// CFServiceUsageEventSpec defines the desired state of CFServiceUsageEvent
+type CFServiceUsageEventSpec struct {
+	// The state of the service instance when the event was emitted. One of `CREATED`, `DELETED` or `UPDATED`
+	//+kubebuilder:validation:Enum=CREATED;DELETED;UPDATED
+	State string `json:"state"`
+
+	ServiceInstance UsageEventServiceInstance `json:"serviceInstance"`
+	Space           UsageEventResource        `json:"space"`
+	Organization    UsageEventResource        `json:"organization"`
+
+	// The plan of the service instance. Not set for user-provided service instances
+	//+kubebuilder:validation:Optional
+	ServicePlan *UsageEventResource `json:"servicePlan,omitempty"`
+	// The offering of the service instance. Not set for user-provided service instances
+	//+kubebuilder:validation:Optional
+	ServiceOffering *UsageEventResource `json:"serviceOffering,omitempty"`
+	// The broker of the service instance. Not set for user-provided service instances
+	//+kubebuilder:validation:Optional
+	ServiceBroker *UsageEventResource `json:"serviceBroker,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.serviceInstance.name`
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.serviceInstance.type`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceUsageEvent is the Schema for the cfserviceusageevents API. Service
+// usage events are emitted by the CFServiceInstance controllers in the root
+// namespace whenever a service instance is created, updated or deleted.
+type CFServiceUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServiceUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceUsageEventList contains a list of CFServiceUsageEvent
+type CFServiceUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceUsageEvent{}, &CFServiceUsageEventList{})
+}
@@ -35,6 +35,14 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUID of the CFSpaceQuota applied to the space. The space is unlimited when not set
+	//+kubebuilder:validation:Optional
+	QuotaGUID string `json:"quotaGuid,omitempty"`
+
+	// Whether SSH access to the app instances in the space is allowed. Defaults to allowed when not set
+	//+kubebuilder:validation:Optional
+	AllowSSH *bool `json:"allowSSH,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
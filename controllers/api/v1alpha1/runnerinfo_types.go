@@ -40,6 +40,8 @@ type RunnerInfoStatus struct {
 
 type RunnerInfoCapabilities struct {
 	RollingDeploy bool `json:"rollingDeploy,omitempty"`
+	// Whether deployments can replace more than one instance of a process at once
+	MaxInFlight bool `json:"maxInFlight,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -28,6 +28,10 @@ const (
 
 	PodIndexLabelKey = "apps.kubernetes.io/pod-index"
 
+	// OriginatingIdentityAnnotation holds the CF user that triggered the last
+	// broker operation on a service instance or binding
+	OriginatingIdentityAnnotation = "korifi.cloudfoundry.org/originating-identity"
+
 	StagingConditionType   = "Staging"
 	SucceededConditionType = "Succeeded"
 
@@ -36,11 +40,12 @@ const (
 	PropagateDeletionAnnotation       = "cloudfoundry.org/propagate-deletion"
 	PropagatedFromLabel               = "cloudfoundry.org/propagated-from"
 
-	RelationshipsLabelPrefix    = "korifi.cloudfoundry.org/rel-"
-	RelServiceBrokerGUIDLabel   = RelationshipsLabelPrefix + "service-broker-guid"
-	RelServiceBrokerNameLabel   = RelationshipsLabelPrefix + "service-broker-name"
-	RelServiceOfferingGUIDLabel = RelationshipsLabelPrefix + "service-offering-guid"
-	RelServiceOfferingNameLabel = RelationshipsLabelPrefix + "service-offering-name"
+	RelationshipsLabelPrefix       = "korifi.cloudfoundry.org/rel-"
+	RelServiceBrokerGUIDLabel      = RelationshipsLabelPrefix + "service-broker-guid"
+	RelServiceBrokerNameLabel      = RelationshipsLabelPrefix + "service-broker-name"
+	RelServiceBrokerSpaceGUIDLabel = RelationshipsLabelPrefix + "service-broker-space-guid"
+	RelServiceOfferingGUIDLabel    = RelationshipsLabelPrefix + "service-offering-guid"
+	RelServiceOfferingNameLabel    = RelationshipsLabelPrefix + "service-offering-name"
 
 	InstanceStateDown     InstanceState = "DOWN"
 	InstanceStateCrashed  InstanceState = "CRASHED"
@@ -101,11 +101,13 @@ var _ = BeforeSuite(func() {
 	Expect(korifiv1alpha1.NewCFAppDefaulter().SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(apps.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType)),
+		validation.NewQuotaValidator(uncachedClient, namespace),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(korifiv1alpha1.NewCFRouteDefaulter().SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(routes.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routes.RouteEntityType)),
+		validation.NewQuotaValidator(uncachedClient, namespace),
 		namespace,
 		uncachedClient,
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
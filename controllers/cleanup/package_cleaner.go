@@ -34,9 +34,10 @@ func (c PackageCleaner) Clean(ctx context.Context, app types.NamespacedName) err
 			Namespace: app.Namespace,
 			Name:      cfApp.Spec.CurrentDropletRef.Name,
 		}, &cfBuild)
-		if err != nil {
+		if client.IgnoreNotFound(err) != nil {
 			return err
 		}
+		// droplets uploaded via the API have no build and therefore no package
 		currentPackage = cfBuild.Spec.PackageRef.Name
 	}
 
@@ -110,6 +110,33 @@ var _ = Describe("PackageCleaner", func() {
 			Expect(pkgDeletable).To(BeNotFound())
 		})
 	})
+
+	When("the current droplet of the app has been uploaded", func() {
+		BeforeEach(func() {
+			dropletGUID := uuid.NewString()
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFDroplet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: dropletGUID},
+				Spec: korifiv1alpha1.CFDropletSpec{
+					AppRef:    corev1.LocalObjectReference{Name: appGUID},
+					Lifecycle: korifiv1alpha1.Lifecycle{Type: "buildpack"},
+				},
+			})).To(Succeed())
+
+			cfApp.Spec.CurrentDropletRef = corev1.LocalObjectReference{Name: dropletGUID}
+			Expect(k8sClient.Update(ctx, cfApp)).To(Succeed())
+		})
+
+		It("deletes the two oldest packages", func() {
+			Expect(cleanErr).NotTo(HaveOccurred())
+
+			Expect(pkgOtherApp).To(BeFound())
+			Expect(pkgNotReady).To(BeFound())
+			Expect(pkgReady).To(BeFound())
+
+			Expect(pkgCurrent).To(BeNotFound())
+			Expect(pkgDeletable).To(BeNotFound())
+		})
+	})
 })
 
 func createPackage(namespace, appGUID, name string) *korifiv1alpha1.CFPackage {
@@ -15,6 +15,7 @@ type ControllerConfig struct {
 	CFRootNamespace                  string             `yaml:"cfRootNamespace"`
 	ContainerRegistrySecretNames     []string           `yaml:"containerRegistrySecretNames"`
 	TaskTTL                          string             `yaml:"taskTTL"`
+	AuditEventRetention              string             `yaml:"auditEventRetention"`
 	BuilderName                      string             `yaml:"builderName"`
 	RunnerName                       string             `yaml:"runnerName"`
 	NamespaceLabels                  map[string]string  `yaml:"namespaceLabels"`
@@ -26,9 +27,13 @@ type ControllerConfig struct {
 
 	Networking Networking `yaml:"networking"`
 
-	ExperimentalManagedServicesEnabled bool `yaml:"experimentalManagedServicesEnabled"`
-	TrustInsecureServiceBrokers        bool `yaml:"trustInsecureServiceBrokers"`
-	DisableRouteController             bool `yaml:"disableRouteController"`
+	ExperimentalManagedServicesEnabled  bool   `yaml:"experimentalManagedServicesEnabled"`
+	TrustInsecureServiceBrokers         bool   `yaml:"trustInsecureServiceBrokers"`
+	ServiceBrokerCatalogRefreshInterval string `yaml:"serviceBrokerCatalogRefreshInterval"`
+	DisableRouteController              bool   `yaml:"disableRouteController"`
+	ExperimentalSecurityGroupsEnabled   bool   `yaml:"experimentalSecurityGroupsEnabled"`
+
+	ExperimentalServiceBindingProjectionEnabled bool `yaml:"experimentalServiceBindingProjectionEnabled"`
 }
 
 type CFProcessDefaults struct {
@@ -44,15 +49,28 @@ type CFStagingResources struct {
 }
 
 type Networking struct {
-	GatewayName      string `yaml:"gatewayName"`
-	GatewayNamespace string `yaml:"gatewayNamespace"`
+	GatewayName       string            `yaml:"gatewayName"`
+	GatewayNamespace  string            `yaml:"gatewayNamespace"`
+	RouteServiceProxy RouteServiceProxy `yaml:"routeServiceProxy"`
+}
+
+// RouteServiceProxy configures the proxy that signs the traffic sent to route
+// services and verifies the signature of the traffic they send back
+type RouteServiceProxy struct {
+	// Host is the in-cluster host name the gateway reaches the proxy at
+	Host           string `yaml:"host"`
+	Port           int32  `yaml:"port"`
+	SigningKeyPath string `yaml:"signingKeyPath"`
+	SignatureTTL   string `yaml:"signatureTTL"`
 }
 
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int32 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL                        = 30 * 24 * time.Hour
+	defaultAuditEventRetention            = 31 * 24 * time.Hour
+	defaultTimeout                  int32 = 60
+	defaultJobTTL                         = 24 * time.Hour
+	defaultBuildCacheMB                   = 2048
+	defaultRouteServiceSignatureTTL       = 60 * time.Second
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -93,3 +111,29 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 
 	return tools.ParseDuration(c.TaskTTL)
 }
+
+func (c ControllerConfig) ParseAuditEventRetention() (time.Duration, error) {
+	if c.AuditEventRetention == "" {
+		return defaultAuditEventRetention, nil
+	}
+
+	return tools.ParseDuration(c.AuditEventRetention)
+}
+
+// ParseServiceBrokerCatalogRefreshInterval returns zero when the interval is
+// not set, which disables the periodic broker catalog refresh
+func (c ControllerConfig) ParseServiceBrokerCatalogRefreshInterval() (time.Duration, error) {
+	if c.ServiceBrokerCatalogRefreshInterval == "" {
+		return 0, nil
+	}
+
+	return tools.ParseDuration(c.ServiceBrokerCatalogRefreshInterval)
+}
+
+func (c ControllerConfig) ParseRouteServiceSignatureTTL() (time.Duration, error) {
+	if c.Networking.RouteServiceProxy.SignatureTTL == "" {
+		return defaultRouteServiceSignatureTTL, nil
+	}
+
+	return tools.ParseDuration(c.Networking.RouteServiceProxy.SignatureTTL)
+}
@@ -43,6 +43,7 @@ var _ = Describe("LoadFromPath", func() {
 			CFRootNamespace:                  "rootNamespace",
 			ContainerRegistrySecretNames:     []string{"packageRegistrySecretName"},
 			TaskTTL:                          "taskTTL",
+			AuditEventRetention:              "auditEventRetention",
 			BuilderName:                      "buildReconciler",
 			RunnerName:                       "statefulset-runner",
 			LogLevel:                         zapcore.DebugLevel,
@@ -84,6 +85,7 @@ var _ = Describe("LoadFromPath", func() {
 			CFRootNamespace:                  "rootNamespace",
 			ContainerRegistrySecretNames:     []string{"packageRegistrySecretName"},
 			TaskTTL:                          "taskTTL",
+			AuditEventRetention:              "auditEventRetention",
 			BuilderName:                      "buildReconciler",
 			RunnerName:                       "statefulset-runner",
 			NamespaceLabels:                  map[string]string{},
@@ -194,3 +196,145 @@ var _ = Describe("ParseTaskTTL", func() {
 		})
 	})
 })
+
+var _ = Describe("ParseAuditEventRetention", func() {
+	var (
+		retentionString string
+		retention       time.Duration
+		parseErr        error
+	)
+
+	BeforeEach(func() {
+		retentionString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			AuditEventRetention: retentionString,
+		}
+
+		retention, parseErr = cfg.ParseAuditEventRetention()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(retention).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			retentionString = "7d12h"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(retention).To(Equal(7*24*time.Hour + 12*time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			retentionString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseServiceBrokerCatalogRefreshInterval", func() {
+	var (
+		intervalString string
+		interval       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		intervalString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			ServiceBrokerCatalogRefreshInterval: intervalString,
+		}
+
+		interval, parseErr = cfg.ParseServiceBrokerCatalogRefreshInterval()
+	})
+
+	It("returns zero by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(interval).To(BeZero())
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			intervalString = "1h30m"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(90 * time.Minute))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			intervalString = "sometimes"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseRouteServiceSignatureTTL", func() {
+	var (
+		ttlString string
+		ttl       time.Duration
+		parseErr  error
+	)
+
+	BeforeEach(func() {
+		ttlString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			Networking: config.Networking{
+				RouteServiceProxy: config.RouteServiceProxy{
+					SignatureTTL: ttlString,
+				},
+			},
+		}
+
+		ttl, parseErr = cfg.ParseRouteServiceSignatureTTL()
+	})
+
+	It("returns 60 seconds by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(ttl).To(Equal(60 * time.Second))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			ttlString = "2m"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(ttl).To(Equal(2 * time.Minute))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			ttlString = "sometimes"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Reconciler struct {
+	k8sClient client.Client
+	log       logr.Logger
+	retention time.Duration
+}
+
+func NewReconciler(
+	k8sClient client.Client,
+	log logr.Logger,
+	retention time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAuditEvent] {
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFAuditEvent](log, k8sClient, &Reconciler{
+		k8sClient: k8sClient,
+		log:       log,
+		retention: retention,
+	})
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFAuditEvent{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents/status,verbs=get;patch
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfAuditEvent *korifiv1alpha1.CFAuditEvent) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfAuditEvent.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cfAuditEvent.Status.ObservedGeneration = cfAuditEvent.Generation
+	log.V(1).Info("set observed generation", "generation", cfAuditEvent.Status.ObservedGeneration)
+
+	expiresIn := time.Until(cfAuditEvent.CreationTimestamp.Add(r.retention))
+	if expiresIn > 0 {
+		return ctrl.Result{RequeueAfter: expiresIn}, nil
+	}
+
+	log.V(1).Info("deleting expired audit event")
+	err := r.k8sClient.Delete(ctx, cfAuditEvent)
+	if err != nil && !errors.IsNotFound(err) {
+		log.Info("error deleting audit event", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,57 @@
+package events_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFAuditEventReconciler Integration Tests", func() {
+	var cfAuditEvent *korifiv1alpha1.CFAuditEvent
+
+	BeforeEach(func() {
+		namespace := uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		cfAuditEvent = &korifiv1alpha1.CFAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAuditEventSpec{
+				Type: "audit.app.create",
+				Actor: korifiv1alpha1.CFAuditEventActor{
+					GUID: "alice",
+					Type: "user",
+					Name: "alice",
+				},
+				Target: korifiv1alpha1.CFAuditEventTarget{
+					GUID: uuid.NewString(),
+					Type: "app",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfAuditEvent)).To(Succeed())
+	})
+
+	It("sets the observed generation", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)).To(Succeed())
+			g.Expect(cfAuditEvent.Status.ObservedGeneration).To(Equal(cfAuditEvent.Generation))
+		}).Should(Succeed())
+	})
+
+	It("deletes the event once its retention period is over", func() {
+		Eventually(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)
+			g.Expect(err).To(MatchError(ContainSubstring("not found")))
+		}).Should(Succeed())
+	})
+})
@@ -19,16 +19,19 @@ package routes
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/routeservice"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -40,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -48,6 +52,7 @@ type Reconciler struct {
 	scheme           *runtime.Scheme
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
+	routeSigner      *routeservice.Signer
 }
 
 func NewReconciler(
@@ -55,8 +60,9 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
+	routeSigner *routeservice.Signer,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFRoute] {
-	routeReconciler := Reconciler{client: client, scheme: scheme, log: log, controllerConfig: controllerConfig}
+	routeReconciler := Reconciler{client: client, scheme: scheme, log: log, controllerConfig: controllerConfig, routeSigner: routeSigner}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFRoute](log, client, &routeReconciler)
 }
 
@@ -66,9 +72,27 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFApp{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFAppRequests),
+		).
+		Watches(
+			&korifiv1alpha1.CFServiceBinding{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRouteBindingRequests),
 		)
 }
 
+func (r *Reconciler) enqueueRouteBindingRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	cfServiceBinding, ok := o.(*korifiv1alpha1.CFServiceBinding)
+	if !ok || cfServiceBinding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeRoute {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      cfServiceBinding.Spec.RouteRef.Name,
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}}
+}
+
 func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object) []reconcile.Request {
 	var requests []reconcile.Request
 
@@ -106,6 +130,10 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes/status,verbs=get
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 
@@ -130,19 +158,42 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("InvalidDomainRef")
 	}
 
-	err = r.createOrPatchServices(ctx, cfRoute)
+	canaries, err := getCanaryDestinations(ctx, r.client, cfRoute)
 	if err != nil {
-		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CreatePatchServices")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("GetCanaryDestinations")
 	}
 
-	err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain)
+	err = r.createOrPatchServices(ctx, cfRoute, canaries)
 	if err != nil {
-		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileHTTPRoute")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CreatePatchServices")
+	}
+
+	var routeSvc *routeService
+	if cfRoute.Spec.Protocol == korifiv1alpha1.RouteProtocolTCP {
+		err = r.reconcileTCPRoute(ctx, cfRoute, canaries)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileTCPRoute")
+		}
+	} else {
+		routeSvc, err = getRouteService(ctx, r.client, cfRoute)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("GetRouteService")
+		}
+
+		err = r.createOrPatchRouteServiceService(ctx, cfRoute, routeSvc)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CreatePatchRouteServiceService")
+		}
+
+		err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain, canaries, routeSvc)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileHTTPRoute")
+		}
 	}
 
 	fqdn := buildFQDN(cfRoute, cfDomain)
 	cfRoute.Status.FQDN = fqdn
-	cfRoute.Status.URI = fqdn + cfRoute.Spec.Path
+	cfRoute.Status.URI = buildURI(cfRoute, fqdn)
 
 	effectiveDestinations, err := r.buildEffectiveDestinations(ctx, cfRoute)
 	if err != nil {
@@ -150,7 +201,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 	}
 	cfRoute.Status.Destinations = effectiveDestinations
 
-	if cleanupErr := r.deleteOrphanedServices(ctx, cfRoute); cleanupErr != nil {
+	if cleanupErr := r.deleteOrphanedServices(ctx, cfRoute, canaries, routeSvc); cleanupErr != nil {
 		// technically, failing to delete the orphaned services does not make
 		// the CFRoute invalid or not ready so we don't mess with the cfRoute
 		// ready status condition here
@@ -174,55 +225,225 @@ func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha
 	return nil
 }
 
-func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
-	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices")
+// canaryDestination describes how traffic to a destination is split while
+// the destination app is being deployed with the canary strategy
+type canaryDestination struct {
+	stableRevision string
+	canaryRevision string
+	stableWeight   int32
+}
+
+func getCanaryDestinations(ctx context.Context, k8sClient client.Client, cfRoute *korifiv1alpha1.CFRoute) (map[string]canaryDestination, error) {
+	canaries := map[string]canaryDestination{}
 
 	for _, destination := range cfRoute.Status.Destinations {
-		serviceName := generateServiceName(destination)
-		loopLog := log.WithValues("processType", destination.ProcessType, "appRef", destination.AppRef.Name, "serviceName", serviceName)
+		cfApp := &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfRoute.Namespace,
+				Name:      destination.AppRef.Name,
+			},
+		}
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get app %q: %w", destination.AppRef.Name, err)
+		}
 
-		if destination.Port == nil {
+		if cfApp.Spec.Canary == nil {
 			continue
 		}
 
-		service := &corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      serviceName,
-				Namespace: cfRoute.Namespace,
-			},
+		stableWeight, err := getProcessDesiredInstances(ctx, k8sClient, cfApp, destination.ProcessType)
+		if err != nil {
+			return nil, err
 		}
 
-		result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
-			service.Labels = map[string]string{
-				korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
-				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
-			}
+		canaries[destination.GUID] = canaryDestination{
+			stableRevision: cfApp.Spec.Canary.StableRevision,
+			canaryRevision: cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey],
+			stableWeight:   stableWeight,
+		}
+	}
+
+	return canaries, nil
+}
+
+func getProcessDesiredInstances(ctx context.Context, k8sClient client.Client, cfApp *korifiv1alpha1.CFApp, processType string) (int32, error) {
+	var cfProcesses korifiv1alpha1.CFProcessList
+	err := k8sClient.List(ctx, &cfProcesses,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list processes for app %q: %w", cfApp.Name, err)
+	}
+
+	for _, cfProcess := range cfProcesses.Items {
+		if cfProcess.Spec.ProcessType == processType {
+			return tools.ZeroIfNil(cfProcess.Spec.DesiredInstances), nil
+		}
+	}
 
-			err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
+	return 0, nil
+}
+
+// routeService describes the route service the route traffic is forwarded
+// to before it reaches the route destinations
+type routeService struct {
+	url *url.URL
+}
+
+func getRouteService(ctx context.Context, k8sClient client.Client, cfRoute *korifiv1alpha1.CFRoute) (*routeService, error) {
+	var cfServiceBindings korifiv1alpha1.CFServiceBindingList
+	err := k8sClient.List(ctx, &cfServiceBindings,
+		client.InNamespace(cfRoute.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list route bindings for route %q: %w", cfRoute.Name, err)
+	}
+
+	for _, cfServiceBinding := range cfServiceBindings.Items {
+		if cfServiceBinding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeRoute ||
+			cfServiceBinding.Status.RouteServiceURL == "" ||
+			!cfServiceBinding.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		routeServiceURL, err := url.Parse(cfServiceBinding.Status.RouteServiceURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route service url %q: %w", cfServiceBinding.Status.RouteServiceURL, err)
+		}
+
+		return &routeService{url: routeServiceURL}, nil
+	}
+
+	return nil, nil
+}
+
+// createOrPatchRouteServiceService creates an ExternalName service pointing
+// to the route service proxy, so that the HTTPRoute can forward traffic to it
+func (r *Reconciler) createOrPatchRouteServiceService(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, routeSvc *routeService) error {
+	if routeSvc == nil {
+		return nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchRouteServiceService").
+		WithValues("routeServiceURL", routeSvc.url.String())
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generateRouteServiceServiceName(cfRoute),
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		service.Labels = map[string]string{
+			korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+		}
+
+		service.Spec.Type = corev1.ServiceTypeExternalName
+		service.Spec.ExternalName = r.controllerConfig.Networking.RouteServiceProxy.Host
+		service.Spec.Ports = []corev1.ServicePort{{
+			Port: r.controllerConfig.Networking.RouteServiceProxy.Port,
+		}}
+
+		return controllerutil.SetControllerReference(cfRoute, service, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to patch route service Service", "reason", err)
+		return fmt.Errorf("route service reconciliation failed for CFRoute/%s", cfRoute.Name)
+	}
+
+	log.V(1).Info("route service Service reconciled", "operation", result)
+	return nil
+}
+
+func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]canaryDestination) error {
+	for _, destination := range cfRoute.Status.Destinations {
+		if destination.Port == nil {
+			continue
+		}
+
+		canary, isCanary := canaries[destination.GUID]
+		if !isCanary {
+			err := r.createOrPatchService(ctx, cfRoute, destination, generateServiceName(destination), nil)
 			if err != nil {
-				loopLog.Info("failed to set OwnerRef on Service", "reason", err)
 				return err
 			}
+			continue
+		}
 
-			service.Spec.Ports = []corev1.ServicePort{{
-				Port: int32(*destination.Port),
-			}}
-
-			service.Spec.Selector = map[string]string{
-				korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
-				korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
-			}
+		err := r.createOrPatchService(ctx, cfRoute, destination, generateServiceName(destination), map[string]string{
+			korifiv1alpha1.VersionLabelKey: canary.stableRevision,
+		})
+		if err != nil {
+			return err
+		}
 
-			return nil
+		err = r.createOrPatchService(ctx, cfRoute, destination, generateCanaryServiceName(destination), map[string]string{
+			korifiv1alpha1.VersionLabelKey: canary.canaryRevision,
 		})
 		if err != nil {
-			log.Info("failed to patch Service", "reason", err)
-			return fmt.Errorf("service reconciliation failed for CFRoute/%s destinations", cfRoute.Name)
+			return err
 		}
+	}
+
+	return nil
+}
 
-		log.V(1).Info("Service reconciled", "operation", result)
+func (r *Reconciler) createOrPatchService(
+	ctx context.Context,
+	cfRoute *korifiv1alpha1.CFRoute,
+	destination korifiv1alpha1.Destination,
+	serviceName string,
+	extraSelector map[string]string,
+) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices").
+		WithValues("processType", destination.ProcessType, "appRef", destination.AppRef.Name, "serviceName", serviceName)
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		service.Labels = map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
+			korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+		}
+
+		err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
+		if err != nil {
+			log.Info("failed to set OwnerRef on Service", "reason", err)
+			return err
+		}
+
+		service.Spec.Ports = []corev1.ServicePort{{
+			Port: int32(*destination.Port),
+		}}
+
+		service.Spec.Selector = map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
+			korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
+		}
+		for k, v := range extraSelector {
+			service.Spec.Selector[k] = v
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to patch Service", "reason", err)
+		return fmt.Errorf("service reconciliation failed for CFRoute/%s destinations", cfRoute.Name)
 	}
 
+	log.V(1).Info("Service reconciled", "operation", result)
 	return nil
 }
 
@@ -234,6 +455,9 @@ func (r *Reconciler) buildEffectiveDestinations(ctx context.Context, cfRoute *ko
 
 		if effectiveDest.Protocol == nil {
 			effectiveDest.Protocol = tools.PtrTo("http1")
+			if cfRoute.Spec.Protocol == korifiv1alpha1.RouteProtocolTCP {
+				effectiveDest.Protocol = tools.PtrTo("tcp")
+			}
 		}
 
 		if effectiveDest.Port == nil {
@@ -274,21 +498,21 @@ func (r *Reconciler) getAppCurrentDroplet(ctx context.Context, appNamespace, app
 		return nil, nil
 	}
 
-	cfBuild := &korifiv1alpha1.CFBuild{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: cfApp.Namespace,
-			Name:      cfApp.Spec.CurrentDropletRef.Name,
-		},
-	}
-	err = r.client.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)
+	droplet, err := shared.GetDroplet(ctx, r.client, cfApp.Namespace, cfApp.Spec.CurrentDropletRef.Name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get build for app %q: %w", cfApp.Name, err)
+		return nil, fmt.Errorf("failed to get droplet for app %q: %w", cfApp.Name, err)
 	}
 
-	return cfBuild.Status.Droplet, nil
+	return droplet, nil
 }
 
-func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+func (r *Reconciler) reconcileHTTPRoute(
+	ctx context.Context,
+	cfRoute *korifiv1alpha1.CFRoute,
+	cfDomain *korifiv1alpha1.CFDomain,
+	canaries map[string]canaryDestination,
+	routeSvc *routeService,
+) error {
 	fqdn := buildFQDN(cfRoute, cfDomain)
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchHTTPRoute").WithValues("fqdn", fqdn, "path", cfRoute.Spec.Path)
 
@@ -321,17 +545,18 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		}
 
 		httpRoute.Spec.Rules = []gatewayv1beta1.HTTPRouteRule{{
-			BackendRefs: toBackendRefs(cfRoute.Status.Destinations),
+			BackendRefs: toHTTPBackendRefs(toBackendRefs(cfRoute.Status.Destinations, canaries)),
 		}}
 		if cfRoute.Spec.Path != "" {
 			httpRoute.Spec.Rules[0].Matches = []gatewayv1beta1.HTTPRouteMatch{{
-				Path: &gatewayv1beta1.HTTPPathMatch{
-					Type:  tools.PtrTo(gatewayv1.PathMatchPathPrefix),
-					Value: tools.PtrTo(strings.ToLower(cfRoute.Spec.Path)),
-				},
+				Path: toHTTPPathMatch(cfRoute),
 			}}
 		}
 
+		if routeSvc != nil {
+			httpRoute.Spec.Rules = r.toRouteServiceRules(cfRoute)
+		}
+
 		return controllerutil.SetControllerReference(cfRoute, httpRoute, r.scheme)
 	})
 	if err != nil {
@@ -343,7 +568,52 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 	return nil
 }
 
-func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+func (r *Reconciler) reconcileTCPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]canaryDestination) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTCPRoute").WithValues("port", tools.ZeroIfNil(cfRoute.Spec.Port))
+
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	if len(cfRoute.Status.Destinations) == 0 {
+		err := r.client.Delete(ctx, tcpRoute)
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete existing TCPRoutes", "reason", err)
+			return err
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, tcpRoute, func() error {
+		// tcp traffic cannot be routed by hostname, so the route is attached
+		// to the gateway listener dedicated to its port
+		tcpRoute.Spec.ParentRefs = []gatewayv1alpha2.ParentReference{{
+			Group:     tools.PtrTo(gatewayv1alpha2.Group("gateway.networking.k8s.io")),
+			Kind:      tools.PtrTo(gatewayv1alpha2.Kind("Gateway")),
+			Namespace: tools.PtrTo(gatewayv1alpha2.Namespace(r.controllerConfig.Networking.GatewayNamespace)),
+			Name:      gatewayv1alpha2.ObjectName(r.controllerConfig.Networking.GatewayName),
+			Port:      tools.PtrTo(gatewayv1alpha2.PortNumber(tools.ZeroIfNil(cfRoute.Spec.Port))),
+		}}
+
+		tcpRoute.Spec.Rules = []gatewayv1alpha2.TCPRouteRule{{
+			BackendRefs: toBackendRefs(cfRoute.Status.Destinations, canaries),
+		}}
+
+		return controllerutil.SetControllerReference(cfRoute, tcpRoute, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch TCPRoute", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("TCPRoute reconciled", "operation", result)
+	return nil
+}
+
+func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]canaryDestination, routeSvc *routeService) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedServices")
 
 	matchingLabelSet := map[string]string{
@@ -359,12 +629,17 @@ func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifi
 	for i, service := range serviceList.Items {
 		loopLog := log.WithValues("serviceName", service.Name)
 
-		isOrphan := true
+		isOrphan := routeSvc == nil || service.Name != generateRouteServiceServiceName(cfRoute)
 		for _, destination := range cfRoute.Status.Destinations {
 			if service.Name == generateServiceName(destination) {
 				isOrphan = false
 				break
 			}
+
+			if _, isCanary := canaries[destination.GUID]; isCanary && service.Name == generateCanaryServiceName(destination) {
+				isOrphan = false
+				break
+			}
 		}
 
 		if isOrphan {
@@ -402,24 +677,119 @@ func generateServiceName(destination korifiv1alpha1.Destination) string {
 	return fmt.Sprintf("s-%s", destination.GUID)
 }
 
+func generateCanaryServiceName(destination korifiv1alpha1.Destination) string {
+	return generateServiceName(destination) + "-canary"
+}
+
+func generateRouteServiceServiceName(cfRoute *korifiv1alpha1.CFRoute) string {
+	return fmt.Sprintf("rs-%s", cfRoute.Name)
+}
+
 func buildFQDN(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) string {
+	if cfRoute.Spec.Protocol == korifiv1alpha1.RouteProtocolTCP {
+		return cfDomain.Spec.Name
+	}
+
 	return fmt.Sprintf("%s.%s", strings.ToLower(cfRoute.Spec.Host), cfDomain.Spec.Name)
 }
 
-func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.HTTPBackendRef {
-	backendRefs := []gatewayv1beta1.HTTPBackendRef{}
+func buildURI(cfRoute *korifiv1alpha1.CFRoute, fqdn string) string {
+	if cfRoute.Spec.Protocol == korifiv1alpha1.RouteProtocolTCP {
+		return fmt.Sprintf("%s:%d", fqdn, tools.ZeroIfNil(cfRoute.Spec.Port))
+	}
+
+	return fqdn + cfRoute.Spec.Path
+}
+
+func toBackendRefs(destinations []korifiv1alpha1.Destination, canaries map[string]canaryDestination) []gatewayv1beta1.BackendRef {
+	backendRefs := []gatewayv1beta1.BackendRef{}
 
 	for _, destination := range destinations {
-		backendRefs = append(backendRefs, gatewayv1beta1.HTTPBackendRef{
-			BackendRef: gatewayv1beta1.BackendRef{
-				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
-					Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
-					Name: gatewayv1beta1.ObjectName(generateServiceName(destination)),
-					Port: tools.PtrTo(gatewayv1beta1.PortNumber(*destination.Port)),
-				},
-			},
-		})
+		canary, isCanary := canaries[destination.GUID]
+		if !isCanary {
+			backendRefs = append(backendRefs, toBackendRef(generateServiceName(destination), *destination.Port, destination.Weight))
+			continue
+		}
+
+		// the canary runs a single instance, weight traffic as if it was
+		// one more instance of the process
+		stableWeight, canaryWeight := canary.stableWeight, int32(1)
+		if destination.Weight != nil {
+			stableWeight, canaryWeight = splitCanaryWeight(*destination.Weight, canary.stableWeight)
+		}
+
+		backendRefs = append(backendRefs,
+			toBackendRef(generateServiceName(destination), *destination.Port, tools.PtrTo(stableWeight)),
+			toBackendRef(generateCanaryServiceName(destination), *destination.Port, tools.PtrTo(canaryWeight)),
+		)
 	}
 
 	return backendRefs
 }
+
+// splitCanaryWeight splits the weight of a destination between its stable
+// instances and the single canary instance, so that the destination keeps
+// receiving its share of the route traffic
+func splitCanaryWeight(weight, stableInstances int32) (int32, int32) {
+	canaryWeight := max(weight/(stableInstances+1), 1)
+	return max(weight-canaryWeight, 0), canaryWeight
+}
+
+func toBackendRef(serviceName string, port int32, weight *int32) gatewayv1beta1.BackendRef {
+	return gatewayv1beta1.BackendRef{
+		BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+			Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
+			Name: gatewayv1beta1.ObjectName(serviceName),
+			Port: tools.PtrTo(gatewayv1beta1.PortNumber(port)),
+		},
+		Weight: weight,
+	}
+}
+
+func toHTTPBackendRefs(backendRefs []gatewayv1beta1.BackendRef) []gatewayv1beta1.HTTPBackendRef {
+	httpBackendRefs := []gatewayv1beta1.HTTPBackendRef{}
+	for _, backendRef := range backendRefs {
+		httpBackendRefs = append(httpBackendRefs, gatewayv1beta1.HTTPBackendRef{BackendRef: backendRef})
+	}
+
+	return httpBackendRefs
+}
+
+func toHTTPPathMatch(cfRoute *korifiv1alpha1.CFRoute) *gatewayv1beta1.HTTPPathMatch {
+	if cfRoute.Spec.Path == "" {
+		return nil
+	}
+
+	return &gatewayv1beta1.HTTPPathMatch{
+		Type:  tools.PtrTo(gatewayv1.PathMatchPathPrefix),
+		Value: tools.PtrTo(strings.ToLower(cfRoute.Spec.Path)),
+	}
+}
+
+// toRouteServiceRules sends the route traffic to the route service proxy.
+// The proxy signs the requests and forwards them to the route service, which
+// sends them back to the X-CF-Forwarded-Url. The proxy then verifies their
+// signature and forwards them to the route destinations. The route header is
+// signed, so that the proxy only trusts it when it was set here
+func (r *Reconciler) toRouteServiceRules(cfRoute *korifiv1alpha1.CFRoute) []gatewayv1beta1.HTTPRouteRule {
+	return []gatewayv1beta1.HTTPRouteRule{{
+		Matches: []gatewayv1beta1.HTTPRouteMatch{{
+			Path: toHTTPPathMatch(cfRoute),
+		}},
+		Filters: []gatewayv1beta1.HTTPRouteFilter{{
+			Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &gatewayv1beta1.HTTPHeaderFilter{
+				Set: []gatewayv1beta1.HTTPHeader{{
+					Name:  routeservice.RouteHeader,
+					Value: cfRoute.Namespace + "/" + cfRoute.Name,
+				}, {
+					Name:  routeservice.RouteSignatureHeader,
+					Value: r.routeSigner.SignRoute(client.ObjectKeyFromObject(cfRoute)),
+				}},
+			},
+		}},
+		BackendRefs: toHTTPBackendRefs([]gatewayv1beta1.BackendRef{
+			toBackendRef(generateRouteServiceServiceName(cfRoute), r.controllerConfig.Networking.RouteServiceProxy.Port, nil),
+		}),
+	}}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -19,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -276,6 +278,19 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the destination is weighted", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations[0].Weight = tools.PtrTo[int32](100)
+			})
+
+			It("sets the weight on the backend ref", func() {
+				httpRoute := getHTTPRoute()
+
+				Expect(httpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+				Expect(httpRoute.Spec.Rules[0].BackendRefs[0].Weight).To(PointTo(BeEquivalentTo(100)))
+			})
+		})
+
 		When("the destination has no port set", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Destinations[0].Port = nil
@@ -386,6 +401,241 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the destination app is being deployed with the canary strategy", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFProcess{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns.Name,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:           corev1.LocalObjectReference{Name: cfApp.Name},
+						ProcessType:      "web",
+						DesiredInstances: tools.PtrTo[int32](3),
+					},
+				})).To(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations = map[string]string{
+						korifiv1alpha1.CFAppRevisionKey: "6",
+					}
+					cfApp.Spec.Canary = &korifiv1alpha1.CanaryDeployment{
+						StableRevision: "5",
+					}
+				})).To(Succeed())
+			})
+
+			It("restricts the destination service to the stable revision", func() {
+				serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Selector).To(SatisfyAll(
+						HaveLen(3),
+						HaveKeyWithValue("korifi.cloudfoundry.org/app-guid", cfApp.Name),
+						HaveKeyWithValue("korifi.cloudfoundry.org/process-type", "web"),
+						HaveKeyWithValue("korifi.cloudfoundry.org/version", "5"),
+					))
+				}).Should(Succeed())
+			})
+
+			It("creates a canary service selecting the new revision", func() {
+				serviceName := fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID)
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Labels).To(HaveKeyWithValue("korifi.cloudfoundry.org/route-guid", cfRoute.Name))
+					g.Expect(svc.Spec.Selector).To(SatisfyAll(
+						HaveLen(3),
+						HaveKeyWithValue("korifi.cloudfoundry.org/app-guid", cfApp.Name),
+						HaveKeyWithValue("korifi.cloudfoundry.org/process-type", "web"),
+						HaveKeyWithValue("korifi.cloudfoundry.org/version", "6"),
+					))
+				}).Should(Succeed())
+			})
+
+			It("weights the traffic between the stable and the canary services", func() {
+				httpRoute := getHTTPRoute()
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(httpRoute), httpRoute)).To(Succeed())
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"BackendRef": MatchFields(IgnoreExtras, Fields{
+								"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+									"Name": BeEquivalentTo(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+								}),
+								"Weight": PointTo(BeEquivalentTo(3)),
+							}),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"BackendRef": MatchFields(IgnoreExtras, Fields{
+								"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+									"Name": BeEquivalentTo(fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID)),
+								}),
+								"Weight": PointTo(BeEquivalentTo(1)),
+							}),
+						}),
+					))
+				}).Should(Succeed())
+			})
+
+			When("the destination is weighted", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].Weight = tools.PtrTo[int32](100)
+				})
+
+				It("splits the destination weight between the stable and the canary services", func() {
+					httpRoute := getHTTPRoute()
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(httpRoute), httpRoute)).To(Succeed())
+						g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(2))
+						g.Expect(httpRoute.Spec.Rules[0].BackendRefs[0].Weight).To(PointTo(BeEquivalentTo(75)))
+						g.Expect(httpRoute.Spec.Rules[0].BackendRefs[1].Weight).To(PointTo(BeEquivalentTo(25)))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the canary deployment ends", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{
+							Name:      fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID),
+							Namespace: ns.Name,
+						}, new(corev1.Service))).To(Succeed())
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+				})
+
+				It("deletes the canary service", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, types.NamespacedName{
+							Name:      fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID),
+							Namespace: ns.Name,
+						}, new(corev1.Service))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the route is bound to a route service", func() {
+			var routeBinding *korifiv1alpha1.CFServiceBinding
+
+			BeforeEach(func() {
+				routeBinding = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns.Name,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Type:     korifiv1alpha1.CFServiceBindingTypeRoute,
+						RouteRef: corev1.LocalObjectReference{Name: cfRoute.Name},
+						Service: corev1.ObjectReference{
+							Kind:       "CFServiceInstance",
+							APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+							Name:       uuid.NewString(),
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, routeBinding)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, routeBinding, func() {
+					routeBinding.Status.RouteServiceURL = "https://route-service.io:8443/proxy"
+				})).To(Succeed())
+			})
+
+			It("creates an external service for the route service proxy", func() {
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "rs-" + cfRoute.Name, Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Labels).To(HaveKeyWithValue("korifi.cloudfoundry.org/route-guid", cfRoute.Name))
+					g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeExternalName))
+					g.Expect(svc.Spec.ExternalName).To(Equal("korifi-controllers-route-service-proxy.korifi.svc.cluster.local"))
+					g.Expect(svc.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Port": BeEquivalentTo(8082),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("forwards the route traffic through the route service proxy", func() {
+				httpRoute := getHTTPRoute()
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(httpRoute), httpRoute)).To(Succeed())
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+
+					rule := httpRoute.Spec.Rules[0]
+					g.Expect(rule.BackendRefs).To(HaveLen(1))
+					g.Expect(rule.BackendRefs[0].Name).To(BeEquivalentTo("rs-" + cfRoute.Name))
+					g.Expect(rule.BackendRefs[0].Port).To(PointTo(BeEquivalentTo(8082)))
+					g.Expect(rule.Filters).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"RequestHeaderModifier": PointTo(MatchFields(IgnoreExtras, Fields{
+							"Set": ConsistOf(gatewayv1beta1.HTTPHeader{
+								Name:  "X-Korifi-Route",
+								Value: ns.Name + "/" + cfRoute.Name,
+							}, gatewayv1beta1.HTTPHeader{
+								Name:  "X-Korifi-Route-Signature",
+								Value: routeSigner.SignRoute(client.ObjectKeyFromObject(cfRoute)),
+							}),
+						})),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("resolves the route service and destination backends for the proxy", func() {
+				resolver := routes.NewRouteServiceBackendResolver(adminClient)
+				Eventually(func(g Gomega) {
+					backends, err := resolver.ResolveBackends(ctx, client.ObjectKeyFromObject(cfRoute))
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(backends.RouteService).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Scheme": Equal("https"),
+						"Host":   Equal("route-service.io:8443"),
+						"Path":   Equal("/proxy"),
+					})))
+					g.Expect(backends.Destination).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Scheme": Equal("http"),
+						"Host":   Equal(fmt.Sprintf("s-%s.%s.svc:80", cfRoute.Spec.Destinations[0].GUID, ns.Name)),
+					})))
+				}).Should(Succeed())
+			})
+
+			When("the route binding is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "rs-" + cfRoute.Name, Namespace: ns.Name}, new(corev1.Service))).To(Succeed())
+					}).Should(Succeed())
+
+					Expect(adminClient.Delete(ctx, routeBinding)).To(Succeed())
+				})
+
+				It("sends the route traffic directly to the destinations", func() {
+					httpRoute := getHTTPRoute()
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(httpRoute), httpRoute)).To(Succeed())
+						g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+						g.Expect(httpRoute.Spec.Rules[0].Filters).To(BeEmpty())
+						g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+						g.Expect(httpRoute.Spec.Rules[0].BackendRefs[0].Name).To(BeEquivalentTo(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)))
+					}).Should(Succeed())
+				})
+
+				It("deletes the route service service", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, types.NamespacedName{Name: "rs-" + cfRoute.Name, Namespace: ns.Name}, new(corev1.Service))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the destinations are deleted from the route", func() {
 			var (
 				httpRoute   *gatewayv1beta1.HTTPRoute
@@ -416,6 +666,127 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the route is a tcp route", func() {
+		var cfApp *korifiv1alpha1.CFApp
+
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, adminClient, cfDomain, func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+			})).To(Succeed())
+
+			cfApp = &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					Lifecycle: korifiv1alpha1.Lifecycle{
+						Type: "buildpack",
+					},
+					DesiredState: "STARTED",
+					DisplayName:  uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+			cfRoute.Spec.Host = ""
+			cfRoute.Spec.Path = ""
+			cfRoute.Spec.Protocol = korifiv1alpha1.RouteProtocolTCP
+			cfRoute.Spec.Port = tools.PtrTo[int32](1024)
+			cfRoute.Spec.RouterGroup = "default-tcp"
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+				{
+					GUID: uuid.NewString(),
+					AppRef: corev1.LocalObjectReference{
+						Name: cfApp.Name,
+					},
+					ProcessType: "web",
+					Port:        tools.PtrTo[int32](5432),
+				},
+			}
+		})
+
+		getTCPRoute := func() *gatewayv1alpha2.TCPRoute {
+			GinkgoHelper()
+
+			tcpRoute := &gatewayv1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfRoute.Name,
+					Namespace: cfRoute.Namespace,
+				},
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(tcpRoute), tcpRoute)).To(Succeed())
+			}).Should(Succeed())
+			return tcpRoute
+		}
+
+		It("creates a TCPRoute attached to the gateway listener for the route port", func() {
+			tcpRoute := getTCPRoute()
+
+			Expect(tcpRoute.Spec.ParentRefs).To(ConsistOf(gatewayv1alpha2.ParentReference{
+				Group:     tools.PtrTo(gatewayv1alpha2.Group("gateway.networking.k8s.io")),
+				Kind:      tools.PtrTo(gatewayv1alpha2.Kind("Gateway")),
+				Namespace: tools.PtrTo(gatewayv1alpha2.Namespace("korifi-gateway")),
+				Name:      gatewayv1alpha2.ObjectName("korifi"),
+				Port:      tools.PtrTo(gatewayv1alpha2.PortNumber(1024)),
+			}))
+
+			Expect(tcpRoute.Spec.Rules).To(HaveLen(1))
+			Expect(tcpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+			Expect(tcpRoute.Spec.Rules[0].BackendRefs[0].BackendObjectReference).To(Equal(gatewayv1alpha2.BackendObjectReference{
+				Group: tools.PtrTo(gatewayv1alpha2.Group("")),
+				Kind:  tools.PtrTo(gatewayv1alpha2.Kind("Service")),
+				Name:  gatewayv1alpha2.ObjectName(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+				Port:  tools.PtrTo(gatewayv1alpha2.PortNumber(5432)),
+			}))
+		})
+
+		It("does not create a HTTPRoute", func() {
+			Consistently(func(g Gomega) {
+				httpRoutes := &gatewayv1beta1.HTTPRouteList{}
+				g.Expect(adminClient.List(ctx, httpRoutes, client.InNamespace(ns.Name))).To(Succeed())
+				g.Expect(httpRoutes.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("sets the domain and port as the route uri", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfRoute.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				g.Expect(cfRoute.Status.FQDN).To(Equal(cfDomain.Spec.Name))
+				g.Expect(cfRoute.Status.URI).To(Equal(cfDomain.Spec.Name + ":1024"))
+			}).Should(Succeed())
+		})
+
+		It("defaults the destinations protocol to tcp", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+				g.Expect(cfRoute.Status.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Protocol": PointTo(Equal("tcp")),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the destinations are deleted from the route", func() {
+			var tcpRoute *gatewayv1alpha2.TCPRoute
+
+			JustBeforeEach(func() {
+				tcpRoute = getTCPRoute()
+				Expect(k8s.Patch(ctx, adminClient, cfRoute, func() {
+					cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{}
+				})).To(Succeed())
+			})
+
+			It("deletes the TCPRoute", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(tcpRoute), tcpRoute)
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("a route has a legacy finalizer", func() {
 		BeforeEach(func() {
 			cfRoute.Finalizers = []string{
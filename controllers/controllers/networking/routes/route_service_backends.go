@@ -0,0 +1,87 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/routeservice"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// RouteServiceBackendResolver resolves the backends the route service proxy
+// sends the traffic of a route to. It uses the same route service and weighted
+// destinations as the reconciler does when building the route HTTPRoute
+type RouteServiceBackendResolver struct {
+	k8sClient client.Client
+}
+
+func NewRouteServiceBackendResolver(k8sClient client.Client) *RouteServiceBackendResolver {
+	return &RouteServiceBackendResolver{
+		k8sClient: k8sClient,
+	}
+}
+
+func (r *RouteServiceBackendResolver) ResolveBackends(ctx context.Context, route types.NamespacedName) (routeservice.Backends, error) {
+	cfRoute := &korifiv1alpha1.CFRoute{}
+	if err := r.k8sClient.Get(ctx, route, cfRoute); err != nil {
+		return routeservice.Backends{}, fmt.Errorf("failed to get route %q: %w", route, err)
+	}
+
+	routeSvc, err := getRouteService(ctx, r.k8sClient, cfRoute)
+	if err != nil {
+		return routeservice.Backends{}, err
+	}
+
+	canaries, err := getCanaryDestinations(ctx, r.k8sClient, cfRoute)
+	if err != nil {
+		return routeservice.Backends{}, err
+	}
+
+	backends := routeservice.Backends{}
+	if routeSvc != nil {
+		backends.RouteService = routeSvc.url
+	}
+
+	if backendRef, ok := pickBackendRef(toBackendRefs(cfRoute.Status.Destinations, canaries)); ok {
+		backends.Destination = &url.URL{
+			Scheme: "http",
+			Host:   fmt.Sprintf("%s.%s.svc:%d", backendRef.Name, cfRoute.Namespace, *backendRef.Port),
+		}
+	}
+
+	return backends, nil
+}
+
+// pickBackendRef picks a backend ref at random, in proportion to its weight.
+// Like in HTTPRoutes, backend refs without a weight have a weight of 1
+func pickBackendRef(backendRefs []gatewayv1beta1.BackendRef) (gatewayv1beta1.BackendRef, bool) {
+	totalWeight := int32(0)
+	for _, backendRef := range backendRefs {
+		totalWeight += backendRefWeight(backendRef)
+	}
+
+	if totalWeight == 0 {
+		return gatewayv1beta1.BackendRef{}, false
+	}
+
+	pick := rand.Int32N(totalWeight)
+	for _, backendRef := range backendRefs {
+		pick -= backendRefWeight(backendRef)
+		if pick < 0 {
+			return backendRef, true
+		}
+	}
+
+	return gatewayv1beta1.BackendRef{}, false
+}
+
+func backendRefWeight(backendRef gatewayv1beta1.BackendRef) int32 {
+	return *tools.IfNil(backendRef.Weight, tools.PtrTo[int32](1))
+}
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/routeservice"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type BackendResolver struct {
+	ResolveBackendsStub        func(context.Context, types.NamespacedName) (routeservice.Backends, error)
+	resolveBackendsMutex       sync.RWMutex
+	resolveBackendsArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}
+	resolveBackendsReturns struct {
+		result1 routeservice.Backends
+		result2 error
+	}
+	resolveBackendsReturnsOnCall map[int]struct {
+		result1 routeservice.Backends
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *BackendResolver) ResolveBackends(arg1 context.Context, arg2 types.NamespacedName) (routeservice.Backends, error) {
+	fake.resolveBackendsMutex.Lock()
+	ret, specificReturn := fake.resolveBackendsReturnsOnCall[len(fake.resolveBackendsArgsForCall)]
+	fake.resolveBackendsArgsForCall = append(fake.resolveBackendsArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}{arg1, arg2})
+	stub := fake.ResolveBackendsStub
+	fakeReturns := fake.resolveBackendsReturns
+	fake.recordInvocation("ResolveBackends", []interface{}{arg1, arg2})
+	fake.resolveBackendsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BackendResolver) ResolveBackendsCallCount() int {
+	fake.resolveBackendsMutex.RLock()
+	defer fake.resolveBackendsMutex.RUnlock()
+	return len(fake.resolveBackendsArgsForCall)
+}
+
+func (fake *BackendResolver) ResolveBackendsCalls(stub func(context.Context, types.NamespacedName) (routeservice.Backends, error)) {
+	fake.resolveBackendsMutex.Lock()
+	defer fake.resolveBackendsMutex.Unlock()
+	fake.ResolveBackendsStub = stub
+}
+
+func (fake *BackendResolver) ResolveBackendsArgsForCall(i int) (context.Context, types.NamespacedName) {
+	fake.resolveBackendsMutex.RLock()
+	defer fake.resolveBackendsMutex.RUnlock()
+	argsForCall := fake.resolveBackendsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BackendResolver) ResolveBackendsReturns(result1 routeservice.Backends, result2 error) {
+	fake.resolveBackendsMutex.Lock()
+	defer fake.resolveBackendsMutex.Unlock()
+	fake.ResolveBackendsStub = nil
+	fake.resolveBackendsReturns = struct {
+		result1 routeservice.Backends
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BackendResolver) ResolveBackendsReturnsOnCall(i int, result1 routeservice.Backends, result2 error) {
+	fake.resolveBackendsMutex.Lock()
+	defer fake.resolveBackendsMutex.Unlock()
+	fake.ResolveBackendsStub = nil
+	if fake.resolveBackendsReturnsOnCall == nil {
+		fake.resolveBackendsReturnsOnCall = make(map[int]struct {
+			result1 routeservice.Backends
+			result2 error
+		})
+	}
+	fake.resolveBackendsReturnsOnCall[i] = struct {
+		result1 routeservice.Backends
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BackendResolver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.resolveBackendsMutex.RLock()
+	defer fake.resolveBackendsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *BackendResolver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ routeservice.BackendResolver = new(BackendResolver)
@@ -0,0 +1,196 @@
+package routeservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+const (
+	ForwardedURLHeader = "X-CF-Forwarded-Url"
+	SignatureHeader    = "X-CF-Proxy-Signature"
+
+	// RouteHeader is set by the HTTPRoute of routes bound to a route service
+	// and tells the proxy which route the request has been sent to
+	RouteHeader = "X-Korifi-Route"
+	// RouteSignatureHeader is set next to the RouteHeader and proves that the
+	// route was set by the HTTPRoute rather than by the client
+	RouteSignatureHeader = "X-Korifi-Route-Signature"
+)
+
+// Backends are the urls the proxy sends the traffic of a route to
+type Backends struct {
+	// RouteService is nil when the route is no longer bound to a route service
+	RouteService *url.URL
+	Destination  *url.URL
+}
+
+//counterfeiter:generate -o fake -fake-name BackendResolver . BackendResolver
+type BackendResolver interface {
+	ResolveBackends(ctx context.Context, route types.NamespacedName) (Backends, error)
+}
+
+// Proxy sits between the gateway and the destinations of routes bound to a
+// route service. Requests without a valid signature are signed and sent to
+// the route service, while requests the route service sends back with the
+// signature it was given are sent to the route destinations
+type Proxy struct {
+	addr     string
+	signer   *Signer
+	resolver BackendResolver
+	log      logr.Logger
+}
+
+func NewProxy(addr string, signer *Signer, resolver BackendResolver, log logr.Logger) *Proxy {
+	return &Proxy{
+		addr:     addr,
+		signer:   signer,
+		resolver: resolver,
+		log:      log,
+	}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, err := parseRoute(r.Header.Get(RouteHeader))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log := p.log.WithValues("route", route)
+
+	if err = p.signer.VerifyRoute(r.Header.Get(RouteSignatureHeader), route); err != nil {
+		log.Info("rejecting request", "reason", err)
+		http.Error(w, "invalid route signature", http.StatusForbidden)
+		return
+	}
+
+	backends, err := p.resolver.ResolveBackends(r.Context(), route)
+	if err != nil {
+		log.Info("failed to resolve route backends", "reason", err)
+		http.Error(w, "failed to resolve route backends", http.StatusBadGateway)
+		return
+	}
+
+	forwardedURL := toForwardedURL(r)
+
+	if backends.RouteService == nil {
+		p.forwardToDestination(w, r, backends.Destination)
+		return
+	}
+
+	verifyErr := p.signer.Verify(r.Header.Get(SignatureHeader), forwardedURL, time.Now())
+	if verifyErr == nil {
+		p.forwardToDestination(w, r, backends.Destination)
+		return
+	}
+	log.V(1).Info("forwarding request to the route service", "reason", verifyErr)
+
+	signature, err := p.signer.Sign(forwardedURL, time.Now())
+	if err != nil {
+		log.Info("failed to sign request", "reason", err)
+		http.Error(w, "failed to sign request", http.StatusInternalServerError)
+		return
+	}
+
+	p.forwardToRouteService(w, r, backends.RouteService, forwardedURL, signature)
+}
+
+func (p *Proxy) forwardToDestination(w http.ResponseWriter, r *http.Request, destination *url.URL) {
+	if destination == nil {
+		http.Error(w, "the route has no destinations", http.StatusServiceUnavailable)
+		return
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(out *http.Request) {
+			out.URL.Scheme = destination.Scheme
+			out.URL.Host = destination.Host
+			out.Header.Del(RouteHeader)
+			out.Header.Del(RouteSignatureHeader)
+			out.Header.Del(SignatureHeader)
+			out.Header.Del(ForwardedURLHeader)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+func (p *Proxy) forwardToRouteService(w http.ResponseWriter, r *http.Request, routeService *url.URL, forwardedURL, signature string) {
+	proxy := &httputil.ReverseProxy{
+		Director: func(out *http.Request) {
+			out.URL = &url.URL{
+				Scheme:   routeService.Scheme,
+				Host:     routeService.Host,
+				Path:     routeService.Path,
+				RawQuery: routeService.RawQuery,
+			}
+			out.Host = routeService.Host
+			out.Header.Del(RouteHeader)
+			out.Header.Del(RouteSignatureHeader)
+			out.Header.Set(ForwardedURLHeader, forwardedURL)
+			out.Header.Set(SignatureHeader, signature)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// Start implements manager.Runnable
+func (p *Proxy) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              p.addr,
+		Handler:           p,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			p.log.Info("failed to shut down the route service proxy", "reason", err)
+		}
+	}()
+
+	p.log.Info("starting route service proxy", "address", p.addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every replica
+// serves route service traffic
+func (p *Proxy) NeedLeaderElection() bool {
+	return false
+}
+
+func parseRoute(value string) (types.NamespacedName, error) {
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("invalid %s header %q", RouteHeader, value)
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// toForwardedURL returns the url the client requested, including its path
+// and query, as the gateway terminates tls it defaults to https
+func toForwardedURL(r *http.Request) string {
+	scheme := r.Header.Get("X-Forwarded-Proto")
+	if scheme == "" {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
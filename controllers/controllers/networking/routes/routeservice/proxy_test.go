@@ -0,0 +1,233 @@
+package routeservice_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/routeservice"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/routeservice/fake"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Proxy", func() {
+	var (
+		signer              *routeservice.Signer
+		resolver            *fake.BackendResolver
+		routeServiceServer  *httptest.Server
+		routeServiceRequest *http.Request
+		destinationServer   *httptest.Server
+		destinationRequest  *http.Request
+		proxyServer         *httptest.Server
+		req                 *http.Request
+		resp                *http.Response
+	)
+
+	recordingServer := func(name string, recordedRequest **http.Request) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*recordedRequest = r
+			_, _ = w.Write([]byte(name))
+		}))
+	}
+
+	mustParseURL := func(rawURL string) *url.URL {
+		u, err := url.Parse(rawURL)
+		Expect(err).NotTo(HaveOccurred())
+		return u
+	}
+
+	BeforeEach(func() {
+		routeServiceRequest = nil
+		destinationRequest = nil
+		routeServiceServer = recordingServer("route-service", &routeServiceRequest)
+		destinationServer = recordingServer("destination", &destinationRequest)
+
+		signer = routeservice.NewSigner([]byte("signing-key"), time.Minute)
+		resolver = new(fake.BackendResolver)
+		resolver.ResolveBackendsReturns(routeservice.Backends{
+			RouteService: mustParseURL(routeServiceServer.URL + "/proxy?rs=param"),
+			Destination:  mustParseURL(destinationServer.URL),
+		}, nil)
+
+		proxyServer = httptest.NewServer(routeservice.NewProxy(":0", signer, resolver, logr.Discard()))
+
+		var err error
+		req, err = http.NewRequest(http.MethodGet, proxyServer.URL+"/some/path?foo=bar", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Host = "my-app.example.com"
+		req.Header.Set(routeservice.RouteHeader, "route-ns/route-name")
+		req.Header.Set(routeservice.RouteSignatureHeader, signer.SignRoute(types.NamespacedName{Namespace: "route-ns", Name: "route-name"}))
+	})
+
+	AfterEach(func() {
+		proxyServer.Close()
+		routeServiceServer.Close()
+		destinationServer.Close()
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		resp, err = http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		resp.Body.Close()
+	})
+
+	readBody := func() string {
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	It("resolves the backends of the route", func() {
+		Expect(resolver.ResolveBackendsCallCount()).To(Equal(1))
+		_, route := resolver.ResolveBackendsArgsForCall(0)
+		Expect(route).To(Equal(types.NamespacedName{Namespace: "route-ns", Name: "route-name"}))
+	})
+
+	It("forwards the request to the route service", func() {
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(readBody()).To(Equal("route-service"))
+
+		Expect(routeServiceRequest.URL.Path).To(Equal("/proxy"))
+		Expect(routeServiceRequest.URL.RawQuery).To(Equal("rs=param"))
+		Expect(routeServiceRequest.Header.Get(routeservice.RouteHeader)).To(BeEmpty())
+		Expect(routeServiceRequest.Header.Get(routeservice.RouteSignatureHeader)).To(BeEmpty())
+		Expect(routeServiceRequest.Header.Get(routeservice.ForwardedURLHeader)).To(Equal("https://my-app.example.com/some/path?foo=bar"))
+		Expect(signer.Verify(
+			routeServiceRequest.Header.Get(routeservice.SignatureHeader),
+			"https://my-app.example.com/some/path?foo=bar",
+			time.Now(),
+		)).To(Succeed())
+	})
+
+	When("the request carries a valid signature", func() {
+		BeforeEach(func() {
+			signature, err := signer.Sign("https://my-app.example.com/some/path?foo=bar", time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(routeservice.SignatureHeader, signature)
+			req.Header.Set(routeservice.ForwardedURLHeader, "https://my-app.example.com/some/path?foo=bar")
+		})
+
+		It("forwards the request to the destination", func() {
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(readBody()).To(Equal("destination"))
+			Expect(routeServiceRequest).To(BeNil())
+
+			Expect(destinationRequest.Host).To(Equal("my-app.example.com"))
+			Expect(destinationRequest.URL.RequestURI()).To(Equal("/some/path?foo=bar"))
+			Expect(destinationRequest.Header.Get(routeservice.RouteHeader)).To(BeEmpty())
+			Expect(destinationRequest.Header.Get(routeservice.RouteSignatureHeader)).To(BeEmpty())
+			Expect(destinationRequest.Header.Get(routeservice.SignatureHeader)).To(BeEmpty())
+			Expect(destinationRequest.Header.Get(routeservice.ForwardedURLHeader)).To(BeEmpty())
+		})
+	})
+
+	When("the signature has expired", func() {
+		BeforeEach(func() {
+			signature, err := signer.Sign("https://my-app.example.com/some/path?foo=bar", time.Now().Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(routeservice.SignatureHeader, signature)
+		})
+
+		It("forwards the request to the route service again", func() {
+			Expect(readBody()).To(Equal("route-service"))
+			Expect(destinationRequest).To(BeNil())
+		})
+	})
+
+	When("the signature was issued for another url", func() {
+		BeforeEach(func() {
+			signature, err := signer.Sign("https://my-app.example.com/another/path", time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(routeservice.SignatureHeader, signature)
+		})
+
+		It("forwards the request to the route service again", func() {
+			Expect(readBody()).To(Equal("route-service"))
+			Expect(destinationRequest).To(BeNil())
+		})
+	})
+
+	When("the route is no longer bound to a route service", func() {
+		BeforeEach(func() {
+			resolver.ResolveBackendsReturns(routeservice.Backends{
+				Destination: mustParseURL(destinationServer.URL),
+			}, nil)
+		})
+
+		It("forwards the request to the destination", func() {
+			Expect(readBody()).To(Equal("destination"))
+		})
+	})
+
+	When("the route has no destinations", func() {
+		BeforeEach(func() {
+			signature, err := signer.Sign("https://my-app.example.com/some/path?foo=bar", time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(routeservice.SignatureHeader, signature)
+
+			resolver.ResolveBackendsReturns(routeservice.Backends{
+				RouteService: mustParseURL(routeServiceServer.URL),
+			}, nil)
+		})
+
+		It("returns service unavailable", func() {
+			Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		})
+	})
+
+	When("the route header is missing", func() {
+		BeforeEach(func() {
+			req.Header.Del(routeservice.RouteHeader)
+		})
+
+		It("returns bad request", func() {
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(resolver.ResolveBackendsCallCount()).To(BeZero())
+		})
+	})
+
+	When("the route signature is missing", func() {
+		BeforeEach(func() {
+			req.Header.Del(routeservice.RouteSignatureHeader)
+		})
+
+		It("returns forbidden", func() {
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+			Expect(resolver.ResolveBackendsCallCount()).To(BeZero())
+			Expect(routeServiceRequest).To(BeNil())
+			Expect(destinationRequest).To(BeNil())
+		})
+	})
+
+	When("the route signature was issued for another route", func() {
+		BeforeEach(func() {
+			req.Header.Set(routeservice.RouteSignatureHeader, signer.SignRoute(types.NamespacedName{Namespace: "route-ns", Name: "another-route"}))
+		})
+
+		It("returns forbidden", func() {
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+			Expect(resolver.ResolveBackendsCallCount()).To(BeZero())
+		})
+	})
+
+	When("resolving the backends fails", func() {
+		BeforeEach(func() {
+			resolver.ResolveBackendsReturns(routeservice.Backends{}, errors.New("resolve-err"))
+		})
+
+		It("returns bad gateway", func() {
+			Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+		})
+	})
+})
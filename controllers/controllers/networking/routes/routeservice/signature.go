@@ -0,0 +1,114 @@
+package routeservice
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// routeSignaturePrefix keeps route signatures apart from request signatures,
+// whose payloads are base64url encoded and can never contain a colon
+const routeSignaturePrefix = "route:"
+
+type signaturePayload struct {
+	RequestedTime int64  `json:"requested_time"`
+	ForwardedURL  string `json:"forwarded_url"`
+}
+
+// Signer produces and verifies the X-CF-Proxy-Signature header. Like the CF
+// router signature, it carries the time the request was forwarded to the
+// route service and the url it was forwarded from, so that it can neither be
+// replayed after it expires nor used for another url
+type Signer struct {
+	key []byte
+	ttl time.Duration
+}
+
+func NewSigner(key []byte, ttl time.Duration) *Signer {
+	return &Signer{
+		key: key,
+		ttl: ttl,
+	}
+}
+
+func (s *Signer) Sign(forwardedURL string, requestedTime time.Time) (string, error) {
+	payload, err := json.Marshal(signaturePayload{
+		RequestedTime: requestedTime.Unix(),
+		ForwardedURL:  forwardedURL,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal signature payload: %w", err)
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(s.mac(encodedPayload)), nil
+}
+
+func (s *Signer) Verify(signature string, forwardedURL string, now time.Time) error {
+	encodedPayload, encodedMAC, ok := strings.Cut(signature, ".")
+	if !ok {
+		return errors.New("malformed signature")
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+
+	if !hmac.Equal(mac, s.mac(encodedPayload)) {
+		return errors.New("invalid signature")
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return fmt.Errorf("malformed signature payload: %w", err)
+	}
+
+	var payload signaturePayload
+	if err = json.Unmarshal(rawPayload, &payload); err != nil {
+		return fmt.Errorf("malformed signature payload: %w", err)
+	}
+
+	if now.Sub(time.Unix(payload.RequestedTime, 0)) > s.ttl {
+		return errors.New("signature expired")
+	}
+
+	if payload.ForwardedURL != forwardedURL {
+		return fmt.Errorf("signature was issued for url %q", payload.ForwardedURL)
+	}
+
+	return nil
+}
+
+// SignRoute produces the X-Korifi-Route-Signature header the HTTPRoute of a
+// route sets next to the X-Korifi-Route header. Only holders of the signing
+// key can produce it, so the proxy only serves routes the gateway sent it
+func (s *Signer) SignRoute(route types.NamespacedName) string {
+	return base64.RawURLEncoding.EncodeToString(s.mac(routeSignaturePrefix + route.String()))
+}
+
+func (s *Signer) VerifyRoute(signature string, route types.NamespacedName) error {
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("malformed route signature: %w", err)
+	}
+
+	if !hmac.Equal(mac, s.mac(routeSignaturePrefix+route.String())) {
+		return errors.New("invalid route signature")
+	}
+
+	return nil
+}
+
+func (s *Signer) mac(encodedPayload string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(encodedPayload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,161 @@
+package routeservice_test
+
+import (
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/routeservice"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Signer", func() {
+	var (
+		signer      *routeservice.Signer
+		signedAt    time.Time
+		signature   string
+		verifiedAt  time.Time
+		verifiedURL string
+		verifyErr   error
+	)
+
+	BeforeEach(func() {
+		signer = routeservice.NewSigner([]byte("signing-key"), time.Minute)
+		signedAt = time.Now()
+		verifiedAt = signedAt.Add(30 * time.Second)
+		verifiedURL = "https://my-app.example.com/some/path?foo=bar"
+
+		var err error
+		signature, err = signer.Sign("https://my-app.example.com/some/path?foo=bar", signedAt)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		verifyErr = signer.Verify(signature, verifiedURL, verifiedAt)
+	})
+
+	It("verifies the signature", func() {
+		Expect(verifyErr).NotTo(HaveOccurred())
+	})
+
+	When("the signature has expired", func() {
+		BeforeEach(func() {
+			verifiedAt = signedAt.Add(2 * time.Minute)
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError("signature expired"))
+		})
+	})
+
+	When("the signature was issued for another url", func() {
+		BeforeEach(func() {
+			verifiedURL = "https://my-app.example.com/another/path"
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError(ContainSubstring("signature was issued for url")))
+		})
+	})
+
+	When("the signature was issued with another key", func() {
+		BeforeEach(func() {
+			var err error
+			signature, err = routeservice.NewSigner([]byte("another-key"), time.Minute).Sign(verifiedURL, signedAt)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError("invalid signature"))
+		})
+	})
+
+	When("the signature payload has been tampered with", func() {
+		BeforeEach(func() {
+			_, mac, _ := strings.Cut(signature, ".")
+			tamperedSignature, err := signer.Sign(verifiedURL, signedAt.Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			payload, _, _ := strings.Cut(tamperedSignature, ".")
+			signature = payload + "." + mac
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError("invalid signature"))
+		})
+	})
+
+	When("the signature is malformed", func() {
+		BeforeEach(func() {
+			signature = "not-a-signature"
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError("malformed signature"))
+		})
+	})
+})
+
+var _ = Describe("Signer routes", func() {
+	var (
+		signer    *routeservice.Signer
+		route     types.NamespacedName
+		signature string
+		verifyErr error
+	)
+
+	BeforeEach(func() {
+		signer = routeservice.NewSigner([]byte("signing-key"), time.Minute)
+		route = types.NamespacedName{Namespace: "route-ns", Name: "route-name"}
+		signature = signer.SignRoute(route)
+	})
+
+	JustBeforeEach(func() {
+		verifyErr = signer.VerifyRoute(signature, route)
+	})
+
+	It("verifies the route signature", func() {
+		Expect(verifyErr).NotTo(HaveOccurred())
+	})
+
+	When("the signature was issued for another route", func() {
+		BeforeEach(func() {
+			signature = signer.SignRoute(types.NamespacedName{Namespace: "route-ns", Name: "another-route"})
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError("invalid route signature"))
+		})
+	})
+
+	When("the signature was issued with another key", func() {
+		BeforeEach(func() {
+			signature = routeservice.NewSigner([]byte("another-key"), time.Minute).SignRoute(route)
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError("invalid route signature"))
+		})
+	})
+
+	When("the signature is missing", func() {
+		BeforeEach(func() {
+			signature = ""
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError("invalid route signature"))
+		})
+	})
+
+	When("the signature is malformed", func() {
+		BeforeEach(func() {
+			signature = "not a signature"
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError(ContainSubstring("malformed route signature")))
+		})
+	})
+})
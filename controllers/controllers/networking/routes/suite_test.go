@@ -9,6 +9,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/routeservice"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
@@ -20,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
@@ -30,6 +32,7 @@ var (
 	testEnv         *envtest.Environment
 	adminClient     client.Client
 	ctx             context.Context
+	routeSigner     *routeservice.Signer
 )
 
 func TestNetworkingControllers(t *testing.T) {
@@ -59,12 +62,14 @@ var _ = BeforeSuite(func() {
 
 	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(gatewayv1beta1.Install(scheme.Scheme)).To(Succeed())
+	Expect(gatewayv1alpha2.Install(scheme.Scheme)).To(Succeed())
 
 	k8sManager := helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
 	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	routeSigner = routeservice.NewSigner([]byte("signing-key"), time.Minute)
 	Expect(routes.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
@@ -77,8 +82,13 @@ var _ = BeforeSuite(func() {
 			Networking: config.Networking{
 				GatewayName:      "korifi",
 				GatewayNamespace: "korifi-gateway",
+				RouteServiceProxy: config.RouteServiceProxy{
+					Host: "korifi-controllers-route-service-proxy.korifi.svc.cluster.local",
+					Port: 8082,
+				},
 			},
 		},
+		routeSigner,
 	).SetupWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
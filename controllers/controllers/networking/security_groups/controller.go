@@ -0,0 +1,289 @@
+package securitygroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Build pods created by kpack inherit the labels of the kpack image, which
+// carry the name of the build workload they are staging
+const stagingPodLabelKey = "korifi.cloudfoundry.org/build-workload-name"
+
+type Reconciler struct {
+	k8sClient client.Client
+	log       logr.Logger
+}
+
+func NewReconciler(
+	k8sClient client.Client,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFSecurityGroup] {
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFSecurityGroup](log, k8sClient, &Reconciler{
+		k8sClient: k8sClient,
+		log:       log,
+	})
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFSecurityGroup{}).
+		Watches(
+			&korifiv1alpha1.CFSpace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueSpaceSecurityGroupRequests),
+		)
+}
+
+func (r *Reconciler) enqueueSpaceSecurityGroupRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	cfSpace, ok := o.(*korifiv1alpha1.CFSpace)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	securityGroups := &korifiv1alpha1.CFSecurityGroupList{}
+	if err := r.k8sClient.List(ctx, securityGroups); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, securityGroup := range securityGroups.Items {
+		if !appliesToSpace(securityGroup, cfSpace.Name) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&securityGroup),
+		})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;patch;delete
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfSecurityGroup.Status.ObservedGeneration = cfSecurityGroup.Generation
+	log.V(1).Info("set observed generation", "generation", cfSecurityGroup.Status.ObservedGeneration)
+
+	if !cfSecurityGroup.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.finalizeCFSecurityGroup(ctx, cfSecurityGroup)
+	}
+
+	egressRules, ruleErrors := toEgressRules(cfSecurityGroup.Spec.Rules)
+	setRulesValidCondition(cfSecurityGroup, ruleErrors)
+
+	desiredPolicies, err := r.desiredNetworkPolicies(ctx, cfSecurityGroup)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ListSpaces")
+	}
+
+	if len(egressRules) == 0 {
+		// A network policy without egress rules would deny all egress
+		// traffic of the selected pods, which is not what an empty security
+		// group means
+		desiredPolicies = map[types.NamespacedName]string{}
+	}
+
+	for policyKey, workloadType := range desiredPolicies {
+		if err = r.createOrPatchNetworkPolicy(ctx, cfSecurityGroup, policyKey, workloadType, egressRules); err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileNetworkPolicy")
+		}
+	}
+
+	if err = r.deleteOrphanedNetworkPolicies(ctx, cfSecurityGroup, desiredPolicies); err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("DeleteNetworkPolicy")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func setRulesValidCondition(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup, ruleErrors []ruleError) {
+	if len(ruleErrors) == 0 {
+		meta.SetStatusCondition(&cfSecurityGroup.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SecurityGroupRulesValidConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Valid",
+			ObservedGeneration: cfSecurityGroup.Generation,
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&cfSecurityGroup.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SecurityGroupRulesValidConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "UnsupportedRules",
+		Message:            errors.Join(slices.Collect(it.Map(slices.Values(ruleErrors), func(e ruleError) error { return e }))...).Error(),
+		ObservedGeneration: cfSecurityGroup.Generation,
+	})
+}
+
+// desiredNetworkPolicies returns the network policies the security group
+// should have, mapped to the type of workload they apply to. Spaces whose
+// namespace has not been created yet are skipped; the CFSpace watch
+// requeues the security group once they are.
+func (r *Reconciler) desiredNetworkPolicies(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) (map[types.NamespacedName]string, error) {
+	cfSpaces := &korifiv1alpha1.CFSpaceList{}
+	if err := r.k8sClient.List(ctx, cfSpaces); err != nil {
+		return nil, fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	desiredPolicies := map[types.NamespacedName]string{}
+	for _, cfSpace := range cfSpaces.Items {
+		if cfSpace.Status.GUID == "" {
+			continue
+		}
+
+		workloads := cfSecurityGroup.Spec.Spaces[cfSpace.Name]
+
+		if workloads.Running || cfSecurityGroup.Spec.GloballyEnabled.Running {
+			desiredPolicies[policyKey(cfSecurityGroup, cfSpace.Status.GUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)] = korifiv1alpha1.SecurityGroupRunningWorkloadType
+		}
+
+		if workloads.Staging || cfSecurityGroup.Spec.GloballyEnabled.Staging {
+			desiredPolicies[policyKey(cfSecurityGroup, cfSpace.Status.GUID, korifiv1alpha1.SecurityGroupStagingWorkloadType)] = korifiv1alpha1.SecurityGroupStagingWorkloadType
+		}
+	}
+
+	return desiredPolicies, nil
+}
+
+func (r *Reconciler) createOrPatchNetworkPolicy(
+	ctx context.Context,
+	cfSecurityGroup *korifiv1alpha1.CFSecurityGroup,
+	policyKey types.NamespacedName,
+	workloadType string,
+	egressRules []networkingv1.NetworkPolicyEgressRule,
+) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchNetworkPolicy").WithValues("networkPolicy", policyKey)
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policyKey.Name,
+			Namespace: policyKey.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, networkPolicy, func() error {
+		if networkPolicy.Labels == nil {
+			networkPolicy.Labels = map[string]string{}
+		}
+		networkPolicy.Labels[korifiv1alpha1.CFSecurityGroupGUIDLabelKey] = cfSecurityGroup.Name
+		networkPolicy.Labels[korifiv1alpha1.CFSecurityGroupWorkloadTypeLabelKey] = workloadType
+
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: workloadPodSelector(workloadType),
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egressRules,
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch NetworkPolicy", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("NetworkPolicy reconciled", "operation", result)
+	return nil
+}
+
+func (r *Reconciler) deleteOrphanedNetworkPolicies(
+	ctx context.Context,
+	cfSecurityGroup *korifiv1alpha1.CFSecurityGroup,
+	desiredPolicies map[types.NamespacedName]string,
+) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedNetworkPolicies")
+
+	networkPolicies := &networkingv1.NetworkPolicyList{}
+	if err := r.k8sClient.List(ctx, networkPolicies, client.MatchingLabels{
+		korifiv1alpha1.CFSecurityGroupGUIDLabelKey: cfSecurityGroup.Name,
+	}); err != nil {
+		log.Info("failed to list network policies", "reason", err)
+		return err
+	}
+
+	for i := range networkPolicies.Items {
+		if _, desired := desiredPolicies[client.ObjectKeyFromObject(&networkPolicies.Items[i])]; desired {
+			continue
+		}
+
+		if err := r.k8sClient.Delete(ctx, &networkPolicies.Items[i]); client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete network policy", "name", networkPolicies.Items[i].Name, "namespace", networkPolicies.Items[i].Namespace, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) finalizeCFSecurityGroup(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFSecurityGroup")
+
+	if !controllerutil.ContainsFinalizer(cfSecurityGroup, korifiv1alpha1.CFSecurityGroupFinalizerName) {
+		return nil
+	}
+
+	// Network policies live in the space namespaces and cannot be owned by
+	// the security group, so they have to be deleted explicitly
+	if err := r.deleteOrphanedNetworkPolicies(ctx, cfSecurityGroup, map[types.NamespacedName]string{}); err != nil {
+		return err
+	}
+
+	if controllerutil.RemoveFinalizer(cfSecurityGroup, korifiv1alpha1.CFSecurityGroupFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+
+	return nil
+}
+
+func appliesToSpace(securityGroup korifiv1alpha1.CFSecurityGroup, spaceGUID string) bool {
+	if securityGroup.Spec.GloballyEnabled.Running || securityGroup.Spec.GloballyEnabled.Staging {
+		return true
+	}
+
+	_, ok := securityGroup.Spec.Spaces[spaceGUID]
+	return ok
+}
+
+func policyKey(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup, namespace, workloadType string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s-%s", cfSecurityGroup.Name, workloadType),
+	}
+}
+
+func workloadPodSelector(workloadType string) metav1.LabelSelector {
+	labelKey := korifiv1alpha1.CFProcessTypeLabelKey
+	if workloadType == korifiv1alpha1.SecurityGroupStagingWorkloadType {
+		labelKey = stagingPodLabelKey
+	}
+
+	return metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      labelKey,
+			Operator: metav1.LabelSelectorOpExists,
+		}},
+	}
+}
@@ -0,0 +1,273 @@
+package securitygroups_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFSecurityGroupReconciler Integration Tests", func() {
+	var (
+		rootNamespace   string
+		spaceGUID       string
+		otherSpaceGUID  string
+		cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+	)
+
+	createSpace := func(orgNamespace string) string {
+		spaceGUID := uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: spaceGUID},
+		})).To(Succeed())
+
+		cfSpace := &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: orgNamespace,
+				Name:      spaceGUID,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: uuid.NewString(),
+			},
+		}
+		Expect(adminClient.Create(ctx, cfSpace)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, cfSpace, func() {
+			cfSpace.Status.GUID = spaceGUID
+		})).To(Succeed())
+
+		return spaceGUID
+	}
+
+	getNetworkPolicy := func(g Gomega, namespace, workloadType string) *networkingv1.NetworkPolicy {
+		networkPolicy := &networkingv1.NetworkPolicy{}
+		g.Expect(adminClient.Get(ctx, client.ObjectKey{
+			Namespace: namespace,
+			Name:      cfSecurityGroup.Name + "-" + workloadType,
+		}, networkPolicy)).To(Succeed())
+
+		return networkPolicy
+	}
+
+	expectNoNetworkPolicy := func(g Gomega, namespace, workloadType string) {
+		err := adminClient.Get(ctx, client.ObjectKey{
+			Namespace: namespace,
+			Name:      cfSecurityGroup.Name + "-" + workloadType,
+		}, &networkingv1.NetworkPolicy{})
+		g.Expect(err).To(MatchError(ContainSubstring("not found")))
+	}
+
+	BeforeEach(func() {
+		rootNamespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: rootNamespace},
+		})).To(Succeed())
+
+		spaceGUID = createSpace(rootNamespace)
+		otherSpaceGUID = createSpace(rootNamespace)
+
+		cfSecurityGroup = &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+				Finalizers: []string{
+					korifiv1alpha1.CFSecurityGroupFinalizerName,
+				},
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: uuid.NewString(),
+				Rules: []korifiv1alpha1.SecurityGroupRule{
+					{Protocol: korifiv1alpha1.ProtocolTCP, Destination: "10.0.0.1", Ports: "80,443"},
+					{Protocol: korifiv1alpha1.ProtocolUDP, Destination: "10.0.0.0/24", Ports: "1000-2000"},
+					{Protocol: korifiv1alpha1.ProtocolALL, Destination: "192.168.0.1-192.168.0.2"},
+				},
+				Spaces: map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					spaceGUID: {Running: true},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+	})
+
+	It("sets the ready and rules valid conditions", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+			g.Expect(cfSecurityGroup.Status.ObservedGeneration).To(Equal(cfSecurityGroup.Generation))
+			g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.SecurityGroupRulesValidConditionType)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("creates a running network policy in the bound space", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := getNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+			g.Expect(networkPolicy.Labels).To(MatchKeys(IgnoreExtras, Keys{
+				korifiv1alpha1.CFSecurityGroupGUIDLabelKey:         Equal(cfSecurityGroup.Name),
+				korifiv1alpha1.CFSecurityGroupWorkloadTypeLabelKey: Equal(korifiv1alpha1.SecurityGroupRunningWorkloadType),
+			}))
+			g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+			g.Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+				Key:      korifiv1alpha1.CFProcessTypeLabelKey,
+				Operator: metav1.LabelSelectorOpExists,
+			}))
+			g.Expect(networkPolicy.Spec.Egress).To(ConsistOf(
+				networkingv1.NetworkPolicyEgressRule{
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: tools.PtrTo(corev1.ProtocolTCP), Port: tools.PtrTo(intstr.FromInt32(80))},
+						{Protocol: tools.PtrTo(corev1.ProtocolTCP), Port: tools.PtrTo(intstr.FromInt32(443))},
+					},
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}}},
+				},
+				networkingv1.NetworkPolicyEgressRule{
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: tools.PtrTo(corev1.ProtocolUDP), Port: tools.PtrTo(intstr.FromInt32(1000)), EndPort: tools.PtrTo[int32](2000)},
+					},
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/24"}}},
+				},
+				networkingv1.NetworkPolicyEgressRule{
+					To: []networkingv1.NetworkPolicyPeer{
+						{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.1/32"}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.2/32"}},
+					},
+				},
+			))
+		}).Should(Succeed())
+	})
+
+	It("does not create network policies for unbound workloads and spaces", func() {
+		Consistently(func(g Gomega) {
+			expectNoNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupStagingWorkloadType)
+			expectNoNetworkPolicy(g, otherSpaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+			expectNoNetworkPolicy(g, otherSpaceGUID, korifiv1alpha1.SecurityGroupStagingWorkloadType)
+		}, "2s").Should(Succeed())
+	})
+
+	When("the security group is bound to the space for staging", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Spaces[spaceGUID] = korifiv1alpha1.SecurityGroupWorkloads{Staging: true}
+		})
+
+		It("creates a staging network policy selecting build pods", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := getNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupStagingWorkloadType)
+				g.Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+					Key:      "korifi.cloudfoundry.org/build-workload-name",
+					Operator: metav1.LabelSelectorOpExists,
+				}))
+				expectNoNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+			}).Should(Succeed())
+		})
+	})
+
+	When("the security group is globally enabled", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Spaces = nil
+			cfSecurityGroup.Spec.GloballyEnabled = korifiv1alpha1.SecurityGroupWorkloads{Running: true, Staging: true}
+		})
+
+		It("creates network policies in all spaces", func() {
+			Eventually(func(g Gomega) {
+				getNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+				getNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupStagingWorkloadType)
+				getNetworkPolicy(g, otherSpaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+				getNetworkPolicy(g, otherSpaceGUID, korifiv1alpha1.SecurityGroupStagingWorkloadType)
+			}).Should(Succeed())
+		})
+
+		When("a new space is created", func() {
+			var newSpaceGUID string
+
+			JustBeforeEach(func() {
+				newSpaceGUID = createSpace(rootNamespace)
+			})
+
+			It("creates network policies in the new space", func() {
+				Eventually(func(g Gomega) {
+					getNetworkPolicy(g, newSpaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+					getNetworkPolicy(g, newSpaceGUID, korifiv1alpha1.SecurityGroupStagingWorkloadType)
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the security group is unbound from a space", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				getNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+			}).Should(Succeed())
+
+			helpers.EnsurePatch(adminClient, cfSecurityGroup, func(sg *korifiv1alpha1.CFSecurityGroup) {
+				sg.Spec.Spaces = map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					otherSpaceGUID: {Running: true},
+				}
+			})
+		})
+
+		It("moves the network policy to the newly bound space", func() {
+			Eventually(func(g Gomega) {
+				expectNoNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+				getNetworkPolicy(g, otherSpaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+			}).Should(Succeed())
+		})
+	})
+
+	When("a rule cannot be expressed as a network policy", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Rules = append(cfSecurityGroup.Spec.Rules, korifiv1alpha1.SecurityGroupRule{
+				Protocol:    "icmp",
+				Destination: "10.0.0.1",
+			})
+		})
+
+		It("reports it in the rules valid condition", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+
+				rulesValidCondition := meta.FindStatusCondition(cfSecurityGroup.Status.Conditions, korifiv1alpha1.SecurityGroupRulesValidConditionType)
+				g.Expect(rulesValidCondition).NotTo(BeNil())
+				g.Expect(rulesValidCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(rulesValidCondition.Reason).To(Equal("UnsupportedRules"))
+				g.Expect(rulesValidCondition.Message).To(ContainSubstring(`rules[3]: protocol "icmp" cannot be expressed`))
+			}).Should(Succeed())
+		})
+
+		It("still applies the remaining rules", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := getNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+				g.Expect(networkPolicy.Spec.Egress).To(HaveLen(3))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the security group is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				getNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, cfSecurityGroup)).To(Succeed())
+		})
+
+		It("deletes the network policies and the security group", func() {
+			Eventually(func(g Gomega) {
+				expectNoNetworkPolicy(g, spaceGUID, korifiv1alpha1.SecurityGroupRunningWorkloadType)
+
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)
+				g.Expect(err).To(MatchError(ContainSubstring("not found")))
+			}).Should(Succeed())
+		})
+	})
+})
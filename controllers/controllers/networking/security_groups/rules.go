@@ -0,0 +1,193 @@
+package securitygroups
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"net"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type ruleError struct {
+	index int
+	err   error
+}
+
+func (e ruleError) Error() string {
+	return fmt.Sprintf("rules[%d]: %s", e.index, e.err.Error())
+}
+
+// toEgressRules translates the security group rules into network policy
+// egress rules. Rules that cannot be expressed as a network policy are
+// skipped and reported back to the caller.
+func toEgressRules(rules []korifiv1alpha1.SecurityGroupRule) ([]networkingv1.NetworkPolicyEgressRule, []ruleError) {
+	egressRules := []networkingv1.NetworkPolicyEgressRule{}
+	ruleErrors := []ruleError{}
+
+	for i, rule := range rules {
+		egressRule, err := toEgressRule(rule)
+		if err != nil {
+			ruleErrors = append(ruleErrors, ruleError{index: i, err: err})
+			continue
+		}
+
+		egressRules = append(egressRules, egressRule)
+	}
+
+	return egressRules, ruleErrors
+}
+
+func toEgressRule(rule korifiv1alpha1.SecurityGroupRule) (networkingv1.NetworkPolicyEgressRule, error) {
+	ports, err := toPorts(rule.Protocol, rule.Ports)
+	if err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, err
+	}
+
+	peers, err := toPeers(rule.Destination)
+	if err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, err
+	}
+
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: ports,
+		To:    peers,
+	}, nil
+}
+
+func toPorts(protocol, ports string) ([]networkingv1.NetworkPolicyPort, error) {
+	var k8sProtocol corev1.Protocol
+	switch protocol {
+	case korifiv1alpha1.ProtocolALL:
+		// A rule without ports matches all ports and protocols
+		return nil, nil
+	case korifiv1alpha1.ProtocolTCP:
+		k8sProtocol = corev1.ProtocolTCP
+	case korifiv1alpha1.ProtocolUDP:
+		k8sProtocol = corev1.ProtocolUDP
+	default:
+		return nil, fmt.Errorf("protocol %q cannot be expressed as a network policy", protocol)
+	}
+
+	if start, end, isRange := strings.Cut(ports, "-"); isRange {
+		startPort, err := parsePort(start)
+		if err != nil {
+			return nil, err
+		}
+
+		endPort, err := parsePort(end)
+		if err != nil {
+			return nil, err
+		}
+
+		if startPort > endPort {
+			return nil, fmt.Errorf("invalid port range %q", ports)
+		}
+
+		return []networkingv1.NetworkPolicyPort{{
+			Protocol: tools.PtrTo(k8sProtocol),
+			Port:     tools.PtrTo(intstr.FromInt32(startPort)),
+			EndPort:  tools.PtrTo(endPort),
+		}}, nil
+	}
+
+	policyPorts := []networkingv1.NetworkPolicyPort{}
+	for _, p := range strings.Split(ports, ",") {
+		port, err := parsePort(p)
+		if err != nil {
+			return nil, err
+		}
+
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: tools.PtrTo(k8sProtocol),
+			Port:     tools.PtrTo(intstr.FromInt32(port)),
+		})
+	}
+
+	return policyPorts, nil
+}
+
+func parsePort(port string) (int32, error) {
+	p, err := strconv.ParseInt(strings.TrimSpace(port), 10, 32)
+	if err != nil || p < 1 || p > math.MaxUint16 {
+		return 0, fmt.Errorf("invalid port %q", port)
+	}
+
+	return int32(p), nil
+}
+
+func toPeers(destinations string) ([]networkingv1.NetworkPolicyPeer, error) {
+	peers := []networkingv1.NetworkPolicyPeer{}
+
+	for _, destination := range strings.Split(destinations, ",") {
+		cidrs, err := toCIDRs(strings.TrimSpace(destination))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cidr := range cidrs {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+	}
+
+	return peers, nil
+}
+
+func toCIDRs(destination string) ([]string, error) {
+	if ip := net.ParseIP(destination).To4(); ip != nil {
+		return []string{ip.String() + "/32"}, nil
+	}
+
+	if _, ipNet, err := net.ParseCIDR(destination); err == nil && ipNet.IP.To4() != nil {
+		return []string{ipNet.String()}, nil
+	}
+
+	if start, end, isRange := strings.Cut(destination, "-"); isRange {
+		startIP := net.ParseIP(strings.TrimSpace(start)).To4()
+		endIP := net.ParseIP(strings.TrimSpace(end)).To4()
+		if startIP == nil || endIP == nil {
+			return nil, fmt.Errorf("invalid destination IP address range %q", destination)
+		}
+
+		startAddr := binary.BigEndian.Uint32(startIP)
+		endAddr := binary.BigEndian.Uint32(endIP)
+		if startAddr > endAddr {
+			return nil, fmt.Errorf("invalid destination IP address range %q", destination)
+		}
+
+		return rangeToCIDRs(startAddr, endAddr), nil
+	}
+
+	return nil, fmt.Errorf("invalid destination %q", destination)
+}
+
+// rangeToCIDRs splits an inclusive IPv4 address range into the minimal list
+// of CIDR blocks covering it
+func rangeToCIDRs(start, end uint32) []string {
+	cidrs := []string{}
+
+	current := uint64(start)
+	for current <= uint64(end) {
+		blockBits := bits.TrailingZeros32(uint32(current))
+		for blockBits > 0 && current+(uint64(1)<<blockBits)-1 > uint64(end) {
+			blockBits--
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, uint32(current))
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip.String(), 32-blockBits))
+
+		current += uint64(1) << blockBits
+	}
+
+	return cidrs
+}
@@ -18,6 +18,8 @@ package bindings
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
@@ -77,7 +79,6 @@ func (r *Reconciler) serviceInstanceToServiceBindings(ctx context.Context, o cli
 
 	serviceBindings := korifiv1alpha1.CFServiceBindingList{}
 	if err := r.k8sClient.List(ctx, &serviceBindings,
-		client.InNamespace(serviceInstance.Namespace),
 		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: serviceInstance.Name},
 	); err != nil {
 		return []reconcile.Request{}
@@ -107,12 +108,19 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 	log.V(1).Info("set observed generation", "generation", cfServiceBinding.Status.ObservedGeneration)
 
 	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.ServiceInstanceNamespace()}, cfServiceInstance)
 	if err != nil {
 		log.Info("service instance not found", "service-instance", cfServiceBinding.Spec.Service.Name, "error", err)
 		return ctrl.Result{}, err
 	}
 
+	if cfServiceBinding.GetDeletionTimestamp().IsZero() && !isAccessible(cfServiceInstance, cfServiceBinding) {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("ServiceInstanceNotShared").
+			WithMessage(fmt.Sprintf("Service instance %q is not shared with namespace %q", cfServiceInstance.Name, cfServiceBinding.Namespace)).
+			WithNoRequeue()
+	}
+
 	cfServiceBinding.Annotations = tools.SetMapValue(cfServiceBinding.Annotations, korifiv1alpha1.ServiceInstanceTypeAnnotation, string(cfServiceInstance.Spec.Type))
 
 	res, err := r.reconcileByType(ctx, cfServiceInstance, cfServiceBinding)
@@ -134,6 +142,14 @@ func (r *Reconciler) reconcileByType(ctx context.Context, cfServiceInstance *kor
 	return r.managedReconciler.ReconcileResource(ctx, cfServiceBinding)
 }
 
+func isAccessible(cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) bool {
+	if cfServiceInstance.Namespace == cfServiceBinding.Namespace {
+		return true
+	}
+
+	return slices.Contains(cfServiceInstance.Spec.SharedSpaces, cfServiceBinding.Namespace)
+}
+
 func needsRequeue(res ctrl.Result, err error) bool {
 	if err != nil {
 		return true
@@ -309,6 +309,125 @@ var _ = Describe("CFServiceBinding", func() {
 				}).Should(Succeed())
 			})
 		})
+
+		When("the binding is a route binding", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.RouteServiceURL = tools.PtrTo("https://route-service.io")
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
+					binding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeRoute
+					binding.Spec.RouteRef = corev1.LocalObjectReference{Name: uuid.NewString()}
+				})).To(Succeed())
+			})
+
+			It("sets the route service url in the binding status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.RouteServiceURL).To(Equal("https://route-service.io"))
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+			})
+
+			When("the service instance has no route service url", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+						instance.Spec.RouteServiceURL = nil
+					})).To(Succeed())
+				})
+
+				It("sets the Ready condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("RouteServiceURLNotSet")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the binding is in a namespace the service instance is shared with", func() {
+			var sharedBinding *korifiv1alpha1.CFServiceBinding
+
+			BeforeEach(func() {
+				consumerNamespace := uuid.NewString()
+				Expect(adminClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: consumerNamespace,
+					},
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.SharedSpaces = []string{consumerNamespace}
+				})).To(Succeed())
+
+				sharedBinding = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: consumerNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Service: corev1.ObjectReference{
+							Kind:       "ServiceInstance",
+							Name:       instanceGUID,
+							Namespace:  testNamespace,
+							APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+						},
+						AppRef: corev1.LocalObjectReference{
+							Name: uuid.NewString(),
+						},
+						Type: korifiv1alpha1.CFServiceBindingTypeApp,
+					},
+				}
+				Expect(adminClient.Create(ctx, sharedBinding)).To(Succeed())
+			})
+
+			It("copies the instance credentials into the binding namespace", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), sharedBinding)).To(Succeed())
+					g.Expect(sharedBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+					g.Expect(sharedBinding.Status.EnvSecretRef.Name).To(Equal(sharedBinding.Name + "-env"))
+
+					envSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: sharedBinding.Namespace,
+							Name:      sharedBinding.Status.EnvSecretRef.Name,
+						},
+					}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+					g.Expect(envSecret.Data).To(Equal(instanceCredentialsSecret.Data))
+				}).Should(Succeed())
+			})
+
+			When("the service instance is not shared with the binding namespace", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+						instance.Spec.SharedSpaces = nil
+					})).To(Succeed())
+				})
+
+				It("sets the Ready condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), sharedBinding)).To(Succeed())
+						g.Expect(sharedBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("ServiceInstanceNotShared")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
 	})
 
 	Describe("managed service bindings", func() {
@@ -571,6 +690,55 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("binding is of type route", func() {
+			var cfRoute *korifiv1alpha1.CFRoute
+
+			BeforeEach(func() {
+				cfRoute = &korifiv1alpha1.CFRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFRouteSpec{
+						Host: "my-route",
+						DomainRef: corev1.ObjectReference{
+							Name:      uuid.NewString(),
+							Namespace: testNamespace,
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, cfRoute)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfRoute, func() {
+					cfRoute.Status.URI = "my-route.apps.com/path"
+				})).To(Succeed())
+
+				brokerClient.BindReturns(osbapi.BindResponse{
+					RouteServiceURL: "https://route-service.io",
+				}, nil)
+
+				Expect(k8s.Patch(ctx, adminClient, binding, func() {
+					binding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeRoute
+					binding.Spec.RouteRef = corev1.LocalObjectReference{Name: cfRoute.Name}
+				})).To(Succeed())
+			})
+
+			It("binds the service to the route", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.BindCallCount()).To(BeNumerically(">", 0))
+					_, payload := brokerClient.BindArgsForCall(brokerClient.BindCallCount() - 1)
+					g.Expect(payload.BindResource.Route).To(Equal("https://my-route.apps.com/path"))
+				}).Should(Succeed())
+			})
+
+			It("sets the route service url in the binding status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.RouteServiceURL).To(Equal("https://route-service.io"))
+					g.Expect(binding.Status.MountSecretRef.Name).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+
 		When("the credentials contain type key", func() {
 			BeforeEach(func() {
 				brokerClient.BindReturns(osbapi.BindResponse{
@@ -615,6 +783,183 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("the service offering supports retrieving bindings", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, serviceOffering, func() {
+					serviceOffering.Spec.BrokerCatalog.Features.BindingsRetrievable = true
+				})).To(Succeed())
+
+				brokerClient.GetServiceBindingReturns(osbapi.BindingResponse{
+					Credentials: map[string]any{
+						"foo": "retrieved-bar",
+					},
+				}, nil)
+			})
+
+			It("gets the credentials from the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.GetServiceBindingCallCount()).To(BeNumerically(">", 0))
+					_, payload := brokerClient.GetServiceBindingArgsForCall(0)
+					g.Expect(payload).To(Equal(osbapi.BindPayload{
+						InstanceID: instance.Name,
+						BindingID:  binding.Name,
+						BindRequest: osbapi.BindRequest{
+							ServiceId: "service-offering-id",
+							PlanID:    "service-plan-id",
+						},
+					}))
+
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					envSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: binding.Namespace,
+							Name:      binding.Status.EnvSecretRef.Name,
+						},
+					}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+					g.Expect(envSecret.Data).To(MatchKeys(IgnoreExtras, Keys{
+						tools.CredentialsSecretKey: BeEquivalentTo(`{"foo":"retrieved-bar"}`),
+					}))
+				}).Should(Succeed())
+			})
+
+			When("getting the binding fails", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceBindingReturns(osbapi.BindingResponse{}, errors.New("get-binding-failed"))
+				})
+
+				It("sets the ready condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("GetBindingFailed")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the binding credentials rotation is requested", func() {
+			var bindingRotatable bool
+
+			BeforeEach(func() {
+				bindingRotatable = true
+			})
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.MountSecretRef.Name).NotTo(BeEmpty())
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+					servicePlan.Spec.BrokerCatalog.Features.BindingRotatable = bindingRotatable
+				})).To(Succeed())
+
+				brokerClient.BindReturns(osbapi.BindResponse{
+					Credentials: map[string]any{
+						"foo": "rotated-bar",
+					},
+				}, nil)
+
+				Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
+					binding.Spec.CredentialsRotation = &korifiv1alpha1.CredentialsRotation{
+						ID: "rotation-1",
+					}
+				})).To(Succeed())
+			})
+
+			It("creates a new broker binding with the current one as its predecessor", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.BindCallCount()).To(BeNumerically(">", 1))
+					_, payload := brokerClient.BindArgsForCall(brokerClient.BindCallCount() - 1)
+					g.Expect(payload.BindingID).NotTo(Equal(binding.Name))
+					g.Expect(payload.PredecessorBindingID).To(Equal(binding.Name))
+				}).Should(Succeed())
+			})
+
+			It("updates the credentials secrets in place", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.EnvSecretRef.Name).To(Equal(binding.Name))
+
+					envSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: binding.Namespace,
+							Name:      binding.Status.EnvSecretRef.Name,
+						},
+					}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+					g.Expect(envSecret.Data).To(MatchKeys(IgnoreExtras, Keys{
+						tools.CredentialsSecretKey: BeEquivalentTo(`{"foo":"rotated-bar"}`),
+					}))
+
+					mountSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: binding.Namespace,
+							Name:      binding.Status.MountSecretRef.Name,
+						},
+					}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(mountSecret), mountSecret)).To(Succeed())
+					g.Expect(mountSecret.Data).To(MatchKeys(IgnoreExtras, Keys{
+						"foo": BeEquivalentTo("rotated-bar"),
+					}))
+				}).Should(Succeed())
+			})
+
+			It("records the completed rotation and the new broker binding", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.CredentialsRotationID).To(Equal("rotation-1"))
+					g.Expect(binding.Status.BrokerBindingID).NotTo(BeEmpty())
+					g.Expect(binding.Status.BrokerBindingID).NotTo(Equal(binding.Name))
+				}).Should(Succeed())
+			})
+
+			It("unbinds the predecessor", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UnbindCallCount()).To(BeNumerically(">", 0))
+					_, payload := brokerClient.UnbindArgsForCall(0)
+					g.Expect(payload.BindingID).To(Equal(binding.Name))
+
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.PredecessorBrokerBindingID).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("does not bind again once the rotation has completed", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.CredentialsRotationID).To(Equal("rotation-1"))
+				}).Should(Succeed())
+
+				bindCount := brokerClient.BindCallCount()
+				Consistently(func(g Gomega) {
+					g.Expect(brokerClient.BindCallCount()).To(Equal(bindCount))
+				}).Should(Succeed())
+			})
+
+			When("the service plan does not support binding rotation", func() {
+				BeforeEach(func() {
+					bindingRotatable = false
+				})
+
+				It("does not rotate the credentials", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("BindingNotRotatable")),
+						)))
+						g.Expect(binding.Status.CredentialsRotationID).To(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the binding has failed", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, binding, func() {
@@ -675,6 +1020,35 @@ var _ = Describe("CFServiceBinding", func() {
 				}).Should(Succeed())
 			})
 
+			It("records the asynchronous operation", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.AsyncOperation).NotTo(BeNil())
+					g.Expect(binding.Status.AsyncOperation.Type).To(Equal("create"))
+				}).Should(Succeed())
+			})
+
+			When("the maximum polling duration is exceeded", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+						servicePlan.Spec.BrokerCatalog.MaximumPollingDuration = tools.PtrTo[int32](0)
+					})).To(Succeed())
+				})
+
+				It("fails the binding", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+							HasReason(Equal("BindingTimedOut")),
+							HasMessage(ContainSubstring("maximum polling duration")),
+						)))
+						g.Expect(binding.Status.AsyncOperation).To(BeNil())
+					}).Should(Succeed())
+				})
+			})
+
 			When("getting binding last operation fails", func() {
 				BeforeEach(func() {
 					brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{}, errors.New("get-last-op-failed"))
@@ -1008,6 +1382,38 @@ var _ = Describe("CFServiceBinding", func() {
 					}).Should(Succeed())
 				})
 
+				When("the maximum polling duration is exceeded", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+							servicePlan.Spec.BrokerCatalog.MaximumPollingDuration = tools.PtrTo[int32](0)
+						})).To(Succeed())
+					})
+
+					It("sets the unbinding failed condition", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.UnbindingFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasReason(Equal("UnbindingTimedOut")),
+							)))
+						}).Should(Succeed())
+					})
+
+					It("does not call the broker again", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.UnbindingFailedCondition)).To(BeTrue())
+						}).Should(Succeed())
+
+						unbindCallCount := brokerClient.UnbindCallCount()
+						Consistently(func(g Gomega) {
+							g.Expect(brokerClient.UnbindCallCount()).To(Equal(unbindCallCount))
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						}).Should(Succeed())
+					})
+				})
+
 				When("the last operation is succeeded", func() {
 					BeforeEach(func() {
 						brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
@@ -22,6 +22,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+// predecessorUnbindRetryInterval is how often unbinding the broker binding
+// replaced by a credentials rotation is retried
+const predecessorUnbindRetryInterval = 10 * time.Second
+
 type ManagedBindingsReconciler struct {
 	k8sClient           client.Client
 	osbapiClientFactory osbapi.BrokerClientFactory
@@ -40,6 +44,7 @@ func NewReconciler(k8sClient client.Client, brokerClientFactory osbapi.BrokerCli
 
 func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("reconcile-managed-service-binding")
+	ctx = osbapi.WithOriginatingIdentity(ctx, cfServiceBinding.Annotations[korifiv1alpha1.OriginatingIdentityAnnotation])
 
 	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
 		return r.finalize(ctx, cfServiceBinding)
@@ -57,7 +62,11 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 		return ctrl.Result{}, err
 	}
 
-	if isReconciled(cfServiceBinding) {
+	if cfServiceBinding.Status.PredecessorBrokerBindingID != "" {
+		return r.unbindPredecessor(ctx, cfServiceBinding, assets, osbapiClient)
+	}
+
+	if isReconciled(cfServiceBinding) && !isRotationRequested(cfServiceBinding) {
 		return ctrl.Result{}, nil
 	}
 
@@ -67,22 +76,47 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 
 	cfServiceBinding.Labels = tools.SetMapValue(cfServiceBinding.Labels, korifiv1alpha1.PlanGUIDLabelKey, assets.ServicePlan.Name)
 
-	bindResponse, err := r.bind(ctx, cfServiceBinding, assets, osbapiClient)
+	bindingID, predecessorBindingID := brokerBindingID(cfServiceBinding), ""
+	if isReconciled(cfServiceBinding) {
+		if !assets.ServicePlan.Spec.BrokerCatalog.Features.BindingRotatable {
+			return ctrl.Result{}, k8s.NewNotReadyError().
+				WithReason("BindingNotRotatable").
+				WithMessage("The service plan does not support binding rotation.").
+				WithNoRequeue()
+		}
+
+		bindingID, predecessorBindingID = rotationBrokerBindingID(cfServiceBinding), bindingID
+	}
+
+	bindResponse, err := r.bind(ctx, cfServiceBinding, assets, osbapiClient, bindingID, predecessorBindingID)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	if bindResponse.IsAsync {
+		cfServiceBinding.Status.AsyncOperation = osbapi.TrackAsyncOperation(cfServiceBinding.Status.AsyncOperation, "create")
+
 		var lastOpResponse osbapi.LastOperationResponse
-		lastOpResponse, err = r.pollLastOperation(ctx, cfServiceBinding, assets, osbapiClient, bindResponse.Operation)
+		lastOpResponse, err = r.pollLastOperation(ctx, cfServiceBinding, assets, osbapiClient, bindingID, bindResponse.Operation)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
-		return r.processBindOperation(cfServiceBinding, lastOpResponse)
+		return r.processBindOperation(cfServiceBinding, assets, lastOpResponse)
+	}
+
+	if cfServiceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeRoute {
+		cfServiceBinding.Status.RouteServiceURL = bindResponse.RouteServiceURL
+		completeRotation(cfServiceBinding, bindingID)
+		return ctrl.Result{}, nil
 	}
 
-	envSecret, err := r.createEnvSecret(ctx, cfServiceBinding, bindResponse.Credentials)
+	creds, err := r.getCredentials(ctx, assets, osbapiClient, bindingID, bindResponse)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	envSecret, err := r.createEnvSecret(ctx, cfServiceBinding, creds)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -90,24 +124,60 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 	cfServiceBinding.Status.EnvSecretRef.Name = envSecret.Name
 
 	if cfServiceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeKey {
+		completeRotation(cfServiceBinding, bindingID)
 		return ctrl.Result{}, nil
 	}
 
-	mountSecret, err := r.createMountSecret(ctx, cfServiceBinding, bindResponse.Credentials)
+	mountSecret, err := r.createMountSecret(ctx, cfServiceBinding, creds)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	cfServiceBinding.Status.MountSecretRef.Name = mountSecret.Name
+	completeRotation(cfServiceBinding, bindingID)
 
 	return ctrl.Result{}, nil
 }
 
+// getCredentials returns the credentials of the binding. Brokers that
+// support retrieving bindings are asked for the credentials they currently
+// hold, as these may have been rotated since the binding has been created
+func (r *ManagedBindingsReconciler) getCredentials(
+	ctx context.Context,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+	bindingID string,
+	bindResponse osbapi.BindResponse,
+) (map[string]any, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !assets.ServiceOffering.Spec.BrokerCatalog.Features.BindingsRetrievable {
+		return bindResponse.Credentials, nil
+	}
+
+	bindingResponse, err := osbapiClient.GetServiceBinding(ctx, osbapi.BindPayload{
+		BindingID:  bindingID,
+		InstanceID: assets.ServiceInstance.Name,
+		BindRequest: osbapi.BindRequest{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		},
+	})
+	if err != nil {
+		log.Error(err, "failed to get binding")
+		return nil, k8s.NewNotReadyError().WithCause(err).WithReason("GetBindingFailed")
+	}
+
+	return bindingResponse.Credentials, nil
+}
+
 func (r *ManagedBindingsReconciler) bind(
 	ctx context.Context,
 	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
 	assets osbapi.ServiceBindingAssets,
 	osbapiClient osbapi.BrokerClient,
+	bindingID string,
+	predecessorBindingID string,
 ) (osbapi.BindResponse, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -116,8 +186,13 @@ func (r *ManagedBindingsReconciler) bind(
 		return osbapi.BindResponse{}, k8s.NewNotReadyError().WithReason("InvalidParameters")
 	}
 
+	routeURL, err := r.getRouteURL(ctx, cfServiceBinding)
+	if err != nil {
+		return osbapi.BindResponse{}, k8s.NewNotReadyError().WithCause(err).WithReason("RouteNotFound")
+	}
+
 	bindResponse, err := osbapiClient.Bind(ctx, osbapi.BindPayload{
-		BindingID:  cfServiceBinding.Name,
+		BindingID:  bindingID,
 		InstanceID: assets.ServiceInstance.Name,
 		BindRequest: osbapi.BindRequest{
 			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
@@ -125,8 +200,10 @@ func (r *ManagedBindingsReconciler) bind(
 			AppGUID:   cfServiceBinding.Spec.AppRef.Name,
 			BindResource: osbapi.BindResource{
 				AppGUID: cfServiceBinding.Spec.AppRef.Name,
+				Route:   routeURL,
 			},
-			Parameters: parameters,
+			Parameters:           parameters,
+			PredecessorBindingID: predecessorBindingID,
 		},
 	})
 	if err != nil {
@@ -170,15 +247,76 @@ func (r *ManagedBindingsReconciler) getParameters(ctx context.Context, cfService
 	return tools.FromParametersSecretData(paramsSecret.Data)
 }
 
+// getRouteURL returns the URL of the route the binding forwards to the route
+// service. It is empty for bindings that are not of type "route"
+func (r *ManagedBindingsReconciler) getRouteURL(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (string, error) {
+	if cfServiceBinding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeRoute {
+		return "", nil
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfServiceBinding.Namespace,
+			Name:      cfServiceBinding.Spec.RouteRef.Name,
+		},
+	}
+
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)
+	if err != nil {
+		return "", fmt.Errorf("failed to get route %q: %w", cfServiceBinding.Spec.RouteRef.Name, err)
+	}
+
+	return "https://" + cfRoute.Status.URI, nil
+}
+
+// unbindPredecessor unbinds the broker binding replaced by the latest
+// credentials rotation. The binding already uses the credentials of its new
+// broker binding, so failures are retried without making it not ready
+func (r *ManagedBindingsReconciler) unbindPredecessor(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("predecessor", cfServiceBinding.Status.PredecessorBrokerBindingID)
+
+	unbindResponse, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
+		InstanceID: cfServiceBinding.Spec.Service.Name,
+		BindingID:  cfServiceBinding.Status.PredecessorBrokerBindingID,
+		UnbindRequestParameters: osbapi.UnbindRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		log.Info("failed to unbind predecessor", "reason", err)
+		return ctrl.Result{RequeueAfter: predecessorUnbindRetryInterval}, nil
+	}
+
+	if unbindResponse.IsAsync {
+		lastOpResponse, err := r.pollLastOperation(ctx, cfServiceBinding, assets, osbapiClient, cfServiceBinding.Status.PredecessorBrokerBindingID, unbindResponse.Operation)
+		if err != nil || lastOpResponse.State != "succeeded" {
+			log.V(1).Info("predecessor unbind not completed", "state", lastOpResponse.State, "reason", err)
+			return ctrl.Result{RequeueAfter: max(lastOpResponse.RetryAfter, predecessorUnbindRetryInterval)}, nil
+		}
+	}
+
+	cfServiceBinding.Status.PredecessorBrokerBindingID = ""
+	return ctrl.Result{Requeue: true}, nil
+}
+
 func (r *ManagedBindingsReconciler) processBindOperation(
 	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
 	lastOperation osbapi.LastOperationResponse,
 ) (ctrl.Result, error) {
 	if lastOperation.State == "succeeded" {
+		cfServiceBinding.Status.AsyncOperation = nil
 		return ctrl.Result{Requeue: true}, nil
 	}
 
 	if lastOperation.State == "failed" {
+		cfServiceBinding.Status.AsyncOperation = nil
 		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.BindingFailedCondition,
 			Status:             metav1.ConditionTrue,
@@ -190,7 +328,12 @@ func (r *ManagedBindingsReconciler) processBindOperation(
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed").WithMessage(lastOperation.Description)
 	}
 
-	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingInProgress").WithRequeue()
+	if osbapi.IsPollingDeadlineExceeded(cfServiceBinding.Status.AsyncOperation, assets.MaximumPollingDuration()) {
+		cfServiceBinding.Status.AsyncOperation = nil
+		return ctrl.Result{}, osbapi.FailTimedOutOperation(cfServiceBinding, korifiv1alpha1.BindingFailedCondition, "BindingTimedOut", assets.MaximumPollingDuration())
+	}
+
+	return ctrl.Result{}, osbapi.InProgress("BindingInProgress", lastOperation)
 }
 
 func (r *ManagedBindingsReconciler) createEnvSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, creds map[string]any) (*corev1.Secret, error) {
@@ -273,7 +416,7 @@ func (r *ManagedBindingsReconciler) finalizeCFServiceBinding(
 ) error {
 	serviceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: serviceBinding.Namespace,
+			Namespace: serviceBinding.ServiceInstanceNamespace(),
 			Name:      serviceBinding.Spec.Service.Name,
 		},
 	}
@@ -287,6 +430,11 @@ func (r *ManagedBindingsReconciler) finalizeCFServiceBinding(
 		return nil
 	}
 
+	if osbapi.IsOperationTimedOut(serviceBinding, korifiv1alpha1.UnbindingFailedCondition, "UnbindingTimedOut") {
+		// The binding may still exist on the broker, the instance has to be purged
+		return k8s.NewNotReadyError().WithReason("UnbindingTimedOut").WithNoRequeue()
+	}
+
 	assets, err := r.assets.GetServiceBindingAssets(ctx, serviceBinding)
 	if err != nil {
 		return fmt.Errorf("failed to get service binding assets: %w", err)
@@ -297,17 +445,25 @@ func (r *ManagedBindingsReconciler) finalizeCFServiceBinding(
 		return fmt.Errorf("failed to client for broker %q: %w", assets.ServiceBroker.Name, err)
 	}
 
-	unbindResponse, err := r.deleteServiceBinding(ctx, serviceBinding, assets, osbapiClient)
+	if serviceBinding.Status.PredecessorBrokerBindingID != "" {
+		if _, err = r.deleteServiceBinding(ctx, serviceBinding, assets, osbapiClient, serviceBinding.Status.PredecessorBrokerBindingID); err != nil {
+			return err
+		}
+		serviceBinding.Status.PredecessorBrokerBindingID = ""
+	}
+
+	unbindResponse, err := r.deleteServiceBinding(ctx, serviceBinding, assets, osbapiClient, brokerBindingID(serviceBinding))
 	if err != nil {
 		return err
 	}
 	if unbindResponse.IsAsync {
-		lastOpresponse, err := r.pollLastOperation(ctx, serviceBinding, assets, osbapiClient, unbindResponse.Operation)
+		serviceBinding.Status.AsyncOperation = osbapi.TrackAsyncOperation(serviceBinding.Status.AsyncOperation, "delete")
+		lastOpresponse, err := r.pollLastOperation(ctx, serviceBinding, assets, osbapiClient, brokerBindingID(serviceBinding), unbindResponse.Operation)
 		if err != nil {
 			return err
 		}
 
-		return r.processUnbindLastOperation(serviceBinding, lastOpresponse)
+		return r.processUnbindLastOperation(serviceBinding, assets, lastOpresponse)
 	}
 
 	return nil
@@ -318,13 +474,14 @@ func (r *ManagedBindingsReconciler) pollLastOperation(
 	serviceBinding *korifiv1alpha1.CFServiceBinding,
 	assets osbapi.ServiceBindingAssets,
 	osbapiClient osbapi.BrokerClient,
+	bindingID string,
 	operationID string,
 ) (osbapi.LastOperationResponse, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("poll-operation")
 
 	lastOpResponse, err := osbapiClient.GetServiceBindingLastOperation(ctx, osbapi.GetBindingLastOperationRequest{
 		InstanceID: serviceBinding.Spec.Service.Name,
-		BindingID:  serviceBinding.Name,
+		BindingID:  bindingID,
 		GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
 			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
 			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
@@ -341,6 +498,7 @@ func (r *ManagedBindingsReconciler) pollLastOperation(
 
 func (r *ManagedBindingsReconciler) processUnbindLastOperation(
 	serviceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
 	lastOpResponse osbapi.LastOperationResponse,
 ) error {
 	if lastOpResponse.State == "succeeded" {
@@ -348,6 +506,7 @@ func (r *ManagedBindingsReconciler) processUnbindLastOperation(
 	}
 
 	if lastOpResponse.State == "failed" {
+		serviceBinding.Status.AsyncOperation = nil
 		meta.SetStatusCondition(&serviceBinding.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.UnbindingFailedCondition,
 			Status:             metav1.ConditionTrue,
@@ -359,7 +518,13 @@ func (r *ManagedBindingsReconciler) processUnbindLastOperation(
 		return k8s.NewNotReadyError().WithReason("UnbindFailed")
 
 	}
-	return k8s.NewNotReadyError().WithReason("UnbindingInProgress").WithRequeue()
+
+	if osbapi.IsPollingDeadlineExceeded(serviceBinding.Status.AsyncOperation, assets.MaximumPollingDuration()) {
+		serviceBinding.Status.AsyncOperation = nil
+		return osbapi.FailTimedOutOperation(serviceBinding, korifiv1alpha1.UnbindingFailedCondition, "UnbindingTimedOut", assets.MaximumPollingDuration())
+	}
+
+	return osbapi.InProgress("UnbindingInProgress", lastOpResponse)
 }
 
 func (r *ManagedBindingsReconciler) deleteServiceBinding(
@@ -367,10 +532,11 @@ func (r *ManagedBindingsReconciler) deleteServiceBinding(
 	serviceBinding *korifiv1alpha1.CFServiceBinding,
 	assets osbapi.ServiceBindingAssets,
 	osbapiClient osbapi.BrokerClient,
+	bindingID string,
 ) (osbapi.UnbindResponse, error) {
 	unbindResponse, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
 		InstanceID: serviceBinding.Spec.Service.Name,
-		BindingID:  serviceBinding.Name,
+		BindingID:  bindingID,
 		UnbindRequestParameters: osbapi.UnbindRequestParameters{
 			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
 			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
@@ -400,5 +566,52 @@ func isFailed(binding *korifiv1alpha1.CFServiceBinding) bool {
 }
 
 func isReconciled(binding *korifiv1alpha1.CFServiceBinding) bool {
-	return binding.Status.EnvSecretRef.Name != "" && binding.Status.MountSecretRef.Name != ""
+	switch binding.Spec.Type {
+	case korifiv1alpha1.CFServiceBindingTypeRoute:
+		return binding.Status.RouteServiceURL != ""
+	case korifiv1alpha1.CFServiceBindingTypeKey:
+		return binding.Status.EnvSecretRef.Name != ""
+	default:
+		return binding.Status.EnvSecretRef.Name != "" && binding.Status.MountSecretRef.Name != ""
+	}
+}
+
+// isRotationRequested reports whether the binding credentials rotation
+// requested in the spec has not been completed yet
+func isRotationRequested(binding *korifiv1alpha1.CFServiceBinding) bool {
+	if binding.Spec.CredentialsRotation == nil {
+		return false
+	}
+
+	return binding.Spec.CredentialsRotation.ID != binding.Status.CredentialsRotationID
+}
+
+// brokerBindingID returns the ID the broker knows the binding by
+func brokerBindingID(binding *korifiv1alpha1.CFServiceBinding) string {
+	return tools.IfZero(binding.Status.BrokerBindingID, binding.Name)
+}
+
+// rotationBrokerBindingID returns the ID of the broker binding created by the
+// requested credentials rotation. It is derived from the rotation ID so that
+// the same broker binding is used until the rotation completes
+func rotationBrokerBindingID(binding *korifiv1alpha1.CFServiceBinding) string {
+	return tools.NamespacedUUID(binding.Name, binding.Spec.CredentialsRotation.ID)
+}
+
+// completeRotation records the completed rotation once the credentials secrets
+// have been switched to the credentials of the given broker binding. The
+// credentials secrets are patched in place, so that the app instances never
+// refer to credentials that no longer exist. The broker binding that has been
+// replaced is unbound on the next reconciliation
+func completeRotation(binding *korifiv1alpha1.CFServiceBinding, bindingID string) {
+	if binding.Spec.CredentialsRotation == nil {
+		return
+	}
+
+	if bindingID != brokerBindingID(binding) {
+		binding.Status.PredecessorBrokerBindingID = brokerBindingID(binding)
+		binding.Status.BrokerBindingID = bindingID
+	}
+
+	binding.Status.CredentialsRotationID = binding.Spec.CredentialsRotation.ID
 }
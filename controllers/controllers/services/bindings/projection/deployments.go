@@ -0,0 +1,227 @@
+package projection
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ServiceBindingRootEnvVar  = "SERVICE_BINDING_ROOT"
+	DefaultServiceBindingRoot = "/bindings"
+
+	projectedVolumePrefix = "korifi-binding-"
+)
+
+// DeploymentsReconciler mounts the projected binding secrets into the pod
+// template of Deployments that request them via the
+// korifi.cloudfoundry.org/service-bindings annotation. Each binding is mounted
+// as a directory named after the binding under $SERVICE_BINDING_ROOT. Bindings
+// whose secret has not been projected yet are skipped until it appears
+type DeploymentsReconciler struct {
+	k8sClient client.Client
+	log       logr.Logger
+}
+
+func NewDeploymentsReconciler(k8sClient client.Client, log logr.Logger) *DeploymentsReconciler {
+	return &DeploymentsReconciler{
+		k8sClient: k8sClient,
+		log:       log,
+	}
+}
+
+func (r *DeploymentsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("deployment-binding-projection").
+		For(&appsv1.Deployment{}).
+		Watches(
+			&korifiv1alpha1.CFServiceBinding{},
+			handler.EnqueueRequestsFromMapFunc(r.serviceBindingToDeployments),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.projectedSecretToDeployments),
+		).
+		Complete(r)
+}
+
+func (r *DeploymentsReconciler) serviceBindingToDeployments(ctx context.Context, o client.Object) []reconcile.Request {
+	return r.deploymentsRequestingBinding(ctx, o.GetNamespace(), o.GetName())
+}
+
+func (r *DeploymentsReconciler) projectedSecretToDeployments(ctx context.Context, o client.Object) []reconcile.Request {
+	bindingGUID, ok := o.GetLabels()[korifiv1alpha1.ServiceBindingGUIDLabel]
+	if !ok || o.GetName() != ProjectedSecretName(bindingGUID) {
+		return []reconcile.Request{}
+	}
+
+	return r.deploymentsRequestingBinding(ctx, o.GetNamespace(), bindingGUID)
+}
+
+func (r *DeploymentsReconciler) deploymentsRequestingBinding(ctx context.Context, namespace string, bindingGUID string) []reconcile.Request {
+	deployments := appsv1.DeploymentList{}
+	if err := r.k8sClient.List(ctx, &deployments, client.InNamespace(namespace)); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, deployment := range deployments.Items {
+		if slices.Contains(requestedBindingGUIDs(&deployment), bindingGUID) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      deployment.Name,
+					Namespace: deployment.Namespace,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *DeploymentsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("namespace", req.Namespace, "name", req.Name)
+
+	deployment := new(appsv1.Deployment)
+	err := r.k8sClient.Get(ctx, req.NamespacedName, deployment)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	bindings, err := r.getProjectableBindings(ctx, deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	podSpec := deployment.Spec.Template.Spec.DeepCopy()
+	projectBindings(podSpec, bindings)
+	if equality.Semantic.DeepEqual(*podSpec, deployment.Spec.Template.Spec) {
+		return ctrl.Result{}, nil
+	}
+
+	err = k8s.PatchResource(ctx, r.k8sClient, deployment, func() {
+		deployment.Spec.Template.Spec = *podSpec
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to patch deployment")
+	}
+
+	log.V(1).Info("projected service bindings", "bindings", len(bindings))
+	return ctrl.Result{}, nil
+}
+
+func (r *DeploymentsReconciler) getProjectableBindings(ctx context.Context, deployment *appsv1.Deployment) ([]korifiv1alpha1.CFServiceBinding, error) {
+	bindings := []korifiv1alpha1.CFServiceBinding{}
+	for _, bindingGUID := range requestedBindingGUIDs(deployment) {
+		binding := korifiv1alpha1.CFServiceBinding{}
+		err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: deployment.Namespace, Name: bindingGUID}, &binding)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get service binding %q", bindingGUID)
+		}
+
+		err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: deployment.Namespace, Name: ProjectedSecretName(bindingGUID)}, &corev1.Secret{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get projected secret for service binding %q", bindingGUID)
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	return bindings, nil
+}
+
+func requestedBindingGUIDs(deployment *appsv1.Deployment) []string {
+	bindingGUIDs := []string{}
+	for _, guid := range strings.Split(deployment.Annotations[korifiv1alpha1.ServiceBindingsProjectionAnnotation], ",") {
+		guid = strings.TrimSpace(guid)
+		if guid != "" && !slices.Contains(bindingGUIDs, guid) {
+			bindingGUIDs = append(bindingGUIDs, guid)
+		}
+	}
+
+	return bindingGUIDs
+}
+
+// projectBindings replaces the binding volumes previously projected into the
+// pod spec with ones for the given bindings. The SERVICE_BINDING_ROOT env var
+// is only set on containers that do not already define it
+func projectBindings(podSpec *corev1.PodSpec, bindings []korifiv1alpha1.CFServiceBinding) {
+	podSpec.Volumes = slices.DeleteFunc(podSpec.Volumes, func(v corev1.Volume) bool {
+		return strings.HasPrefix(v.Name, projectedVolumePrefix)
+	})
+	for _, binding := range bindings {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: projectedVolumePrefix + binding.Name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ProjectedSecretName(binding.Name),
+				},
+			},
+		})
+	}
+
+	for i := range podSpec.InitContainers {
+		projectBindingsIntoContainer(&podSpec.InitContainers[i], bindings)
+	}
+	for i := range podSpec.Containers {
+		projectBindingsIntoContainer(&podSpec.Containers[i], bindings)
+	}
+}
+
+func projectBindingsIntoContainer(container *corev1.Container, bindings []korifiv1alpha1.CFServiceBinding) {
+	container.VolumeMounts = slices.DeleteFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool {
+		return strings.HasPrefix(m.Name, projectedVolumePrefix)
+	})
+	if len(bindings) == 0 {
+		return
+	}
+
+	serviceBindingRoot := DefaultServiceBindingRoot
+	envVarIndex := slices.IndexFunc(container.Env, func(e corev1.EnvVar) bool {
+		return e.Name == ServiceBindingRootEnvVar
+	})
+	if envVarIndex < 0 {
+		container.Env = append(container.Env, corev1.EnvVar{Name: ServiceBindingRootEnvVar, Value: serviceBindingRoot})
+	} else if container.Env[envVarIndex].Value != "" {
+		serviceBindingRoot = container.Env[envVarIndex].Value
+	}
+
+	for _, binding := range bindings {
+		bindingName := binding.Name
+		if binding.Spec.DisplayName != nil {
+			bindingName = *binding.Spec.DisplayName
+		}
+
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      projectedVolumePrefix + binding.Name,
+			MountPath: path.Join(serviceBindingRoot, bindingName),
+			ReadOnly:  true,
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package projection_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/projection"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("DeploymentsReconciler", func() {
+	var (
+		testNamespace string
+		binding       *korifiv1alpha1.CFServiceBinding
+		deployment    *appsv1.Deployment
+	)
+
+	BeforeEach(func() {
+		testNamespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+		})).To(Succeed())
+
+		credentialsSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Data: map[string][]byte{
+				tools.CredentialsSecretKey: []byte(`{"username":"bob"}`),
+			},
+		}
+		Expect(adminClient.Create(ctx, credentialsSecret)).To(Succeed())
+
+		binding = &korifiv1alpha1.CFServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Annotations: map[string]string{
+					korifiv1alpha1.ServiceInstanceTypeAnnotation: korifiv1alpha1.UserProvidedType,
+				},
+			},
+			Spec: korifiv1alpha1.CFServiceBindingSpec{
+				DisplayName: tools.PtrTo("my-db"),
+				Type:        korifiv1alpha1.CFServiceBindingTypeKey,
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+					Name:       uuid.NewString(),
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, binding)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, binding, func() {
+			binding.Status.EnvSecretRef.Name = credentialsSecret.Name
+		})).To(Succeed())
+
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Annotations: map[string]string{
+					korifiv1alpha1.ServiceBindingsProjectionAnnotation: binding.Name,
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "sidecar"},
+				},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "sidecar"},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "main", Image: "busybox"},
+							{
+								Name:  "custom-root",
+								Image: "busybox",
+								Env: []corev1.EnvVar{
+									{Name: projection.ServiceBindingRootEnvVar, Value: "/custom"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, deployment)).To(Succeed())
+	})
+
+	It("mounts the projected binding secret", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).To(Succeed())
+
+			podSpec := deployment.Spec.Template.Spec
+			g.Expect(podSpec.Volumes).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("korifi-binding-" + binding.Name),
+				"VolumeSource": MatchFields(IgnoreExtras, Fields{
+					"Secret": PointTo(MatchFields(IgnoreExtras, Fields{
+						"SecretName": Equal(projection.ProjectedSecretName(binding.Name)),
+					})),
+				}),
+			})))
+
+			g.Expect(podSpec.Containers[0].Env).To(ConsistOf(corev1.EnvVar{Name: projection.ServiceBindingRootEnvVar, Value: "/bindings"}))
+			g.Expect(podSpec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+				Name:      "korifi-binding-" + binding.Name,
+				MountPath: "/bindings/my-db",
+				ReadOnly:  true,
+			}))
+
+			g.Expect(podSpec.Containers[1].Env).To(ConsistOf(corev1.EnvVar{Name: projection.ServiceBindingRootEnvVar, Value: "/custom"}))
+			g.Expect(podSpec.Containers[1].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+				Name:      "korifi-binding-" + binding.Name,
+				MountPath: "/custom/my-db",
+				ReadOnly:  true,
+			}))
+		}).Should(Succeed())
+	})
+
+	When("the binding is no longer requested", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).To(Succeed())
+				g.Expect(deployment.Spec.Template.Spec.Volumes).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, deployment, func() {
+				delete(deployment.Annotations, korifiv1alpha1.ServiceBindingsProjectionAnnotation)
+			})).To(Succeed())
+		})
+
+		It("removes the binding volume and mounts", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).To(Succeed())
+				g.Expect(deployment.Spec.Template.Spec.Volumes).To(BeEmpty())
+				g.Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(BeEmpty())
+				g.Expect(deployment.Spec.Template.Spec.Containers[1].VolumeMounts).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the requested binding does not exist", func() {
+		BeforeEach(func() {
+			deployment.Annotations[korifiv1alpha1.ServiceBindingsProjectionAnnotation] = "i-do-not-exist"
+		})
+
+		It("does not modify the deployment", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).To(Succeed())
+				g.Expect(deployment.Spec.Template.Spec.Volumes).To(BeEmpty())
+				g.Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
+})
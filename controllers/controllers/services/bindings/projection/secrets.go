@@ -0,0 +1,147 @@
+package projection
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const projectedSecretSuffix = "-projection"
+
+// ProjectedSecretName returns the name of the servicebinding.io secret
+// projected for the binding with the given GUID
+func ProjectedSecretName(bindingGUID string) string {
+	return bindingGUID + projectedSecretSuffix
+}
+
+// SecretsReconciler projects the credentials of every CFServiceBinding into a
+// secret that follows the servicebinding.io workload projection layout (see
+// https://servicebinding.io/spec/core/1.1.0/#workload-projection), so that
+// non-CF workloads can consume them. It does not modify the bindings
+// themselves and therefore runs alongside the CFServiceBinding controller
+type SecretsReconciler struct {
+	k8sClient client.Client
+	scheme    *runtime.Scheme
+	assets    *osbapi.Assets
+	log       logr.Logger
+}
+
+func NewSecretsReconciler(k8sClient client.Client, scheme *runtime.Scheme, rootNamespace string, log logr.Logger) *SecretsReconciler {
+	return &SecretsReconciler{
+		k8sClient: k8sClient,
+		scheme:    scheme,
+		assets:    osbapi.NewAssets(k8sClient, rootNamespace),
+		log:       log,
+	}
+}
+
+func (r *SecretsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cfservicebinding-projection").
+		For(&korifiv1alpha1.CFServiceBinding{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances;cfserviceplans;cfserviceofferings;cfservicebrokers,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch
+
+func (r *SecretsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("namespace", req.Namespace, "name", req.Name)
+
+	cfServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+	err := r.k8sClient.Get(ctx, req.NamespacedName, cfServiceBinding)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() || cfServiceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeRoute {
+		return ctrl.Result{}, nil
+	}
+
+	if cfServiceBinding.Status.EnvSecretRef.Name == "" {
+		log.V(1).Info("binding credentials not available yet")
+		return ctrl.Result{}, nil
+	}
+
+	serviceType, provider, err := r.getTypeAndProvider(ctx, cfServiceBinding)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	credentialsSecret := new(corev1.Secret)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: cfServiceBinding.Namespace, Name: cfServiceBinding.Status.EnvSecretRef.Name}, credentialsSecret)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(client.IgnoreNotFound(err), "failed to get binding credentials secret")
+	}
+
+	secretData, err := credentials.GetTypedServiceBindingIOSecretData(credentialsSecret, serviceType)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if _, hasProvider := secretData["provider"]; !hasProvider && provider != "" {
+		secretData["provider"] = []byte(provider)
+	}
+
+	projectedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ProjectedSecretName(cfServiceBinding.Name),
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, projectedSecret, func() error {
+		projectedSecret.Labels = tools.SetMapValue(projectedSecret.Labels, korifiv1alpha1.ServiceBindingGUIDLabel, cfServiceBinding.Name)
+		projectedSecret.Type = corev1.SecretType(credentials.ServiceBindingSecretTypePrefix + string(secretData["type"]))
+		projectedSecret.Data = secretData
+
+		return controllerutil.SetControllerReference(cfServiceBinding, projectedSecret, r.scheme)
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create projected binding secret")
+	}
+
+	log.V(1).Info("projected binding secret", "secret", projectedSecret.Name)
+	return ctrl.Result{}, nil
+}
+
+// getTypeAndProvider returns the values of the "type" and "provider" entries
+// used when the binding credentials do not specify them. The type is the
+// service label of the instance if set, or the name of its offering
+// otherwise, the provider is the name of the broker the instance comes from.
+// User-provided instances have no provider
+func (r *SecretsReconciler) getTypeAndProvider(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (string, string, error) {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: cfServiceBinding.ServiceInstanceNamespace(), Name: cfServiceBinding.Spec.Service.Name}, serviceInstance)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to get service instance")
+	}
+
+	serviceLabel := tools.ZeroIfNil(serviceInstance.Spec.ServiceLabel)
+
+	if serviceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return tools.IfZero(serviceLabel, korifiv1alpha1.UserProvidedType), "", nil
+	}
+
+	assets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to get service instance assets")
+	}
+
+	return tools.IfZero(serviceLabel, assets.ServiceOffering.Spec.Name), assets.ServiceBroker.Spec.Name, nil
+}
@@ -0,0 +1,243 @@
+package projection_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/projection"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SecretsReconciler", func() {
+	var (
+		testNamespace     string
+		credentialsSecret *corev1.Secret
+		serviceInstance   *korifiv1alpha1.CFServiceInstance
+		binding           *korifiv1alpha1.CFServiceBinding
+	)
+
+	BeforeEach(func() {
+		testNamespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+		})).To(Succeed())
+
+		serviceBroker := &korifiv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceBrokerSpec{
+				Name: "my-broker",
+				Credentials: corev1.LocalObjectReference{
+					Name: "my-broker-secret",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, serviceBroker)).To(Succeed())
+
+		serviceOffering := &korifiv1alpha1.CFServiceOffering{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceOfferingSpec{
+				Name: "postgresql",
+				BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+					ID: "service-offering-id",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, serviceOffering)).To(Succeed())
+
+		servicePlan := &korifiv1alpha1.CFServicePlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.RelServiceBrokerGUIDLabel:   serviceBroker.Name,
+					korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOffering.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFServicePlanSpec{
+				Visibility: korifiv1alpha1.ServicePlanVisibility{
+					Type: "public",
+				},
+				BrokerCatalog: korifiv1alpha1.ServicePlanBrokerCatalog{
+					ID: "service-plan-id",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, servicePlan)).To(Succeed())
+
+		serviceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName: "my-instance",
+				Type:        korifiv1alpha1.ManagedType,
+				PlanGUID:    servicePlan.Name,
+			},
+		}
+
+		credentialsSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Data: map[string][]byte{
+				tools.CredentialsSecretKey: []byte(`{"username":"bob","password":"secret"}`),
+			},
+		}
+
+		binding = &korifiv1alpha1.CFServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceBindingSpec{
+				Type: korifiv1alpha1.CFServiceBindingTypeKey,
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+					Name:       serviceInstance.Name,
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, serviceInstance)).To(Succeed())
+		Expect(adminClient.Create(ctx, credentialsSecret)).To(Succeed())
+		Expect(adminClient.Create(ctx, binding)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, binding, func() {
+			binding.Status.EnvSecretRef.Name = credentialsSecret.Name
+		})).To(Succeed())
+	})
+
+	It("projects the credentials into a servicebinding.io secret", func() {
+		Eventually(func(g Gomega) {
+			projectedSecret := &corev1.Secret{}
+			g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: projection.ProjectedSecretName(binding.Name)}, projectedSecret)).To(Succeed())
+
+			g.Expect(projectedSecret.Type).To(BeEquivalentTo("servicebinding.io/postgresql"))
+			g.Expect(projectedSecret.Labels).To(HaveKeyWithValue(korifiv1alpha1.ServiceBindingGUIDLabel, binding.Name))
+			g.Expect(projectedSecret.Data).To(MatchAllKeys(Keys{
+				"type":     BeEquivalentTo("postgresql"),
+				"provider": BeEquivalentTo("my-broker"),
+				"username": BeEquivalentTo("bob"),
+				"password": BeEquivalentTo("secret"),
+			}))
+			g.Expect(projectedSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("CFServiceBinding"),
+				"Name": Equal(binding.Name),
+			})))
+		}).Should(Succeed())
+	})
+
+	When("the credentials change", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: projection.ProjectedSecretName(binding.Name)}, &corev1.Secret{})).To(Succeed())
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, credentialsSecret, func() {
+				credentialsSecret.Data[tools.CredentialsSecretKey] = []byte(`{"username":"alice","password":"rotated"}`)
+			})).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, binding, func() {
+				binding.Annotations["rotated"] = "true"
+			})).To(Succeed())
+		})
+
+		It("updates the projected secret", func() {
+			Eventually(func(g Gomega) {
+				projectedSecret := &corev1.Secret{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: projection.ProjectedSecretName(binding.Name)}, projectedSecret)).To(Succeed())
+				g.Expect(projectedSecret.Data).To(MatchAllKeys(Keys{
+					"type":     BeEquivalentTo("postgresql"),
+					"provider": BeEquivalentTo("my-broker"),
+					"username": BeEquivalentTo("alice"),
+					"password": BeEquivalentTo("rotated"),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the credentials specify the type and provider", func() {
+		BeforeEach(func() {
+			credentialsSecret.Data[tools.CredentialsSecretKey] = []byte(`{"type":"mysql","provider":"my-provider"}`)
+		})
+
+		It("keeps them", func() {
+			Eventually(func(g Gomega) {
+				projectedSecret := &corev1.Secret{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: projection.ProjectedSecretName(binding.Name)}, projectedSecret)).To(Succeed())
+				g.Expect(projectedSecret.Type).To(BeEquivalentTo("servicebinding.io/mysql"))
+				g.Expect(projectedSecret.Data).To(MatchAllKeys(Keys{
+					"type":     BeEquivalentTo("mysql"),
+					"provider": BeEquivalentTo("my-provider"),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the instance has a service label", func() {
+		BeforeEach(func() {
+			serviceInstance.Spec.ServiceLabel = tools.PtrTo("my-label")
+		})
+
+		It("uses it as the type", func() {
+			Eventually(func(g Gomega) {
+				projectedSecret := &corev1.Secret{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: projection.ProjectedSecretName(binding.Name)}, projectedSecret)).To(Succeed())
+				g.Expect(projectedSecret.Type).To(BeEquivalentTo("servicebinding.io/my-label"))
+				g.Expect(projectedSecret.Data).To(MatchKeys(IgnoreExtras, Keys{
+					"type":     BeEquivalentTo("my-label"),
+					"provider": BeEquivalentTo("my-broker"),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the instance is user-provided", func() {
+		BeforeEach(func() {
+			serviceInstance.Spec.Type = korifiv1alpha1.UserProvidedType
+			serviceInstance.Spec.PlanGUID = ""
+		})
+
+		It("projects the user-provided type without a provider", func() {
+			Eventually(func(g Gomega) {
+				projectedSecret := &corev1.Secret{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: projection.ProjectedSecretName(binding.Name)}, projectedSecret)).To(Succeed())
+				g.Expect(projectedSecret.Type).To(BeEquivalentTo("servicebinding.io/user-provided"))
+				g.Expect(projectedSecret.Data).To(MatchAllKeys(Keys{
+					"type":     BeEquivalentTo("user-provided"),
+					"username": BeEquivalentTo("bob"),
+					"password": BeEquivalentTo("secret"),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the binding is a route binding", func() {
+		BeforeEach(func() {
+			binding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeRoute
+		})
+
+		It("does not project a secret", func() {
+			Consistently(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: projection.ProjectedSecretName(binding.Name)}, &corev1.Secret{})
+				g.Expect(err).To(MatchError(ContainSubstring("not found")))
+			}).Should(Succeed())
+		})
+	})
+})
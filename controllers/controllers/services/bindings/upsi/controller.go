@@ -45,12 +45,16 @@ func (r *UPSIBindingReconciler) ReconcileResource(ctx context.Context, cfService
 	}
 
 	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.ServiceInstanceNamespace()}, cfServiceInstance)
 	if err != nil {
 		log.Info("service instance not found", "service-instance", cfServiceBinding.Spec.Service.Name, "error", err)
 		return ctrl.Result{}, err
 	}
 
+	if cfServiceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeRoute {
+		return r.reconcileRouteBinding(cfServiceInstance, cfServiceBinding)
+	}
+
 	if cfServiceInstance.Status.Credentials.Name == "" {
 		return ctrl.Result{}, k8s.NewNotReadyError().
 			WithReason("CredentialsSecretNotAvailable").
@@ -58,7 +62,13 @@ func (r *UPSIBindingReconciler) ReconcileResource(ctx context.Context, cfService
 			WithRequeueAfter(time.Second)
 	}
 
-	cfServiceBinding.Status.EnvSecretRef.Name = cfServiceInstance.Status.Credentials.Name
+	envSecretName, err := r.reconcileEnvSecret(ctx, cfServiceInstance, cfServiceBinding)
+	if err != nil {
+		log.Error(err, "failed to reconcile env secret")
+		return ctrl.Result{}, err
+	}
+
+	cfServiceBinding.Status.EnvSecretRef.Name = envSecretName
 
 	mountSecret, err := r.createMountSecret(ctx, cfServiceInstance, cfServiceBinding)
 	if err != nil {
@@ -81,6 +91,61 @@ func (r *UPSIBindingReconciler) ReconcileResource(ctx context.Context, cfService
 	return ctrl.Result{}, nil
 }
 
+// reconcileRouteBinding forwards the traffic of the bound route to the route
+// service URL of the user-provided service instance. Route bindings have no
+// credentials
+func (r *UPSIBindingReconciler) reconcileRouteBinding(cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	if cfServiceInstance.Spec.RouteServiceURL == nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("RouteServiceURLNotSet").
+			WithMessage("Service instance does not have a route service url").
+			WithNoRequeue()
+	}
+
+	cfServiceBinding.Status.RouteServiceURL = *cfServiceInstance.Spec.RouteServiceURL
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileEnvSecret returns the name of the secret that holds the binding
+// credentials in the namespace of the binding. Bindings to service instances
+// shared from other namespaces get a copy of the instance credentials secret
+func (r *UPSIBindingReconciler) reconcileEnvSecret(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (string, error) {
+	if cfServiceInstance.Namespace == cfServiceBinding.Namespace {
+		return cfServiceInstance.Status.Credentials.Name, nil
+	}
+
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfServiceInstance.Namespace,
+			Name:      cfServiceInstance.Status.Credentials.Name,
+		},
+	}
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get service instance credentials secret %q: %w", cfServiceInstance.Status.Credentials.Name, err)
+	}
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceBinding.Name + "-env",
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, envSecret, func() error {
+		envSecret.Type = credentialsSecret.Type
+		envSecret.Data = credentialsSecret.Data
+
+		return controllerutil.SetControllerReference(cfServiceBinding, envSecret, r.scheme)
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create env secret")
+	}
+
+	return envSecret.Name, nil
+}
+
 func (r *UPSIBindingReconciler) createMountSecret(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (*corev1.Secret, error) {
 	credentialsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
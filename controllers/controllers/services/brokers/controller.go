@@ -30,6 +30,7 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,26 +43,32 @@ import (
 )
 
 type Reconciler struct {
-	k8sClient           client.Client
-	osbapiClientFactory osbapi.BrokerClientFactory
-	scheme              *runtime.Scheme
-	log                 logr.Logger
+	k8sClient              client.Client
+	osbapiClientFactory    osbapi.BrokerClientFactory
+	scheme                 *runtime.Scheme
+	rootNamespace          string
+	catalogRefreshInterval time.Duration
+	log                    logr.Logger
 }
 
 func NewReconciler(
 	client client.Client,
 	osbapiClientFactory osbapi.BrokerClientFactory,
 	scheme *runtime.Scheme,
+	rootNamespace string,
+	catalogRefreshInterval time.Duration,
 	log logr.Logger,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBroker] {
 	return k8s.NewPatchingReconciler(
 		log,
 		client,
 		&Reconciler{
-			k8sClient:           client,
-			osbapiClientFactory: osbapiClientFactory,
-			scheme:              scheme,
-			log:                 log,
+			k8sClient:              client,
+			osbapiClientFactory:    osbapiClientFactory,
+			scheme:                 scheme,
+			rootNamespace:          rootNamespace,
+			catalogRefreshInterval: catalogRefreshInterval,
+			log:                    log,
 		},
 	)
 }
@@ -102,6 +109,7 @@ func (r *Reconciler) secretToServiceBroker(ctx context.Context, o client.Object)
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("broker-id", cfServiceBroker.Name)
@@ -144,25 +152,144 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBroker *kor
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile catalog: %v", err)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.catalogRefreshInterval}, nil
 }
 
 func (r *Reconciler) reconcileCatalog(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, catalog osbapi.Catalog) error {
+	catalogOfferingGUIDs := map[string]bool{}
+	catalogPlanGUIDs := map[string]bool{}
+
 	for _, service := range catalog.Services {
 		err := r.reconcileCatalogService(ctx, cfServiceBroker, service)
 		if err != nil {
 			return err
 		}
 
+		catalogOfferingGUIDs[tools.NamespacedUUID(cfServiceBroker.Name, service.ID)] = true
+		for _, plan := range service.Plans {
+			catalogPlanGUIDs[tools.NamespacedUUID(cfServiceBroker.Name, plan.ID)] = true
+		}
+	}
+
+	return r.reconcileRemovedCatalogEntries(ctx, cfServiceBroker, catalogOfferingGUIDs, catalogPlanGUIDs)
+}
+
+// reconcileRemovedCatalogEntries deletes the offerings and plans that are no
+// longer in the broker catalog. Plans that are still used by service
+// instances (and their offerings) are marked as unavailable instead, and the
+// broker reports the drift via the CatalogDrift condition
+func (r *Reconciler) reconcileRemovedCatalogEntries(
+	ctx context.Context,
+	cfServiceBroker *korifiv1alpha1.CFServiceBroker,
+	catalogOfferingGUIDs map[string]bool,
+	catalogPlanGUIDs map[string]bool,
+) error {
+	brokerLabelSelector := client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: cfServiceBroker.Name}
+
+	plans := &korifiv1alpha1.CFServicePlanList{}
+	if err := r.k8sClient.List(ctx, plans, client.InNamespace(r.rootNamespace), brokerLabelSelector); err != nil {
+		return fmt.Errorf("failed to list service plans: %w", err)
+	}
+
+	unavailablePlans := 0
+	inUseOfferingGUIDs := map[string]bool{}
+	for _, plan := range plans.Items {
+		if catalogPlanGUIDs[plan.Name] {
+			continue
+		}
+
+		inUse, err := r.isPlanInUse(ctx, plan.Name)
+		if err != nil {
+			return err
+		}
+
+		if !inUse {
+			if err = r.k8sClient.Delete(ctx, &plan); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete service plan %q: %w", plan.Name, err)
+			}
+			continue
+		}
+
+		unavailablePlans++
+		inUseOfferingGUIDs[plan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]] = true
+		if err = k8s.PatchResource(ctx, r.k8sClient, &plan, func() {
+			plan.Spec.Unavailable = true
+		}); err != nil {
+			return fmt.Errorf("failed to mark service plan %q as unavailable: %w", plan.Name, err)
+		}
+	}
+
+	offerings := &korifiv1alpha1.CFServiceOfferingList{}
+	if err := r.k8sClient.List(ctx, offerings, client.InNamespace(r.rootNamespace), brokerLabelSelector); err != nil {
+		return fmt.Errorf("failed to list service offerings: %w", err)
+	}
+
+	unavailableOfferings := 0
+	for _, offering := range offerings.Items {
+		if catalogOfferingGUIDs[offering.Name] {
+			continue
+		}
+
+		if !inUseOfferingGUIDs[offering.Name] {
+			if err := r.k8sClient.Delete(ctx, &offering); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete service offering %q: %w", offering.Name, err)
+			}
+			continue
+		}
+
+		unavailableOfferings++
+		if err := k8s.PatchResource(ctx, r.k8sClient, &offering, func() {
+			offering.Spec.Unavailable = true
+		}); err != nil {
+			return fmt.Errorf("failed to mark service offering %q as unavailable: %w", offering.Name, err)
+		}
 	}
+
+	setCatalogDriftCondition(cfServiceBroker, unavailableOfferings, unavailablePlans)
+
 	return nil
 }
 
+func (r *Reconciler) isPlanInUse(ctx context.Context, planGUID string) (bool, error) {
+	serviceInstances := &korifiv1alpha1.CFServiceInstanceList{}
+	if err := r.k8sClient.List(ctx, serviceInstances, client.MatchingFields{shared.IndexServiceInstancePlanGUID: planGUID}); err != nil {
+		return false, fmt.Errorf("failed to list service instances for plan %q: %w", planGUID, err)
+	}
+
+	return len(serviceInstances.Items) > 0, nil
+}
+
+func setCatalogDriftCondition(cfServiceBroker *korifiv1alpha1.CFServiceBroker, unavailableOfferings int, unavailablePlans int) {
+	if unavailablePlans == 0 {
+		meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.CatalogDriftCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "InSync",
+			ObservedGeneration: cfServiceBroker.Generation,
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+		Type:   korifiv1alpha1.CatalogDriftCondition,
+		Status: metav1.ConditionTrue,
+		Reason: "UnavailableEntries",
+		Message: fmt.Sprintf(
+			"%d service offering(s) and %d service plan(s) have been removed from the broker catalog but are still in use",
+			unavailableOfferings,
+			unavailablePlans,
+		),
+		ObservedGeneration: cfServiceBroker.Generation,
+	})
+}
+
 func (r *Reconciler) reconcileCatalogService(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, catalogService osbapi.Service) error {
+	// Offerings and plans always live in the root namespace, even for
+	// space-scoped brokers, so that they can be looked up by guid only
 	serviceOffering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      tools.NamespacedUUID(cfServiceBroker.Name, catalogService.ID),
-			Namespace: cfServiceBroker.Namespace,
+			Namespace: r.rootNamespace,
 		},
 	}
 
@@ -172,6 +299,9 @@ func (r *Reconciler) reconcileCatalogService(ctx context.Context, cfServiceBroke
 		}
 		serviceOffering.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel] = cfServiceBroker.Name
 		serviceOffering.Labels[korifiv1alpha1.RelServiceBrokerNameLabel] = cfServiceBroker.Spec.Name
+		if r.isSpaceScoped(cfServiceBroker) {
+			serviceOffering.Labels[korifiv1alpha1.RelServiceBrokerSpaceGUIDLabel] = cfServiceBroker.Namespace
+		}
 
 		var err error
 		serviceOffering.Spec, err = toServiceOfferingSpec(catalogService)
@@ -208,9 +338,19 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 		servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel] = serviceOffering.Name
 		servicePlan.Labels[korifiv1alpha1.RelServiceOfferingNameLabel] = serviceOffering.Spec.Name
 
-		visibilityType := korifiv1alpha1.AdminServicePlanVisibilityType
+		visibility := korifiv1alpha1.ServicePlanVisibility{
+			Type: korifiv1alpha1.AdminServicePlanVisibilityType,
+		}
 		if servicePlan.Spec.Visibility.Type != "" {
-			visibilityType = servicePlan.Spec.Visibility.Type
+			visibility = servicePlan.Spec.Visibility
+		}
+
+		if spaceGUID, ok := serviceOffering.Labels[korifiv1alpha1.RelServiceBrokerSpaceGUIDLabel]; ok {
+			servicePlan.Labels[korifiv1alpha1.RelServiceBrokerSpaceGUIDLabel] = spaceGUID
+			visibility = korifiv1alpha1.ServicePlanVisibility{
+				Type:  korifiv1alpha1.SpaceServicePlanVisibilityType,
+				Space: spaceGUID,
+			}
 		}
 
 		metadata, err := korifiv1alpha1.AsRawExtension(catalogPlan.Metadata)
@@ -241,9 +381,11 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 				ID:       catalogPlan.ID,
 				Metadata: metadata,
 				Features: korifiv1alpha1.ServicePlanFeatures{
-					PlanUpdateable: catalogPlan.PlanUpdateable,
-					Bindable:       catalogPlan.Bindable,
+					PlanUpdateable:   *tools.IfNil(catalogPlan.PlanUpdateable, &serviceOffering.Spec.BrokerCatalog.Features.PlanUpdateable),
+					Bindable:         catalogPlan.Bindable,
+					BindingRotatable: catalogPlan.BindingRotatable,
 				},
+				MaximumPollingDuration: catalogPlan.MaximumPollingDuration,
 			},
 			Schemas: korifiv1alpha1.ServicePlanSchemas{
 				ServiceInstance: korifiv1alpha1.ServiceInstanceSchema{
@@ -263,9 +405,7 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 			MaintenanceInfo: korifiv1alpha1.MaintenanceInfo{
 				Version: catalogPlan.MaintenanceInfo.Version,
 			},
-			Visibility: korifiv1alpha1.ServicePlanVisibility{
-				Type: visibilityType,
-			},
+			Visibility: visibility,
 		}
 
 		return nil
@@ -274,6 +414,10 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 	return err
 }
 
+func (r *Reconciler) isSpaceScoped(cfServiceBroker *korifiv1alpha1.CFServiceBroker) bool {
+	return cfServiceBroker.Namespace != r.rootNamespace
+}
+
 func toServiceOfferingSpec(catalogService osbapi.Service) (korifiv1alpha1.CFServiceOfferingSpec, error) {
 	metadata, err := korifiv1alpha1.AsRawExtension(catalogService.Metadata)
 	if err != nil {
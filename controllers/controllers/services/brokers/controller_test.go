@@ -13,6 +13,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -55,10 +56,10 @@ var _ = Describe("CFServiceBroker", func() {
 					Metadata: map[string]any{
 						"plan-md": "plan-md-value",
 					},
-					Free:             true,
-					Bindable:         true,
-					BindingRotatable: true,
-					PlanUpdateable:   true,
+					Free:                   true,
+					Bindable:               true,
+					BindingRotatable:       true,
+					MaximumPollingDuration: tools.PtrTo[int32](3600),
 					Schemas: osbapi.ServicePlanSchemas{
 						ServiceInstance: osbapi.ServiceInstanceSchema{
 							Create: osbapi.InputParameterSchema{
@@ -202,9 +203,11 @@ var _ = Describe("CFServiceBroker", func() {
 						"Raw": MatchJSON(`{"plan-md": "plan-md-value"}`),
 					})),
 					"Features": Equal(korifiv1alpha1.ServicePlanFeatures{
-						PlanUpdateable: true,
-						Bindable:       true,
+						PlanUpdateable:   true,
+						Bindable:         true,
+						BindingRotatable: true,
 					}),
+					"MaximumPollingDuration": PointTo(BeEquivalentTo(3600)),
 				}),
 				"Schemas": MatchFields(IgnoreExtras, Fields{
 					"ServiceInstance": MatchFields(IgnoreExtras, Fields{
@@ -233,6 +236,7 @@ var _ = Describe("CFServiceBroker", func() {
 				"Visibility": MatchAllFields(Fields{
 					"Type":          Equal(korifiv1alpha1.AdminServicePlanVisibilityType),
 					"Organizations": BeEmpty(),
+					"Space":         BeEmpty(),
 				}),
 			}))
 		}).Should(Succeed())
@@ -284,6 +288,78 @@ var _ = Describe("CFServiceBroker", func() {
 		})
 	})
 
+	When("the broker is space-scoped", func() {
+		var spaceNamespace string
+
+		BeforeEach(func() {
+			spaceNamespace = uuid.NewString()
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: spaceNamespace,
+				},
+			})).To(Succeed())
+
+			spaceBrokerSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceNamespace,
+					Name:      uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, spaceBrokerSecret)).To(Succeed())
+
+			serviceBroker = &korifiv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBrokerSpec{
+					Name: "my-space-service-broker",
+					URL:  "some-url",
+					Credentials: corev1.LocalObjectReference{
+						Name: spaceBrokerSecret.Name,
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, serviceBroker)).To(Succeed())
+		})
+
+		It("creates the offerings in the root namespace", func() {
+			Eventually(func(g Gomega) {
+				offerings := &korifiv1alpha1.CFServiceOfferingList{}
+				g.Expect(adminClient.List(ctx, offerings,
+					client.InNamespace(rootNamespace),
+					client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+				)).To(Succeed())
+				g.Expect(offerings.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Labels": HaveKeyWithValue(korifiv1alpha1.RelServiceBrokerSpaceGUIDLabel, spaceNamespace),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("creates plans that are only visible in the broker space", func() {
+			Eventually(func(g Gomega) {
+				plans := &korifiv1alpha1.CFServicePlanList{}
+				g.Expect(adminClient.List(ctx, plans,
+					client.InNamespace(rootNamespace),
+					client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+				)).To(Succeed())
+				g.Expect(plans.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Labels": HaveKeyWithValue(korifiv1alpha1.RelServiceBrokerSpaceGUIDLabel, spaceNamespace),
+					}),
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Visibility": Equal(korifiv1alpha1.ServicePlanVisibility{
+							Type:  korifiv1alpha1.SpaceServicePlanVisibilityType,
+							Space: spaceNamespace,
+						}),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
 	When("there are multiple brokers serving the same catalog", func() {
 		var anotherServiceBroker *korifiv1alpha1.CFServiceBroker
 
@@ -376,6 +452,174 @@ var _ = Describe("CFServiceBroker", func() {
 		}).Should(Succeed())
 	})
 
+	It("reports that the catalog is in sync", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)).To(Succeed())
+			g.Expect(serviceBroker.Status.Conditions).To(ContainElement(SatisfyAll(
+				HasType(Equal(korifiv1alpha1.CatalogDriftCondition)),
+				HasStatus(Equal(metav1.ConditionFalse)),
+				HasReason(Equal("InSync")),
+			)))
+		}).Should(Succeed())
+	})
+
+	When("a catalog refresh is requested", func() {
+		var getCatalogCallCount int
+
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(serviceBroker.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+			getCatalogCallCount = brokerClient.GetCatalogCallCount()
+
+			Expect(k8s.PatchResource(ctx, adminClient, serviceBroker, func() {
+				serviceBroker.Spec.CatalogRefreshRequestID = uuid.NewString()
+			})).To(Succeed())
+		})
+
+		It("fetches the catalog again", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(brokerClient.GetCatalogCallCount()).To(BeNumerically(">", getCatalogCallCount))
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)).To(Succeed())
+				g.Expect(serviceBroker.Status.ObservedGeneration).To(Equal(serviceBroker.Generation))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the plan sets plan_updateable explicitly", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(serviceBroker.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+
+			brokerClient.GetCatalogReturns(osbapi.Catalog{
+				Services: []osbapi.Service{{
+					ID:             "service-id",
+					Name:           "service-name",
+					PlanUpdateable: true,
+					Plans: []osbapi.Plan{{
+						ID:             "plan-id",
+						Name:           "plan-name",
+						PlanUpdateable: tools.PtrTo(false),
+					}},
+				}},
+			}, nil)
+
+			Expect(k8s.PatchResource(ctx, adminClient, serviceBroker, func() {
+				serviceBroker.Spec.CatalogRefreshRequestID = uuid.NewString()
+			})).To(Succeed())
+		})
+
+		It("overrides the offering value", func() {
+			Eventually(func(g Gomega) {
+				plans := &korifiv1alpha1.CFServicePlanList{}
+				g.Expect(adminClient.List(ctx, plans,
+					client.InNamespace(serviceBroker.Namespace),
+					client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+				)).To(Succeed())
+				g.Expect(plans.Items).To(HaveLen(1))
+				g.Expect(plans.Items[0].Spec.BrokerCatalog.Features.PlanUpdateable).To(BeFalse())
+			}).Should(Succeed())
+		})
+	})
+
+	When("offerings and plans are removed from the catalog", func() {
+		var (
+			offering *korifiv1alpha1.CFServiceOffering
+			plan     *korifiv1alpha1.CFServicePlan
+		)
+
+		getPlans := func(g Gomega) []korifiv1alpha1.CFServicePlan {
+			plans := &korifiv1alpha1.CFServicePlanList{}
+			g.Expect(adminClient.List(ctx, plans,
+				client.InNamespace(rootNamespace),
+				client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+			)).To(Succeed())
+			return plans.Items
+		}
+
+		getOfferings := func(g Gomega) []korifiv1alpha1.CFServiceOffering {
+			offerings := &korifiv1alpha1.CFServiceOfferingList{}
+			g.Expect(adminClient.List(ctx, offerings,
+				client.InNamespace(rootNamespace),
+				client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+			)).To(Succeed())
+			return offerings.Items
+		}
+
+		requestRefresh := func() {
+			Expect(k8s.PatchResource(ctx, adminClient, serviceBroker, func() {
+				serviceBroker.Spec.CatalogRefreshRequestID = uuid.NewString()
+			})).To(Succeed())
+		}
+
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				plans := getPlans(g)
+				g.Expect(plans).To(HaveLen(1))
+				plan = &plans[0]
+
+				offerings := getOfferings(g)
+				g.Expect(offerings).To(HaveLen(1))
+				offering = &offerings[0]
+			}).Should(Succeed())
+
+			brokerClient.GetCatalogReturns(osbapi.Catalog{}, nil)
+		})
+
+		It("deletes them", func() {
+			requestRefresh()
+
+			Eventually(func(g Gomega) {
+				g.Expect(getPlans(g)).To(BeEmpty())
+				g.Expect(getOfferings(g)).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		When("the plan is still used by a service instance", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFServiceInstanceSpec{
+						DisplayName: "my-instance",
+						Type:        korifiv1alpha1.ManagedType,
+						PlanGUID:    tools.NamespacedUUID(serviceBroker.Name, "plan-id"),
+					},
+				})).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				requestRefresh()
+			})
+
+			It("marks the plan and the offering as unavailable", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+					g.Expect(plan.Spec.Unavailable).To(BeTrue())
+
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(offering), offering)).To(Succeed())
+					g.Expect(offering.Spec.Unavailable).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("reports the catalog drift", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)).To(Succeed())
+					g.Expect(serviceBroker.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.CatalogDriftCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+						HasReason(Equal("UnavailableEntries")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the credentials secret does not exist", func() {
 		BeforeEach(func() {
 			Expect(adminClient.Delete(ctx, brokerSecret)).To(Succeed())
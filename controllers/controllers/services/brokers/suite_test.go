@@ -102,6 +102,8 @@ var _ = BeforeEach(func() {
 		k8sManager.GetClient(),
 		brokerClientFactory,
 		k8sManager.GetScheme(),
+		rootNamespace,
+		0,
 		ctrl.Log.WithName("controllers").WithName("CFServiceBroker"),
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -41,6 +41,13 @@ func GetServiceBindingIOSecretData(credentials map[string]any) (map[string][]byt
 }
 
 func GetUserProvidedServiceBindingIOSecretData(credentialsSecret *corev1.Secret) (map[string][]byte, error) {
+	return GetTypedServiceBindingIOSecretData(credentialsSecret, korifiv1alpha1.UserProvidedType)
+}
+
+// GetTypedServiceBindingIOSecretData converts the credentials in the secret
+// into servicebinding.io secret data. The mandatory "type" entry is set to
+// defaultType unless the credentials specify one themselves
+func GetTypedServiceBindingIOSecretData(credentialsSecret *corev1.Secret, defaultType string) (map[string][]byte, error) {
 	credentials := map[string]any{}
 	err := GetCredentials(credentialsSecret, &credentials)
 	if err != nil {
@@ -53,7 +60,7 @@ func GetUserProvidedServiceBindingIOSecretData(credentialsSecret *corev1.Secret)
 	}
 
 	if _, hasType := secretData["type"]; !hasType {
-		secretData["type"] = []byte("user-provided")
+		secretData["type"] = []byte(defaultType)
 	}
 
 	return secretData, err
@@ -132,6 +132,37 @@ var _ = Describe("Credentials", func() {
 		})
 	})
 
+	Describe("GetTypedServiceBindingIOSecretData", func() {
+		var bindingSecretData map[string][]byte
+
+		JustBeforeEach(func() {
+			bindingSecretData, err = credentials.GetTypedServiceBindingIOSecretData(credentialsSecret, "managed")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("sets the default type", func() {
+			Expect(bindingSecretData).To(MatchAllKeys(Keys{
+				"type": BeEquivalentTo("managed"),
+				"foo":  BeEquivalentTo(`{"bar":"baz"}`),
+			}))
+		})
+
+		When("the credentials secrets has 'type' and 'provider' attributes specified", func() {
+			BeforeEach(func() {
+				credentialsSecret.Data = map[string][]byte{
+					tools.CredentialsSecretKey: []byte(`{"type": "postgresql", "provider": "my-provider"}`),
+				}
+			})
+
+			It("keeps them", func() {
+				Expect(bindingSecretData).To(MatchAllKeys(Keys{
+					"type":     BeEquivalentTo("postgresql"),
+					"provider": BeEquivalentTo("my-provider"),
+				}))
+			})
+		})
+	})
+
 	Describe("ToCredentialsSecretData", func() {
 		var creds any
 
@@ -40,7 +40,6 @@ func EnsureNoServiceBindings(
 func getBindings(ctx context.Context, k8sClient client.Client, serviceInstance *korifiv1alpha1.CFServiceInstance) ([]korifiv1alpha1.CFServiceBinding, error) {
 	serviceBindings := korifiv1alpha1.CFServiceBindingList{}
 	if err := k8sClient.List(ctx, &serviceBindings,
-		client.InNamespace(serviceInstance.Namespace),
 		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: serviceInstance.Name},
 	); err != nil {
 		return nil, fmt.Errorf("failed to list bindings: %w", err)
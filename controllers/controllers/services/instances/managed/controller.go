@@ -26,6 +26,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -52,6 +53,7 @@ type Reconciler struct {
 	rootNamespace       string
 	log                 logr.Logger
 	assets              *osbapi.Assets
+	usageRecorder       *usage.Recorder
 }
 
 func NewReconciler(
@@ -68,6 +70,7 @@ func NewReconciler(
 		rootNamespace:       rootNamespace,
 		log:                 log,
 		assets:              osbapi.NewAssets(client, rootNamespace),
+		usageRecorder:       usage.NewRecorder(client, rootNamespace),
 	})
 }
 
@@ -118,9 +121,11 @@ func (r *Reconciler) isManaged(object client.Object) bool {
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;delete
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
+	ctx = osbapi.WithOriginatingIdentity(ctx, serviceInstance.Annotations[korifiv1alpha1.OriginatingIdentityAnnotation])
 
 	serviceInstance.Status.ObservedGeneration = serviceInstance.Generation
 	log.V(1).Info("set observed generation", "generation", serviceInstance.Status.ObservedGeneration)
@@ -143,8 +148,15 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 
 	serviceInstance.Status.UpgradeAvailable = serviceInstance.Status.MaintenanceInfo.Version != serviceInstanceAssets.ServicePlan.Spec.MaintenanceInfo.Version
 
-	if isReady(serviceInstance) {
-		return ctrl.Result{}, nil
+	if isReady(serviceInstance) && serviceInstance.Status.PlanGUID == "" {
+		// Service instances provisioned before updates were supported do not
+		// record the plan and parameters the broker has been called with
+		serviceInstance.Status.PlanGUID = serviceInstance.Spec.PlanGUID
+		serviceInstance.Status.Parameters = serviceInstance.Spec.Parameters
+	}
+
+	if isProvisioned(serviceInstance) {
+		return r.reconcileProvisionedServiceInstance(ctx, serviceInstance, serviceInstanceAssets, osbapiClient)
 	}
 
 	if isFailed(serviceInstance) {
@@ -173,18 +185,56 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 	}
 
 	if provisionResponse.IsAsync {
+		serviceInstance.Status.AsyncOperation = osbapi.TrackAsyncOperation(serviceInstance.Status.AsyncOperation, "create")
 		lastOpResponse, err := r.pollLastOperation(ctx, serviceInstance, serviceInstanceAssets, osbapiClient, provisionResponse.Operation)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		return r.processProvisionOperation(serviceInstance, lastOpResponse)
+		return r.processProvisionOperation(serviceInstance, serviceInstanceAssets, lastOpResponse)
 	}
 
-	serviceInstance.Status.MaintenanceInfo = serviceInstanceAssets.ServicePlan.Spec.MaintenanceInfo
-	serviceInstance.Status.LastOperation.State = "succeeded"
+	completeProvision(serviceInstance, serviceInstanceAssets.ServicePlan)
 	return ctrl.Result{}, nil
 }
 
+func (r *Reconciler) reconcileProvisionedServiceInstance(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !needsUpdate(serviceInstance) {
+		if err := r.usageRecorder.RecordServiceInstanceUsage(ctx, serviceInstance); err != nil {
+			log.Error(err, "failed to record service usage")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if isUpdateFailed(serviceInstance) {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("UpdateFailed").WithNoRequeue()
+	}
+
+	updateResponse, err := r.updateServiceInstance(ctx, serviceInstance, assets, osbapiClient)
+	if err != nil {
+		log.Error(err, "failed to update service instance")
+		return ctrl.Result{}, fmt.Errorf("failed to update service instance: %w", err)
+	}
+
+	if updateResponse.IsAsync {
+		serviceInstance.Status.AsyncOperation = osbapi.TrackAsyncOperation(serviceInstance.Status.AsyncOperation, "update")
+		lastOpResponse, err := r.pollLastOperation(ctx, serviceInstance, assets, osbapiClient, updateResponse.Operation)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.processUpdateOperation(ctx, serviceInstance, assets, lastOpResponse)
+	}
+
+	return r.completeUpdate(ctx, serviceInstance, assets.ServicePlan)
+}
+
 func (r *Reconciler) provisionServiceInstance(
 	ctx context.Context,
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
@@ -246,13 +296,16 @@ func (r *Reconciler) provisionServiceInstance(
 
 func (r *Reconciler) processProvisionOperation(
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
 	lastOpResponse osbapi.LastOperationResponse,
 ) (ctrl.Result, error) {
 	if lastOpResponse.State == "succeeded" {
+		completeProvision(serviceInstance, assets.ServicePlan)
 		return ctrl.Result{}, nil
 	}
 
 	if lastOpResponse.State == "failed" {
+		serviceInstance.Status.AsyncOperation = nil
 		meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.ProvisioningFailedCondition,
 			Status:             metav1.ConditionTrue,
@@ -264,7 +317,199 @@ func (r *Reconciler) processProvisionOperation(
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisionFailed")
 	}
 
-	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisionInProgress").WithRequeue()
+	if osbapi.IsPollingDeadlineExceeded(serviceInstance.Status.AsyncOperation, assets.MaximumPollingDuration()) {
+		serviceInstance.Status.AsyncOperation = nil
+		serviceInstance.Status.LastOperation.State = "failed"
+		serviceInstance.Status.LastOperation.Description = osbapi.PollingDeadlineExceededMessage(assets.MaximumPollingDuration())
+		return ctrl.Result{}, osbapi.FailTimedOutOperation(serviceInstance, korifiv1alpha1.ProvisioningFailedCondition, "ProvisionTimedOut", assets.MaximumPollingDuration())
+	}
+
+	return ctrl.Result{}, osbapi.InProgress("ProvisionInProgress", lastOpResponse)
+}
+
+func (r *Reconciler) updateServiceInstance(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+) (osbapi.UpdateResponse, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("update-service-instance")
+
+	namespace, err := r.getNamespace(ctx, serviceInstance.Namespace)
+	if err != nil {
+		log.Error(err, "failed to get namespace")
+		return osbapi.UpdateResponse{}, err
+	}
+
+	previousPlanID, err := r.getPreviousPlanID(ctx, serviceInstance, assets)
+	if err != nil {
+		log.Error(err, "failed to get previous service plan")
+		return osbapi.UpdateResponse{}, err
+	}
+
+	updateRequest := osbapi.UpdateRequest{
+		ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PreviousValues: osbapi.PreviousValues{
+			ServiceId:       assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:          previousPlanID,
+			SpaceGUID:       namespace.Labels[korifiv1alpha1.SpaceGUIDKey],
+			OrgGUID:         namespace.Labels[korifiv1alpha1.OrgGUIDKey],
+			MaintenanceInfo: toOSBAPIMaintenanceInfo(serviceInstance.Status.MaintenanceInfo),
+		},
+	}
+
+	if serviceInstance.Spec.PlanGUID != serviceInstance.Status.PlanGUID {
+		updateRequest.PlanID = assets.ServicePlan.Spec.BrokerCatalog.ID
+		updateRequest.MaintenanceInfo = toOSBAPIMaintenanceInfo(assets.ServicePlan.Spec.MaintenanceInfo)
+	}
+
+	if isUpgradeRequested(serviceInstance) {
+		updateRequest.MaintenanceInfo = toOSBAPIMaintenanceInfo(serviceInstance.Spec.MaintenanceInfo)
+	}
+
+	if serviceInstance.Spec.Parameters.Name != serviceInstance.Status.Parameters.Name {
+		updateRequest.Parameters, err = r.getServiceInstanceParameters(ctx, serviceInstance)
+		if err != nil {
+			log.Error(err, "failed to get service instance parameters")
+			return osbapi.UpdateResponse{}, k8s.NewNotReadyError().WithReason("InvalidParameters")
+		}
+	}
+
+	serviceInstance.Status.LastOperation = korifiv1alpha1.LastOperation{
+		Type:  "update",
+		State: "initial",
+	}
+
+	updateResponse, err := osbapiClient.Update(ctx, osbapi.UpdatePayload{
+		InstanceID:    serviceInstance.Name,
+		UpdateRequest: updateRequest,
+	})
+	if err != nil {
+		log.Error(err, "failed to update service")
+
+		if osbapi.IsUnrecoveralbeError(err) {
+			serviceInstance.Status.LastOperation.State = "failed"
+			meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.UpdateFailedCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: serviceInstance.Generation,
+				LastTransitionTime: metav1.NewTime(time.Now()),
+				Reason:             "UpdateFailed",
+				Message:            err.Error(),
+			})
+			return osbapi.UpdateResponse{},
+				k8s.NewNotReadyError().WithReason("UpdateFailed")
+		}
+
+		return osbapi.UpdateResponse{}, err
+	}
+
+	return updateResponse, nil
+}
+
+func (r *Reconciler) getPreviousPlanID(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+) (string, error) {
+	if serviceInstance.Status.PlanGUID == serviceInstance.Spec.PlanGUID {
+		return assets.ServicePlan.Spec.BrokerCatalog.ID, nil
+	}
+
+	previousPlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceInstance.Status.PlanGUID,
+			Namespace: r.rootNamespace,
+		},
+	}
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(previousPlan), previousPlan)
+	if err != nil {
+		// The previous plan may have been removed from the broker catalog
+		return "", client.IgnoreNotFound(err)
+	}
+
+	return previousPlan.Spec.BrokerCatalog.ID, nil
+}
+
+func (r *Reconciler) processUpdateOperation(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	lastOpResponse osbapi.LastOperationResponse,
+) (ctrl.Result, error) {
+	if lastOpResponse.State == "succeeded" {
+		return r.completeUpdate(ctx, serviceInstance, assets.ServicePlan)
+	}
+
+	if lastOpResponse.State == "failed" {
+		serviceInstance.Status.AsyncOperation = nil
+		meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.UpdateFailedCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: serviceInstance.Generation,
+			LastTransitionTime: metav1.NewTime(time.Now()),
+			Reason:             "UpdateFailed",
+			Message:            lastOpResponse.Description,
+		})
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("UpdateFailed")
+	}
+
+	if osbapi.IsPollingDeadlineExceeded(serviceInstance.Status.AsyncOperation, assets.MaximumPollingDuration()) {
+		serviceInstance.Status.AsyncOperation = nil
+		serviceInstance.Status.LastOperation.State = "failed"
+		serviceInstance.Status.LastOperation.Description = osbapi.PollingDeadlineExceededMessage(assets.MaximumPollingDuration())
+		return ctrl.Result{}, osbapi.FailTimedOutOperation(serviceInstance, korifiv1alpha1.UpdateFailedCondition, "UpdateTimedOut", assets.MaximumPollingDuration())
+	}
+
+	return ctrl.Result{}, osbapi.InProgress("UpdateInProgress", lastOpResponse)
+}
+
+func completeProvision(serviceInstance *korifiv1alpha1.CFServiceInstance, servicePlan *korifiv1alpha1.CFServicePlan) {
+	serviceInstance.Status.PlanGUID = serviceInstance.Spec.PlanGUID
+	serviceInstance.Status.Parameters = serviceInstance.Spec.Parameters
+	serviceInstance.Status.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
+	serviceInstance.Status.UpgradeAvailable = false
+	serviceInstance.Status.LastOperation.State = "succeeded"
+	serviceInstance.Status.AsyncOperation = nil
+}
+
+func (r *Reconciler) completeUpdate(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	servicePlan *korifiv1alpha1.CFServicePlan,
+) (ctrl.Result, error) {
+	if err := r.deletePreviousParametersSecret(ctx, serviceInstance); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "failed to delete previous parameters secret")
+		return ctrl.Result{}, err
+	}
+
+	if isUpgradeRequested(serviceInstance) {
+		serviceInstance.Status.MaintenanceInfo = serviceInstance.Spec.MaintenanceInfo
+	} else if serviceInstance.Spec.PlanGUID != serviceInstance.Status.PlanGUID {
+		serviceInstance.Status.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
+	}
+
+	serviceInstance.Status.PlanGUID = serviceInstance.Spec.PlanGUID
+	serviceInstance.Status.Parameters = serviceInstance.Spec.Parameters
+	serviceInstance.Status.UpgradeAvailable = serviceInstance.Status.MaintenanceInfo.Version != servicePlan.Spec.MaintenanceInfo.Version
+	serviceInstance.Status.LastOperation.State = "succeeded"
+	serviceInstance.Status.AsyncOperation = nil
+	meta.RemoveStatusCondition(&serviceInstance.Status.Conditions, korifiv1alpha1.UpdateFailedCondition)
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) deletePreviousParametersSecret(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	previousParameters := serviceInstance.Status.Parameters.Name
+	if previousParameters == "" || previousParameters == serviceInstance.Spec.Parameters.Name {
+		return nil
+	}
+
+	return client.IgnoreNotFound(r.k8sClient.Delete(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: serviceInstance.Namespace,
+			Name:      previousParameters,
+		},
+	}))
 }
 
 func (r *Reconciler) finalize(
@@ -279,6 +524,10 @@ func (r *Reconciler) finalize(
 		return ctrl.Result{}, err
 	}
 
+	if err := r.usageRecorder.RecordServiceInstanceDeletion(ctx, serviceInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	logr.FromContextOrDiscard(ctx).WithName("finalizeCFServiceInstance").V(1).Info("finalizer removed")
 	return ctrl.Result{}, nil
@@ -296,6 +545,11 @@ func (r *Reconciler) finalizeCFServiceInstance(
 		return nil
 	}
 
+	if osbapi.IsOperationTimedOut(serviceInstance, korifiv1alpha1.DeprovisioningFailedCondition, "DeprovisionTimedOut") {
+		// The instance may still exist on the broker, it has to be purged
+		return k8s.NewNotReadyError().WithReason("DeprovisionTimedOut").WithNoRequeue()
+	}
+
 	assets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance)
 	if err != nil {
 		return fmt.Errorf("failed to get service instance assets: %w", err)
@@ -312,11 +566,12 @@ func (r *Reconciler) finalizeCFServiceInstance(
 	}
 
 	if deprovisionResponse.IsAsync {
+		serviceInstance.Status.AsyncOperation = osbapi.TrackAsyncOperation(serviceInstance.Status.AsyncOperation, "delete")
 		lastOpResponse, err := r.pollLastOperation(ctx, serviceInstance, assets, osbapiClient, deprovisionResponse.Operation)
 		if err != nil {
 			return err
 		}
-		if err = r.processDeprovisionOperation(serviceInstance, lastOpResponse); err != nil {
+		if err = r.processDeprovisionOperation(serviceInstance, assets, lastOpResponse); err != nil {
 			return err
 		}
 	}
@@ -364,6 +619,7 @@ func (r *Reconciler) deprovisionServiceInstance(
 
 func (r *Reconciler) processDeprovisionOperation(
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
 	lastOpResponse osbapi.LastOperationResponse,
 ) error {
 	if lastOpResponse.State == "succeeded" {
@@ -371,6 +627,7 @@ func (r *Reconciler) processDeprovisionOperation(
 	}
 
 	if lastOpResponse.State == "failed" {
+		serviceInstance.Status.AsyncOperation = nil
 		meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.DeprovisioningFailedCondition,
 			Status:             metav1.ConditionTrue,
@@ -382,7 +639,14 @@ func (r *Reconciler) processDeprovisionOperation(
 		return k8s.NewNotReadyError().WithReason("DeprovisionFailed")
 	}
 
-	return k8s.NewNotReadyError().WithReason("DeprovisionInProgress").WithRequeue()
+	if osbapi.IsPollingDeadlineExceeded(serviceInstance.Status.AsyncOperation, assets.MaximumPollingDuration()) {
+		serviceInstance.Status.AsyncOperation = nil
+		serviceInstance.Status.LastOperation.State = "failed"
+		serviceInstance.Status.LastOperation.Description = osbapi.PollingDeadlineExceededMessage(assets.MaximumPollingDuration())
+		return osbapi.FailTimedOutOperation(serviceInstance, korifiv1alpha1.DeprovisioningFailedCondition, "DeprovisionTimedOut", assets.MaximumPollingDuration())
+	}
+
+	return osbapi.InProgress("DeprovisionInProgress", lastOpResponse)
 }
 
 func (r *Reconciler) pollLastOperation(
@@ -444,6 +708,10 @@ func (r *Reconciler) isServicePlanVisible(
 		return true, nil
 	}
 
+	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.SpaceServicePlanVisibilityType {
+		return servicePlan.Spec.Visibility.Space == serviceInstance.Namespace, nil
+	}
+
 	namespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: serviceInstance.Namespace,
@@ -475,6 +743,33 @@ func isFailed(instance *korifiv1alpha1.CFServiceInstance) bool {
 	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.ProvisioningFailedCondition)
 }
 
+func isProvisioned(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return instance.Status.PlanGUID != ""
+}
+
+func isUpdateFailed(instance *korifiv1alpha1.CFServiceInstance) bool {
+	condition := meta.FindStatusCondition(instance.Status.Conditions, korifiv1alpha1.UpdateFailedCondition)
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == instance.Generation
+}
+
+func needsUpdate(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return instance.Spec.PlanGUID != instance.Status.PlanGUID ||
+		instance.Spec.Parameters.Name != instance.Status.Parameters.Name ||
+		isUpgradeRequested(instance)
+}
+
+func isUpgradeRequested(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return instance.Spec.MaintenanceInfo.Version != "" && instance.Spec.MaintenanceInfo.Version != instance.Status.MaintenanceInfo.Version
+}
+
+func toOSBAPIMaintenanceInfo(maintenanceInfo korifiv1alpha1.MaintenanceInfo) *osbapi.MaintenanceInfo {
+	if maintenanceInfo.Version == "" {
+		return nil
+	}
+
+	return &osbapi.MaintenanceInfo{Version: maintenanceInfo.Version}
+}
+
 func isReady(instance *korifiv1alpha1.CFServiceInstance) bool {
 	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)
 }
@@ -137,6 +137,34 @@ var _ = Describe("CFServiceInstance", func() {
 		}).Should(Succeed())
 	})
 
+	It("records a CREATED service usage event", func() {
+		Eventually(func(g Gomega) {
+			var serviceUsageEvents korifiv1alpha1.CFServiceUsageEventList
+			g.Expect(adminClient.List(ctx, &serviceUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+			g.Expect(serviceUsageEvents.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+					State: korifiv1alpha1.ServiceUsageStateCreated,
+					ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+						GUID: instance.Name,
+						Name: "service-instance-name",
+						Type: korifiv1alpha1.ManagedType,
+					},
+					Space:        korifiv1alpha1.UsageEventResource{GUID: instance.Namespace},
+					Organization: korifiv1alpha1.UsageEventResource{GUID: "org-guid"},
+					ServicePlan:  &korifiv1alpha1.UsageEventResource{GUID: servicePlan.Name},
+					ServiceOffering: &korifiv1alpha1.UsageEventResource{
+						GUID: servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel],
+						Name: "service-offering-name",
+					},
+					ServiceBroker: &korifiv1alpha1.UsageEventResource{
+						GUID: serviceBroker.Name,
+						Name: "my-service-broker",
+					},
+				}),
+			})))
+		}).Should(Succeed())
+	})
+
 	It("defaults the service label to the service offering name", func() {
 		Eventually(func(g Gomega) {
 			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
@@ -381,6 +409,48 @@ var _ = Describe("CFServiceInstance", func() {
 			}).Should(Succeed())
 		})
 
+		It("records the asynchronous operation", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.AsyncOperation).To(PointTo(MatchAllFields(Fields{
+					"Type":      Equal("create"),
+					"StartedAt": Not(BeZero()),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the plan maximum polling duration is exceeded", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+					servicePlan.Spec.BrokerCatalog.MaximumPollingDuration = tools.PtrTo[int32](0)
+				})).To(Succeed())
+			})
+
+			It("fails the instance", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElements(
+						SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("ProvisionTimedOut")),
+						),
+						SatisfyAll(
+							HasType(Equal(korifiv1alpha1.ProvisioningFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+							HasReason(Equal("ProvisionTimedOut")),
+						),
+					))
+					g.Expect(instance.Status.LastOperation).To(MatchAllFields(Fields{
+						"Type":        Equal("create"),
+						"State":       Equal("failed"),
+						"Description": ContainSubstring("maximum polling duration"),
+					}))
+					g.Expect(instance.Status.AsyncOperation).To(BeNil())
+				}).Should(Succeed())
+			})
+		})
+
 		When("getting service last operation fails", func() {
 			BeforeEach(func() {
 				brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{}, errors.New("get-last-op-failed"))
@@ -603,6 +673,331 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 
+	It("records the provisioned plan in the status", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+			g.Expect(instance.Status.PlanGUID).To(Equal(servicePlan.Name))
+		}).Should(Succeed())
+	})
+
+	When("the instance has been provisioned", func() {
+		BeforeEach(func() {
+			brokerClient.UpdateReturns(osbapi.UpdateResponse{}, nil)
+
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.PlanGUID).To(Equal(servicePlan.Name))
+				g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("does not update the instance", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(brokerClient.UpdateCallCount()).To(BeZero())
+			}).Should(Succeed())
+		})
+
+		When("the service plan is changed", func() {
+			var newServicePlan *korifiv1alpha1.CFServicePlan
+
+			BeforeEach(func() {
+				newServicePlan = &korifiv1alpha1.CFServicePlan{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+						Labels:    servicePlan.Labels,
+					},
+					Spec: korifiv1alpha1.CFServicePlanSpec{
+						Visibility: korifiv1alpha1.ServicePlanVisibility{
+							Type: "public",
+						},
+						BrokerCatalog: korifiv1alpha1.ServicePlanBrokerCatalog{
+							ID: "new-service-plan-id",
+						},
+						MaintenanceInfo: korifiv1alpha1.MaintenanceInfo{
+							Version: "2.0.0",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, newServicePlan)).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.PlanGUID = newServicePlan.Name
+				})).To(Succeed())
+			})
+
+			It("updates the instance plan at the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).NotTo(BeZero())
+					_, payload := brokerClient.UpdateArgsForCall(0)
+					g.Expect(payload).To(Equal(osbapi.UpdatePayload{
+						InstanceID: instance.Name,
+						UpdateRequest: osbapi.UpdateRequest{
+							ServiceId: "service-offering-id",
+							PlanID:    "new-service-plan-id",
+							MaintenanceInfo: &osbapi.MaintenanceInfo{
+								Version: "2.0.0",
+							},
+							PreviousValues: osbapi.PreviousValues{
+								ServiceId: "service-offering-id",
+								PlanID:    "service-plan-id",
+								SpaceGUID: "space-guid",
+								OrgGUID:   "org-guid",
+								MaintenanceInfo: &osbapi.MaintenanceInfo{
+									Version: "1.2.3",
+								},
+							},
+						},
+					}))
+				}).Should(Succeed())
+			})
+
+			It("records the new plan in the status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.PlanGUID).To(Equal(newServicePlan.Name))
+					g.Expect(instance.Status.MaintenanceInfo.Version).To(Equal("2.0.0"))
+					g.Expect(instance.Status.UpgradeAvailable).To(BeFalse())
+					g.Expect(instance.Status.LastOperation).To(Equal(korifiv1alpha1.LastOperation{
+						Type:  "update",
+						State: "succeeded",
+					}))
+					g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("records an UPDATED service usage event", func() {
+				Eventually(func(g Gomega) {
+					var serviceUsageEvents korifiv1alpha1.CFServiceUsageEventList
+					g.Expect(adminClient.List(ctx, &serviceUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+					g.Expect(serviceUsageEvents.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":       Equal(korifiv1alpha1.ServiceUsageStateUpdated),
+							"ServicePlan": PointTo(Equal(korifiv1alpha1.UsageEventResource{GUID: newServicePlan.Name})),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
+			When("the update is asynchronous", func() {
+				BeforeEach(func() {
+					brokerClient.UpdateReturns(osbapi.UpdateResponse{
+						IsAsync:   true,
+						Operation: "update-op",
+					}, nil)
+					brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{
+						State: "in progress",
+					}, nil)
+				})
+
+				It("sets the ready condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("UpdateInProgress")),
+						)))
+						g.Expect(instance.Status.LastOperation).To(Equal(korifiv1alpha1.LastOperation{
+							Type:  "update",
+							State: "in progress",
+						}))
+					}).Should(Succeed())
+				})
+
+				It("checks the update last operation", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.GetServiceInstanceLastOperationCallCount()).NotTo(BeZero())
+						_, lastOp := brokerClient.GetServiceInstanceLastOperationArgsForCall(brokerClient.GetServiceInstanceLastOperationCallCount() - 1)
+						g.Expect(lastOp).To(Equal(osbapi.GetInstanceLastOperationRequest{
+							InstanceID: instance.Name,
+							GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+								ServiceId: "service-offering-id",
+								PlanID:    "new-service-plan-id",
+								Operation: "update-op",
+							},
+						}))
+					}).Should(Succeed())
+				})
+
+				It("keeps the previous plan in the status", func() {
+					Consistently(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.PlanGUID).To(Equal(servicePlan.Name))
+					}).Should(Succeed())
+				})
+
+				When("the broker maximum polling duration is exceeded", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, adminClient, serviceBroker, func() {
+							serviceBroker.Spec.MaximumPollingDuration = &metav1.Duration{}
+						})).To(Succeed())
+					})
+
+					It("fails the update", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.UpdateFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasReason(Equal("UpdateTimedOut")),
+							)))
+							g.Expect(instance.Status.LastOperation.State).To(Equal("failed"))
+							g.Expect(instance.Status.PlanGUID).To(Equal(servicePlan.Name))
+						}).Should(Succeed())
+					})
+				})
+
+				When("the last operation is failed", func() {
+					BeforeEach(func() {
+						brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{
+							State:       "failed",
+							Description: "update-failed",
+						}, nil)
+					})
+
+					It("sets the update failed condition", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(instance.Status.Conditions).To(ContainElements(
+								SatisfyAll(
+									HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+									HasStatus(Equal(metav1.ConditionFalse)),
+									HasReason(Equal("UpdateFailed")),
+								),
+								SatisfyAll(
+									HasType(Equal(korifiv1alpha1.UpdateFailedCondition)),
+									HasStatus(Equal(metav1.ConditionTrue)),
+									HasMessage(Equal("update-failed")),
+								),
+							))
+							g.Expect(instance.Status.LastOperation).To(Equal(korifiv1alpha1.LastOperation{
+								Type:        "update",
+								State:       "failed",
+								Description: "update-failed",
+							}))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("the update fails with unrecoverable error", func() {
+				BeforeEach(func() {
+					brokerClient.UpdateReturns(osbapi.UpdateResponse{}, osbapi.UnrecoverableError{Status: http.StatusUnprocessableEntity})
+				})
+
+				It("fails the update", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.UpdateFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+							HasMessage(ContainSubstring("The server responded with status: 422")),
+						)))
+						g.Expect(instance.Status.LastOperation).To(Equal(korifiv1alpha1.LastOperation{
+							Type:  "update",
+							State: "failed",
+						}))
+						g.Expect(instance.Status.PlanGUID).To(Equal(servicePlan.Name))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the parameters are changed", func() {
+			var previousParamsSecret *corev1.Secret
+
+			BeforeEach(func() {
+				previousParamsSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: instance.Namespace,
+						Name:      uuid.NewString(),
+					},
+					Data: map[string][]byte{
+						tools.ParametersSecretKey: []byte(`{"p1":"p1-value"}`),
+					},
+				}
+				Expect(adminClient.Create(ctx, previousParamsSecret)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, instance, func() {
+					instance.Spec.Parameters.Name = previousParamsSecret.Name
+					instance.Status.Parameters.Name = previousParamsSecret.Name
+				})).To(Succeed())
+
+				paramsSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: instance.Namespace,
+						Name:      uuid.NewString(),
+					},
+					Data: map[string][]byte{
+						tools.ParametersSecretKey: []byte(`{"p2":"p2-value"}`),
+					},
+				}
+				Expect(adminClient.Create(ctx, paramsSecret)).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.Parameters.Name = paramsSecret.Name
+				})).To(Succeed())
+			})
+
+			It("sends the parameters to the broker without changing the plan", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).NotTo(BeZero())
+					_, payload := brokerClient.UpdateArgsForCall(brokerClient.UpdateCallCount() - 1)
+					g.Expect(payload.PlanID).To(BeEmpty())
+					g.Expect(payload.MaintenanceInfo).To(BeNil())
+					g.Expect(payload.Parameters).To(Equal(map[string]any{
+						"p2": "p2-value",
+					}))
+				}).Should(Succeed())
+			})
+
+			It("records the parameters in the status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Parameters).To(Equal(instance.Spec.Parameters))
+					g.Expect(instance.Status.MaintenanceInfo.Version).To(Equal("1.2.3"))
+				}).Should(Succeed())
+			})
+
+			It("deletes the previous parameters secret", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(previousParamsSecret), previousParamsSecret)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("an upgrade is requested", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+					servicePlan.Spec.MaintenanceInfo.Version = "2.3.4"
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.MaintenanceInfo.Version = "2.3.4"
+				})).To(Succeed())
+			})
+
+			It("sends the maintenance info to the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).NotTo(BeZero())
+					_, payload := brokerClient.UpdateArgsForCall(0)
+					g.Expect(payload.PlanID).To(BeEmpty())
+					g.Expect(payload.MaintenanceInfo).To(Equal(&osbapi.MaintenanceInfo{Version: "2.3.4"}))
+					g.Expect(payload.PreviousValues.MaintenanceInfo).To(Equal(&osbapi.MaintenanceInfo{Version: "1.2.3"}))
+				}).Should(Succeed())
+			})
+
+			It("upgrades the instance", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.MaintenanceInfo.Version).To(Equal("2.3.4"))
+					g.Expect(instance.Status.UpgradeAvailable).To(BeFalse())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the instance provisioning has failed", func() {
 		BeforeEach(func() {
 			Expect(k8s.Patch(ctx, adminClient, instance, func() {
@@ -753,6 +1148,50 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 
+	When("the service plan has space visibility type", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+				servicePlan.Spec.Visibility = korifiv1alpha1.ServicePlanVisibility{
+					Type:  korifiv1alpha1.SpaceServicePlanVisibilityType,
+					Space: "another-space",
+				}
+			})).To(Succeed())
+		})
+
+		It("fails the instance", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+
+				g.Expect(instance.Status.Conditions).To(ContainElements(
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("InvalidServicePlan")),
+						HasMessage(Equal("The service plan is disabled")),
+					),
+				))
+			}).Should(Succeed())
+		})
+
+		When("the plan is visible in the instance space", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+					servicePlan.Spec.Visibility.Space = instance.Namespace
+				})).To(Succeed())
+			})
+
+			It("becomes ready", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the service instance is user-provided", func() {
 		BeforeEach(func() {
 			Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
@@ -977,6 +1416,54 @@ var _ = Describe("CFServiceInstance", func() {
 						}))
 					}).Should(Succeed())
 				})
+
+				When("the maximum polling duration is exceeded", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+							servicePlan.Spec.BrokerCatalog.MaximumPollingDuration = tools.PtrTo[int32](0)
+						})).To(Succeed())
+					})
+
+					It("sets the deprovisioning failed condition", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.DeprovisioningFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasReason(Equal("DeprovisionTimedOut")),
+							)))
+						}).Should(Succeed())
+					})
+
+					It("does not call the broker again", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.DeprovisioningFailedCondition)).To(BeTrue())
+						}).Should(Succeed())
+
+						deprovisionCallCount := brokerClient.DeprovisionCallCount()
+						Consistently(func(g Gomega) {
+							g.Expect(brokerClient.DeprovisionCallCount()).To(Equal(deprovisionCallCount))
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						}).Should(Succeed())
+					})
+
+					It("can be purged", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.DeprovisioningFailedCondition)).To(BeTrue())
+						}).Should(Succeed())
+
+						Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+							instance.Annotations = tools.SetMapValue(instance.Annotations, korifiv1alpha1.DeprovisionWithoutBrokerAnnotation, "true")
+						})).To(Succeed())
+
+						Eventually(func(g Gomega) {
+							err := adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+							g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+						}).Should(Succeed())
+					})
+				})
 			})
 
 			When("the last operation is failed", func() {
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -144,6 +145,69 @@ var _ = Describe("CFServiceInstance", func() {
 				}).Should(Succeed())
 			})
 
+			It("records a CREATED service usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(serviceUsageEvents(g, instance.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+							State: korifiv1alpha1.ServiceUsageStateCreated,
+							ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+								GUID: instance.Name,
+								Name: "service-instance-name",
+								Type: korifiv1alpha1.UserProvidedType,
+							},
+							Space: korifiv1alpha1.UsageEventResource{GUID: testNamespace},
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
+			When("the service instance is renamed", func() {
+				BeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.Usage).NotTo(BeNil())
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+						instance.Spec.DisplayName = "new-name"
+					})).To(Succeed())
+				})
+
+				It("records an UPDATED service usage event", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(serviceUsageEvents(g, instance.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State": Equal(korifiv1alpha1.ServiceUsageStateUpdated),
+								"ServiceInstance": MatchFields(IgnoreExtras, Fields{
+									"Name": Equal("new-name"),
+								}),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the service instance is deleted after its creation has been recorded", func() {
+				BeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.Usage).NotTo(BeNil())
+					}).Should(Succeed())
+
+					Expect(adminClient.Delete(ctx, instance)).To(Succeed())
+				})
+
+				It("records a DELETED service usage event", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(serviceUsageEvents(g, instance.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State": Equal(korifiv1alpha1.ServiceUsageStateDeleted),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+
 			When("the credentials secret changes", func() {
 				var secretVersion string
 
@@ -281,3 +345,17 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 })
+
+func serviceUsageEvents(g Gomega, instanceGUID string) []korifiv1alpha1.CFServiceUsageEvent {
+	var serviceUsageEvents korifiv1alpha1.CFServiceUsageEventList
+	g.Expect(adminClient.List(ctx, &serviceUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	var result []korifiv1alpha1.CFServiceUsageEvent
+	for _, event := range serviceUsageEvents.Items {
+		if event.Spec.ServiceInstance.GUID == instanceGUID {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
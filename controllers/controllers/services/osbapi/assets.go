@@ -33,7 +33,7 @@ func (r *Assets) GetServiceInstanceAssets(ctx context.Context, serviceInstance *
 		return ServiceInstanceAssets{}, err
 	}
 
-	serviceBroker, err := r.getServiceBroker(ctx, servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel])
+	serviceBroker, err := r.getServiceBroker(ctx, servicePlan)
 	if err != nil {
 		return ServiceInstanceAssets{}, err
 	}
@@ -58,7 +58,7 @@ type ServiceBindingAssets struct {
 func (r *Assets) GetServiceBindingAssets(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (ServiceBindingAssets, error) {
 	serviceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: serviceBinding.Namespace,
+			Namespace: serviceBinding.ServiceInstanceNamespace(),
 			Name:      serviceBinding.Spec.Service.Name,
 		},
 	}
@@ -107,11 +107,19 @@ func (r *Assets) getServicePlan(ctx context.Context, planGUID string) (*korifiv1
 	return servicePlan, nil
 }
 
-func (r *Assets) getServiceBroker(ctx context.Context, brokerGUID string) (*korifiv1alpha1.CFServiceBroker, error) {
+func (r *Assets) getServiceBroker(ctx context.Context, servicePlan *korifiv1alpha1.CFServicePlan) (*korifiv1alpha1.CFServiceBroker, error) {
+	brokerGUID := servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]
+
+	// Space-scoped brokers live in their space namespace
+	brokerNamespace := r.rootNamespace
+	if spaceGUID, ok := servicePlan.Labels[korifiv1alpha1.RelServiceBrokerSpaceGUIDLabel]; ok {
+		brokerNamespace = spaceGUID
+	}
+
 	serviceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      brokerGUID,
-			Namespace: r.rootNamespace,
+			Namespace: brokerNamespace,
 		},
 	}
 	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)
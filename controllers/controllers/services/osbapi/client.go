@@ -8,12 +8,32 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
 )
 
 const osbapiVersion = "2.17"
 
+type originatingIdentityKey struct{}
+
+// WithOriginatingIdentity returns a context carrying the GUID of the CF user
+// that triggered the broker operation. Requests sent with this context
+// include the X-Broker-API-Originating-Identity header.
+func WithOriginatingIdentity(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, originatingIdentityKey{}, userID)
+}
+
+func originatingIdentityFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(originatingIdentityKey{}).(string)
+	return userID
+}
+
 type GoneError struct{}
 
 func (g GoneError) Error() string {
@@ -88,12 +108,20 @@ func (c *Client) Provision(ctx context.Context, payload ProvisionPayload) (Provi
 			payload.ProvisionRequest,
 		)
 	if err != nil {
+		if isTimeout(err) {
+			c.deprovisionOrphan(ctx, payload)
+		}
 		return ProvisionResponse{}, fmt.Errorf("provision request failed: %w", err)
 	}
 	if statusCode == http.StatusBadRequest || statusCode == http.StatusConflict || statusCode == http.StatusUnprocessableEntity {
 		return ProvisionResponse{}, UnrecoverableError{Status: statusCode}
 	}
 
+	if requiresOrphanMitigation(statusCode) {
+		c.deprovisionOrphan(ctx, payload)
+		return ProvisionResponse{}, fmt.Errorf("provision request failed with status code: %d", statusCode)
+	}
+
 	if statusCode >= 300 {
 		return ProvisionResponse{}, fmt.Errorf("provision request failed with status code: %d", statusCode)
 	}
@@ -104,12 +132,66 @@ func (c *Client) Provision(ctx context.Context, payload ProvisionPayload) (Provi
 
 	err = json.Unmarshal(respBytes, &response)
 	if err != nil {
+		if statusCode == http.StatusCreated {
+			c.deprovisionOrphan(ctx, payload)
+		}
 		return ProvisionResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return response, nil
 }
 
+// deprovisionOrphan makes a best effort attempt to clean up an instance that
+// the broker may have created even though the provision request failed, see
+// https://github.com/openservicebrokerapi/servicebroker/blob/v2.17/spec.md#orphan-mitigation
+func (c *Client) deprovisionOrphan(ctx context.Context, payload ProvisionPayload) {
+	_, err := c.Deprovision(context.WithoutCancel(ctx), DeprovisionPayload{
+		ID: payload.InstanceID,
+		DeprovisionRequestParamaters: DeprovisionRequestParamaters{
+			ServiceId: payload.ServiceId,
+			PlanID:    payload.PlanID,
+		},
+	})
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Info("orphan mitigation failed", "instance-id", payload.InstanceID, "reason", err)
+	}
+}
+
+func (c *Client) Update(ctx context.Context, payload UpdatePayload) (UpdateResponse, error) {
+	statusCode, respBytes, err := c.newBrokerRequester().
+		forBroker(c.broker).
+		async().
+		sendRequest(
+			ctx,
+			"/v2/service_instances/"+payload.InstanceID,
+			http.MethodPatch,
+			nil,
+			payload.UpdateRequest,
+		)
+	if err != nil {
+		return UpdateResponse{}, fmt.Errorf("update request failed: %w", err)
+	}
+
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity {
+		return UpdateResponse{}, UnrecoverableError{Status: statusCode}
+	}
+
+	if statusCode >= 300 {
+		return UpdateResponse{}, fmt.Errorf("update request failed with status code: %d", statusCode)
+	}
+
+	response := UpdateResponse{
+		IsAsync: statusCode == http.StatusAccepted,
+	}
+
+	err = json.Unmarshal(respBytes, &response)
+	if err != nil {
+		return UpdateResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return response, nil
+}
+
 func (c *Client) Deprovision(ctx context.Context, payload DeprovisionPayload) (ProvisionResponse, error) {
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
@@ -153,35 +235,69 @@ func (c *Client) Deprovision(ctx context.Context, payload DeprovisionPayload) (P
 }
 
 func (c *Client) GetServiceInstanceLastOperation(ctx context.Context, request GetInstanceLastOperationRequest) (LastOperationResponse, error) {
+	requester := c.newBrokerRequester().forBroker(c.broker)
+	statusCode, respBytes, err := requester.sendRequest(
+		ctx,
+		"/v2/service_instances/"+request.InstanceID+"/last_operation",
+		http.MethodGet,
+		map[string]string{
+			"service_id": request.ServiceId,
+			"plan_id":    request.PlanID,
+			"operation":  request.Operation,
+		},
+		nil,
+	)
+	if err != nil {
+		return LastOperationResponse{}, fmt.Errorf("getting service instance last operation request failed: %w", err)
+	}
+
+	if statusCode == http.StatusGone {
+		return LastOperationResponse{}, GoneError{}
+	}
+
+	if statusCode != http.StatusOK {
+		return LastOperationResponse{}, fmt.Errorf("getting service instance last operation request failed with code: %d", statusCode)
+	}
+
+	var response LastOperationResponse
+	err = json.Unmarshal(respBytes, &response)
+	if err != nil {
+		return LastOperationResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	response.RetryAfter = parseRetryAfter(requester.responseHeader.Get("Retry-After"))
+
+	return response, nil
+}
+
+func (c *Client) GetServiceInstance(ctx context.Context, request GetServiceInstanceRequest) (ServiceInstanceResponse, error) {
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
 		sendRequest(
 			ctx,
-			"/v2/service_instances/"+request.InstanceID+"/last_operation",
+			"/v2/service_instances/"+request.InstanceID,
 			http.MethodGet,
 			map[string]string{
 				"service_id": request.ServiceId,
 				"plan_id":    request.PlanID,
-				"operation":  request.Operation,
 			},
 			nil,
 		)
 	if err != nil {
-		return LastOperationResponse{}, fmt.Errorf("getting service instance last operation request failed: %w", err)
+		return ServiceInstanceResponse{}, fmt.Errorf("fetching service instance failed: %w", err)
 	}
 
-	if statusCode == http.StatusGone {
-		return LastOperationResponse{}, GoneError{}
+	if statusCode == http.StatusNotFound {
+		return ServiceInstanceResponse{}, UnrecoverableError{Status: statusCode}
 	}
 
 	if statusCode != http.StatusOK {
-		return LastOperationResponse{}, fmt.Errorf("getting service instance last operation request failed with code: %d", statusCode)
+		return ServiceInstanceResponse{}, fmt.Errorf("fetching service instance failed with code: %d", statusCode)
 	}
 
-	var response LastOperationResponse
+	response := ServiceInstanceResponse{}
 	err = json.Unmarshal(respBytes, &response)
 	if err != nil {
-		return LastOperationResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		return ServiceInstanceResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return response, nil
@@ -199,6 +315,9 @@ func (c *Client) Bind(ctx context.Context, payload BindPayload) (BindResponse, e
 			payload.BindRequest,
 		)
 	if err != nil {
+		if isTimeout(err) {
+			c.unbindOrphan(ctx, payload)
+		}
 		return BindResponse{}, fmt.Errorf("bind request failed: %w", err)
 	}
 
@@ -206,6 +325,11 @@ func (c *Client) Bind(ctx context.Context, payload BindPayload) (BindResponse, e
 		return BindResponse{}, UnrecoverableError{Status: statusCode}
 	}
 
+	if requiresOrphanMitigation(statusCode) {
+		c.unbindOrphan(ctx, payload)
+		return BindResponse{}, fmt.Errorf("binding request failed with code: %d", statusCode)
+	}
+
 	if statusCode >= 300 {
 		return BindResponse{}, fmt.Errorf("binding request failed with code: %d", statusCode)
 	}
@@ -216,26 +340,44 @@ func (c *Client) Bind(ctx context.Context, payload BindPayload) (BindResponse, e
 
 	err = json.Unmarshal(respBytes, &response)
 	if err != nil {
+		if statusCode == http.StatusCreated {
+			c.unbindOrphan(ctx, payload)
+		}
 		return BindResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return response, nil
 }
 
+// unbindOrphan makes a best effort attempt to clean up a binding that the
+// broker may have created even though the bind request failed
+func (c *Client) unbindOrphan(ctx context.Context, payload BindPayload) {
+	_, err := c.Unbind(context.WithoutCancel(ctx), UnbindPayload{
+		BindingID:  payload.BindingID,
+		InstanceID: payload.InstanceID,
+		UnbindRequestParameters: UnbindRequestParameters{
+			ServiceId: payload.ServiceId,
+			PlanID:    payload.PlanID,
+		},
+	})
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Info("orphan mitigation failed", "binding-id", payload.BindingID, "reason", err)
+	}
+}
+
 func (c *Client) GetServiceBindingLastOperation(ctx context.Context, request GetBindingLastOperationRequest) (LastOperationResponse, error) {
-	statusCode, respBytes, err := c.newBrokerRequester().
-		forBroker(c.broker).
-		sendRequest(
-			ctx,
-			"/v2/service_instances/"+request.InstanceID+"/service_bindings/"+request.BindingID+"/last_operation",
-			http.MethodGet,
-			map[string]string{
-				"service_id": request.ServiceId,
-				"plan_id":    request.PlanID,
-				"operation":  request.Operation,
-			},
-			nil,
-		)
+	requester := c.newBrokerRequester().forBroker(c.broker)
+	statusCode, respBytes, err := requester.sendRequest(
+		ctx,
+		"/v2/service_instances/"+request.InstanceID+"/service_bindings/"+request.BindingID+"/last_operation",
+		http.MethodGet,
+		map[string]string{
+			"service_id": request.ServiceId,
+			"plan_id":    request.PlanID,
+			"operation":  request.Operation,
+		},
+		nil,
+	)
 	if err != nil {
 		return LastOperationResponse{}, fmt.Errorf("getting service binding last operation request failed: %w", err)
 	}
@@ -253,6 +395,7 @@ func (c *Client) GetServiceBindingLastOperation(ctx context.Context, request Get
 	if err != nil {
 		return LastOperationResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	response.RetryAfter = parseRetryAfter(requester.responseHeader.Get("Retry-After"))
 
 	return response, nil
 }
@@ -328,6 +471,43 @@ func (c *Client) GetServiceBinding(ctx context.Context, payload BindPayload) (Bi
 	return response, nil
 }
 
+// requiresOrphanMitigation reports whether a response to a provision or bind
+// request may leave an orphaned resource behind on the broker
+func requiresOrphanMitigation(statusCode int) bool {
+	if statusCode >= 500 || statusCode == http.StatusRequestTimeout {
+		return true
+	}
+
+	return statusCode > http.StatusAccepted && statusCode < 300
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 func payloadToReader(payload any) (io.Reader, error) {
 	if payload == nil {
 		return nil, nil
@@ -345,6 +525,7 @@ type brokerRequester struct {
 	broker            Broker
 	acceptsIncomplete bool
 	httpClient        *http.Client
+	responseHeader    http.Header
 }
 
 func (c *Client) newBrokerRequester() *brokerRequester {
@@ -384,6 +565,10 @@ func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, m
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-Broker-API-Version", osbapiVersion)
+	req.Header.Add("X-Broker-API-Request-Identity", uuid.NewString())
+	if userID := originatingIdentityFromContext(ctx); userID != "" {
+		req.Header.Add("X-Broker-API-Originating-Identity", buildOriginatingIdentityHeaderValue(userID))
+	}
 
 	queryValues := req.URL.Query()
 	for queryParam, queryParamValue := range queryParams {
@@ -397,17 +582,22 @@ func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, m
 	}
 	req.URL.RawQuery = queryValues.Encode()
 
-	authHeader, err := r.buildAuthorizationHeaderValue()
-	if err != nil {
-		return 0, nil, fmt.Errorf("failed to build Authorization request header value: %w", err)
+	if authHeader := r.buildAuthorizationHeaderValue(); authHeader != "" {
+		req.Header.Add("Authorization", authHeader)
 	}
-	req.Header.Add("Authorization", authHeader)
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
 		return 0, nil, fmt.Errorf("failed to execute HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
+	r.responseHeader = resp.Header
+
+	// Brokers reject requests for API versions they do not support, retrying
+	// the request is pointless
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return 0, nil, fmt.Errorf("broker does not support OSBAPI version %s: %w", osbapiVersion, UnrecoverableError{Status: resp.StatusCode})
+	}
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -417,8 +607,23 @@ func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, m
 	return resp.StatusCode, respBody, nil
 }
 
-func (r *brokerRequester) buildAuthorizationHeaderValue() (string, error) {
+func (r *brokerRequester) buildAuthorizationHeaderValue() string {
+	if r.broker.Token != "" {
+		return "Bearer " + r.broker.Token
+	}
+
+	// Brokers authenticating with client certificates do not need an
+	// Authorization header
+	if r.broker.Username == "" && r.broker.Password == "" {
+		return ""
+	}
+
 	authPlain := fmt.Sprintf("%s:%s", r.broker.Username, r.broker.Password)
 	auth := base64.StdEncoding.EncodeToString([]byte(authPlain))
-	return "Basic " + auth, nil
+	return "Basic " + auth
+}
+
+func buildOriginatingIdentityHeaderValue(userID string) string {
+	identity, _ := json.Marshal(map[string]string{"user_id": userID})
+	return "cloudfoundry " + base64.StdEncoding.EncodeToString(identity)
 }
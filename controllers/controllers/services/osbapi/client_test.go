@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/tests/helpers/broker"
@@ -95,6 +96,44 @@ var _ = Describe("OSBAPI Client", func() {
 			}))))
 		})
 
+		It("sends a request identity", func() {
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Header": HaveKeyWithValue("X-Broker-Api-Request-Identity", ConsistOf(Not(BeEmpty()))),
+			}))))
+		})
+
+		It("does not send an originating identity", func() {
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Header": Not(HaveKey("X-Broker-Api-Originating-Identity")),
+			}))))
+		})
+
+		When("the context carries an originating identity", func() {
+			JustBeforeEach(func() {
+				catalog, getCatalogErr = brokerClient.GetCatalog(osbapi.WithOriginatingIdentity(ctx, "user-guid"))
+			})
+
+			It("sends the originating identity", func() {
+				Expect(getCatalogErr).NotTo(HaveOccurred())
+				requests := brokerServer.ServedRequests()
+				Expect(requests).To(HaveLen(2))
+				Expect(requests[1].Header.Get("X-Broker-API-Originating-Identity")).To(Equal(
+					"cloudfoundry " + base64.StdEncoding.EncodeToString([]byte(`{"user_id":"user-guid"}`)),
+				))
+			})
+		})
+
+		When("the broker does not support the OSBAPI version", func() {
+			BeforeEach(func() {
+				brokerServer = brokerServer.WithResponse("/v2/catalog", nil, http.StatusPreconditionFailed)
+			})
+
+			It("returns an unrecoverable error", func() {
+				Expect(osbapi.IsUnrecoveralbeError(getCatalogErr)).To(BeTrue())
+				Expect(getCatalogErr).To(MatchError(ContainSubstring("broker does not support OSBAPI version 2.17")))
+			})
+		})
+
 		When("getting the catalog fails", func() {
 			BeforeEach(func() {
 				brokerServer = brokerServer.WithResponse("/v2/catalog", nil, http.StatusTeapot)
@@ -233,6 +272,165 @@ var _ = Describe("OSBAPI Client", func() {
 				It("returns an error", func() {
 					Expect(provisionErr).To(MatchError(ContainSubstring("provision request failed")))
 				})
+
+				It("deprovisions the potentially orphaned instance", func() {
+					requests := brokerServer.ServedRequests()
+					Expect(requests).To(HaveLen(2))
+					Expect(requests[1].Method).To(Equal(http.MethodDelete))
+					Expect(requests[1].URL.Path).To(Equal("/v2/service_instances/my-service-instance"))
+					Expect(requests[1].URL.Query().Get("service_id")).To(Equal("service-guid"))
+					Expect(requests[1].URL.Query().Get("plan_id")).To(Equal("plan-guid"))
+				})
+			})
+
+			When("the provision request fails with a client error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusTeapot)
+				})
+
+				It("does not attempt orphan mitigation", func() {
+					Expect(provisionErr).To(HaveOccurred())
+					Expect(brokerServer.ServedRequests()).To(HaveLen(1))
+				})
+			})
+		})
+
+		Describe("Update", func() {
+			var (
+				updateResp osbapi.UpdateResponse
+				updateErr  error
+			)
+
+			BeforeEach(func() {
+				brokerServer = brokerServer.WithResponse(
+					"/v2/service_instances/{id}",
+					map[string]any{},
+					http.StatusOK,
+				)
+			})
+
+			JustBeforeEach(func() {
+				updateResp, updateErr = brokerClient.Update(ctx, osbapi.UpdatePayload{
+					InstanceID: "my-service-instance",
+					UpdateRequest: osbapi.UpdateRequest{
+						ServiceId: "service-guid",
+						PlanID:    "new-plan-guid",
+						Parameters: map[string]any{
+							"foo": "bar",
+						},
+						MaintenanceInfo: &osbapi.MaintenanceInfo{
+							Version: "2.0.0",
+						},
+						PreviousValues: osbapi.PreviousValues{
+							ServiceId: "service-guid",
+							PlanID:    "plan-guid",
+							SpaceGUID: "space-guid",
+							OrgGUID:   "org-guid",
+							MaintenanceInfo: &osbapi.MaintenanceInfo{
+								Version: "1.0.0",
+							},
+						},
+					},
+				})
+			})
+
+			It("sends async update request to broker", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				requests := brokerServer.ServedRequests()
+
+				Expect(requests).To(HaveLen(1))
+
+				Expect(requests[0].Method).To(Equal(http.MethodPatch))
+				Expect(requests[0].URL.Path).To(Equal("/v2/service_instances/my-service-instance"))
+
+				Expect(requests[0].URL.Query().Get("accepts_incomplete")).To(Equal("true"))
+			})
+
+			It("sends correct request body", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				requests := brokerServer.ServedRequests()
+
+				Expect(requests).To(HaveLen(1))
+
+				requestBytes, err := io.ReadAll(requests[0].Body)
+				Expect(err).NotTo(HaveOccurred())
+				requestBody := map[string]any{}
+				Expect(json.Unmarshal(requestBytes, &requestBody)).To(Succeed())
+
+				Expect(requestBody).To(MatchAllKeys(Keys{
+					"service_id": Equal("service-guid"),
+					"plan_id":    Equal("new-plan-guid"),
+					"parameters": MatchAllKeys(Keys{
+						"foo": Equal("bar"),
+					}),
+					"maintenance_info": MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+					}),
+					"previous_values": MatchAllKeys(Keys{
+						"service_id":      Equal("service-guid"),
+						"plan_id":         Equal("plan-guid"),
+						"space_id":        Equal("space-guid"),
+						"organization_id": Equal("org-guid"),
+						"maintenance_info": MatchAllKeys(Keys{
+							"version": Equal("1.0.0"),
+						}),
+					}),
+				}))
+			})
+
+			It("updates the service synchronously", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(updateResp).To(Equal(osbapi.UpdateResponse{}))
+			})
+
+			When("the broker accepts the update request", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{id}",
+						map[string]any{
+							"operation": "update_op1",
+						},
+						http.StatusAccepted,
+					)
+				})
+
+				It("updates the service asynchronously", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(updateResp).To(Equal(osbapi.UpdateResponse{
+						IsAsync:   true,
+						Operation: "update_op1",
+					}))
+				})
+			})
+
+			When("the update request fails with 400 BadRequest error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusBadRequest)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(updateErr).To(Equal(osbapi.UnrecoverableError{Status: http.StatusBadRequest}))
+				})
+			})
+
+			When("the update request fails with 422 Unprocessable entity error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusUnprocessableEntity)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(updateErr).To(Equal(osbapi.UnrecoverableError{Status: http.StatusUnprocessableEntity}))
+				})
+			})
+
+			When("the update request fails", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusInternalServerError)
+				})
+
+				It("returns an error", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("update request failed")))
+				})
 			})
 		})
 
@@ -390,6 +588,42 @@ var _ = Describe("OSBAPI Client", func() {
 				}))
 			})
 
+			When("the broker specifies when to poll again", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponseAndHeaders(
+						"/v2/service_instances/{id}/last_operation",
+						map[string]any{
+							"state": "in progress",
+						},
+						http.StatusOK,
+						map[string]string{"Retry-After": "30"},
+					)
+				})
+
+				It("returns the retry interval", func() {
+					Expect(lastOpErr).NotTo(HaveOccurred())
+					Expect(lastOpResp.RetryAfter).To(Equal(30 * time.Second))
+				})
+			})
+
+			When("the broker specifies an invalid retry interval", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponseAndHeaders(
+						"/v2/service_instances/{id}/last_operation",
+						map[string]any{
+							"state": "in progress",
+						},
+						http.StatusOK,
+						map[string]string{"Retry-After": "soon"},
+					)
+				})
+
+				It("ignores it", func() {
+					Expect(lastOpErr).NotTo(HaveOccurred())
+					Expect(lastOpResp.RetryAfter).To(BeZero())
+				})
+			})
+
 			It("sends correct request to broker", func() {
 				Expect(lastOpErr).NotTo(HaveOccurred())
 				requests := brokerServer.ServedRequests()
@@ -540,6 +774,23 @@ var _ = Describe("OSBAPI Client", func() {
 				}))
 			})
 
+			When("binding to a route", func() {
+				BeforeEach(func() {
+					brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						map[string]any{
+							"route_service_url": "https://route-service.io",
+						},
+						http.StatusCreated,
+					)
+				})
+
+				It("returns the route service url", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bindResp.RouteServiceURL).To(Equal("https://route-service.io"))
+				})
+			})
+
 			When("bind is asynchronous", func() {
 				BeforeEach(func() {
 					brokerServer.WithResponse(
@@ -574,6 +825,24 @@ var _ = Describe("OSBAPI Client", func() {
 				})
 			})
 
+			When("binding request fails with a server error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						nil,
+						http.StatusBadGateway,
+					)
+				})
+
+				It("unbinds the potentially orphaned binding", func() {
+					Expect(bindErr).To(MatchError(ContainSubstring("binding request failed")))
+					requests := brokerServer.ServedRequests()
+					Expect(requests).To(HaveLen(2))
+					Expect(requests[1].Method).To(Equal(http.MethodDelete))
+					Expect(requests[1].URL.Path).To(Equal("/v2/service_instances/instance-id/service_bindings/binding-id"))
+				})
+			})
+
 			When("binding request fails with 409 Conflict", func() {
 				BeforeEach(func() {
 					brokerServer = brokerServer.WithResponse(
@@ -617,6 +886,86 @@ var _ = Describe("OSBAPI Client", func() {
 			})
 		})
 
+		Describe("GetServiceInstance", func() {
+			var (
+				instanceResp   osbapi.ServiceInstanceResponse
+				getInstanceErr error
+			)
+
+			BeforeEach(func() {
+				brokerServer.WithResponse(
+					"/v2/service_instances/{instance_id}",
+					map[string]any{
+						"service_id":    "my-service-offering-id",
+						"plan_id":       "my-plan-id",
+						"dashboard_url": "https://dashboard.example.com",
+						"parameters": map[string]string{
+							"billing-account": "abcde12345",
+						},
+					},
+					http.StatusOK,
+				)
+			})
+
+			JustBeforeEach(func() {
+				instanceResp, getInstanceErr = brokerClient.GetServiceInstance(ctx, osbapi.GetServiceInstanceRequest{
+					InstanceID: "my-service-instance",
+					ServiceId:  "my-service-offering-id",
+					PlanID:     "my-plan-id",
+				})
+			})
+
+			It("gets the service instance", func() {
+				Expect(getInstanceErr).NotTo(HaveOccurred())
+
+				requests := brokerServer.ServedRequests()
+				Expect(requests).To(HaveLen(1))
+				Expect(requests[0].Method).To(Equal(http.MethodGet))
+				Expect(requests[0].URL.Path).To(Equal("/v2/service_instances/my-service-instance"))
+				Expect(requests[0].URL.Query()).To(BeEquivalentTo(map[string][]string{
+					"service_id": {"my-service-offering-id"},
+					"plan_id":    {"my-plan-id"},
+				}))
+
+				Expect(instanceResp).To(Equal(osbapi.ServiceInstanceResponse{
+					ServiceID:    "my-service-offering-id",
+					PlanID:       "my-plan-id",
+					DashboardURL: "https://dashboard.example.com",
+					Parameters: map[string]any{
+						"billing-account": "abcde12345",
+					},
+				}))
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}",
+						nil,
+						http.StatusNotFound,
+					)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(getInstanceErr).To(BeAssignableToTypeOf(osbapi.UnrecoverableError{}))
+				})
+			})
+
+			When("the broker fails", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}",
+						nil,
+						http.StatusTeapot,
+					)
+				})
+
+				It("returns an error", func() {
+					Expect(getInstanceErr).To(MatchError(ContainSubstring("fetching service instance failed with code: 418")))
+				})
+			})
+		})
+
 		Describe("GetServiceBinding", func() {
 			var (
 				bindingResp osbapi.BindingResponse
@@ -626,6 +975,9 @@ var _ = Describe("OSBAPI Client", func() {
 				brokerServer.WithResponse(
 					"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
 					map[string]any{
+						"credentials": map[string]string{
+							"password": "rotated-password",
+						},
 						"parameters": map[string]string{
 							"billing-account": "abcde12345",
 						},
@@ -657,6 +1009,9 @@ var _ = Describe("OSBAPI Client", func() {
 				}))
 
 				Expect(bindingResp).To(Equal(osbapi.BindingResponse{
+					Credentials: map[string]any{
+						"password": "rotated-password",
+					},
 					Parameters: map[string]any{
 						"billing-account": "abcde12345",
 					},
@@ -3,9 +3,12 @@ package osbapi
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
@@ -17,8 +20,10 @@ import (
 //counterfeiter:generate -o fake -fake-name BrokerClient code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi.BrokerClient
 type BrokerClient interface {
 	Provision(context.Context, ProvisionPayload) (ProvisionResponse, error)
+	Update(context.Context, UpdatePayload) (UpdateResponse, error)
 	Deprovision(context.Context, DeprovisionPayload) (ProvisionResponse, error)
 	GetServiceInstanceLastOperation(context.Context, GetInstanceLastOperationRequest) (LastOperationResponse, error)
+	GetServiceInstance(context.Context, GetServiceInstanceRequest) (ServiceInstanceResponse, error)
 	GetCatalog(context.Context) (Catalog, error)
 	Bind(context.Context, BindPayload) (BindResponse, error)
 	Unbind(context.Context, UnbindPayload) (UnbindResponse, error)
@@ -31,6 +36,8 @@ type BrokerClientFactory interface {
 	CreateClient(context.Context, *korifiv1alpha1.CFServiceBroker) (BrokerClient, error)
 }
 
+const DefaultRequestTimeout = 60 * time.Second
+
 type ClientFactory struct {
 	k8sClient            client.Client
 	trustInsecureBrokers bool
@@ -62,14 +69,59 @@ func (f *ClientFactory) CreateClient(ctx context.Context, cfServiceBroker *korif
 		return nil, fmt.Errorf("failed to unmarshal broker credentials secret: %w", err)
 	}
 
+	broker := Broker{URL: cfServiceBroker.Spec.URL}
+	tlsConfig := &tls.Config{InsecureSkipVerify: f.trustInsecureBrokers} //#nosec G402
+
+	switch cfServiceBroker.Spec.AuthenticationType {
+	case korifiv1alpha1.BearerAuthenticationType:
+		broker.Token = creds[korifiv1alpha1.TokenCredentialsKey]
+	case korifiv1alpha1.ClientCertificateAuthenticationType:
+		err = configureClientCertificate(tlsConfig, creds)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		broker.Username = creds[korifiv1alpha1.UsernameCredentialsKey]
+		broker.Password = creds[korifiv1alpha1.PasswordCredentialsKey]
+	}
+
 	return NewClient(
-		Broker{
-			URL:      cfServiceBroker.Spec.URL,
-			Username: creds["username"],
-			Password: creds["password"],
+		broker,
+		&http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			Timeout:   requestTimeout(cfServiceBroker),
 		},
-		&http.Client{Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: f.trustInsecureBrokers}, //#nosec G402
-		}},
 	), nil
 }
+
+func configureClientCertificate(tlsConfig *tls.Config, creds map[string]string) error {
+	certificate, err := tls.X509KeyPair(
+		[]byte(creds[korifiv1alpha1.CertificateCredentialsKey]),
+		[]byte(creds[korifiv1alpha1.PrivateKeyCredentialsKey]),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load broker client certificate: %w", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{certificate}
+
+	caCertificate, ok := creds[korifiv1alpha1.CACertificateCredentialsKey]
+	if !ok {
+		return nil
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM([]byte(caCertificate)) {
+		return errors.New("failed to parse broker CA certificate")
+	}
+	tlsConfig.RootCAs = rootCAs
+
+	return nil
+}
+
+func requestTimeout(cfServiceBroker *korifiv1alpha1.CFServiceBroker) time.Duration {
+	if cfServiceBroker.Spec.RequestTimeout == nil {
+		return DefaultRequestTimeout
+	}
+
+	return cfServiceBroker.Spec.RequestTimeout.Duration
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -114,6 +115,47 @@ var _ = Describe("ClientFactory", func() {
 		})
 	})
 
+	When("the broker uses bearer token authentication", func() {
+		BeforeEach(func() {
+			credentialsSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      cfServiceBroker.Spec.Credentials.Name,
+				},
+			}
+			helpers.EnsurePatch(k8sClient, credentialsSecret, func(s *corev1.Secret) {
+				s.Data = map[string][]byte{
+					tools.CredentialsSecretKey: []byte(`{"token": "broker-token"}`),
+				}
+			})
+			helpers.EnsurePatch(k8sClient, cfServiceBroker, func(b *korifiv1alpha1.CFServiceBroker) {
+				b.Spec.AuthenticationType = korifiv1alpha1.BearerAuthenticationType
+			})
+		})
+
+		It("creates a client that sends the token", func() {
+			Expect(createClientErr).NotTo(HaveOccurred())
+
+			_, err := osbapiClient.GetCatalog(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(brokerServer.ServedRequests()).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Header": HaveKeyWithValue("Authorization", ConsistOf("Bearer broker-token")),
+			}))))
+		})
+	})
+
+	When("the broker uses client certificate authentication with an invalid certificate", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(k8sClient, cfServiceBroker, func(b *korifiv1alpha1.CFServiceBroker) {
+				b.Spec.AuthenticationType = korifiv1alpha1.ClientCertificateAuthenticationType
+			})
+		})
+
+		It("returns an error", func() {
+			Expect(createClientErr).To(MatchError(ContainSubstring("failed to load broker client certificate")))
+		})
+	})
+
 	When("the client does not trust insecure brokers", func() {
 		BeforeEach(func() {
 			factory = osbapi.NewClientFactory(k8sClient, false)
@@ -0,0 +1,105 @@
+package osbapi
+
+import (
+	"fmt"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/k8s/conditions"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultMaximumPollingDuration is how long asynchronous operations are polled
+// for when neither the service plan nor the service broker specify it
+const DefaultMaximumPollingDuration = 7 * 24 * time.Hour
+
+// MaximumPollingDuration returns how long asynchronous operations on the
+// service instance are polled for. The maximum_polling_duration advertised by
+// the plan takes precedence over the one configured on the broker
+func (a ServiceInstanceAssets) MaximumPollingDuration() time.Duration {
+	if planDuration := a.ServicePlan.Spec.BrokerCatalog.MaximumPollingDuration; planDuration != nil {
+		return time.Duration(*planDuration) * time.Second
+	}
+
+	if brokerDuration := a.ServiceBroker.Spec.MaximumPollingDuration; brokerDuration != nil {
+		return brokerDuration.Duration
+	}
+
+	return DefaultMaximumPollingDuration
+}
+
+// TrackAsyncOperation returns the asynchronous operation of the given type to
+// poll. Brokers are sent the same request on every reconcile until the
+// operation completes, so the start time of an operation of the same type
+// that is already being tracked is preserved
+func TrackAsyncOperation(asyncOperation *korifiv1alpha1.AsyncOperation, operationType string) *korifiv1alpha1.AsyncOperation {
+	if asyncOperation != nil && asyncOperation.Type == operationType {
+		return asyncOperation
+	}
+
+	return &korifiv1alpha1.AsyncOperation{
+		Type:      operationType,
+		StartedAt: metav1.NewTime(time.Now()),
+	}
+}
+
+// IsPollingDeadlineExceeded reports whether the asynchronous operation has
+// been polled for longer than the maximum polling duration
+func IsPollingDeadlineExceeded(asyncOperation *korifiv1alpha1.AsyncOperation, maximumPollingDuration time.Duration) bool {
+	if asyncOperation == nil {
+		return false
+	}
+
+	return time.Since(asyncOperation.StartedAt.Time) > maximumPollingDuration
+}
+
+// PollingDeadlineExceededMessage describes why an operation that has not
+// completed within the maximum polling duration is considered failed
+func PollingDeadlineExceededMessage(maximumPollingDuration time.Duration) string {
+	return fmt.Sprintf("The broker did not complete the operation within the maximum polling duration of %s", maximumPollingDuration)
+}
+
+// FailTimedOutOperation sets the failed condition of the asynchronous
+// operation that has been polled for longer than the maximum polling
+// duration. The outcome of the operation on the broker is unknown, therefore
+// the returned error stops the reconciliation instead of retrying it
+func FailTimedOutOperation(
+	obj conditions.RuntimeObjectWithStatusConditions,
+	conditionType string,
+	reason string,
+	maximumPollingDuration time.Duration,
+) k8s.NotReadyError {
+	message := PollingDeadlineExceededMessage(maximumPollingDuration)
+
+	meta.SetStatusCondition(obj.StatusConditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             reason,
+		Message:            message,
+	})
+
+	return k8s.NewNotReadyError().WithReason(reason).WithMessage(message).WithNoRequeue()
+}
+
+// IsOperationTimedOut reports whether the failed condition has been set by
+// FailTimedOutOperation with the given reason
+func IsOperationTimedOut(obj conditions.RuntimeObjectWithStatusConditions, conditionType string, reason string) bool {
+	condition := meta.FindStatusCondition(*obj.StatusConditions(), conditionType)
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.Reason == reason
+}
+
+// InProgress requeues the reconciliation of an operation that is still in
+// progress, honouring the polling interval requested by the broker
+func InProgress(reason string, lastOpResponse LastOperationResponse) k8s.NotReadyError {
+	notReadyErr := k8s.NewNotReadyError().WithReason(reason)
+	if lastOpResponse.RetryAfter > 0 {
+		return notReadyErr.WithRequeueAfter(lastOpResponse.RetryAfter)
+	}
+
+	return notReadyErr.WithRequeue()
+}
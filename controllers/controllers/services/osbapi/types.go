@@ -1,9 +1,12 @@
 package osbapi
 
+import "time"
+
 type Broker struct {
 	URL      string
 	Username string
 	Password string
+	Token    string
 }
 
 type Catalog struct {
@@ -38,9 +41,11 @@ type Plan struct {
 	Free             bool               `json:"free"`
 	Bindable         bool               `json:"bindable"`
 	BindingRotatable bool               `json:"binding_rotatable"`
-	PlanUpdateable   bool               `json:"plan_updateable"`
+	PlanUpdateable   *bool              `json:"plan_updateable,omitempty"`
 	Schemas          ServicePlanSchemas `json:"schemas"`
 	MaintenanceInfo  MaintenanceInfo    `json:"maintenance_info"`
+
+	MaximumPollingDuration *int32 `json:"maximum_polling_duration"`
 }
 
 type ServicePlanSchemas struct {
@@ -83,6 +88,46 @@ type ProvisionResponse struct {
 	Operation string `json:"operation,omitempty"`
 }
 
+type UpdatePayload struct {
+	InstanceID string
+	UpdateRequest
+}
+
+type UpdateRequest struct {
+	ServiceId       string           `json:"service_id"`
+	PlanID          string           `json:"plan_id,omitempty"`
+	Parameters      map[string]any   `json:"parameters,omitempty"`
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+	PreviousValues  PreviousValues   `json:"previous_values"`
+}
+
+type PreviousValues struct {
+	ServiceId       string           `json:"service_id,omitempty"`
+	PlanID          string           `json:"plan_id,omitempty"`
+	SpaceGUID       string           `json:"space_id,omitempty"`
+	OrgGUID         string           `json:"organization_id,omitempty"`
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+}
+
+type UpdateResponse struct {
+	IsAsync   bool
+	Operation string `json:"operation,omitempty"`
+}
+
+type GetServiceInstanceRequest struct {
+	InstanceID string
+	ServiceId  string
+	PlanID     string
+}
+
+type ServiceInstanceResponse struct {
+	ServiceID       string           `json:"service_id"`
+	PlanID          string           `json:"plan_id"`
+	DashboardURL    string           `json:"dashboard_url"`
+	Parameters      map[string]any   `json:"parameters"`
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+}
+
 type GetBindingRequest struct {
 	InstanceID string
 	BindingID  string
@@ -121,11 +166,12 @@ type DeprovisionRequestParamaters struct {
 }
 
 type BindRequest struct {
-	ServiceId    string         `json:"service_id"`
-	PlanID       string         `json:"plan_id"`
-	AppGUID      string         `json:"app_guid"`
-	BindResource BindResource   `json:"bind_resource"`
-	Parameters   map[string]any `json:"parameters"`
+	ServiceId            string         `json:"service_id"`
+	PlanID               string         `json:"plan_id"`
+	AppGUID              string         `json:"app_guid"`
+	BindResource         BindResource   `json:"bind_resource"`
+	Parameters           map[string]any `json:"parameters"`
+	PredecessorBindingID string         `json:"predecessor_binding_id,omitempty"`
 }
 
 type BindPayload struct {
@@ -135,17 +181,20 @@ type BindPayload struct {
 }
 
 type BindResponse struct {
-	Credentials map[string]any `json:"credentials"`
-	Operation   string         `json:"operation"`
-	IsAsync     bool
+	Credentials     map[string]any `json:"credentials"`
+	RouteServiceURL string         `json:"route_service_url"`
+	Operation       string         `json:"operation"`
+	IsAsync         bool
 }
 
 type BindingResponse struct {
-	Parameters map[string]any `json:"parameters"`
+	Credentials map[string]any `json:"credentials"`
+	Parameters  map[string]any `json:"parameters"`
 }
 
 type BindResource struct {
 	AppGUID string `json:"app_guid"`
+	Route   string `json:"route,omitempty"`
 }
 
 type UnbindPayload struct {
@@ -171,6 +220,11 @@ func (r UnbindResponse) IsComplete() bool {
 type LastOperationResponse struct {
 	State       LastOperationResponseState `json:"state"`
 	Description string                     `json:"description"`
+
+	// How long the broker asks the platform to wait before polling the
+	// operation again, as specified by the Retry-After response header. Zero
+	// if the broker did not specify it
+	RetryAfter time.Duration `json:"-"`
 }
 
 type LastOperationResponseState string
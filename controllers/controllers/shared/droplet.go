@@ -0,0 +1,34 @@
+package shared
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdroplets,verbs=get;list;watch
+
+// GetDroplet resolves a droplet reference of an app. The reference either
+// names the CFBuild that staged the droplet or a CFDroplet created via the
+// API. The returned droplet is nil if it has no image yet.
+func GetDroplet(ctx context.Context, k8sClient client.Client, namespace, name string) (*korifiv1alpha1.BuildDropletStatus, error) {
+	cfBuild := new(korifiv1alpha1.CFBuild)
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cfBuild)
+	if err == nil {
+		return cfBuild.Status.Droplet, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	cfDroplet := new(korifiv1alpha1.CFDroplet)
+	err = k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cfDroplet)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfDroplet.DropletStatus(), nil
+}
@@ -0,0 +1,257 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents,verbs=create
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceusageevents,verbs=create
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Recorder emits app and service usage events into the root namespace. The
+// usage last recorded for a process or service instance is kept in its
+// status, so that events are only emitted on actual transitions.
+type Recorder struct {
+	k8sClient     client.Client
+	rootNamespace string
+	assets        *osbapi.Assets
+}
+
+func NewRecorder(k8sClient client.Client, rootNamespace string) *Recorder {
+	return &Recorder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+		assets:        osbapi.NewAssets(k8sClient, rootNamespace),
+	}
+}
+
+// RecordProcessUsage emits an app usage event if the process has been
+// started, stopped or scaled since the last recorded event. The app may be
+// nil if it has already been deleted.
+func (r *Recorder) RecordProcessUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
+	current := korifiv1alpha1.ProcessUsage{State: korifiv1alpha1.AppUsageStateStopped}
+	if cfApp != nil && cfProcess.GetDeletionTimestamp().IsZero() && cfApp.Spec.DesiredState == korifiv1alpha1.StartedState {
+		current = korifiv1alpha1.ProcessUsage{
+			State:     korifiv1alpha1.AppUsageStateStarted,
+			MemoryMB:  cfProcess.Spec.MemoryMB,
+			Instances: tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+		}
+	}
+
+	previous := korifiv1alpha1.ProcessUsage{State: korifiv1alpha1.AppUsageStateStopped}
+	if cfProcess.Status.Usage != nil {
+		previous = *cfProcess.Status.Usage
+	}
+
+	if previous == current {
+		cfProcess.Status.Usage = &current
+		return nil
+	}
+
+	state := current.State
+	if previous.State == korifiv1alpha1.AppUsageStateStarted && current.State == korifiv1alpha1.AppUsageStateStarted {
+		state = korifiv1alpha1.AppUsageStateScaled
+	}
+
+	space, org, err := r.getSpaceAndOrg(ctx, cfProcess.Namespace)
+	if err != nil {
+		return err
+	}
+
+	app := korifiv1alpha1.UsageEventResource{GUID: cfProcess.Spec.AppRef.Name}
+	if cfApp != nil {
+		app.Name = cfApp.Spec.DisplayName
+	}
+
+	err = r.createEvent(ctx, &korifiv1alpha1.CFAppUsageEvent{
+		ObjectMeta: r.eventMeta(),
+		Spec: korifiv1alpha1.CFAppUsageEventSpec{
+			State:         state,
+			PreviousState: previous.State,
+			App:           app,
+			Process: korifiv1alpha1.UsageEventProcess{
+				GUID: cfProcess.Name,
+				Type: cfProcess.Spec.ProcessType,
+			},
+			Space:                         space,
+			Organization:                  org,
+			MemoryInMBPerInstance:         current.MemoryMB,
+			PreviousMemoryInMBPerInstance: previous.MemoryMB,
+			InstanceCount:                 current.Instances,
+			PreviousInstanceCount:         previous.Instances,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	cfProcess.Status.Usage = &current
+	return nil
+}
+
+// RecordServiceInstanceUsage emits a service usage event when the service
+// instance is first created, or when its name or plan changed since the last
+// recorded event.
+func (r *Recorder) RecordServiceInstanceUsage(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	current := korifiv1alpha1.ServiceInstanceUsage{
+		State:    korifiv1alpha1.ServiceUsageStateCreated,
+		Name:     serviceInstance.Spec.DisplayName,
+		PlanGUID: serviceInstance.Spec.PlanGUID,
+	}
+
+	previous := serviceInstance.Status.Usage
+	if previous != nil && *previous == current {
+		return nil
+	}
+
+	state := korifiv1alpha1.ServiceUsageStateCreated
+	if previous != nil {
+		state = korifiv1alpha1.ServiceUsageStateUpdated
+	}
+
+	event, err := r.serviceUsageEvent(ctx, serviceInstance, state)
+	if err != nil {
+		return err
+	}
+
+	if err = r.createEvent(ctx, event); err != nil {
+		return err
+	}
+
+	serviceInstance.Status.Usage = &current
+	return nil
+}
+
+// RecordServiceInstanceDeletion emits a DELETED service usage event for a
+// service instance whose creation has been recorded before.
+func (r *Recorder) RecordServiceInstanceDeletion(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	if serviceInstance.Status.Usage == nil || serviceInstance.Status.Usage.State == korifiv1alpha1.ServiceUsageStateDeleted {
+		return nil
+	}
+
+	event, err := r.serviceUsageEvent(ctx, serviceInstance, korifiv1alpha1.ServiceUsageStateDeleted)
+	if err != nil {
+		return err
+	}
+
+	if err = r.createEvent(ctx, event); err != nil {
+		return err
+	}
+
+	serviceInstance.Status.Usage.State = korifiv1alpha1.ServiceUsageStateDeleted
+	return nil
+}
+
+func (r *Recorder) serviceUsageEvent(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	state string,
+) (*korifiv1alpha1.CFServiceUsageEvent, error) {
+	space, org, err := r.getSpaceAndOrg(ctx, serviceInstance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &korifiv1alpha1.CFServiceUsageEvent{
+		ObjectMeta: r.eventMeta(),
+		Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+			State: state,
+			ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+				GUID: serviceInstance.Name,
+				Name: serviceInstance.Spec.DisplayName,
+				Type: serviceInstance.Spec.Type,
+			},
+			Space:        space,
+			Organization: org,
+		},
+	}
+
+	if serviceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return event, nil
+	}
+
+	assets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		if state != korifiv1alpha1.ServiceUsageStateDeleted {
+			return nil, fmt.Errorf("failed to get service instance assets: %w", err)
+		}
+
+		// The plan may be long gone by the time the instance is deleted,
+		// do not block the deletion on it
+		logr.FromContextOrDiscard(ctx).Info("recording service usage without plan details", "reason", err)
+		return event, nil
+	}
+
+	event.Spec.ServicePlan = &korifiv1alpha1.UsageEventResource{
+		GUID: assets.ServicePlan.Name,
+		Name: assets.ServicePlan.Spec.Name,
+	}
+	event.Spec.ServiceOffering = &korifiv1alpha1.UsageEventResource{
+		GUID: assets.ServiceOffering.Name,
+		Name: assets.ServiceOffering.Spec.Name,
+	}
+	event.Spec.ServiceBroker = &korifiv1alpha1.UsageEventResource{
+		GUID: assets.ServiceBroker.Name,
+		Name: assets.ServiceBroker.Spec.Name,
+	}
+
+	return event, nil
+}
+
+func (r *Recorder) getSpaceAndOrg(ctx context.Context, spaceNamespace string) (korifiv1alpha1.UsageEventResource, korifiv1alpha1.UsageEventResource, error) {
+	spaceNS := &corev1.Namespace{}
+	if err := r.k8sClient.Get(ctx, client.ObjectKey{Name: spaceNamespace}, spaceNS); err != nil {
+		return korifiv1alpha1.UsageEventResource{}, korifiv1alpha1.UsageEventResource{}, fmt.Errorf("failed to get space namespace %q: %w", spaceNamespace, err)
+	}
+
+	space := korifiv1alpha1.UsageEventResource{
+		GUID: spaceNS.Name,
+		Name: spaceNS.Annotations[korifiv1alpha1.SpaceNameKey],
+	}
+	org := korifiv1alpha1.UsageEventResource{
+		GUID: spaceNS.Labels[korifiv1alpha1.OrgGUIDKey],
+	}
+
+	if org.GUID == "" {
+		return space, org, nil
+	}
+
+	orgNS := &corev1.Namespace{}
+	if err := r.k8sClient.Get(ctx, client.ObjectKey{Name: org.GUID}, orgNS); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return korifiv1alpha1.UsageEventResource{}, korifiv1alpha1.UsageEventResource{}, fmt.Errorf("failed to get org namespace %q: %w", org.GUID, err)
+		}
+		return space, org, nil
+	}
+	org.Name = orgNS.Annotations[korifiv1alpha1.OrgNameKey]
+
+	return space, org, nil
+}
+
+func (r *Recorder) eventMeta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: r.rootNamespace,
+		// Version 7 UUIDs are time ordered, which lets consumers page
+		// through usage events by guid
+		Name: uuid.Must(uuid.NewV7()).String(),
+	}
+}
+
+func (r *Recorder) createEvent(ctx context.Context, event client.Object) error {
+	if err := r.k8sClient.Create(ctx, event); err != nil {
+		return fmt.Errorf("failed to create usage event: %w", err)
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("usage event recorded", "name", event.GetName())
+	return nil
+}
@@ -60,6 +60,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 			&korifiv1alpha1.CFBuild{},
 			handler.EnqueueRequestsFromMapFunc(buildToApp),
 		).
+		Watches(
+			&korifiv1alpha1.CFDroplet{},
+			handler.EnqueueRequestsFromMapFunc(dropletToApp),
+		).
 		Watches(
 			&korifiv1alpha1.CFServiceBinding{},
 			handler.EnqueueRequestsFromMapFunc(serviceBindingToApp),
@@ -82,6 +86,22 @@ func buildToApp(ctx context.Context, o client.Object) []reconcile.Request {
 	}
 }
 
+func dropletToApp(ctx context.Context, o client.Object) []reconcile.Request {
+	cfDroplet, ok := o.(*korifiv1alpha1.CFDroplet)
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      cfDroplet.Spec.AppRef.Name,
+				Namespace: o.GetNamespace(),
+			},
+		},
+	}
+}
+
 func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Request {
 	serviceBinding, ok := o.(*korifiv1alpha1.CFServiceBinding)
 	if !ok {
@@ -145,6 +165,10 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 
 	cfApp.Status.VCAPServicesSecretName = secretName
 
+	if err = restartForCredentialsRotation(ctx, cfApp, bindings); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if cfApp.Spec.CurrentDropletRef.Name == "" {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DropletNotAssigned")
 	}
@@ -154,11 +178,21 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("CannotResolveCurrentDropletRef")
 	}
 
+	if err = r.restoreRollbackRevision(ctx, cfApp); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	reconciledProcesses, err := r.reconcileProcesses(ctx, cfApp, droplet)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StartedState {
+		if err = r.reconcileRevision(ctx, cfApp, reconciledProcesses); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	cfApp.Status.ActualState = getActualState(reconciledProcesses)
 	if cfApp.Status.ActualState != cfApp.Spec.DesiredState {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DesiredStateNotReached")
@@ -227,20 +261,19 @@ func getActualState(processes []*korifiv1alpha1.CFProcess) korifiv1alpha1.AppSta
 func (r *Reconciler) getDroplet(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.BuildDropletStatus, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("getDroplet").WithValues("dropletName", cfApp.Spec.CurrentDropletRef.Name)
 
-	var cfBuild korifiv1alpha1.CFBuild
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfApp.Spec.CurrentDropletRef.Name, Namespace: cfApp.Namespace}, &cfBuild)
+	droplet, err := shared.GetDroplet(ctx, r.k8sClient, cfApp.Namespace, cfApp.Spec.CurrentDropletRef.Name)
 	if err != nil {
-		log.Info("error when fetching CFBuild", "reason", err)
+		log.Info("error when fetching droplet", "reason", err)
 		return nil, err
 	}
 
-	if cfBuild.Status.Droplet == nil {
-		err = errors.New("status field CFBuildDropletStatus is nil on CFBuild")
+	if droplet == nil {
+		err = errors.New("current droplet has no image")
 		log.Info(err.Error())
 		return nil, err
 	}
 
-	return cfBuild.Status.Droplet, nil
+	return droplet, nil
 }
 
 func (r *Reconciler) reconcileProcesses(ctx context.Context, cfApp *korifiv1alpha1.CFApp, droplet *korifiv1alpha1.BuildDropletStatus) ([]*korifiv1alpha1.CFProcess, error) {
@@ -259,14 +292,14 @@ func (r *Reconciler) reconcileProcesses(ctx context.Context, cfApp *korifiv1alph
 		}
 
 		if existingProcess != nil {
-			err = r.updateCFProcess(ctx, existingProcess, dropletProcess.Command, cfApp.Status.ServiceBindings)
+			err = r.updateCFProcess(ctx, cfApp, existingProcess, dropletProcess.Command, droplet.Sidecars)
 			if err != nil {
 				loopLog.Info("error updating CFProcess", "reason", err)
 				return nil, err
 			}
 			reconciledProcess = append(reconciledProcess, existingProcess)
 		} else {
-			createdProcess, err := r.createCFProcess(ctx, dropletProcess, cfApp)
+			createdProcess, err := r.createCFProcess(ctx, dropletProcess, droplet.Sidecars, cfApp)
 			if err != nil {
 				loopLog.Info("error creating CFProcess", "reason", err)
 				return nil, err
@@ -288,13 +321,43 @@ func addWebIfMissing(processTypes []korifiv1alpha1.ProcessType) []korifiv1alpha1
 	return append([]korifiv1alpha1.ProcessType{{Type: korifiv1alpha1.ProcessTypeWeb}}, processTypes...)
 }
 
-func (r *Reconciler) updateCFProcess(ctx context.Context, process *korifiv1alpha1.CFProcess, command string, bindings []korifiv1alpha1.ServiceBinding) error {
+func (r *Reconciler) updateCFProcess(ctx context.Context, cfApp *korifiv1alpha1.CFApp, process *korifiv1alpha1.CFProcess, command string, dropletSidecars []korifiv1alpha1.DropletSidecar) error {
 	return k8s.Patch(ctx, r.k8sClient, process, func() {
 		process.Spec.DetectedCommand = command
+		process.Spec.Sidecars = withBuildpackSidecars(process.Spec.Sidecars, cfApp.Name, process.Spec.ProcessType, dropletSidecars)
+	})
+}
+
+// withBuildpackSidecars replaces the buildpack sidecars of a process with the
+// ones the droplet declares for its type. User sidecars take precedence over
+// buildpack sidecars with the same name.
+func withBuildpackSidecars(sidecars []korifiv1alpha1.Sidecar, appGUID, processType string, dropletSidecars []korifiv1alpha1.DropletSidecar) []korifiv1alpha1.Sidecar {
+	result := slices.DeleteFunc(slices.Clone(sidecars), func(s korifiv1alpha1.Sidecar) bool {
+		return s.Origin == korifiv1alpha1.SidecarOriginBuildpack
 	})
+
+	for _, dropletSidecar := range dropletSidecars {
+		if !slices.Contains(dropletSidecar.ProcessTypes, processType) {
+			continue
+		}
+
+		if slices.ContainsFunc(result, func(s korifiv1alpha1.Sidecar) bool { return s.Name == dropletSidecar.Name }) {
+			continue
+		}
+
+		result = append(result, korifiv1alpha1.Sidecar{
+			GUID:     tools.NamespacedUUID(appGUID, "sidecar", dropletSidecar.Name),
+			Name:     dropletSidecar.Name,
+			Command:  dropletSidecar.Command,
+			MemoryMB: dropletSidecar.MemoryMB,
+			Origin:   korifiv1alpha1.SidecarOriginBuildpack,
+		})
+	}
+
+	return result
 }
 
-func (r *Reconciler) createCFProcess(ctx context.Context, process korifiv1alpha1.ProcessType, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.CFProcess, error) {
+func (r *Reconciler) createCFProcess(ctx context.Context, process korifiv1alpha1.ProcessType, dropletSidecars []korifiv1alpha1.DropletSidecar, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.CFProcess, error) {
 	desiredCFProcess := &korifiv1alpha1.CFProcess{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfApp.Namespace,
@@ -308,6 +371,7 @@ func (r *Reconciler) createCFProcess(ctx context.Context, process korifiv1alpha1
 			AppRef:          corev1.LocalObjectReference{Name: cfApp.Name},
 			ProcessType:     process.Type,
 			DetectedCommand: process.Command,
+			Sidecars:        withBuildpackSidecars(nil, cfApp.Name, process.Type, dropletSidecars),
 		},
 	}
 
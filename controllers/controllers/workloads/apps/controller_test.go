@@ -291,6 +291,52 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 
+		When("the droplet declares sidecars", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, defaultWebProcess, func() {
+					defaultWebProcess.Spec.Sidecars = []korifiv1alpha1.Sidecar{
+						{GUID: "user-sidecar-guid", Name: "reloader", Command: "my-reloader", Origin: korifiv1alpha1.SidecarOriginUser},
+						{GUID: "stale-sidecar-guid", Name: "stale", Command: "stale", Origin: korifiv1alpha1.SidecarOriginBuildpack},
+					}
+				})).To(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfBuild, func() {
+					cfBuild.Status.Droplet.Sidecars = []korifiv1alpha1.DropletSidecar{
+						{Name: "apm-agent", Command: "run-agent", ProcessTypes: []string{"web"}, MemoryMB: 64},
+						{Name: "reloader", Command: "reload-config", ProcessTypes: []string{"web", "worker"}},
+					}
+				})).To(Succeed())
+			})
+
+			It("replaces the buildpack sidecars of the processes with the ones of the droplet", func() {
+				Eventually(func(g Gomega) {
+					webProcess := &korifiv1alpha1.CFProcess{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: tools.NamespacedUUID(cfApp.Name, "web")}, webProcess)).To(Succeed())
+					g.Expect(webProcess.Spec.Sidecars).To(ConsistOf(
+						korifiv1alpha1.Sidecar{GUID: "user-sidecar-guid", Name: "reloader", Command: "my-reloader", Origin: korifiv1alpha1.SidecarOriginUser},
+						korifiv1alpha1.Sidecar{
+							GUID:     tools.NamespacedUUID(cfApp.Name, "sidecar", "apm-agent"),
+							Name:     "apm-agent",
+							Command:  "run-agent",
+							MemoryMB: 64,
+							Origin:   korifiv1alpha1.SidecarOriginBuildpack,
+						},
+					))
+
+					workerProcess := &korifiv1alpha1.CFProcess{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: tools.NamespacedUUID(cfApp.Name, "worker")}, workerProcess)).To(Succeed())
+					g.Expect(workerProcess.Spec.Sidecars).To(ConsistOf(
+						korifiv1alpha1.Sidecar{
+							GUID:    tools.NamespacedUUID(cfApp.Name, "sidecar", "reloader"),
+							Name:    "reloader",
+							Command: "reload-config",
+							Origin:  korifiv1alpha1.SidecarOriginBuildpack,
+						},
+					))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the process is not ready", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, defaultWebProcess, func() {
@@ -339,6 +385,222 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		})
 	})
 
+	Describe("revisions", func() {
+		var envSecret *corev1.Secret
+
+		getRevisions := func(g Gomega) []korifiv1alpha1.CFAppRevision {
+			revisions := &korifiv1alpha1.CFAppRevisionList{}
+			g.Expect(adminClient.List(ctx, revisions,
+				client.InNamespace(cfApp.Namespace),
+				client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+			)).To(Succeed())
+			return revisions.Items
+		}
+
+		BeforeEach(func() {
+			envSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfApp.Namespace,
+					Name:      uuid.NewString(),
+				},
+				Data: map[string][]byte{"FOO": []byte("bar")},
+			}
+			Expect(adminClient.Create(ctx, envSecret)).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.EnvSecretName = envSecret.Name
+			})).To(Succeed())
+		})
+
+		It("does not create a revision for a stopped app", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(getRevisions(g)).To(BeEmpty())
+			}, "1s").Should(Succeed())
+		})
+
+		When("the app is started", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, defaultWebProcess, func() {
+					defaultWebProcess.Spec.Command = "custom command"
+					defaultWebProcess.Spec.Sidecars = []korifiv1alpha1.Sidecar{{
+						GUID:     "sidecar-guid",
+						Name:     "apm-agent",
+						Command:  "run-agent",
+						MemoryMB: 64,
+						Origin:   korifiv1alpha1.SidecarOriginUser,
+					}}
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				})).To(Succeed())
+			})
+
+			It("creates a revision capturing the app state", func() {
+				Eventually(func(g Gomega) {
+					revisions := getRevisions(g)
+					g.Expect(revisions).To(HaveLen(1))
+
+					revision := revisions[0]
+					g.Expect(revision.Spec).To(MatchFields(IgnoreExtras, Fields{
+						"AppRef":      Equal(corev1.LocalObjectReference{Name: cfApp.Name}),
+						"Version":     BeEquivalentTo(42),
+						"DropletRef":  Equal(corev1.LocalObjectReference{Name: cfBuild.Name}),
+						"Description": Equal("Initial revision."),
+						"Processes": ConsistOf(korifiv1alpha1.RevisionProcess{
+							Type:    "web",
+							Command: "custom command",
+						}),
+						"Sidecars": ConsistOf(korifiv1alpha1.RevisionSidecar{
+							GUID:         "sidecar-guid",
+							Name:         "apm-agent",
+							Command:      "run-agent",
+							ProcessTypes: []string{"web"},
+							MemoryMB:     64,
+							Origin:       korifiv1alpha1.SidecarOriginUser,
+						}),
+					}))
+					g.Expect(revision.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Name": Equal(cfApp.Name),
+					})))
+
+					envSnapshot := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: revision.Spec.EnvSecretName}, envSnapshot)).To(Succeed())
+					g.Expect(envSnapshot.Data).To(Equal(envSecret.Data))
+				}).Should(Succeed())
+			})
+
+			When("the app gets a new app-rev", func() {
+				var revisionDescription string
+
+				BeforeEach(func() {
+					revisionDescription = ""
+				})
+
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(getRevisions(g)).To(HaveLen(1))
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, envSecret, func() {
+						envSecret.Data = map[string][]byte{"FOO": []byte("baz")}
+					})).To(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "43"
+						if revisionDescription != "" {
+							cfApp.Annotations[korifiv1alpha1.CFAppRevisionDescriptionKey] = revisionDescription
+						}
+					})).To(Succeed())
+				})
+
+				It("creates a new revision describing the changes", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(getRevisions(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version":     BeEquivalentTo(43),
+								"Description": Equal("New environment variables deployed."),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+
+				When("the app has a revision description annotation", func() {
+					BeforeEach(func() {
+						revisionDescription = "Rolled back to revision 1."
+					})
+
+					It("uses it as revision description and removes it", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(getRevisions(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+								"Spec": MatchFields(IgnoreExtras, Fields{
+									"Version":     BeEquivalentTo(43),
+									"Description": Equal("Rolled back to revision 1."),
+								}),
+							})))
+
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+							g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRevisionDescriptionKey))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("the app is rolled back to a revision", func() {
+				var revision korifiv1alpha1.CFAppRevision
+
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						revisions := getRevisions(g)
+						g.Expect(revisions).To(HaveLen(1))
+						revision = revisions[0]
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, envSecret, func() {
+						envSecret.Data = map[string][]byte{"FOO": []byte("baz")}
+					})).To(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, defaultWebProcess, func() {
+						defaultWebProcess.Spec.Command = "new command"
+						defaultWebProcess.Spec.Sidecars = []korifiv1alpha1.Sidecar{{
+							GUID:    "new-sidecar-guid",
+							Name:    "new-sidecar",
+							Command: "run-new",
+							Origin:  korifiv1alpha1.SidecarOriginUser,
+						}}
+					})).To(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "43"
+						cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] = revision.Name
+					})).To(Succeed())
+				})
+
+				It("restores the revision environment variables", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+						g.Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("bar")}))
+					}).Should(Succeed())
+				})
+
+				It("restores the revision process command and user sidecars", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(defaultWebProcess), defaultWebProcess)).To(Succeed())
+						g.Expect(defaultWebProcess.Spec.Command).To(Equal("custom command"))
+						g.Expect(defaultWebProcess.Spec.Sidecars).To(ConsistOf(korifiv1alpha1.Sidecar{
+							GUID:     "sidecar-guid",
+							Name:     "apm-agent",
+							Command:  "run-agent",
+							MemoryMB: 64,
+							Origin:   korifiv1alpha1.SidecarOriginUser,
+						}))
+					}).Should(Succeed())
+				})
+
+				It("removes the rollback annotation", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRollbackRevisionKey))
+					}).Should(Succeed())
+				})
+
+				It("captures the restored state in the new revision", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(getRevisions(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version": BeEquivalentTo(43),
+								"Processes": ConsistOf(korifiv1alpha1.RevisionProcess{
+									Type:    "web",
+									Command: "custom command",
+								}),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+
 	When("the cfapp droplet ref is not set", func() {
 		BeforeEach(func() {
 			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
@@ -486,6 +748,57 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 				}).Should(Succeed())
 			})
 
+			When("the binding credentials have been rotated", func() {
+				var appMode string
+
+				BeforeEach(func() {
+					appMode = korifiv1alpha1.CredentialsRotationAppModeRestart
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+					})).To(Succeed())
+				})
+
+				JustBeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
+						binding.Spec.CredentialsRotation = &korifiv1alpha1.CredentialsRotation{
+							ID:      "rotation-1",
+							AppMode: appMode,
+						}
+					})).To(Succeed())
+					Expect(k8s.Patch(ctx, adminClient, binding, func() {
+						binding.Status.CredentialsRotationID = "rotation-1"
+					})).To(Succeed())
+				})
+
+				It("restarts the app once", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "43"))
+						g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppCredentialsRotationKeyPrefix+binding.Name, "rotation-1"))
+					}).Should(Succeed())
+
+					Consistently(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "43"))
+					}, "1s").Should(Succeed())
+				})
+
+				When("the rotation app mode is none", func() {
+					BeforeEach(func() {
+						appMode = korifiv1alpha1.CredentialsRotationAppModeNone
+					})
+
+					It("does not restart the app", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+							g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppCredentialsRotationKeyPrefix+binding.Name, "rotation-1"))
+							g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "42"))
+						}).Should(Succeed())
+					})
+				})
+			})
+
 			When("the binding has a display name", func() {
 				BeforeEach(func() {
 					Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
@@ -0,0 +1,66 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+)
+
+// restartForCredentialsRotation bumps the app-rev of a started app once one of
+// its service bindings has completed a credentials rotation that requires the
+// app to be restarted. The processes controller then rolls the app instances
+// so that they pick up the rotated credentials. The app keeps track of the
+// rotations it has already handled in annotations, so that it is only
+// restarted once per rotation
+func restartForCredentialsRotation(ctx context.Context, cfApp *korifiv1alpha1.CFApp, bindings []korifiv1alpha1.CFServiceBinding) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("restartForCredentialsRotation")
+
+	handledRotationKeys := map[string]bool{}
+	restartRequired := false
+
+	for _, binding := range bindings {
+		rotation := binding.Spec.CredentialsRotation
+		if rotation == nil || rotation.ID != binding.Status.CredentialsRotationID {
+			continue
+		}
+
+		rotationKey := korifiv1alpha1.CFAppCredentialsRotationKeyPrefix + binding.Name
+		handledRotationKeys[rotationKey] = true
+
+		if cfApp.Annotations[rotationKey] == rotation.ID {
+			continue
+		}
+
+		cfApp.Annotations = tools.SetMapValue(cfApp.Annotations, rotationKey, rotation.ID)
+		if rotation.AppMode == korifiv1alpha1.CredentialsRotationAppModeRestart {
+			log.V(1).Info("restarting app for rotated credentials", "binding", binding.Name, "rotation", rotation.ID)
+			restartRequired = true
+		}
+	}
+
+	for key := range cfApp.Annotations {
+		if strings.HasPrefix(key, korifiv1alpha1.CFAppCredentialsRotationKeyPrefix) && !handledRotationKeys[key] {
+			delete(cfApp.Annotations, key)
+		}
+	}
+
+	if !restartRequired || cfApp.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return nil
+	}
+
+	appRev, err := strconv.Atoi(cfApp.LatestRevision())
+	if err != nil {
+		return fmt.Errorf("expected app-rev to be an integer: %w", err)
+	}
+
+	cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = strconv.Itoa(appRev + 1)
+	cfApp.Annotations[korifiv1alpha1.CFAppRevisionDescriptionKey] = "Service binding credentials rotated."
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package apps
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapprevisions,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create
+
+// reconcileRevision makes sure that there is a CFAppRevision for the current
+// app-rev of a started app, capturing its droplet, environment variables,
+// process commands and sidecars
+func (r *Reconciler) reconcileRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp, processes []*korifiv1alpha1.CFProcess) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileRevision")
+
+	version, err := strconv.ParseInt(cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey], 10, 64)
+	if err != nil {
+		return fmt.Errorf("expected app-rev to be an integer: %w", err)
+	}
+
+	revision := &korifiv1alpha1.CFAppRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      revisionGUID(cfApp.Name, version),
+		},
+	}
+
+	err = r.k8sClient.Get(ctx, client.ObjectKeyFromObject(revision), revision)
+	if err == nil {
+		delete(cfApp.Annotations, korifiv1alpha1.CFAppRevisionDescriptionKey)
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	envData, err := r.getEnvData(ctx, cfApp.Namespace, cfApp.Spec.EnvSecretName)
+	if err != nil {
+		return err
+	}
+
+	envSecretName, err := r.snapshotEnv(ctx, cfApp, revision.Name, envData)
+	if err != nil {
+		return err
+	}
+
+	revision.Labels = map[string]string{
+		korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+	}
+	revision.Spec = korifiv1alpha1.CFAppRevisionSpec{
+		AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+		Version:       version,
+		DropletRef:    cfApp.Spec.CurrentDropletRef,
+		EnvSecretName: envSecretName,
+		Processes:     toRevisionProcesses(processes),
+		Sidecars:      toRevisionSidecars(processes),
+	}
+
+	revision.Spec.Description = cfApp.Annotations[korifiv1alpha1.CFAppRevisionDescriptionKey]
+	if revision.Spec.Description == "" {
+		revision.Spec.Description, err = r.describeRevision(ctx, cfApp, revision, envData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = controllerutil.SetControllerReference(cfApp, revision, r.scheme); err != nil {
+		return fmt.Errorf("failed to set OwnerRef on CFAppRevision: %w", err)
+	}
+
+	if err = r.k8sClient.Create(ctx, revision); client.IgnoreAlreadyExists(err) != nil {
+		log.Info("failed to create revision", "reason", err)
+		return err
+	}
+	log.V(1).Info("revision created", "name", revision.Name, "version", version)
+
+	delete(cfApp.Annotations, korifiv1alpha1.CFAppRevisionDescriptionKey)
+	return nil
+}
+
+// revisionGUID returns the guid of the revision of an app for a given app-rev
+func revisionGUID(appGUID string, version int64) string {
+	return tools.NamespacedUUID(appGUID, "revision", strconv.FormatInt(version, 10))
+}
+
+func (r *Reconciler) getEnvData(ctx context.Context, namespace, secretName string) (map[string][]byte, error) {
+	if secretName == "" {
+		return nil, nil
+	}
+
+	envSecret := &corev1.Secret{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, envSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get env secret %q: %w", secretName, err)
+	}
+
+	return envSecret.Data, nil
+}
+
+func (r *Reconciler) snapshotEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp, revisionGUID string, envData map[string][]byte) (string, error) {
+	if cfApp.Spec.EnvSecretName == "" {
+		return "", nil
+	}
+
+	snapshot := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      revisionGUID + "-env",
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, snapshot, func() error {
+		snapshot.Data = envData
+
+		return controllerutil.SetControllerReference(cfApp, snapshot, r.scheme)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot env secret: %w", err)
+	}
+
+	return snapshot.Name, nil
+}
+
+func (r *Reconciler) describeRevision(
+	ctx context.Context,
+	cfApp *korifiv1alpha1.CFApp,
+	revision *korifiv1alpha1.CFAppRevision,
+	envData map[string][]byte,
+) (string, error) {
+	previous, err := r.getLatestRevision(ctx, cfApp)
+	if err != nil {
+		return "", err
+	}
+
+	if previous == nil {
+		return "Initial revision.", nil
+	}
+
+	var changes []string
+	if previous.Spec.DropletRef.Name != revision.Spec.DropletRef.Name {
+		changes = append(changes, "New droplet deployed.")
+	}
+
+	previousEnvData, err := r.getEnvData(ctx, previous.Namespace, previous.Spec.EnvSecretName)
+	if err != nil {
+		return "", err
+	}
+	if !maps.EqualFunc(previousEnvData, envData, bytes.Equal) {
+		changes = append(changes, "New environment variables deployed.")
+	}
+
+	previousCommands := processCommands(previous.Spec.Processes)
+	for _, process := range revision.Spec.Processes {
+		previousCommand := previousCommands[process.Type]
+		switch {
+		case previousCommand == process.Command:
+		case previousCommand == "":
+			changes = append(changes, fmt.Sprintf("Custom start command added for '%s' process.", process.Type))
+		case process.Command == "":
+			changes = append(changes, fmt.Sprintf("Custom start command removed for '%s' process.", process.Type))
+		default:
+			changes = append(changes, fmt.Sprintf("Custom start command updated for '%s' process.", process.Type))
+		}
+	}
+
+	if len(changes) == 0 {
+		return "Restarted.", nil
+	}
+
+	return strings.Join(changes, " "), nil
+}
+
+func (r *Reconciler) getLatestRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.CFAppRevision, error) {
+	revisions := &korifiv1alpha1.CFAppRevisionList{}
+	if err := r.k8sClient.List(ctx, revisions,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list app revisions: %w", err)
+	}
+
+	if len(revisions.Items) == 0 {
+		return nil, nil
+	}
+
+	latest := slices.MaxFunc(revisions.Items, func(r1, r2 korifiv1alpha1.CFAppRevision) int {
+		return cmp.Compare(r1.Spec.Version, r2.Spec.Version)
+	})
+
+	return &latest, nil
+}
+
+func toRevisionProcesses(processes []*korifiv1alpha1.CFProcess) []korifiv1alpha1.RevisionProcess {
+	revisionProcesses := []korifiv1alpha1.RevisionProcess{}
+	for _, process := range processes {
+		revisionProcesses = append(revisionProcesses, korifiv1alpha1.RevisionProcess{
+			Type:    process.Spec.ProcessType,
+			Command: process.Spec.Command,
+		})
+	}
+
+	slices.SortFunc(revisionProcesses, func(p1, p2 korifiv1alpha1.RevisionProcess) int {
+		return strings.Compare(p1.Type, p2.Type)
+	})
+
+	return revisionProcesses
+}
+
+func toRevisionSidecars(processes []*korifiv1alpha1.CFProcess) []korifiv1alpha1.RevisionSidecar {
+	sidecars := map[string]*korifiv1alpha1.RevisionSidecar{}
+	for _, process := range processes {
+		for _, sidecar := range process.Spec.Sidecars {
+			if _, ok := sidecars[sidecar.Name]; !ok {
+				sidecars[sidecar.Name] = &korifiv1alpha1.RevisionSidecar{
+					GUID:     sidecar.GUID,
+					Name:     sidecar.Name,
+					Command:  sidecar.Command,
+					MemoryMB: sidecar.MemoryMB,
+					Origin:   sidecar.Origin,
+				}
+			}
+			sidecars[sidecar.Name].ProcessTypes = append(sidecars[sidecar.Name].ProcessTypes, process.Spec.ProcessType)
+		}
+	}
+
+	revisionSidecars := []korifiv1alpha1.RevisionSidecar{}
+	for _, sidecar := range sidecars {
+		slices.Sort(sidecar.ProcessTypes)
+		revisionSidecars = append(revisionSidecars, *sidecar)
+	}
+
+	slices.SortFunc(revisionSidecars, func(s1, s2 korifiv1alpha1.RevisionSidecar) int {
+		return strings.Compare(s1.Name, s2.Name)
+	})
+
+	return revisionSidecars
+}
+
+func processCommands(processes []korifiv1alpha1.RevisionProcess) map[string]string {
+	commands := map[string]string{}
+	for _, process := range processes {
+		commands[process.Type] = process.Command
+	}
+
+	return commands
+}
@@ -0,0 +1,144 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restoreRollbackRevision puts back the environment variables, process
+// commands and user sidecars captured in the revision a rollback deploys, so
+// that the new app-rev runs the app as it was when the revision was created.
+// Buildpack sidecars follow the droplet of the revision. The rollback
+// annotation is only removed once everything has been restored, so that a
+// failed restore is retried
+func (r *Reconciler) restoreRollbackRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
+	revisionGUID := cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey]
+	if revisionGUID == "" {
+		return nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx).WithName("restoreRollbackRevision").WithValues("revision", revisionGUID)
+
+	revision := &korifiv1alpha1.CFAppRevision{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: revisionGUID}, revision)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("rollback revision not found, skipping restore")
+			delete(cfApp.Annotations, korifiv1alpha1.CFAppRollbackRevisionKey)
+			return nil
+		}
+		return fmt.Errorf("failed to get rollback revision %q: %w", revisionGUID, err)
+	}
+
+	if err = r.restoreEnv(ctx, cfApp, revision); err != nil {
+		return err
+	}
+
+	if err = r.restoreProcesses(ctx, cfApp, revision); err != nil {
+		return err
+	}
+
+	log.V(1).Info("rollback revision restored")
+	delete(cfApp.Annotations, korifiv1alpha1.CFAppRollbackRevisionKey)
+	return nil
+}
+
+func (r *Reconciler) restoreEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp, revision *korifiv1alpha1.CFAppRevision) error {
+	if revision.Spec.EnvSecretName == "" || cfApp.Spec.EnvSecretName == "" {
+		return nil
+	}
+
+	snapshot := &corev1.Secret{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: revision.Namespace, Name: revision.Spec.EnvSecretName}, snapshot)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logr.FromContextOrDiscard(ctx).Info("revision env secret not found, skipping env restore", "name", revision.Spec.EnvSecretName)
+			return nil
+		}
+		return fmt.Errorf("failed to get revision env secret %q: %w", revision.Spec.EnvSecretName, err)
+	}
+
+	envSecret := &corev1.Secret{}
+	err = r.k8sClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: cfApp.Spec.EnvSecretName}, envSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get env secret %q: %w", cfApp.Spec.EnvSecretName, err)
+	}
+
+	err = k8s.PatchResource(ctx, r.k8sClient, envSecret, func() {
+		envSecret.Data = snapshot.Data
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore env secret %q: %w", cfApp.Spec.EnvSecretName, err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) restoreProcesses(ctx context.Context, cfApp *korifiv1alpha1.CFApp, revision *korifiv1alpha1.CFAppRevision) error {
+	commands := processCommands(revision.Spec.Processes)
+
+	processList := &korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, processList,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	)
+	if err != nil {
+		return fmt.Errorf("error listing app CFProcesses: %w", err)
+	}
+
+	for i := range processList.Items {
+		process := &processList.Items[i]
+		command, ok := commands[process.Spec.ProcessType]
+		if !ok {
+			command = process.Spec.Command
+		}
+		sidecars := withRevisionUserSidecars(process.Spec.Sidecars, process.Spec.ProcessType, revision.Spec.Sidecars)
+
+		if command == process.Spec.Command && slices.Equal(sidecars, process.Spec.Sidecars) {
+			continue
+		}
+
+		err = k8s.PatchResource(ctx, r.k8sClient, process, func() {
+			process.Spec.Command = command
+			process.Spec.Sidecars = sidecars
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore CFProcess %q: %w", process.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// withRevisionUserSidecars replaces the user sidecars of a process with the
+// ones the revision captured for its type
+func withRevisionUserSidecars(sidecars []korifiv1alpha1.Sidecar, processType string, revisionSidecars []korifiv1alpha1.RevisionSidecar) []korifiv1alpha1.Sidecar {
+	result := slices.DeleteFunc(slices.Clone(sidecars), func(s korifiv1alpha1.Sidecar) bool {
+		return s.Origin == korifiv1alpha1.SidecarOriginUser
+	})
+
+	for _, revisionSidecar := range revisionSidecars {
+		if revisionSidecar.Origin != korifiv1alpha1.SidecarOriginUser || !slices.Contains(revisionSidecar.ProcessTypes, processType) {
+			continue
+		}
+
+		result = append(result, korifiv1alpha1.Sidecar{
+			GUID:     revisionSidecar.GUID,
+			Name:     revisionSidecar.Name,
+			Command:  revisionSidecar.Command,
+			MemoryMB: revisionSidecar.MemoryMB,
+			Origin:   korifiv1alpha1.SidecarOriginUser,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package droplets
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+//counterfeiter:generate -o fake -fake-name ImageDeleter . ImageDeleter
+
+type ImageDeleter interface {
+	Delete(ctx context.Context, creds image.Creds, imageRef string, tagsToDelete ...string) error
+}
+
+// Reconciler deletes the image of CFDroplets from the app droplet repository
+// when they are deleted. CFDroplets are owned by their CFApp, so this also
+// happens when the app is deleted.
+type Reconciler struct {
+	k8sClient           client.Client
+	imageDeleter        ImageDeleter
+	registrySecretNames []string
+	log                 logr.Logger
+}
+
+func NewReconciler(
+	k8sClient client.Client,
+	log logr.Logger,
+	imageDeleter ImageDeleter,
+	registrySecretNames []string,
+) *Reconciler {
+	return &Reconciler{
+		k8sClient:           k8sClient,
+		imageDeleter:        imageDeleter,
+		registrySecretNames: registrySecretNames,
+		log:                 log,
+	}
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFDroplet{}).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdroplets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdroplets/finalizers,verbs=get;update;patch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := r.log.WithName("CFDroplet").
+		WithValues("namespace", req.Namespace, "name", req.Name, "logID", uuid.NewString())
+
+	cfDroplet := &korifiv1alpha1.CFDroplet{}
+	err := r.k8sClient.Get(ctx, req.NamespacedName, cfDroplet)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Info("unable to fetch CFDroplet", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if cfDroplet.GetDeletionTimestamp().IsZero() || !controllerutil.ContainsFinalizer(cfDroplet, korifiv1alpha1.CFDropletFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if cfDroplet.Spec.Registry != nil && cfDroplet.Spec.Registry.Image != "" {
+		if err = r.imageDeleter.Delete(ctx, image.Creds{
+			Namespace:   cfDroplet.Namespace,
+			SecretNames: r.registrySecretNames,
+		}, cfDroplet.Spec.Registry.Image, cfDroplet.Name); err != nil {
+			log.Info("failed to delete image", "reason", err)
+		}
+	}
+
+	err = k8s.PatchResource(ctx, r.k8sClient, cfDroplet, func() {
+		if controllerutil.RemoveFinalizer(cfDroplet, korifiv1alpha1.CFDropletFinalizerName) {
+			log.V(1).Info("finalizer removed")
+		}
+	})
+	if err != nil {
+		log.Info("unable to remove finalizer from CFDroplet", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,90 @@
+package droplets_test
+
+import (
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFDropletReconciler Integration Tests", func() {
+	var (
+		cfDroplet   *korifiv1alpha1.CFDroplet
+		deleteCount int
+	)
+
+	BeforeEach(func() {
+		deleteCount = imageDeleter.DeleteCallCount()
+
+		cfDroplet = &korifiv1alpha1.CFDroplet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+				Finalizers: []string{
+					korifiv1alpha1.CFDropletFinalizerName,
+				},
+			},
+			Spec: korifiv1alpha1.CFDropletSpec{
+				AppRef: corev1.LocalObjectReference{Name: "the-app"},
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+				Registry: &korifiv1alpha1.Registry{
+					Image: "the-app-droplets@sha256:abc",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfDroplet)).To(Succeed())
+		Expect(adminClient.Delete(ctx, cfDroplet)).To(Succeed())
+	})
+
+	It("deletes itself and the droplet image", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(imageDeleter.DeleteCallCount()).To(BeNumerically(">", deleteCount))
+
+			_, creds, ref, tagsToDelete := imageDeleter.DeleteArgsForCall(deleteCount)
+			g.Expect(creds.Namespace).To(Equal(testNamespace))
+			g.Expect(creds.SecretNames).To(ConsistOf("registry-secret-name"))
+			g.Expect(ref).To(Equal("the-app-droplets@sha256:abc"))
+			g.Expect(tagsToDelete).To(ConsistOf(cfDroplet.Name))
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfDroplet), cfDroplet)).To(MatchError(ContainSubstring("not found")))
+		}).Should(Succeed())
+	})
+
+	When("the droplet bits have not been uploaded", func() {
+		BeforeEach(func() {
+			cfDroplet.Spec.Registry = nil
+		})
+
+		It("deletes itself without deleting any image", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfDroplet), cfDroplet)).To(MatchError(ContainSubstring("not found")))
+			}).Should(Succeed())
+			Expect(imageDeleter.DeleteCallCount()).To(Equal(deleteCount))
+		})
+	})
+
+	When("deleting the image fails", func() {
+		BeforeEach(func() {
+			imageDeleter.DeleteReturns(errors.New("oops"))
+		})
+
+		It("ignores the error and finishes finalization", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfDroplet), cfDroplet)).To(MatchError(ContainSubstring("not found")))
+			}).Should(Succeed())
+		})
+	})
+})
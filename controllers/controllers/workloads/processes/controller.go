@@ -26,6 +26,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/ports"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -34,6 +35,7 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -58,6 +60,7 @@ type Reconciler struct {
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
 	envBuilder       ProcessEnvBuilder
+	usageRecorder    *usage.Recorder
 }
 
 func NewReconciler(
@@ -67,7 +70,14 @@ func NewReconciler(
 	controllerConfig *config.ControllerConfig,
 	envBuilder ProcessEnvBuilder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFProcess] {
-	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder}
+	processReconciler := Reconciler{
+		k8sClient:        client,
+		scheme:           scheme,
+		log:              log,
+		controllerConfig: controllerConfig,
+		envBuilder:       envBuilder,
+		usageRecorder:    usage.NewRecorder(client, controllerConfig.CFRootNamespace),
+	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFProcess](log, client, &processReconciler)
 }
 
@@ -132,7 +142,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 	log := logr.FromContextOrDiscard(ctx)
 
 	if !cfProcess.GetDeletionTimestamp().IsZero() {
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.finalizeCFProcess(ctx, cfProcess)
 	}
 
 	cfProcess.Status.ObservedGeneration = cfProcess.Generation
@@ -150,6 +160,12 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, err
 	}
 
+	err = r.usageRecorder.RecordProcessUsage(ctx, cfApp, cfProcess)
+	if err != nil {
+		log.Info("failed to record app usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	if needsAppWorkload(cfApp, cfProcess) {
 		err = r.createOrPatchAppWorkload(ctx, cfApp, cfProcess)
 		if err != nil {
@@ -177,6 +193,34 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 	return ctrl.Result{}, nil
 }
 
+func (r *Reconciler) finalizeCFProcess(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFProcess")
+
+	if !controllerutil.ContainsFinalizer(cfProcess, korifiv1alpha1.CFProcessFinalizerName) {
+		return nil
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfProcess.Spec.AppRef.Name, Namespace: cfProcess.Namespace}, cfApp)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		cfApp = nil
+	}
+
+	err = r.usageRecorder.RecordProcessUsage(ctx, cfApp, cfProcess)
+	if err != nil {
+		return err
+	}
+
+	if controllerutil.RemoveFinalizer(cfProcess, korifiv1alpha1.CFProcessFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+
+	return nil
+}
+
 func allReady(appWorkloads []korifiv1alpha1.AppWorkload) bool {
 	return it.All(it.Map(slices.Values(appWorkloads), func(w korifiv1alpha1.AppWorkload) bool {
 		return conditions.CheckConditionIsTrue(&w, korifiv1alpha1.StatusConditionReady) == nil
@@ -218,6 +262,34 @@ func needsAppWorkload(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFP
 	return cfProcess.Spec.DesiredInstances != nil && *cfProcess.Spec.DesiredInstances > 0
 }
 
+type desiredAppWorkload struct {
+	name             string
+	lastStopRevision string
+	instances        int32
+}
+
+// getDesiredAppWorkload returns the app workload the process should be running
+// at the current app revision. During a canary deployment the stable app
+// workload is left untouched and a single canary instance is started beside
+// it instead.
+func getDesiredAppWorkload(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) desiredAppWorkload {
+	if cfApp.Spec.Canary == nil {
+		return desiredAppWorkload{
+			name:             getDesiredAppWorkloadName(cfApp, cfProcess),
+			lastStopRevision: getLastStopRevision(cfApp),
+			instances:        tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+		}
+	}
+
+	// The runner derives the statefulset name from the last stop revision,
+	// make sure the canary gets a statefulset of its own
+	return desiredAppWorkload{
+		name:             getCanaryAppWorkloadName(cfApp, cfProcess),
+		lastStopRevision: getLastStopRevision(cfApp) + "-canary",
+		instances:        1,
+	}
+}
+
 func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchAppWorkload")
 
@@ -225,16 +297,19 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		return k8s.NewNotReadyError().WithReason("OutdatedCFAppStatus").WithRequeue()
 	}
 
-	cfBuild := new(korifiv1alpha1.CFBuild)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfApp.Spec.CurrentDropletRef.Name, Namespace: cfProcess.Namespace}, cfBuild)
+	if cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] != "" {
+		return k8s.NewNotReadyError().WithReason("RollbackRevisionNotRestored").WithRequeue()
+	}
+
+	droplet, err := shared.GetDroplet(ctx, r.k8sClient, cfProcess.Namespace, cfApp.Spec.CurrentDropletRef.Name)
 	if err != nil {
-		log.Info("error when trying to fetch CFBuild", "namespace", cfProcess.Namespace, "name", cfApp.Spec.CurrentDropletRef.Name, "reason", err)
+		log.Info("error when trying to fetch droplet", "namespace", cfProcess.Namespace, "name", cfApp.Spec.CurrentDropletRef.Name, "reason", err)
 		return err
 	}
 
-	if cfBuild.Status.Droplet == nil {
-		log.Info("no build droplet status on CFBuild", "namespace", cfProcess.Namespace, "name", cfApp.Spec.CurrentDropletRef.Name, "reason", err)
-		return errors.New("no build droplet status on CFBuild")
+	if droplet == nil {
+		log.Info("no image on current droplet", "namespace", cfProcess.Namespace, "name", cfApp.Spec.CurrentDropletRef.Name)
+		return errors.New("no image on current droplet")
 	}
 
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
@@ -248,15 +323,24 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 	appPorts := ports.FromRoutes(cfRoutesForProcess.Items, cfApp.Name, cfProcess.Spec.ProcessType)
 
+	processMemoryMB, sidecarsMemoryMB, ok := splitProcessMemory(cfProcess)
+	if !ok {
+		return k8s.NewNotReadyError().
+			WithReason("InsufficientMemory").
+			WithMessage("The process memory does not leave enough memory for the process and its sidecars").
+			WithNoRequeue()
+	}
+
 	envVars, err := r.envBuilder.Build(ctx, cfApp, cfProcess)
 	if err != nil {
 		log.Info("error when trying build the process environment for app", "namespace", cfProcess.Namespace, "name", cfApp.Spec.DisplayName, "reason", err)
 		return err
 	}
 
+	desiredWorkload := getDesiredAppWorkload(cfApp, cfProcess)
 	appWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getDesiredAppWorkloadName(cfApp, cfProcess),
+			Name:      desiredWorkload.name,
 			Namespace: cfProcess.Namespace,
 		},
 	}
@@ -269,27 +353,29 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		appWorkload.Labels[korifiv1alpha1.CFProcessTypeLabelKey] = cfProcess.Spec.ProcessType
 
 		appWorkload.Annotations = make(map[string]string)
-		appWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = getLastStopRevision(cfApp)
+		appWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = desiredWorkload.lastStopRevision
 
 		appWorkload.Spec.GUID = cfProcess.Name
 		appWorkload.Spec.Version = getRevision(cfApp)
 		appWorkload.Spec.Resources.Requests = corev1.ResourceList{
 			corev1.ResourceCPU:              calculateCPURequest(cfProcess.Spec.MemoryMB),
 			corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
-			corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
+			corev1.ResourceMemory:           mebibyteQuantity(processMemoryMB),
 		}
 		appWorkload.Spec.Resources.Limits = corev1.ResourceList{
 			corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
-			corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
+			corev1.ResourceMemory:           mebibyteQuantity(processMemoryMB),
 		}
 		appWorkload.Spec.ProcessType = cfProcess.Spec.ProcessType
 		appWorkload.Spec.Command = commandForProcess(cfProcess, cfApp)
+		appWorkload.Spec.Sidecars = sidecarsForProcess(cfProcess, cfApp, sidecarsMemoryMB)
 		appWorkload.Spec.AppGUID = cfApp.Name
-		appWorkload.Spec.Image = cfBuild.Status.Droplet.Registry.Image
-		appWorkload.Spec.ImagePullSecrets = cfBuild.Status.Droplet.Registry.ImagePullSecrets
+		appWorkload.Spec.Image = droplet.Registry.Image
+		appWorkload.Spec.ImagePullSecrets = droplet.Registry.ImagePullSecrets
 
 		appWorkload.Spec.Ports = appPorts
-		appWorkload.Spec.Instances = tools.ZeroIfNil(cfProcess.Spec.DesiredInstances)
+		appWorkload.Spec.Instances = desiredWorkload.instances
+		appWorkload.Spec.MaxInFlight = cfApp.Spec.MaxInFlight
 
 		appWorkload.Spec.Env = envVars
 
@@ -338,7 +424,11 @@ func needsToDeleteAppWorkload(
 ) bool {
 	return cfApp.Spec.DesiredState == korifiv1alpha1.StoppedState ||
 		(cfProcess.Spec.DesiredInstances != nil && *cfProcess.Spec.DesiredInstances == 0) ||
-		appWorkload.Name != getDesiredAppWorkloadName(cfApp, cfProcess)
+		(appWorkload.Name != getDesiredAppWorkloadName(cfApp, cfProcess) && !isCanaryAppWorkload(cfApp, cfProcess, appWorkload))
+}
+
+func isCanaryAppWorkload(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, appWorkload korifiv1alpha1.AppWorkload) bool {
+	return cfApp.Spec.Canary != nil && appWorkload.Name == getCanaryAppWorkloadName(cfApp, cfProcess)
 }
 
 func calculateCPURequest(memoryMiB int64) resource.Quantity {
@@ -361,6 +451,10 @@ func getDesiredAppWorkloadName(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1a
 	return appWorkloadName
 }
 
+func getCanaryAppWorkloadName(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) string {
+	return getDesiredAppWorkloadName(cfApp, cfProcess) + "-canary"
+}
+
 func (r *Reconciler) fetchAppWorkloadsForProcess(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) ([]korifiv1alpha1.AppWorkload, error) {
 	appWorkloadsForProcess := &korifiv1alpha1.AppWorkloadList{}
 	err := r.k8sClient.List(ctx, appWorkloadsForProcess, client.InNamespace(cfProcess.Namespace), client.MatchingLabels{
@@ -379,6 +473,62 @@ func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CF
 		cmd = process.Spec.DetectedCommand
 	}
 
+	return launchCommand(cmd, app)
+}
+
+func sidecarsForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp, sidecarsMemoryMB []int64) []korifiv1alpha1.AppWorkloadSidecar {
+	var sidecars []korifiv1alpha1.AppWorkloadSidecar
+	for i, sidecar := range process.Spec.Sidecars {
+		sidecars = append(sidecars, korifiv1alpha1.AppWorkloadSidecar{
+			Name:    sidecar.Name,
+			Command: launchCommand(sidecar.Command, app),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: mebibyteQuantity(sidecarsMemoryMB[i]),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: mebibyteQuantity(sidecarsMemoryMB[i]),
+				},
+			},
+		})
+	}
+
+	return sidecars
+}
+
+// splitProcessMemory splits the memory of the process between the containers
+// of its instances, so that they never use more memory than the process is
+// allotted, which is what quotas account for. Sidecars that set their own
+// memory have it carved out of the process memory, the remaining memory is
+// shared evenly by the process and the sidecars that do not. It returns false
+// when the memory of any container would not be positive
+func splitProcessMemory(process *korifiv1alpha1.CFProcess) (int64, []int64, bool) {
+	remainingMemoryMB := process.Spec.MemoryMB
+	sharingContainers := int64(1)
+	for _, sidecar := range process.Spec.Sidecars {
+		if sidecar.MemoryMB == 0 {
+			sharingContainers++
+		}
+		remainingMemoryMB -= sidecar.MemoryMB
+	}
+
+	sharedMemoryMB := remainingMemoryMB / sharingContainers
+	if len(process.Spec.Sidecars) > 0 && sharedMemoryMB <= 0 {
+		return 0, nil, false
+	}
+
+	sidecarsMemoryMB := []int64{}
+	for _, sidecar := range process.Spec.Sidecars {
+		if sidecar.MemoryMB < 0 {
+			return 0, nil, false
+		}
+		sidecarsMemoryMB = append(sidecarsMemoryMB, tools.IfZero(sidecar.MemoryMB, sharedMemoryMB))
+	}
+
+	return remainingMemoryMB - sharedMemoryMB*(sharingContainers-1), sidecarsMemoryMB, true
+}
+
+func launchCommand(cmd string, app *korifiv1alpha1.CFApp) []string {
 	if cmd == "" {
 		return []string{}
 	}
@@ -211,6 +211,38 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})).To(Succeed())
 		})
 
+		It("records a STARTED app usage event", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(appUsageEvents(g, cfProcess.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchAllFields(Fields{
+						"State":         Equal(korifiv1alpha1.AppUsageStateStarted),
+						"PreviousState": Equal(korifiv1alpha1.AppUsageStateStopped),
+						"App": Equal(korifiv1alpha1.UsageEventResource{
+							GUID: cfApp.Name,
+							Name: "test-app-name",
+						}),
+						"Process": Equal(korifiv1alpha1.UsageEventProcess{
+							GUID: cfProcess.Name,
+							Type: korifiv1alpha1.ProcessTypeWeb,
+						}),
+						"Space":                         Equal(korifiv1alpha1.UsageEventResource{GUID: testNamespace}),
+						"Organization":                  Equal(korifiv1alpha1.UsageEventResource{}),
+						"MemoryInMBPerInstance":         BeEquivalentTo(1024),
+						"PreviousMemoryInMBPerInstance": BeZero(),
+						"InstanceCount":                 BeEquivalentTo(1),
+						"PreviousInstanceCount":         BeZero(),
+					}),
+				})))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Status.Usage).To(Equal(&korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.AppUsageStateStarted,
+					MemoryMB:  1024,
+					Instances: 1,
+				}))
+			}).Should(Succeed())
+		})
+
 		It("reconciles the CFProcess into an AppWorkload", func() {
 			withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
 				g.Expect(appWorkload.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
@@ -291,6 +323,33 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the app rollback revision has not been restored yet", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] = "some-revision"
+				})).To(Succeed())
+			})
+
+			It("does not reconcile to app workload", func() {
+				Consistently(func(g Gomega) {
+					var appWorkloads korifiv1alpha1.AppWorkloadList
+					g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+					g.Expect(appWorkloads.Items).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("sets the CFProcess ready status to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					g.Expect(cfProcess.Status.Conditions).To(ContainElement(SatisfyAll(
+						matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						matchers.HasStatus(Equal(metav1.ConditionFalse)),
+						matchers.HasReason(Equal("RollbackRevisionNotRestored")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the CFProcess has an http health check", func() {
 			BeforeEach(func() {
 				cfProcess.Spec.HealthCheck = korifiv1alpha1.HealthCheck{
@@ -454,6 +513,23 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the app specifies max in flight", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.MaxInFlight = tools.PtrTo[int32](3)
+				})).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Status.ObservedGeneration = cfApp.Generation
+				})).To(Succeed())
+			})
+
+			It("sets max in flight on the app workload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.MaxInFlight).To(PointTo(BeEquivalentTo(3)))
+				})
+			})
+		})
+
 		When("the app bindings change after the workload has been created", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -490,6 +566,63 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the process has sidecars", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.Sidecars = []korifiv1alpha1.Sidecar{
+					{GUID: "sidecar-1", Name: "apm-agent", Command: "run-agent", MemoryMB: 64, Origin: korifiv1alpha1.SidecarOriginUser},
+					{GUID: "sidecar-2", Name: "reloader", Command: "reload-config", Origin: korifiv1alpha1.SidecarOriginBuildpack},
+				}
+			})
+
+			It("sets the sidecars on the app workload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Sidecars).To(HaveLen(2))
+
+					g.Expect(appWorkload.Spec.Sidecars[0].Name).To(Equal("apm-agent"))
+					g.Expect(appWorkload.Spec.Sidecars[0].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "run-agent"}))
+					g.Expect(appWorkload.Spec.Sidecars[0].Resources.Limits.Memory().String()).To(Equal("64Mi"))
+					g.Expect(appWorkload.Spec.Sidecars[0].Resources.Requests.Memory().String()).To(Equal("64Mi"))
+
+					g.Expect(appWorkload.Spec.Sidecars[1].Name).To(Equal("reloader"))
+					g.Expect(appWorkload.Spec.Sidecars[1].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "reload-config"}))
+					g.Expect(appWorkload.Spec.Sidecars[1].Resources.Limits.Memory().String()).To(Equal("480Mi"))
+					g.Expect(appWorkload.Spec.Sidecars[1].Resources.Requests.Memory().String()).To(Equal("480Mi"))
+				})
+			})
+
+			It("carves the sidecars memory out of the process memory", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Resources.Limits.Memory().String()).To(Equal("480Mi"))
+					g.Expect(appWorkload.Spec.Resources.Requests.Memory().String()).To(Equal("480Mi"))
+				})
+			})
+
+			When("the process is scaled below the memory of its sidecars", func() {
+				BeforeEach(func() {
+					cfProcess.Spec.MemoryMB = 64
+				})
+
+				It("does not reconcile to app workload", func() {
+					Consistently(func(g Gomega) {
+						var appWorkloads korifiv1alpha1.AppWorkloadList
+						g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+						g.Expect(appWorkloads.Items).To(BeEmpty())
+					}).Should(Succeed())
+				})
+
+				It("sets the CFProcess ready status to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+						g.Expect(cfProcess.Status.Conditions).To(ContainElement(SatisfyAll(
+							matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							matchers.HasStatus(Equal(metav1.ConditionFalse)),
+							matchers.HasReason(Equal("InsufficientMemory")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("there are no route destinations for the process app", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, cfRoute, func() {
@@ -529,6 +662,21 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 					g.Expect(appWorkloads.Items).To(BeEmpty())
 				}).Should(Succeed())
 			})
+
+			It("records a STOPPED app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(appUsageEvents(g, cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":                         Equal(korifiv1alpha1.AppUsageStateStopped),
+							"PreviousState":                 Equal(korifiv1alpha1.AppUsageStateStarted),
+							"MemoryInMBPerInstance":         BeZero(),
+							"PreviousMemoryInMBPerInstance": BeEquivalentTo(1024),
+							"InstanceCount":                 BeZero(),
+							"PreviousInstanceCount":         BeEquivalentTo(1),
+						}),
+					})))
+				}).Should(Succeed())
+			})
 		})
 
 		When("the app process instances are scaled down to 0", func() {
@@ -546,6 +694,19 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 					g.Expect(appWorkloads.Items).To(BeEmpty())
 				}).Should(Succeed())
 			})
+
+			It("records a SCALED app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(appUsageEvents(g, cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":                 Equal(korifiv1alpha1.AppUsageStateScaled),
+							"PreviousState":         Equal(korifiv1alpha1.AppUsageStateStarted),
+							"InstanceCount":         BeZero(),
+							"PreviousInstanceCount": BeEquivalentTo(1),
+						}),
+					})))
+				}).Should(Succeed())
+			})
 		})
 
 		When("the process desired instances are unset", func() {
@@ -605,6 +766,81 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				}, "1s").Should(Succeed())
 			})
 		})
+
+		When("a canary deployment is started", func() {
+			var stableAppWorkload *korifiv1alpha1.AppWorkload
+
+			JustBeforeEach(func() {
+				withAppWorkload(func(g Gomega, workload korifiv1alpha1.AppWorkload) {
+					stableAppWorkload = &workload
+				})
+
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "6"
+					cfApp.Spec.Canary = &korifiv1alpha1.CanaryDeployment{
+						StableRevision:   "5",
+						StableDropletRef: cfApp.Spec.CurrentDropletRef,
+					}
+				})).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Status.ObservedGeneration = cfApp.Generation
+				})).To(Succeed())
+			})
+
+			It("creates a single canary app workload at the new revision", func() {
+				Eventually(func(g Gomega) {
+					var appWorkloads korifiv1alpha1.AppWorkloadList
+					g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+					g.Expect(appWorkloads.Items).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+								"Name": Equal(stableAppWorkload.Name),
+							}),
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version":   Equal("5"),
+								"Instances": BeEquivalentTo(1),
+							}),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+								"Name": Equal(stableAppWorkload.Name + "-canary"),
+								"Annotations": HaveKeyWithValue(
+									korifiv1alpha1.CFAppLastStopRevisionKey, "2-canary",
+								),
+							}),
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version":   Equal("6"),
+								"Instances": BeEquivalentTo(1),
+							}),
+						}),
+					))
+				}).Should(Succeed())
+			})
+
+			When("the canary deployment is continued", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						var appWorkloads korifiv1alpha1.AppWorkloadList
+						g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+						g.Expect(appWorkloads.Items).To(HaveLen(2))
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Status.ObservedGeneration = cfApp.Generation
+					})).To(Succeed())
+				})
+
+				It("deletes the canary and rolls the stable app workload to the new revision", func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Name).To(Equal(stableAppWorkload.Name))
+						g.Expect(appWorkload.Spec.Version).To(Equal("6"))
+					})
+				})
+			})
+		})
 	})
 })
 
@@ -619,3 +855,17 @@ func withAppWorkload(shouldFn func(Gomega, korifiv1alpha1.AppWorkload)) {
 		shouldFn(g, appWorkloads.Items[0])
 	}).Should(Succeed())
 }
+
+func appUsageEvents(g Gomega, processGUID string) []korifiv1alpha1.CFAppUsageEvent {
+	var appUsageEvents korifiv1alpha1.CFAppUsageEventList
+	g.Expect(adminClient.List(context.Background(), &appUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	var result []korifiv1alpha1.CFAppUsageEvent
+	for _, event := range appUsageEvents.Items {
+		if event.Spec.Process.GUID == processGUID {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
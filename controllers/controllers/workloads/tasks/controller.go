@@ -23,6 +23,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -129,7 +130,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfTask *korifiv1alph
 		return ctrl.Result{}, err
 	}
 
-	r.initializeStatus(ctx, cfTask, cfDroplet)
+	r.initializeStatus(ctx, cfTask, cfApp.Spec.CurrentDropletRef.Name)
 
 	webProcess, err := r.getWebProcess(ctx, cfApp)
 	if err != nil {
@@ -204,14 +205,10 @@ func (r *Reconciler) getApp(ctx context.Context, cfTask *korifiv1alpha1.CFTask)
 	return cfApp, nil
 }
 
-func (r *Reconciler) getDroplet(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.CFBuild, error) {
+func (r *Reconciler) getDroplet(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.BuildDropletStatus, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("getDroplet").WithValues("dropletName", cfApp.Spec.CurrentDropletRef.Name)
 
-	cfDroplet := new(korifiv1alpha1.CFBuild)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{
-		Namespace: cfApp.Namespace,
-		Name:      cfApp.Spec.CurrentDropletRef.Name,
-	}, cfDroplet)
+	cfDroplet, err := shared.GetDroplet(ctx, r.k8sClient, cfApp.Namespace, cfApp.Spec.CurrentDropletRef.Name)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			r.recorder.Eventf(cfTask, "Warning", "AppCurrentDropletNotFound", "Current droplet %s for app %s does not exist", cfApp.Spec.CurrentDropletRef.Name, cfTask.Spec.AppRef.Name)
@@ -222,7 +219,7 @@ func (r *Reconciler) getDroplet(ctx context.Context, cfTask *korifiv1alpha1.CFTa
 		return nil, err
 	}
 
-	if cfDroplet.Status.Droplet == nil {
+	if cfDroplet == nil {
 		log.Info("droplet build status not set")
 		r.recorder.Eventf(cfTask, "Warning", "DropletBuildStatusNotSet", "Current droplet %s from app %s does not have a droplet image", cfApp.Spec.CurrentDropletRef.Name, cfTask.Spec.AppRef.Name)
 		return nil, errors.New("droplet build status not set")
@@ -248,7 +245,7 @@ func (r *Reconciler) getWebProcess(ctx context.Context, cfApp *korifiv1alpha1.CF
 	return processList.Items[0], nil
 }
 
-func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar) (*korifiv1alpha1.TaskWorkload, error) {
+func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.BuildDropletStatus, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar) (*korifiv1alpha1.TaskWorkload, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTaskWorkload")
 
 	taskWorkload := &korifiv1alpha1.TaskWorkload{
@@ -266,8 +263,8 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 		taskWorkload.Labels[korifiv1alpha1.CFTaskGUIDLabelKey] = cfTask.Name
 
 		taskWorkload.Spec.Command = []string{LifecycleLauncherPath, cfTask.Spec.Command}
-		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.Registry.Image
-		taskWorkload.Spec.ImagePullSecrets = cfDroplet.Status.Droplet.Registry.ImagePullSecrets
+		taskWorkload.Spec.Image = cfDroplet.Registry.Image
+		taskWorkload.Spec.ImagePullSecrets = cfDroplet.Registry.ImagePullSecrets
 
 		if taskWorkload.Spec.Resources.Requests == nil {
 			taskWorkload.Spec.Resources.Requests = corev1.ResourceList{}
@@ -317,8 +314,8 @@ func calculateDefaultCPURequestMillicores(memoryMiB int64) int64 {
 	return cpuMillicores
 }
 
-func (r *Reconciler) initializeStatus(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild) {
-	cfTask.Status.DropletRef.Name = cfDroplet.Name
+func (r *Reconciler) initializeStatus(ctx context.Context, cfTask *korifiv1alpha1.CFTask, dropletGUID string) {
+	cfTask.Status.DropletRef.Name = dropletGUID
 	meta.SetStatusCondition(&cfTask.Status.Conditions, metav1.Condition{
 		Type:               korifiv1alpha1.TaskInitializedConditionType,
 		Status:             metav1.ConditionTrue,
@@ -27,10 +27,14 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/cleanup"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/audit/events"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/domains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/routeservice"
+	securitygroups "code.cloudfoundry.org/korifi/controllers/controllers/networking/security_groups"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
 	managed_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/projection"
 	upsi_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/managed"
@@ -40,6 +44,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/buildpack"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/docker"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/droplets"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/labels"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/orgs"
@@ -62,6 +67,7 @@ import (
 	appswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/apps"
 	orgswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/orgs"
 	packageswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
+	processeswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
 	spaceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/spaces"
 	taskswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	"code.cloudfoundry.org/korifi/tools"
@@ -80,6 +86,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -97,6 +104,7 @@ func init() {
 	utilruntime.Must(buildv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(gatewayv1beta1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
 	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -217,6 +225,16 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = droplets.NewReconciler(
+			controllersClient,
+			controllersLog,
+			imageClient,
+			controllerConfig.ContainerRegistrySecretNames,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFDroplet")
+			os.Exit(1)
+		}
+
 		if err = processes.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
@@ -231,6 +249,7 @@ func main() {
 		if err = (upsi_instances.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
+			controllerConfig.CFRootNamespace,
 			controllersLog,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "UPSICFServiceInstance")
@@ -301,6 +320,21 @@ func main() {
 			os.Exit(1)
 		}
 
+		var auditEventRetention time.Duration
+		auditEventRetention, err = controllerConfig.ParseAuditEventRetention()
+		if err != nil {
+			setupLog.Error(err, "failed to parse audit event retention", "controller", "CFAuditEvent", "auditEventRetention", controllerConfig.AuditEventRetention)
+			os.Exit(1)
+		}
+		if err = events.NewReconciler(
+			controllersClient,
+			controllersLog,
+			auditEventRetention,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAuditEvent")
+			os.Exit(1)
+		}
+
 		if err = domains.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
@@ -310,11 +344,50 @@ func main() {
 			os.Exit(1)
 		}
 
+		if controllerConfig.ExperimentalSecurityGroupsEnabled {
+			if err = securitygroups.NewReconciler(
+				controllersClient,
+				controllersLog,
+			).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "CFSecurityGroup")
+				os.Exit(1)
+			}
+		}
+
+		if controllerConfig.ExperimentalServiceBindingProjectionEnabled {
+			if err = projection.NewSecretsReconciler(
+				controllersClient,
+				mgr.GetScheme(),
+				controllerConfig.CFRootNamespace,
+				controllersLog,
+			).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "CFServiceBindingProjection")
+				os.Exit(1)
+			}
+
+			if err = projection.NewDeploymentsReconciler(
+				controllersClient,
+				controllersLog,
+			).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "DeploymentBindingProjection")
+				os.Exit(1)
+			}
+		}
+
 		if controllerConfig.ExperimentalManagedServicesEnabled {
+			var catalogRefreshInterval time.Duration
+			catalogRefreshInterval, err = controllerConfig.ParseServiceBrokerCatalogRefreshInterval()
+			if err != nil {
+				setupLog.Error(err, "failed to parse catalog refresh interval", "controller", "CFServiceBroker", "serviceBrokerCatalogRefreshInterval", controllerConfig.ServiceBrokerCatalogRefreshInterval)
+				os.Exit(1)
+			}
+
 			if err = brokers.NewReconciler(
 				controllersClient,
 				osbapi.NewClientFactory(controllersClient, controllerConfig.TrustInsecureServiceBrokers),
 				mgr.GetScheme(),
+				controllerConfig.CFRootNamespace,
+				catalogRefreshInterval,
 				controllersLog,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "CFServiceBroker")
@@ -343,15 +416,42 @@ func main() {
 		}
 
 		if !controllerConfig.DisableRouteController {
+			var routeServiceSigningKey []byte
+			routeServiceSigningKey, err = os.ReadFile(controllerConfig.Networking.RouteServiceProxy.SigningKeyPath)
+			if err != nil {
+				setupLog.Error(err, "unable to read route service signing key")
+				os.Exit(1)
+			}
+
+			var routeServiceSignatureTTL time.Duration
+			routeServiceSignatureTTL, err = controllerConfig.ParseRouteServiceSignatureTTL()
+			if err != nil {
+				setupLog.Error(err, "unable to parse route service signature TTL")
+				os.Exit(1)
+			}
+
+			routeServiceSigner := routeservice.NewSigner(routeServiceSigningKey, routeServiceSignatureTTL)
+
 			if err = routes.NewReconciler(
 				controllersClient,
 				mgr.GetScheme(),
 				controllersLog,
 				controllerConfig,
+				routeServiceSigner,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "CFRoute")
 				os.Exit(1)
 			}
+
+			if err = mgr.Add(routeservice.NewProxy(
+				fmt.Sprintf(":%d", controllerConfig.Networking.RouteServiceProxy.Port),
+				routeServiceSigner,
+				routes.NewRouteServiceBackendResolver(mgr.GetClient()),
+				ctrl.Log.WithName("route-service-proxy"),
+			)); err != nil {
+				setupLog.Error(err, "unable to add route service proxy")
+				os.Exit(1)
+			}
 		}
 	}
 
@@ -391,15 +491,24 @@ func main() {
 			os.Exit(1)
 		}
 
+		quotaValidator := validation.NewQuotaValidator(uncachedClient, controllerConfig.CFRootNamespace)
+
 		if err = appswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, appswebhook.AppEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 			os.Exit(1)
 		}
 
+		if err = processeswebhook.NewValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
+			os.Exit(1)
+		}
+
 		if err = routeswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routeswebhook.RouteEntityType)),
+			quotaValidator,
 			controllerConfig.CFRootNamespace,
 			uncachedClient,
 		).SetupWebhookWithManager(mgr); err != nil {
@@ -409,6 +518,7 @@ func main() {
 
 		if err = instanceswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, instanceswebhook.ServiceInstanceEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceInstance")
 			os.Exit(1)
@@ -446,6 +556,7 @@ func main() {
 
 		if err = brokerswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, brokerswebhook.ServiceBrokerEntityType)),
+			controllerConfig.CFRootNamespace,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceBroker")
 			os.Exit(1)
@@ -469,7 +580,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err = taskswebhook.NewValidator().SetupWebhookWithManager(mgr); err != nil {
+		if err = taskswebhook.NewValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFTask")
 			os.Exit(1)
 		}
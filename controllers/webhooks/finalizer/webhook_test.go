@@ -145,5 +145,45 @@ var _ = Describe("Controllers Finalizers Webhook", func() {
 			},
 			korifiv1alpha1.CFServiceBindingFinalizerName,
 		),
+		Entry("cfsecuritygroup",
+			&korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName: uuid.NewString(),
+					Rules:       []korifiv1alpha1.SecurityGroupRule{},
+				},
+			},
+			korifiv1alpha1.CFSecurityGroupFinalizerName,
+		),
+		Entry("cfprocess",
+			&korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					ProcessType: "web",
+				},
+			},
+			korifiv1alpha1.CFProcessFinalizerName,
+		),
+		Entry("cfdroplet",
+			&korifiv1alpha1.CFDroplet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFDropletSpec{
+					AppRef: corev1.LocalObjectReference{Name: "app-guid"},
+					Lifecycle: korifiv1alpha1.Lifecycle{
+						Type: "buildpack",
+					},
+				},
+			},
+			korifiv1alpha1.CFDropletFinalizerName,
+		),
 	)
 })
@@ -124,6 +124,13 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, o
 		}.ExportJSONError()
 	}
 
+	if oldDomain.Spec.RouterGroup != domain.Spec.RouterGroup {
+		return nil, validationwebhook.ValidationError{
+			Type:    validationwebhook.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.RouterGroup"),
+		}.ExportJSONError()
+	}
+
 	return nil, nil
 }
 
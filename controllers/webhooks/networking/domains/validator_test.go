@@ -174,6 +174,20 @@ var _ = Describe("CFDomainValidator", func() {
 			))
 		})
 
+		When("the router group changes", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.RouterGroup = "default-tcp"
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validation.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.RouterGroup' field is immutable"),
+				))
+			})
+		})
+
 		When("the domain is being deleted", func() {
 			BeforeEach(func() {
 				updatedCFDomain.DeletionTimestamp = &metav1.Time{Time: time.Now()}
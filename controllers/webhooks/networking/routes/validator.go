@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -12,6 +13,7 @@ import (
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"github.com/hashicorp/go-multierror"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,8 +30,11 @@ const (
 
 	RouteDestinationNotInSpaceErrorType    = "RouteDestinationNotInSpaceError"
 	RouteDestinationNotInSpaceErrorMessage = "Route destination app not found in space"
+	RouteDestinationWeightErrorType        = "RouteDestinationWeightError"
+	RouteDestinationWeightErrorMessage     = "Destinations weights must be set on all destinations and total 100"
 	RouteHostNameValidationErrorType       = "RouteHostNameValidationError"
 	RoutePathValidationErrorType           = "RoutePathValidationError"
+	RouteProtocolValidationErrorType       = "RouteProtocolValidationError"
 	RouteSubdomainValidationErrorType      = "RouteSubdomainValidationError"
 	RouteSubdomainValidationErrorMessage   = "Subdomains must each be at most 63 characters"
 
@@ -41,6 +46,14 @@ const (
 	PathIsSlashError         = "Path cannot be a single slash"
 	PathHasQuestionMarkError = "Path cannot contain a question mark"
 	PathLengthExceededError  = "Path cannot exceed 128 characters"
+
+	HTTPRoutePortError       = "Routes with protocol 'http' do not support ports."
+	HTTPRouteProtocolError   = "Routes on domains without a router group must use the 'http' protocol."
+	TCPRouteHostError        = "Hosts are not supported for TCP routes."
+	TCPRoutePathError        = "Paths are not supported for TCP routes."
+	TCPRoutePortError        = "Ports are required for TCP routes."
+	TCPRouteProtocolError    = "Routes on domains with a router group must use the 'tcp' protocol."
+	TCPRouteRouterGroupError = "TCP routes must reference the router group of their domain."
 )
 
 var logger = logf.Log.WithName("route-validation")
@@ -49,6 +62,7 @@ var logger = logf.Log.WithName("route-validation")
 
 type Validator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 	rootNamespace      string
 	client             client.Client
 }
@@ -57,11 +71,13 @@ var _ webhook.CustomValidator = &Validator{}
 
 func NewValidator(
 	nameValidator webhooks.NameValidator,
+	quotaValidator webhooks.QuotaValidator,
 	rootNamespace string,
 	client client.Client,
 ) *Validator {
 	return &Validator{
 		duplicateValidator: nameValidator,
+		quotaValidator:     quotaValidator,
 		rootNamespace:      rootNamespace,
 		client:             client,
 	}
@@ -87,6 +103,10 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 
 	route.Status.FQDN = cfDomain.Spec.Name
 
+	if err = v.quotaValidator.ValidateRouteCreate(ctx, route); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route)
 }
 
@@ -124,12 +144,22 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, immutableError.ExportJSONError()
 	}
 
+	if !equalPorts(route.Spec.Port, oldRoute.Spec.Port) {
+		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.Port")
+		return nil, immutableError.ExportJSONError()
+	}
+
 	if route.Spec.DomainRef.Name != oldRoute.Spec.DomainRef.Name {
 		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.DomainRef.Name")
 		return nil, immutableError.ExportJSONError()
 	}
 
-	err := v.validateDestinations(ctx, route)
+	if route.Spec.RouterGroup != oldRoute.Spec.RouterGroup {
+		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.RouterGroup")
+		return nil, immutableError.ExportJSONError()
+	}
+
+	err := v.validateDestinations(ctx, route, oldRoute.Spec.Destinations)
 	if err != nil {
 		return nil, err
 	}
@@ -152,11 +182,23 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return domain, err
 	}
 
-	err = v.validateDestinations(ctx, route)
+	err = v.validateDestinations(ctx, route, nil)
 	if err != nil {
 		return domain, err
 	}
 
+	if domain.Spec.RouterGroup != "" {
+		if err = validateTCPRoute(route, domain); err != nil {
+			return nil, err
+		}
+
+		return domain, nil
+	}
+
+	if err = validateHTTPRoute(route); err != nil {
+		return nil, err
+	}
+
 	if err = validateFQDN(route.Spec.Host, domain.Spec.Name); err != nil {
 		return nil, err
 	}
@@ -182,7 +224,18 @@ func (v *Validator) fetchDomain(ctx context.Context, route *korifiv1alpha1.CFRou
 	return domain, err
 }
 
-func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+// validateDestinations checks the destinations of the route. Weighted
+// destinations must total 100, unless the update only removes destinations
+// (e.g. when their app is deleted), in which case the traffic is split among
+// the remaining destinations in proportion to their weights.
+func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alpha1.CFRoute, oldDestinations []korifiv1alpha1.Destination) error {
+	if !destinationWeightsValid(route.Spec.Destinations) && !onlyRemovesDestinations(oldDestinations, route.Spec.Destinations) {
+		return validationwebhook.ValidationError{
+			Type:    RouteDestinationWeightErrorType,
+			Message: RouteDestinationWeightErrorMessage,
+		}.ExportJSONError()
+	}
+
 	err := v.checkDestinationsExistInNamespace(ctx, *route)
 	if err != nil {
 		validationErr := validationwebhook.ValidationError{}
@@ -202,6 +255,101 @@ func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alp
 	return nil
 }
 
+func destinationWeightsValid(destinations []korifiv1alpha1.Destination) bool {
+	weighted := 0
+	total := int32(0)
+	for _, destination := range destinations {
+		if destination.Weight != nil {
+			weighted++
+			total += *destination.Weight
+		}
+	}
+
+	if weighted == 0 {
+		return true
+	}
+
+	return weighted == len(destinations) && total == 100
+}
+
+func onlyRemovesDestinations(oldDestinations, destinations []korifiv1alpha1.Destination) bool {
+	if len(destinations) >= len(oldDestinations) {
+		return false
+	}
+
+	for _, destination := range destinations {
+		if !slices.ContainsFunc(oldDestinations, func(oldDestination korifiv1alpha1.Destination) bool {
+			return equality.Semantic.DeepEqual(oldDestination, destination)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateTCPRoute(route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	var errStrings []string
+
+	if route.Spec.Protocol != korifiv1alpha1.RouteProtocolTCP {
+		errStrings = append(errStrings, TCPRouteProtocolError)
+	}
+
+	if route.Spec.Port == nil {
+		errStrings = append(errStrings, TCPRoutePortError)
+	}
+
+	if route.Spec.Host != "" {
+		errStrings = append(errStrings, TCPRouteHostError)
+	}
+
+	if route.Spec.Path != "" {
+		errStrings = append(errStrings, TCPRoutePathError)
+	}
+
+	if route.Spec.RouterGroup != domain.Spec.RouterGroup {
+		errStrings = append(errStrings, TCPRouteRouterGroupError)
+	}
+
+	if len(errStrings) == 0 {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteProtocolValidationErrorType,
+		Message: strings.Join(errStrings, " "),
+	}.ExportJSONError()
+}
+
+func validateHTTPRoute(route *korifiv1alpha1.CFRoute) error {
+	var errStrings []string
+
+	if route.Spec.Protocol == korifiv1alpha1.RouteProtocolTCP {
+		errStrings = append(errStrings, HTTPRouteProtocolError)
+	}
+
+	if route.Spec.Port != nil {
+		errStrings = append(errStrings, HTTPRoutePortError)
+	}
+
+	if len(errStrings) == 0 {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteProtocolValidationErrorType,
+		Message: strings.Join(errStrings, " "),
+	}.ExportJSONError()
+}
+
+func equalPorts(port, otherPort *int32) bool {
+	if port == nil || otherPort == nil {
+		return port == otherPort
+	}
+
+	return *port == *otherPort
+}
+
 func validateFQDN(host, domain string) error {
 	// we only need to validate that "<host>.<domain>" is not too long and that
 	// <host> is either "*" or a valid dns label. The domain webhook already
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking/routes"
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,6 +31,7 @@ var _ = Describe("CFRouteValidator", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.QuotaValidator
 		fakeClient         *controllerfake.Client
 		cfRoute            *korifiv1alpha1.CFRoute
 		cfDomain           *korifiv1alpha1.CFDomain
@@ -102,7 +105,8 @@ var _ = Describe("CFRouteValidator", func() {
 			}
 		}
 
-		validatingWebhook = routes.NewValidator(duplicateValidator, rootNamespace, fakeClient)
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = routes.NewValidator(duplicateValidator, quotaValidator, rootNamespace, fakeClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -163,6 +167,24 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		It("validates the route quota", func() {
+			Expect(quotaValidator.ValidateRouteCreateCallCount()).To(Equal(1))
+			actualContext, actualRoute := quotaValidator.ValidateRouteCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualRoute).To(Equal(cfRoute))
+		})
+
+		When("the route quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateRouteCreateReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request without registering the route name", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the route name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
@@ -254,6 +276,94 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the route has a port", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteProtocolValidationErrorType,
+					Equal("Routes with protocol 'http' do not support ports."),
+				))
+			})
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+				cfRoute.Spec.Protocol = korifiv1alpha1.RouteProtocolTCP
+				cfRoute.Spec.Port = tools.PtrTo[int32](1024)
+				cfRoute.Spec.RouterGroup = "default-tcp"
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			It("validates the route is unique by its router group and port", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				_, _, _, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+				Expect(actualResource.UniqueName()).To(Equal("tcp::default-tcp::1024"))
+				Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Port 1024 is not available on this domain's router group."))
+			})
+
+			When("the route does not reference the router group of the domain", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.RouterGroup = "another-tcp"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal("TCP routes must reference the router group of their domain."),
+					))
+				})
+			})
+
+			When("the route has no port", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Port = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal("Ports are required for TCP routes."),
+					))
+				})
+			})
+
+			When("the route has a host and a path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "my-host"
+					cfRoute.Spec.Path = "/my-path"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal("Hosts are not supported for TCP routes. Paths are not supported for TCP routes."),
+					))
+				})
+			})
+
+			When("the route protocol is http", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Protocol = korifiv1alpha1.RouteProtocolHTTP
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal("Routes on domains with a router group must use the 'tcp' protocol."),
+					))
+				})
+			})
+		})
+
 		When("the route has destinations", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
@@ -294,6 +404,52 @@ var _ = Describe("CFRouteValidator", func() {
 					))
 				})
 			})
+
+			When("the destinations are weighted", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+						{
+							AppRef: v1.LocalObjectReference{Name: "blue"},
+							Weight: tools.PtrTo[int32](80),
+						},
+						{
+							AppRef: v1.LocalObjectReference{Name: "green"},
+							Weight: tools.PtrTo[int32](20),
+						},
+					}
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+
+				When("the weights do not total 100", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.Destinations[1].Weight = tools.PtrTo[int32](30)
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(
+							routes.RouteDestinationWeightErrorType,
+							Equal(routes.RouteDestinationWeightErrorMessage),
+						))
+					})
+				})
+
+				When("some of the destinations are not weighted", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.Destinations[0].Weight = tools.PtrTo[int32](100)
+						cfRoute.Spec.Destinations[1].Weight = nil
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(
+							routes.RouteDestinationWeightErrorType,
+							Equal(routes.RouteDestinationWeightErrorMessage),
+						))
+					})
+				})
+			})
 		})
 	})
 
@@ -391,6 +547,19 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the port is updated", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validationwebhook.ImmutableFieldErrorType,
+					Equal("'CFRoute.Spec.Port' field is immutable"),
+				))
+			})
+		})
+
 		When("the DomainRef is updated", func() {
 			BeforeEach(func() {
 				updatedCFRoute.Spec.DomainRef = v1.ObjectReference{Name: "newDomainRef"}
@@ -404,6 +573,19 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the RouterGroup is updated", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.RouterGroup = "another-tcp"
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validationwebhook.ImmutableFieldErrorType,
+					Equal("'CFRoute.Spec.RouterGroup' field is immutable"),
+				))
+			})
+		})
+
 		When("the destination contains an app not found in the route's namespace", func() {
 			BeforeEach(func() {
 				getAppError = k8serrors.NewNotFound(schema.GroupResource{}, "foo")
@@ -429,6 +611,67 @@ var _ = Describe("CFRouteValidator", func() {
 				))
 			})
 		})
+
+		When("the route has weighted destinations", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+					{
+						GUID:   "blue-guid",
+						AppRef: v1.LocalObjectReference{Name: "blue"},
+						Weight: tools.PtrTo[int32](50),
+					},
+					{
+						GUID:   "green-guid",
+						AppRef: v1.LocalObjectReference{Name: "green"},
+						Weight: tools.PtrTo[int32](30),
+					},
+					{
+						GUID:   "red-guid",
+						AppRef: v1.LocalObjectReference{Name: "red"},
+						Weight: tools.PtrTo[int32](20),
+					},
+				}
+				updatedCFRoute.Spec.Destinations = cfRoute.Spec.Destinations[:2]
+			})
+
+			It("allows removing destinations", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the remaining destinations are changed", func() {
+				BeforeEach(func() {
+					updatedCFRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+						cfRoute.Spec.Destinations[0],
+						*cfRoute.Spec.Destinations[1].DeepCopy(),
+					}
+					updatedCFRoute.Spec.Destinations[1].Weight = tools.PtrTo[int32](40)
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteDestinationWeightErrorType,
+						Equal(routes.RouteDestinationWeightErrorMessage),
+					))
+				})
+			})
+
+			When("a destination is added", func() {
+				BeforeEach(func() {
+					updatedCFRoute.Spec.Destinations = append(slices.Clone(cfRoute.Spec.Destinations), korifiv1alpha1.Destination{
+						GUID:   "yellow-guid",
+						AppRef: v1.LocalObjectReference{Name: "yellow"},
+						Weight: tools.PtrTo[int32](10),
+					})
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteDestinationWeightErrorType,
+						Equal(routes.RouteDestinationWeightErrorMessage),
+					))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateDelete", func() {
@@ -73,6 +73,10 @@ func (v *CFServiceBindingValidator) ValidateUpdate(ctx context.Context, oldObj,
 		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "AppRef.Name is immutable"}
 	}
 
+	if oldServiceBinding.Spec.RouteRef.Name != serviceBinding.Spec.RouteRef.Name {
+		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "RouteRef.Name is immutable"}
+	}
+
 	if oldServiceBinding.Spec.Service.Name != serviceBinding.Spec.Service.Name {
 		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "Service.Name is immutable"}
 	}
@@ -78,6 +78,21 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Service binding already exists: App: " + appGUID + " Service Instance: " + serviceInstanceGUID))
 		})
 
+		When("the binding is a route binding", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeRoute
+				serviceBinding.Spec.AppRef = v1.LocalObjectReference{}
+				serviceBinding.Spec.RouteRef = v1.LocalObjectReference{Name: "route-guid"}
+			})
+
+			It("allows a single route binding per route", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				_, _, _, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+				Expect(actualResource.UniqueName()).To(Equal("sb::route::route-guid"))
+				Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("A route may only be bound to a single service instance"))
+			})
+		})
+
 		When("a duplicate service binding already exists", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
@@ -126,6 +141,16 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			})
 		})
 
+		When("the RouteRef name changes", func() {
+			BeforeEach(func() {
+				updatedServiceBinding.Spec.RouteRef.Name = "updated-route-name"
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(MatchError(ContainSubstring("RouteRef.Name is immutable")))
+			})
+		})
+
 		When("the Service Instance name changes", func() {
 			BeforeEach(func() {
 				updatedServiceBinding.Spec.Service.Name = "updated-service-instance"
@@ -32,13 +32,15 @@ func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 type Validator struct {
 	duplicateValidator webhooks.NameValidator
+	rootNamespace      string
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(duplicateValidator webhooks.NameValidator, rootNamespace string) *Validator {
 	return &Validator{
 		duplicateValidator: duplicateValidator,
+		rootNamespace:      rootNamespace,
 	}
 }
 
@@ -48,7 +50,7 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBroker but got a %T", obj))
 	}
 
-	return nil, v.duplicateValidator.ValidateCreate(ctx, cfservicebrokerlog, serviceBroker.Namespace, serviceBroker)
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfservicebrokerlog, v.rootNamespace, serviceBroker)
 }
 
 func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
@@ -66,7 +68,7 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBroker but got a %T", oldObj))
 	}
 
-	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfservicebrokerlog, serviceBroker.Namespace, oldServiceBroker, serviceBroker)
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfservicebrokerlog, v.rootNamespace, oldServiceBroker, serviceBroker)
 }
 
 func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -75,5 +77,5 @@ func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBroker but got a %T", obj))
 	}
 
-	return nil, v.duplicateValidator.ValidateDelete(ctx, cfservicebrokerlog, serviceBroker.Namespace, serviceBroker)
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfservicebrokerlog, v.rootNamespace, serviceBroker)
 }
@@ -19,6 +19,7 @@ import (
 var _ = Describe("CFServiceBrokerValidatingWebhook", func() {
 	const (
 		defaultNamespace = "default"
+		rootNamespace    = "cf"
 	)
 
 	var (
@@ -47,7 +48,7 @@ var _ = Describe("CFServiceBrokerValidatingWebhook", func() {
 		}
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = brokers.NewValidator(duplicateValidator)
+		validatingWebhook = brokers.NewValidator(duplicateValidator, rootNamespace)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -63,7 +64,7 @@ var _ = Describe("CFServiceBrokerValidatingWebhook", func() {
 			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
 			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
 			Expect(actualContext).To(Equal(ctx))
-			Expect(actualNamespace).To(Equal(serviceBroker.Namespace))
+			Expect(actualNamespace).To(Equal(rootNamespace))
 			Expect(actualResource).To(Equal(serviceBroker))
 			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Name must be unique"))
 		})
@@ -99,7 +100,7 @@ var _ = Describe("CFServiceBrokerValidatingWebhook", func() {
 			Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
 			actualContext, _, actualNamespace, oldResource, newResource := duplicateValidator.ValidateUpdateArgsForCall(0)
 			Expect(actualContext).To(Equal(ctx))
-			Expect(actualNamespace).To(Equal(serviceBroker.Namespace))
+			Expect(actualNamespace).To(Equal(rootNamespace))
 			Expect(oldResource).To(Equal(serviceBroker))
 			Expect(newResource).To(Equal(updatedServiceBroker))
 		})
@@ -138,7 +139,7 @@ var _ = Describe("CFServiceBrokerValidatingWebhook", func() {
 			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
 			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
 			Expect(actualContext).To(Equal(ctx))
-			Expect(actualNamespace).To(Equal(serviceBroker.Namespace))
+			Expect(actualNamespace).To(Equal(rootNamespace))
 			Expect(actualResource).To(Equal(serviceBroker))
 		})
 
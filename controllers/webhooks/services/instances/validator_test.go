@@ -26,6 +26,7 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.QuotaValidator
 		serviceInstance    *korifiv1alpha1.CFServiceInstance
 		validatingWebhook  *instances.Validator
 		retErr             error
@@ -50,7 +51,8 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		}
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = instances.NewValidator(duplicateValidator)
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = instances.NewValidator(duplicateValidator, quotaValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -71,6 +73,24 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("The service instance name is taken: " + serviceInstance.Spec.DisplayName))
 		})
 
+		It("validates the service instance quota", func() {
+			Expect(quotaValidator.ValidateServiceInstanceCreateCallCount()).To(Equal(1))
+			actualContext, actualServiceInstance := quotaValidator.ValidateServiceInstanceCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualServiceInstance).To(Equal(serviceInstance))
+		})
+
+		When("the service instance quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateServiceInstanceCreateReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request without registering the service instance name", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the serviceInstance name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
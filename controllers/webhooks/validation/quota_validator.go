@@ -0,0 +1,495 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// The quota error types match the titles of the corresponding CF API errors
+// (without the `CF-` prefix) so that the API can translate them into errors
+// the CF CLI understands.
+const (
+	OrgMemoryQuotaExceededErrorType              = "AppMemoryQuotaExceeded"
+	OrgMemoryQuotaExceededErrorMessage           = "You have exceeded your organization's memory limit: app requested more memory than available"
+	SpaceMemoryQuotaExceededErrorType            = "SpaceQuotaMemoryLimitExceeded"
+	SpaceMemoryQuotaExceededErrorMessage         = "You have exceeded your space's memory limit: app requested more memory than available"
+	OrgInstanceMemoryQuotaExceededErrorType      = "QuotaInstanceMemoryLimitExceeded"
+	OrgInstanceMemoryQuotaExceededErrorMessage   = "You have exceeded the instance memory limit for your organization's quota."
+	SpaceInstanceMemoryQuotaExceededErrorType    = "SpaceQuotaInstanceMemoryLimitExceeded"
+	SpaceInstanceMemoryQuotaExceededErrorMessage = "You have exceeded the instance memory limit for your space's quota."
+	OrgInstanceQuotaExceededErrorType            = "QuotaInstanceLimitExceeded"
+	OrgInstanceQuotaExceededErrorMessage         = "You have exceeded the instance limit for your organization's quota."
+	SpaceInstanceQuotaExceededErrorType          = "SpaceQuotaInstanceLimitExceeded"
+	SpaceInstanceQuotaExceededErrorMessage       = "You have exceeded the instance limit for your space's quota."
+	OrgServiceQuotaExceededErrorType             = "ServiceInstanceQuotaExceeded"
+	OrgServiceQuotaExceededErrorMessage          = "You have exceeded your organization's services limit."
+	SpaceServiceQuotaExceededErrorType           = "ServiceInstanceSpaceQuotaExceeded"
+	SpaceServiceQuotaExceededErrorMessage        = "You have exceeded your space's services limit."
+	RouteQuotaExceededErrorType                  = "RouteQuotaExceeded"
+	RouteQuotaExceededErrorMessage               = "Routes quota exceeded for %s '%s'."
+	TaskQuotaExceededErrorType                   = "TaskQuotaExceeded"
+	TaskQuotaExceededErrorMessage                = "The app has reached the maximum number of tasks allowed per app by the %s quota."
+	LogRateQuotaExceededErrorType                = "LogRateQuotaExceeded"
+	LogRateQuotaExceededErrorMessage             = "log_rate_limit cannot be unlimited in %s '%s'."
+
+	orgQuotaScope   = "organization"
+	spaceQuotaScope = "space"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgquotas;cfspacequotas,verbs=get;list;watch
+
+type QuotaValidator struct {
+	client        client.Client
+	rootNamespace string
+}
+
+func NewQuotaValidator(client client.Client, rootNamespace string) *QuotaValidator {
+	return &QuotaValidator{client: client, rootNamespace: rootNamespace}
+}
+
+// quotaScope is a quota applied to an org or a space, along with the space
+// namespaces whose resources count towards it
+type quotaScope struct {
+	kind       string
+	name       string
+	limits     korifiv1alpha1.QuotaLimits
+	namespaces []string
+}
+
+func (s quotaScope) quotaError(orgErrType, orgErrMessage, spaceErrType, spaceErrMessage string) error {
+	if s.kind == orgQuotaScope {
+		return ValidationError{Type: orgErrType, Message: orgErrMessage}.ExportJSONError()
+	}
+
+	return ValidationError{Type: spaceErrType, Message: spaceErrMessage}.ExportJSONError()
+}
+
+func (v QuotaValidator) ValidateProcess(ctx context.Context, oldProcess, process *korifiv1alpha1.CFProcess) error {
+	logger := logf.FromContext(ctx).WithName("quotaValidator.ValidateProcess").WithValues("namespace", process.Namespace, "name", process.Name)
+
+	instances := int64(desiredInstances(process))
+	if oldProcess != nil && process.Spec.MemoryMB <= oldProcess.Spec.MemoryMB && instances <= int64(desiredInstances(oldProcess)) {
+		// Scaling down is always allowed, even when the quota is already exceeded
+		return nil
+	}
+
+	scopes, err := v.quotaScopes(ctx, process.Namespace)
+	if err != nil {
+		logger.Info("failed to get quotas", "reason", err)
+		return unknownError()
+	}
+
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	started, err := v.isAppStarted(ctx, process.Namespace, process.Spec.AppRef.Name)
+	if err != nil {
+		logger.Info("failed to get app", "reason", err)
+		return unknownError()
+	}
+
+	for _, scope := range scopes {
+		if exceeds(scope.limits.Apps.PerProcessMemoryInMB, process.Spec.MemoryMB) {
+			return scope.quotaError(
+				OrgInstanceMemoryQuotaExceededErrorType, OrgInstanceMemoryQuotaExceededErrorMessage,
+				SpaceInstanceMemoryQuotaExceededErrorType, SpaceInstanceMemoryQuotaExceededErrorMessage,
+			)
+		}
+
+		// Only the processes of started apps count towards the quota usage.
+		// Stopped apps are validated when they are started.
+		if !started {
+			continue
+		}
+
+		err = v.validateStartedUsage(ctx, scope, process.Spec.MemoryMB*instances, instances, func(p *korifiv1alpha1.CFProcess) bool {
+			return p.Namespace == process.Namespace && p.Name == process.Name
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v QuotaValidator) ValidateAppStart(ctx context.Context, oldApp, app *korifiv1alpha1.CFApp) error {
+	logger := logf.FromContext(ctx).WithName("quotaValidator.ValidateAppStart").WithValues("namespace", app.Namespace, "name", app.Name)
+
+	if app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return nil
+	}
+
+	if oldApp != nil && oldApp.Spec.DesiredState == korifiv1alpha1.StartedState {
+		return nil
+	}
+
+	scopes, err := v.quotaScopes(ctx, app.Namespace)
+	if err != nil {
+		logger.Info("failed to get quotas", "reason", err)
+		return unknownError()
+	}
+
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	processes := &korifiv1alpha1.CFProcessList{}
+	if err = v.client.List(ctx, processes, client.InNamespace(app.Namespace)); err != nil {
+		logger.Info("failed to list app processes", "reason", err)
+		return unknownError()
+	}
+
+	isAppProcess := func(p *korifiv1alpha1.CFProcess) bool {
+		return p.Namespace == app.Namespace && p.Spec.AppRef.Name == app.Name
+	}
+
+	var memory, instances, maxProcessMemory int64
+	for _, p := range processes.Items {
+		if !isAppProcess(&p) {
+			continue
+		}
+
+		memory += p.Spec.MemoryMB * int64(desiredInstances(&p))
+		instances += int64(desiredInstances(&p))
+		maxProcessMemory = max(maxProcessMemory, p.Spec.MemoryMB)
+	}
+
+	for _, scope := range scopes {
+		if exceeds(scope.limits.Apps.PerProcessMemoryInMB, maxProcessMemory) {
+			return scope.quotaError(
+				OrgInstanceMemoryQuotaExceededErrorType, OrgInstanceMemoryQuotaExceededErrorMessage,
+				SpaceInstanceMemoryQuotaExceededErrorType, SpaceInstanceMemoryQuotaExceededErrorMessage,
+			)
+		}
+
+		if err = v.validateStartedUsage(ctx, scope, memory, instances, isAppProcess); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateStartedUsage checks that the given memory and instances can be
+// started on top of the usage of all the other started processes in the
+// scope. Processes matching excluded are not counted towards the usage.
+func (v QuotaValidator) validateStartedUsage(
+	ctx context.Context,
+	scope quotaScope,
+	memory int64,
+	instances int64,
+	excluded func(*korifiv1alpha1.CFProcess) bool,
+) error {
+	logger := logf.FromContext(ctx).WithName("quotaValidator.validateStartedUsage").WithValues("scope", scope.kind, "name", scope.name)
+	appLimits := scope.limits.Apps
+
+	// Korifi processes have no log rate limit of their own, i.e. their log
+	// rate is unlimited, which no log rate quota can accommodate
+	if appLimits.LogRateLimitInBytesPerSecond != nil && instances > 0 {
+		return ValidationError{
+			Type:    LogRateQuotaExceededErrorType,
+			Message: fmt.Sprintf(LogRateQuotaExceededErrorMessage, scope.kind, scope.name),
+		}.ExportJSONError()
+	}
+
+	if appLimits.TotalMemoryInMB == nil && appLimits.TotalInstances == nil {
+		return nil
+	}
+
+	usedMemory, usedInstances, err := v.processUsage(ctx, scope.namespaces, excluded)
+	if err != nil {
+		logger.Info("failed to compute process usage", "reason", err)
+		return unknownError()
+	}
+
+	if exceeds(appLimits.TotalMemoryInMB, usedMemory+memory) {
+		return scope.quotaError(
+			OrgMemoryQuotaExceededErrorType, OrgMemoryQuotaExceededErrorMessage,
+			SpaceMemoryQuotaExceededErrorType, SpaceMemoryQuotaExceededErrorMessage,
+		)
+	}
+
+	if exceeds(appLimits.TotalInstances, usedInstances+instances) {
+		return scope.quotaError(
+			OrgInstanceQuotaExceededErrorType, OrgInstanceQuotaExceededErrorMessage,
+			SpaceInstanceQuotaExceededErrorType, SpaceInstanceQuotaExceededErrorMessage,
+		)
+	}
+
+	return nil
+}
+
+func (v QuotaValidator) ValidateRouteCreate(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+	logger := logf.FromContext(ctx).WithName("quotaValidator.ValidateRouteCreate").WithValues("namespace", route.Namespace, "name", route.Name)
+
+	scopes, err := v.quotaScopes(ctx, route.Namespace)
+	if err != nil {
+		logger.Info("failed to get quotas", "reason", err)
+		return unknownError()
+	}
+
+	for _, scope := range scopes {
+		if scope.limits.Routes.TotalRoutes == nil {
+			continue
+		}
+
+		routesCount, err := v.count(ctx, scope.namespaces, &korifiv1alpha1.CFRouteList{})
+		if err != nil {
+			logger.Info("failed to count routes", "reason", err)
+			return unknownError()
+		}
+
+		if exceeds(scope.limits.Routes.TotalRoutes, routesCount+1) {
+			return ValidationError{
+				Type:    RouteQuotaExceededErrorType,
+				Message: fmt.Sprintf(RouteQuotaExceededErrorMessage, scope.kind, scope.name),
+			}.ExportJSONError()
+		}
+	}
+
+	return nil
+}
+
+func (v QuotaValidator) ValidateServiceInstanceCreate(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	logger := logf.FromContext(ctx).WithName("quotaValidator.ValidateServiceInstanceCreate").WithValues("namespace", serviceInstance.Namespace, "name", serviceInstance.Name)
+
+	scopes, err := v.quotaScopes(ctx, serviceInstance.Namespace)
+	if err != nil {
+		logger.Info("failed to get quotas", "reason", err)
+		return unknownError()
+	}
+
+	for _, scope := range scopes {
+		if scope.limits.Services.TotalServiceInstances == nil {
+			continue
+		}
+
+		instancesCount, err := v.count(ctx, scope.namespaces, &korifiv1alpha1.CFServiceInstanceList{})
+		if err != nil {
+			logger.Info("failed to count service instances", "reason", err)
+			return unknownError()
+		}
+
+		if exceeds(scope.limits.Services.TotalServiceInstances, instancesCount+1) {
+			return scope.quotaError(
+				OrgServiceQuotaExceededErrorType, OrgServiceQuotaExceededErrorMessage,
+				SpaceServiceQuotaExceededErrorType, SpaceServiceQuotaExceededErrorMessage,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (v QuotaValidator) ValidateTaskCreate(ctx context.Context, task *korifiv1alpha1.CFTask) error {
+	logger := logf.FromContext(ctx).WithName("quotaValidator.ValidateTaskCreate").WithValues("namespace", task.Namespace, "name", task.Name)
+
+	scopes, err := v.quotaScopes(ctx, task.Namespace)
+	if err != nil {
+		logger.Info("failed to get quotas", "reason", err)
+		return unknownError()
+	}
+
+	for _, scope := range scopes {
+		if scope.limits.Apps.PerAppTasks == nil {
+			continue
+		}
+
+		tasksCount, err := v.pendingTasksCount(ctx, task)
+		if err != nil {
+			logger.Info("failed to count tasks", "reason", err)
+			return unknownError()
+		}
+
+		if exceeds(scope.limits.Apps.PerAppTasks, tasksCount+1) {
+			return ValidationError{
+				Type:    TaskQuotaExceededErrorType,
+				Message: fmt.Sprintf(TaskQuotaExceededErrorMessage, scope.kind),
+			}.ExportJSONError()
+		}
+	}
+
+	return nil
+}
+
+// quotaScopes returns the space and org quotas applying to the given space
+// namespace. Namespaces that do not belong to a space are not subject to
+// quotas.
+func (v QuotaValidator) quotaScopes(ctx context.Context, spaceNamespace string) ([]quotaScope, error) {
+	namespace := &corev1.Namespace{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: spaceNamespace}, namespace); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	orgGUID := namespace.Labels[korifiv1alpha1.OrgGUIDKey]
+	if orgGUID == "" || namespace.Labels[korifiv1alpha1.SpaceGUIDKey] == "" {
+		return nil, nil
+	}
+
+	scopes := []quotaScope{}
+
+	cfSpace := &korifiv1alpha1.CFSpace{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: orgGUID, Name: spaceNamespace}, cfSpace); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	if cfSpace.Spec.QuotaGUID != "" {
+		spaceQuota := &korifiv1alpha1.CFSpaceQuota{}
+		err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: cfSpace.Spec.QuotaGUID}, spaceQuota)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+
+		if err == nil {
+			scopes = append(scopes, quotaScope{
+				kind:       spaceQuotaScope,
+				name:       cfSpace.Spec.DisplayName,
+				limits:     spaceQuota.Spec.QuotaLimits,
+				namespaces: []string{spaceNamespace},
+			})
+		}
+	}
+
+	cfOrg := &korifiv1alpha1.CFOrg{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: orgGUID}, cfOrg); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	if cfOrg.Spec.QuotaGUID == "" {
+		return scopes, nil
+	}
+
+	orgQuota := &korifiv1alpha1.CFOrgQuota{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: cfOrg.Spec.QuotaGUID}, orgQuota); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return scopes, nil
+		}
+		return nil, err
+	}
+
+	orgSpaces := &korifiv1alpha1.CFSpaceList{}
+	if err := v.client.List(ctx, orgSpaces, client.InNamespace(orgGUID)); err != nil {
+		return nil, err
+	}
+
+	orgNamespaces := []string{}
+	for _, space := range orgSpaces.Items {
+		orgNamespaces = append(orgNamespaces, space.Name)
+	}
+
+	return append(scopes, quotaScope{
+		kind:       orgQuotaScope,
+		name:       cfOrg.Spec.DisplayName,
+		limits:     orgQuota.Spec.QuotaLimits,
+		namespaces: orgNamespaces,
+	}), nil
+}
+
+// processUsage returns the memory and instances used by the processes of
+// the started apps in the namespaces, except for the excluded ones
+func (v QuotaValidator) processUsage(ctx context.Context, namespaces []string, excluded func(*korifiv1alpha1.CFProcess) bool) (int64, int64, error) {
+	var memory, instances int64
+
+	for _, ns := range namespaces {
+		apps := &korifiv1alpha1.CFAppList{}
+		if err := v.client.List(ctx, apps, client.InNamespace(ns)); err != nil {
+			return 0, 0, err
+		}
+
+		startedApps := map[string]bool{}
+		for _, app := range apps.Items {
+			startedApps[app.Name] = app.Spec.DesiredState == korifiv1alpha1.StartedState
+		}
+
+		processes := &korifiv1alpha1.CFProcessList{}
+		if err := v.client.List(ctx, processes, client.InNamespace(ns)); err != nil {
+			return 0, 0, err
+		}
+
+		for _, p := range processes.Items {
+			if excluded(&p) || !startedApps[p.Spec.AppRef.Name] {
+				continue
+			}
+
+			memory += p.Spec.MemoryMB * int64(desiredInstances(&p))
+			instances += int64(desiredInstances(&p))
+		}
+	}
+
+	return memory, instances, nil
+}
+
+func (v QuotaValidator) isAppStarted(ctx context.Context, namespace, appGUID string) (bool, error) {
+	app := &korifiv1alpha1.CFApp{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: appGUID}, app); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return app.Spec.DesiredState == korifiv1alpha1.StartedState, nil
+}
+
+func (v QuotaValidator) count(ctx context.Context, namespaces []string, list client.ObjectList) (int64, error) {
+	var count int64
+
+	for _, ns := range namespaces {
+		if err := v.client.List(ctx, list, client.InNamespace(ns)); err != nil {
+			return 0, err
+		}
+
+		count += int64(meta.LenList(list))
+	}
+
+	return count, nil
+}
+
+func (v QuotaValidator) pendingTasksCount(ctx context.Context, task *korifiv1alpha1.CFTask) (int64, error) {
+	tasks := &korifiv1alpha1.CFTaskList{}
+	if err := v.client.List(ctx, tasks, client.InNamespace(task.Namespace)); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, t := range tasks.Items {
+		if t.Spec.AppRef.Name != task.Spec.AppRef.Name || t.Name == task.Name {
+			continue
+		}
+
+		if meta.IsStatusConditionTrue(t.Status.Conditions, korifiv1alpha1.TaskSucceededConditionType) ||
+			meta.IsStatusConditionTrue(t.Status.Conditions, korifiv1alpha1.TaskFailedConditionType) {
+			continue
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func desiredInstances(process *korifiv1alpha1.CFProcess) int32 {
+	if process.Spec.DesiredInstances == nil {
+		return 0
+	}
+
+	return *process.Spec.DesiredInstances
+}
+
+func exceeds(limit *int64, value int64) bool {
+	return limit != nil && value > *limit
+}
+
+// check interface is implemented correctly
+var _ webhooks.QuotaValidator = QuotaValidator{}
@@ -0,0 +1,672 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("QuotaValidator", func() {
+	const (
+		rootNamespace = "cf"
+		orgGUID       = "org-guid"
+		spaceGUID     = "space-guid"
+		otherSpace    = "other-space-guid"
+	)
+
+	var (
+		ctx            context.Context
+		fakeClient     *fake.Client
+		objects        []client.Object
+		quotaValidator *validation.QuotaValidator
+		validationErr  error
+
+		cfOrg      *korifiv1alpha1.CFOrg
+		cfSpace    *korifiv1alpha1.CFSpace
+		orgQuota   *korifiv1alpha1.CFOrgQuota
+		spaceQuota *korifiv1alpha1.CFSpaceQuota
+	)
+
+	app := func(namespace, name string, state korifiv1alpha1.AppState) *korifiv1alpha1.CFApp {
+		return &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       korifiv1alpha1.CFAppSpec{DesiredState: state},
+		}
+	}
+
+	// processes belong to the started "<namespace>-app" app unless changed
+	process := func(namespace, name string, memoryMB int64, instances int32) *korifiv1alpha1.CFProcess {
+		return &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           corev1.LocalObjectReference{Name: namespace + "-app"},
+				MemoryMB:         memoryMB,
+				DesiredInstances: tools.PtrTo(instances),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = new(fake.Client)
+
+		cfOrg = &korifiv1alpha1.CFOrg{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: orgGUID},
+			Spec:       korifiv1alpha1.CFOrgSpec{DisplayName: "my-org", QuotaGUID: "org-quota-guid"},
+		}
+		cfSpace = &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{Namespace: orgGUID, Name: spaceGUID},
+			Spec:       korifiv1alpha1.CFSpaceSpec{DisplayName: "my-space", QuotaGUID: "space-quota-guid"},
+		}
+		orgQuota = &korifiv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: "org-quota-guid"},
+		}
+		spaceQuota = &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: "space-quota-guid"},
+		}
+
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: spaceGUID,
+				Labels: map[string]string{
+					korifiv1alpha1.OrgGUIDKey:   orgGUID,
+					korifiv1alpha1.SpaceGUIDKey: spaceGUID,
+				},
+			}},
+			cfOrg,
+			cfSpace,
+			&korifiv1alpha1.CFSpace{ObjectMeta: metav1.ObjectMeta{Namespace: orgGUID, Name: otherSpace}},
+			orgQuota,
+			spaceQuota,
+			app(spaceGUID, spaceGUID+"-app", korifiv1alpha1.StartedState),
+			app(otherSpace, otherSpace+"-app", korifiv1alpha1.StartedState),
+		}
+
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			for _, o := range objects {
+				if reflect.TypeOf(o) == reflect.TypeOf(obj) && o.GetNamespace() == key.Namespace && o.GetName() == key.Name {
+					reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(o).Elem())
+					return nil
+				}
+			}
+
+			return k8serrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			listOpts := &client.ListOptions{}
+			listOpts.ApplyOptions(opts)
+
+			items := []client.Object{}
+			for _, o := range objects {
+				if o.GetNamespace() == listOpts.Namespace {
+					items = append(items, o)
+				}
+			}
+
+			return setListItems(list, items)
+		}
+
+		quotaValidator = validation.NewQuotaValidator(fakeClient, rootNamespace)
+	})
+
+	Describe("ValidateProcess", func() {
+		var (
+			oldProcess *korifiv1alpha1.CFProcess
+			newProcess *korifiv1alpha1.CFProcess
+		)
+
+		BeforeEach(func() {
+			oldProcess = nil
+			newProcess = process(spaceGUID, "my-process", 256, 2)
+
+			objects = append(objects,
+				process(spaceGUID, "another-process", 512, 1),
+				process(otherSpace, "other-space-process", 1024, 1),
+			)
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateProcess(ctx, oldProcess, newProcess)
+		})
+
+		It("succeeds when no limits are set", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the process exceeds the space per process memory limit", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.PerProcessMemoryInMB = tools.PtrTo[int64](128)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.SpaceInstanceMemoryQuotaExceededErrorType,
+					Equal(validation.SpaceInstanceMemoryQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the process exceeds the org per process memory limit", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Apps.PerProcessMemoryInMB = tools.PtrTo[int64](128)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.OrgInstanceMemoryQuotaExceededErrorType,
+					Equal(validation.OrgInstanceMemoryQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the space total memory limit is not exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](1024)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the space total memory limit is exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](1023)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.SpaceMemoryQuotaExceededErrorType,
+					Equal(validation.SpaceMemoryQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the org total memory limit is exceeded by the processes of all spaces", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](2047)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.OrgMemoryQuotaExceededErrorType,
+					Equal(validation.OrgMemoryQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the space total instances limit is exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.TotalInstances = tools.PtrTo[int64](2)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.SpaceInstanceQuotaExceededErrorType,
+					Equal(validation.SpaceInstanceQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the org total instances limit is exceeded", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Apps.TotalInstances = tools.PtrTo[int64](3)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.OrgInstanceQuotaExceededErrorType,
+					Equal(validation.OrgInstanceQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the log rate limit is set", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](1024)
+			})
+
+			It("fails as processes have an unlimited log rate", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.LogRateQuotaExceededErrorType,
+					Equal("log_rate_limit cannot be unlimited in organization 'my-org'."),
+				))
+			})
+		})
+
+		When("other apps are stopped", func() {
+			BeforeEach(func() {
+				objects = append(objects, app(otherSpace, "stopped-app", korifiv1alpha1.StoppedState))
+				stoppedProcess := process(otherSpace, "stopped-app-process", 4096, 4)
+				stoppedProcess.Spec.AppRef.Name = "stopped-app"
+				objects = append(objects, stoppedProcess)
+
+				orgQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](2048)
+				orgQuota.Spec.Apps.TotalInstances = tools.PtrTo[int64](4)
+			})
+
+			It("does not count their processes towards the usage", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the app of the process is stopped", func() {
+			BeforeEach(func() {
+				newProcess.Spec.AppRef.Name = "stopped-app"
+				objects = append(objects, app(spaceGUID, "stopped-app", korifiv1alpha1.StoppedState))
+				spaceQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](0)
+				orgQuota.Spec.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](1024)
+			})
+
+			It("does not validate the total usage", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+
+			When("the process exceeds the per process memory limit", func() {
+				BeforeEach(func() {
+					spaceQuota.Spec.Apps.PerProcessMemoryInMB = tools.PtrTo[int64](128)
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.SpaceInstanceMemoryQuotaExceededErrorType,
+						Equal(validation.SpaceInstanceMemoryQuotaExceededErrorMessage),
+					))
+				})
+			})
+		})
+
+		When("the process is being updated", func() {
+			BeforeEach(func() {
+				oldProcess = process(spaceGUID, "my-process", 256, 1)
+				objects = append(objects, oldProcess)
+				spaceQuota.Spec.Apps.TotalInstances = tools.PtrTo[int64](3)
+			})
+
+			It("does not count the current process instances towards the usage", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+
+			When("the process is scaled down while the quota is exceeded", func() {
+				BeforeEach(func() {
+					oldProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+					spaceQuota.Spec.Apps.TotalInstances = tools.PtrTo[int64](1)
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the space has no quota applied", func() {
+			BeforeEach(func() {
+				cfSpace.Spec.QuotaGUID = ""
+				spaceQuota.Spec.Apps.TotalInstances = tools.PtrTo[int64](0)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the applied quota does not exist", func() {
+			BeforeEach(func() {
+				cfOrg.Spec.QuotaGUID = "i-do-not-exist"
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the namespace does not belong to a space", func() {
+			BeforeEach(func() {
+				newProcess.Namespace = "not-a-space"
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("listing the processes fails", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](1024)
+				fakeClient.ListReturns(errors.New("list-error"))
+				fakeClient.ListStub = nil
+			})
+
+			It("returns an unknown error", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.UnknownErrorType,
+					Equal(validation.UnknownErrorMessage),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateAppStart", func() {
+		var (
+			oldApp *korifiv1alpha1.CFApp
+			newApp *korifiv1alpha1.CFApp
+		)
+
+		BeforeEach(func() {
+			oldApp = app(spaceGUID, "my-app", korifiv1alpha1.StoppedState)
+			newApp = app(spaceGUID, "my-app", korifiv1alpha1.StartedState)
+
+			webProcess := process(spaceGUID, "my-app-web", 256, 2)
+			webProcess.Spec.AppRef.Name = "my-app"
+			workerProcess := process(spaceGUID, "my-app-worker", 128, 1)
+			workerProcess.Spec.AppRef.Name = "my-app"
+
+			objects = append(objects,
+				oldApp,
+				webProcess,
+				workerProcess,
+				process(spaceGUID, "another-process", 512, 1),
+				process(otherSpace, "other-space-process", 1024, 1),
+			)
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateAppStart(ctx, oldApp, newApp)
+		})
+
+		It("succeeds when no limits are set", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the space total memory limit is not exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](1152)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the space total memory limit is exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](1151)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.SpaceMemoryQuotaExceededErrorType,
+					Equal(validation.SpaceMemoryQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the org total instances limit is exceeded", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Apps.TotalInstances = tools.PtrTo[int64](4)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.OrgInstanceQuotaExceededErrorType,
+					Equal(validation.OrgInstanceQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("a process exceeds the per process memory limit", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.PerProcessMemoryInMB = tools.PtrTo[int64](200)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.SpaceInstanceMemoryQuotaExceededErrorType,
+					Equal(validation.SpaceInstanceMemoryQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the log rate limit is set", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](1024)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.LogRateQuotaExceededErrorType,
+					Equal("log_rate_limit cannot be unlimited in space 'my-space'."),
+				))
+			})
+		})
+
+		When("the app is already started", func() {
+			BeforeEach(func() {
+				oldApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				spaceQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](0)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the app is being stopped", func() {
+			BeforeEach(func() {
+				newApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+				spaceQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](0)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the app is created started", func() {
+			BeforeEach(func() {
+				oldApp = nil
+				spaceQuota.Spec.Apps.TotalInstances = tools.PtrTo[int64](3)
+			})
+
+			It("validates the quota", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.SpaceInstanceQuotaExceededErrorType,
+					Equal(validation.SpaceInstanceQuotaExceededErrorMessage),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateRouteCreate", func() {
+		BeforeEach(func() {
+			objects = append(objects,
+				&korifiv1alpha1.CFRoute{ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "route-1"}},
+				&korifiv1alpha1.CFRoute{ObjectMeta: metav1.ObjectMeta{Namespace: otherSpace, Name: "route-2"}},
+			)
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateRouteCreate(ctx, &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "new-route"},
+			})
+		})
+
+		It("succeeds when no limits are set", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the space routes limit is exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Routes.TotalRoutes = tools.PtrTo[int64](1)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.RouteQuotaExceededErrorType,
+					Equal("Routes quota exceeded for space 'my-space'."),
+				))
+			})
+		})
+
+		When("the org routes limit is exceeded", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Routes.TotalRoutes = tools.PtrTo[int64](2)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.RouteQuotaExceededErrorType,
+					Equal("Routes quota exceeded for organization 'my-org'."),
+				))
+			})
+		})
+
+		When("the org routes limit is not exceeded", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Routes.TotalRoutes = tools.PtrTo[int64](3)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ValidateServiceInstanceCreate", func() {
+		BeforeEach(func() {
+			objects = append(objects,
+				&korifiv1alpha1.CFServiceInstance{ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "instance-1"}},
+				&korifiv1alpha1.CFServiceInstance{ObjectMeta: metav1.ObjectMeta{Namespace: otherSpace, Name: "instance-2"}},
+			)
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateServiceInstanceCreate(ctx, &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "new-instance"},
+			})
+		})
+
+		It("succeeds when no limits are set", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the space service instances limit is exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Services.TotalServiceInstances = tools.PtrTo[int64](1)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.SpaceServiceQuotaExceededErrorType,
+					Equal(validation.SpaceServiceQuotaExceededErrorMessage),
+				))
+			})
+		})
+
+		When("the org service instances limit is exceeded", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Services.TotalServiceInstances = tools.PtrTo[int64](2)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.OrgServiceQuotaExceededErrorType,
+					Equal(validation.OrgServiceQuotaExceededErrorMessage),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateTaskCreate", func() {
+		task := func(name, appGUID string, conditionType string) *korifiv1alpha1.CFTask {
+			t := &korifiv1alpha1.CFTask{
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: name},
+				Spec: korifiv1alpha1.CFTaskSpec{
+					AppRef: corev1.LocalObjectReference{Name: appGUID},
+				},
+			}
+			if conditionType != "" {
+				meta.SetStatusCondition(&t.Status.Conditions, metav1.Condition{
+					Type:   conditionType,
+					Status: metav1.ConditionTrue,
+					Reason: "Done",
+				})
+			}
+
+			return t
+		}
+
+		BeforeEach(func() {
+			objects = append(objects,
+				task("running-task", "my-app", ""),
+				task("succeeded-task", "my-app", korifiv1alpha1.TaskSucceededConditionType),
+				task("failed-task", "my-app", korifiv1alpha1.TaskFailedConditionType),
+				task("other-app-task", "other-app", ""),
+			)
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateTaskCreate(ctx, task("new-task", "my-app", ""))
+		})
+
+		It("succeeds when no limits are set", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the per app tasks limit is not exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.PerAppTasks = tools.PtrTo[int64](2)
+			})
+
+			It("only counts the pending tasks of the app", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the per app tasks limit is exceeded", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Apps.PerAppTasks = tools.PtrTo[int64](1)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.TaskQuotaExceededErrorType,
+					ContainSubstring("organization quota"),
+				))
+			})
+		})
+	})
+})
+
+func setListItems(list client.ObjectList, objects []client.Object) error {
+	items := reflect.ValueOf(list).Elem().FieldByName("Items")
+	itemType := items.Type().Elem()
+
+	matching := reflect.MakeSlice(items.Type(), 0, len(objects))
+	for _, o := range objects {
+		if reflect.TypeOf(o).Elem() == itemType {
+			matching = reflect.Append(matching, reflect.ValueOf(o).Elem())
+		}
+	}
+	items.Set(matching)
+
+	return nil
+}
@@ -42,9 +42,12 @@ func (r *AppRevWebhook) Handle(ctx context.Context, req admission.Request) admis
 	}
 
 	if cfApp.Spec.DesiredState == korifiv1alpha1.StoppedState && oldCFApp.Spec.DesiredState == korifiv1alpha1.StartedState {
-		newAppRev := bumpAppRev(cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey])
+		newAppRev := bumpAppRev(cfApp.LatestRevision())
 		cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = newAppRev
 		cfApp.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = newAppRev
+		// stopping the app removes all its workloads, so there is no stable
+		// revision to keep running beside the canary anymore
+		cfApp.Spec.Canary = nil
 	}
 
 	marshalled, err := json.Marshal(cfApp)
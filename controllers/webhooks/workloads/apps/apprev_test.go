@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -63,6 +64,35 @@ var _ = Describe("ApprevWebhook", func() {
 			Expect(app.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey]).To(Equal("6"))
 		})
 
+		When("a canary deployment is in progress", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, app, func() {
+					app.Spec.Canary = &korifiv1alpha1.CanaryDeployment{
+						StableRevision: "4",
+						StableDropletRef: corev1.LocalObjectReference{
+							Name: "stable-droplet",
+						},
+					}
+				})).To(Succeed())
+			})
+
+			It("ends the canary deployment", func() {
+				Expect(app.Spec.Canary).To(BeNil())
+			})
+		})
+
+		When("the last deployment has been canceled", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, app, func() {
+					app.Annotations[korifiv1alpha1.CFAppCanceledRevisionKey] = "7"
+				})).To(Succeed())
+			})
+
+			It("does not reuse the app rev of the canceled deployment", func() {
+				Expect(app.Annotations[korifiv1alpha1.CFAppRevisionKey]).To(Equal("8"))
+			})
+		})
+
 		When("the app rev is not a number", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, app, func() {
@@ -9,6 +9,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/coordination"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
@@ -33,8 +34,9 @@ var (
 	adminClient        client.Client
 	adminNonSyncClient client.Client
 
-	ctx           context.Context
-	testNamespace string
+	ctx            context.Context
+	testNamespace  string
+	quotaValidator *fake.QuotaValidator
 )
 
 func TestWorkloadsWebhooks(t *testing.T) {
@@ -83,13 +85,15 @@ var _ = BeforeSuite(func() {
 
 	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
 	appNameDuplicateValidator := validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType))
-	Expect(apps.NewValidator(appNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	quotaValidator = new(fake.QuotaValidator)
+	Expect(apps.NewValidator(appNameDuplicateValidator, quotaValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
 
 var _ = BeforeEach(func() {
 	ctx = context.Background()
+	quotaValidator.ValidateAppStartReturns(nil)
 
 	testNamespace = uuid.NewString()
 
@@ -27,13 +27,15 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 
 type Validator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(duplicateValidator webhooks.NameValidator, quotaValidator webhooks.QuotaValidator) *Validator {
 	return &Validator{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -50,7 +52,11 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFApp but got a %T", obj))
 	}
 
-	return nil, v.duplicateValidator.ValidateCreate(ctx, cfapplog, app.Namespace, app)
+	if err := v.duplicateValidator.ValidateCreate(ctx, cfapplog, app.Namespace, app); err != nil {
+		return nil, err
+	}
+
+	return nil, v.quotaValidator.ValidateAppStart(ctx, nil, app)
 }
 
 func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
@@ -75,7 +81,11 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		}.ExportJSONError()
 	}
 
-	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfapplog, app.Namespace, oldApp, app)
+	if err := v.duplicateValidator.ValidateUpdate(ctx, cfapplog, app.Namespace, oldApp, app); err != nil {
+		return nil, err
+	}
+
+	return nil, v.quotaValidator.ValidateAppStart(ctx, oldApp, app)
 }
 
 func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -228,6 +229,34 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 				Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(app), app)).To(Succeed())
 				Expect(app.Spec.DesiredState).To(Equal(korifiv1alpha1.StartedState))
 			})
+
+			It("validates the app start against the quotas", func() {
+				Expect(quotaValidator.ValidateAppStartCallCount()).NotTo(BeZero())
+				_, oldApp, newApp := quotaValidator.ValidateAppStartArgsForCall(quotaValidator.ValidateAppStartCallCount() - 1)
+				Expect(oldApp.Spec.DesiredState).To(Equal(korifiv1alpha1.StoppedState))
+				Expect(newApp.Spec.DesiredState).To(Equal(korifiv1alpha1.StartedState))
+			})
+
+			When("starting the app exceeds the quota", func() {
+				BeforeEach(func() {
+					quotaValidator.ValidateAppStartStub = func(_ context.Context, _, app *korifiv1alpha1.CFApp) error {
+						if app.Spec.DesiredState != korifiv1alpha1.StartedState {
+							return nil
+						}
+
+						return validation.ValidationError{
+							Type:    validation.SpaceMemoryQuotaExceededErrorType,
+							Message: "too much memory",
+						}.ExportJSONError()
+					}
+				})
+
+				It("should fail", func() {
+					validationErr, ok := validation.WebhookErrorToValidationError(updateErr)
+					Expect(ok).To(BeTrue())
+					Expect(validationErr.Type).To(Equal(validation.SpaceMemoryQuotaExceededErrorType))
+				})
+			})
 		})
 
 		Describe("changing the lifecycle type", func() {
@@ -0,0 +1,165 @@
+package processes_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFProcessValidatingWebhook", func() {
+	var (
+		ctx               context.Context
+		quotaValidator    *fake.QuotaValidator
+		process           *korifiv1alpha1.CFProcess
+		validatingWebhook *processes.Validator
+		retErr            error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		process = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				ProcessType:      "web",
+				MemoryMB:         256,
+				DesiredInstances: tools.PtrTo[int32](2),
+			},
+		}
+
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = processes.NewValidator(quotaValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, process)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the process against the quotas", func() {
+			Expect(quotaValidator.ValidateProcessCallCount()).To(Equal(1))
+			actualContext, actualOldProcess, actualProcess := quotaValidator.ValidateProcessArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualOldProcess).To(BeNil())
+			Expect(actualProcess).To(Equal(process))
+		})
+
+		When("the quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateProcessReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+		})
+
+		When("the process has sidecars", func() {
+			BeforeEach(func() {
+				process.Spec.Sidecars = []korifiv1alpha1.Sidecar{
+					{Name: "sidecar-1", MemoryMB: 100},
+					{Name: "sidecar-2", MemoryMB: 100},
+					{Name: "sidecar-3"},
+				}
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the sidecars use all the process memory", func() {
+				BeforeEach(func() {
+					process.Spec.Sidecars[1].MemoryMB = 156
+				})
+
+				It("denies the request", func() {
+					validationErr, ok := validation.WebhookErrorToValidationError(retErr)
+					Expect(ok).To(BeTrue())
+					Expect(validationErr.Type).To(Equal(processes.InsufficientMemoryErrorType))
+					Expect(validationErr.Message).To(Equal("Process memory (256MB) must be greater than the total memory of its sidecars (256MB)"))
+				})
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedProcess *korifiv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			updatedProcess = process.DeepCopy()
+			updatedProcess.Spec.DesiredInstances = tools.PtrTo[int32](5)
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, process, updatedProcess)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the process against the quotas", func() {
+			Expect(quotaValidator.ValidateProcessCallCount()).To(Equal(1))
+			actualContext, actualOldProcess, actualProcess := quotaValidator.ValidateProcessArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualOldProcess).To(Equal(process))
+			Expect(actualProcess).To(Equal(updatedProcess))
+		})
+
+		When("the quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateProcessReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+		})
+
+		When("the process is scaled below the memory of its sidecars", func() {
+			BeforeEach(func() {
+				updatedProcess.Spec.Sidecars = []korifiv1alpha1.Sidecar{{Name: "sidecar", MemoryMB: 128}}
+				updatedProcess.Spec.MemoryMB = 64
+			})
+
+			It("denies the request", func() {
+				validationErr, ok := validation.WebhookErrorToValidationError(retErr)
+				Expect(ok).To(BeTrue())
+				Expect(validationErr.Type).To(Equal(processes.InsufficientMemoryErrorType))
+				Expect(validationErr.Message).To(Equal("Process memory (64MB) must be greater than the total memory of its sidecars (128MB)"))
+			})
+
+			It("does not validate the quotas", func() {
+				Expect(quotaValidator.ValidateProcessCallCount()).To(BeZero())
+			})
+		})
+
+		When("the process is being deleted", func() {
+			BeforeEach(func() {
+				updatedProcess.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("does not validate the quotas", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(quotaValidator.ValidateProcessCallCount()).To(BeZero())
+			})
+		})
+	})
+})
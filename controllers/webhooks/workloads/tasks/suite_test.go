@@ -9,6 +9,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
@@ -32,8 +33,9 @@ var (
 	adminClient        client.Client
 	adminNonSyncClient client.Client
 
-	ctx           context.Context
-	testNamespace string
+	ctx            context.Context
+	testNamespace  string
+	quotaValidator *fake.QuotaValidator
 )
 
 func TestWorkloadsWebhooks(t *testing.T) {
@@ -82,13 +84,15 @@ var _ = BeforeSuite(func() {
 		MemoryMB:    500,
 		DiskQuotaMB: 512,
 	}).SetupWebhookWithManager(k8sManager)).To(Succeed())
-	Expect(tasks.NewValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	quotaValidator = new(fake.QuotaValidator)
+	Expect(tasks.NewValidator(quotaValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
 
 var _ = BeforeEach(func() {
 	ctx = context.Background()
+	quotaValidator.ValidateTaskCreateReturns(nil)
 
 	testNamespace = uuid.NewString()
 
@@ -41,12 +41,16 @@ var cftasklog = logf.Log.WithName("cftask-resource")
 
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cftask,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cftasks;cftasks/status,verbs=create;update,versions=v1alpha1,name=vcftask.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
-type Validator struct{}
+type Validator struct {
+	quotaValidator webhooks.QuotaValidator
+}
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator() *Validator {
-	return &Validator{}
+func NewValidator(quotaValidator webhooks.QuotaValidator) *Validator {
+	return &Validator{
+		quotaValidator: quotaValidator,
+	}
 }
 
 func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -80,7 +84,7 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		}.ExportJSONError()
 	}
 
-	return nil, nil
+	return nil, v.quotaValidator.ValidateTaskCreate(ctx, task)
 }
 
 func (v *Validator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (admission.Warnings, error) {
@@ -60,6 +60,23 @@ var _ = Describe("CFTask Validator", func() {
 			})
 		})
 
+		When("the task quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateTaskCreateReturns(validation.ValidationError{
+					Type:    validation.TaskQuotaExceededErrorType,
+					Message: "too many tasks",
+				}.ExportJSONError())
+			})
+
+			It("returns a validation error", func() {
+				validationErr, ok := validation.WebhookErrorToValidationError(creationErr)
+				Expect(ok).To(BeTrue())
+
+				Expect(validationErr.Type).To(Equal(validation.TaskQuotaExceededErrorType))
+				Expect(validationErr.Message).To(Equal("too many tasks"))
+			})
+		})
+
 		When("the app reference is not set", func() {
 			BeforeEach(func() {
 				cfTask.Spec.AppRef = corev1.LocalObjectReference{}
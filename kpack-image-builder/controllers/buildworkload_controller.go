@@ -60,6 +60,9 @@ const (
 	ImageGenerationKey          = "korifi.cloudfoundry.org/kpack-image-generation"
 	KpackReconcilerName         = "kpack-image-builder"
 	buildpackBuildMetadataLabel = "io.buildpacks.build.metadata"
+	// Buildpacks declare sidecars by adding this label to the app image via
+	// the [[labels]] table of their launch.toml
+	buildpackSidecarsLabel = "korifi.cloudfoundry.org/sidecars"
 )
 
 //counterfeiter:generate -o fake -fake-name ImageConfigGetter . ImageConfigGetter
@@ -820,6 +823,11 @@ func (r *BuildWorkloadReconciler) generateDropletStatus(ctx context.Context, kpa
 		})
 	}
 
+	sidecars, err := extractSidecars(config.Labels)
+	if err != nil {
+		return nil, err
+	}
+
 	return &korifiv1alpha1.BuildDropletStatus{
 		Registry: korifiv1alpha1.Registry{
 			Image:            imageRef,
@@ -830,6 +838,7 @@ func (r *BuildWorkloadReconciler) generateDropletStatus(ctx context.Context, kpa
 
 		ProcessTypes: processTypes,
 		Ports:        config.ExposedPorts,
+		Sidecars:     sidecars,
 	}, nil
 }
 
@@ -843,6 +852,37 @@ type process struct {
 	Args    []string `json:"args"`
 }
 
+type sidecar struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	Memory       int64    `json:"memory"`
+}
+
+func extractSidecars(imageLabels map[string]string) ([]korifiv1alpha1.DropletSidecar, error) {
+	sidecarsLabel, ok := imageLabels[buildpackSidecarsLabel]
+	if !ok {
+		return nil, nil
+	}
+
+	var sidecars []sidecar
+	if err := json.Unmarshal([]byte(sidecarsLabel), &sidecars); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sidecars: %w", err)
+	}
+
+	dropletSidecars := []korifiv1alpha1.DropletSidecar{}
+	for _, s := range sidecars {
+		dropletSidecars = append(dropletSidecars, korifiv1alpha1.DropletSidecar{
+			Name:         s.Name,
+			Command:      s.Command,
+			ProcessTypes: s.ProcessTypes,
+			MemoryMB:     s.Memory,
+		})
+	}
+
+	return dropletSidecars, nil
+}
+
 func extractFullCommand(process process) string {
 	cmdString := process.Command
 	for _, a := range process.Args {
@@ -134,6 +134,9 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 						{"type": "db", "command": "my-command2"}
 					]
 				}`,
+				"korifi.cloudfoundry.org/sidecars": `[
+					{"name": "apm-agent", "command": "run-agent", "process_types": ["web", "db"], "memory": 64}
+				]`,
 			},
 			ExposedPorts: []int32{8080, 8443},
 		}, nil)
@@ -667,6 +670,9 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 					{Type: "db", Command: "my-command2"},
 				}))
 				Expect(updatedBuildWorkload.Status.Droplet.Ports).To(Equal([]int32{8080, 8443}))
+				Expect(updatedBuildWorkload.Status.Droplet.Sidecars).To(Equal([]korifiv1alpha1.DropletSidecar{
+					{Name: "apm-agent", Command: "run-agent", ProcessTypes: []string{"web", "db"}, MemoryMB: 64},
+				}))
 			})
 
 			When("there are two kpack.Builds for the kpack.Image", func() {
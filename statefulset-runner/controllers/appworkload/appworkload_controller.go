@@ -61,6 +61,7 @@ const (
 	LabelProcessType     = "korifi.cloudfoundry.org/process-type"
 
 	ApplicationContainerName = "application"
+	SidecarContainerPrefix   = "sidecar-"
 	ServiceAccountName       = "korifi-app"
 
 	LivenessFailureThreshold  = 4
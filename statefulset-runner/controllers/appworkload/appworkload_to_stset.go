@@ -18,6 +18,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const bindingRootPath = "/bindings"
@@ -107,6 +109,14 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		return envs[i].Name < envs[j].Name
 	})
 
+	volumeMounts := slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
+		return corev1.VolumeMount{
+			Name:      s.Name,
+			ReadOnly:  true,
+			MountPath: filepath.Join(bindingRootPath, s.Name),
+		}
+	}))
+
 	containers := []corev1.Container{
 		{
 			Name:            ApplicationContainerName,
@@ -117,28 +127,29 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 			Ports: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Ports), func(port int32) corev1.ContainerPort {
 				return corev1.ContainerPort{ContainerPort: port}
 			})),
-			SecurityContext: &corev1.SecurityContext{
-				AllowPrivilegeEscalation: tools.PtrTo(false),
-				Capabilities: &corev1.Capabilities{
-					Drop: []corev1.Capability{"ALL"},
-				},
-				SeccompProfile: &corev1.SeccompProfile{
-					Type: corev1.SeccompProfileTypeRuntimeDefault,
-				},
-			},
-			Resources:     appWorkload.Spec.Resources,
-			StartupProbe:  appWorkload.Spec.StartupProbe,
-			LivenessProbe: appWorkload.Spec.LivenessProbe,
-			VolumeMounts: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
-				return corev1.VolumeMount{
-					Name:      s.Name,
-					ReadOnly:  true,
-					MountPath: filepath.Join(bindingRootPath, s.Name),
-				}
-			})),
+			SecurityContext: containerSecurityContext(),
+			Resources:       appWorkload.Spec.Resources,
+			StartupProbe:    appWorkload.Spec.StartupProbe,
+			LivenessProbe:   appWorkload.Spec.LivenessProbe,
+			VolumeMounts:    volumeMounts,
 		},
 	}
 
+	// Sidecars run the same image as the application, sharing its
+	// environment and service bindings
+	for i, sidecar := range appWorkload.Spec.Sidecars {
+		containers = append(containers, corev1.Container{
+			Name:            sidecarContainerName(sidecar.Name, i),
+			Image:           appWorkload.Spec.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         sidecar.Command,
+			Env:             envs,
+			SecurityContext: containerSecurityContext(),
+			Resources:       sidecar.Resources,
+			VolumeMounts:    volumeMounts,
+		})
+	}
+
 	statefulsetName, err := getStatefulSetName(appWorkload)
 	if err != nil {
 		return nil, err
@@ -179,6 +190,18 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		},
 	}
 
+	// MaxUnavailable is ignored unless the alpha MaxUnavailableStatefulSet
+	// feature gate is enabled, in which case the runner is started with
+	// --max-in-flight so that the API accepts max_in_flight greater than 1
+	if appWorkload.Spec.MaxInFlight != nil {
+		statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+				MaxUnavailable: tools.PtrTo(intstr.FromInt32(*appWorkload.Spec.MaxInFlight)),
+			},
+		}
+	}
+
 	statefulSet.Spec.Template.Spec.AutomountServiceAccountToken = tools.PtrTo(false)
 	statefulSet.Spec.Selector = statefulSetLabelSelector(appWorkload)
 
@@ -226,6 +249,30 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 	return statefulSet, nil
 }
 
+func containerSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: tools.PtrTo(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// sidecarContainerName prefixes sidecar containers so that they never clash
+// with the application container. Sidecar names are free text, so names that
+// are not valid container names fall back to the position of the sidecar.
+func sidecarContainerName(sidecarName string, index int) string {
+	name := strings.ReplaceAll(strings.ToLower(SidecarContainerPrefix+sidecarName), "_", "-")
+	if len(validation.IsDNS1123Label(name)) == 0 {
+		return name
+	}
+
+	return fmt.Sprintf("%s%d", SidecarContainerPrefix, index)
+}
+
 func sanitizeName(name, fallback string) string {
 	const sanitizedNameMaxLen = 40
 	return sanitizeNameWithMaxStringLen(name, fallback, sanitizedNameMaxLen)
@@ -158,6 +158,25 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(string(statefulSet.Spec.PodManagementPolicy)).To(Equal("Parallel"))
 	})
 
+	It("should use the default update strategy", func() {
+		Expect(statefulSet.Spec.UpdateStrategy).To(BeZero())
+	})
+
+	When("the app workload specifies max in flight", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.MaxInFlight = tools.PtrTo[int32](3)
+		})
+
+		It("updates that many instances at once", func() {
+			Expect(statefulSet.Spec.UpdateStrategy).To(Equal(appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					MaxUnavailable: tools.PtrTo(intstr.FromInt32(3)),
+				},
+			}))
+		})
+	})
+
 	It("should deny privilegeEscalation", func() {
 		Expect(statefulSet.Spec.Template.Spec.Containers[0].SecurityContext.AllowPrivilegeEscalation).NotTo(BeNil())
 		Expect(*statefulSet.Spec.Template.Spec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
@@ -406,6 +425,63 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		})
 	})
 
+	It("should only have the application container", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(1))
+	})
+
+	When("the app workload has sidecars", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Env = []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+			appWorkload.Spec.Services = []korifiv1alpha1.ServiceBinding{{
+				Secret: "service-secret",
+				Name:   "binding-name",
+			}}
+			appWorkload.Spec.Sidecars = []korifiv1alpha1.AppWorkloadSidecar{
+				{
+					Name:    "APM_Agent",
+					Command: []string{"/cnb/lifecycle/launcher", "run-agent"},
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+				},
+				{
+					Name:    "config reloader",
+					Command: []string{"/cnb/lifecycle/launcher", "reload-config"},
+				},
+			}
+		})
+
+		It("adds a container for each sidecar", func() {
+			containers := statefulSet.Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(3))
+			Expect(containers[0].Name).To(Equal("application"))
+
+			Expect(containers[1].Name).To(Equal("sidecar-apm-agent"))
+			Expect(containers[1].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "run-agent"}))
+			Expect(containers[1].Resources.Limits).To(HaveKeyWithValue(corev1.ResourceMemory, resource.MustParse("64Mi")))
+
+			Expect(containers[2].Name).To(Equal("sidecar-1"))
+			Expect(containers[2].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "reload-config"}))
+		})
+
+		It("runs the sidecars like the application container", func() {
+			sidecar := statefulSet.Spec.Template.Spec.Containers[1]
+			Expect(sidecar.Image).To(Equal("gcr.io/foo/bar"))
+			Expect(sidecar.Env).To(Equal(statefulSet.Spec.Template.Spec.Containers[0].Env))
+			Expect(sidecar.VolumeMounts).To(Equal(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts))
+			Expect(sidecar.SecurityContext).To(Equal(statefulSet.Spec.Template.Spec.Containers[0].SecurityContext))
+		})
+
+		It("does not expose ports or set probes on the sidecars", func() {
+			sidecar := statefulSet.Spec.Template.Spec.Containers[1]
+			Expect(sidecar.Ports).To(BeEmpty())
+			Expect(sidecar.StartupProbe).To(BeNil())
+			Expect(sidecar.LivenessProbe).To(BeNil())
+		})
+	})
+
 	It("should produce a stable statefulset regardless of labels iteration order", func() {
 		for i := 0; i < 100; i++ {
 			ss, err := converter.Convert(appWorkload)
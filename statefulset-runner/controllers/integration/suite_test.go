@@ -97,6 +97,7 @@ var _ = BeforeEach(func() {
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("statefulset-runner").WithName("RunnerInfo"),
+		false,
 	)
 	err = runnerInfoReconciler.SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
@@ -33,20 +33,23 @@ import (
 
 // RunnerInfoReconciler reconciles a RunnerInfo object
 type RunnerInfoReconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient   client.Client
+	scheme      *runtime.Scheme
+	log         logr.Logger
+	maxInFlight bool
 }
 
 func NewRunnerInfoReconciler(
 	c client.Client,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	maxInFlight bool,
 ) *k8s.PatchingReconciler[korifiv1alpha1.RunnerInfo] {
 	runnerInfoReconciler := RunnerInfoReconciler{
-		k8sClient: c,
-		scheme:    scheme,
-		log:       log,
+		k8sClient:   c,
+		scheme:      scheme,
+		log:         log,
+		maxInFlight: maxInFlight,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.RunnerInfo](log, c, &runnerInfoReconciler)
 }
@@ -81,6 +84,7 @@ func (r *RunnerInfoReconciler) ReconcileResource(ctx context.Context, runnerInfo
 
 	runnerInfo.Status.Capabilities = korifiv1alpha1.RunnerInfoCapabilities{
 		RollingDeploy: true,
+		MaxInFlight:   r.maxInFlight,
 	}
 
 	return ctrl.Result{}, nil
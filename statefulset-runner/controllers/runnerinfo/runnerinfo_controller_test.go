@@ -25,6 +25,7 @@ var _ = Describe("RunnerInfo Reconcile", func() {
 		reconcileErr    error
 		req             ctrl.Request
 		runnerInfo      *korifiv1alpha1.RunnerInfo
+		maxInFlight     bool
 	)
 
 	BeforeEach(func() {
@@ -51,14 +52,16 @@ var _ = Describe("RunnerInfo Reconcile", func() {
 			}
 		}
 
+		maxInFlight = false
+	})
+
+	JustBeforeEach(func() {
 		reconciler = runnerinfo.NewRunnerInfoReconciler(
 			fakeClient,
 			scheme.Scheme,
 			ctrl.Log.WithName("controllers").WithName("TestRunnerInfo"),
+			maxInFlight,
 		)
-	})
-
-	JustBeforeEach(func() {
 		reconcileResult, reconcileErr = reconciler.Reconcile(context.Background(), req)
 	})
 
@@ -77,6 +80,20 @@ var _ = Describe("RunnerInfo Reconcile", func() {
 		Expect(ok).To(BeTrue())
 		Expect(patchedRunnerInfo.Status.ObservedGeneration).To(Equal(patchedRunnerInfo.Generation))
 		Expect(patchedRunnerInfo.Status.Capabilities.RollingDeploy).To(BeTrue())
+		Expect(patchedRunnerInfo.Status.Capabilities.MaxInFlight).To(BeFalse())
+	})
+
+	When("replacing more than one instance at once is allowed", func() {
+		BeforeEach(func() {
+			maxInFlight = true
+		})
+
+		It("applies the Status.Capabilities.MaxInFlight field", func() {
+			_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedRunnerInfo, ok := object.(*korifiv1alpha1.RunnerInfo)
+			Expect(ok).To(BeTrue())
+			Expect(patchedRunnerInfo.Status.Capabilities.MaxInFlight).To(BeTrue())
+		})
 	})
 
 	When("the RunnerInfo is being deleted gracefully", func() {
@@ -41,6 +41,7 @@ func main() {
 		metricsAddr          string
 		enableLeaderElection bool
 		probeAddr            string
+		maxInFlight          bool
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
@@ -48,6 +49,9 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&maxInFlight, "max-in-flight", false,
+		"Allow deployments to replace more than one instance at once. "+
+			"Requires the MaxUnavailableStatefulSet feature gate to be enabled on the cluster.")
 	flag.Parse()
 
 	logger, _, err := tools.NewZapLogger(zapcore.InfoLevel)
@@ -78,7 +82,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := setupControllers(mgr); err != nil {
+	if err := setupControllers(mgr, maxInFlight); err != nil {
 		setupLog.Error(err, "unable to set up controllers")
 		os.Exit(1)
 	}
@@ -97,7 +101,7 @@ func main() {
 	}
 }
 
-func setupControllers(mgr manager.Manager) error {
+func setupControllers(mgr manager.Manager, maxInFlight bool) error {
 	controllersLog := ctrl.Log.WithName("controllers")
 	controllersClient := k8s.IgnoreEmptyPatches(mgr.GetClient())
 
@@ -116,6 +120,7 @@ func setupControllers(mgr manager.Manager) error {
 		controllersClient,
 		mgr.GetScheme(),
 		controllersLog,
+		maxInFlight,
 	).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create RunnerInfo controller: %w", err)
 	}
@@ -28,12 +28,21 @@ func NewServer() *BrokerServer {
 func (b *BrokerServer) WithResponse(pattern string, response map[string]any, statusCode int) *BrokerServer {
 	GinkgoHelper()
 
+	return b.WithResponseAndHeaders(pattern, response, statusCode, nil)
+}
+
+func (b *BrokerServer) WithResponseAndHeaders(pattern string, response map[string]any, statusCode int, headers map[string]string) *BrokerServer {
+	GinkgoHelper()
+
 	respBytes, err := json.Marshal(response)
 	Expect(err).NotTo(HaveOccurred())
 
 	return b.withHandler(pattern, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		Expect(r.Header).To(HaveKeyWithValue("Content-Type", ConsistOf("application/json")))
 		Expect(r.Header).To(HaveKeyWithValue("X-Broker-Api-Version", ConsistOf("2.17")))
+		for name, value := range headers {
+			w.Header().Set(name, value)
+		}
 		w.WriteHeader(statusCode)
 		_, err := w.Write(respBytes)
 		Expect(err).NotTo(HaveOccurred())
@@ -1,11 +1,15 @@
 package image
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/buildpacks/pack/pkg/archive"
@@ -13,6 +17,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -30,10 +35,17 @@ type Client struct {
 
 type Creds struct {
 	Namespace string
-	// At most one of SecretNames and ServiceAccountName should be set.
-	// If both unset, the fallback auth approach will be used.
+	// At most one of SecretNames, ServiceAccountName, Username and Anonymous
+	// should be set. If all unset, the fallback auth approach will be used.
 	SecretNames        []string
 	ServiceAccountName string
+	// Username and Password are basic auth credentials, e.g. supplied by a
+	// user for an image of their own
+	Username string
+	Password string
+	// Anonymous accesses the registry without any credentials, not even the
+	// ones available to the fallback auth approach
+	Anonymous bool
 }
 
 type Config struct {
@@ -82,12 +94,80 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 		return "", fmt.Errorf("error creating keychain: %w", err)
 	}
 
-	if err = remote.Write(ref, image, authOpt); err != nil {
+	return c.write(ref, image, authOpt, tags...)
+}
+
+// PushTarball pushes a container image tarball, e.g. the output of `docker
+// save`, to the repoRef repository
+func (c Client) PushTarball(ctx context.Context, creds Creds, repoRef string, tarballReader io.Reader, tags ...string) (string, error) {
+	tmpFile, err := os.CreateTemp(os.TempDir(), "imagetarball-%s")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp file for image: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	if _, err = io.Copy(tmpFile, tarballReader); err != nil {
+		return "", fmt.Errorf("failed to copy image tarball into temp file '%s' %w", tmpFile.Name(), err)
+	}
+
+	image, err := tarball.ImageFromPath(tmpFile.Name(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image tarball: %w", err)
+	}
+
+	ref, err := name.ParseReference(repoRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return "", fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	return c.write(ref, image, authOpt, tags...)
+}
+
+// Copy pushes the image referenced by srcImageRef to the dstRepoRef
+// repository without downloading its layers when both are on the same registry.
+// The source image is read with srcCreds and written with dstCreds.
+func (c Client) Copy(ctx context.Context, srcCreds Creds, srcImageRef string, dstCreds Creds, dstRepoRef string, tags ...string) (string, error) {
+	srcRef, err := name.ParseReference(srcImageRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing source image reference %s: %w", srcImageRef, err)
+	}
+
+	dstRef, err := name.ParseReference(dstRepoRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", dstRepoRef, err)
+	}
+
+	srcAuthOpt, err := c.authOpt(ctx, srcCreds)
+	if err != nil {
+		return "", fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	dstAuthOpt, err := c.authOpt(ctx, dstCreds)
+	if err != nil {
+		return "", fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	image, err := remote.Image(srcRef, srcAuthOpt)
+	if err != nil {
+		return "", fmt.Errorf("failed to get image: %w", err)
+	}
+
+	return c.write(dstRef, image, dstAuthOpt, tags...)
+}
+
+func (c Client) write(ref name.Reference, image v1.Image, authOpt remote.Option, tags ...string) (string, error) {
+	if err := remote.Write(ref, image, authOpt); err != nil {
 		return "", fmt.Errorf("failed to upload image: %w", err)
 	}
 
 	for _, tag := range tags {
-		err = remote.Tag(ref.Context().Tag(tag), image, authOpt)
+		err := remote.Tag(ref.Context().Tag(tag), image, authOpt)
 		if err != nil {
 			return "", fmt.Errorf("failed to tag image: %w", err)
 		}
@@ -106,6 +186,126 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 	return refWithDigest.Name(), nil
 }
 
+// Download returns the content of a source image created by Push as a zip archive
+func (c Client) Download(ctx context.Context, creds Creds, imageRef string) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image layers: %w", err)
+	}
+
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("expected source image %s to have a single layer, got %d", imageRef, len(layers))
+	}
+
+	layerReader, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image layer: %w", err)
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		defer layerReader.Close()
+		pipeWriter.CloseWithError(tarToZip(layerReader, pipeWriter))
+	}()
+
+	return pipeReader, nil
+}
+
+// DownloadTarball returns the image referenced by imageRef as a tarball that
+// can be pushed back with PushTarball or loaded with `docker load`
+func (c Client) DownloadTarball(ctx context.Context, creds Creds, imageRef string) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.CloseWithError(tarball.Write(ref, img, pipeWriter))
+	}()
+
+	return pipeReader, nil
+}
+
+func tarToZip(src io.Reader, dst io.Writer) error {
+	tarReader := tar.NewReader(src)
+	zipWriter := zip.NewWriter(dst)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read layer: %w", err)
+		}
+
+		entryName := strings.TrimPrefix(path.Clean(header.Name), "/")
+		if entryName == "" || entryName == "." {
+			continue
+		}
+
+		zipHeader, err := zip.FileInfoHeader(header.FileInfo())
+		if err != nil {
+			return fmt.Errorf("failed to create zip header for %s: %w", entryName, err)
+		}
+		zipHeader.Name = entryName
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			zipHeader.Name += "/"
+			zipHeader.Method = zip.Store
+			if _, err = zipWriter.CreateHeader(zipHeader); err != nil {
+				return fmt.Errorf("failed to write %s: %w", entryName, err)
+			}
+		case tar.TypeSymlink:
+			var entryWriter io.Writer
+			if entryWriter, err = zipWriter.CreateHeader(zipHeader); err != nil {
+				return fmt.Errorf("failed to write %s: %w", entryName, err)
+			}
+			if _, err = io.WriteString(entryWriter, header.Linkname); err != nil {
+				return fmt.Errorf("failed to write %s: %w", entryName, err)
+			}
+		case tar.TypeReg:
+			zipHeader.Method = zip.Deflate
+			var entryWriter io.Writer
+			if entryWriter, err = zipWriter.CreateHeader(zipHeader); err != nil {
+				return fmt.Errorf("failed to write %s: %w", entryName, err)
+			}
+			if _, err = io.Copy(entryWriter, tarReader); err != nil { // #nosec G110 -- the layer was created from an uploaded package
+				return fmt.Errorf("failed to write %s: %w", entryName, err)
+			}
+		}
+	}
+
+	return zipWriter.Close()
+}
+
 func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Config, error) {
 	ref, err := name.ParseReference(imageRef)
 	if err != nil {
@@ -259,6 +459,14 @@ func (c Client) deleteTag(ref name.Reference, tag string, authOpt remote.Option)
 }
 
 func (c Client) authOpt(ctx context.Context, creds Creds) (remote.Option, error) {
+	if creds.Anonymous {
+		return remote.WithAuth(authn.Anonymous), nil
+	}
+
+	if creds.Username != "" {
+		return remote.WithAuth(&authn.Basic{Username: creds.Username, Password: creds.Password}), nil
+	}
+
 	var keychain authn.Keychain
 	var err error
 
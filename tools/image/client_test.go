@@ -1,7 +1,11 @@
 package image_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"io"
 	"os"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/tests/helpers/oci"
 	"code.cloudfoundry.org/korifi/tools/image"
@@ -129,6 +133,207 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Download", func() {
+		var zipContents map[string]string
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var zipReader io.ReadCloser
+			zipReader, testErr = imgClient.Download(ctx, creds, imgRef)
+			if testErr != nil {
+				return
+			}
+			defer zipReader.Close()
+
+			content, err := io.ReadAll(zipReader)
+			Expect(err).NotTo(HaveOccurred())
+
+			archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			Expect(err).NotTo(HaveOccurred())
+
+			zipContents = map[string]string{}
+			for _, f := range archive.File {
+				if f.FileInfo().IsDir() {
+					continue
+				}
+				src, err := f.Open()
+				Expect(err).NotTo(HaveOccurred())
+				fileContent, err := io.ReadAll(src)
+				Expect(err).NotTo(HaveOccurred())
+				zipContents[f.Name] = string(fileContent)
+			}
+		})
+
+		It("returns the pushed files as a zip archive", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(zipContents).To(HaveLen(1))
+			Expect(zipContents).To(HaveKey("foo"))
+		})
+
+		When("the ref is invalid", func() {
+			BeforeEach(func() {
+				imgRef += "::ads"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing image reference")))
+			})
+		})
+
+		When("the image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/not-there")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		var (
+			srcCreds  image.Creds
+			copyRef   string
+			copiedRef string
+			srcDigest string
+		)
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+			srcDigest = strings.Split(imgRef, "@")[1]
+
+			srcCreds = creds
+			copyRef = containerRegistry.ImageRef("foo/copy")
+		})
+
+		JustBeforeEach(func() {
+			copiedRef, testErr = imgClient.Copy(ctx, srcCreds, imgRef, creds, copyRef, "jim")
+		})
+
+		It("copies the image to the destination repository", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(copiedRef).To(Equal(copyRef + "@" + srcDigest))
+
+			_, err := imgClient.Config(ctx, creds, copiedRef)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = imgClient.Config(ctx, creds, copyRef+":jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the source image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/not-there")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+
+		When("the source image is read with basic auth credentials", func() {
+			BeforeEach(func() {
+				srcCreds = image.Creds{Username: "user", Password: "password"}
+			})
+
+			It("copies the image", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(copiedRef).To(Equal(copyRef + "@" + srcDigest))
+			})
+		})
+
+		When("the source image is read anonymously", func() {
+			BeforeEach(func() {
+				srcCreds = image.Creds{Anonymous: true}
+			})
+
+			It("does not use the destination credentials to read it", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+
+		When("the destination ref is invalid", func() {
+			BeforeEach(func() {
+				copyRef += ":bar:baz"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+	})
+
+	Describe("DownloadTarball and PushTarball", func() {
+		var (
+			tarballRef  string
+			pushedRef   string
+			tarballData []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			tarballRef = containerRegistry.ImageRef("foo/tarball")
+		})
+
+		JustBeforeEach(func() {
+			var tarballReader io.ReadCloser
+			tarballReader, testErr = imgClient.DownloadTarball(ctx, creds, imgRef)
+			if testErr != nil {
+				return
+			}
+			defer tarballReader.Close()
+
+			var err error
+			tarballData, err = io.ReadAll(tarballReader)
+			Expect(err).NotTo(HaveOccurred())
+
+			pushedRef, testErr = imgClient.PushTarball(ctx, creds, tarballRef, bytes.NewReader(tarballData), "jim")
+		})
+
+		It("round-trips the image through a tarball", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(pushedRef).To(HavePrefix(tarballRef + "@sha256:"))
+
+			_, err := imgClient.Config(ctx, creds, tarballRef+":jim")
+			Expect(err).NotTo(HaveOccurred())
+
+			zipReader, err := imgClient.Download(ctx, creds, pushedRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zipReader.Close()).To(Succeed())
+		})
+
+		When("the image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/not-there")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+
+		When("the tarball is not valid", func() {
+			JustBeforeEach(func() {
+				pushedRef, testErr = imgClient.PushTarball(ctx, creds, tarballRef, strings.NewReader("not a tarball"))
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to read image tarball")))
+			})
+		})
+	})
+
 	Describe("Config", func() {
 		var config image.Config
 